@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// adoptionIssueTitle is the title of the issues 'ghprs konflux adopt' opens, also used to find one it already opened
+const adoptionIssueTitle = "Konflux onboarding: missing configuration"
+
+// defaultAdoptionTemplate are the files a Konflux component is expected to have when no template is configured:
+// the pipelines Konflux creates when onboarding a component
+var defaultAdoptionTemplate = []string{".tekton/{component}-pull-request.yaml", ".tekton/{component}-push.yaml"}
+
+var adoptOpenIssue bool
+
+// AdoptionTemplate returns the files every Konflux repository is expected to have
+func (c *Config) AdoptionTemplate() []string {
+	if len(c.Konflux.Template) == 0 {
+		return defaultAdoptionTemplate
+	}
+	return c.Konflux.Template
+}
+
+// AdoptionTemplateFor returns the files a Konflux repository is expected to have: its own template, else the
+// template of every repository
+func (c *Config) AdoptionTemplateFor(repo string) []string {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo && len(existingRepo.Template) > 0 {
+			return existingRepo.Template
+		}
+	}
+	return c.AdoptionTemplate()
+}
+
+// repoTree is a repository's file tree as returned by the git trees API
+type repoTree struct {
+	Tree []struct {
+		Path string `json:"path"`
+		Type string `json:"type"`
+	} `json:"tree"`
+	Truncated bool `json:"truncated"`
+}
+
+// Issue is the part of a GitHub issue 'ghprs konflux adopt' looks at
+type Issue struct {
+	Number  int    `json:"number"`
+	Title   string `json:"title"`
+	HTMLURL string `json:"html_url"`
+}
+
+// IssueRequest is the body of a request to open an issue
+type IssueRequest struct {
+	Title string `json:"title"`
+	Body  string `json:"body"`
+}
+
+// fetchRepositoryFiles lists every file on a repository's default branch, warning when the tree was too large
+// to be listed completely
+func fetchRepositoryFiles(client RESTClientInterface, owner, repo string) ([]string, error) {
+	var tree repoTree
+	if err := client.Get(fmt.Sprintf("repos/%s/%s/git/trees/HEAD?recursive=1", owner, repo), &tree); err != nil {
+		return nil, err
+	}
+	if tree.Truncated {
+		logger.Warn("Repository tree is too large to list completely, files may be reported missing", "repo", owner+"/"+repo)
+	}
+
+	var files []string
+	for _, entry := range tree.Tree {
+		if entry.Type == "blob" {
+			files = append(files, entry.Path)
+		}
+	}
+	return files, nil
+}
+
+// adoptionGaps returns the entries of template no file matches, with {component} replaced by component. Entries
+// are file paths or path.Match patterns, e.g. .tekton/*-push.yaml.
+func adoptionGaps(template []string, component string, files []string) []string {
+	var gaps []string
+	for _, entry := range template {
+		pattern := strings.ReplaceAll(entry, "{component}", component)
+		found := false
+		for _, file := range files {
+			if matched, _ := path.Match(pattern, file); matched {
+				found = true
+				break
+			}
+		}
+		if !found {
+			gaps = append(gaps, pattern)
+		}
+	}
+	return gaps
+}
+
+// adoptionIssueBody lists the gaps of a repository in the issue opened for them
+func adoptionIssueBody(component string, gaps []string) string {
+	var body strings.Builder
+	fmt.Fprintf(&body, "The Konflux component `%s` is missing configuration expected by the organization template:\n\n", component)
+	for _, gap := range gaps {
+		fmt.Fprintf(&body, "- [ ] `%s`\n", gap)
+	}
+	body.WriteString("\n_Opened by `ghprs konflux adopt`._\n")
+	return body.String()
+}
+
+// openAdoptionIssue opens an issue listing the gaps of a repository, unless one is already open, and returns it
+func openAdoptionIssue(ctx context.Context, client RESTClientInterface, owner, repo, component string, gaps []string) (*Issue, bool, error) {
+	issues, err := fetchPages[Issue](ctx, client, fmt.Sprintf("repos/%s/%s/issues?state=open", owner, repo))
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to list open issues: %v", err)
+	}
+	for _, issue := range issues {
+		if issue.Title == adoptionIssueTitle {
+			return &issue, false, nil
+		}
+	}
+
+	requestJSON, err := json.Marshal(IssueRequest{Title: adoptionIssueTitle, Body: adoptionIssueBody(component, gaps)})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to marshal issue: %v", err)
+	}
+	var issue Issue
+	if err := client.Post(fmt.Sprintf("repos/%s/%s/issues", owner, repo), bytes.NewReader(requestJSON), &issue); err != nil {
+		return nil, false, fmt.Errorf("failed to open issue: %v", err)
+	}
+	auditLog.record(client, owner, repo, issue.Number, AuditOpenIssue, adoptionIssueTitle)
+	return &issue, true, nil
+}
+
+// adoptRepository reports the gaps of a repository against the template and, with openIssue, opens an issue for
+// them once confirmed. It returns whether the repository has gaps.
+func adoptRepository(ctx context.Context, client RESTClientInterface, config *Config, owner, repo string, openIssue, assumeYes bool) (bool, error) {
+	repoSpec := owner + "/" + repo
+	component := repo
+	if mapping, ok := konfluxComponentFor(owner, repo, ""); ok {
+		component = mapping.Component
+	}
+
+	files, err := fetchRepositoryFiles(client, owner, repo)
+	if err != nil {
+		return false, fmt.Errorf("failed to list files: %v", err)
+	}
+	gaps := adoptionGaps(config.AdoptionTemplateFor(repoSpec), component, files)
+	configured := slices.Contains(config.GetRepositories(true), repoSpec)
+
+	if len(gaps) == 0 {
+		streams.Printf("✅ %s (component %s) has every file of the template\n", repoSpec, component)
+	} else {
+		streams.Printf("❌ %s (component %s) is missing %d file(s) of the template:\n", repoSpec, component, len(gaps))
+		for _, gap := range gaps {
+			streams.Printf("   %s\n", gap)
+		}
+	}
+	if !configured {
+		streams.Printf("   ℹ️  Not a configured Konflux repository, add it with 'ghprs config add-konflux-repo %s'\n", repoSpec)
+	}
+	if len(gaps) == 0 || !openIssue {
+		return len(gaps) > 0, nil
+	}
+
+	if !assumeYes {
+		confirmed, err := prompter.Confirm(fmt.Sprintf("Open an issue in %s listing the missing files?", repoSpec))
+		if err != nil || !confirmed {
+			streams.Println("   Skipped opening an issue.")
+			return true, nil
+		}
+	}
+	issue, opened, err := openAdoptionIssue(ctx, client, owner, repo, component, gaps)
+	if err != nil {
+		return true, err
+	}
+	if opened {
+		streams.Printf("   📝 Opened issue #%d %s\n", issue.Number, issue.HTMLURL)
+	} else {
+		streams.Printf("   📝 Issue #%d is already open %s\n", issue.Number, issue.HTMLURL)
+	}
+	return true, nil
+}
+
+// konfluxAdoptCmd represents the konflux adopt command
+var konfluxAdoptCmd = &cobra.Command{
+	Use:   "adopt [owner/repo...]",
+	Short: "Report Konflux configuration missing from repositories being onboarded",
+	Long: `Check the configured Konflux repositories, or the given ones, for the files the organization
+template expects on their default branch and report the gaps, e.g. for platform teams onboarding
+many components. It exits with status 1 when a repository has gaps.
+
+The template is a list of file paths or patterns, where {component} is the repository's Konflux
+component (see 'ghprs config set-konflux-component', the repository name otherwise). Unset, it
+expects the pull request and push pipelines Konflux creates. For example:
+  konflux:
+    template:
+      - .tekton/{component}-pull-request.yaml
+      - .tekton/{component}-push.yaml
+      - renovate.json
+
+With --open-issue an issue listing the missing files is opened in each repository with gaps, once
+confirmed, unless one is already open.
+
+Examples:
+  ghprs konflux adopt
+  ghprs konflux adopt my-org/operator my-org/console
+  ghprs konflux adopt my-org/operator --open-issue`,
+	ValidArgsFunction: completeRepositoryArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositorySettings(config)
+		setRepositoryHosts(config)
+		setKonfluxComponents(config)
+
+		repositories := args
+		if len(repositories) == 0 {
+			repositories = config.GetRepositories(true)
+		}
+		if len(repositories) == 0 {
+			log.Fatal("No repositories specified and no Konflux repositories configured. Specify owner/repo or configure Konflux repositories with 'ghprs config add-konflux-repo owner/repo'.")
+		}
+
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		incomplete := false
+		for _, repoSpec := range repositories {
+			owner, repo, ok := parseRepoSpec(repoSpec)
+			if !ok {
+				logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+				continue
+			}
+			client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+			if err != nil {
+				logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+				incomplete = true
+				continue
+			}
+			gaps, err := adoptRepository(ctx, withContext(client, ctx), config, owner, repo, adoptOpenIssue, assumeYes)
+			if err != nil {
+				logger.Error("Failed to check repository", "repo", repoSpec, "error", err)
+			}
+			incomplete = incomplete || gaps || err != nil
+		}
+		if incomplete {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	konfluxCmd.AddCommand(konfluxAdoptCmd)
+
+	konfluxAdoptCmd.Flags().BoolVar(&adoptOpenIssue, "open-issue", false, "Open an issue listing the missing files in each repository with gaps")
+	konfluxAdoptCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Open the issues without asking for confirmation")
+}
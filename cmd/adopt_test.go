@@ -0,0 +1,107 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Konflux adopt", func() {
+	var (
+		out        *bytes.Buffer
+		mockClient *cmd.MockRESTClient
+		config     *cmd.Config
+	)
+
+	tree := func(paths ...string) map[string]interface{} {
+		var entries []map[string]string
+		for _, path := range paths {
+			entries = append(entries, map[string]string{"path": path, "type": "blob"})
+		}
+		return map[string]interface{}{"tree": entries, "truncated": false}
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, out), nil)
+		mockClient = cmd.NewMockRESTClient()
+		config = &cmd.Config{Repositories: []cmd.RepositoryConfig{{Name: "owner/operator", Konflux: true}}}
+		cmd.SetKonfluxComponentsTest(config)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+		cmd.SetKonfluxComponentsTest(&cmd.Config{})
+	})
+
+	It("should report template entries no file matches", func() {
+		template := []string{".tekton/{component}-pull-request.yaml", ".tekton/{component}-push.yaml", "renovate.json", "docs/*.md"}
+		files := []string{".tekton/operator-push.yaml", "docs/README.md"}
+
+		Expect(cmd.AdoptionGapsTest(template, "operator", files)).To(Equal([]string{".tekton/operator-pull-request.yaml", "renovate.json"}))
+	})
+
+	It("should check the default template against the repository's component", func() {
+		config.Konflux.Components = []cmd.KonfluxComponent{{Repository: "owner/operator", Application: "app", Component: "operator-bundle"}}
+		cmd.SetKonfluxComponentsTest(config)
+		mockClient.AddResponse("repos/owner/operator/git/trees/HEAD", 200, tree(".tekton/operator-bundle-pull-request.yaml", ".tekton/operator-bundle-push.yaml"))
+
+		gaps, err := cmd.AdoptRepositoryTest(mockClient, config, "owner", "operator", false, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gaps).To(BeFalse())
+		Expect(out.String()).To(ContainSubstring("✅ owner/operator (component operator-bundle) has every file of the template"))
+	})
+
+	It("should report the gaps of a repository that isn't configured", func() {
+		mockClient.AddResponse("repos/owner/console/git/trees/HEAD", 200, tree("README.md"))
+
+		gaps, err := cmd.AdoptRepositoryTest(mockClient, config, "owner", "console", false, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gaps).To(BeTrue())
+		Expect(out.String()).To(ContainSubstring("missing 2 file(s)"))
+		Expect(out.String()).To(ContainSubstring(".tekton/console-push.yaml"))
+		Expect(out.String()).To(ContainSubstring("ghprs config add-konflux-repo owner/console"))
+	})
+
+	Describe("opening an issue", func() {
+		BeforeEach(func() {
+			mockClient.AddResponse("repos/owner/operator/git/trees/HEAD", 200, tree(".tekton/operator-push.yaml"))
+		})
+
+		It("should open an issue listing the missing files", func() {
+			mockClient.AddResponse("repos/owner/operator/issues?state=open", 200, []cmd.Issue{{Number: 3, Title: "Unrelated"}})
+			mockClient.AddResponse("repos/owner/operator/issues", 201, cmd.Issue{Number: 4, HTMLURL: "https://github.com/owner/operator/issues/4"})
+
+			_, err := cmd.AdoptRepositoryTest(mockClient, config, "owner", "operator", true, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring("Opened issue #4"))
+
+			var posted *cmd.MockRequest
+			for i := range mockClient.Requests {
+				if mockClient.Requests[i].Method == "POST" {
+					posted = &mockClient.Requests[i]
+				}
+			}
+			Expect(posted).NotTo(BeNil())
+			var issue cmd.IssueRequest
+			Expect(json.Unmarshal([]byte(posted.Body), &issue)).To(Succeed())
+			Expect(issue.Title).To(Equal("Konflux onboarding: missing configuration"))
+			Expect(issue.Body).To(ContainSubstring("- [ ] `.tekton/operator-pull-request.yaml`"))
+		})
+
+		It("should not open another issue while one is open", func() {
+			mockClient.AddResponse("repos/owner/operator/issues?state=open", 200, []cmd.Issue{{Number: 3, Title: "Konflux onboarding: missing configuration"}})
+
+			_, err := cmd.AdoptRepositoryTest(mockClient, config, "owner", "operator", true, true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(out.String()).To(ContainSubstring("Issue #3 is already open"))
+			for _, request := range mockClient.Requests {
+				Expect(request.Method).NotTo(Equal("POST"))
+			}
+		})
+	})
+})
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+)
+
+// defaultStalePRAfter is how long a PR may go without an update before it is marked stale
+const defaultStalePRAfter = 14 * 24 * time.Hour
+
+// stalePRAfter is how long a PR may go without an update before the AGE column marks it stale, 0 never does
+var stalePRAfter = defaultStalePRAfter
+
+// parseAgeFilters sets where the --older-than and --updated-within windows of opts start
+func (opts *listOptions) parseAgeFilters(now time.Time) error {
+	opts.createdBefore, opts.updatedAfter = time.Time{}, time.Time{}
+	if opts.OlderThan != "" {
+		age, err := parseHoldDuration(opts.OlderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than %q (use e.g. 14d, 2w or 36h)", opts.OlderThan)
+		}
+		opts.createdBefore = now.Add(-age)
+	}
+	if opts.UpdatedWithin != "" {
+		window, err := parseHoldDuration(opts.UpdatedWithin)
+		if err != nil {
+			return fmt.Errorf("invalid --updated-within %q (use e.g. 2d, 1w or 8h)", opts.UpdatedWithin)
+		}
+		opts.updatedAfter = now.Add(-window)
+	}
+	return nil
+}
+
+// matchesAgeFilters reports whether a PR was opened before --older-than and updated within --updated-within.
+// A PR whose time can't be read doesn't match a filter on it.
+func (opts *listOptions) matchesAgeFilters(pr PullRequest) bool {
+	if !opts.createdBefore.IsZero() {
+		createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+		if err != nil || !createdAt.Before(opts.createdBefore) {
+			return false
+		}
+	}
+	if !opts.updatedAfter.IsZero() {
+		updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt)
+		if err != nil || updatedAt.Before(opts.updatedAfter) {
+			return false
+		}
+	}
+	return true
+}
+
+// isStalePR reports whether an open PR went without an update for longer than the stale window
+func isStalePR(pr PullRequest, now time.Time) bool {
+	if stalePRAfter <= 0 || pr.State == "closed" {
+		return false
+	}
+	updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt)
+	return err == nil && now.Sub(updatedAt) > stalePRAfter
+}
+
+// humanAge renders how old something is in its largest whole unit, e.g. "12d", "5h" or "40m"
+func humanAge(age time.Duration) string {
+	switch {
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", max(int(age.Minutes()), 0))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(age.Hours()))
+	default:
+		return fmt.Sprintf("%dd", int(age.Hours())/24)
+	}
+}
+
+// ageColumn renders the AGE column of a row: how long ago the PR was opened, marked 🐌 when it is stale, and
+// empty when the opening time is unknown
+func ageColumn(row PRRow, now time.Time) string {
+	createdAt, err := time.Parse(time.RFC3339, row.CreatedAt)
+	if err != nil {
+		return ""
+	}
+	age := humanAge(now.Sub(createdAt))
+	if row.Stale {
+		age += " 🐌"
+	}
+	return age
+}
+
+// validateStalePRAfter checks the display.stale_after setting: a duration such as 14d or 36h, or 0 to never
+// mark PRs stale
+func validateStalePRAfter(value string) error {
+	if value == "0" {
+		return nil
+	}
+	if _, err := parseHoldDuration(value); err != nil {
+		return fmt.Errorf("invalid display.stale_after %q, must be a duration such as 14d or 36h, or 0", value)
+	}
+	return nil
+}
+
+// StalePRAfter returns how long a PR may go without an update before it is marked stale, falling back to the
+// default for unset or invalid values
+func (c *Config) StalePRAfter() time.Duration {
+	if c.Display.StaleAfter == "" || validateStalePRAfter(c.Display.StaleAfter) != nil {
+		return defaultStalePRAfter
+	}
+	if c.Display.StaleAfter == "0" {
+		return 0
+	}
+	after, _ := parseHoldDuration(c.Display.StaleAfter)
+	return after
+}
@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("PR age", func() {
+	now := time.Now()
+	at := func(ago time.Duration) string { return now.Add(-ago).UTC().Format(time.RFC3339) }
+	day := 24 * time.Hour
+
+	AfterEach(func() {
+		Expect(cmd.SetAgeFiltersTest("", "", now)).To(Succeed())
+		cmd.SetStalePRAfterTest(14 * day)
+	})
+
+	It("renders ages in their largest whole unit", func() {
+		Expect(cmd.HumanAgeTest(12*day + 5*time.Hour)).To(Equal("12d"))
+		Expect(cmd.HumanAgeTest(5*time.Hour + 59*time.Minute)).To(Equal("5h"))
+		Expect(cmd.HumanAgeTest(40 * time.Minute)).To(Equal("40m"))
+		Expect(cmd.HumanAgeTest(-time.Minute)).To(Equal("0m"))
+	})
+
+	It("filters PRs by when they were opened and last updated", func() {
+		prs := []cmd.PullRequest{
+			{Number: 1, State: "open", CreatedAt: at(30 * day), UpdatedAt: at(20 * day)},
+			{Number: 2, State: "open", CreatedAt: at(30 * day), UpdatedAt: at(time.Hour)},
+			{Number: 3, State: "open", CreatedAt: at(2 * day), UpdatedAt: at(time.Hour)},
+			{Number: 4, State: "open"},
+		}
+		client := cmd.NewMockRESTClient()
+
+		Expect(cmd.SetAgeFiltersTest("14d", "", now)).To(Succeed())
+		Expect(prNumbers(cmd.FilterPRsTest(prs, client, "owner", "repo", false))).To(Equal([]int{1, 2}))
+
+		Expect(cmd.SetAgeFiltersTest("", "2d", now)).To(Succeed())
+		Expect(prNumbers(cmd.FilterPRsTest(prs, client, "owner", "repo", false))).To(Equal([]int{2, 3}))
+
+		Expect(cmd.SetAgeFiltersTest("2w", "1d", now)).To(Succeed())
+		Expect(prNumbers(cmd.FilterPRsTest(prs, client, "owner", "repo", false))).To(Equal([]int{2}))
+
+		Expect(cmd.SetAgeFiltersTest("", "", now)).To(Succeed())
+		Expect(prNumbers(cmd.FilterPRsTest(prs, client, "owner", "repo", false))).To(Equal([]int{1, 2, 3, 4}))
+	})
+
+	It("rejects invalid windows", func() {
+		Expect(cmd.SetAgeFiltersTest("soon", "", now)).To(MatchError(ContainSubstring(`invalid --older-than "soon"`)))
+		Expect(cmd.SetAgeFiltersTest("", "-2d", now)).To(MatchError(ContainSubstring(`invalid --updated-within "-2d"`)))
+	})
+
+	It("marks open PRs not updated within the stale window", func() {
+		prs := []cmd.PullRequest{
+			{Number: 1, State: "open", CreatedAt: at(40 * day), UpdatedAt: at(20 * day)},
+			{Number: 2, State: "open", CreatedAt: at(40 * day), UpdatedAt: at(day)},
+			{Number: 3, State: "closed", CreatedAt: at(40 * day), UpdatedAt: at(20 * day)},
+		}
+
+		rows := cmd.BuildPRRowsTest(prs, "owner", "repo", cmd.NewMockRESTClient(), false)
+		Expect(rows[0].Stale).To(BeTrue())
+		Expect(rows[0].CreatedAt).To(Equal(prs[0].CreatedAt))
+		Expect(rows[1].Stale).To(BeFalse())
+		Expect(rows[2].Stale).To(BeFalse())
+
+		cmd.SetStalePRAfterTest(0)
+		rows = cmd.BuildPRRowsTest(prs, "owner", "repo", cmd.NewMockRESTClient(), false)
+		Expect(rows[0].Stale).To(BeFalse())
+	})
+
+	It("reads the stale window from the config", func() {
+		config := cmd.DefaultConfig()
+		Expect(config.StalePRAfter()).To(Equal(14 * day))
+		config.Display.StaleAfter = "3d"
+		Expect(config.StalePRAfter()).To(Equal(3 * day))
+		config.Display.StaleAfter = "0"
+		Expect(config.StalePRAfter()).To(BeZero())
+		config.Display.StaleAfter = "whenever"
+		Expect(config.StalePRAfter()).To(Equal(14 * day))
+
+		Expect(cmd.ValidateConfigDataTest("display:\n  stale_after: whenever\n")).To(ConsistOf(ContainSubstring(`invalid display.stale_after "whenever"`)))
+		Expect(cmd.ValidateConfigDataTest("display:\n  stale_after: 10d\n")).To(BeEmpty())
+	})
+})
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"ghprs/internal/render"
+)
+
+// ArtifactSchemaVersion is bumped whenever the shape of the run artifact changes
+const ArtifactSchemaVersion = "1"
+
+// runArtifact records what the current run shows, nil when --artifact isn't given
+var runArtifact *artifactRecorder
+
+// RunArtifact is what a run of list or konflux showed and what was decided, saved with --artifact so an
+// approval can be justified later
+type RunArtifact struct {
+	SchemaVersion string             `json:"schemaVersion"`
+	Command       string             `json:"command"`
+	StartedAt     time.Time          `json:"startedAt"`
+	FinishedAt    time.Time          `json:"finishedAt"`
+	Decisions     []ArtifactDecision `json:"decisions"`
+	// Transcript is everything written to the terminal, with the answers typed, without escape sequences
+	Transcript string `json:"transcript"`
+}
+
+// ArtifactDecision is what was done to a PR, interactively or by a rule
+type ArtifactDecision struct {
+	Time       time.Time `json:"time"`
+	Repository string    `json:"repository"`
+	Number     int       `json:"number"`
+	Title      string    `json:"title"`
+	URL        string    `json:"url"`
+	// HeadSHA is the commit that was shown when deciding
+	HeadSHA string `json:"headSha"`
+	Action  string `json:"action"`
+	// Reason is why a rule decided, empty for interactive decisions
+	Reason string `json:"reason,omitempty"`
+}
+
+// approvalActions names the approval results recorded as decisions
+var approvalActions = map[ApprovalResult]string{
+	ApprovalResultApprove:        "approve",
+	ApprovalResultSkip:           "skip",
+	ApprovalResultHold:           "hold",
+	ApprovalResultUnhold:         "unhold",
+	ApprovalResultComment:        "comment",
+	ApprovalResultRebase:         "rebase",
+	ApprovalResultClose:          "close",
+	ApprovalResultRequestChanges: "request-changes",
+	ApprovalResultCommentReview:  "comment-review",
+}
+
+// artifactRecorder collects the output and decisions of a run until it is saved
+type artifactRecorder struct {
+	dir       string
+	command   string
+	startedAt time.Time
+	mutex     sync.Mutex
+	output    bytes.Buffer
+	decisions []ArtifactDecision
+}
+
+// newArtifactRecorder starts recording a run of command, creating dir so a bad --artifact fails up front
+func newArtifactRecorder(dir, command string, now time.Time) (*artifactRecorder, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, fmt.Errorf("failed to create artifact directory: %w", err)
+	}
+	return &artifactRecorder{dir: dir, command: command, startedAt: now}, nil
+}
+
+// Write records output shown by the run
+func (a *artifactRecorder) Write(p []byte) (int, error) {
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	return a.output.Write(p)
+}
+
+// decide records what was done to a PR. A nil recorder records nothing.
+func (a *artifactRecorder) decide(owner, repo string, pr PullRequest, action, reason string, now time.Time) {
+	if a == nil {
+		return
+	}
+	url := pr.HTMLURL
+	if url == "" {
+		url = prURL(owner, repo, pr.Number)
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	a.decisions = append(a.decisions, ArtifactDecision{
+		Time:       now,
+		Repository: owner + "/" + repo,
+		Number:     pr.Number,
+		Title:      pr.Title,
+		URL:        url,
+		HeadSHA:    pr.Head.SHA,
+		Action:     action,
+		Reason:     reason,
+	})
+}
+
+// decideApproval records the result of the approval prompt for a PR, except quitting
+func (a *artifactRecorder) decideApproval(owner, repo string, pr PullRequest, result ApprovalResult) {
+	if action, ok := approvalActions[result]; ok {
+		a.decide(owner, repo, pr, action, "", time.Now())
+	}
+}
+
+// save writes the run as JSON and HTML files named after when it started, returning the JSON file
+func (a *artifactRecorder) save(now time.Time) (string, error) {
+	a.mutex.Lock()
+	artifact := RunArtifact{
+		SchemaVersion: ArtifactSchemaVersion,
+		Command:       a.command,
+		StartedAt:     a.startedAt,
+		FinishedAt:    now,
+		Decisions:     append([]ArtifactDecision{}, a.decisions...),
+		Transcript:    render.StripANSISequences(a.output.String()),
+	}
+	a.mutex.Unlock()
+
+	base := filepath.Join(a.dir, "ghprs-"+a.startedAt.Format("20060102-150405"))
+	data, err := json.MarshalIndent(artifact, "", "  ")
+	if err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(base+".json", data, 0644); err != nil {
+		return "", err
+	}
+
+	var page bytes.Buffer
+	if err := artifactHTML.Execute(&page, artifact); err != nil {
+		return "", err
+	}
+	if err := os.WriteFile(base+".html", page.Bytes(), 0644); err != nil {
+		return "", err
+	}
+	return base + ".json", nil
+}
+
+// startArtifact starts recording what the run shows in dir, the directory of --artifact, returning the function
+// that saves it. Without a directory nothing is recorded.
+func startArtifact(dir string, args []string) func() {
+	if dir == "" {
+		return func() {}
+	}
+	recorder, err := newArtifactRecorder(dir, strings.Join(append([]string{"ghprs"}, args...), " "), time.Now())
+	if err != nil {
+		logger.Error("Not saving an artifact of this run", "error", err)
+		return func() {}
+	}
+	runArtifact = recorder
+	stopTee := streams.Tee(recorder)
+	return func() {
+		stopTee()
+		runArtifact = nil
+		path, err := recorder.save(time.Now())
+		if err != nil {
+			logger.Error("Failed to save the artifact of this run", "dir", dir, "error", err)
+			return
+		}
+		streams.Printf("\n🗂️  Saved what was shown to %s (and .html)\n", path)
+	}
+}
+
+// artifactHTML renders a run artifact as a standalone page
+var artifactHTML = template.Must(template.New("artifact").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<title>{{.Command}} ({{.StartedAt.Format "2006-01-02 15:04:05"}})</title>
+<style>
+body { font-family: sans-serif; margin: 2em; }
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; text-align: left; }
+pre { background: #f6f8fa; padding: 1em; overflow-x: auto; }
+</style>
+</head>
+<body>
+<h1>{{.Command}}</h1>
+<p>Started {{.StartedAt.Format "2006-01-02 15:04:05 MST"}}, finished {{.FinishedAt.Format "2006-01-02 15:04:05 MST"}}</p>
+<h2>Decisions</h2>
+{{if .Decisions}}<table>
+<tr><th>Time</th><th>PR</th><th>Title</th><th>Commit</th><th>Action</th><th>Reason</th></tr>
+{{range .Decisions}}<tr><td>{{.Time.Format "15:04:05"}}</td><td><a href="{{.URL}}">{{.Repository}}#{{.Number}}</a></td><td>{{.Title}}</td><td><code>{{.HeadSHA}}</code></td><td>{{.Action}}</td><td>{{.Reason}}</td></tr>
+{{end}}</table>{{else}}<p>No decisions were made.</p>{{end}}
+<h2>Shown</h2>
+<pre>{{.Transcript}}</pre>
+</body>
+</html>
+`))
@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Run artifact", func() {
+	var (
+		dir        string
+		out        *bytes.Buffer
+		mockClient *cmd.MockRESTClient
+	)
+
+	BeforeEach(func() {
+		dir = filepath.Join(GinkgoT().TempDir(), "audit")
+		out = &bytes.Buffer{}
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	readArtifact := func() (cmd.RunArtifact, string) {
+		files, err := filepath.Glob(filepath.Join(dir, "ghprs-*.json"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(HaveLen(1))
+		data, err := os.ReadFile(files[0])
+		Expect(err).NotTo(HaveOccurred())
+		var artifact cmd.RunArtifact
+		Expect(json.Unmarshal(data, &artifact)).To(Succeed())
+
+		page, err := os.ReadFile(strings.TrimSuffix(files[0], ".json") + ".html")
+		Expect(err).NotTo(HaveOccurred())
+		return artifact, string(page)
+	}
+
+	It("should save the prompts, the answers typed and the decisions of an approval run", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nn\n"), out, out), nil)
+		pr := cmd.PullRequest{Number: 1, Title: "Update <deps>", State: "open", Head: cmd.Branch{SHA: "abc123"}}
+
+		cmd.RecordArtifactTest(dir, []string{"list", "--approve"}, func() {
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, false)
+		})
+
+		artifact, page := readArtifact()
+		Expect(artifact.Command).To(Equal("ghprs list --approve"))
+		Expect(artifact.Transcript).To(ContainSubstring("🔍 Review PR #1:"))
+		Expect(artifact.Transcript).To(ContainSubstring("Approve this PR?"))
+		Expect(artifact.Transcript).To(ContainSubstring("): n\nSkipping PR #1"))
+		Expect(artifact.Decisions).To(HaveLen(1))
+		decision := artifact.Decisions[0]
+		Expect(decision.Repository).To(Equal("owner/repo"))
+		Expect(decision.Number).To(Equal(1))
+		Expect(decision.HeadSHA).To(Equal("abc123"))
+		Expect(decision.Action).To(Equal("skip"))
+		Expect(page).To(ContainSubstring("Update &lt;deps&gt;"))
+
+		// The terminal still shows everything
+		Expect(out.String()).To(ContainSubstring("🔍 Review PR #1:"))
+		Expect(out.String()).To(ContainSubstring("Saved what was shown to " + dir))
+	})
+
+	It("should save nothing without --artifact", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+		cmd.RecordArtifactTest("", nil, func() { out.WriteString("table\n") })
+		_, err := os.Stat(dir)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+})
@@ -0,0 +1,230 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	assignReviewers []string
+	assignAssignees []string
+)
+
+// ReviewersRequest is the body of a request to ask users and teams for a review
+type ReviewersRequest struct {
+	Reviewers     []string `json:"reviewers,omitempty"`
+	TeamReviewers []string `json:"team_reviewers,omitempty"`
+}
+
+// AssigneesRequest is the body of a request to assign users to a PR
+type AssigneesRequest struct {
+	Assignees []string `json:"assignees"`
+}
+
+// splitReviewers separates teams, given as org/team, from users, keeping only the team slug GitHub expects
+func splitReviewers(reviewers []string) ([]string, []string) {
+	var users, teams []string
+	for _, reviewer := range reviewers {
+		if _, team, ok := strings.Cut(reviewer, "/"); ok {
+			teams = append(teams, team)
+		} else {
+			users = append(users, reviewer)
+		}
+	}
+	return users, teams
+}
+
+// resolveLogins replaces @me in logins with the authenticated user's login and drops empty ones
+func resolveLogins(client RESTClientInterface, owner, repo string, logins []string) ([]string, error) {
+	var resolved []string
+	for _, login := range logins {
+		login = strings.TrimPrefix(strings.TrimSpace(login), "@")
+		if login == "" {
+			continue
+		}
+		if isMe("@" + login) {
+			me, err := viewerLogin(client, owner, repo)
+			if err != nil {
+				return nil, err
+			}
+			login = me
+		}
+		resolved = append(resolved, login)
+	}
+	return resolved, nil
+}
+
+// requestReviewers asks users, and teams given as org/team, to review a PR
+func requestReviewers(client RESTClientInterface, owner, repo string, prNumber int, reviewers []string) error {
+	users, teams := splitReviewers(reviewers)
+	requestJSON, err := json.Marshal(ReviewersRequest{Reviewers: users, TeamReviewers: teams})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reviewers request: %v", err)
+	}
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/requested_reviewers", owner, repo, prNumber)
+	if err := client.Post(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return fmt.Errorf("failed to request reviews: %v", err)
+	}
+	auditLog.record(client, owner, repo, prNumber, AuditRequestReview, strings.Join(reviewers, ","))
+	return nil
+}
+
+// addAssignees assigns users to a PR. GitHub silently leaves out users who can't be assigned, so they are
+// returned to be reported.
+func addAssignees(client RESTClientInterface, owner, repo string, prNumber int, assignees []string) ([]string, error) {
+	requestJSON, err := json.Marshal(AssigneesRequest{Assignees: assignees})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal assignees request: %v", err)
+	}
+	var issue struct {
+		Assignees []User `json:"assignees"`
+	}
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/assignees", owner, repo, prNumber)
+	if err := client.Post(path, bytes.NewReader(requestJSON), &issue); err != nil {
+		return nil, fmt.Errorf("failed to add assignees: %v", err)
+	}
+	var assigned, ignored []string
+	for _, assignee := range assignees {
+		if !slices.ContainsFunc(issue.Assignees, func(user User) bool { return strings.EqualFold(user.Login, assignee) }) {
+			ignored = append(ignored, assignee)
+			continue
+		}
+		assigned = append(assigned, assignee)
+	}
+	if len(assigned) > 0 {
+		auditLog.record(client, owner, repo, prNumber, AuditAssign, strings.Join(assigned, ","))
+	}
+	return ignored, nil
+}
+
+// assignPR requests reviews from reviewers and assigns assignees, reporting each step. It returns false if
+// either failed.
+func assignPR(client RESTClientInterface, owner, repo string, prNumber int, reviewers, assignees []string) bool {
+	link := formatPRLink(owner, repo, prNumber)
+	ok := true
+	if len(reviewers) > 0 {
+		if err := requestReviewers(client, owner, repo, prNumber, reviewers); err != nil {
+			streams.Printf("❌ Failed to request reviews on PR %s: %v\n", link, err)
+			ok = false
+		} else {
+			streams.Printf("👀 Requested reviews from %s on PR %s\n", strings.Join(reviewers, ", "), link)
+		}
+	}
+	if len(assignees) > 0 {
+		ignored, err := addAssignees(client, owner, repo, prNumber, assignees)
+		switch {
+		case err != nil:
+			streams.Printf("❌ Failed to assign PR %s: %v\n", link, err)
+			ok = false
+		case len(ignored) == len(assignees):
+			streams.Printf("❌ GitHub assigned none of %s to PR %s, they may lack access to the repository\n", strings.Join(ignored, ", "), link)
+			ok = false
+		default:
+			streams.Printf("👤 Assigned PR %s to %s\n", link, strings.Join(assignees, ", "))
+			if len(ignored) > 0 {
+				streams.Printf("   ⚠️  GitHub did not assign %s, they may lack access to the repository\n", strings.Join(ignored, ", "))
+				ok = false
+			}
+		}
+	}
+	return ok
+}
+
+// promptForAssign asks whom to request a review from and whom to assign the PR to at the approval prompt,
+// offering the code owners of what the PR changes as reviewers. It only returns the errors of reading the answers.
+func promptForAssign(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest) error {
+	owners, err := codeOwnersOf(ctx, client, owner, repo, pr)
+	if err != nil {
+		streams.Printf("   ⚠️  Could not look up the code owners: %v\n", err)
+	}
+	ownerReviewers := requestableOwners(owners, pr.User.Login)
+	question := "Request a review from (comma-separated logins or org/team, Enter for none): "
+	if len(ownerReviewers) > 0 {
+		streams.Printf("   👥 Code owners: %s\n", strings.Join(owners, " "))
+		question = "Request a review from (comma-separated logins or org/team, 'o' for the code owners, Enter for none): "
+	}
+	reviewers, err := prompter.Input(question)
+	if err != nil {
+		return err
+	}
+	if strings.EqualFold(strings.TrimSpace(reviewers), "o") && len(ownerReviewers) > 0 {
+		reviewers = strings.Join(ownerReviewers, ",")
+	}
+	assignees, err := prompter.Input("Assign to (comma-separated logins, @me for yourself, Enter for none): ")
+	if err != nil {
+		return err
+	}
+
+	resolvedReviewers, err := resolveLogins(client, owner, repo, strings.Split(reviewers, ","))
+	if err != nil {
+		streams.Printf("❌ %v\n", err)
+		return nil
+	}
+	resolvedAssignees, err := resolveLogins(client, owner, repo, strings.Split(assignees, ","))
+	if err != nil {
+		streams.Printf("❌ %v\n", err)
+		return nil
+	}
+	if len(resolvedReviewers) == 0 && len(resolvedAssignees) == 0 {
+		streams.Printf("Nobody given, nothing changed.\n")
+		return nil
+	}
+	assignPR(client, owner, repo, pr.Number, resolvedReviewers, resolvedAssignees)
+	return nil
+}
+
+// assignCmd requests reviews on a PR and assigns it
+var assignCmd = &cobra.Command{
+	Use:   "assign [owner/repo] <number>",
+	Short: "Request reviews on a pull request and assign it",
+	Long: `Request reviews on a pull request and assign it, e.g. to route a Konflux PR with a migration
+warning to the owner of the component. 'a' at the approval prompt does the same, and offers to
+request the reviews of the code owners of what the PR changes.
+
+Reviewers are logins or teams given as org/team. @me stands for yourself. GitHub silently leaves out
+assignees without access to the repository, which is reported.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs assign 123 --reviewer alice
+  ghprs assign owner/repo 123 --reviewer alice,my-org/platform --assignee bob
+  ghprs assign owner/repo 123 --assignee @me`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+		if len(assignReviewers) == 0 && len(assignAssignees) == 0 {
+			log.Fatal("Give --reviewer or --assignee")
+		}
+
+		client := newCommandClient(ctx, owner, repo)
+		reviewers, err := resolveLogins(client, owner, repo, assignReviewers)
+		if err != nil {
+			log.Fatal(err)
+		}
+		assignees, err := resolveLogins(client, owner, repo, assignAssignees)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !assignPR(client, owner, repo, number, reviewers, assignees) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(assignCmd)
+
+	assignCmd.Flags().StringSliceVar(&assignReviewers, "reviewer", nil, "Request a review from this login or org/team (repeatable or comma separated)")
+	assignCmd.Flags().StringSliceVar(&assignAssignees, "assignee", nil, "Assign the PR to this login, @me for yourself (repeatable or comma separated)")
+}
@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Assigning PRs", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	postsTo := func(path string) []string {
+		var bodies []string
+		for _, req := range mockClient.Requests {
+			if req.Method == "POST" && req.URL == path {
+				bodies = append(bodies, req.Body)
+			}
+		}
+		return bodies
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should request reviews from users and teams and assign the PR", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/requested_reviewers", 201, cmd.PullRequest{Number: 1})
+		mockClient.AddResponse("repos/owner/repo/issues/1/assignees", 201, map[string]any{"assignees": []cmd.User{{Login: "bob"}}})
+
+		Expect(cmd.AssignPRTest(mockClient, "owner", "repo", 1, []string{"alice", "my-org/platform"}, []string{"bob"})).To(BeTrue())
+
+		Expect(postsTo("repos/owner/repo/pulls/1/requested_reviewers")).To(Equal([]string{`{"reviewers":["alice"],"team_reviewers":["platform"]}`}))
+		Expect(postsTo("repos/owner/repo/issues/1/assignees")).To(Equal([]string{`{"assignees":["bob"]}`}))
+		Expect(out.String()).To(ContainSubstring("👀 Requested reviews from alice, my-org/platform on PR #1"))
+		Expect(out.String()).To(ContainSubstring("👤 Assigned PR #1 to bob"))
+	})
+
+	It("should report assignees GitHub left out", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/1/assignees", 201, map[string]any{"assignees": []cmd.User{{Login: "bob"}}})
+
+		Expect(cmd.AssignPRTest(mockClient, "owner", "repo", 1, nil, []string{"bob", "outsider"})).To(BeFalse())
+
+		Expect(postsTo("repos/owner/repo/pulls/1/requested_reviewers")).To(BeEmpty())
+		Expect(out.String()).To(ContainSubstring("GitHub did not assign outsider"))
+	})
+
+	It("should report a review request GitHub refuses", func() {
+		Expect(cmd.AssignPRTest(mockClient, "owner", "repo", 1, []string{"stranger"}, nil)).To(BeFalse())
+		Expect(out.String()).To(ContainSubstring("❌ Failed to request reviews on PR #1"))
+	})
+
+	It("should resolve @me to the authenticated user", func() {
+		mockClient.AddResponse("user", 200, cmd.User{Login: "me-myself"})
+
+		logins, err := cmd.ResolveLoginsTest(mockClient, "owner", "repo", []string{"@me", " alice ", ""})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(logins).To(Equal([]string{"me-myself", "alice"}))
+	})
+
+	It("should assign from the approval prompt without deciding the PR", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/requested_reviewers", 201, cmd.PullRequest{Number: 1})
+		prs := []cmd.PullRequest{{Number: 1, Title: "Migrate task bundles", State: "open"}}
+
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\na\ncomponent-owner\n\nn\n"), out, &bytes.Buffer{}), nil)
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, true)
+
+		Expect(postsTo("repos/owner/repo/pulls/1/requested_reviewers")).To(Equal([]string{`{"reviewers":["component-owner"]}`}))
+		Expect(postsTo("repos/owner/repo/issues/1/assignees")).To(BeEmpty())
+		Expect(out.String()).To(ContainSubstring("Skipping PR #1"))
+	})
+})
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/cli/go-gh/v2/pkg/auth"
+	ghconfig "github.com/cli/go-gh/v2/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// accountFlag is the account of gh's stored auth to use on every host, for gh logged in to several
+var accountFlag string
+
+// accountToken is the token of an account of gh's stored auth, or why it couldn't be found
+type accountToken struct {
+	token string
+	err   error
+}
+
+var (
+	accountTokensMutex sync.Mutex
+	// accountTokens maps "host/account" to the token found for it, so gh is asked once per run
+	accountTokens = map[string]accountToken{}
+)
+
+// accountFor returns the account of gh's stored auth to use on host and where it was chosen, "" for gh's active
+// account
+func accountFor(host string) (string, string) {
+	if accountFlag != "" {
+		return accountFlag, "--account"
+	}
+	hostsMutex.RLock()
+	defer hostsMutex.RUnlock()
+	if account := configuredAccounts[host]; account != "" {
+		return account, "accounts in the config"
+	}
+	return "", ""
+}
+
+// ghAccountToken finds the token of an account of gh's stored auth on host: in gh's hosts.yml, or else by asking
+// gh, which keeps tokens in the system keyring. Replaced in tests.
+var ghAccountToken = func(host, account string) (string, error) {
+	if cfg, err := ghconfig.Read(nil); err == nil {
+		token, err := cfg.Get([]string{"hosts", auth.NormalizeHostname(host), "users", account, "oauth_token"})
+		if err == nil && token != "" {
+			return token, nil
+		}
+	}
+	ghExe := os.Getenv("GH_PATH")
+	if ghExe == "" {
+		var err error
+		if ghExe, err = exec.LookPath("gh"); err != nil {
+			return "", fmt.Errorf("gh isn't installed to look up account %s", account)
+		}
+	}
+	out, err := exec.Command(ghExe, "auth", "token", "--hostname", host, "--user", account).Output()
+	token := strings.TrimSpace(string(out))
+	if err != nil || token == "" {
+		return "", fmt.Errorf("gh has no token of account %s on %s, log in with 'gh auth login --hostname %s'", account, host, host)
+	}
+	return token, nil
+}
+
+// hostToken returns the token to use on host instead of go-gh's choice and where it came from: the token
+// override, or else the token of the account chosen for host. An empty token leaves the choice to go-gh.
+func hostToken(host string) (string, string, error) {
+	if token, source := tokenOverride(); token != "" {
+		return token, source, nil
+	}
+	account, chosenBy := accountFor(host)
+	if account == "" {
+		return "", "", nil
+	}
+
+	accountTokensMutex.Lock()
+	defer accountTokensMutex.Unlock()
+	key := host + "/" + account
+	found, ok := accountTokens[key]
+	if !ok {
+		found.token, found.err = ghAccountToken(host, account)
+		accountTokens[key] = found
+	}
+	if found.err != nil {
+		return "", "", found.err
+	}
+	return found.token, fmt.Sprintf("account %s (%s)", account, chosenBy), nil
+}
+
+// tokenForHost returns the token used on host and where it came from, falling back to go-gh's choice: GH_TOKEN,
+// GH_ENTERPRISE_TOKEN, gh's hosts.yml or the keyring. An empty token means there is none.
+func tokenForHost(host string) (string, string, error) {
+	token, source, err := hostToken(host)
+	if err != nil || token != "" {
+		return token, source, err
+	}
+	token, source = auth.TokenForHost(host)
+	if token == "" {
+		return "", "", nil
+	}
+	return token, describeTokenSource(source), nil
+}
+
+// describeTokenSource names the sources of go-gh's tokens for people
+func describeTokenSource(source string) string {
+	switch source {
+	case "oauth_token":
+		return "gh's hosts.yml"
+	case "gh":
+		return "gh's stored auth"
+	default:
+		return source
+	}
+}
+
+// authHosts are the hosts 'auth status' reports on: gh's default host, the hosts of the config and those go-gh
+// has a token for
+func authHosts(config *Config) []string {
+	var hosts []string
+	addHost := func(host string) {
+		if host != "" && !slices.Contains(hosts, host) {
+			hosts = append(hosts, host)
+		}
+	}
+	defaultHost, _ := auth.DefaultHost()
+	addHost(defaultHost)
+	addHost(config.Host)
+	for _, repo := range config.Repositories {
+		addHost(repo.Host)
+	}
+	for host := range config.Accounts {
+		addHost(host)
+	}
+	for _, host := range auth.KnownHosts() {
+		addHost(host)
+	}
+	if len(hosts) > 1 {
+		// gh's default host first, then the others in order
+		sort.Strings(hosts[1:])
+	}
+	return hosts
+}
+
+// authWhoami looks up the user a token authenticates as on host, replaced in tests
+var authWhoami = func(host, token string) (string, error) {
+	client, err := api.NewRESTClient(api.ClientOptions{Host: host, AuthToken: token})
+	if err != nil {
+		return "", err
+	}
+	var user User
+	if err := client.Get("user", &user); err != nil {
+		return "", err
+	}
+	return user.Login, nil
+}
+
+// displayAuthStatus shows, for each host, the identity ghprs acts as and where its token comes from. It returns
+// whether every host has a working token.
+func displayAuthStatus(hosts []string) bool {
+	ok := true
+	for _, host := range hosts {
+		streams.Printf("%s\n", host)
+		token, source, err := tokenForHost(host)
+		switch {
+		case err != nil:
+			ok = false
+			streams.Printf("  ❌ %v\n", err)
+			continue
+		case token == "":
+			ok = false
+			streams.Printf("  ❌ Not logged in, run 'gh auth login --hostname %s' or set a token\n", host)
+			continue
+		}
+		login, err := authWhoami(host, token)
+		if err != nil {
+			ok = false
+			streams.Printf("  ❌ The token from %s doesn't work: %v\n", source, err)
+			continue
+		}
+		streams.Printf("  ✅ Acting as @%s\n", login)
+		streams.Printf("  Token: %s\n", source)
+	}
+	return ok
+}
+
+// authStatusCmd shows which identity ghprs uses on each host
+var authStatusCmd = &cobra.Command{
+	Use:   "status",
+	Short: "Show the GitHub identity used on each host",
+	Long: `Show the user ghprs acts as on each GitHub host it works with and where the token comes from.
+
+Tokens are chosen the same way for every request, including fetching diffs:
+  1. --token or GHPRS_TOKEN
+  2. the account of gh's stored auth chosen with --account (GHPRS_ACCOUNT) or, per host, in the
+     accounts section of the config, for gh logged in to several accounts
+  3. GH_TOKEN or GITHUB_TOKEN (GH_ENTERPRISE_TOKEN on Enterprise hosts), then gh's active account
+
+Exits with status 1 when a host has no working token.
+
+Examples:
+  ghprs auth status
+  ghprs auth status --account my-bot
+  ghprs config set account github.example.com=work-user`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+		if !displayAuthStatus(authHosts(config)) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	authCmd := &cobra.Command{
+		Use:   "auth",
+		Short: "Show how ghprs authenticates to GitHub",
+	}
+	authCmd.AddCommand(authStatusCmd)
+	RootCmd.AddCommand(authCmd)
+
+	RootCmd.PersistentFlags().StringVar(&accountFlag, "account", "", "Account of gh's stored auth to use, for gh logged in to several (or set GHPRS_ACCOUNT)")
+}
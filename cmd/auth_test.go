@@ -0,0 +1,124 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Auth", func() {
+	var (
+		out           *bytes.Buffer
+		lookups       []string
+		restoreLookup func()
+		restoreWhoami func()
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		previous, set := os.LookupEnv("GHPRS_TOKEN")
+		Expect(os.Unsetenv("GHPRS_TOKEN")).To(Succeed())
+		DeferCleanup(func() {
+			if set {
+				_ = os.Setenv("GHPRS_TOKEN", previous)
+			}
+		})
+
+		lookups = nil
+		restoreLookup = cmd.SetGhAccountTokenTest(func(host, account string) (string, error) {
+			lookups = append(lookups, host+"/"+account)
+			if account == "missing" {
+				return "", errors.New("gh has no token of account missing on " + host)
+			}
+			return "token-of-" + account, nil
+		})
+		restoreWhoami = cmd.SetAuthWhoamiTest(func(host, token string) (string, error) {
+			if token == "revoked" {
+				return "", errors.New("HTTP 401")
+			}
+			return strings.TrimPrefix(token, "token-of-"), nil
+		})
+	})
+
+	AfterEach(func() {
+		restoreLookup()
+		restoreWhoami()
+		cmd.SetAccountTest("")
+		cmd.SetTokenFlagTest("")
+		cmd.SetRepositoryHostsTest(cmd.DefaultConfig())
+		cmd.ResetIOStreams()
+	})
+
+	Describe("choosing the token", func() {
+		It("should use the token of the account given with --account, looking it up once", func() {
+			cmd.SetAccountTest("work-user")
+
+			token, source, err := cmd.TokenForHostTest("github.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("token-of-work-user"))
+			Expect(source).To(Equal("account work-user (--account)"))
+			Expect(cmd.ClientOptionsTokenTest("github.com")).To(Equal("token-of-work-user"))
+			Expect(lookups).To(Equal([]string{"github.com/work-user"}))
+		})
+
+		It("should use the account configured for a host", func() {
+			config := cmd.DefaultConfig()
+			config.Accounts = map[string]string{"github.example.com": "enterprise-user"}
+			cmd.SetRepositoryHostsTest(config)
+
+			token, source, err := cmd.TokenForHostTest("github.example.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("token-of-enterprise-user"))
+			Expect(source).To(Equal("account enterprise-user (accounts in the config)"))
+		})
+
+		It("should prefer the token override over an account", func() {
+			cmd.SetAccountTest("work-user")
+			cmd.SetTokenFlagTest("flag-token")
+
+			token, source, err := cmd.TokenForHostTest("github.com")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(token).To(Equal("flag-token"))
+			Expect(source).To(Equal("--token"))
+			Expect(lookups).To(BeEmpty())
+		})
+
+		It("should fail rather than act as another user when the account has no token", func() {
+			cmd.SetAccountTest("missing")
+
+			_, _, err := cmd.TokenForHostTest("github.com")
+			Expect(err).To(MatchError(ContainSubstring("no token of account missing")))
+			_, err = cmd.NewAPIClientTest("github.com")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("auth status", func() {
+		It("should show the user acting on each host and where the token comes from", func() {
+			config := cmd.DefaultConfig()
+			config.Accounts = map[string]string{"github.com": "work-user", "github.example.com": "missing"}
+			cmd.SetRepositoryHostsTest(config)
+
+			Expect(cmd.DisplayAuthStatusTest([]string{"github.com", "github.example.com"})).To(BeFalse())
+			Expect(out.String()).To(Equal("github.com\n" +
+				"  ✅ Acting as @work-user\n" +
+				"  Token: account work-user (accounts in the config)\n" +
+				"github.example.com\n" +
+				"  ❌ gh has no token of account missing on github.example.com\n"))
+		})
+
+		It("should report a token that doesn't work", func() {
+			cmd.SetTokenFlagTest("revoked")
+
+			Expect(cmd.DisplayAuthStatusTest([]string{"github.com"})).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("❌ The token from --token doesn't work: HTTP 401"))
+		})
+	})
+})
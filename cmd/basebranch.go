@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// BranchInfo is the part of a branch the base branch check needs
+type BranchInfo struct {
+	Name   string `json:"name"`
+	Commit struct {
+		SHA string `json:"sha"`
+	} `json:"commit"`
+	Protection struct {
+		RequiredStatusChecks struct {
+			Contexts []string `json:"contexts"`
+		} `json:"required_status_checks"`
+	} `json:"protection"`
+}
+
+// baseStatus is whether the tip of a base branch passes its checks
+type baseStatus struct {
+	Branch string
+	SHA    string
+	// Failed are the failed checks of the tip, only the required ones when the branch protection lists them
+	Failed []string
+	// Required is set when the branch protection lists its required checks
+	Required bool
+}
+
+// red reports whether the tip of the branch fails checks that matter
+func (s *baseStatus) red() bool {
+	return s != nil && len(s.Failed) > 0
+}
+
+// describe explains why the base branch is red
+func (s *baseStatus) describe() string {
+	which := "checks"
+	if s.Required {
+		which = "required checks"
+	}
+	return fmt.Sprintf("base branch %s is failing %s on its tip %s: %s", s.Branch, which, shortSHA(s.SHA), strings.Join(s.Failed, ", "))
+}
+
+var (
+	baseStatusesMutex sync.Mutex
+	// baseStatuses maps "owner/repo@branch" to the status of the branch tip, checked once per run
+	baseStatuses = map[string]*baseStatus{}
+)
+
+// resetBaseStatuses forgets the base branches checked and the checks they require, so a new run sees fixes and
+// protection changes made since
+func resetBaseStatuses() {
+	baseStatusesMutex.Lock()
+	baseStatuses = map[string]*baseStatus{}
+	baseStatusesMutex.Unlock()
+
+	requiredContextsMutex.Lock()
+	requiredContexts = map[string][]string{}
+	requiredContextsMutex.Unlock()
+}
+
+// fetchBaseStatus checks the checks of the latest commit of a branch
+func fetchBaseStatus(client RESTClientInterface, owner, repo, branch string) (*baseStatus, error) {
+	var info BranchInfo
+	path := fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch))
+	if err := client.DoWithContext(withFreshData(context.Background()), http.MethodGet, path, nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch %s: %w", branch, err)
+	}
+	if info.Commit.SHA == "" {
+		return nil, fmt.Errorf("branch %s has no commit", branch)
+	}
+
+	checkRuns, statusChecks, err := fetchChecks(client, owner, repo, info.Commit.SHA)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the checks of branch %s: %w", branch, err)
+	}
+
+	required := info.Protection.RequiredStatusChecks.Contexts
+	status := &baseStatus{Branch: branch, SHA: info.Commit.SHA, Required: len(required) > 0}
+	counts := func(name string) bool {
+		return !status.Required || slices.Contains(required, name)
+	}
+	for _, checkRun := range checkRuns {
+		if checkRunFailed(checkRun) && counts(checkRun.Name) {
+			status.Failed = append(status.Failed, checkRun.Name)
+		}
+	}
+	for _, statusCheck := range statusChecks {
+		if statusCheckFailed(statusCheck) && counts(statusCheck.Context) {
+			status.Failed = append(status.Failed, statusCheck.Context)
+		}
+	}
+	return status, nil
+}
+
+// baseStatusOf returns the status of the branch a PR targets, checking each branch once per run. It returns nil
+// when the PR has no base or the branch can't be checked, which isn't worth failing an approval for.
+func baseStatusOf(client RESTClientInterface, owner, repo string, pr PullRequest) *baseStatus {
+	if pr.Base.Ref == "" {
+		return nil
+	}
+	key := fmt.Sprintf("%s/%s@%s", owner, repo, pr.Base.Ref)
+	baseStatusesMutex.Lock()
+	defer baseStatusesMutex.Unlock()
+	if status, ok := baseStatuses[key]; ok {
+		return status
+	}
+	status, err := fetchBaseStatus(client, owner, repo, pr.Base.Ref)
+	if err != nil {
+		logger.Debug("Could not check the base branch", "repo", owner+"/"+repo, "branch", pr.Base.Ref, "error", err)
+	}
+	baseStatuses[key] = status
+	return status
+}
+
+// warnIfBaseRed warns during approval that merging more PRs into a broken base branch is pointless
+func warnIfBaseRed(client RESTClientInterface, owner, repo string, pr PullRequest) {
+	if status := baseStatusOf(client, owner, repo, pr); status.red() {
+		streams.Printf("   🔴 The %s; merging more PRs won't help until it is fixed\n", status.describe())
+	}
+}
+
+// redBase returns the status of the base branch of a PR when config skips PRs whose base is red
+// (--skip-red-base) and the branch is red
+func redBase(config ApprovalConfig, client RESTClientInterface, owner, repo string, pr PullRequest) *baseStatus {
+	if !config.SkipRedBase {
+		return nil
+	}
+	if status := baseStatusOf(client, owner, repo, pr); status.red() {
+		return status
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Base branch status", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	redMain := func(required ...string) {
+		branch := cmd.BranchInfo{Name: "main"}
+		branch.Commit.SHA = "base1234567"
+		branch.Protection.RequiredStatusChecks.Contexts = required
+		mockClient.AddResponse("repos/owner/repo/branches/main", 200, branch)
+		mockClient.AddResponse("repos/owner/repo/commits/base1234567/check-runs", 200, cmd.CreateMockCheckRuns(1, 2, 0))
+		mockClient.AddResponse("repos/owner/repo/commits/base1234567/status", 200, map[string]any{"statuses": []cmd.StatusCheck{}})
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		cmd.ResetBaseStatusesTest()
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+		cmd.ResetBaseStatusesTest()
+	})
+
+	It("should only count the required checks when the branch protection lists them", func() {
+		redMain("test-failed-2", "test-passed-1")
+		failed, err := cmd.BaseBranchFailuresTest(mockClient, "owner", "repo", "main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(Equal([]string{"test-failed-2"}))
+	})
+
+	It("should count every failed check when the required ones aren't known", func() {
+		redMain()
+		failed, err := cmd.BaseBranchFailuresTest(mockClient, "owner", "repo", "main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(Equal([]string{"test-failed-1", "test-failed-2"}))
+	})
+
+	It("should warn at the approval prompt when the base branch is red, checking it once", func() {
+		redMain("test-failed-1")
+		prs := []cmd.PullRequest{
+			{Number: 1, Title: "Update tasks", State: "open", Base: cmd.Branch{Ref: "main"}},
+			{Number: 2, Title: "Update go", State: "open", Base: cmd.Branch{Ref: "main"}},
+		}
+
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nn\n#2\nn\nq\n"), out, &bytes.Buffer{}), nil)
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, true)
+
+		Expect(strings.Count(out.String(), "🔴 The base branch main is failing required checks on its tip base123: test-failed-1")).To(Equal(2))
+		Expect(mockClient.GetRequestCount("repos/owner/repo/branches/main")).To(Equal(1))
+	})
+
+	It("should not approve with --skip-red-base while the base branch is red", func() {
+		redMain("test-failed-1")
+		pr := cmd.PullRequest{Number: 1, Title: "Update tasks", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha1"}, Base: cmd.Branch{Ref: "main"}}
+		rules := []cmd.Rule{{Name: "all", Action: cmd.RuleActionApprove}}
+
+		Expect(cmd.ApplyRulesSkippingRedBaseTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, rules)).To(BeZero())
+
+		Expect(out.String()).To(ContainSubstring("🔴 Not approving: base branch main is failing required checks"))
+		Expect(out.String()).To(ContainSubstring("skipped: 1"))
+		for _, request := range mockClient.Requests {
+			Expect(request.Method).NotTo(Equal("POST"))
+		}
+	})
+})
@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// defaultRepoRequestBudget is how many API requests the rows of one repository may make to fill in their
+// details when several repositories are listed
+const defaultRepoRequestBudget = 300
+
+// repoRequestBudget caps the requests made for the details of each repository's rows, 0 for no cap. It is
+// only set when several repositories are listed, so one huge repository can't use up the quota of the others.
+var repoRequestBudget int
+
+// enrichmentCost is the most requests filling in the details of one row takes
+func enrichmentCost(opts *listOptions, isKonflux bool) int {
+	// Reviews, and the PR details for the rebase and blocked state
+	cost := 2
+	if isKonflux {
+		// The changed files, for Tekton-only PRs
+		cost++
+	}
+	if opts.readinessRequested() || opts.Interactive {
+		// Check runs and statuses
+		cost += 2
+	}
+	return cost
+}
+
+// rowsWithinBudget returns how many of count rows get their details within the per-repository budget. The
+// first rows, which the sort puts first, are filled in; the others are only partly loaded.
+func rowsWithinBudget(opts *listOptions, count int, isKonflux bool) int {
+	if repoRequestBudget <= 0 {
+		return count
+	}
+	return min(count, repoRequestBudget/enrichmentCost(opts, isKonflux))
+}
+
+// reportPartialRows tells which rows were only partly loaded because the request budget ran out
+func reportPartialRows(rows []PRRow, owner, repo string) {
+	var partial []string
+	for _, row := range rows {
+		if row.Partial {
+			partial = append(partial, rowPRLink(row, owner, repo))
+		}
+	}
+	if len(partial) == 0 {
+		return
+	}
+	streams.Printf("⏳ Only partly loaded, the budget of %d API requests per repository ran out: %s\n", repoRequestBudget, strings.Join(partial, ", "))
+	streams.Printf("   Raise it with 'ghprs config set repo-request-budget' (0 for no budget) or list the repository on its own\n")
+}
+
+// repoRequestBudgetFor returns the budget of each repository of a run over count repositories
+func repoRequestBudgetFor(config *Config, count int) int {
+	if count < 2 {
+		return 0
+	}
+	return config.RepoRequestBudget()
+}
+
+// describeRepoRequestBudget shows the configured budget for 'config show'
+func describeRepoRequestBudget(budget int) string {
+	if budget == 0 {
+		return "none"
+	}
+	return fmt.Sprintf("%d requests per repository when listing several", budget)
+}
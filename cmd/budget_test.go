@@ -0,0 +1,73 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Per-repository request budget", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should only fill in the details of the rows within the budget", func() {
+		prs := cmd.CreateMockPullRequests(5)
+		for _, pr := range prs {
+			mockClient.AddResponse(fmt.Sprintf("repos/owner/repo/pulls/%d/reviews", pr.Number), 200, cmd.CreateMockReviews(true))
+		}
+
+		// Each row takes at most 2 requests outside Konflux and without readiness, so 5 requests cover 2 rows
+		rows := cmd.BuildPRRowsWithBudgetTest(prs, "owner", "repo", mockClient, 5)
+
+		var partial []int
+		for _, row := range rows {
+			if row.Partial {
+				partial = append(partial, row.Number)
+				Expect(row.Reviewed).To(BeNil())
+			}
+		}
+		Expect(partial).To(Equal([]int{prs[2].Number, prs[3].Number, prs[4].Number}))
+		Expect(mockClient.GetRequestCount("/reviews")).To(Equal(2))
+	})
+
+	It("should fill in every row without a budget", func() {
+		rows := cmd.BuildPRRowsWithBudgetTest(cmd.CreateMockPullRequests(3), "owner", "repo", mockClient, 0)
+		for _, row := range rows {
+			Expect(row.Partial).To(BeFalse())
+		}
+	})
+
+	It("should say which PRs were only partly loaded", func() {
+		rows := []cmd.PRRow{{Number: 1, Title: "Loaded", State: "open"}, {Number: 2, Title: "Not loaded", State: "open", Partial: true}}
+		cmd.RenderPRTableWithBudgetTest(rows, "owner", "repo", 300)
+
+		Expect(out.String()).To(ContainSubstring("⏳ Only partly loaded, the budget of 300 API requests per repository ran out: #2\n"))
+	})
+
+	It("should only apply the configured budget when several repositories are listed", func() {
+		config := cmd.DefaultConfig()
+		Expect(cmd.RepoRequestBudgetForTest(config, 1)).To(BeZero())
+		Expect(cmd.RepoRequestBudgetForTest(config, 3)).To(Equal(300))
+
+		budget := 0
+		config.RateLimit.RepoBudget = &budget
+		Expect(cmd.RepoRequestBudgetForTest(config, 3)).To(BeZero())
+	})
+})
@@ -0,0 +1,338 @@
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultCacheTTL is how long cached PR data is reused when the config doesn't set a TTL
+const defaultCacheTTL = 5 * time.Minute
+
+var (
+	// prDataPathRE matches the per-PR REST paths that are cached while the PR's updated_at is unchanged,
+	// including the pages of its commits
+	prDataPathRE = regexp.MustCompile(`^(repos/[^/]+/[^/]+/pulls/\d+)(/reviews|/files|/commits\?.*)?$`)
+	// prTimelinePathRE matches the pages of a PR's timeline, which are cached like the PR's data
+	prTimelinePathRE = regexp.MustCompile(`^(repos/[^/]+/[^/]+)/issues/(\d+)/timeline\?`)
+	// commitDataPathRE matches the per-commit check paths, which are cached by SHA until the TTL expires
+	commitDataPathRE = regexp.MustCompile(`^repos/[^/]+/[^/]+/commits/[0-9a-fA-F]+/(check-runs|status)$`)
+	// prMutationPathRE matches the PR and issue paths that mutations are sent to
+	prMutationPathRE = regexp.MustCompile(`^(repos/[^/]+/[^/]+)/(?:pulls|issues)/(\d+)`)
+)
+
+// freshDataKey marks a request context whose GET must not be answered from cached or prefetched data
+type freshDataKey struct{}
+
+// withFreshData returns a context whose requests bypass cached and prefetched data,
+// for reads that guard a mutation such as checking a PR's head before approving it
+func withFreshData(ctx context.Context) context.Context {
+	return context.WithValue(ctx, freshDataKey{}, true)
+}
+
+// wantsFreshData reports whether ctx was created by withFreshData
+func wantsFreshData(ctx context.Context) bool {
+	fresh, _ := ctx.Value(freshDataKey{}).(bool)
+	return fresh
+}
+
+// cacheDir can be overridden for testing
+var cacheDir string
+
+// SetCacheDir sets a custom cache directory (used for testing)
+func SetCacheDir(dir string) {
+	cacheDir = dir
+}
+
+// ResetCacheDir resets the cache directory to use the default user cache path
+func ResetCacheDir() {
+	cacheDir = ""
+}
+
+// getCacheDir returns the directory PR data is cached in
+func getCacheDir() string {
+	if cacheDir != "" {
+		return cacheDir
+	}
+
+	userCacheDir, err := os.UserCacheDir()
+	if err != nil {
+		// Fallback to current directory
+		return ".ghprs-cache"
+	}
+	return filepath.Join(userCacheDir, "ghprs")
+}
+
+// cacheEntry is the on-disk representation of a cached response
+type cacheEntry struct {
+	Key      string          `json:"key"`
+	StoredAt time.Time       `json:"stored_at"`
+	Data     json.RawMessage `json:"data"`
+}
+
+// CacheStats summarizes the contents of the on-disk cache
+type CacheStats struct {
+	Dir     string
+	Entries int
+	Expired int
+	Bytes   int64
+}
+
+// diskCache stores API responses as files, one per key, and expires them after a TTL
+type diskCache struct {
+	dir string
+	ttl time.Duration
+}
+
+// newDiskCache creates a cache in dir whose entries expire after ttl
+func newDiskCache(dir string, ttl time.Duration) *diskCache {
+	return &diskCache{dir: dir, ttl: ttl}
+}
+
+// entryPath returns the file an entry for key is stored in
+func (c *diskCache) entryPath(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
+
+// get returns the cached data for key if it exists and hasn't expired
+func (c *diskCache) get(key string) ([]byte, bool) {
+	path := c.entryPath(key)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, false
+	}
+
+	var entry cacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil || entry.Key != key {
+		return nil, false
+	}
+	if time.Since(entry.StoredAt) > c.ttl {
+		_ = os.Remove(path)
+		return nil, false
+	}
+	return entry.Data, true
+}
+
+// put stores data for key, writing through a temporary file so concurrent readers never see partial entries
+func (c *diskCache) put(key string, data []byte) error {
+	if err := os.MkdirAll(c.dir, 0755); err != nil {
+		return fmt.Errorf("failed to create cache directory: %w", err)
+	}
+
+	entry, err := json.Marshal(cacheEntry{Key: key, StoredAt: time.Now(), Data: data})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(c.dir, "entry-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if _, err := tmp.Write(entry); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write cache entry: %w", err)
+	}
+	return os.Rename(tmp.Name(), c.entryPath(key))
+}
+
+// clear removes every cached entry and returns how many were removed
+func (c *diskCache) clear() (int, error) {
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, file := range files {
+		if err := os.Remove(file); err != nil {
+			return removed, fmt.Errorf("failed to remove cache entry: %w", err)
+		}
+		removed++
+	}
+	return removed, nil
+}
+
+// stats counts the cached entries, how many have expired and their total size
+func (c *diskCache) stats() (CacheStats, error) {
+	stats := CacheStats{Dir: c.dir}
+
+	files, err := filepath.Glob(filepath.Join(c.dir, "*.json"))
+	if err != nil {
+		return stats, err
+	}
+
+	for _, file := range files {
+		info, err := os.Stat(file)
+		if err != nil {
+			continue
+		}
+		stats.Entries++
+		stats.Bytes += info.Size()
+
+		var entry cacheEntry
+		data, err := os.ReadFile(file)
+		if err != nil || json.Unmarshal(data, &entry) != nil || time.Since(entry.StoredAt) > c.ttl {
+			stats.Expired++
+		}
+	}
+	return stats, nil
+}
+
+// cachedRESTClient serves per-PR GET requests from the on-disk cache and forwards everything else.
+// PR details, reviews, files and timeline are keyed by the PR's updated_at as seen in the PR list,
+// so they are only reused while the PR is unchanged; check results are keyed by commit SHA.
+type cachedRESTClient struct {
+	RESTClientInterface
+	cache    *diskCache
+	host     string
+	mutex    sync.RWMutex
+	versions map[string]string
+}
+
+// withDiskCache wraps a client for host so per-PR data is cached on disk (a nil cache or a TTL of 0 disables caching)
+func withDiskCache(client RESTClientInterface, cache *diskCache, host string) RESTClientInterface {
+	if cache == nil || cache.ttl <= 0 {
+		return client
+	}
+	return &cachedRESTClient{
+		RESTClientInterface: client,
+		cache:               cache,
+		host:                host,
+		versions:            make(map[string]string),
+	}
+}
+
+// cacheKey returns the cache key for a GET path, or false if the path isn't cacheable.
+// Keys include the host, since the same owner/repo can exist on github.com and an Enterprise host.
+func (c *cachedRESTClient) cacheKey(path string) (string, bool) {
+	prPath := ""
+	if match := prDataPathRE.FindStringSubmatch(path); match != nil {
+		prPath = match[1]
+	} else if match := prTimelinePathRE.FindStringSubmatch(path); match != nil {
+		prPath = fmt.Sprintf("%s/pulls/%s", match[1], match[2])
+	}
+	if prPath != "" {
+		c.mutex.RLock()
+		version := c.versions[prPath]
+		c.mutex.RUnlock()
+		if version == "" {
+			return "", false
+		}
+		return c.host + "/" + path + "@" + version, true
+	}
+	if commitDataPathRE.MatchString(path) {
+		return c.host + "/" + path, true
+	}
+	return "", false
+}
+
+// trackPullRequests records the updated_at of every PR in a fetched PR list
+func (c *cachedRESTClient) trackPullRequests(path string, prs []PullRequest) {
+	basePath := strings.SplitN(path, "?", 2)[0]
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	for _, pr := range prs {
+		c.versions[fmt.Sprintf("%s/%d", basePath, pr.Number)] = pr.UpdatedAt
+	}
+}
+
+// forget stops caching data for the PR a mutation was sent to, since its updated_at is now stale
+func (c *cachedRESTClient) forget(path string) {
+	match := prMutationPathRE.FindStringSubmatch(path)
+	if match == nil {
+		return
+	}
+	c.mutex.Lock()
+	delete(c.versions, fmt.Sprintf("%s/pulls/%s", match[1], match[2]))
+	c.mutex.Unlock()
+}
+
+// Get serves cached responses before falling back to REST
+func (c *cachedRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post forwards to REST and stops caching the PR it modifies
+func (c *cachedRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put forwards to REST and stops caching the PR it modifies
+func (c *cachedRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch forwards to REST and stops caching the PR it modifies
+func (c *cachedRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete forwards to REST and stops caching the PR it modifies
+func (c *cachedRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do serves cached GET responses and forwards everything else to REST
+func (c *cachedRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext serves cached GET responses, caches fresh ones and forwards everything else to REST
+func (c *cachedRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	if method != http.MethodGet {
+		c.forget(path)
+		return c.RESTClientInterface.DoWithContext(ctx, method, path, body, response)
+	}
+
+	key, cacheable := c.cacheKey(path)
+	if cacheable && response != nil && !wantsFreshData(ctx) {
+		if data, ok := c.cache.get(key); ok && json.Unmarshal(data, response) == nil {
+			logger.DebugContext(ctx, "Cache hit", "path", path)
+			return nil
+		}
+		logger.DebugContext(ctx, "Cache miss", "path", path)
+	}
+
+	if err := c.RESTClientInterface.DoWithContext(ctx, method, path, body, response); err != nil {
+		return err
+	}
+
+	if prs, ok := response.(*[]PullRequest); ok {
+		c.trackPullRequests(path, *prs)
+	}
+
+	if !cacheable || response == nil {
+		return nil
+	}
+
+	// mergeable_state is computed lazily by GitHub, so don't keep details that don't have it yet
+	if pr, ok := response.(*PullRequest); ok {
+		if state := normalizeMergeableState(pr.MergeableState); state == "" || state == "unknown" {
+			return nil
+		}
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return nil
+	}
+	// Failing to write the cache only costs a re-fetch next time
+	_ = c.cache.put(key, data)
+	return nil
+}
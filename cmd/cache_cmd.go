@@ -0,0 +1,79 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// openDiskCache opens the on-disk cache with the TTL from the configuration
+func openDiskCache() *diskCache {
+	config, err := LoadConfig()
+	if err != nil {
+		config = DefaultConfig()
+	}
+	return newDiskCache(getCacheDir(), config.CacheTTL())
+}
+
+// cacheClearCmd removes all cached PR data
+var cacheClearCmd = &cobra.Command{
+	Use:   "clear",
+	Short: "Remove all cached PR data",
+	Long:  `Remove every cached PR details, reviews, files and check results entry.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cache := openDiskCache()
+		removed, err := cache.clear()
+		if err != nil {
+			fmt.Printf("Error clearing cache: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Removed %d cached entries from %s\n", removed, cache.dir)
+	},
+}
+
+// cacheStatsCmd shows what is in the cache
+var cacheStatsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show cache statistics",
+	Long:  `Show the cache location, TTL, number of entries and their total size.`,
+	Run: func(cmd *cobra.Command, args []string) {
+		cache := openDiskCache()
+		stats, err := cache.stats()
+		if err != nil {
+			fmt.Printf("Error reading cache: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Cache directory: %s\n", stats.Dir)
+		if cache.ttl > 0 {
+			fmt.Printf("  TTL: %s\n", cache.ttl)
+		} else {
+			fmt.Println("  TTL: disabled")
+		}
+		fmt.Printf("  Entries: %d (%d expired)\n", stats.Entries, stats.Expired)
+		fmt.Printf("  Size: %.1f KiB\n", float64(stats.Bytes)/1024)
+	},
+}
+
+// AddCacheCommands adds all cache commands to the provided root command
+func AddCacheCommands(rootCmd *cobra.Command) {
+	cacheCmd := &cobra.Command{
+		Use:   "cache",
+		Short: "Manage the on-disk PR cache",
+		Long: `Manage the on-disk cache of PR details, reviews, files and check results.
+
+'ghprs list' and 'ghprs konflux' reuse cached data for PRs that haven't been updated
+since it was stored, until the TTL expires. Set the TTL with 'ghprs config set cache-ttl 10m'
+(0 disables the cache) or bypass it for a single run with --no-cache.`,
+	}
+
+	rootCmd.AddCommand(cacheCmd)
+
+	cacheCmd.AddCommand(cacheClearCmd)
+	cacheCmd.AddCommand(cacheStatsCmd)
+}
+
+func init() {
+	AddCacheCommands(RootCmd)
+}
@@ -0,0 +1,191 @@
+package cmd_test
+
+import (
+	"context"
+	"os"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Disk Cache", func() {
+	var (
+		tempDir    string
+		mockClient *cmd.MockRESTClient
+	)
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "ghprs-cache-test")
+		Expect(err).NotTo(HaveOccurred())
+
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls?", 200, []cmd.PullRequest{
+			{Number: 1, Title: "First", UpdatedAt: "2026-01-01T00:00:00Z"},
+		})
+		mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{{State: "APPROVED"}})
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+		mockClient.AddResponse("repos/owner/repo/commits/abc123/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+	})
+
+	AfterEach(func() {
+		_ = os.RemoveAll(tempDir)
+	})
+
+	countRequests := func(path string) int {
+		count := 0
+		for _, req := range mockClient.Requests {
+			if req.URL == path {
+				count++
+			}
+		}
+		return count
+	}
+
+	listPRs := func(client cmd.RESTClientInterface) {
+		var prs []cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls?state=open", &prs)).To(Succeed())
+	}
+
+	It("should reuse PR data across clients while the PR is unchanged", func() {
+		for run := 0; run < 2; run++ {
+			client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+			listPRs(client)
+
+			var reviews []cmd.Review
+			Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+			Expect(reviews).To(HaveLen(1))
+
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+			Expect(pr.MergeableState).To(Equal("clean"))
+		}
+
+		Expect(countRequests("repos/owner/repo/pulls?state=open")).To(Equal(2))
+		Expect(countRequests("repos/owner/repo/pulls/1/reviews")).To(Equal(1))
+		Expect(countRequests("repos/owner/repo/pulls/1")).To(Equal(1))
+	})
+
+	It("should re-fetch PR data once the PR has been updated", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		listPRs(client)
+		var reviews []cmd.Review
+		Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+
+		mockClient.AddResponse("repos/owner/repo/pulls?", 200, []cmd.PullRequest{
+			{Number: 1, Title: "First", UpdatedAt: "2026-01-02T00:00:00Z"},
+		})
+		client = cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		listPRs(client)
+		Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+
+		Expect(countRequests("repos/owner/repo/pulls/1/reviews")).To(Equal(2))
+	})
+
+	It("should reuse a PR's timeline while the PR is unchanged", func() {
+		const timeline = "repos/owner/repo/issues/1/timeline?per_page=100&page=1"
+		mockClient.AddResponse(timeline, 200, []cmd.TimelineEvent{{Event: "commented"}})
+		for run := 0; run < 2; run++ {
+			client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+			listPRs(client)
+			var events []cmd.TimelineEvent
+			Expect(client.Get(timeline, &events)).To(Succeed())
+			Expect(events).To(HaveLen(1))
+		}
+		Expect(countRequests(timeline)).To(Equal(1))
+	})
+
+	It("should not cache PR data when the PR list hasn't been seen", func() {
+		for run := 0; run < 2; run++ {
+			client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+			var reviews []cmd.Review
+			Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+		}
+		Expect(countRequests("repos/owner/repo/pulls/1/reviews")).To(Equal(2))
+	})
+
+	It("should not cache PR details without a known mergeable state", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "unknown"})
+		for run := 0; run < 2; run++ {
+			client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+			listPRs(client)
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		}
+		Expect(countRequests("repos/owner/repo/pulls/1")).To(Equal(2))
+	})
+
+	It("should cache check runs by commit until the TTL expires", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		var checks cmd.CheckRunsResponse
+		Expect(client.Get("repos/owner/repo/commits/abc123/check-runs", &checks)).To(Succeed())
+		Expect(client.Get("repos/owner/repo/commits/abc123/check-runs", &checks)).To(Succeed())
+		Expect(checks.CheckRuns).To(HaveLen(1))
+		Expect(countRequests("repos/owner/repo/commits/abc123/check-runs")).To(Equal(1))
+
+		expired := cmd.WithDiskCacheTest(mockClient, tempDir, time.Nanosecond)
+		time.Sleep(time.Millisecond)
+		Expect(expired.Get("repos/owner/repo/commits/abc123/check-runs", &checks)).To(Succeed())
+		Expect(countRequests("repos/owner/repo/commits/abc123/check-runs")).To(Equal(2))
+	})
+
+	It("should stop serving cached data for a PR after it is modified", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		listPRs(client)
+		var reviews []cmd.Review
+		Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+
+		Expect(client.Post("repos/owner/repo/pulls/1/reviews", strings.NewReader(`{"event":"APPROVE"}`), nil)).To(Succeed())
+		Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+
+		getCount := 0
+		for _, req := range mockClient.Requests {
+			if req.Method == "GET" && req.URL == "repos/owner/repo/pulls/1/reviews" {
+				getCount++
+			}
+		}
+		Expect(getCount).To(Equal(2))
+	})
+
+	It("should bypass cached data for fresh reads", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		listPRs(client)
+
+		var pr cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(client.DoWithContext(cmd.WithFreshDataTest(context.Background()), "GET", "repos/owner/repo/pulls/1", nil, &pr)).To(Succeed())
+		Expect(countRequests("repos/owner/repo/pulls/1")).To(Equal(2))
+	})
+
+	It("should not wrap the client when the TTL is 0", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, 0)
+		Expect(client).To(BeIdenticalTo(mockClient))
+	})
+
+	It("should report stats and clear entries", func() {
+		client := cmd.WithDiskCacheTest(mockClient, tempDir, time.Minute)
+		listPRs(client)
+		var reviews []cmd.Review
+		Expect(client.Get("repos/owner/repo/pulls/1/reviews", &reviews)).To(Succeed())
+		var checks cmd.CheckRunsResponse
+		Expect(client.Get("repos/owner/repo/commits/abc123/check-runs", &checks)).To(Succeed())
+
+		stats, err := cmd.DiskCacheStatsTest(tempDir, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Entries).To(Equal(2))
+		Expect(stats.Expired).To(Equal(0))
+		Expect(stats.Bytes).To(BeNumerically(">", 0))
+
+		removed, err := cmd.ClearDiskCacheTest(tempDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(removed).To(Equal(2))
+
+		stats, err = cmd.DiskCacheStatsTest(tempDir, time.Minute)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Entries).To(Equal(0))
+	})
+})
@@ -0,0 +1,512 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/pkg/ghprs"
+)
+
+const (
+	// defaultCanaryWait bounds how long the canary's merge and post-merge pipelines are waited for
+	defaultCanaryWait = time.Hour
+	// defaultCanaryPollInterval is how often the canary's merge and post-merge checks are looked at
+	defaultCanaryPollInterval = 30 * time.Second
+)
+
+var (
+	canaryTitle        string
+	canaryMergeMethod  string
+	canaryWait         time.Duration
+	canaryPollInterval time.Duration
+)
+
+// repoPR is a PR together with its repository and the client to use for it
+type repoPR struct {
+	Owner  string
+	Repo   string
+	Client RESTClientInterface
+	PR     PullRequest
+}
+
+// canaryGroup is one change opened as a PR in several repositories: the PR of the canary repository,
+// which is merged first, and the PRs of the other repositories, which wait for the canary
+type canaryGroup struct {
+	Title  string
+	Canary repoPR
+	Others []repoPR
+}
+
+// MergeRequest represents a request to merge a pull request
+type MergeRequest struct {
+	// SHA makes GitHub refuse the merge if the PR changed since it was approved
+	SHA         string `json:"sha,omitempty"`
+	MergeMethod string `json:"merge_method,omitempty"`
+}
+
+// MergeResponse represents the response of the merge API
+type MergeResponse struct {
+	SHA     string `json:"sha"`
+	Merged  bool   `json:"merged"`
+	Message string `json:"message"`
+}
+
+// canaryCmd approves and merges a change in a canary repository and, once its post-merge pipelines pass,
+// approves the same change in the other Konflux repositories
+var canaryCmd = &cobra.Command{
+	Use:   "canary <owner/repo>",
+	Short: "Roll out a change made in many Konflux repositories through a canary repository first",
+	Long: `Roll out a Konflux or renovate change that was opened as a PR in many repositories by trying it
+in one canary repository first.
+
+The Konflux PRs of the configured Konflux repositories are grouped by their diff. For the chosen change,
+the PR of the canary repository is approved and merged (or, for repositories managed by Prow,
+approved and waited for until Prow merges it). Then the checks of the merge commit, such as the
+Konflux push pipelines, are waited for. Only when they all pass is the same change approved in the
+other repositories, after a plan of the approvals is confirmed. Each of them is checked like the canary:
+drafts, PRs on hold or with a migration warning, CI or ownership changes by untrusted authors and PRs
+larger than max_changes are skipped, and PRs pushed to since they were compared are not approved.
+
+Examples:
+  ghprs canary owner/canary-repo
+  ghprs canary owner/canary-repo --title "update konflux references"
+  ghprs canary owner/canary-repo --merge-method squash --canary-wait 2h`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRepositoryArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		canarySpec := args[0]
+		if _, _, ok := parseRepoSpec(canarySpec); !ok {
+			log.Fatalf("Invalid repository format '%s'. Must be 'owner/repo'", canarySpec)
+		}
+		if err := validateMergeMethod(canaryMergeMethod); err != nil {
+			log.Fatal(err)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+
+		repositories := config.GetRepositories(true)
+		if !slices.Contains(repositories, canarySpec) {
+			repositories = append([]string{canarySpec}, repositories...)
+		}
+		if len(repositories) < 2 {
+			log.Fatal("Configure the other Konflux repositories with 'ghprs config add-konflux-repo' to roll a change out to them")
+		}
+
+		ctx := commandContext(cmd)
+		// Every open Konflux PR counts, whatever the list defaults, and which files they change doesn't matter
+		opts := everyOpenPR(newListOptions())
+		groups := groupIdenticalChanges(ctx, canarySpec, fetchKonfluxPRs(ctx, opts, config, repositories))
+		groups = filterCanaryGroups(groups, canaryTitle)
+		group := selectCanaryGroup(groups)
+		if group == nil {
+			return
+		}
+		if !runCanary(opts, *group, config, canaryMergeMethod, canaryWait, canaryPollInterval, assumeYes) {
+			os.Exit(1)
+		}
+	},
+}
+
+// validateMergeMethod checks that method is empty (the repository's preferred method) or a merge method GitHub accepts
+func validateMergeMethod(method string) error {
+	if method == "" || slices.Contains(mergeMethods, method) {
+		return nil
+	}
+	return fmt.Errorf("invalid merge method %q (must be merge, squash or rebase)", method)
+}
+
+// fetchKonfluxPRs fetches the open Konflux PRs of each repository that the filters of opts keep, skipping
+// repositories that fail or take longer than --timeout
+func fetchKonfluxPRs(ctx context.Context, opts *listOptions, config *Config, repositories []string) [][]repoPR {
+	limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+	var prsByRepo [][]repoPR
+	for _, repoSpec := range repositories {
+		owner, repo, ok := parseRepoSpec(repoSpec)
+		if !ok {
+			logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+			continue
+		}
+		client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+		if err != nil {
+			logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+			continue
+		}
+		repoCtx, cancel := withRepositoryTimeout(ctx)
+		pullRequests, err := fetchPullRequestsREST(withContext(client, repoCtx), owner, repo, "open", "", 0, newPRFilter(repoCtx, opts, owner, repo, queueAuthors(config, repoSpec, []string{konfluxBotAuthor}, true), true))
+		reason := describeCancellation(repoCtx.Err())
+		cancel()
+		if reason != "" {
+			logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+			if ctx.Err() != nil {
+				break
+			}
+			continue
+		}
+		if err != nil {
+			logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+			continue
+		}
+		var prs []repoPR
+		for _, pr := range pullRequests {
+			prs = append(prs, repoPR{Owner: owner, Repo: repo, Client: client, PR: pr})
+		}
+		prsByRepo = append(prsByRepo, prs)
+	}
+	return prsByRepo
+}
+
+// changeKey identifies the change a PR makes by its normalized diff, so the same change opened in several
+// repositories groups together whatever its title. PRs whose diff can't be fetched or compared have no key.
+func changeKey(ctx context.Context, pr repoPR) string {
+	link := fmt.Sprintf("%s/%s#%d", pr.Owner, pr.Repo, pr.PR.Number)
+	files, err := ghprs.FetchFiles(ctx, pr.Client, pr.Owner, pr.Repo, pr.PR.Number)
+	if err != nil {
+		logger.Warn("Could not fetch the changed files, leaving the PR out of the rollout", "pr", link, "error", err)
+		return ""
+	}
+	diff, err := normalizedDiff(files)
+	if err != nil {
+		logger.Warn("Could not compare the diff, leaving the PR out of the rollout", "pr", link, "error", err)
+		return ""
+	}
+	return strings.Join(diff, "\n")
+}
+
+// groupIdenticalChanges groups the PRs of every repository by the diff they make, keeping the changes that have a
+// PR in the canary repository and in at least one other repository, in the order of the canary's PRs
+func groupIdenticalChanges(ctx context.Context, canarySpec string, prsByRepo [][]repoPR) []canaryGroup {
+	var all []repoPR
+	for _, prs := range prsByRepo {
+		all = append(all, prs...)
+	}
+	keys := make([]string, len(all))
+	runConcurrently(len(all), defaultConcurrency, func(i int) {
+		keys[i] = changeKey(ctx, all[i])
+	})
+
+	var groups []canaryGroup
+	byKey := make(map[string]int)
+	for i, pr := range all {
+		if pr.Owner+"/"+pr.Repo != canarySpec || keys[i] == "" {
+			continue
+		}
+		if _, ok := byKey[keys[i]]; !ok {
+			byKey[keys[i]] = len(groups)
+			groups = append(groups, canaryGroup{Title: pr.PR.Title, Canary: pr})
+		}
+	}
+
+	for i, pr := range all {
+		if pr.Owner+"/"+pr.Repo == canarySpec {
+			continue
+		}
+		if group, ok := byKey[keys[i]]; ok {
+			groups[group].Others = append(groups[group].Others, pr)
+		}
+	}
+
+	var shared []canaryGroup
+	for _, group := range groups {
+		if len(group.Others) > 0 {
+			shared = append(shared, group)
+		}
+	}
+	return shared
+}
+
+// filterCanaryGroups keeps the changes whose title contains title, ignoring case
+func filterCanaryGroups(groups []canaryGroup, title string) []canaryGroup {
+	if title == "" {
+		return groups
+	}
+	var filtered []canaryGroup
+	for _, group := range groups {
+		if strings.Contains(strings.ToLower(group.Title), strings.ToLower(title)) {
+			filtered = append(filtered, group)
+		}
+	}
+	return filtered
+}
+
+// selectCanaryGroup picks the change to roll out, asking when there are several
+func selectCanaryGroup(groups []canaryGroup) *canaryGroup {
+	switch len(groups) {
+	case 0:
+		streams.Println("No change has a Konflux PR in the canary repository and in another repository.")
+		return nil
+	case 1:
+		return &groups[0]
+	}
+
+	streams.Printf("\n📦 Changes opened in the canary and other repositories (%d):\n", len(groups))
+	for i, group := range groups {
+		streams.Printf("  %d. %s (%d other repositories)\n", i+1, group.Title, len(group.Others))
+	}
+	for {
+		input, err := prompter.Input(fmt.Sprintf("\nSelect the change to roll out (1-%d, 0 to cancel): ", len(groups)))
+		if err != nil {
+			if err == io.EOF {
+				streams.Printf("\n")
+			}
+			return nil
+		}
+		choice, err := strconv.Atoi(input)
+		if err != nil || choice < 0 || choice > len(groups) {
+			streams.Printf("Invalid choice '%s'. Please select a number between 0 and %d.\n", input, len(groups))
+			continue
+		}
+		if choice == 0 {
+			return nil
+		}
+		return &groups[choice-1]
+	}
+}
+
+// runCanary approves and merges the canary PR of group, waits for the post-merge checks of its merge commit
+// and then approves the PRs of the other repositories, each with the approval settings of its repository.
+// It reports whether the rollout went through.
+func runCanary(opts *listOptions, group canaryGroup, config *Config, mergeMethod string, timeout, interval time.Duration, assumeYes bool) bool {
+	canary := group.Canary
+	canaryLink := formatPRLink(canary.Owner, canary.Repo, canary.PR.Number)
+	canaryConfig := newApprovalConfig(opts, config, canary.Owner+"/"+canary.Repo, true)
+
+	streams.Printf("\n🐤 Canary for %q: %s\n", group.Title, canaryLink)
+	for _, other := range group.Others {
+		streams.Printf("   ⏳ waiting: %s\n", formatPRLink(other.Owner, other.Repo, other.PR.Number))
+	}
+
+	prowManaged := isProwManaged(canary.PR)
+	if !prowManaged {
+		// Check the method against the repository rather than have GitHub refuse the merge after approving
+		var err error
+		if mergeMethod, err = resolveMergeMethod(canary.Client, canary.Owner, canary.Repo, mergeMethod); err != nil {
+			streams.Printf("❌ Can't merge the canary %s: %v\n", canaryLink, err)
+			return false
+		}
+	}
+	if !assumeYes {
+		question := fmt.Sprintf("\nApprove and merge the canary PR %s?", canaryLink)
+		if mergeMethod != "" {
+			question = fmt.Sprintf("\nApprove and merge the canary PR %s (%s)?", canaryLink, mergeMethod)
+		}
+		if prowManaged {
+			question = fmt.Sprintf("\nApprove the canary PR %s and wait for Prow to merge it?", canaryLink)
+		}
+		confirmed, err := prompter.Confirm(question)
+		if err != nil || !confirmed {
+			streams.Println("Cancelled, no changes were made.")
+			return false
+		}
+	}
+
+	if !confirmSensitiveChanges(canary.Client, canary.Owner, canary.Repo, canary.PR, canaryConfig.TrustedAuthors) {
+		return false
+	}
+	if !confirmLargeChanges(canary.Client, canary.Owner, canary.Repo, canary.PR, canaryConfig.Review.MaxChanges, canaryConfig.TrustedAuthors) {
+		return false
+	}
+	if !confirmPRUnchanged(canary.Client, canary.Owner, canary.Repo, canary.PR, "approve") {
+		return false
+	}
+	streams.Printf("✅ Approving %s: %s\n", canaryLink, canary.PR.Title)
+	if err := postApproval(canary.Client, canary.Owner, canary.Repo, canary.PR, canaryConfig.Review); err != nil {
+		streams.Printf("❌ Failed to approve %s: %v\n", canaryLink, err)
+		return false
+	}
+
+	var mergeSHA string
+	var err error
+	if prowManaged {
+		streams.Printf("⏳ Waiting for Prow to merge %s...\n", canaryLink)
+		mergeSHA, err = waitForMerge(canary.Client, canary.Owner, canary.Repo, canary.PR.Number, timeout, interval)
+	} else {
+		mergeSHA, err = mergePR(canary.Client, canary.Owner, canary.Repo, canary.PR, mergeMethod)
+	}
+	if err != nil {
+		streams.Printf("❌ Canary %s was not merged: %v\n", canaryLink, err)
+		return false
+	}
+	streams.Printf("🔀 Merged %s as %s\n", canaryLink, shortSHA(mergeSHA))
+
+	streams.Printf("⏳ Waiting for the post-merge checks of %s...\n", shortSHA(mergeSHA))
+	if err := waitForPostMergeChecks(canary.Client, canary.Owner, canary.Repo, mergeSHA, timeout, interval); err != nil {
+		streams.Printf("❌ Canary %s failed after merging, the other repositories are left alone: %v\n", canaryLink, err)
+		return false
+	}
+	streams.Printf("✅ Post-merge checks of the canary passed, unlocking the other repositories\n")
+
+	failed := 0
+	for _, plan := range planCanaryApprovals(opts, group.Others, config) {
+		if !confirmPlan(plan, assumeYes) {
+			continue
+		}
+		settings := newApprovalConfig(opts, config, plan.owner+"/"+plan.repo, true).Review
+		prs := make(map[int]repoPR)
+		for _, other := range group.Others {
+			if other.Owner == plan.owner && other.Repo == plan.repo {
+				prs[other.PR.Number] = other
+			}
+		}
+		failed += executePlan(plan, func(action PlannedAction) error {
+			other := prs[action.Number]
+			// Make sure nothing was pushed since the diff was compared, so unseen commits aren't approved
+			if !confirmPRUnchanged(other.Client, other.Owner, other.Repo, other.PR, "approve") {
+				return fmt.Errorf("not approved")
+			}
+			return postApproval(other.Client, other.Owner, other.Repo, other.PR, settings)
+		})
+	}
+	return failed == 0
+}
+
+// planCanaryApprovals plans the approvals of the PRs waiting for the canary, one plan per repository. Drafts,
+// PRs on hold or with a migration warning, PRs by untrusted authors that change CI or ownership files and
+// routine PRs larger than the max_changes of their repository are skipped, as nobody confirms them one by one.
+func planCanaryApprovals(opts *listOptions, others []repoPR, config *Config) []*batchPlan {
+	var plans []*batchPlan
+	byRepo := make(map[string]*batchPlan)
+	for _, other := range others {
+		repoSpec := other.Owner + "/" + other.Repo
+		plan, ok := byRepo[repoSpec]
+		if !ok {
+			plan = &batchPlan{owner: other.Owner, repo: other.Repo}
+			byRepo[repoSpec] = plan
+			plans = append(plans, plan)
+		}
+
+		switch {
+		case other.PR.State != "open":
+			plan.add(other.PR, PlanActionSkip, "not open")
+		case other.PR.Draft:
+			plan.add(other.PR, PlanActionSkip, "draft")
+		case isOnHold(other.PR):
+			plan.add(other.PR, PlanActionSkip, "on hold")
+		case hasMigrationWarning(other.PR):
+			plan.add(other.PR, PlanActionSkip, "migration warning, approve it on its own")
+		default:
+			if reason := batchApprovalBlocker(other, config.TrustedAuthors(), newApprovalConfig(opts, config, repoSpec, true).Review.MaxChanges); reason != "" {
+				plan.add(other.PR, PlanActionSkip, reason)
+				continue
+			}
+			plan.add(other.PR, PlanActionApprove, "canary passed")
+		}
+	}
+	return plans
+}
+
+// mergePR merges a PR through the API, refusing to merge commits pushed since it was shown.
+// It returns the SHA of the merge commit.
+func mergePR(client RESTClientInterface, owner, repo string, pr PullRequest, method string) (string, error) {
+	requestJSON, err := json.Marshal(MergeRequest{SHA: pr.Head.SHA, MergeMethod: method})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal merge request: %v", err)
+	}
+	var response MergeResponse
+	mergePath := fmt.Sprintf("repos/%s/%s/pulls/%d/merge", owner, repo, pr.Number)
+	if err := client.Put(mergePath, bytes.NewReader(requestJSON), &response); err != nil {
+		return "", err
+	}
+	if !response.Merged || response.SHA == "" {
+		return "", fmt.Errorf("not merged: %s", response.Message)
+	}
+	auditLog.record(client, owner, repo, pr.Number, AuditMerge, method)
+	return response.SHA, nil
+}
+
+// waitForMerge waits until a PR is merged by someone else, such as Prow, and returns its merge commit
+func waitForMerge(client RESTClientInterface, owner, repo string, number int, timeout, interval time.Duration) (string, error) {
+	ctx := withFreshData(context.Background())
+	deadline := time.Now().Add(timeout)
+	prPath := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, number)
+	for {
+		var pr PullRequest
+		if err := client.DoWithContext(ctx, http.MethodGet, prPath, nil, &pr); err != nil {
+			return "", err
+		}
+		if pr.Merged && pr.MergeCommitSHA != "" {
+			return pr.MergeCommitSHA, nil
+		}
+		if pr.State == "closed" {
+			return "", fmt.Errorf("the PR was closed without being merged")
+		}
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("not merged after %s", formatAge(timeout))
+		}
+		if err := sleepContext(context.Background(), interval); err != nil {
+			return "", err
+		}
+	}
+}
+
+// waitForPostMergeChecks waits until the checks of a merge commit have all completed, returning an error if
+// any failed or they didn't complete in time. Checks can take a while to be reported after a merge, so a
+// commit without checks is waited for too.
+func waitForPostMergeChecks(client RESTClientInterface, owner, repo, sha string, timeout, interval time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		checkRuns, statusChecks, err := fetchChecks(client, owner, repo, sha)
+		if err != nil {
+			return err
+		}
+
+		var failed []string
+		pending := 0
+		for _, checkRun := range checkRuns {
+			if checkRunFailed(checkRun) {
+				failed = append(failed, checkRun.Name)
+			} else if checkRun.Status != "completed" {
+				pending++
+			}
+		}
+		for _, statusCheck := range statusChecks {
+			if statusCheckFailed(statusCheck) {
+				failed = append(failed, statusCheck.Context)
+			} else if statusCheck.State == "pending" {
+				pending++
+			}
+		}
+
+		if len(failed) > 0 {
+			return fmt.Errorf("failed checks: %s", strings.Join(failed, ", "))
+		}
+		total := len(checkRuns) + len(statusChecks)
+		if total > 0 && pending == 0 {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			if total == 0 {
+				return fmt.Errorf("no checks were reported within %s", formatAge(timeout))
+			}
+			return fmt.Errorf("%d check(s) still pending after %s", pending, formatAge(timeout))
+		}
+		if err := sleepContext(context.Background(), interval); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	canaryCmd.Flags().StringVar(&canaryTitle, "title", "", "Only consider changes whose PR title contains this text")
+	canaryCmd.Flags().StringVar(&canaryMergeMethod, "merge-method", "", "How to merge the canary PR: merge, squash or rebase (default: the repository's preferred method)")
+	canaryCmd.Flags().DurationVar(&canaryWait, "canary-wait", defaultCanaryWait, "How long to wait for the canary to be merged and for its post-merge checks")
+	canaryCmd.Flags().DurationVar(&canaryPollInterval, "poll-interval", defaultCanaryPollInterval, "How often to look at the canary's merge and post-merge checks")
+	canaryCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Merge the canary and approve the other PRs without asking for confirmation")
+	RootCmd.AddCommand(canaryCmd)
+}
@@ -0,0 +1,229 @@
+package cmd_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Canary rollout", func() {
+	const title = "chore(deps): update konflux references"
+	konfluxPR := func(number int, sha, prTitle string) cmd.PullRequest {
+		return cmd.PullRequest{Number: number, Title: prTitle, State: "open", Head: cmd.Branch{SHA: sha}, User: cmd.User{Login: "red-hat-konflux[bot]"}}
+	}
+	references := []cmd.PRFile{{Filename: ".tekton/app-push.yaml", Status: "modified", Patch: "@@ -9 +9 @@\n-  bundle: task@sha256:old\n+  bundle: task@sha256:new"}}
+
+	It("should group the same diff across repositories behind the canary, whatever the titles", func() {
+		canaryClient, appClient := cmd.NewMockRESTClient(), cmd.NewMockRESTClient()
+		canaryClient.AddResponse("repos/owner/canary/pulls/1/files", 200, references)
+		canaryClient.AddResponse("repos/owner/canary/pulls/2/files", 200, []cmd.PRFile{{Filename: "go.mod", Patch: "+\tfoo v2"}})
+		appClient.AddResponse("repos/owner/app1/pulls/5/files", 200, references)
+		appClient.AddResponse("repos/owner/app2/pulls/6/files", 200, append(references, cmd.PRFile{Filename: "main.go", Patch: "-\tcheckToken()"}))
+		appClient.AddResponse("repos/owner/app2/pulls/7/files", 200, references)
+		groups := cmd.GroupIdenticalChangesTest("owner/canary", []cmd.CanaryPRTest{
+			{RepoSpec: "owner/canary", Client: canaryClient, PR: konfluxPR(1, "c1", title)},
+			{RepoSpec: "owner/canary", Client: canaryClient, PR: konfluxPR(2, "c2", "Update dependency foo to v2")},
+			{RepoSpec: "owner/app1", Client: appClient, PR: konfluxPR(5, "a1", "Update the Konflux task bundles")},
+			{RepoSpec: "owner/app2", Client: appClient, PR: konfluxPR(6, "a2", title)},
+			{RepoSpec: "owner/app2", Client: appClient, PR: konfluxPR(7, "a3", title)},
+			{RepoSpec: "owner/app3", Client: appClient, PR: konfluxPR(8, "a4", title)},
+		})
+		Expect(groups).To(Equal(map[string][]string{
+			title: {"owner/canary#1", "owner/app1#5", "owner/app2#7"},
+		}))
+	})
+
+	It("should validate merge methods", func() {
+		Expect(cmd.ValidateMergeMethodTest("")).To(Succeed())
+		Expect(cmd.ValidateMergeMethodTest("squash")).To(Succeed())
+		Expect(cmd.ValidateMergeMethodTest("fast-forward")).To(MatchError(ContainSubstring("fast-forward")))
+	})
+
+	Describe("Choosing the merge method", func() {
+		allow := func(merge, squash, rebase bool) cmd.RepoMergeSettings {
+			return cmd.RepoMergeSettings{AllowMergeCommit: &merge, AllowSquashMerge: &squash, AllowRebaseMerge: &rebase}
+		}
+
+		It("should default to the first method the repository allows", func() {
+			Expect(cmd.ChooseMergeMethodTest(allow(true, true, true), "")).To(Equal("merge"))
+			Expect(cmd.ChooseMergeMethodTest(allow(false, true, true), "")).To(Equal("squash"))
+			Expect(cmd.ChooseMergeMethodTest(allow(false, false, true), "")).To(Equal("rebase"))
+		})
+
+		It("should refuse a method the repository doesn't allow", func() {
+			_, err := cmd.ChooseMergeMethodTest(allow(false, true, true), "merge")
+			Expect(err).To(MatchError(ContainSubstring("doesn't allow the merge merge method (allowed: squash, rebase)")))
+			Expect(cmd.ChooseMergeMethodTest(allow(false, true, true), "rebase")).To(Equal("rebase"))
+		})
+
+		It("should treat settings GitHub didn't return as allowed", func() {
+			Expect(cmd.ChooseMergeMethodTest(cmd.RepoMergeSettings{}, "rebase")).To(Equal("rebase"))
+			Expect(cmd.ChooseMergeMethodTest(cmd.RepoMergeSettings{}, "")).To(Equal("merge"))
+		})
+	})
+
+	Describe("Waiting for post-merge checks", func() {
+		var mockClient *cmd.MockRESTClient
+		const commit = "repos/owner/canary/commits/merge123"
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{"statuses": []cmd.StatusCheck{}})
+		})
+
+		It("should pass once every check completed successfully", func() {
+			mockClient.AddResponse(commit+"/check-runs", 200, cmd.CreateMockCheckRuns(2, 0, 0))
+			Expect(cmd.WaitForPostMergeChecksTest(mockClient, "owner", "canary", "merge123", time.Minute)).To(Succeed())
+		})
+
+		It("should fail as soon as a check failed", func() {
+			mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+				{Name: "app-on-push", Status: "completed", Conclusion: "failure"},
+				{Name: "other", Status: "in_progress"},
+			}})
+			Expect(cmd.WaitForPostMergeChecksTest(mockClient, "owner", "canary", "merge123", time.Minute)).To(MatchError(ContainSubstring("app-on-push")))
+		})
+
+		It("should time out on pending checks and on commits without checks", func() {
+			mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{{Name: "app-on-push", Status: "in_progress"}}})
+			Expect(cmd.WaitForPostMergeChecksTest(mockClient, "owner", "canary", "merge123", 0)).To(MatchError(ContainSubstring("1 check(s) still pending")))
+
+			mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{})
+			Expect(cmd.WaitForPostMergeChecksTest(mockClient, "owner", "canary", "merge123", 0)).To(MatchError(ContainSubstring("no checks were reported")))
+		})
+	})
+
+	Describe("Rolling out", func() {
+		var canaryClient, appClient *cmd.MockRESTClient
+		var in, out *bytes.Buffer
+		var prs []cmd.CanaryPRTest
+
+		BeforeEach(func() {
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+
+			canaryClient = cmd.NewMockRESTClient()
+			canaryClient.AddResponse("repos/owner/canary/pulls/1", 200, konfluxPR(1, "c1", title))
+			canaryClient.AddResponse("repos/owner/canary/pulls/1/files", 200, references)
+			canaryClient.AddResponse("repos/owner/canary/pulls/1/reviews", 200, nil)
+			canaryClient.AddResponse("repos/owner/canary/pulls/1/merge", 200, cmd.MergeResponse{SHA: "merge123", Merged: true})
+			canaryClient.AddResponse("repos/owner/canary/commits/merge123/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+			canaryClient.AddResponse("repos/owner/canary/commits/merge123/status", 200, map[string]interface{}{"statuses": []cmd.StatusCheck{}})
+
+			appClient = cmd.NewMockRESTClient()
+			appClient.AddResponse("/reviews", 200, nil)
+			appClient.AddResponse("repos/owner/app1/pulls/5", 200, konfluxPR(5, "a1", title))
+			for _, path := range []string{"repos/owner/app1/pulls/5", "repos/owner/app2/pulls/6", "repos/owner/app2/pulls/7"} {
+				appClient.AddResponse(path+"/files", 200, references)
+			}
+
+			held := konfluxPR(7, "a3", title)
+			held.Labels = []cmd.Label{{Name: "do-not-merge/hold"}}
+			prs = []cmd.CanaryPRTest{
+				{RepoSpec: "owner/canary", Client: canaryClient, PR: konfluxPR(1, "c1", title)},
+				{RepoSpec: "owner/app1", Client: appClient, PR: konfluxPR(5, "a1", title)},
+				{RepoSpec: "owner/app2", Client: appClient, PR: held},
+			}
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should merge the canary and approve the others once its checks pass", func() {
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "squash", time.Minute, true)).To(BeTrue())
+
+			Expect(canaryClient.GetRequestCount("pulls/1/reviews")).To(Equal(1))
+			Expect(canaryClient.GetRequestCount("pulls/1/merge")).To(Equal(1))
+			Expect(canaryClient.Requests).To(ContainElement(HaveField("Body", ContainSubstring(`"merge_method":"squash"`))))
+			Expect(appClient.GetRequestCount("repos/owner/app1/pulls/5/reviews")).To(Equal(1))
+			Expect(appClient.GetRequestCount("repos/owner/app2/pulls/7/reviews")).To(Equal(0))
+			Expect(out.String()).To(ContainSubstring("on hold"))
+		})
+
+		It("should approve each PR with the approval settings of its repository", func() {
+			canaryBody, appBody := "/lgtm canary", "/lgtm app1"
+			config := cmd.DefaultConfig()
+			config.Repositories = []cmd.RepositoryConfig{
+				{Name: "owner/canary", Approval: &cmd.ApprovalSettings{Body: &canaryBody}},
+				{Name: "owner/app1", Approval: &cmd.ApprovalSettings{Body: &appBody}},
+			}
+
+			Expect(cmd.RunCanaryWithConfigTest(config, "owner/canary", prs, "squash", time.Minute, true)).To(BeTrue())
+			Expect(canaryClient.RequestBodies("POST", "repos/owner/canary/pulls/1/reviews")).To(ConsistOf(ContainSubstring(canaryBody)))
+			Expect(appClient.RequestBodies("POST", "repos/owner/app1/pulls/5/reviews")).To(ConsistOf(ContainSubstring(appBody)))
+		})
+
+		It("should skip the PRs larger than the max_changes of their repository", func() {
+			config := cmd.DefaultConfig()
+			config.Repositories = []cmd.RepositoryConfig{{Name: "owner/app1", Approval: &cmd.ApprovalSettings{MaxChanges: 1}}}
+			prs[1].PR.Additions, prs[1].PR.Deletions, prs[1].PR.ChangedFiles = 1, 1, 1
+
+			Expect(cmd.RunCanaryWithConfigTest(config, "owner/canary", prs, "squash", time.Minute, true)).To(BeTrue())
+			Expect(canaryClient.GetRequestCount("pulls/1/reviews")).To(Equal(1))
+			Expect(appClient.GetRequestCount("repos/owner/app1/pulls/5/reviews")).To(Equal(0))
+		})
+
+		It("should check the other PRs like the canary before approving them", func() {
+			migration := konfluxPR(6, "a2", title)
+			migration.Body = "⚠️ [migration] this update needs manual steps"
+			prs = append(prs, cmd.CanaryPRTest{RepoSpec: "owner/app2", Client: appClient, PR: migration})
+			appClient.AddResponse("repos/owner/app1/pulls/5", 200, konfluxPR(5, "a9", title))
+
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "squash", time.Minute, true)).To(BeFalse())
+			Expect(appClient.GetRequestCount("repos/owner/app1/pulls/5/reviews")).To(Equal(0))
+			Expect(appClient.GetRequestCount("repos/owner/app2/pulls/6/reviews")).To(Equal(0))
+			Expect(out.String()).To(ContainSubstring("migration warning"))
+			Expect(out.String()).To(ContainSubstring("PR changed since displayed"))
+		})
+
+		It("should merge with the repository's preferred method", func() {
+			canaryClient.AddResponse("repos/owner/canary", 200, map[string]bool{"allow_merge_commit": false, "allow_squash_merge": true, "allow_rebase_merge": true})
+
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "", time.Minute, true)).To(BeTrue())
+			Expect(canaryClient.Requests).To(ContainElement(HaveField("Body", ContainSubstring(`"merge_method":"squash"`))))
+			Expect(out.String()).To(ContainSubstring("owner/canary allows squash and rebase merges, using squash"))
+		})
+
+		It("should approve nothing when the repository doesn't allow the merge method", func() {
+			canaryClient.AddResponse("repos/owner/canary", 200, map[string]bool{"allow_merge_commit": false, "allow_squash_merge": true, "allow_rebase_merge": false})
+
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "rebase", time.Minute, true)).To(BeFalse())
+			Expect(canaryClient.GetRequestCount("/reviews")).To(Equal(0))
+			Expect(canaryClient.GetRequestCount("/merge")).To(Equal(0))
+			Expect(out.String()).To(ContainSubstring("doesn't allow the rebase merge method (allowed: squash)"))
+		})
+
+		It("should leave the other repositories alone when the canary fails after merging", func() {
+			canaryClient.AddResponse("repos/owner/canary/commits/merge123/check-runs", 200, cmd.CreateMockCheckRuns(0, 1, 0))
+
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "", time.Minute, true)).To(BeFalse())
+			Expect(appClient.GetRequestCount("/reviews")).To(Equal(0))
+			Expect(out.String()).To(ContainSubstring("the other repositories are left alone"))
+		})
+
+		It("should wait for Prow to merge a Prow-managed canary instead of merging it", func() {
+			prowPR := konfluxPR(1, "c1", title)
+			prowPR.Labels = []cmd.Label{{Name: "ok-to-test"}}
+			prs[0].PR = prowPR
+			merged := prowPR
+			merged.State, merged.Merged, merged.MergeCommitSHA = "closed", true, "merge123"
+			canaryClient.AddResponse("repos/owner/canary/pulls/1", 200, merged)
+
+			// The PR is merged by the time the approval is checked, which must not block it
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "", time.Minute, true)).To(BeFalse())
+			Expect(canaryClient.GetRequestCount("pulls/1/merge")).To(Equal(0))
+		})
+
+		It("should change nothing unless the canary is confirmed", func() {
+			in.WriteString("n\n")
+			Expect(cmd.RunCanaryTest("owner/canary", prs, "", time.Minute, false)).To(BeFalse())
+			Expect(canaryClient.GetRequestCount("/reviews")).To(Equal(0))
+			Expect(canaryClient.GetRequestCount("/merge")).To(Equal(0))
+		})
+	})
+})
@@ -0,0 +1,674 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/pkg/ghprs"
+)
+
+// defaultStaleCheckAfter is how long a check may stay pending before it is considered stuck
+const defaultStaleCheckAfter = time.Hour
+
+// staleCheckAfter is how long a check may stay pending before it is reported as stale and can be re-triggered
+var staleCheckAfter = defaultStaleCheckAfter
+
+// defaultLogLines is how many lines at the end of a failed job's log are shown
+const defaultLogLines = 30
+
+// githubActionsApp is the slug of the GitHub app that reports GitHub Actions jobs as check runs
+const githubActionsApp = "github-actions"
+
+// defaultRetestComment is posted to re-run the failed checks of Prow-managed repositories unless configured otherwise
+const defaultRetestComment = "/retest"
+
+var (
+	showLogs       bool
+	logLines       int
+	retestComments []string
+)
+
+// checksCmd lists the checks of a PR and optionally the end of the logs of its failed GitHub Actions jobs
+var checksCmd = &cobra.Command{
+	Use:   "checks [owner/repo] <number>",
+	Short: "Show the checks of a pull request",
+	Long: `Show every check run and status check of a pull request with its result, how long it took
+(or has been pending) and where to find it.
+
+With --logs the end of the log of every failed GitHub Actions job is shown too, so a red PR can
+be diagnosed without the browser. Checks reported by other systems (such as Prow or Konflux) only
+link to their own pages.
+
+When the branch protection of the target branch requires checks, they are listed with their state
+too, so it is clear which required checks a blocked PR still waits on.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+Exits with status 1 when a check failed.
+
+Examples:
+  ghprs checks 123
+  ghprs checks owner/repo 123
+  ghprs checks owner/repo 123 --logs
+  ghprs checks owner/repo 123 --logs --log-lines 100`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+
+		var failed int
+		paged(func() { failed, err = showChecks(client, owner, repo, *pr, showLogs, logLines) })
+		if err != nil {
+			log.Fatalf("Failed to fetch the checks of PR #%d: %v", number, err)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// rerunChecksCmd re-runs the failed checks of a PR
+var rerunChecksCmd = &cobra.Command{
+	Use:   "rerun-checks [owner/repo] <number>",
+	Short: "Re-run the failed checks of a pull request",
+	Long: `Re-run the failed checks of a pull request, which usually failed because of flaky infrastructure.
+
+Failed check runs are re-requested through the GitHub checks API. Failed status checks can't be
+re-requested that way; for repositories managed by Prow the configured retest comments (default
+/retest, see 'ghprs config set retest-comments') are posted instead.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs rerun-checks 123
+  ghprs rerun-checks owner/repo 123
+  ghprs rerun-checks owner/repo 123 --comment /ok-to-test --comment /retest`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+
+		comments := retestComments
+		if len(comments) == 0 {
+			config, err := LoadConfig()
+			if err != nil {
+				config = DefaultConfig()
+			}
+			comments = config.RetestComments()
+		}
+
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+		if !reportRerunChecks(client, owner, repo, *pr, comments) {
+			os.Exit(1)
+		}
+	},
+}
+
+// rerunResult describes what was done to re-run the failed checks of a PR
+type rerunResult struct {
+	// Rerequested is the number of failed check runs re-requested
+	Rerequested int
+	// Comments are the retest comments posted for failed status checks
+	Comments []string
+	// FailedStatuses is the number of failed status checks
+	FailedStatuses int
+	// Errors describe the check runs and comments that couldn't be re-run or posted
+	Errors []string
+}
+
+// rerunFailedChecks re-requests the failed check runs of a PR and, when it is managed by Prow and has failed
+// status checks, posts the retest comments. It only returns an error when the checks can't be fetched.
+func rerunFailedChecks(client RESTClientInterface, owner, repo string, pr PullRequest, comments []string) (rerunResult, error) {
+	var result rerunResult
+	checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+	if err != nil {
+		return result, err
+	}
+
+	var rerequested []string
+	for _, checkRun := range checkRuns {
+		if !checkRunFailed(checkRun) {
+			continue
+		}
+		rerequestPath := fmt.Sprintf("repos/%s/%s/check-runs/%d/rerequest", owner, repo, checkRun.ID)
+		if err := client.Post(rerequestPath, nil, nil); err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("failed to re-request %s: %v", checkRun.Name, err))
+			continue
+		}
+		result.Rerequested++
+		rerequested = append(rerequested, checkRun.Name)
+	}
+	if len(rerequested) > 0 {
+		auditLog.record(client, owner, repo, pr.Number, AuditRerunChecks, strings.Join(rerequested, ","))
+	}
+
+	for _, statusCheck := range statusChecks {
+		if statusCheckFailed(statusCheck) {
+			result.FailedStatuses++
+		}
+	}
+	if result.FailedStatuses > 0 && isProwManaged(pr) {
+		for _, comment := range comments {
+			if err := addCommentToPR(client, owner, repo, pr.Number, comment); err != nil {
+				result.Errors = append(result.Errors, fmt.Sprintf("failed to post %s: %v", comment, err))
+				continue
+			}
+			result.Comments = append(result.Comments, comment)
+		}
+	}
+	return result, nil
+}
+
+// reportRerunChecks re-runs the failed checks of a PR and tells the user what happened.
+// It reports whether everything that failed could be re-run.
+func reportRerunChecks(client RESTClientInterface, owner, repo string, pr PullRequest, comments []string) bool {
+	link := formatPRLink(owner, repo, pr.Number)
+	result, err := rerunFailedChecks(client, owner, repo, pr, comments)
+	if err != nil {
+		streams.Printf("❌ Failed to re-run checks of PR %s: %v\n", link, err)
+		return false
+	}
+
+	for _, message := range result.Errors {
+		streams.Printf("❌ PR %s: %s\n", link, message)
+	}
+	if result.Rerequested > 0 {
+		streams.Printf("🔁 Re-requested %d failed check run(s) of PR %s\n", result.Rerequested, link)
+	}
+	if len(result.Comments) > 0 {
+		streams.Printf("💬 Posted %s on PR %s\n", strings.Join(result.Comments, ", "), link)
+	}
+	if result.FailedStatuses > 0 && len(result.Comments) == 0 && len(result.Errors) == 0 {
+		streams.Printf("⚠️  %d failed status check(s) of PR %s come from an external CI and can't be re-run here\n", result.FailedStatuses, link)
+	}
+	if result.Rerequested == 0 && result.FailedStatuses == 0 && len(result.Errors) == 0 {
+		streams.Printf("✅ No checks of PR %s have failed\n", link)
+	}
+	return len(result.Errors) == 0
+}
+
+// checkRunState returns the icon and a description of the state of a check run
+func checkRunState(checkRun CheckRun) (string, string) {
+	switch checkRun.Status {
+	case "completed":
+		switch checkRun.Conclusion {
+		case "success":
+			return "✅", "passed"
+		case "failure", "timed_out", "action_required":
+			return "❌", fmt.Sprintf("failed (%s)", checkRun.Conclusion)
+		case "cancelled":
+			return "⚫", "cancelled"
+		case "skipped", "neutral", "stale":
+			return "⚪", fmt.Sprintf("skipped (%s)", checkRun.Conclusion)
+		default:
+			return "❓", checkRun.Conclusion
+		}
+	case "queued":
+		return "🟡", "queued"
+	case "in_progress":
+		return "🟡", "running"
+	default:
+		return "❓", checkRun.Status
+	}
+}
+
+// statusCheckIcon returns the icon for the state of a status check
+func statusCheckIcon(state string) string {
+	switch state {
+	case "success":
+		return "✅"
+	case "failure", "error":
+		return "❌"
+	case "pending":
+		return "🟡"
+	default:
+		return "❓"
+	}
+}
+
+// checkRunFailed reports whether a check run completed unsuccessfully
+func checkRunFailed(checkRun CheckRun) bool {
+	return ghprs.CheckRunFailed(checkRun)
+}
+
+// statusCheckFailed reports whether a status check failed or errored
+func statusCheckFailed(statusCheck StatusCheck) bool {
+	return ghprs.StatusCheckFailed(statusCheck)
+}
+
+// pendingAge returns how long a check has been pending, or false if it isn't pending or the start time is unknown
+func pendingAge(pending bool, since *time.Time, now time.Time) (time.Duration, bool) {
+	if !pending || since == nil || since.IsZero() {
+		return 0, false
+	}
+	return now.Sub(*since), true
+}
+
+// checkRunPendingAge returns how long a queued or running check run has been pending
+func checkRunPendingAge(checkRun CheckRun, now time.Time) (time.Duration, bool) {
+	return pendingAge(checkRun.Status != "completed", checkRun.StartedAt, now)
+}
+
+// statusCheckPendingAge returns how long a pending status check has been pending
+func statusCheckPendingAge(statusCheck StatusCheck, now time.Time) (time.Duration, bool) {
+	return pendingAge(statusCheck.State == "pending", statusCheck.CreatedAt, now)
+}
+
+// isStale reports whether a check pending for age is probably stuck
+func isStale(age time.Duration) bool {
+	return staleCheckAfter > 0 && age > staleCheckAfter
+}
+
+// formatAge renders a duration compactly, e.g. "45m", "3h12m" or "2d5h"
+func formatAge(age time.Duration) string {
+	switch {
+	case age < time.Minute:
+		return "<1m"
+	case age < time.Hour:
+		return fmt.Sprintf("%dm", int(age.Minutes()))
+	case age < 24*time.Hour:
+		return fmt.Sprintf("%dh%dm", int(age.Hours()), int(age.Minutes())%60)
+	default:
+		return fmt.Sprintf("%dd%dh", int(age.Hours())/24, int(age.Hours())%24)
+	}
+}
+
+// pendingDescription describes how long a check has been pending, flagging it when it is stale
+func pendingDescription(age time.Duration) string {
+	if isStale(age) {
+		return fmt.Sprintf(", pending for %s ⏰ stale", formatAge(age))
+	}
+	return fmt.Sprintf(", pending for %s", formatAge(age))
+}
+
+// staleCheckSuites returns the check suites that have a check run pending for longer than the stale threshold
+func staleCheckSuites(checkRuns []CheckRun, now time.Time) []int64 {
+	var suites []int64
+	seen := make(map[int64]bool)
+	for _, checkRun := range checkRuns {
+		age, pending := checkRunPendingAge(checkRun, now)
+		if !pending || !isStale(age) || checkRun.CheckSuite == nil || seen[checkRun.CheckSuite.ID] {
+			continue
+		}
+		seen[checkRun.CheckSuite.ID] = true
+		suites = append(suites, checkRun.CheckSuite.ID)
+	}
+	return suites
+}
+
+// retriggerStaleChecks asks GitHub to rerun the check suites with stale pending check runs on headSHA.
+// It returns the number of check suites re-requested and the number of stale legacy status checks,
+// which are reported by external systems and can't be re-triggered through the API.
+func retriggerStaleChecks(client RESTClientInterface, owner, repo, headSHA string) (int, int, error) {
+	// Always look at the current state of the checks, not what was shown earlier
+	ctx := withFreshData(context.Background())
+	now := time.Now()
+
+	var checkRunsResp CheckRunsResponse
+	checkRunsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, headSHA)
+	if err := client.DoWithContext(ctx, http.MethodGet, checkRunsPath, nil, &checkRunsResp); err != nil {
+		return 0, 0, fmt.Errorf("failed to fetch check runs: %v", err)
+	}
+
+	staleStatuses := 0
+	var statusResp struct {
+		Statuses []StatusCheck `json:"statuses"`
+	}
+	statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status", owner, repo, headSHA)
+	if err := client.DoWithContext(ctx, http.MethodGet, statusPath, nil, &statusResp); err == nil {
+		for _, statusCheck := range statusResp.Statuses {
+			if age, pending := statusCheckPendingAge(statusCheck, now); pending && isStale(age) {
+				staleStatuses++
+			}
+		}
+	}
+
+	suites := staleCheckSuites(checkRunsResp.CheckRuns, now)
+	for i, suiteID := range suites {
+		rerequestPath := fmt.Sprintf("repos/%s/%s/check-suites/%d/rerequest", owner, repo, suiteID)
+		if err := client.Post(rerequestPath, nil, nil); err != nil {
+			return i, staleStatuses, fmt.Errorf("failed to re-request check suite %d: %v", suiteID, err)
+		}
+	}
+	return len(suites), staleStatuses, nil
+}
+
+// reportRetriggeredChecks re-triggers the stale checks of a PR and tells the user what happened
+func reportRetriggeredChecks(client RESTClientInterface, owner, repo string, pr PullRequest) {
+	link := formatPRLink(owner, repo, pr.Number)
+	suites, staleStatuses, err := retriggerStaleChecks(client, owner, repo, pr.Head.SHA)
+	if err != nil {
+		streams.Printf("❌ Failed to re-trigger checks of PR %s: %v\n", link, err)
+	}
+	if suites > 0 {
+		auditLog.record(client, owner, repo, pr.Number, AuditRerunChecks, fmt.Sprintf("%d stale check suite(s)", suites))
+		streams.Printf("🔁 Re-requested %d check suite(s) of PR %s\n", suites, link)
+	} else if err == nil && staleStatuses == 0 {
+		streams.Printf("✅ No checks of PR %s have been pending for over %s\n", link, formatAge(staleCheckAfter))
+	}
+	if staleStatuses > 0 {
+		streams.Printf("⚠️  %d stale status check(s) of PR %s come from an external CI and can't be re-triggered here (for Prow, comment /retest or /test <job>)\n",
+			staleStatuses, link)
+	}
+}
+
+// checkRunTiming describes how long a completed check run took, or how long a pending one has been pending
+func checkRunTiming(checkRun CheckRun, now time.Time) string {
+	if age, pending := checkRunPendingAge(checkRun, now); pending {
+		return pendingDescription(age)
+	}
+	if checkRun.StartedAt != nil && checkRun.CompletedAt != nil && !checkRun.StartedAt.IsZero() {
+		return ", took " + formatAge(checkRun.CompletedAt.Sub(*checkRun.StartedAt))
+	}
+	return ""
+}
+
+// fetchChecks fetches the check runs and status checks of a commit
+func fetchChecks(client RESTClientInterface, owner, repo, headSHA string) ([]CheckRun, []StatusCheck, error) {
+	// Checks change while a PR is looked at, so never show cached results
+	return ghprs.FetchChecks(withFreshData(context.Background()), client, owner, repo, headSHA)
+}
+
+// showChecks lists the checks of a PR with their timing and links and, with withLogs, the last lines of the
+// log of every failed GitHub Actions job. It returns the number of failed checks.
+func showChecks(client RESTClientInterface, owner, repo string, pr PullRequest, withLogs bool, lines int) (int, error) {
+	checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+	if err != nil {
+		return 0, err
+	}
+
+	streams.Printf("🔍 Checks of PR %s: %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
+	if len(checkRuns) == 0 && len(statusChecks) == 0 {
+		streams.Printf("   ✅ No checks configured\n")
+		return 0, nil
+	}
+
+	now := time.Now()
+	failed := 0
+	var failedRuns []CheckRun
+	if len(checkRuns) > 0 {
+		streams.Printf("\n📋 Check Runs:\n")
+		for _, checkRun := range checkRuns {
+			icon, status := checkRunState(checkRun)
+			streams.Printf("   %s %s: %s%s\n", icon, checkRun.Name, status, checkRunTiming(checkRun, now))
+			if checkRun.HTMLURL != "" {
+				streams.Printf("      %s\n", checkRun.HTMLURL)
+			}
+			if checkRunFailed(checkRun) {
+				failed++
+				failedRuns = append(failedRuns, checkRun)
+			}
+		}
+	}
+
+	if len(statusChecks) > 0 {
+		streams.Printf("\n📋 Status Checks:\n")
+		for _, statusCheck := range statusChecks {
+			description := statusCheck.Description
+			if description == "" {
+				description = statusCheck.State
+			}
+			if age, pending := statusCheckPendingAge(statusCheck, now); pending {
+				description += pendingDescription(age)
+			}
+			streams.Printf("   %s %s: %s\n", statusCheckIcon(statusCheck.State), statusCheck.Context, description)
+			if statusCheck.TargetURL != "" {
+				streams.Printf("      %s\n", statusCheck.TargetURL)
+			}
+			if statusCheckFailed(statusCheck) {
+				failed++
+			}
+		}
+	}
+
+	displayRequiredChecks(client, owner, repo, pr, checkRuns, statusChecks)
+
+	if withLogs {
+		for _, checkRun := range failedRuns {
+			showJobLog(client, owner, repo, checkRun, lines)
+		}
+	}
+	return failed, nil
+}
+
+// showJobLog shows the last lines of the log of a failed check run, when it is a GitHub Actions job
+func showJobLog(client RESTClientInterface, owner, repo string, checkRun CheckRun, lines int) {
+	streams.Printf("\n📜 %s:\n", checkRun.Name)
+	if checkRun.App == nil || checkRun.App.Slug != githubActionsApp {
+		streams.Printf("   Logs are only available for GitHub Actions jobs, see %s\n", checkRun.HTMLURL)
+		return
+	}
+
+	logTail, err := fetchJobLogTail(client, owner, repo, checkRun.ID, lines)
+	if err != nil {
+		streams.Printf("   ⚠️  Could not fetch the log: %v\n", err)
+		return
+	}
+	for _, line := range logTail {
+		streams.Printf("   %s\n", line)
+	}
+}
+
+// fetchJobLogTail fetches the log of a GitHub Actions job (the ID of its check run) and returns its last
+// lines, without the timestamp GitHub puts in front of every line
+func fetchJobLogTail(client RESTClientInterface, owner, repo string, jobID int64, lines int) ([]string, error) {
+	logPath := fmt.Sprintf("repos/%s/%s/actions/jobs/%d/logs", owner, repo, jobID)
+	resp, err := client.Request(http.MethodGet, logPath, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return nil, fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the log: %v", err)
+	}
+	logLines := strings.Split(strings.TrimRight(string(content), "\r\n"), "\n")
+	if lines > 0 && len(logLines) > lines {
+		logLines = logLines[len(logLines)-lines:]
+	}
+	for i, line := range logLines {
+		logLines[i] = stripLogTimestamp(strings.TrimRight(line, "\r"))
+	}
+	return logLines, nil
+}
+
+// stripLogTimestamp removes the RFC 3339 timestamp GitHub Actions puts in front of every log line
+func stripLogTimestamp(line string) string {
+	timestamp, rest, ok := strings.Cut(line, " ")
+	if !ok {
+		return line
+	}
+	if _, err := time.Parse(time.RFC3339Nano, timestamp); err != nil {
+		return line
+	}
+	return rest
+}
+
+func init() {
+	checksCmd.Flags().BoolVar(&showLogs, "logs", false, "Show the end of the log of every failed GitHub Actions job")
+	checksCmd.Flags().IntVar(&logLines, "log-lines", defaultLogLines, "Number of log lines to show per failed job with --logs (0 for the whole log)")
+	RootCmd.AddCommand(checksCmd)
+
+	rerunChecksCmd.Flags().StringArrayVar(&retestComments, "comment", nil, "Comment to post for failed status checks of Prow repositories, instead of the configured ones (repeatable)")
+	RootCmd.AddCommand(rerunChecksCmd)
+}
+
+// checkEntry is one check run or status check of the detailed check view
+type checkEntry struct {
+	Icon string
+	// Text is the name of the check and its state
+	Text    string
+	Failed  bool
+	Pending bool
+	Passed  bool
+}
+
+// checkGroup is the checks reported by one GitHub app, or the status checks sharing a context prefix
+type checkGroup struct {
+	Name    string
+	Entries []checkEntry
+}
+
+// checkRunGroup names the group of a check run: the app that reported it, else its check suite
+func checkRunGroup(checkRun CheckRun) string {
+	switch {
+	case checkRun.App != nil && checkRun.App.Slug != "":
+		return checkRun.App.Slug
+	case checkRun.CheckSuite != nil:
+		return fmt.Sprintf("suite %d", checkRun.CheckSuite.ID)
+	default:
+		return "other"
+	}
+}
+
+// statusCheckGroup names the group of a status check: its context up to the last slash, e.g. ci/prow for
+// ci/prow/unit, else the context itself
+func statusCheckGroup(statusCheck StatusCheck) string {
+	if i := strings.LastIndex(statusCheck.Context, "/"); i > 0 {
+		return statusCheck.Context[:i]
+	}
+	return statusCheck.Context
+}
+
+// addCheckEntry adds entry to the group named name, creating the group after the others when it is new
+func addCheckEntry(groups []checkGroup, name string, entry checkEntry) []checkGroup {
+	for i := range groups {
+		if groups[i].Name == name {
+			groups[i].Entries = append(groups[i].Entries, entry)
+			return groups
+		}
+	}
+	return append(groups, checkGroup{Name: name, Entries: []checkEntry{entry}})
+}
+
+// groupCheckRuns groups check runs by the app that reported them, in the order the apps first appear
+func groupCheckRuns(checkRuns []CheckRun, now time.Time) []checkGroup {
+	var groups []checkGroup
+	for _, checkRun := range checkRuns {
+		icon, status := checkRunState(checkRun)
+		age, pending := checkRunPendingAge(checkRun, now)
+		if pending {
+			status += pendingDescription(age)
+		}
+		groups = addCheckEntry(groups, checkRunGroup(checkRun), checkEntry{
+			Icon:    icon,
+			Text:    fmt.Sprintf("%s: %s", checkRun.Name, status),
+			Failed:  checkRunFailed(checkRun),
+			Pending: checkRun.Status != "completed",
+			Passed:  checkRun.Status == "completed" && checkRun.Conclusion == "success",
+		})
+	}
+	return groups
+}
+
+// groupStatusChecks groups status checks by their context prefix, in the order the prefixes first appear
+func groupStatusChecks(statusChecks []StatusCheck, now time.Time) []checkGroup {
+	var groups []checkGroup
+	for _, statusCheck := range statusChecks {
+		description := statusCheck.Description
+		if description == "" {
+			description = statusCheck.State
+		}
+		if age, pending := statusCheckPendingAge(statusCheck, now); pending {
+			description += pendingDescription(age)
+		}
+		groups = addCheckEntry(groups, statusCheckGroup(statusCheck), checkEntry{
+			Icon:    statusCheckIcon(statusCheck.State),
+			Text:    fmt.Sprintf("%s: %s", statusCheck.Context, description),
+			Failed:  statusCheckFailed(statusCheck),
+			Pending: statusCheck.State == "pending",
+			Passed:  statusCheck.State == "success",
+		})
+	}
+	return groups
+}
+
+// rollup returns the icon of a group, the worst state of its checks, and counts its checks by state,
+// e.g. "3 passed, 1 failed"
+func (g checkGroup) rollup() (string, string) {
+	var passed, failed, pending, other int
+	for _, entry := range g.Entries {
+		switch {
+		case entry.Failed:
+			failed++
+		case entry.Pending:
+			pending++
+		case entry.Passed:
+			passed++
+		default:
+			other++
+		}
+	}
+
+	var counts []string
+	for _, count := range []struct {
+		n     int
+		label string
+	}{{passed, "passed"}, {failed, "failed"}, {pending, "pending"}, {other, "other"}} {
+		if count.n > 0 {
+			counts = append(counts, fmt.Sprintf("%d %s", count.n, count.label))
+		}
+	}
+	icon := "✅"
+	switch {
+	case failed > 0:
+		icon = "❌"
+	case pending > 0:
+		icon = "🟡"
+	case passed == 0:
+		icon = "⚪"
+	}
+	return icon, strings.Join(counts, ", ")
+}
+
+// collapsed reports whether a group is shown as its rollup only: every check passed or was skipped, and the
+// groups that passed aren't expanded
+func (g checkGroup) collapsed(expand bool) bool {
+	for _, entry := range g.Entries {
+		if entry.Failed || entry.Pending {
+			return false
+		}
+	}
+	return !expand
+}
+
+// displayCheckGroups shows each group with its rollup, listing the checks of the groups that aren't collapsed,
+// every group with expand (--expand-checks). It returns how many groups were collapsed.
+func displayCheckGroups(groups []checkGroup, expand bool) int {
+	collapsedGroups := 0
+	for _, group := range groups {
+		icon, counts := group.rollup()
+		streams.Printf("   %s %s (%d): %s\n", icon, group.Name, len(group.Entries), counts)
+		if group.collapsed(expand) {
+			collapsedGroups++
+			continue
+		}
+		for _, entry := range group.Entries {
+			streams.Printf("      %s %s\n", entry.Icon, entry.Text)
+		}
+	}
+	return collapsedGroups
+}
@@ -0,0 +1,280 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Stale checks", func() {
+	now := time.Now()
+	ago := func(d time.Duration) *time.Time {
+		t := now.Add(-d)
+		return &t
+	}
+
+	AfterEach(func() {
+		cmd.SetStaleCheckAfterTest(time.Hour)
+	})
+
+	It("should format ages compactly", func() {
+		Expect(cmd.FormatAgeTest(30 * time.Second)).To(Equal("<1m"))
+		Expect(cmd.FormatAgeTest(45 * time.Minute)).To(Equal("45m"))
+		Expect(cmd.FormatAgeTest(3*time.Hour + 12*time.Minute)).To(Equal("3h12m"))
+		Expect(cmd.FormatAgeTest(53 * time.Hour)).To(Equal("2d5h"))
+	})
+
+	It("should find each check suite with a run pending for too long once", func() {
+		checkRuns := []cmd.CheckRun{
+			{Name: "stuck", Status: "queued", StartedAt: ago(3 * time.Hour), CheckSuite: &cmd.CheckSuiteRef{ID: 10}},
+			{Name: "stuck-too", Status: "in_progress", StartedAt: ago(2 * time.Hour), CheckSuite: &cmd.CheckSuiteRef{ID: 10}},
+			{Name: "recent", Status: "in_progress", StartedAt: ago(5 * time.Minute), CheckSuite: &cmd.CheckSuiteRef{ID: 11}},
+			{Name: "done", Status: "completed", Conclusion: "success", StartedAt: ago(5 * time.Hour), CheckSuite: &cmd.CheckSuiteRef{ID: 12}},
+			{Name: "unknown-start", Status: "queued", CheckSuite: &cmd.CheckSuiteRef{ID: 13}},
+		}
+		Expect(cmd.StaleCheckSuitesTest(checkRuns, now)).To(Equal([]int64{10}))
+	})
+
+	It("should never report stale checks when the threshold is 0", func() {
+		cmd.SetStaleCheckAfterTest(0)
+		checkRuns := []cmd.CheckRun{{Status: "queued", StartedAt: ago(48 * time.Hour), CheckSuite: &cmd.CheckSuiteRef{ID: 10}}}
+		Expect(cmd.StaleCheckSuitesTest(checkRuns, now)).To(BeEmpty())
+	})
+
+	Describe("with the API", func() {
+		var mockClient *cmd.MockRESTClient
+		const commit = "repos/owner/repo/commits/abc123"
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+				{Name: "stuck", Status: "queued", StartedAt: ago(3 * time.Hour), CheckSuite: &cmd.CheckSuiteRef{ID: 10}},
+				{Name: "fine", Status: "completed", Conclusion: "success", CheckSuite: &cmd.CheckSuiteRef{ID: 11}},
+			}})
+			mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{
+				"statuses": []cmd.StatusCheck{
+					{Context: "ci/prow/e2e", State: "pending", CreatedAt: ago(4 * time.Hour)},
+					{Context: "ci/prow/unit", State: "pending", CreatedAt: ago(time.Minute)},
+				},
+			})
+		})
+
+		It("should count stale checks in the check status", func() {
+			status, err := cmd.GetCheckStatusTest(mockClient, "owner", "repo", 1, "abc123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(status.Pending).To(Equal(3))
+			Expect(status.Stale).To(Equal(2))
+		})
+
+		It("should re-request the check suites of stale check runs", func() {
+			mockClient.AddResponse("repos/owner/repo/check-suites/10/rerequest", 201, nil)
+
+			suites, staleStatuses, err := cmd.RetriggerStaleChecksTest(mockClient, "owner", "repo", "abc123")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(suites).To(Equal(1))
+			Expect(staleStatuses).To(Equal(1))
+			Expect(mockClient.GetRequestCount("check-suites/10/rerequest")).To(Equal(1))
+			Expect(mockClient.GetLastRequest().Method).To(Equal("POST"))
+		})
+
+		It("should report failures to re-request a check suite", func() {
+			mockClient.AddErrorResponse("repos/owner/repo/check-suites/10/rerequest", fmt.Errorf("HTTP 403"))
+
+			suites, _, err := cmd.RetriggerStaleChecksTest(mockClient, "owner", "repo", "abc123")
+			Expect(err).To(MatchError(ContainSubstring("check suite 10")))
+			Expect(suites).To(Equal(0))
+		})
+	})
+})
+
+var _ = Describe("Checks command", func() {
+	var mockClient *cmd.MockRESTClient
+	var out *bytes.Buffer
+	const commit = "repos/owner/repo/commits/abc123"
+	pr := cmd.PullRequest{Number: 7, Title: "Fix the build", Head: cmd.Branch{SHA: "abc123"}}
+	started := time.Now().Add(-10 * time.Minute)
+	completed := started.Add(3*time.Minute + 20*time.Second)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+
+		mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+			{ID: 101, Name: "unit", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/owner/repo/actions/runs/1/job/101",
+				StartedAt: &started, CompletedAt: &completed, App: &cmd.CheckApp{Slug: "github-actions"}},
+			{ID: 102, Name: "lint", Status: "completed", Conclusion: "success", StartedAt: &started, CompletedAt: &completed,
+				App: &cmd.CheckApp{Slug: "github-actions"}},
+			{ID: 103, Name: "konflux", Status: "completed", Conclusion: "failure", HTMLURL: "https://konflux.example/pipelinerun/1",
+				App: &cmd.CheckApp{Slug: "red-hat-konflux"}},
+		}})
+		mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{
+			"statuses": []cmd.StatusCheck{{Context: "ci/prow/e2e", State: "error", Description: "Job failed", TargetURL: "https://prow.example/e2e"}},
+		})
+		mockClient.AddResponse("repos/owner/repo/actions/jobs/101/logs", 200,
+			[]byte("2025-01-02T03:04:05.1234567Z line 1\r\n2025-01-02T03:04:06.1234567Z line 2\r\n2025-01-02T03:04:07.1234567Z FAIL: TestThing\r\n"))
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should list check runs and status checks with their timing and links", func() {
+		failed, err := cmd.ShowChecksTest(mockClient, "owner", "repo", pr, false, 30)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(Equal(3))
+		Expect(out.String()).To(ContainSubstring("❌ unit: failed (failure), took 3m"))
+		Expect(out.String()).To(ContainSubstring("https://github.com/owner/repo/actions/runs/1/job/101"))
+		Expect(out.String()).To(ContainSubstring("✅ lint: passed"))
+		Expect(out.String()).To(ContainSubstring("❌ ci/prow/e2e: Job failed"))
+		Expect(out.String()).To(ContainSubstring("https://prow.example/e2e"))
+		Expect(out.String()).NotTo(ContainSubstring("FAIL: TestThing"))
+		Expect(mockClient.GetRequestCount("/logs")).To(Equal(0))
+	})
+
+	It("should show the end of the logs of failed GitHub Actions jobs only", func() {
+		_, err := cmd.ShowChecksTest(mockClient, "owner", "repo", pr, true, 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(ContainSubstring("   line 2\n   FAIL: TestThing\n"))
+		Expect(out.String()).NotTo(ContainSubstring("line 1"))
+		Expect(out.String()).To(ContainSubstring("only available for GitHub Actions jobs, see https://konflux.example/pipelinerun/1"))
+		Expect(mockClient.GetRequestCount("/logs")).To(Equal(1))
+	})
+
+	It("should keep lines without a timestamp as they are", func() {
+		mockClient.AddResponse("repos/owner/repo/actions/jobs/5/logs", 200, []byte("plain line\nanother line"))
+		lines, err := cmd.FetchJobLogTailTest(mockClient, "owner", "repo", 5, 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(lines).To(Equal([]string{"plain line", "another line"}))
+	})
+
+	It("should report logs that can't be fetched", func() {
+		mockClient.AddResponse("repos/owner/repo/actions/jobs/101/logs", 410, []byte("gone"))
+		_, err := cmd.ShowChecksTest(mockClient, "owner", "repo", pr, true, 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(out.String()).To(ContainSubstring("Could not fetch the log: HTTP 410"))
+	})
+})
+
+var _ = Describe("Re-running failed checks", func() {
+	var mockClient *cmd.MockRESTClient
+	var out *bytes.Buffer
+	const commit = "repos/owner/repo/commits/abc123"
+	pr := cmd.PullRequest{Number: 7, Head: cmd.Branch{SHA: "abc123"}}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+
+		mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+			{ID: 101, Name: "unit", Status: "completed", Conclusion: "failure"},
+			{ID: 102, Name: "lint", Status: "completed", Conclusion: "success"},
+			{ID: 103, Name: "e2e", Status: "completed", Conclusion: "timed_out"},
+		}})
+		mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{
+			"statuses": []cmd.StatusCheck{{Context: "ci/prow/images", State: "failure"}},
+		})
+		mockClient.AddResponse("/rerequest", 201, nil)
+		mockClient.AddResponse("repos/owner/repo/issues/7/comments", 201, nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should re-request only the failed check runs", func() {
+		Expect(cmd.ReportRerunChecksTest(mockClient, "owner", "repo", pr, []string{"/retest"})).To(BeTrue())
+		Expect(mockClient.GetRequestCount("check-runs/101/rerequest")).To(Equal(1))
+		Expect(mockClient.GetRequestCount("check-runs/103/rerequest")).To(Equal(1))
+		Expect(mockClient.GetRequestCount("check-runs/102/rerequest")).To(Equal(0))
+		Expect(out.String()).To(ContainSubstring("Re-requested 2 failed check run(s)"))
+	})
+
+	It("should only comment on Prow-managed PRs", func() {
+		Expect(cmd.ReportRerunChecksTest(mockClient, "owner", "repo", pr, []string{"/retest"})).To(BeTrue())
+		Expect(mockClient.GetRequestCount("issues/7/comments")).To(Equal(0))
+		Expect(out.String()).To(ContainSubstring("1 failed status check(s)"))
+	})
+
+	It("should post the retest comments for failed status checks of Prow-managed PRs", func() {
+		prowPR := pr
+		prowPR.Labels = []cmd.Label{{Name: "ok-to-test"}}
+		Expect(cmd.ReportRerunChecksTest(mockClient, "owner", "repo", prowPR, []string{"/ok-to-test", "/retest"})).To(BeTrue())
+		Expect(mockClient.GetRequestCount("issues/7/comments")).To(Equal(2))
+		Expect(mockClient.GetLastRequest().Body).To(ContainSubstring("/retest"))
+		Expect(out.String()).To(ContainSubstring("Posted /ok-to-test, /retest"))
+	})
+
+	It("should report check runs that can't be re-requested", func() {
+		mockClient.AddErrorResponse("check-runs/101/rerequest", fmt.Errorf("HTTP 403"))
+		Expect(cmd.ReportRerunChecksTest(mockClient, "owner", "repo", pr, nil)).To(BeFalse())
+		Expect(out.String()).To(ContainSubstring("failed to re-request unit: HTTP 403"))
+		Expect(out.String()).To(ContainSubstring("Re-requested 1 failed check run(s)"))
+	})
+
+	It("should say when nothing failed", func() {
+		mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{})
+		mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{"statuses": []cmd.StatusCheck{}})
+		Expect(cmd.ReportRerunChecksTest(mockClient, "owner", "repo", pr, nil)).To(BeTrue())
+		Expect(out.String()).To(ContainSubstring("No checks of PR"))
+	})
+})
+
+var _ = Describe("Detailed check view", func() {
+	var mockClient *cmd.MockRESTClient
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+
+		actions, konflux := &cmd.CheckApp{Slug: "github-actions"}, &cmd.CheckApp{Slug: "konflux"}
+		mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+			{Name: "lint", Status: "completed", Conclusion: "success", App: actions},
+			{Name: "app-on-pull-request", Status: "completed", Conclusion: "failure", App: konflux},
+			{Name: "unit", Status: "completed", Conclusion: "success", App: actions},
+			{Name: "enterprise-contract", Status: "completed", Conclusion: "success", App: konflux},
+			{Name: "e2e", Status: "completed", Conclusion: "skipped", App: actions},
+		}})
+		mockClient.AddResponse("repos/owner/repo/commits/sha1/status", 200, map[string]any{"statuses": []cmd.StatusCheck{
+			{Context: "ci/prow/unit", State: "success"},
+			{Context: "ci/prow/e2e", State: "pending"},
+			{Context: "tide", State: "success"},
+		}})
+	})
+
+	AfterEach(func() {
+		cmd.SetExpandChecksTest(false)
+		cmd.ResetIOStreams()
+	})
+
+	It("should group checks by app and context with a rollup, collapsing the groups that passed", func() {
+		Expect(cmd.DisplayDetailedCheckStatusTest(mockClient, "owner", "repo", 1, "sha1")).To(Equal(1))
+
+		output := out.String()
+		Expect(output).To(ContainSubstring("✅ github-actions (3): 2 passed, 1 other\n"))
+		Expect(output).NotTo(ContainSubstring("lint"))
+		Expect(output).To(ContainSubstring("❌ konflux (2): 1 passed, 1 failed\n"))
+		Expect(output).To(ContainSubstring("      ❌ app-on-pull-request: failed (failure)\n"))
+		Expect(output).To(ContainSubstring("      ✅ enterprise-contract: passed\n"))
+		Expect(output).To(ContainSubstring("🟡 ci/prow (2): 1 passed, 1 pending\n"))
+		Expect(output).To(ContainSubstring("✅ tide (1): 1 passed\n"))
+		Expect(output).To(ContainSubstring("2 passing group(s) collapsed"))
+	})
+
+	It("should list every check with --expand-checks", func() {
+		cmd.SetExpandChecksTest(true)
+		cmd.DisplayDetailedCheckStatusTest(mockClient, "owner", "repo", 1, "sha1")
+
+		Expect(out.String()).To(ContainSubstring("      ✅ lint: passed\n"))
+		Expect(out.String()).To(ContainSubstring("      ⚪ e2e: skipped (skipped)\n"))
+		Expect(out.String()).NotTo(ContainSubstring("collapsed"))
+	})
+})
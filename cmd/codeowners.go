@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+
+	"ghprs/pkg/ghprs"
+)
+
+// codeownersPaths are where GitHub looks for the CODEOWNERS file of a branch, in its order
+var codeownersPaths = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS"}
+
+// codeownersRule is a line of a CODEOWNERS file: the files a pattern matches and who owns them
+type codeownersRule struct {
+	Pattern string
+	Owners  []string
+	match   *regexp.Regexp
+}
+
+// codeownersContent is a file as the contents API returns it
+type codeownersContent struct {
+	Content  string `json:"content"`
+	Encoding string `json:"encoding"`
+}
+
+var (
+	// codeownersRulesMutex is held while a CODEOWNERS is fetched, so the PRs listed in parallel fetch it once
+	codeownersRulesMutex sync.Mutex
+	// codeownersRules maps "owner/repo@branch" to the rules of its CODEOWNERS, nil without one, fetched once per run
+	codeownersRules = map[string][]codeownersRule{}
+
+	codeownersMutex sync.Mutex
+	// prCodeOwners maps "owner/repo#number@sha" to the owners of what the PR changes, so they are computed once
+	prCodeOwners = map[string][]string{}
+	// ownerIdentities maps a GitHub host to the authenticated user and their teams as CODEOWNERS names them
+	ownerIdentities = map[string][]string{}
+)
+
+// resetCodeowners forgets the CODEOWNERS, owners and teams looked up, so a new run sees changes made since
+func resetCodeowners() {
+	codeownersRulesMutex.Lock()
+	codeownersRules = map[string][]codeownersRule{}
+	codeownersRulesMutex.Unlock()
+
+	codeownersMutex.Lock()
+	defer codeownersMutex.Unlock()
+	prCodeOwners = map[string][]string{}
+	ownerIdentities = map[string][]string{}
+}
+
+// parseCodeowners reads the rules of a CODEOWNERS file, skipping comments, blank lines and invalid patterns
+func parseCodeowners(content string) []codeownersRule {
+	var rules []codeownersRule
+	for _, line := range strings.Split(content, "\n") {
+		if i := strings.Index(line, "#"); i >= 0 && (i == 0 || line[i-1] != '\\') {
+			line = line[:i]
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+		pattern := strings.ReplaceAll(fields[0], `\#`, "#")
+		match, err := codeownersPattern(pattern)
+		if err != nil {
+			logger.Debug("Skipping invalid CODEOWNERS pattern", "pattern", pattern, "error", err)
+			continue
+		}
+		rules = append(rules, codeownersRule{Pattern: pattern, Owners: fields[1:], match: match})
+	}
+	return rules
+}
+
+// codeownersPattern compiles a CODEOWNERS pattern, which follows gitignore: a pattern with a slash other than at
+// its end is relative to the root, others match at any depth, and a matching directory owns everything in it.
+// Unlike in gitignore, dir/* only matches the files directly in dir.
+func codeownersPattern(pattern string) (*regexp.Regexp, error) {
+	anchored := strings.Contains(strings.TrimSuffix(pattern, "/"), "/")
+	pattern = strings.Trim(pattern, "/")
+	if pattern == "" || pattern == "*" || pattern == "**" {
+		return regexp.Compile(".*")
+	}
+
+	var expr strings.Builder
+	if anchored {
+		expr.WriteString("^")
+	} else {
+		expr.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(pattern); i++ {
+		switch {
+		case strings.HasPrefix(pattern[i:], "**/"):
+			expr.WriteString("(?:.*/)?")
+			i += 2
+		case strings.HasPrefix(pattern[i:], "**"):
+			expr.WriteString(".*")
+			i++
+		case pattern[i] == '*':
+			expr.WriteString("[^/]*")
+		case pattern[i] == '?':
+			expr.WriteString("[^/]")
+		default:
+			expr.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	if strings.HasSuffix(pattern, "/*") {
+		expr.WriteString("$")
+	} else {
+		expr.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(expr.String())
+}
+
+// ownersOf returns who owns a file: the owners of the last rule matching it, as the later rules win
+func ownersOf(rules []codeownersRule, filename string) []string {
+	for i := len(rules) - 1; i >= 0; i-- {
+		if rules[i].match.MatchString(filename) {
+			return rules[i].Owners
+		}
+	}
+	return nil
+}
+
+// codeOwners returns the owners of files in the order they first own one, the teams and users whose approval the
+// files need
+func codeOwners(rules []codeownersRule, files []string) []string {
+	var owners []string
+	for _, filename := range files {
+		for _, owner := range ownersOf(rules, filename) {
+			if !slices.ContainsFunc(owners, func(o string) bool { return strings.EqualFold(o, owner) }) {
+				owners = append(owners, owner)
+			}
+		}
+	}
+	return owners
+}
+
+// fetchCodeowners returns the rules of the CODEOWNERS of a branch, fetching each branch once per run. It
+// returns nothing when the branch has no CODEOWNERS.
+func fetchCodeowners(ctx context.Context, client RESTClientInterface, owner, repo, branch string) ([]codeownersRule, error) {
+	key := fmt.Sprintf("%s/%s@%s", owner, repo, branch)
+	codeownersRulesMutex.Lock()
+	defer codeownersRulesMutex.Unlock()
+	if rules, ok := codeownersRules[key]; ok {
+		return rules, nil
+	}
+
+	var rules []codeownersRule
+	for _, filename := range codeownersPaths {
+		var content codeownersContent
+		path := fmt.Sprintf("repos/%s/%s/contents/%s?ref=%s", owner, repo, filename, url.QueryEscape(branch))
+		if err := client.DoWithContext(ctx, http.MethodGet, path, nil, &content); err != nil {
+			var httpErr *api.HTTPError
+			if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+				continue
+			}
+			return nil, fmt.Errorf("failed to fetch %s: %w", filename, err)
+		}
+		data, err := base64.StdEncoding.DecodeString(strings.ReplaceAll(content.Content, "\n", ""))
+		if err != nil || content.Encoding != "base64" {
+			return nil, fmt.Errorf("failed to decode %s", filename)
+		}
+		rules = parseCodeowners(string(data))
+		break
+	}
+	codeownersRules[key] = rules
+	return rules, nil
+}
+
+// codeOwnersOf returns the teams and users whose approval a PR needs by the CODEOWNERS of the branch it targets,
+// nothing when the branch has no CODEOWNERS
+func codeOwnersOf(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest) ([]string, error) {
+	key := fmt.Sprintf("%s/%s#%d@%s", owner, repo, pr.Number, pr.Head.SHA)
+	codeownersMutex.Lock()
+	owners, ok := prCodeOwners[key]
+	codeownersMutex.Unlock()
+	if ok {
+		return owners, nil
+	}
+
+	rules, err := fetchCodeowners(ctx, client, owner, repo, pr.Base.Ref)
+	if err != nil || len(rules) == 0 {
+		return nil, err
+	}
+	files, err := ghprs.FetchFiles(ctx, client, owner, repo, pr.Number)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the files of PR #%d: %w", pr.Number, err)
+	}
+	filenames := make([]string, len(files))
+	for i, file := range files {
+		filenames[i] = file.Filename
+	}
+	owners = codeOwners(rules, filenames)
+
+	codeownersMutex.Lock()
+	prCodeOwners[key] = owners
+	codeownersMutex.Unlock()
+	return owners, nil
+}
+
+// myOwnerIdentities returns the authenticated user and the teams they are a member of as CODEOWNERS names them,
+// @login and @org/team. Looking the teams up needs the read:org scope; without it only the user is returned.
+func myOwnerIdentities(client RESTClientInterface, owner, repo string) ([]string, error) {
+	host := hostFor(owner, repo)
+	codeownersMutex.Lock()
+	identities, ok := ownerIdentities[host]
+	codeownersMutex.Unlock()
+	if ok {
+		return identities, nil
+	}
+
+	login, err := viewerLogin(client, owner, repo)
+	if err != nil {
+		return nil, err
+	}
+	identities = []string{"@" + login}
+	var teams []struct {
+		Slug         string `json:"slug"`
+		Organization struct {
+			Login string `json:"login"`
+		} `json:"organization"`
+	}
+	if err := client.Get(fmt.Sprintf("user/teams?per_page=%d", maxPerPage), &teams); err != nil {
+		logger.Warn("Could not look up your teams, only PRs you own yourself count as needing your team (gh auth refresh -s read:org)", "error", err)
+	}
+	for _, team := range teams {
+		identities = append(identities, fmt.Sprintf("@%s/%s", team.Organization.Login, team.Slug))
+	}
+
+	codeownersMutex.Lock()
+	ownerIdentities[host] = identities
+	codeownersMutex.Unlock()
+	return identities, nil
+}
+
+// ownedByAny reports whether one of owners is one of identities, ignoring case
+func ownedByAny(owners, identities []string) bool {
+	return slices.ContainsFunc(owners, func(owner string) bool {
+		return slices.ContainsFunc(identities, func(identity string) bool { return strings.EqualFold(owner, identity) })
+	})
+}
+
+// needsMyReview reports whether the CODEOWNERS of a PR's base make it need an approval from the authenticated
+// user or one of their teams. PRs whose owners can't be looked up are kept rather than hidden.
+func needsMyReview(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest) bool {
+	identities, err := myOwnerIdentities(client, owner, repo)
+	if err != nil {
+		logger.Warn("Could not look up the authenticated user, keeping the PR", "pr", pr.Number, "error", err)
+		return true
+	}
+	owners, err := codeOwnersOf(ctx, client, owner, repo, pr)
+	if err != nil {
+		logger.Warn("Could not look up the code owners, keeping the PR", "repo", owner+"/"+repo, "pr", pr.Number, "error", err)
+		return true
+	}
+	return ownedByAny(owners, identities)
+}
+
+// ownersColumn renders the OWNERS column of a row: the code owners whose approval the PR needs
+func ownersColumn(row PRRow) string {
+	return strings.Join(row.Owners, " ")
+}
+
+// requestableOwners turns code owners into the reviewers a review can be requested from: logins and org/team,
+// without the PR's author, who can't review their own PR, and without the owners given by email
+func requestableOwners(owners []string, author string) []string {
+	var reviewers []string
+	for _, owner := range owners {
+		if !strings.HasPrefix(owner, "@") {
+			continue
+		}
+		reviewer := strings.TrimPrefix(owner, "@")
+		if strings.EqualFold(reviewer, author) {
+			continue
+		}
+		reviewers = append(reviewers, reviewer)
+	}
+	return reviewers
+}
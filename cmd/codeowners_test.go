@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Code owners", func() {
+	const codeowners = `# Default owners
+*                 @my-org/maintainers
+*.md              @my-org/docs # documentation
+/.tekton/         @my-org/release
+docs/*            @alice
+**/testdata/**    @bob
+/pkg/api/         @my-org/api carol@example.com
+`
+
+	DescribeTable("owners of a file, the last matching rule winning",
+		func(filename string, owners ...string) {
+			Expect(cmd.CodeOwnersTest(codeowners, []string{filename})).To(Equal(owners))
+		},
+		Entry("files matched by the default rule", "main.go", "@my-org/maintainers"),
+		Entry("an extension at any depth", "cmd/README.md", "@my-org/docs"),
+		Entry("a directory anchored at the root", ".tekton/push.yaml", "@my-org/release"),
+		Entry("a directory anchored at the root, nested", ".tekton/tasks/build.yaml", "@my-org/release"),
+		Entry("not a root directory elsewhere", "sub/.tekton/push.yaml", "@my-org/maintainers"),
+		Entry("dir/* matching the files directly in dir", "docs/guide.txt", "@alice"),
+		Entry("dir/* not matching deeper files", "docs/build/guide.txt", "@my-org/maintainers"),
+		Entry("** across directories", "cmd/testdata/table.golden", "@bob"),
+		Entry("several owners", "pkg/api/types.go", "@my-org/api", "carol@example.com"),
+	)
+
+	It("collects the owners of every file once", func() {
+		Expect(cmd.CodeOwnersTest(codeowners, []string{"main.go", "README.md", "go.mod", "docs/a.txt"})).
+			To(Equal([]string{"@my-org/maintainers", "@my-org/docs", "@alice"}))
+		Expect(cmd.CodeOwnersTest("", []string{"main.go"})).To(BeEmpty())
+	})
+
+	Context("of PRs", func() {
+		var (
+			mockClient *cmd.MockRESTClient
+			in, out    *bytes.Buffer
+			prs        []cmd.PullRequest
+		)
+
+		BeforeEach(func() {
+			cmd.ResetCodeownersTest()
+			mockClient = cmd.NewMockRESTClient()
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+
+			mockClient.AddErrorResponse("repos/owner/repo/contents/.github/CODEOWNERS", &api.HTTPError{StatusCode: http.StatusNotFound})
+			mockClient.AddResponse("repos/owner/repo/contents/CODEOWNERS", 200, map[string]string{
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(codeowners)),
+			})
+			mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, []cmd.PRFile{{Filename: "main.go"}})
+			mockClient.AddResponse("repos/owner/repo/pulls/2/files", 200, []cmd.PRFile{{Filename: ".tekton/push.yaml"}, {Filename: "docs/a.txt"}})
+			mockClient.AddResponse("user/teams", 200, []map[string]any{
+				{"slug": "release", "organization": map[string]string{"login": "my-org"}},
+			})
+			mockClient.AddResponse("user", 200, cmd.User{Login: "alice"})
+
+			prs = []cmd.PullRequest{{Number: 1, State: "open"}, {Number: 2, State: "open"}}
+			for i := range prs {
+				prs[i].Base.Ref = "main"
+				prs[i].User.Login = "renovate[bot]"
+			}
+		})
+
+		AfterEach(func() {
+			Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+			cmd.ResetCodeownersTest()
+			cmd.ResetIOStreams()
+		})
+
+		It("fills in the owners of each PR, fetching the CODEOWNERS of a branch once", func() {
+			rows := cmd.BuildPRRowsTest(prs, "owner", "repo", mockClient, false)
+			Expect(rows[0].Owners).To(Equal([]string{"@my-org/maintainers"}))
+			Expect(rows[1].Owners).To(Equal([]string{"@my-org/release", "@alice"}))
+			Expect(mockClient.GetRequestCount("repos/owner/repo/contents/CODEOWNERS")).To(Equal(1))
+		})
+
+		It("keeps the PRs that need my or my team's approval with --needs-my-team", func() {
+			Expect(cmd.UseListFlagsTest([]string{"--needs-my-team"}, false)).To(Succeed())
+
+			kept := cmd.FilterPRsTest(prs, mockClient, "owner", "repo", false)
+			Expect(kept).To(HaveLen(1))
+			Expect(kept[0].Number).To(Equal(2))
+		})
+
+		It("leaves out repositories without a CODEOWNERS", func() {
+			mockClient.AddErrorResponse("repos/owner/repo/contents/CODEOWNERS", &api.HTTPError{StatusCode: http.StatusNotFound})
+			mockClient.AddErrorResponse("repos/owner/repo/contents/docs/CODEOWNERS", &api.HTTPError{StatusCode: http.StatusNotFound})
+
+			rows := cmd.BuildPRRowsTest(prs, "owner", "repo", mockClient, false)
+			Expect(rows[0].Owners).To(BeEmpty())
+			Expect(mockClient.GetRequestCount("pulls/1/files")).To(Equal(0))
+		})
+
+		It("offers to request the reviews of the code owners", func() {
+			in.WriteString("o\n\n")
+			mockClient.AddResponse("repos/owner/repo/pulls/2/requested_reviewers", 201, map[string]any{})
+
+			Expect(cmd.PromptForAssignTest(mockClient, "owner", "repo", prs[1])).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("👥 Code owners: @my-org/release @alice"))
+			Expect(out.String()).To(ContainSubstring("Requested reviews from my-org/release, alice"))
+			Expect(mockClient.GetLastRequest().Body).To(MatchJSON(`{"reviewers":["alice"],"team_reviewers":["release"]}`))
+		})
+	})
+})
@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"slices"
+	"sync"
+
+	"ghprs/internal/render"
+)
+
+var (
+	columnWidthsMutex sync.RWMutex
+	// configuredColumnWidths maps a column name to its configured width, a number or "auto"
+	configuredColumnWidths = render.ColumnWidths{}
+)
+
+// setColumnWidths remembers the column widths from the config, ignoring invalid ones
+func setColumnWidths(config *Config) {
+	columnWidthsMutex.Lock()
+	defer columnWidthsMutex.Unlock()
+	configuredColumnWidths = render.ColumnWidths{}
+	for column, width := range config.Display.Columns {
+		if render.ValidateColumnWidth(column, width) == nil {
+			configuredColumnWidths[column] = width
+		}
+	}
+}
+
+// columnWidth returns the width of a text column for values with the configured widths
+func columnWidth(column, header string, values []string) int {
+	columnWidthsMutex.RLock()
+	defer columnWidthsMutex.RUnlock()
+	return configuredColumnWidths.Width(column, header, values)
+}
+
+// textColumnWidths returns the widths of the title, author, branch and target columns for rows
+func textColumnWidths(rows []PRRow) (int, int, int, int) {
+	titles := make([]string, len(rows))
+	authors := make([]string, len(rows))
+	branches := make([]string, len(rows))
+	targets := make([]string, len(rows))
+	for i, row := range rows {
+		titles[i], authors[i], branches[i], targets[i] = rowTitle(row), row.Author, row.Branch, row.Target
+	}
+	return columnWidth(render.ColumnTitle, "TITLE", titles),
+		columnWidth(render.ColumnAuthor, "AUTHOR", authors),
+		columnWidth(render.ColumnBranch, "BRANCH", branches),
+		columnWidth(render.ColumnTarget, "TARGET", targets)
+}
+
+// repoColumnWidth returns the width of the REPO column, or 0 when rows aren't from the combined table
+func repoColumnWidth(rows []PRRow) int {
+	if len(rows) == 0 || rows[0].Repository == "" {
+		return 0
+	}
+	repos := make([]string, len(rows))
+	for i, row := range rows {
+		repos[i] = row.Repository
+	}
+	return columnWidth(render.ColumnRepo, "REPO", repos)
+}
+
+// componentColumnWidth returns the width of the COMPONENT column, or 0 when no row has a Konflux component
+func componentColumnWidth(rows []PRRow) int {
+	components := make([]string, len(rows))
+	for i, row := range rows {
+		components[i] = row.Component
+	}
+	if !slices.ContainsFunc(components, func(component string) bool { return component != "" }) {
+		return 0
+	}
+	return columnWidth(render.ColumnComponent, "COMPONENT", components)
+}
+
+// ownersColumnWidth returns the width of the OWNERS column, or 0 when no row has code owners
+func ownersColumnWidth(rows []PRRow) int {
+	owners := make([]string, len(rows))
+	for i, row := range rows {
+		owners[i] = lookupColumn(row, lookupOwners, ownersColumn(row))
+	}
+	if !slices.ContainsFunc(owners, func(owner string) bool { return owner != "" }) {
+		return 0
+	}
+	return columnWidth(render.ColumnOwners, "OWNERS", owners)
+}
+
+// dependencyColumnWidth returns the width of the DEP column, or 0 when no row updates a dependency
+func dependencyColumnWidth(rows []PRRow) int {
+	deps := make([]string, len(rows))
+	for i, row := range rows {
+		deps[i] = dependencyColumn(row)
+	}
+	if !slices.ContainsFunc(deps, func(dep string) bool { return dep != "" }) {
+		return 0
+	}
+	return columnWidth(render.ColumnDependency, "DEP", deps)
+}
+
+// semverColumnWidth returns the width of the SEMVER column, or 0 when no row updates a dependency
+func semverColumnWidth(rows []PRRow) int {
+	const maxSemverWidth = 24 // "major 1.22.10→2.0.0-rc.1"
+	width := 0
+	for _, row := range rows {
+		width = max(width, render.DisplayWidth(semverColumn(row)))
+	}
+	if width == 0 {
+		return 0
+	}
+	return min(max(width, len("SEMVER")), maxSemverWidth)
+}
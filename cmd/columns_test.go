@@ -0,0 +1,95 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Column widths", func() {
+	longTitle := "chore(deps): update registry.access.redhat.com/ubi9/ubi-minimal docker digest to 1a2b3c4"
+	rows := []cmd.PRRow{
+		{Number: 1, Title: longTitle, Author: "renovate[bot]", Branch: "renovate/ubi9", Target: "main", State: "open"},
+		{Number: 2, Title: "short", Author: "me", Branch: "fix", Target: "release-1.0", State: "open"},
+	}
+
+	AfterEach(func() {
+		cmd.SetColumnWidthsTest(cmd.DefaultConfig())
+	})
+
+	It("should use the default widths when nothing is configured", func() {
+		cmd.SetColumnWidthsTest(cmd.DefaultConfig())
+		title, author, branch, target := cmd.TextColumnWidthsTest(rows)
+		Expect([]int{title, author, branch, target}).To(Equal([]int{41, 16, 14, 12}))
+	})
+
+	It("should use fixed and automatic widths from the config", func() {
+		config := cmd.DefaultConfig()
+		config.Display.Columns = map[string]string{"title": "auto", "author": "20", "target": "auto"}
+		cmd.SetColumnWidthsTest(config)
+
+		title, author, branch, target := cmd.TextColumnWidthsTest(rows)
+		Expect(title).To(Equal(len(longTitle)))
+		Expect(author).To(Equal(20))
+		Expect(branch).To(Equal(14))
+		Expect(target).To(Equal(len("release-1.0")))
+	})
+
+	It("should fit automatic columns to the header when the values are shorter", func() {
+		config := cmd.DefaultConfig()
+		config.Display.Columns = map[string]string{"target": "auto"}
+		cmd.SetColumnWidthsTest(config)
+
+		_, _, _, target := cmd.TextColumnWidthsTest([]cmd.PRRow{{Target: "dev"}})
+		Expect(target).To(Equal(len("TARGET")))
+	})
+
+	It("should ignore invalid configured widths", func() {
+		config := cmd.DefaultConfig()
+		config.Display.Columns = map[string]string{"title": "wide", "labels": "auto"}
+		cmd.SetColumnWidthsTest(config)
+
+		title, _, _, _ := cmd.TextColumnWidthsTest(rows)
+		Expect(title).To(Equal(41))
+	})
+
+	It("should show whole titles in an automatic title column", func() {
+		config := cmd.DefaultConfig()
+		config.Display.Columns = map[string]string{"title": "auto"}
+		cmd.SetColumnWidthsTest(config)
+
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		defer cmd.ResetIOStreams()
+
+		cmd.RenderPRTableTest(rows, "owner", "repo")
+		Expect(out.String()).To(ContainSubstring(longTitle))
+	})
+
+	Describe("Parsing column=width settings", func() {
+		It("should accept numbers and auto", func() {
+			column, width, err := cmd.ParseColumnWidthTest("Title=AUTO")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(column).To(Equal("title"))
+			Expect(width).To(Equal("auto"))
+
+			column, width, err = cmd.ParseColumnWidthTest("author=20")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(column).To(Equal("author"))
+			Expect(width).To(Equal("20"))
+		})
+
+		It("should reject unknown columns and invalid widths", func() {
+			_, _, err := cmd.ParseColumnWidthTest("labels=auto")
+			Expect(err).To(MatchError(ContainSubstring("labels")))
+			_, _, err = cmd.ParseColumnWidthTest("title=0")
+			Expect(err).To(HaveOccurred())
+			_, _, err = cmd.ParseColumnWidthTest("title")
+			Expect(err).To(HaveOccurred())
+		})
+	})
+})
@@ -0,0 +1,82 @@
+package cmd
+
+import (
+	"sort"
+	"time"
+)
+
+// combinedRow is a row of the combined table together with the PR it shows, which it is sorted by
+type combinedRow struct {
+	PR  PullRequest
+	Row PRRow
+}
+
+// newCombinedRows pairs the rows built for the PRs of repoSpec with their PRs, marking each row with its repository
+func newCombinedRows(repoSpec string, prs []PullRequest, rows []PRRow) []combinedRow {
+	combined := make([]combinedRow, len(rows))
+	for i, row := range rows {
+		row.Repository = repoSpec
+		combined[i] = combinedRow{PR: prs[i], Row: row}
+	}
+	return combined
+}
+
+// sortCombinedRows orders the rows of every repository as one queue. Each repository's PRs arrive newest
+// first, so unlike a single repository the default order has to be sorted for too.
+func sortCombinedRows(rows []combinedRow, sortBy string) {
+	if sortBy == "priority" {
+		// Rows scored with what their PRs don't say themselves keep that score across repositories
+		now := time.Now()
+		score := func(row combinedRow) float64 {
+			if row.Row.Priority != nil {
+				return row.Row.Priority.Score
+			}
+			return scorePriority(row.PR, priorityFacts{Now: now}).Score
+		}
+		sort.SliceStable(rows, func(i, j int) bool {
+			return priorityLess(rows[i].PR, rows[j].PR, score(rows[i]), score(rows[j]))
+		})
+		return
+	}
+	less := pullRequestLess(sortBy)
+	if less == nil {
+		less = func(a, b PullRequest) bool {
+			return a.CreatedAt > b.CreatedAt
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		return less(rows[i].PR, rows[j].PR)
+	})
+}
+
+// displayCombinedTable shows the PRs of every repository in a single table with a REPO column
+func displayCombinedTable(opts *listOptions, combined []combinedRow, isKonflux bool) {
+	if len(combined) == 0 {
+		if isKonflux {
+			streams.Println("\nNo Konflux pull requests found in any repository")
+		} else {
+			streams.Printf("\nNo %s pull requests found in any repository\n", opts.State)
+		}
+		return
+	}
+
+	sortCombinedRows(combined, opts.SortBy)
+	rows := make([]PRRow, len(combined))
+	for i, row := range combined {
+		rows[i] = row.Row
+	}
+	renderPRTable(opts, rows, "", "All repositories", isKonflux, legend.Take())
+	if opts.ExplainSort {
+		explainPriority(rows, "", "")
+	}
+}
+
+// rowPRLink links the PR of a row, to the row's own repository in the combined table
+func rowPRLink(row PRRow, owner, repo string) string {
+	if row.Repository != "" {
+		if rowOwner, rowRepo, ok := parseRepoSpec(row.Repository); ok {
+			return formatPRLink(rowOwner, rowRepo, row.Number)
+		}
+	}
+	return formatPRLink(owner, repo, row.Number)
+}
@@ -0,0 +1,82 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Combined table", func() {
+	var out *bytes.Buffer
+
+	pr := func(number int, title, createdAt, body string) cmd.PullRequest {
+		return cmd.PullRequest{Number: number, Title: title, State: "open", CreatedAt: createdAt, Body: body, User: cmd.User{Login: "dev"}}
+	}
+	// rowOrder returns the titles in the order the table shows them
+	rowOrder := func(titles ...string) []int {
+		var positions []int
+		for _, title := range titles {
+			positions = append(positions, strings.Index(out.String(), title))
+		}
+		return positions
+	}
+
+	repos := []cmd.CombinedPRsTest{
+		{RepoSpec: "org/api", PRs: []cmd.PullRequest{
+			pr(12, "api newest", "2026-03-04T00:00:00Z", ""),
+			pr(11, "api oldest", "2026-01-01T00:00:00Z", ""),
+		}},
+		{RepoSpec: "org/web", PRs: []cmd.PullRequest{
+			pr(40, "web middle", "2026-02-01T00:00:00Z", "⚠️[migration] needs a manual step"),
+		}},
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should show every repository in one table with a REPO column", func() {
+		cmd.DisplayCombinedTableTest(repos, "")
+
+		Expect(out.String()).To(ContainSubstring("=== All repositories: PRs ==="))
+		Expect(strings.Count(out.String(), "===")).To(Equal(2))
+		Expect(out.String()).To(MatchRegexp(`REPO\s+ST\s+PR\s+TITLE`))
+		Expect(out.String()).To(MatchRegexp(`org/web\s+\S+\s+#40\s+web middle`))
+		Expect(out.String()).To(MatchRegexp(`org/api\s+\S+\s+#11\s+api oldest`))
+	})
+
+	It("should order the PRs across repositories, newest first by default", func() {
+		cmd.DisplayCombinedTableTest(repos, "")
+		order := rowOrder("api newest", "web middle", "api oldest")
+		Expect(order[0]).To(BeNumerically("<", order[1]))
+		Expect(order[1]).To(BeNumerically("<", order[2]))
+	})
+
+	It("should sort by age across repositories", func() {
+		cmd.DisplayCombinedTableTest(repos, "oldest")
+		order := rowOrder("api oldest", "web middle", "api newest")
+		Expect(order[0]).To(BeNumerically("<", order[1]))
+		Expect(order[1]).To(BeNumerically("<", order[2]))
+	})
+
+	It("should put migration warnings first by priority", func() {
+		cmd.DisplayCombinedTableTest(repos, "priority")
+		order := rowOrder("web middle", "api newest", "api oldest")
+		Expect(order[0]).To(BeNumerically("<", order[1]))
+		Expect(order[1]).To(BeNumerically("<", order[2]))
+	})
+
+	It("should say so when no repository has PRs", func() {
+		cmd.DisplayCombinedTableTest(nil, "")
+		Expect(out.String()).To(ContainSubstring("No open pull requests found in any repository"))
+	})
+})
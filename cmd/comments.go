@@ -0,0 +1,227 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+// ReviewComment is a comment on a line of the diff of a PR
+type ReviewComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	CreatedAt string `json:"created_at"`
+	Path      string `json:"path"`
+	// Line is the line of the file the comment is on, nil when the line is no longer in the diff
+	Line *int `json:"line,omitempty"`
+	// InReplyToID is the comment this one replies to
+	InReplyToID int64 `json:"in_reply_to_id,omitempty"`
+	// Reactions are the reaction counts of the comment
+	Reactions *Reactions `json:"reactions,omitempty"`
+}
+
+// Kinds of entries in the conversation of a PR
+const (
+	ConversationComment       = "comment"
+	ConversationReview        = "review"
+	ConversationReviewComment = "review comment"
+)
+
+// conversationEntry is one comment, review or review comment of the conversation on a PR
+type conversationEntry struct {
+	Kind      string
+	Author    string
+	CreatedAt time.Time
+	Body      string
+	// Detail says what a review did or which line a review comment is on
+	Detail string
+	// Reactions are the reactions to a comment, "" when there are none
+	Reactions string
+}
+
+// fetchPages fetches every page of a list endpoint
+func fetchPages[T any](ctx context.Context, client RESTClientInterface, path string) ([]T, error) {
+	separator := "?"
+	if strings.Contains(path, "?") {
+		separator = "&"
+	}
+	var all []T
+	for page := 1; ; page++ {
+		var items []T
+		if err := client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("%s%sper_page=%d&page=%d", path, separator, maxPerPage, page), nil, &items); err != nil {
+			return nil, err
+		}
+		all = append(all, items...)
+		if len(items) < maxPerPage {
+			return all, nil
+		}
+	}
+}
+
+// fetchConversation fetches the comments, reviews and review comments of a PR, oldest first
+func fetchConversation(client RESTClientInterface, owner, repo string, prNumber int) ([]conversationEntry, error) {
+	// Comments are usually read right before acting on a PR, so never show cached ones
+	ctx := withFreshData(context.Background())
+
+	comments, err := fetchPages[IssueComment](ctx, client, fmt.Sprintf("repos/%s/%s/issues/%d/comments?sort=created", owner, repo, prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch comments: %v", err)
+	}
+	reviews, err := fetchPages[Review](ctx, client, fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?sort=created", owner, repo, prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch reviews: %v", err)
+	}
+	reviewComments, err := fetchPages[ReviewComment](ctx, client, fmt.Sprintf("repos/%s/%s/pulls/%d/comments?sort=created", owner, repo, prNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch review comments: %v", err)
+	}
+	return newConversation(comments, reviews, reviewComments), nil
+}
+
+// newConversation merges comments, reviews and review comments into one conversation, oldest first.
+// Reviews without a body only say how the PR was reviewed, so they are kept when they approve or
+// request changes.
+func newConversation(comments []IssueComment, reviews []Review, reviewComments []ReviewComment) []conversationEntry {
+	var entries []conversationEntry
+	for _, comment := range comments {
+		entries = append(entries, conversationEntry{
+			Kind:      ConversationComment,
+			Author:    comment.User.Login,
+			CreatedAt: parseConversationTime(comment.CreatedAt),
+			Body:      comment.Body,
+			Reactions: comment.Reactions.summary(),
+		})
+	}
+	for _, review := range reviews {
+		if strings.TrimSpace(review.Body) == "" && review.State != "APPROVED" && review.State != "CHANGES_REQUESTED" {
+			continue
+		}
+		entries = append(entries, conversationEntry{
+			Kind:      ConversationReview,
+			Author:    review.User.Login,
+			CreatedAt: parseConversationTime(review.SubmittedAt),
+			Body:      review.Body,
+			Detail:    strings.ToLower(strings.ReplaceAll(review.State, "_", " ")),
+		})
+	}
+	for _, comment := range reviewComments {
+		detail := "on " + comment.Path
+		if comment.Line != nil {
+			detail += fmt.Sprintf(":%d", *comment.Line)
+		}
+		if comment.InReplyToID != 0 {
+			detail = "reply " + detail
+		}
+		entries = append(entries, conversationEntry{
+			Kind:      ConversationReviewComment,
+			Author:    comment.User.Login,
+			CreatedAt: parseConversationTime(comment.CreatedAt),
+			Body:      comment.Body,
+			Detail:    detail,
+			Reactions: comment.Reactions.summary(),
+		})
+	}
+	sort.SliceStable(entries, func(i, j int) bool { return entries[i].CreatedAt.Before(entries[j].CreatedAt) })
+	return entries
+}
+
+// parseConversationTime parses a GitHub timestamp, the zero time when it is missing or invalid
+func parseConversationTime(timestamp string) time.Time {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	if err != nil {
+		return time.Time{}
+	}
+	return t
+}
+
+// displayConversation shows the conversation on a PR with the author and time of every entry, and the reactions
+// to the PR (reactions is "" when there are none) and to each comment
+func displayConversation(owner, repo string, prNumber int, reactions string, entries []conversationEntry) {
+	streams.Printf("\n💬 Conversation on PR %s (%d):\n", formatPRLink(owner, repo, prNumber), len(entries))
+	if reactions != "" {
+		streams.Printf("   Reactions: %s\n", reactions)
+	}
+	if len(entries) == 0 {
+		streams.Printf("   No comments yet\n")
+		return
+	}
+
+	colors := shouldUseColors()
+	for _, entry := range entries {
+		author, when := "@"+entry.Author, "unknown time"
+		if !entry.CreatedAt.IsZero() {
+			when = entry.CreatedAt.Local().Format("2006-01-02 15:04")
+		}
+		details := []string{when, entry.Kind}
+		if entry.Detail != "" {
+			details = append(details, entry.Detail)
+		}
+		detail := strings.Join(details, " · ")
+		if colors {
+			author, detail = render.ColorizeAuthor(author), render.Dim(detail)
+		}
+		streams.Printf("\n   %s %s\n", author, detail)
+		for _, line := range strings.Split(strings.TrimRight(strings.ReplaceAll(entry.Body, "\r\n", "\n"), "\n"), "\n") {
+			streams.Printf("      %s\n", line)
+		}
+		if entry.Reactions != "" {
+			streams.Printf("      %s\n", entry.Reactions)
+		}
+	}
+}
+
+// showConversation fetches and shows the conversation on a PR
+func showConversation(client RESTClientInterface, owner, repo string, prNumber int) error {
+	entries, err := fetchConversation(client, owner, repo, prNumber)
+	if err != nil {
+		return err
+	}
+	// The conversation is still worth showing without the reactions to the PR
+	reactions, err := fetchPRReactions(withFreshData(context.Background()), client, owner, repo, prNumber)
+	if err != nil {
+		logger.Debug("Could not fetch the reactions to the PR", "number", prNumber, "error", err)
+	}
+	paged(func() { displayConversation(owner, repo, prNumber, reactions.summary(), entries) })
+	return nil
+}
+
+// commentsCmd shows the conversation on a PR
+var commentsCmd = &cobra.Command{
+	Use:   "comments [owner/repo] <number>",
+	Short: "Show the conversation on a pull request",
+	Long: `Show the comments, reviews and review comments of a pull request in the order they were
+made, with their author and time, e.g. to find out why a PR was put on hold.
+
+The same conversation is shown by the 'v' option while approving, where '+' adds a 👍 or 🚀
+reaction to the PR or its latest bot comment.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs comments 123
+  ghprs comments owner/repo 123`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+
+		client := newCommandClient(ctx, owner, repo)
+		if err := showConversation(client, owner, repo, number); err != nil {
+			log.Fatalf("Failed to fetch the conversation on PR #%d: %v", number, err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(commentsCmd)
+}
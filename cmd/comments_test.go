@@ -0,0 +1,111 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Conversation", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+
+		line := 12
+		mockClient.AddResponse("repos/owner/repo/issues/1/comments?sort=created&per_page=100&page=1", 200, []cmd.IssueComment{
+			{Body: "/hold\n\nwaiting for the 1.2 release", User: cmd.User{Login: "alice"}, CreatedAt: "2025-06-01T10:00:00Z"},
+			{Body: "/unhold", User: cmd.User{Login: "alice"}, CreatedAt: "2025-06-05T10:00:00Z"},
+		})
+		mockClient.AddResponse("repos/owner/repo/pulls/1/reviews?sort=created&per_page=100&page=1", 200, []cmd.Review{
+			{State: "COMMENTED", User: cmd.User{Login: "bob"}, SubmittedAt: "2025-06-02T10:00:00Z"},
+			{State: "APPROVED", Body: "/lgtm", User: cmd.User{Login: "carol"}, SubmittedAt: "2025-06-06T10:00:00Z"},
+		})
+		mockClient.AddResponse("repos/owner/repo/pulls/1/comments?sort=created&per_page=100&page=1", 200, []cmd.ReviewComment{
+			{Body: "Why this version?", User: cmd.User{Login: "bob"}, CreatedAt: "2025-06-02T10:00:00Z", Path: ".tekton/push.yaml", Line: &line},
+			{Body: "It fixes a CVE", User: cmd.User{Login: "alice"}, CreatedAt: "2025-06-03T10:00:00Z", Path: ".tekton/push.yaml", InReplyToID: 1},
+		})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should merge comments, reviews and review comments oldest first", func() {
+		entries, err := cmd.FetchConversationTest(mockClient, "owner", "repo", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(Equal([]cmd.ConversationEntryTest{
+			{Kind: cmd.ConversationComment, Author: "alice", Body: "/hold\n\nwaiting for the 1.2 release"},
+			{Kind: cmd.ConversationReviewComment, Author: "bob", Body: "Why this version?", Detail: "on .tekton/push.yaml:12"},
+			{Kind: cmd.ConversationReviewComment, Author: "alice", Body: "It fixes a CVE", Detail: "reply on .tekton/push.yaml"},
+			{Kind: cmd.ConversationComment, Author: "alice", Body: "/unhold"},
+			{Kind: cmd.ConversationReview, Author: "carol", Body: "/lgtm", Detail: "approved"},
+		}))
+	})
+
+	It("should show every entry with its author and kind", func() {
+		Expect(cmd.ShowConversationTest(mockClient, "owner", "repo", 1)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("💬 Conversation on PR #1 (5):"))
+		Expect(out.String()).To(MatchRegexp(`@alice \d{4}-\d{2}-\d{2} \d{2}:\d{2} · comment\n      /hold\n      \n      waiting for the 1.2 release`))
+		Expect(out.String()).To(ContainSubstring("review · approved\n      /lgtm"))
+	})
+
+	It("should be shown from the approval prompt", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nv\nn\n"), out, out), nil)
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Update", State: "open"}}, false)
+		Expect(out.String()).To(ContainSubstring("v=view conversation"))
+		Expect(out.String()).To(ContainSubstring("waiting for the 1.2 release"))
+		Expect(out.String()).To(ContainSubstring("Skipping PR #1"))
+	})
+
+	Describe("Reactions", func() {
+		BeforeEach(func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments?sort=created&per_page=100&page=1", 200, []cmd.IssueComment{
+				{ID: 7, Body: "Build succeeded", User: cmd.User{Login: "red-hat-konflux[bot]"}, CreatedAt: "2025-06-01T10:00:00Z",
+					Reactions: &cmd.Reactions{TotalCount: 3, PlusOne: 2, Rocket: 1}},
+				{ID: 8, Body: "/hold", User: cmd.User{Login: "alice"}, CreatedAt: "2025-06-05T10:00:00Z"},
+			})
+			mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+		})
+
+		It("should show the reactions to the PR and each comment", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1", 200, map[string]interface{}{"reactions": cmd.Reactions{TotalCount: 1, Heart: 1}})
+
+			Expect(cmd.ShowConversationTest(mockClient, "owner", "repo", 1)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("   Reactions: ❤️ 1\n"))
+			Expect(out.String()).To(ContainSubstring("      Build succeeded\n      👍 2  🚀 1\n"))
+		})
+
+		It("should react to the latest bot comment from the approval prompt", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/comments/7/reactions", 201, nil)
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\n+\n2\nb\nn\n"), out, out), nil)
+
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Update", State: "open"}}, false)
+			Expect(out.String()).To(ContainSubstring("+=react"))
+			Expect(out.String()).To(ContainSubstring("🚀 Reacted to the latest comment by @red-hat-konflux[bot] on #1"))
+			Expect(mockClient.Requests).To(ContainElement(cmd.MockRequest{Method: "POST", URL: "repos/owner/repo/issues/comments/7/reactions", Body: `{"content":"rocket"}`}))
+		})
+
+		It("should react to the PR by default", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/reactions", 201, nil)
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\n+\n1\n\nn\n"), out, out), nil)
+
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Update", State: "open"}}, false)
+			Expect(out.String()).To(ContainSubstring("👍 Reacted to PR #1"))
+			Expect(mockClient.Requests).To(ContainElement(cmd.MockRequest{Method: "POST", URL: "repos/owner/repo/issues/1/reactions", Body: `{"content":"+1"}`}))
+		})
+	})
+})
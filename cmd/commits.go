@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// shortSHALength is how much of a commit SHA is shown, as in git log --oneline
+const shortSHALength = 7
+
+// PRCommit is a commit of a pull request
+type PRCommit struct {
+	SHA    string       `json:"sha"`
+	Commit CommitDetail `json:"commit"`
+	// Author is the GitHub user of the commit author, nil when the author's email isn't linked to one
+	Author *User `json:"author,omitempty"`
+}
+
+// CommitDetail is the git data of a commit
+type CommitDetail struct {
+	Message string   `json:"message"`
+	Author  GitActor `json:"author"`
+}
+
+// authorName returns the login of the commit author, or the git author name without one
+func (c PRCommit) authorName() string {
+	if c.Author != nil && c.Author.Login != "" {
+		return "@" + c.Author.Login
+	}
+	return c.Commit.Author.Name
+}
+
+// fetchPRCommits fetches the commits of a PR, oldest first. GitHub lists at most 250 commits of a PR.
+func fetchPRCommits(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int) ([]PRCommit, error) {
+	return fetchPages[PRCommit](ctx, client, fmt.Sprintf("repos/%s/%s/pulls/%d/commits", owner, repo, prNumber))
+}
+
+// displayCommitList shows the short SHA, author and full message of each commit, since Konflux PRs often
+// explain a change, e.g. a migration, only in the commit message
+func displayCommitList(commits []PRCommit) {
+	for _, commit := range commits {
+		sha := commit.SHA
+		if len(sha) > shortSHALength {
+			sha = sha[:shortSHALength]
+		}
+		subject, body, _ := strings.Cut(strings.TrimSpace(commit.Commit.Message), "\n")
+		streams.Printf("      %s %s  %s\n", sha, commit.authorName(), subject)
+		body = strings.Trim(body, "\n")
+		if body == "" {
+			continue
+		}
+		for _, line := range strings.Split(body, "\n") {
+			line = strings.TrimRight(line, " \t\r")
+			if line == "" {
+				streams.Println()
+				continue
+			}
+			streams.Printf("      %s%s\n", strings.Repeat(" ", shortSHALength+1), line)
+		}
+	}
+}
+
+// displayCommits fetches and displays the commits of a PR
+func displayCommits(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int) error {
+	commits, err := fetchPRCommits(ctx, client, owner, repo, prNumber)
+	if err != nil {
+		return err
+	}
+	streams.Printf("   📜 Commits (%d):\n", len(commits))
+	displayCommitList(commits)
+	return nil
+}
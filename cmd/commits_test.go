@@ -0,0 +1,72 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Commits", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	commits := []cmd.PRCommit{
+		{
+			SHA:    "1a2b3c4d5e6f",
+			Commit: cmd.CommitDetail{Message: "Update quay.io/konflux-ci/tekton-catalog/task-buildah\n\n## Migration\n\nRun the migration script   \n", Author: cmd.GitActor{Name: "Konflux"}},
+			Author: &cmd.User{Login: "red-hat-konflux[bot]"},
+		},
+		{SHA: "abcdef0123", Commit: cmd.CommitDetail{Message: "Fix typo", Author: cmd.GitActor{Name: "Jane Doe"}}},
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+		mockClient.AddResponse("repos/owner/repo/pulls/1/commits?per_page=100&page=1", 200, commits)
+		mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should show the short SHA, author and full message of each commit", func() {
+		cmd.DisplayCommitListTest(commits)
+
+		Expect(out.String()).To(Equal(
+			"      1a2b3c4 @red-hat-konflux[bot]  Update quay.io/konflux-ci/tekton-catalog/task-buildah\n" +
+				"              ## Migration\n" +
+				"\n" +
+				"              Run the migration script\n" +
+				"      abcdef0 Jane Doe  Fix typo\n"))
+	})
+
+	It("should be shown from the approval prompt", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nl\nn\n"), out, out), nil)
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Update", State: "open"}}, false)
+		Expect(out.String()).To(ContainSubstring("l=show commit log"))
+		Expect(out.String()).To(ContainSubstring("📜 Commits (2):"))
+		Expect(out.String()).To(ContainSubstring("## Migration"))
+		Expect(out.String()).To(ContainSubstring("Skipping PR #1"))
+	})
+
+	It("should be shown up front with --show-commits", func() {
+		previous := cmd.SetShowCommitsTest(true)
+		defer cmd.SetShowCommitsTest(previous)
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nl\nn\n"), out, out), nil)
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Update", State: "open"}}, false)
+		Expect(out.String()).NotTo(ContainSubstring("l=show commit log"))
+		Expect(strings.Count(out.String(), "📜 Commits (2):")).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring("Commits already shown above"))
+	})
+})
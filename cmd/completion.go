@@ -0,0 +1,354 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+)
+
+var refreshPRIndex bool
+
+const (
+	// completionIndexTTL is how long the PRs of a repository are completed from the PR index before completion
+	// asks GitHub again
+	completionIndexTTL = 10 * time.Minute
+	// completionTimeout bounds asking GitHub during completion, so a slow or unreachable GitHub never holds
+	// up the shell; the PR index is used instead
+	completionTimeout = 2 * time.Second
+)
+
+// completionFetch fetches the first page of open PRs of a repository for completion, replaced in tests
+var completionFetch = func(ctx context.Context, owner, repo string) ([]PullRequest, error) {
+	config, err := LoadConfig()
+	if err != nil {
+		config = DefaultConfig()
+	}
+	setRepositoryHosts(config)
+	client, err := newAPIClient(hostFor(owner, repo), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return fetchPullRequestsREST(withContext(client, ctx), owner, repo, "open", "", maxPerPage, nil)
+}
+
+// prIndexMutex serializes updates of the PR index within a run
+var prIndexMutex sync.Mutex
+
+// prIndexEntry is an open PR remembered for shell completion
+type prIndexEntry struct {
+	Number int    `json:"number"`
+	Title  string `json:"title"`
+}
+
+// repoPRIndex holds the open PRs of a repository as last seen by ghprs
+type repoPRIndex struct {
+	UpdatedAt time.Time      `json:"updated_at"`
+	PRs       []prIndexEntry `json:"prs"`
+}
+
+// prIndex maps owner/repo to its open PRs. It is kept up to date whenever PRs are listed, so
+// completion can be answered instantly and offline.
+type prIndex map[string]*repoPRIndex
+
+// prIndexPath returns the file the PR index is stored in, next to (but not part of) the API cache
+func prIndexPath() string {
+	return filepath.Join(getCacheDir(), "completion", "prs.json")
+}
+
+// loadPRIndex reads the PR index, returning an empty index when there is none or it can't be read
+func loadPRIndex() prIndex {
+	index := prIndex{}
+	data, err := os.ReadFile(prIndexPath())
+	if err != nil {
+		return index
+	}
+	if err := json.Unmarshal(data, &index); err != nil {
+		return prIndex{}
+	}
+	return index
+}
+
+// save writes the index through a temporary file so completion never reads a partial index
+func (i prIndex) save() error {
+	path := prIndexPath()
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return fmt.Errorf("failed to create completion directory: %w", err)
+	}
+	data, err := json.Marshal(i)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(path), "prs-*.tmp")
+	if err != nil {
+		return fmt.Errorf("failed to write PR index: %w", err)
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write PR index: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return fmt.Errorf("failed to write PR index: %w", err)
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// update records the PRs fetched for repoSpec. With complete the PRs are every open PR of the repository
+// and replace what was known; otherwise open PRs are added or updated and PRs seen closed are dropped.
+func (i prIndex) update(repoSpec string, prs []PullRequest, complete bool) {
+	entries := make(map[int]string)
+	if existing, ok := i[repoSpec]; ok && !complete {
+		for _, entry := range existing.PRs {
+			entries[entry.Number] = entry.Title
+		}
+	}
+	for _, pr := range prs {
+		if pr.State == "open" {
+			entries[pr.Number] = pr.Title
+		} else {
+			delete(entries, pr.Number)
+		}
+	}
+
+	repoIndex := &repoPRIndex{UpdatedAt: time.Now(), PRs: make([]prIndexEntry, 0, len(entries))}
+	for number, title := range entries {
+		repoIndex.PRs = append(repoIndex.PRs, prIndexEntry{Number: number, Title: title})
+	}
+	// Newest PRs first, like the PR table
+	sort.Slice(repoIndex.PRs, func(a, b int) bool { return repoIndex.PRs[a].Number > repoIndex.PRs[b].Number })
+	i[repoSpec] = repoIndex
+}
+
+// completions returns the indexed PRs of repoSpec as "number<TAB>title" completions, leaving out the given numbers
+func (i prIndex) completions(repoSpec string, given []string) []cobra.Completion {
+	repoIndex, ok := i[repoSpec]
+	if !ok {
+		return nil
+	}
+	skip := make(map[string]bool, len(given))
+	for _, arg := range given {
+		skip[arg] = true
+	}
+
+	var completions []cobra.Completion
+	for _, entry := range repoIndex.PRs {
+		number := strconv.Itoa(entry.Number)
+		if skip[number] || skip["#"+number] {
+			continue
+		}
+		completions = append(completions, cobra.CompletionWithDesc(number, entry.Title))
+	}
+	return completions
+}
+
+// recordPRs updates the PR index with PRs fetched for repoSpec. Failing to do so never fails the command.
+func recordPRs(repoSpec string, prs []PullRequest, complete bool) {
+	prIndexMutex.Lock()
+	defer prIndexMutex.Unlock()
+	index := loadPRIndex()
+	index.update(repoSpec, prs, complete)
+	if err := index.save(); err != nil {
+		logger.Info("Could not update the PR index used for completion", "error", err)
+	}
+}
+
+// refreshStaleIndex asks GitHub for the open PRs of repoSpec when the index doesn't know them or knows them for
+// longer than completionIndexTTL, recording them so the next completion is instant. When GitHub can't answer
+// within completionTimeout the index is used as it is.
+func refreshStaleIndex(index prIndex, repoSpec string) prIndex {
+	if repoIndex, ok := index[repoSpec]; ok && time.Since(repoIndex.UpdatedAt) < completionIndexTTL {
+		return index
+	}
+	owner, repo, ok := parseRepoSpec(repoSpec)
+	if !ok {
+		return index
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), completionTimeout)
+	defer cancel()
+	prs, err := completionFetch(ctx, owner, repo)
+	if err != nil {
+		logger.Debug("Could not fetch the PRs to complete, using the PR index", "repo", repoSpec, "error", err)
+		return index
+	}
+	recordPRs(repoSpec, prs, len(prs) < maxPerPage)
+	return loadPRIndex()
+}
+
+// fetchedEveryOpenPR reports whether a fetch of fetched PRs with opts returned every open PR of a repository, so
+// the PR index can be replaced rather than added to
+func fetchedEveryOpenPR(opts *listOptions, authors []string, isKonflux bool, fetched int) bool {
+	if opts.State != "open" || opts.TargetBranch != "" || len(authors) > 0 || isKonflux || opts.Since != "" {
+		return false
+	}
+	if opts.SecurityOnly || len(opts.Readiness) > 0 || opts.ReviewRequested || opts.Assignee != "" || snoozedPRs.hiddenCount() > 0 || opts.NewOnly ||
+		opts.OlderThan != "" || opts.UpdatedWithin != "" || opts.NeedsMyTeam || len(opts.UpdateTypes) > 0 {
+		return false
+	}
+	return opts.Limit == 0 || fetched < opts.Limit
+}
+
+// completePRArgs completes the "[owner/repo] <number>" arguments of the commands that work on PRs from the
+// PR index, asking GitHub only for repositories it doesn't know or knows for too long. With batch several PR
+// numbers can be given.
+func completePRArgs(batch bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		index := loadPRIndex()
+
+		repoSpec, given := repoFlag, args
+		if repoSpec == "" {
+			if len(args) == 0 {
+				return completeRepositories(index, batch), cobra.ShellCompDirectiveNoFileComp
+			}
+			if _, _, ok := parseRepoSpec(args[0]); !ok {
+				// A PR number of the current repository was already given
+				return nil, cobra.ShellCompDirectiveNoFileComp
+			}
+			repoSpec, given = args[0], args[1:]
+		}
+		if !batch && len(given) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		index = refreshStaleIndex(index, repoSpec)
+		return index.completions(repoSpec, given), cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completeRepositories completes the repository argument with the configured and indexed repositories and,
+// for commands on a single PR, the PRs of the current repository
+func completeRepositories(index prIndex, batch bool) []cobra.Completion {
+	seen := make(map[string]bool)
+	var completions []cobra.Completion
+	addRepository := func(repoSpec string) {
+		if !seen[repoSpec] {
+			seen[repoSpec] = true
+			completions = append(completions, repoSpec)
+		}
+	}
+
+	if config, err := LoadConfig(); err == nil {
+		for _, repoSpec := range config.GetRepositories(false) {
+			addRepository(repoSpec)
+		}
+	}
+	indexed := make([]string, 0, len(index))
+	for repoSpec := range index {
+		indexed = append(indexed, repoSpec)
+	}
+	sort.Strings(indexed)
+	for _, repoSpec := range indexed {
+		addRepository(repoSpec)
+	}
+
+	if !batch {
+		if currentRepo, err := repository.Current(); err == nil {
+			currentSpec := currentRepo.Owner + "/" + currentRepo.Name
+			index = refreshStaleIndex(index, currentSpec)
+			completions = append(completions, index.completions(currentSpec, nil)...)
+		}
+	}
+	return completions
+}
+
+// completeRepositoryArgs completes the "[owner/repo...]" arguments of the commands that work on repositories
+// with the configured and indexed repositories, leaving out those already given. Without multiple only one
+// repository is completed.
+func completeRepositoryArgs(multiple bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		if !multiple && len(args) > 0 {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		var completions []cobra.Completion
+		for _, repoSpec := range completeRepositories(loadPRIndex(), true) {
+			if !slices.Contains(args, repoSpec) {
+				completions = append(completions, repoSpec)
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
+
+// completionPRsCmd shows, and refreshes, the PRs known for completion
+var completionPRsCmd = &cobra.Command{
+	Use:   "prs [owner/repo...]",
+	Short: "Show the open pull requests used for completion",
+	Long: `Show the open pull requests ghprs remembers for shell completion, one per line as
+owner/repo#number followed by a tab and the title, e.g. to pipe into a fuzzy finder.
+
+The PRs are remembered whenever ghprs lists them, so completion works instantly and offline.
+With --refresh every open PR of the repositories is fetched from GitHub first.
+
+Without repositories every configured and remembered repository is used.
+
+Examples:
+  ghprs completion prs
+  ghprs completion prs owner/repo --refresh
+  ghprs completion prs | fzf`,
+	Run: func(cmd *cobra.Command, args []string) {
+		repositories := args
+		index := loadPRIndex()
+		if len(repositories) == 0 {
+			repositories = completeRepositories(index, true)
+		}
+
+		if refreshPRIndex {
+			ctx := commandContext(cmd)
+			for _, repoSpec := range repositories {
+				owner, repo, ok := parseRepoSpec(repoSpec)
+				if !ok {
+					logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+					continue
+				}
+				repoCtx, cancel := withRepositoryTimeout(ctx)
+				prs, err := fetchPullRequestsREST(newCommandClient(repoCtx, owner, repo), owner, repo, "open", "", 0, nil)
+				cancel()
+				if ctx.Err() != nil {
+					break
+				}
+				if err != nil {
+					logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+					continue
+				}
+				recordPRs(repoSpec, prs, true)
+			}
+			index = loadPRIndex()
+		}
+
+		writePRIndex(index, repositories)
+	},
+}
+
+// writePRIndex prints the indexed PRs of repositories as "owner/repo#number<TAB>title" lines
+func writePRIndex(index prIndex, repositories []string) {
+	for _, repoSpec := range repositories {
+		repoIndex, ok := index[repoSpec]
+		if !ok {
+			continue
+		}
+		for _, entry := range repoIndex.PRs {
+			streams.Printf("%s#%d\t%s\n", repoSpec, entry.Number, entry.Title)
+		}
+	}
+}
+
+func init() {
+	completionPRsCmd.Flags().BoolVar(&refreshPRIndex, "refresh", false, "Fetch the open pull requests from GitHub first")
+	completionPRsCmd.ValidArgsFunction = completeRepositoryArgs(true)
+
+	// Create cobra's completion command now rather than when the command runs, so prs can be added to it.
+	// The commands defined before this file already make RootCmd a command with subcommands.
+	RootCmd.InitDefaultCompletionCmd()
+	if completionCmd, _, err := RootCmd.Find([]string{"completion"}); err == nil && completionCmd != RootCmd {
+		completionCmd.AddCommand(completionPRsCmd)
+	}
+}
@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("PR completion", func() {
+	var (
+		tempDir      string
+		restoreFetch func()
+		fetchedRepos []string
+		fetchedPRs   []cmd.PullRequest
+		fetchErr     error
+	)
+	openPR := func(number int, title string) cmd.PullRequest {
+		return cmd.PullRequest{Number: number, Title: title, State: "open"}
+	}
+
+	BeforeEach(func() {
+		var err error
+		tempDir, err = os.MkdirTemp("", "ghprs-completion-test")
+		Expect(err).NotTo(HaveOccurred())
+		cmd.SetCacheDir(tempDir)
+		cmd.SetConfigPath(filepath.Join(tempDir, "config.yaml"))
+		fetchedRepos, fetchedPRs, fetchErr = nil, nil, errors.New("offline")
+		restoreFetch = cmd.SetCompletionFetchTest(func(ctx context.Context, owner, repo string) ([]cmd.PullRequest, error) {
+			fetchedRepos = append(fetchedRepos, owner+"/"+repo)
+			return fetchedPRs, fetchErr
+		})
+	})
+
+	AfterEach(func() {
+		restoreFetch()
+		cmd.ResetConfigPath()
+		cmd.ResetCacheDir()
+		_ = os.RemoveAll(tempDir)
+	})
+
+	It("should complete PR numbers with their titles, newest first", func() {
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build"), openPR(12, "Add a feature")}, true)
+
+		Expect(cmd.CompletePRArgsTest([]string{"owner/repo"}, "", false)).To(Equal([]string{"12\tAdd a feature", "3\tFix the build"}))
+		Expect(cmd.CompletePRArgsTest(nil, "owner/repo", false)).To(Equal([]string{"12\tAdd a feature", "3\tFix the build"}))
+		Expect(cmd.CompletePRArgsTest([]string{"other/repo"}, "", false)).To(BeEmpty())
+	})
+
+	It("should complete one PR for single PR commands and more for batch commands", func() {
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build"), openPR(12, "Add a feature")}, true)
+
+		Expect(cmd.CompletePRArgsTest([]string{"owner/repo", "12"}, "", false)).To(BeEmpty())
+		Expect(cmd.CompletePRArgsTest([]string{"owner/repo", "12"}, "", true)).To(Equal([]string{"3\tFix the build"}))
+		Expect(cmd.CompletePRArgsTest([]string{"#3"}, "owner/repo", true)).To(Equal([]string{"12\tAdd a feature"}))
+	})
+
+	It("should add and drop PRs seen by partial listings and replace them after complete ones", func() {
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build"), openPR(12, "Add a feature")}, true)
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(14, "Bump deps"), {Number: 3, State: "closed"}}, false)
+		Expect(cmd.CompletePRArgsTest(nil, "owner/repo", true)).To(Equal([]string{"14\tBump deps", "12\tAdd a feature"}))
+
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(15, "Retitled")}, true)
+		Expect(cmd.CompletePRArgsTest(nil, "owner/repo", true)).To(Equal([]string{"15\tRetitled"}))
+	})
+
+	It("should only treat unfiltered listings of open PRs within the limit as complete", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--limit", "30"}, false)).To(Succeed())
+		Expect(cmd.FetchedEveryOpenPRTest(nil, false, 10)).To(BeTrue())
+		Expect(cmd.FetchedEveryOpenPRTest(nil, false, 30)).To(BeFalse())
+		Expect(cmd.FetchedEveryOpenPRTest([]string{"someone"}, false, 10)).To(BeFalse())
+		Expect(cmd.FetchedEveryOpenPRTest(nil, true, 10)).To(BeFalse())
+
+		Expect(cmd.UseListFlagsTest([]string{"--all", "--target-branch", "main"}, false)).To(Succeed())
+		Expect(cmd.FetchedEveryOpenPRTest(nil, false, 500)).To(BeFalse())
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+	})
+
+	It("should ask GitHub for the PRs of a repository the index doesn't know and remember them", func() {
+		fetchedPRs, fetchErr = []cmd.PullRequest{openPR(7, "New feature")}, nil
+
+		Expect(cmd.CompletePRArgsTest([]string{"owner/new"}, "", false)).To(Equal([]string{"7\tNew feature"}))
+		Expect(cmd.CompletePRArgsTest([]string{"owner/new"}, "", false)).To(Equal([]string{"7\tNew feature"}))
+		Expect(fetchedRepos).To(Equal([]string{"owner/new"}))
+	})
+
+	It("should refresh PRs indexed too long ago", func() {
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build")}, true)
+		cmd.AgePRIndexTest("owner/repo", time.Hour)
+		fetchedPRs, fetchErr = []cmd.PullRequest{openPR(4, "Newer")}, nil
+
+		Expect(cmd.CompletePRArgsTest([]string{"owner/repo"}, "", false)).To(Equal([]string{"4\tNewer"}))
+		Expect(fetchedRepos).To(Equal([]string{"owner/repo"}))
+	})
+
+	It("should complete from the index when GitHub can't be reached", func() {
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build")}, true)
+		cmd.AgePRIndexTest("owner/repo", time.Hour)
+
+		Expect(cmd.CompletePRArgsTest([]string{"owner/repo"}, "", false)).To(Equal([]string{"3\tFix the build"}))
+		Expect(fetchedRepos).To(Equal([]string{"owner/repo"}))
+	})
+
+	It("should complete the configured and indexed repositories", func() {
+		Expect(os.WriteFile(filepath.Join(tempDir, "config.yaml"), []byte("repositories:\n  - name: owner/configured\n"), 0o644)).To(Succeed())
+		cmd.RecordPRsTest("owner/indexed", []cmd.PullRequest{openPR(3, "Fix the build")}, true)
+
+		Expect(cmd.CompleteRepositoryArgsTest(nil, false)).To(Equal([]string{"owner/configured", "owner/indexed"}))
+		Expect(cmd.CompleteRepositoryArgsTest([]string{"owner/configured"}, true)).To(Equal([]string{"owner/indexed"}))
+		Expect(cmd.CompleteRepositoryArgsTest([]string{"owner/configured"}, false)).To(BeEmpty())
+	})
+
+	It("should print the index for fuzzy finders", func() {
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+		defer cmd.ResetIOStreams()
+		cmd.RecordPRsTest("owner/repo", []cmd.PullRequest{openPR(3, "Fix the build")}, true)
+		cmd.RecordPRsTest("owner/other", []cmd.PullRequest{openPR(8, "Docs")}, true)
+
+		cmd.WritePRIndexTest([]string{"owner/repo", "owner/other", "owner/unknown"})
+		Expect(out.String()).To(Equal("owner/repo#3\tFix the build\nowner/other#8\tDocs\n"))
+	})
+})
@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+	"sync"
+)
+
+var (
+	konfluxComponentsMutex sync.RWMutex
+	// konfluxComponents are the configured mappings of repositories to Konflux applications and components
+	konfluxComponents []KonfluxComponent
+)
+
+// setKonfluxComponents remembers the configured Konflux component mappings
+func setKonfluxComponents(config *Config) {
+	konfluxComponentsMutex.Lock()
+	defer konfluxComponentsMutex.Unlock()
+	konfluxComponents = slices.Clone(config.Konflux.Components)
+}
+
+// validateComponentRepository checks that a mapping's repository is "owner/repo" or "owner/*"
+func validateComponentRepository(repository string) bool {
+	_, _, ok := parseRepoSpec(repository)
+	return ok && !strings.HasPrefix(repository, "*/")
+}
+
+// konfluxComponentFor returns the Konflux application and component of PRs of owner/repo targeting branch,
+// preferring a mapping of the repository over one of its owner and a mapping of the branch over one without.
+// It returns false when no mapping applies.
+func konfluxComponentFor(owner, repo, branch string) (KonfluxComponent, bool) {
+	konfluxComponentsMutex.RLock()
+	defer konfluxComponentsMutex.RUnlock()
+
+	best, bestScore := KonfluxComponent{}, 0
+	for _, mapping := range konfluxComponents {
+		score := 0
+		switch {
+		case strings.EqualFold(mapping.Repository, owner+"/"+repo):
+			score = 2
+		case strings.EqualFold(mapping.Repository, owner+"/*"):
+			score = 1
+		default:
+			continue
+		}
+		switch mapping.Branch {
+		case "":
+		case branch:
+			// A branch outweighs the repository, so a release branch of the organization keeps its component
+			score += 2
+		default:
+			continue
+		}
+		if score > bestScore {
+			best, bestScore = mapping, score
+		}
+	}
+	if bestScore == 0 {
+		return KonfluxComponent{}, false
+	}
+	best.Component = cmp.Or(best.Component, repo)
+	return best, true
+}
+
+// applicationFor returns the Konflux application a repository is grouped under on the dashboard, from the
+// mapping that applies to all of its branches ("" when there is none)
+func applicationFor(repoSpec string) string {
+	owner, repo, ok := parseRepoSpec(repoSpec)
+	if !ok {
+		return ""
+	}
+	mapping, _ := konfluxComponentFor(owner, repo, "")
+	return mapping.Application
+}
+
+// groupByApplication orders repositories by their Konflux application, keeping the configured order within an
+// application and leaving repositories without one last. It returns false when no repository has an application.
+func groupByApplication(repositories []string) ([]string, bool) {
+	if !slices.ContainsFunc(repositories, func(repoSpec string) bool { return applicationFor(repoSpec) != "" }) {
+		return repositories, false
+	}
+	var applications []string
+	for _, repoSpec := range repositories {
+		if application := applicationFor(repoSpec); application != "" && !slices.Contains(applications, application) {
+			applications = append(applications, application)
+		}
+	}
+	rank := func(repoSpec string) int {
+		if index := slices.Index(applications, applicationFor(repoSpec)); index >= 0 {
+			return index
+		}
+		return len(applications)
+	}
+	grouped := slices.Clone(repositories)
+	slices.SortStableFunc(grouped, func(a, b string) int { return rank(a) - rank(b) })
+	return grouped, true
+}
+
+// applicationHeaders prints a header before each Konflux application's group of repositories
+type applicationHeaders struct {
+	// enabled is false when no repository has an application, so no headers are printed
+	enabled bool
+	started bool
+	current string
+}
+
+// print prints the header of repoSpec's application when repoSpec starts a new group
+func (h *applicationHeaders) print(repoSpec string) {
+	if !h.enabled {
+		return
+	}
+	application := applicationFor(repoSpec)
+	if h.started && application == h.current {
+		return
+	}
+	h.started, h.current = true, application
+	if application == "" {
+		streams.Printf("\n##### Repositories without a Konflux application #####\n")
+	} else {
+		streams.Printf("\n##### Application: %s #####\n", application)
+	}
+}
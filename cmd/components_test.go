@@ -0,0 +1,132 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Konflux components", func() {
+	var config *cmd.Config
+
+	BeforeEach(func() {
+		config = cmd.DefaultConfig()
+		config.Konflux.Components = []cmd.KonfluxComponent{
+			{Repository: "my-org/*", Application: "platform"},
+			{Repository: "my-org/operator", Application: "operator-app", Component: "operator"},
+			{Repository: "my-org/operator", Branch: "release-1.2", Application: "operator-app-1-2", Component: "operator-1-2"},
+			{Repository: "my-org/*", Branch: "release-2.0", Application: "platform-2-0"},
+		}
+		cmd.SetKonfluxComponentsTest(config)
+	})
+
+	AfterEach(func() {
+		cmd.SetKonfluxComponentsTest(cmd.DefaultConfig())
+	})
+
+	Describe("resolving the component of a PR", func() {
+		It("should prefer the repository's mapping over its organization's", func() {
+			mapping, ok := cmd.KonfluxComponentForTest("my-org", "operator", "main")
+			Expect(ok).To(BeTrue())
+			Expect(mapping.Application).To(Equal("operator-app"))
+			Expect(mapping.Component).To(Equal("operator"))
+		})
+
+		It("should prefer a mapping of the target branch", func() {
+			mapping, ok := cmd.KonfluxComponentForTest("my-org", "operator", "release-1.2")
+			Expect(ok).To(BeTrue())
+			Expect(mapping.Component).To(Equal("operator-1-2"))
+
+			mapping, ok = cmd.KonfluxComponentForTest("my-org", "operator", "release-2.0")
+			Expect(ok).To(BeTrue())
+			Expect(mapping.Application).To(Equal("platform-2-0"))
+		})
+
+		It("should use the repository name as the component of an organization's mapping", func() {
+			mapping, ok := cmd.KonfluxComponentForTest("my-org", "console", "main")
+			Expect(ok).To(BeTrue())
+			Expect(mapping.Application).To(Equal("platform"))
+			Expect(mapping.Component).To(Equal("console"))
+		})
+
+		It("should not map repositories of other organizations", func() {
+			_, ok := cmd.KonfluxComponentForTest("other-org", "operator", "main")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Describe("grouping the dashboard by application", func() {
+		It("should keep the repositories of an application together, leaving unmapped ones last", func() {
+			grouped, ok := cmd.GroupByApplicationTest([]string{"other/tool", "my-org/console", "my-org/operator", "my-org/docs"})
+			Expect(ok).To(BeTrue())
+			Expect(grouped).To(Equal([]string{"my-org/console", "my-org/docs", "my-org/operator", "other/tool"}))
+		})
+
+		It("should leave the repositories alone when none has an application", func() {
+			repositories := []string{"other/b", "other/a"}
+			grouped, ok := cmd.GroupByApplicationTest(repositories)
+			Expect(ok).To(BeFalse())
+			Expect(grouped).To(Equal(repositories))
+		})
+
+		It("should print a header before each application", func() {
+			out := &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+			defer cmd.ResetIOStreams()
+
+			cmd.PrintApplicationHeadersTest([]string{"other/tool", "my-org/console", "my-org/operator", "my-org/docs"})
+			Expect(out.String()).To(Equal("\n##### Application: platform #####\nmy-org/console\nmy-org/docs\n" +
+				"\n##### Application: operator-app #####\nmy-org/operator\n" +
+				"\n##### Repositories without a Konflux application #####\nother/tool\n"))
+		})
+	})
+
+	Describe("the PR table", func() {
+		var out *bytes.Buffer
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should show the component of each PR", func() {
+			cmd.RenderPRTableTest([]cmd.PRRow{{Number: 1, Title: "Update tekton", State: "open", Component: "operator-1-2"}}, "my-org", "operator")
+			Expect(out.String()).To(ContainSubstring("COMPONENT"))
+			Expect(out.String()).To(ContainSubstring("operator-1-2"))
+		})
+
+		It("should not have a component column without components", func() {
+			cmd.RenderPRTableTest([]cmd.PRRow{{Number: 1, Title: "Update tekton", State: "open"}}, "other", "tool")
+			Expect(out.String()).NotTo(ContainSubstring("COMPONENT"))
+		})
+	})
+
+	Describe("configuring mappings", func() {
+		It("should replace the mapping of the same repository and branch", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.SetKonfluxComponent(cmd.KonfluxComponent{Repository: "my-org/operator", Application: "a"})).To(BeTrue())
+			Expect(config.SetKonfluxComponent(cmd.KonfluxComponent{Repository: "my-org/operator", Application: "a"})).To(BeFalse())
+			Expect(config.SetKonfluxComponent(cmd.KonfluxComponent{Repository: "my-org/operator", Application: "b"})).To(BeTrue())
+			Expect(config.SetKonfluxComponent(cmd.KonfluxComponent{Repository: "my-org/operator", Branch: "release-1.0", Application: "c"})).To(BeTrue())
+			Expect(config.Konflux.Components).To(Equal([]cmd.KonfluxComponent{
+				{Repository: "my-org/operator", Application: "b"},
+				{Repository: "my-org/operator", Branch: "release-1.0", Application: "c"},
+			}))
+		})
+
+		It("should accept repositories and organizations", func() {
+			Expect(cmd.ValidateComponentRepositoryTest("my-org/operator")).To(BeTrue())
+			Expect(cmd.ValidateComponentRepositoryTest("my-org/*")).To(BeTrue())
+			Expect(cmd.ValidateComponentRepositoryTest("*/operator")).To(BeFalse())
+			Expect(cmd.ValidateComponentRepositoryTest("operator")).To(BeFalse())
+		})
+	})
+})
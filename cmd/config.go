@@ -4,14 +4,176 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
+	"time"
 
 	"gopkg.in/yaml.v3"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
 )
 
 // RepositoryConfig represents a single repository configuration
 type RepositoryConfig struct {
-	Name    string `yaml:"name"`
-	Konflux bool   `yaml:"konflux,omitempty"`
+	Name    string `yaml:"name" json:"name"`
+	Konflux bool   `yaml:"konflux,omitempty" json:"konflux"`
+	// Host is the GitHub Enterprise host the repository lives on, overriding the global host
+	Host string `yaml:"host,omitempty" json:"host,omitempty"`
+	// Authors are additional bot authors (e.g. renovate[bot]) whose PRs 'ghprs konflux' lists next to Konflux's
+	Authors []string `yaml:"authors,omitempty" json:"authors,omitempty"`
+	// TektonFiles are the .tekton files the repository is expected to have; Konflux PRs adding or removing
+	// others are flagged as structural changes
+	TektonFiles []string `yaml:"tekton_files,omitempty" json:"tekton_files,omitempty"`
+	// TektonPatterns are the Tekton pipelines of the repository, as path.Match patterns such as
+	// ".tekton/*-build.yaml", that --tekton-only, the TEKTON column and tekton_only rules look for; unset looks
+	// for .tekton/*-pull-request.yaml and .tekton/*-push.yaml
+	TektonPatterns []string `yaml:"tekton_patterns,omitempty" json:"tekton_patterns,omitempty"`
+	// State and Limit replace the defaults for this repository unless --state and --limit are given
+	State string `yaml:"state,omitempty" json:"state,omitempty"`
+	Limit int    `yaml:"limit,omitempty" json:"limit,omitempty"`
+	// Sort is the order the repository's PRs are shown in unless --sort-by is given: newest, oldest, updated,
+	// number or priority
+	Sort string `yaml:"sort,omitempty" json:"sort,omitempty"`
+	// AuthorFilter lists only the PRs of these authors with 'ghprs list' unless --author is given
+	AuthorFilter []string `yaml:"author_filter,omitempty" json:"author_filter,omitempty"`
+	// Template replaces konflux.template for this repository
+	Template []string `yaml:"template,omitempty" json:"template,omitempty"`
+	// Approval replaces the approval settings for this repository, field by field
+	Approval *ApprovalSettings `yaml:"approval,omitempty" json:"approval,omitempty"`
+}
+
+// CacheConfig controls the on-disk cache of PR details
+type CacheConfig struct {
+	// TTL is a duration such as "5m"; "0" disables the cache
+	TTL string `yaml:"ttl,omitempty"`
+}
+
+// RateLimitConfig controls how ghprs reacts to the GitHub API rate limit
+type RateLimitConfig struct {
+	// Threshold is the remaining quota at which requests pause until the limit resets
+	Threshold *int `yaml:"threshold,omitempty"`
+	// RepoBudget caps the requests made for the details of each repository's PRs when several are listed; 0 disables it
+	RepoBudget *int `yaml:"repo_budget,omitempty"`
+	// Retries is how often requests failing with a server, secondary rate limit or network error are retried, writes
+	// only when they didn't reach GitHub; 0 disables it
+	Retries *int `yaml:"retries,omitempty"`
+}
+
+// ChecksConfig controls how PR checks are reported
+type ChecksConfig struct {
+	// StaleAfter is a duration such as "1h" after which a pending check is reported as stale; "0" never does
+	StaleAfter string `yaml:"stale_after,omitempty"`
+	// RetestComments are posted to re-run failed status checks of Prow-managed repositories; unset posts "/retest"
+	RetestComments []string `yaml:"retest_comments,omitempty"`
+}
+
+// KonfluxConfig controls the checks made on Konflux PRs
+type KonfluxConfig struct {
+	// ImagePinning is the image pinning policy: digest (default) flags images unpinned from a digest,
+	// tag flags images pinned to a digest and off disables the check
+	ImagePinning string `yaml:"image_pinning,omitempty"`
+	// Components map repositories to the Konflux applications and components built from them
+	Components []KonfluxComponent `yaml:"components,omitempty"`
+	// Template lists the files, or patterns, every Konflux repository is expected to have, {component} standing
+	// for its component; unset expects the pull request and push pipelines
+	Template []string `yaml:"template,omitempty"`
+}
+
+// KonfluxComponent maps a repository, or every repository of an owner, to a Konflux application and component
+type KonfluxComponent struct {
+	// Repository is "owner/repo", or "owner/*" for every repository of an organization
+	Repository string `yaml:"repository"`
+	// Branch limits the mapping to PRs targeting a branch, e.g. a release branch built as its own component
+	Branch      string `yaml:"branch,omitempty"`
+	Application string `yaml:"application"`
+	// Component is the Konflux component; unset uses the repository name
+	Component string `yaml:"component,omitempty"`
+}
+
+// DisplayConfig controls how PR tables are displayed
+type DisplayConfig struct {
+	// Legend is when the legend is shown: once (default), always or never
+	Legend string `yaml:"legend,omitempty"`
+	// Columns maps a text column (title, author, branch, target, repo, component) to its width: a number or "auto" to fit the widest value
+	Columns map[string]string `yaml:"columns,omitempty"`
+	// Emoji is whether the tables show emoji: auto (default, ASCII tokens on terminals that can't draw emoji), always or never
+	Emoji string `yaml:"emoji,omitempty"`
+	// DiffMode is how diffs are shown: unified (default), split or word
+	DiffMode string `yaml:"diff_mode,omitempty"`
+	// StaleAfter is how long a PR may go without an update before the AGE column marks it stale, such as 14d
+	// (default) or 36h; "0" never does
+	StaleAfter string `yaml:"stale_after,omitempty"`
+}
+
+// Review events an approval can post
+const (
+	ReviewEventApprove = ghprs.ReviewEventApprove
+	ReviewEventComment = ghprs.ReviewEventComment
+	// ReviewEventRequestChanges is only posted from the approval prompt, never as the approval itself
+	ReviewEventRequestChanges = ghprs.ReviewEventRequestChanges
+)
+
+// Rules and their conditions are shared with the public Go API
+type (
+	Rule           = ghprs.Rule
+	RuleConditions = ghprs.RuleConditions
+)
+
+// defaultApprovalBody is the review body posted when the config doesn't set one
+const defaultApprovalBody = "/lgtm"
+
+// ApprovalSettings controls what an approval posts
+type ApprovalSettings struct {
+	// Body is the review body; unset posts "/lgtm" and "" posts a review without a body
+	Body *string `yaml:"body,omitempty" json:"body,omitempty"`
+	// Event is the review event: APPROVE (default) or COMMENT
+	Event string `yaml:"event,omitempty" json:"event,omitempty"`
+	// ExtraComments are posted as separate comments after the review, such as "/approve" for Prow
+	ExtraComments []string `yaml:"extra_comments,omitempty" json:"extra_comments,omitempty"`
+	// VerifyTimeout is a duration such as "1m" to wait for Prow to label an approved PR; "0" only checks the review
+	VerifyTimeout string `yaml:"verify_timeout,omitempty" json:"verify_timeout,omitempty"`
+	// MaxChanges is how many lines a PR by a bot or trusted author may change before approving it needs its
+	// number typed; 0 (default) sets no limit
+	MaxChanges int `yaml:"max_changes,omitempty" json:"max_changes,omitempty"`
+}
+
+// ReviewBody returns the review body to post, falling back to "/lgtm" when unset
+func (a ApprovalSettings) ReviewBody() string {
+	if a.Body == nil {
+		return defaultApprovalBody
+	}
+	return *a.Body
+}
+
+// VerificationTimeout returns how long to wait for Prow to act on an approval, falling back to the default
+// when unset or invalid
+func (a ApprovalSettings) VerificationTimeout() time.Duration {
+	if a.VerifyTimeout == "" {
+		return defaultApprovalVerifyTimeout
+	}
+	timeout, err := time.ParseDuration(a.VerifyTimeout)
+	if err != nil || timeout < 0 {
+		return defaultApprovalVerifyTimeout
+	}
+	return timeout
+}
+
+// ReviewEvent returns the review event to post, falling back to APPROVE for unset or invalid values
+func (a ApprovalSettings) ReviewEvent() string {
+	if validateReviewEvent(a.Event) != nil {
+		return ReviewEventApprove
+	}
+	return a.Event
+}
+
+// validateReviewEvent checks that event is a review event an approval can post
+func validateReviewEvent(event string) error {
+	switch event {
+	case ReviewEventApprove, ReviewEventComment:
+		return nil
+	default:
+		return fmt.Errorf("invalid review event %q, must be one of: %s, %s", event, ReviewEventApprove, ReviewEventComment)
+	}
 }
 
 // Config represents the application configuration
@@ -21,6 +183,36 @@ type Config struct {
 		State string `yaml:"state"`
 		Limit int    `yaml:"limit"`
 	} `yaml:"defaults"`
+	Cache     CacheConfig     `yaml:"cache,omitempty"`
+	RateLimit RateLimitConfig `yaml:"rate_limit,omitempty"`
+	Display   DisplayConfig   `yaml:"display,omitempty"`
+	Checks    ChecksConfig    `yaml:"checks,omitempty"`
+	Konflux   KonfluxConfig   `yaml:"konflux,omitempty"`
+	// Host is the GitHub Enterprise host to use for repositories without their own host
+	Host     string           `yaml:"host,omitempty"`
+	Approval ApprovalSettings `yaml:"approval,omitempty"`
+	// Rules decide what 'ghprs konflux --auto' does to each PR, the first matching rule winning
+	Rules []Rule `yaml:"rules,omitempty"`
+	// Trust lists the authors whose changes to CI and ownership files are approved without extra confirmation
+	Trust TrustConfig `yaml:"trust,omitempty"`
+	// UI sets the pager and editor ghprs uses
+	UI UIConfig `yaml:"ui,omitempty"`
+	// Notifications sets the webhooks PRs with migration warnings or failing checks are posted to
+	Notifications NotificationsConfig `yaml:"notifications,omitempty"`
+	// Priority sets how --sort-by priority weighs PRs
+	Priority PriorityConfig `yaml:"priority,omitempty"`
+	// Templates are the comments 'm' at the approval prompt offers by name, with {{author}}, {{number}} and
+	// other variables filled in for the PR
+	Templates map[string]string `yaml:"templates,omitempty"`
+	// Accounts maps a GitHub host to the account of gh's stored auth to use there, for gh logged in to several
+	Accounts map[string]string `yaml:"accounts,omitempty"`
+}
+
+// PriorityConfig represents how the priority sort weighs PRs
+type PriorityConfig struct {
+	// Weights maps a factor (security, migration, tekton-only, failing-checks, staleness) to its weight,
+	// replacing its default
+	Weights map[string]float64 `yaml:"weights,omitempty"`
 }
 
 // DefaultConfig returns the default configuration
@@ -39,14 +231,21 @@ func DefaultConfig() *Config {
 
 // LoadConfig loads configuration from the config file
 func LoadConfig() (*Config, error) {
-	configPath := getConfigPath()
+	if err := validateProfileName(activeProfile()); err != nil {
+		return nil, err
+	}
+	path := getConfigPath()
 
-	// If config file doesn't exist, return default config
-	if _, err := os.Stat(configPath); os.IsNotExist(err) {
+	// If config file doesn't exist, return default config, unless a profile was asked for: a misspelled
+	// profile mustn't silently fall back to the defaults
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		if profile := activeProfile(); profile != "" && configPath == "" {
+			return nil, fmt.Errorf("profile %q doesn't exist, create it with 'ghprs --profile %s config init'", profile, profile)
+		}
 		return DefaultConfig(), nil
 	}
 
-	data, err := os.ReadFile(configPath)
+	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
@@ -55,12 +254,16 @@ func LoadConfig() (*Config, error) {
 	if err := yaml.Unmarshal(data, &config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
+	warnConfigIssues(path, data)
 
 	return &config, nil
 }
 
 // SaveConfig saves the configuration to the config file
 func SaveConfig(config *Config) error {
+	if err := validateProfileName(activeProfile()); err != nil {
+		return err
+	}
 	configPath := getConfigPath()
 
 	// Create config directory if it doesn't exist
@@ -81,7 +284,7 @@ func SaveConfig(config *Config) error {
 	return nil
 }
 
-// configPath can be overridden for testing
+// configPath is the config file of --config or GHPRS_CONFIG, also overridden for testing
 var configPath string
 
 // SetConfigPath sets a custom config path (used for testing)
@@ -101,12 +304,34 @@ func getConfigPath() string {
 		return configPath
 	}
 
-	homeDir, err := os.UserHomeDir()
-	if err != nil {
+	dir, ok := configDir()
+	if !ok {
 		// Fallback to current directory
 		return ".ghprs.yaml"
 	}
-	return filepath.Join(homeDir, ".config", "ghprs", "config.yaml")
+	if profile := activeProfile(); profile != "" {
+		return filepath.Join(dir, profilesDir, profile+".yaml")
+	}
+	return filepath.Join(dir, "config.yaml")
+}
+
+// configDir returns the directory of the config file, which also keeps the local state such as snoozed PRs.
+// Profiles share it, so their state is kept together. It returns false when the home directory is unknown.
+func configDir() (string, bool) {
+	if configPath != "" {
+		return filepath.Dir(configPath), true
+	}
+	homeDir, err := os.UserHomeDir()
+	if err != nil {
+		return ".", false
+	}
+	return filepath.Join(homeDir, ".config", "ghprs"), true
+}
+
+// stateDir returns the directory the local state files are kept in, next to the config file
+func stateDir() string {
+	dir, _ := configDir()
+	return dir
 }
 
 // GetConfigPath returns the configuration file path (exported for CLI commands)
@@ -114,6 +339,182 @@ func GetConfigPath() string {
 	return getConfigPath()
 }
 
+// CacheTTL returns how long cached PR data is reused, falling back to the default for unset or invalid values
+func (c *Config) CacheTTL() time.Duration {
+	if c.Cache.TTL == "" {
+		return defaultCacheTTL
+	}
+	ttl, err := time.ParseDuration(c.Cache.TTL)
+	if err != nil || ttl < 0 {
+		return defaultCacheTTL
+	}
+	return ttl
+}
+
+// RateLimitThreshold returns the remaining quota at which requests pause, falling back to the default when unset
+func (c *Config) RateLimitThreshold() int {
+	if c.RateLimit.Threshold == nil || *c.RateLimit.Threshold < 0 {
+		return defaultRateLimitThreshold
+	}
+	return *c.RateLimit.Threshold
+}
+
+// APIRetries returns how often requests that failed transiently are retried, falling back to the default for
+// unset or invalid values
+func (c *Config) APIRetries() int {
+	if c.RateLimit.Retries == nil || *c.RateLimit.Retries < 0 {
+		return defaultAPIRetries
+	}
+	return *c.RateLimit.Retries
+}
+
+// RepoRequestBudget returns how many requests the details of each repository's PRs may take when several
+// repositories are listed, 0 for no budget, falling back to the default for unset or invalid values
+func (c *Config) RepoRequestBudget() int {
+	if c.RateLimit.RepoBudget == nil || *c.RateLimit.RepoBudget < 0 {
+		return defaultRepoRequestBudget
+	}
+	return *c.RateLimit.RepoBudget
+}
+
+// StaleCheckAfter returns how long a check may be pending before it is reported as stale,
+// falling back to the default for unset or invalid values
+func (c *Config) StaleCheckAfter() time.Duration {
+	if c.Checks.StaleAfter == "" {
+		return defaultStaleCheckAfter
+	}
+	after, err := time.ParseDuration(c.Checks.StaleAfter)
+	if err != nil || after < 0 {
+		return defaultStaleCheckAfter
+	}
+	return after
+}
+
+// RetestComments returns the comments posted to re-run failed checks of Prow-managed repositories
+func (c *Config) RetestComments() []string {
+	if len(c.Checks.RetestComments) == 0 {
+		return []string{defaultRetestComment}
+	}
+	return c.Checks.RetestComments
+}
+
+// ImagePinningPolicy returns the image pinning policy for Konflux diffs, falling back to digest for unset or invalid values
+func (c *Config) ImagePinningPolicy() string {
+	if validatePinningPolicy(c.Konflux.ImagePinning) != nil {
+		return PinningDigest
+	}
+	return c.Konflux.ImagePinning
+}
+
+// LegendMode returns when the table legend is shown, falling back to once per run for unset or invalid values
+func (c *Config) LegendMode() string {
+	if render.ValidateLegendMode(c.Display.Legend) != nil {
+		return render.LegendOnce
+	}
+	return c.Display.Legend
+}
+
+// DiffMode returns how diffs are shown, falling back to unified for unset or invalid values
+func (c *Config) DiffMode() string {
+	if render.ValidateDiffMode(c.Display.DiffMode) != nil {
+		return render.DiffUnified
+	}
+	return c.Display.DiffMode
+}
+
+// HostFor returns the host configured for a repository, falling back to the global host ("" when neither is set)
+func (c *Config) HostFor(repo string) string {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo && existingRepo.Host != "" {
+			return existingRepo.Host
+		}
+	}
+	return c.Host
+}
+
+// SetRepositoryHost sets the host of a configured repository, returning false if it isn't configured or already uses host
+func (c *Config) SetRepositoryHost(repo, host string) bool {
+	for i, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			if existingRepo.Host == host {
+				return false
+			}
+			c.Repositories[i].Host = host
+			return true
+		}
+	}
+	return false
+}
+
+// AuthorsFor returns the additional bot authors configured for a repository
+func (c *Config) AuthorsFor(repo string) []string {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			return existingRepo.Authors
+		}
+	}
+	return nil
+}
+
+// AddRepositoryAuthors adds bot authors to a configured repository, returning false if it isn't configured
+// or already has all of them
+func (c *Config) AddRepositoryAuthors(repo string, authors []string) bool {
+	for i, existingRepo := range c.Repositories {
+		if existingRepo.Name != repo {
+			continue
+		}
+		changed := false
+		for _, author := range authors {
+			if author == "" || slices.Contains(c.Repositories[i].Authors, author) {
+				continue
+			}
+			c.Repositories[i].Authors = append(c.Repositories[i].Authors, author)
+			changed = true
+		}
+		return changed
+	}
+	return false
+}
+
+// TektonBaselines returns the expected .tekton files of the repositories that have a baseline, by "owner/repo"
+func (c *Config) TektonBaselines() map[string][]string {
+	baselines := make(map[string][]string)
+	for _, repo := range c.Repositories {
+		if len(repo.TektonFiles) > 0 {
+			baselines[repo.Name] = repo.TektonFiles
+		}
+	}
+	return baselines
+}
+
+// SetTektonBaseline records the expected .tekton files of a configured repository, returning false if it
+// isn't configured
+func (c *Config) SetTektonBaseline(repo string, files []string) bool {
+	for i, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			c.Repositories[i].TektonFiles = files
+			return true
+		}
+	}
+	return false
+}
+
+// SetKonfluxComponent adds a Konflux component mapping, replacing the one for the same repository and branch.
+// It returns false if that mapping is already configured.
+func (c *Config) SetKonfluxComponent(mapping KonfluxComponent) bool {
+	for i, existing := range c.Konflux.Components {
+		if existing.Repository == mapping.Repository && existing.Branch == mapping.Branch {
+			if existing == mapping {
+				return false
+			}
+			c.Konflux.Components[i] = mapping
+			return true
+		}
+	}
+	c.Konflux.Components = append(c.Konflux.Components, mapping)
+	return true
+}
+
 // GetRepositories returns the appropriate repository list based on whether it's Konflux or not
 func (c *Config) GetRepositories(isKonflux bool) []string {
 	var repos []string
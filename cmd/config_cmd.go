@@ -3,9 +3,22 @@ package cmd
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+var (
+	// repoHost is the host given to add-repo and add-konflux-repo with --host
+	repoHost string
+	// repoAuthors are the bot authors given to add-konflux-repo with --author
+	repoAuthors []string
+	// componentBranch is the branch given to set-konflux-component with --branch
+	componentBranch string
 )
 
 // configShowCmd shows the current configuration
@@ -20,17 +33,114 @@ var configShowCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Printf("Configuration file: %s\n\n", GetConfigPath())
+		fmt.Printf("Configuration file: %s\n", GetConfigPath())
+		if profile := activeProfile(); profile != "" {
+			fmt.Printf("Profile: %s\n", profile)
+		}
+		fmt.Println()
 
 		fmt.Println("Current configuration:")
 		fmt.Printf("  Default State: %s\n", config.Defaults.State)
 		fmt.Printf("  Default Limit: %d\n", config.Defaults.Limit)
+		fmt.Printf("  Cache TTL: %s\n", config.CacheTTL())
+		fmt.Printf("  Rate Limit Threshold: %d\n", config.RateLimitThreshold())
+		fmt.Printf("  Repo Request Budget: %s\n", describeRepoRequestBudget(config.RepoRequestBudget()))
+		fmt.Printf("  API Retries: %d\n", config.APIRetries())
+		fmt.Printf("  Legend: %s\n", config.LegendMode())
+		fmt.Printf("  Emoji: %s\n", config.EmojiMode())
+		fmt.Printf("  Diff Mode: %s\n", config.DiffMode())
+		fmt.Printf("  Stale Check After: %s\n", config.StaleCheckAfter())
+		fmt.Printf("  Stale PR After: %s\n", config.StalePRAfter())
+		fmt.Printf("  Retest Comments: %s\n", strings.Join(config.RetestComments(), ", "))
+		fmt.Printf("  Image Pinning: %s\n", config.ImagePinningPolicy())
+		fmt.Printf("  Trusted Authors: %s\n", strings.Join(config.TrustedAuthors(), ", "))
+		fmt.Printf("  Pager: %s\n", config.UI.PagerCommand())
+		fmt.Printf("  Editor: %s\n", config.UI.EditorCommand())
+		if config.UI.Browser != "" {
+			fmt.Printf("  Browser: %s\n", config.UI.Browser)
+		}
+		if config.Notifications.SlackWebhook != "" {
+			fmt.Println("  Slack Webhook: (set)")
+		}
+		if len(config.Notifications.Webhooks) > 0 {
+			fmt.Printf("  Webhooks: %s\n", strings.Join(config.Notifications.Webhooks, ", "))
+		}
+		if len(config.Display.Columns) > 0 {
+			var widths []string
+			for _, column := range []string{render.ColumnTitle, render.ColumnAuthor, render.ColumnBranch, render.ColumnTarget} {
+				if width, ok := config.Display.Columns[column]; ok {
+					widths = append(widths, column+"="+width)
+				}
+			}
+			fmt.Printf("  Column Widths: %s\n", strings.Join(widths, ", "))
+		}
+		if len(config.Priority.Weights) > 0 {
+			var weights []string
+			for _, name := range priorityFactorNames() {
+				if weight, ok := config.Priority.Weights[name]; ok {
+					weights = append(weights, name+"="+formatPoints(weight))
+				}
+			}
+			fmt.Printf("  Priority Weights: %s\n", strings.Join(weights, ", "))
+		}
+		if len(config.Accounts) > 0 {
+			var accounts []string
+			for host, account := range config.Accounts {
+				accounts = append(accounts, host+"="+account)
+			}
+			sort.Strings(accounts)
+			fmt.Printf("  Accounts: %s\n", strings.Join(accounts, ", "))
+		}
+		if len(config.Templates) > 0 {
+			fmt.Printf("  Comment Templates: %s\n", strings.Join(commentTemplateNames(config.Templates), ", "))
+		}
+		if config.Host != "" {
+			fmt.Printf("  Host: %s\n", config.Host)
+		}
+		fmt.Printf("  Approval: %s review with body %q\n", config.Approval.ReviewEvent(), config.Approval.ReviewBody())
+		if len(config.Approval.ExtraComments) > 0 {
+			fmt.Printf("  Approval Extra Comments: %s\n", strings.Join(config.Approval.ExtraComments, ", "))
+		}
 
 		if len(config.Repositories) > 0 {
 			fmt.Println("  Repositories:")
 			for _, repo := range config.Repositories {
+				var notes []string
 				if repo.Konflux {
-					fmt.Printf("    - %s (Konflux)\n", repo.Name)
+					notes = append(notes, "Konflux")
+				}
+				if repo.Host != "" {
+					notes = append(notes, repo.Host)
+				}
+				if len(repo.Authors) > 0 {
+					notes = append(notes, "authors: "+strings.Join(repo.Authors, " "))
+				}
+				if len(repo.TektonFiles) > 0 {
+					notes = append(notes, fmt.Sprintf("%d expected .tekton files", len(repo.TektonFiles)))
+				}
+				if len(repo.TektonPatterns) > 0 {
+					notes = append(notes, "Tekton files: "+strings.Join(repo.TektonPatterns, " "))
+				}
+				if repo.State != "" {
+					notes = append(notes, "state: "+repo.State)
+				}
+				if repo.Limit > 0 {
+					notes = append(notes, fmt.Sprintf("limit: %d", repo.Limit))
+				}
+				if repo.Sort != "" {
+					notes = append(notes, "sort: "+repo.Sort)
+				}
+				if len(repo.AuthorFilter) > 0 {
+					notes = append(notes, "author filter: "+strings.Join(repo.AuthorFilter, " "))
+				}
+				if len(repo.Template) > 0 {
+					notes = append(notes, fmt.Sprintf("%d template files", len(repo.Template)))
+				}
+				if repo.Approval != nil {
+					notes = append(notes, "own approval settings")
+				}
+				if len(notes) > 0 {
+					fmt.Printf("    - %s (%s)\n", repo.Name, strings.Join(notes, ", "))
 				} else {
 					fmt.Printf("    - %s\n", repo.Name)
 				}
@@ -87,7 +197,9 @@ var configAddRepoCmd = &cobra.Command{
 		}
 
 		// Add the repository using the helper method
-		if !config.AddRepository(repo, false) {
+		added := config.AddRepository(repo, false)
+		hostChanged := repoHost != "" && config.SetRepositoryHost(repo, repoHost)
+		if !added && !hostChanged {
 			fmt.Printf("Repository %s is already in the configuration\n", repo)
 			return
 		}
@@ -97,16 +209,22 @@ var configAddRepoCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Printf("Added repository %s to configuration\n", repo)
+		if added {
+			fmt.Printf("Added repository %s to configuration\n", repo)
+		}
+		if hostChanged {
+			fmt.Printf("Set host of repository %s to %s\n", repo, repoHost)
+		}
 	},
 }
 
 // configRemoveRepoCmd removes a repository from the configuration
 var configRemoveRepoCmd = &cobra.Command{
-	Use:   "remove-repo <owner/repo>",
-	Short: "Remove a repository from default list",
-	Long:  `Remove a repository from the default repositories list in the configuration.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "remove-repo <owner/repo>",
+	Short:             "Remove a repository from default list",
+	Long:              `Remove a repository from the default repositories list in the configuration.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRepositoryArgs(false),
 	Run: func(cmd *cobra.Command, args []string) {
 		repo := args[0]
 
@@ -137,7 +255,56 @@ var configSetCmd = &cobra.Command{
 	Short: "Set a configuration value",
 	Long: `Set a configuration value. Available keys:
   - state: default state filter (open, closed, all)
-  - limit: default limit for number of results`,
+  - limit: default limit for number of results
+  - cache-ttl: how long cached PR details are reused (e.g. 5m, 1h, 0 to disable)
+  - rate-limit-threshold: remaining API quota at which requests pause until the limit resets
+  - repo-request-budget: API requests the details of each repository's PRs may take when several
+    repositories are listed, so one huge repository can't starve the others; PRs beyond it are only
+    partly loaded (default 300, 0 for no budget)
+  - retries: how often API requests failing with a server, secondary rate limit or network error are
+    retried, backing off exponentially (default 3, 0 to disable; --retries overrides it)
+  - legend: when to show the table legend (once, always, never)
+  - emoji: whether tables show emoji (auto replaces them with ASCII tokens on terminals that can't draw
+    them, always, never; see 'ghprs config probe-emoji')
+  - diff-mode: how diffs are shown during approval (unified, split side by side, or word with the
+    changed words inline)
+  - stale-check-after: how long a check may be pending before it can be re-triggered (e.g. 1h, 0 to disable)
+  - stale-pr-after: how long a PR may go without an update before the AGE column marks it stale
+    (e.g. 14d, the default, or 36h, 0 to disable)
+  - retest-comments: comma-separated comments posted to re-run failed checks of Prow repositories
+    (e.g. /retest,/ok-to-test, default /retest)
+  - image-pinning: image reference changes flagged in Konflux diffs (digest flags images unpinned
+    from a digest, tag flags images pinned to a digest, off disables the check)
+  - pager: command long diffs, check logs and conversations are paged through (e.g. "less -R", cat to
+    disable paging, "" for $PAGER)
+  - editor: command comments and hold messages are written in when 'e' is entered at their prompt
+    (e.g. "code --wait", "" for $VISUAL or $EDITOR)
+  - browser: command 'ghprs open' and 'o' at the approval prompt open PRs and checks with (e.g. firefox,
+    "" for $GH_BROWSER, the gh config, $BROWSER or the system's default browser)
+  - trusted-authors: comma-separated logins whose changes to CI workflows, Tekton pipelines and OWNERS
+    files are approved without typing the PR number ("" for the default,
+    the Konflux, Dependabot and Renovate bots)
+  - slack-webhook: Slack incoming webhook URL 'ghprs notify' and 'ghprs watch --notify' post PRs with
+    migration warnings or failing checks to ("" to disable)
+  - webhooks: comma-separated URLs the same alerts are posted to as JSON ("" to disable)
+  - column-width: width of a text column as column=width, where column is title, author, branch, target,
+    repo (shown by --combined), component (shown for mapped Konflux components), owners (shown for
+    repositories with a CODEOWNERS file) or dep (shown for Renovate and Dependabot PRs) and width is a
+    number or auto to fit the widest value (e.g. title=auto, author=20)
+  - priority-weight: weight --sort-by priority gives a factor as factor=weight, where factor is security
+    (default 1000), migration (100), tekton-only (10), failing-checks (0, per failed check) or staleness
+    (0, per day since the PR was last updated); a negative weight sorts PRs lower (e.g. failing-checks=-50)
+  - template: comment template 'm' at the approval prompt offers as name=text, where text may use
+    {{number}}, {{author}}, {{branch}}, {{target}}, {{title}} and {{repo}} (e.g. ack="Thanks @{{author}}!",
+    name= removes it)
+  - host: GitHub Enterprise host for repositories without their own host ("" for the gh default)
+  - account: account of gh's stored auth to use on a host as host=account, for gh logged in to several
+    (e.g. github.com=work-user, host= removes it)
+  - approval-body: review body posted when approving ("" for none, default /lgtm)
+  - approval-event: review event posted when approving (APPROVE, COMMENT)
+  - approval-extra-comments: comma-separated comments posted after approving (e.g. /approve)
+  - approval-verify-timeout: how long to wait for Prow to label an approved PR (e.g. 1m, 0 to only check the review)
+  - approval-max-changes: lines a bot or trusted author's PR may change before approving it needs its number typed (0 for no limit)`,
 	Args: cobra.ExactArgs(2),
 	Run: func(cmd *cobra.Command, args []string) {
 		key := args[0]
@@ -169,9 +336,228 @@ var configSetCmd = &cobra.Command{
 			}
 			config.Defaults.Limit = limit
 
+		case "cache-ttl":
+			ttl, err := time.ParseDuration(value)
+			if err != nil || ttl < 0 {
+				fmt.Println("Cache TTL must be a duration such as 5m or 1h (0 to disable)")
+				os.Exit(1)
+			}
+			config.Cache.TTL = value
+
+		case "rate-limit-threshold":
+			var threshold int
+			if _, err := fmt.Sscanf(value, "%d", &threshold); err != nil || threshold < 0 {
+				fmt.Println("Rate limit threshold must be a number of 0 or more")
+				os.Exit(1)
+			}
+			config.RateLimit.Threshold = &threshold
+
+		case "repo-request-budget":
+			var budget int
+			if _, err := fmt.Sscanf(value, "%d", &budget); err != nil || budget < 0 {
+				fmt.Println("Repo request budget must be a number of 0 or more")
+				os.Exit(1)
+			}
+			config.RateLimit.RepoBudget = &budget
+
+		case "retries":
+			var retries int
+			if _, err := fmt.Sscanf(value, "%d", &retries); err != nil || retries < 0 {
+				fmt.Println("Retries must be a number of 0 or more")
+				os.Exit(1)
+			}
+			config.RateLimit.Retries = &retries
+
+		case "legend":
+			if err := render.ValidateLegendMode(value); err != nil {
+				fmt.Println("Legend must be one of: once, always, never")
+				os.Exit(1)
+			}
+			config.Display.Legend = value
+
+		case "emoji":
+			if err := render.ValidateEmojiMode(value); err != nil {
+				fmt.Println("Emoji must be one of: auto, always, never")
+				os.Exit(1)
+			}
+			config.Display.Emoji = value
+
+		case "diff-mode":
+			if err := render.ValidateDiffMode(value); err != nil {
+				fmt.Println("Diff mode must be one of: unified, split, word")
+				os.Exit(1)
+			}
+			config.Display.DiffMode = value
+
+		case "stale-check-after":
+			after, err := time.ParseDuration(value)
+			if err != nil || after < 0 {
+				fmt.Println("Stale check threshold must be a duration such as 30m or 2h (0 to disable)")
+				os.Exit(1)
+			}
+			config.Checks.StaleAfter = value
+
+		case "stale-pr-after":
+			if err := validateStalePRAfter(value); err != nil {
+				fmt.Println("Stale PR threshold must be a duration such as 14d or 36h (0 to disable)")
+				os.Exit(1)
+			}
+			config.Display.StaleAfter = value
+
+		case "retest-comments":
+			var comments []string
+			for _, comment := range strings.Split(value, ",") {
+				if comment = strings.TrimSpace(comment); comment != "" {
+					comments = append(comments, comment)
+				}
+			}
+			config.Checks.RetestComments = comments
+
+		case "image-pinning":
+			if err := validatePinningPolicy(value); err != nil {
+				fmt.Printf("Image pinning must be one of: %s, %s, %s\n", PinningDigest, PinningTag, PinningOff)
+				os.Exit(1)
+			}
+			config.Konflux.ImagePinning = value
+
+		case "pager":
+			config.UI.Pager = value
+
+		case "editor":
+			config.UI.Editor = value
+
+		case "browser":
+			config.UI.Browser = value
+
+		case "trusted-authors":
+			var authors []string
+			for _, author := range strings.Split(value, ",") {
+				if author = strings.TrimSpace(author); author != "" {
+					authors = append(authors, author)
+				}
+			}
+			config.Trust.Authors = authors
+
+		case "slack-webhook":
+			if value != "" && !isWebhookURL(value) {
+				fmt.Println("Slack webhook must be an http(s) URL")
+				os.Exit(1)
+			}
+			config.Notifications.SlackWebhook = value
+
+		case "webhooks":
+			var webhooks []string
+			for _, webhook := range strings.Split(value, ",") {
+				if webhook = strings.TrimSpace(webhook); webhook == "" {
+					continue
+				}
+				if !isWebhookURL(webhook) {
+					fmt.Printf("Webhook %s must be an http(s) URL\n", webhook)
+					os.Exit(1)
+				}
+				webhooks = append(webhooks, webhook)
+			}
+			config.Notifications.Webhooks = webhooks
+
+		case "column-width":
+			column, width, err := render.ParseColumnWidth(value)
+			if err != nil {
+				fmt.Printf("Column width must be column=width, e.g. title=auto or author=20: %v\n", err)
+				os.Exit(1)
+			}
+			if config.Display.Columns == nil {
+				config.Display.Columns = make(map[string]string)
+			}
+			config.Display.Columns[column] = width
+
+		case "priority-weight":
+			factor, weight, err := parsePriorityWeight(value)
+			if err != nil {
+				fmt.Printf("Priority weight must be factor=weight, e.g. staleness=2 or failing-checks=-50: %v\n", err)
+				os.Exit(1)
+			}
+			if config.Priority.Weights == nil {
+				config.Priority.Weights = make(map[string]float64)
+			}
+			config.Priority.Weights[factor] = weight
+
+		case "template":
+			name, text, err := parseCommentTemplate(value)
+			if err != nil {
+				fmt.Printf("Template must be name=text, e.g. retest=/retest: %v\n", err)
+				os.Exit(1)
+			}
+			if text == "" {
+				delete(config.Templates, name)
+				break
+			}
+			if config.Templates == nil {
+				config.Templates = make(map[string]string)
+			}
+			config.Templates[name] = text
+
+		case "host":
+			if strings.Contains(value, "/") {
+				fmt.Println("Host must be a hostname such as github.example.com")
+				os.Exit(1)
+			}
+			config.Host = value
+
+		case "account":
+			host, account, ok := strings.Cut(value, "=")
+			host, account = strings.TrimSpace(host), strings.TrimSpace(account)
+			if !ok || host == "" || strings.Contains(host, "/") {
+				fmt.Println("Account must be host=account, e.g. github.com=work-user")
+				os.Exit(1)
+			}
+			if account == "" {
+				delete(config.Accounts, host)
+				break
+			}
+			if config.Accounts == nil {
+				config.Accounts = make(map[string]string)
+			}
+			config.Accounts[host] = account
+
+		case "approval-body":
+			config.Approval.Body = &value
+
+		case "approval-event":
+			event := strings.ToUpper(value)
+			if err := validateReviewEvent(event); err != nil {
+				fmt.Printf("Approval event must be one of: %s, %s\n", ReviewEventApprove, ReviewEventComment)
+				os.Exit(1)
+			}
+			config.Approval.Event = event
+
+		case "approval-extra-comments":
+			var comments []string
+			for _, comment := range strings.Split(value, ",") {
+				if comment = strings.TrimSpace(comment); comment != "" {
+					comments = append(comments, comment)
+				}
+			}
+			config.Approval.ExtraComments = comments
+
+		case "approval-verify-timeout":
+			timeout, err := time.ParseDuration(value)
+			if err != nil || timeout < 0 {
+				fmt.Println("Approval verify timeout must be a duration such as 30s or 2m (0 to only check the review)")
+				os.Exit(1)
+			}
+			config.Approval.VerifyTimeout = value
+
+		case "approval-max-changes":
+			var lines int
+			if _, err := fmt.Sscanf(value, "%d", &lines); err != nil || lines < 0 {
+				fmt.Println("Approval max changes must be a number of lines, 0 for no limit")
+				os.Exit(1)
+			}
+			config.Approval.MaxChanges = lines
+
 		default:
 			fmt.Printf("Unknown configuration key: %s\n", key)
-			fmt.Println("Available keys: state, limit")
+			fmt.Println("Available keys: state, limit, cache-ttl, rate-limit-threshold, repo-request-budget, retries, legend, emoji, diff-mode, stale-check-after, stale-pr-after, retest-comments, image-pinning, pager, editor, browser, trusted-authors, slack-webhook, webhooks, column-width, priority-weight, template, host, account, approval-body, approval-event, approval-extra-comments, approval-verify-timeout, approval-max-changes")
 			os.Exit(1)
 		}
 
@@ -188,8 +574,11 @@ var configSetCmd = &cobra.Command{
 var configAddKonfluxRepoCmd = &cobra.Command{
 	Use:   "add-konflux-repo <owner/repo>",
 	Short: "Add a repository and mark it as a Konflux repository",
-	Long:  `Add a repository to the configuration and mark it as a Konflux repository. Konflux repositories will be included when running 'ghprs konflux' command.`,
-	Args:  cobra.ExactArgs(1),
+	Long: `Add a repository to the configuration and mark it as a Konflux repository. Konflux repositories will be included when running 'ghprs konflux' command.
+
+Use --author to also list PRs by other bots in the repository's Konflux queue, e.g.
+  ghprs config add-konflux-repo owner/repo --author renovate[bot] --author dependabot[bot]`,
+	Args: cobra.ExactArgs(1),
 	Run: func(cmd *cobra.Command, args []string) {
 		repo := args[0]
 
@@ -206,7 +595,10 @@ var configAddKonfluxRepoCmd = &cobra.Command{
 		}
 
 		// Add the repository using the helper method
-		if !config.AddRepository(repo, true) {
+		added := config.AddRepository(repo, true)
+		hostChanged := repoHost != "" && config.SetRepositoryHost(repo, repoHost)
+		authorsAdded := config.AddRepositoryAuthors(repo, repoAuthors)
+		if !added && !hostChanged && !authorsAdded {
 			fmt.Printf("Repository %s is already configured as a Konflux repository\n", repo)
 			return
 		}
@@ -216,16 +608,25 @@ var configAddKonfluxRepoCmd = &cobra.Command{
 			os.Exit(1)
 		}
 
-		fmt.Printf("Added repository %s and marked it as a Konflux repository\n", repo)
+		if added {
+			fmt.Printf("Added repository %s and marked it as a Konflux repository\n", repo)
+		}
+		if hostChanged {
+			fmt.Printf("Set host of repository %s to %s\n", repo, repoHost)
+		}
+		if authorsAdded {
+			fmt.Printf("Listing PRs by %s in repository %s\n", strings.Join(config.AuthorsFor(repo), ", "), repo)
+		}
 	},
 }
 
 // configRemoveKonfluxRepoCmd removes the Konflux marking from a repository
 var configRemoveKonfluxRepoCmd = &cobra.Command{
-	Use:   "remove-konflux-repo <owner/repo>",
-	Short: "Remove the Konflux marking from a repository",
-	Long:  `Remove the Konflux marking from a repository in the configuration. The repository will remain in the list but will no longer be treated as a Konflux repository.`,
-	Args:  cobra.ExactArgs(1),
+	Use:               "remove-konflux-repo <owner/repo>",
+	Short:             "Remove the Konflux marking from a repository",
+	Long:              `Remove the Konflux marking from a repository in the configuration. The repository will remain in the list but will no longer be treated as a Konflux repository.`,
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: completeRepositoryArgs(false),
 	Run: func(cmd *cobra.Command, args []string) {
 		repo := args[0]
 
@@ -250,6 +651,61 @@ var configRemoveKonfluxRepoCmd = &cobra.Command{
 	},
 }
 
+// configSetKonfluxComponentCmd maps a repository, or every repository of an organization, to a Konflux component
+var configSetKonfluxComponentCmd = &cobra.Command{
+	Use:   "set-konflux-component <owner/repo|owner/*> <application> [component]",
+	Short: "Map a repository to a Konflux application and component",
+	Long: `Map a repository to the Konflux application and component built from it. 'ghprs konflux' shows
+the component of each PR and groups the repositories by application.
+
+Use owner/* to map every repository of an organization to an application, with each repository
+name as its component. Use --branch for a branch built as its own component, e.g. a release branch.
+A mapping of a repository is preferred over one of its organization.
+
+Examples:
+  ghprs config set-konflux-component my-org/operator my-app operator
+  ghprs config set-konflux-component my-org/operator my-app-1-2 operator-1-2 --branch release-1.2
+  ghprs config set-konflux-component 'my-org/*' my-app`,
+	Args: cobra.RangeArgs(2, 3),
+	Run: func(cmd *cobra.Command, args []string) {
+		mapping := KonfluxComponent{Repository: args[0], Branch: componentBranch, Application: args[1]}
+		if len(args) == 3 {
+			mapping.Component = args[2]
+		}
+
+		if !validateComponentRepository(mapping.Repository) {
+			fmt.Println("Repository must be in the format 'owner/repo' or 'owner/*'")
+			os.Exit(1)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+
+		if !config.SetKonfluxComponent(mapping) {
+			fmt.Printf("Repository %s is already mapped to this Konflux component\n", mapping.Repository)
+			return
+		}
+
+		if err := SaveConfig(config); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+
+		target := mapping.Repository
+		if mapping.Branch != "" {
+			target += " (branch " + mapping.Branch + ")"
+		}
+		if mapping.Component != "" {
+			fmt.Printf("Mapped %s to component %s of Konflux application %s\n", target, mapping.Component, mapping.Application)
+		} else {
+			fmt.Printf("Mapped %s to Konflux application %s\n", target, mapping.Application)
+		}
+	},
+}
+
 // AddConfigCommands adds all config commands to the provided root command
 // This is used for testing to avoid global state issues
 func AddConfigCommands(rootCmd *cobra.Command) {
@@ -261,7 +717,8 @@ func AddConfigCommands(rootCmd *cobra.Command) {
 The configuration file allows you to set repositories, states, and limits.
 Repositories can be marked as Konflux repositories. 'ghprs list' shows all repositories,
 while 'ghprs konflux' shows only repositories marked as Konflux.
-Configuration is stored in ~/.config/ghprs/config.yaml`,
+Configuration is stored in ~/.config/ghprs/config.yaml, or with --profile or $GHPRS_PROFILE in
+~/.config/ghprs/profiles/<name>.yaml (see 'ghprs config profiles')`,
 	}
 
 	rootCmd.AddCommand(configCmd)
@@ -272,9 +729,21 @@ Configuration is stored in ~/.config/ghprs/config.yaml`,
 	configCmd.AddCommand(configRemoveRepoCmd)
 	configCmd.AddCommand(configAddKonfluxRepoCmd)
 	configCmd.AddCommand(configRemoveKonfluxRepoCmd)
+	configCmd.AddCommand(configSetKonfluxComponentCmd)
 	configCmd.AddCommand(configSetCmd)
+	configCmd.AddCommand(configProbeEmojiCmd)
+	configCmd.AddCommand(configLearnTektonFilesCmd)
+	configCmd.AddCommand(configProfilesCmd)
+	configCmd.AddCommand(configValidateCmd)
+	configCmd.AddCommand(configEditCmd)
+	configCmd.AddCommand(configListReposCmd)
 }
 
 func init() {
+	configAddRepoCmd.Flags().StringVar(&repoHost, "host", "", "GitHub Enterprise host the repository lives on")
+	configAddKonfluxRepoCmd.Flags().StringVar(&repoHost, "host", "", "GitHub Enterprise host the repository lives on")
+	configAddKonfluxRepoCmd.Flags().StringSliceVar(&repoAuthors, "author", nil, "Additional bot author whose PRs 'ghprs konflux' lists, e.g. renovate[bot] (repeatable)")
+	configSetKonfluxComponentCmd.Flags().StringVar(&componentBranch, "branch", "", "Only map PRs targeting this branch, e.g. a release branch")
+
 	AddConfigCommands(RootCmd)
 }
@@ -8,6 +8,7 @@ import (
 	. "github.com/onsi/gomega"
 
 	"ghprs/cmd"
+	"ghprs/internal/render"
 )
 
 var _ = Describe("Configuration", func() {
@@ -179,6 +180,57 @@ defaults:
 		})
 	})
 
+	Describe("Approval settings", func() {
+		It("should post /lgtm with APPROVE by default", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.Approval.ReviewBody()).To(Equal("/lgtm"))
+			Expect(config.Approval.ReviewEvent()).To(Equal(cmd.ReviewEventApprove))
+		})
+
+		It("should load the approval section", func() {
+			configPath := filepath.Join(tempDir, "config.yaml")
+			Expect(os.WriteFile(configPath, []byte(`approval:
+  body: ""
+  event: COMMENT
+  extra_comments:
+    - /approve
+`), 0644)).To(Succeed())
+			cmd.SetConfigPath(configPath)
+			defer cmd.ResetConfigPath()
+
+			config, err := cmd.LoadConfig()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(config.Approval.ReviewBody()).To(BeEmpty())
+			Expect(config.Approval.ReviewEvent()).To(Equal(cmd.ReviewEventComment))
+			Expect(config.Approval.ExtraComments).To(Equal([]string{"/approve"}))
+		})
+
+		It("should fall back to APPROVE for unknown events", func() {
+			Expect(cmd.ApprovalSettings{Event: "REQUEST_CHANGES"}.ReviewEvent()).To(Equal(cmd.ReviewEventApprove))
+		})
+
+		It("should let flags override the configured body", func() {
+			body := "LGTM from the config"
+			config := cmd.DefaultConfig()
+			config.Approval.Body = &body
+
+			Expect(cmd.NewApprovalConfigTest(config, "", false).Review.ReviewBody()).To(Equal("LGTM from the config"))
+			Expect(cmd.NewApprovalConfigTest(config, "", true).Review.ReviewBody()).To(BeEmpty())
+			Expect(cmd.NewApprovalConfigTest(config, "Looks good", true).Review.ReviewBody()).To(Equal("Looks good"))
+		})
+	})
+
+	Describe("Diff mode", func() {
+		It("should fall back to unified for unset or invalid values", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.DiffMode()).To(Equal(render.DiffUnified))
+			config.Display.DiffMode = "side-by-side"
+			Expect(config.DiffMode()).To(Equal(render.DiffUnified))
+			config.Display.DiffMode = render.DiffSplit
+			Expect(config.DiffMode()).To(Equal(render.DiffSplit))
+		})
+	})
+
 	Describe("Repository Management", func() {
 		var config *cmd.Config
 
@@ -294,5 +346,90 @@ defaults:
 				Expect(config.Repositories).To(HaveLen(3))
 			})
 		})
+
+		Describe("Hosts", func() {
+			BeforeEach(func() {
+				config.Repositories = []cmd.RepositoryConfig{
+					{Name: "owner/repo1"},
+					{Name: "konflux/repo1", Konflux: true},
+				}
+			})
+
+			It("should prefer the repository's host over the global host", func() {
+				config.Host = "ghe.example.com"
+				Expect(config.SetRepositoryHost("owner/repo1", "other.example.com")).To(BeTrue())
+
+				Expect(config.HostFor("owner/repo1")).To(Equal("other.example.com"))
+				Expect(config.HostFor("konflux/repo1")).To(Equal("ghe.example.com"))
+				Expect(config.HostFor("owner/unconfigured")).To(Equal("ghe.example.com"))
+			})
+
+			It("should only report a change when the host is set on a configured repository", func() {
+				Expect(config.SetRepositoryHost("owner/repo1", "ghe.example.com")).To(BeTrue())
+				Expect(config.SetRepositoryHost("owner/repo1", "ghe.example.com")).To(BeFalse())
+				Expect(config.SetRepositoryHost("owner/nonexistent", "ghe.example.com")).To(BeFalse())
+				Expect(config.HostFor("owner/nonexistent")).To(BeEmpty())
+			})
+
+			It("should save and load hosts", func() {
+				cmd.SetConfigPath(filepath.Join(tempDir, "config.yaml"))
+				defer cmd.ResetConfigPath()
+
+				config.Host = "ghe.example.com"
+				config.SetRepositoryHost("owner/repo1", "other.example.com")
+				Expect(cmd.SaveConfig(config)).To(Succeed())
+
+				loaded, err := cmd.LoadConfig()
+				Expect(err).NotTo(HaveOccurred())
+				Expect(loaded.Host).To(Equal("ghe.example.com"))
+				Expect(loaded.HostFor("owner/repo1")).To(Equal("other.example.com"))
+			})
+		})
+
+		Describe("Bot authors", func() {
+			BeforeEach(func() {
+				config.Repositories = []cmd.RepositoryConfig{{Name: "konflux/repo1", Konflux: true}}
+			})
+
+			It("should add each author once", func() {
+				Expect(config.AddRepositoryAuthors("konflux/repo1", []string{"renovate[bot]"})).To(BeTrue())
+				Expect(config.AddRepositoryAuthors("konflux/repo1", []string{"renovate[bot]", "dependabot[bot]"})).To(BeTrue())
+				Expect(config.AddRepositoryAuthors("konflux/repo1", []string{"dependabot[bot]"})).To(BeFalse())
+				Expect(config.AuthorsFor("konflux/repo1")).To(Equal([]string{"renovate[bot]", "dependabot[bot]"}))
+			})
+
+			It("should not add authors to unconfigured repositories", func() {
+				Expect(config.AddRepositoryAuthors("owner/nonexistent", []string{"renovate[bot]"})).To(BeFalse())
+				Expect(config.AuthorsFor("owner/nonexistent")).To(BeEmpty())
+			})
+
+			It("should extend the Konflux queue with the configured authors", func() {
+				config.AddRepositoryAuthors("konflux/repo1", []string{"renovate[bot]"})
+
+				Expect(cmd.QueueAuthorsTest(config, "konflux/repo1", []string{"red-hat-konflux[bot]"}, true)).
+					To(Equal([]string{"red-hat-konflux[bot]", "renovate[bot]"}))
+				Expect(cmd.QueueAuthorsTest(config, "konflux/repo1", nil, false)).To(BeEmpty())
+			})
+		})
+	})
+
+	Describe("Retest comments", func() {
+		It("should default to /retest", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.RetestComments()).To(Equal([]string{"/retest"}))
+			config.Checks.RetestComments = []string{"/ok-to-test", "/retest"}
+			Expect(config.RetestComments()).To(Equal([]string{"/ok-to-test", "/retest"}))
+		})
+	})
+
+	Describe("Image pinning policy", func() {
+		It("should default to digest and ignore invalid policies", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.ImagePinningPolicy()).To(Equal(cmd.PinningDigest))
+			config.Konflux.ImagePinning = "sometimes"
+			Expect(config.ImagePinningPolicy()).To(Equal(cmd.PinningDigest))
+			config.Konflux.ImagePinning = cmd.PinningTag
+			Expect(config.ImagePinningPolicy()).To(Equal(cmd.PinningTag))
+		})
 	})
 })
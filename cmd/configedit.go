@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// configListKonflux limits 'config list-repos' to the Konflux repositories
+var configListKonflux bool
+
+// editConfigFile opens a copy of the config file in the editor and saves it back once it validates. A config
+// with issues is reopened until it is fixed or the changes are discarded. It reports whether the config changed.
+func editConfigFile(path string) (bool, error) {
+	original, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		original, err = yaml.Marshal(DefaultConfig())
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to read config file: %w", err)
+	}
+
+	file, err := os.CreateTemp("", "ghprs-config-*.yaml")
+	if err != nil {
+		return false, fmt.Errorf("failed to create the file to edit: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	_, err = file.Write(original)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create the file to edit: %w", err)
+	}
+
+	for {
+		if err := editFile(file.Name()); err != nil {
+			return false, err
+		}
+		edited, err := os.ReadFile(file.Name())
+		if err != nil {
+			return false, fmt.Errorf("failed to read the edited config: %w", err)
+		}
+		if bytes.Equal(edited, original) {
+			return false, nil
+		}
+
+		issues := validateConfigData(edited)
+		if len(issues) == 0 {
+			if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+				return false, fmt.Errorf("failed to create config directory: %w", err)
+			}
+			if err := os.WriteFile(path, edited, 0644); err != nil {
+				return false, fmt.Errorf("failed to write config file: %w", err)
+			}
+			return true, nil
+		}
+
+		streams.Printf("The edited config has %d issue(s):\n", len(issues))
+		for _, issue := range issues {
+			streams.Printf("  %s\n", issue)
+		}
+		again, err := prompter.Confirm("Edit it again? (no discards the changes)")
+		if err != nil {
+			return false, err
+		}
+		if !again {
+			return false, nil
+		}
+	}
+}
+
+// configEditCmd opens the config file in the editor
+var configEditCmd = &cobra.Command{
+	Use:   "edit",
+	Short: "Edit the config file in your editor",
+	Long: `Open the config file in ui.editor, $VISUAL or $EDITOR. When the editor is closed the config is validated
+as 'ghprs config validate' does: a valid config is saved, one with issues lists them and is reopened
+until it is fixed or the changes are discarded. Without a config file the defaults are edited.
+
+Examples:
+  ghprs config edit
+  EDITOR="code --wait" ghprs config edit
+  ghprs --profile work config edit`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateProfileName(activeProfile()); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		path := getConfigPath()
+		changed, err := editConfigFile(path)
+		if err != nil {
+			fmt.Printf("Error editing config: %v\n", err)
+			os.Exit(1)
+		}
+		if !changed {
+			fmt.Println("Config not changed")
+			return
+		}
+		fmt.Printf("✅ Saved %s\n", path)
+	},
+}
+
+// writeRepositoryList writes the configured repositories, or only the Konflux ones, as one name per line or as
+// JSON or YAML
+func writeRepositoryList(w io.Writer, repositories []RepositoryConfig, konfluxOnly bool, format string) error {
+	listed := []RepositoryConfig{}
+	for _, repository := range repositories {
+		if !konfluxOnly || repository.Konflux {
+			listed = append(listed, repository)
+		}
+	}
+	switch format {
+	case OutputJSON, OutputYAML:
+		return writeStructuredOutput(w, listed, format)
+	case OutputTable, OutputPlain:
+		for _, repository := range listed {
+			if _, err := fmt.Fprintln(w, repository.Name); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("--output %s isn't supported by config list-repos (use plain, json or yaml)", format)
+	}
+}
+
+// configListReposCmd lists the configured repositories for scripts
+var configListReposCmd = &cobra.Command{
+	Use:   "list-repos",
+	Short: "List the configured repositories",
+	Long: `List the configured repositories, one per line, or with --output json or yaml with their Konflux
+marking, host and authors. --konflux lists only the Konflux repositories.
+
+Examples:
+  ghprs config list-repos
+  ghprs config list-repos --konflux
+  ghprs config list-repos --output json | jq -r '.[] | select(.host) | .name'`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateOutputFormat(outputFormat); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+		config, err := LoadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		if err := writeRepositoryList(streams.Out, config.Repositories, configListKonflux, outputFormat); err != nil {
+			fmt.Printf("Error: %v\n", err)
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	configListReposCmd.Flags().BoolVar(&configListKonflux, "konflux", false, "List only the Konflux repositories")
+}
@@ -0,0 +1,121 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Config edit and list-repos", func() {
+	Describe("editing the config", func() {
+		var (
+			in, out *bytes.Buffer
+			dir     string
+			path    string
+		)
+
+		// editorWriting installs an editor that replaces the file with each of contents in turn
+		editorWriting := func(contents ...string) {
+			script := "#!/bin/sh\ncount=$(cat \"" + dir + "/count\" 2>/dev/null || echo 0)\ncount=$((count + 1))\necho $count > \"" + dir + "/count\"\n"
+			for i, content := range contents {
+				Expect(os.WriteFile(filepath.Join(dir, "content"+strconv.Itoa(i+1)), []byte(content), 0o644)).To(Succeed())
+			}
+			script += "cp \"" + dir + "/content$count\" \"$1\"\n"
+			editor := filepath.Join(dir, "editor")
+			Expect(os.WriteFile(editor, []byte(script), 0o755)).To(Succeed())
+			cmd.SetUISettingsTest(cmd.UIConfig{Editor: editor})
+		}
+
+		BeforeEach(func() {
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			// The editor gets stdin, so the answers are read from a stream of their own
+			cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), cmd.NewPrompter(cmd.NewIOStreams(in, out, &bytes.Buffer{})))
+			dir = GinkgoT().TempDir()
+			path = filepath.Join(dir, "config.yaml")
+			Expect(os.WriteFile(path, []byte("repositories:\n  - name: owner/repo\n"), 0o644)).To(Succeed())
+		})
+
+		AfterEach(func() {
+			cmd.SetUISettingsTest(cmd.UIConfig{})
+			cmd.ResetIOStreams()
+		})
+
+		It("should save a valid config", func() {
+			editorWriting("repositories:\n  - name: owner/repo\n  - name: owner/other\n")
+			Expect(cmd.EditConfigFileTest(path)).To(BeTrue())
+			Expect(os.ReadFile(path)).To(ContainSubstring("owner/other"))
+		})
+
+		It("should report a config left as it was", func() {
+			editorWriting("repositories:\n  - name: owner/repo\n")
+			Expect(cmd.EditConfigFileTest(path)).To(BeFalse())
+		})
+
+		It("should reopen a config with issues until it is fixed", func() {
+			editorWriting("repositories:\n  - name: not-a-repo\n", "repositories:\n  - name: owner/fixed\n")
+			in.WriteString("y\n")
+			Expect(cmd.EditConfigFileTest(path)).To(BeTrue())
+			Expect(out.String()).To(ContainSubstring(`line 2: invalid repository "not-a-repo"`))
+			Expect(os.ReadFile(path)).To(ContainSubstring("owner/fixed"))
+		})
+
+		It("should discard the changes of a config with issues when asked", func() {
+			editorWriting("repositories:\n  - name: owner/repo\n    colour: blue\n")
+			in.WriteString("n\n")
+			Expect(cmd.EditConfigFileTest(path)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring(`unknown key "colour"`))
+			Expect(os.ReadFile(path)).NotTo(ContainSubstring("colour"))
+		})
+
+		It("should start from the defaults without a config file", func() {
+			path = filepath.Join(dir, "new", "config.yaml")
+			editorWriting("repositories:\n  - name: owner/new\n")
+			Expect(cmd.EditConfigFileTest(path)).To(BeTrue())
+			Expect(os.ReadFile(path)).To(ContainSubstring("owner/new"))
+		})
+	})
+
+	Describe("listing the repositories", func() {
+		repositories := []cmd.RepositoryConfig{
+			{Name: "owner/app"},
+			{Name: "owner/operator", Konflux: true, Host: "github.example.com"},
+		}
+
+		It("should list one repository per line", func() {
+			var out bytes.Buffer
+			Expect(cmd.WriteRepositoryListTest(&out, repositories, false, cmd.OutputPlain)).To(Succeed())
+			Expect(out.String()).To(Equal("owner/app\nowner/operator\n"))
+		})
+
+		It("should list only the Konflux repositories with --konflux", func() {
+			var out bytes.Buffer
+			Expect(cmd.WriteRepositoryListTest(&out, repositories, true, cmd.OutputTable)).To(Succeed())
+			Expect(out.String()).To(Equal("owner/operator\n"))
+		})
+
+		It("should list the repositories as JSON", func() {
+			var out bytes.Buffer
+			Expect(cmd.WriteRepositoryListTest(&out, repositories, false, cmd.OutputJSON)).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`[
+				{"name": "owner/app", "konflux": false},
+				{"name": "owner/operator", "konflux": true, "host": "github.example.com"}
+			]`))
+		})
+
+		It("should list no repositories as an empty JSON array", func() {
+			var out bytes.Buffer
+			Expect(cmd.WriteRepositoryListTest(&out, nil, false, cmd.OutputJSON)).To(Succeed())
+			Expect(out.String()).To(MatchJSON(`[]`))
+		})
+
+		It("should reject row formats", func() {
+			Expect(cmd.WriteRepositoryListTest(&bytes.Buffer{}, repositories, false, cmd.OutputCSV)).To(MatchError(ContainSubstring("isn't supported")))
+		})
+	})
+})
@@ -0,0 +1,344 @@
+package cmd
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
+// ConfigIssue is a problem found in a config file, at the line it is on (0 when unknown)
+type ConfigIssue struct {
+	Line    int
+	Message string
+}
+
+func (i ConfigIssue) String() string {
+	if i.Line == 0 {
+		return i.Message
+	}
+	return fmt.Sprintf("line %d: %s", i.Line, i.Message)
+}
+
+var (
+	// yamlErrorLineRE matches the line number yaml puts in front of its errors
+	yamlErrorLineRE = regexp.MustCompile(`^(?:yaml: )?line (\d+): (.*)$`)
+	// unknownFieldRE matches the error of a key the config doesn't have
+	unknownFieldRE = regexp.MustCompile(`^field (\S+) not found in type .+$`)
+
+	// configWarned remembers the config files whose issues were already warned about, so loading a config
+	// several times in a run warns once
+	configWarned      = map[string]bool{}
+	configWarnedMutex sync.Mutex
+)
+
+// validateConfigData checks a config file: that it parses, has no unknown keys or values of the wrong type,
+// lists valid repositories once each and has valid states, limits, durations and modes. Issues are sorted by line.
+func validateConfigData(data []byte) []ConfigIssue {
+	var root yaml.Node
+	if err := yaml.Unmarshal(data, &root); err != nil {
+		return yamlIssues(err)
+	}
+	if len(root.Content) == 0 {
+		return nil
+	}
+
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	var config Config
+	var issues []ConfigIssue
+	if err := decoder.Decode(&config); err != nil && !errors.Is(err, io.EOF) {
+		issues = yamlIssues(err)
+	}
+
+	doc := root.Content[0]
+	issues = append(issues, repositoryIssues(mappingValue(doc, "repositories"))...)
+	issues = append(issues, componentIssues(mappingValue(doc, "konflux", "components"))...)
+
+	defaults := mappingValue(doc, "defaults")
+	if state := mappingValue(defaults, "state"); isScalar(state) && state.Value != "" {
+		if state.Value != "open" && state.Value != "closed" && state.Value != "all" {
+			issues = append(issues, ConfigIssue{state.Line, fmt.Sprintf("invalid state %q, must be one of: open, closed, all", state.Value)})
+		}
+	}
+	if limit := mappingValue(defaults, "limit"); isScalar(limit) {
+		if n, err := strconv.Atoi(limit.Value); err == nil && n < 0 {
+			issues = append(issues, ConfigIssue{limit.Line, fmt.Sprintf("invalid limit %d, must be greater than 0", n)})
+		}
+	}
+
+	for _, path := range [][]string{{"cache", "ttl"}, {"checks", "stale_after"}, {"approval", "verify_timeout"}} {
+		if value := mappingValue(doc, path...); isScalar(value) && value.Value != "" {
+			if d, err := time.ParseDuration(value.Value); err != nil || d < 0 {
+				issues = append(issues, ConfigIssue{value.Line, fmt.Sprintf("invalid %s %q, must be a duration such as 5m or 1h", strings.Join(path, "."), value.Value)})
+			}
+		}
+	}
+	for _, path := range [][]string{{"rate_limit", "threshold"}, {"rate_limit", "repo_budget"}, {"rate_limit", "retries"}} {
+		if value := mappingValue(doc, path...); isScalar(value) {
+			if n, err := strconv.Atoi(value.Value); err == nil && n < 0 {
+				issues = append(issues, ConfigIssue{value.Line, fmt.Sprintf("invalid %s %d, must not be negative", strings.Join(path, "."), n)})
+			}
+		}
+	}
+
+	checks := []struct {
+		path     []string
+		validate func(string) error
+	}{
+		{[]string{"display", "legend"}, render.ValidateLegendMode},
+		{[]string{"display", "emoji"}, render.ValidateEmojiMode},
+		{[]string{"display", "diff_mode"}, render.ValidateDiffMode},
+		{[]string{"display", "stale_after"}, validateStalePRAfter},
+		{[]string{"konflux", "image_pinning"}, validatePinningPolicy},
+		{[]string{"approval", "event"}, validateReviewEvent},
+	}
+	for _, check := range checks {
+		if value := mappingValue(doc, check.path...); isScalar(value) && value.Value != "" {
+			if err := check.validate(value.Value); err != nil {
+				issues = append(issues, ConfigIssue{value.Line, err.Error()})
+			}
+		}
+	}
+
+	if columns := mappingValue(doc, "display", "columns"); columns != nil && columns.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(columns.Content); i += 2 {
+			if err := render.ValidateColumnWidth(columns.Content[i].Value, columns.Content[i+1].Value); err != nil {
+				issues = append(issues, ConfigIssue{columns.Content[i].Line, err.Error()})
+			}
+		}
+	}
+	if weights := mappingValue(doc, "priority", "weights"); weights != nil && weights.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(weights.Content); i += 2 {
+			if _, _, err := parsePriorityWeight(weights.Content[i].Value + "=" + weights.Content[i+1].Value); err != nil {
+				issues = append(issues, ConfigIssue{weights.Content[i].Line, err.Error()})
+			}
+		}
+	}
+	if templates := mappingValue(doc, "templates"); templates != nil && templates.Kind == yaml.MappingNode {
+		for i := 0; i+1 < len(templates.Content); i += 2 {
+			if _, _, err := parseCommentTemplate(templates.Content[i].Value + "="); err != nil {
+				issues = append(issues, ConfigIssue{templates.Content[i].Line, err.Error()})
+			}
+		}
+	}
+
+	sort.SliceStable(issues, func(i, j int) bool { return issues[i].Line < issues[j].Line })
+	return issues
+}
+
+// repositoryIssues checks that each configured repository is owner/repo, listed once and has valid settings
+func repositoryIssues(repositories *yaml.Node) []ConfigIssue {
+	if repositories == nil || repositories.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var issues []ConfigIssue
+	seen := map[string]int{}
+	for _, repository := range repositories.Content {
+		name := mappingValue(repository, "name")
+		if !isScalar(name) || name.Value == "" {
+			issues = append(issues, ConfigIssue{repository.Line, "repository without a name"})
+			continue
+		}
+		if owner, repo, ok := parseRepoSpec(name.Value); !ok || owner == "" || repo == "" {
+			issues = append(issues, ConfigIssue{name.Line, fmt.Sprintf("invalid repository %q, must be owner/repo", name.Value)})
+			continue
+		}
+		key := strings.ToLower(name.Value)
+		if line, ok := seen[key]; ok {
+			issues = append(issues, ConfigIssue{name.Line, fmt.Sprintf("duplicate repository %q, already listed on line %d", name.Value, line)})
+			continue
+		}
+		seen[key] = name.Line
+		issues = append(issues, repositorySettingIssues(repository, name.Value)...)
+	}
+	return issues
+}
+
+// repositorySettingIssues checks the settings a repository overrides
+func repositorySettingIssues(repository *yaml.Node, name string) []ConfigIssue {
+	var issues []ConfigIssue
+	if state := mappingValue(repository, "state"); isScalar(state) && state.Value != "" {
+		if state.Value != "open" && state.Value != "closed" && state.Value != "all" {
+			issues = append(issues, ConfigIssue{state.Line, fmt.Sprintf("invalid state %q of %s, must be one of: open, closed, all", state.Value, name)})
+		}
+	}
+	if limit := mappingValue(repository, "limit"); isScalar(limit) {
+		if n, err := strconv.Atoi(limit.Value); err == nil && n < 0 {
+			issues = append(issues, ConfigIssue{limit.Line, fmt.Sprintf("invalid limit %d of %s, must be greater than 0", n, name)})
+		}
+	}
+	if order := mappingValue(repository, "sort"); isScalar(order) && order.Value != "" {
+		if err := validateSortOrder(order.Value); err != nil {
+			issues = append(issues, ConfigIssue{order.Line, err.Error()})
+		}
+	}
+	if event := mappingValue(repository, "approval", "event"); isScalar(event) && event.Value != "" {
+		if err := validateReviewEvent(event.Value); err != nil {
+			issues = append(issues, ConfigIssue{event.Line, err.Error()})
+		}
+	}
+	if patterns := mappingValue(repository, "tekton_patterns"); patterns != nil && patterns.Kind == yaml.SequenceNode {
+		for _, pattern := range patterns.Content {
+			if !isScalar(pattern) {
+				continue
+			}
+			if err := ghprs.ValidateTektonPatterns([]string{pattern.Value}); err != nil {
+				issues = append(issues, ConfigIssue{pattern.Line, fmt.Sprintf("%v of %s", err, name)})
+			}
+		}
+	}
+	if timeout := mappingValue(repository, "approval", "verify_timeout"); isScalar(timeout) && timeout.Value != "" {
+		if d, err := time.ParseDuration(timeout.Value); err != nil || d < 0 {
+			issues = append(issues, ConfigIssue{timeout.Line, fmt.Sprintf("invalid approval.verify_timeout %q of %s, must be a duration such as 1m", timeout.Value, name)})
+		}
+	}
+	return issues
+}
+
+// componentIssues checks that each Konflux component mapping has a valid repository and an application, and
+// that no repository and branch is mapped twice
+func componentIssues(components *yaml.Node) []ConfigIssue {
+	if components == nil || components.Kind != yaml.SequenceNode {
+		return nil
+	}
+	var issues []ConfigIssue
+	seen := map[string]int{}
+	for _, component := range components.Content {
+		repository := mappingValue(component, "repository")
+		if !isScalar(repository) || !validateComponentRepository(repository.Value) {
+			value := ""
+			if isScalar(repository) {
+				value = repository.Value
+			}
+			issues = append(issues, ConfigIssue{component.Line, fmt.Sprintf("invalid component repository %q, must be owner/repo or owner/*", value)})
+			continue
+		}
+		if application := mappingValue(component, "application"); !isScalar(application) || application.Value == "" {
+			issues = append(issues, ConfigIssue{component.Line, fmt.Sprintf("component mapping of %s without an application", repository.Value)})
+		}
+		key := strings.ToLower(repository.Value)
+		if branch := mappingValue(component, "branch"); isScalar(branch) {
+			key += "@" + branch.Value
+		}
+		if line, ok := seen[key]; ok {
+			issues = append(issues, ConfigIssue{repository.Line, fmt.Sprintf("duplicate component mapping of %s, already on line %d", repository.Value, line)})
+			continue
+		}
+		seen[key] = repository.Line
+	}
+	return issues
+}
+
+// yamlIssues turns the errors of parsing or decoding a config into issues, keeping their line numbers
+func yamlIssues(err error) []ConfigIssue {
+	messages := []string{err.Error()}
+	var typeErr *yaml.TypeError
+	if errors.As(err, &typeErr) {
+		messages = typeErr.Errors
+	}
+	issues := make([]ConfigIssue, 0, len(messages))
+	for _, message := range messages {
+		issue := ConfigIssue{Message: message}
+		if match := yamlErrorLineRE.FindStringSubmatch(message); match != nil {
+			issue.Line, _ = strconv.Atoi(match[1])
+			issue.Message = match[2]
+		}
+		if match := unknownFieldRE.FindStringSubmatch(issue.Message); match != nil {
+			issue.Message = fmt.Sprintf("unknown key %q", match[1])
+		}
+		issues = append(issues, issue)
+	}
+	return issues
+}
+
+// mappingValue follows keys down nested mappings, returning nil when one is missing
+func mappingValue(node *yaml.Node, keys ...string) *yaml.Node {
+	for _, key := range keys {
+		if node == nil || node.Kind != yaml.MappingNode {
+			return nil
+		}
+		var value *yaml.Node
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			if node.Content[i].Value == key {
+				value = node.Content[i+1]
+			}
+		}
+		node = value
+	}
+	return node
+}
+
+// isScalar reports whether a node is a single value
+func isScalar(node *yaml.Node) bool {
+	return node != nil && node.Kind == yaml.ScalarNode
+}
+
+// warnConfigIssues warns about the issues of a config file being loaded, once per file, rather than silently
+// ignoring what ghprs can't use
+func warnConfigIssues(path string, data []byte) {
+	configWarnedMutex.Lock()
+	defer configWarnedMutex.Unlock()
+	if configWarned[path] {
+		return
+	}
+	configWarned[path] = true
+	for _, issue := range validateConfigData(data) {
+		logger.Warn("Config issue, run 'ghprs config validate' for details", "path", path, "line", issue.Line, "issue", issue.Message)
+	}
+}
+
+// configValidateCmd checks the config file
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check the config file for mistakes",
+	Long: `Check the config file for mistakes: YAML that doesn't parse, unknown keys, values of the wrong type,
+repositories that aren't owner/repo or are listed twice, and invalid states, limits, durations and modes.
+Each issue is printed with the line it is on. The same checks run whenever the config is loaded, as warnings.
+
+Exits with status 1 when the config has issues.
+
+Examples:
+  ghprs config validate
+  ghprs --profile work config validate`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		path := getConfigPath()
+		data, err := os.ReadFile(path)
+		if os.IsNotExist(err) {
+			fmt.Printf("No config file at %s, the defaults are used\n", path)
+			return
+		}
+		if err != nil {
+			fmt.Printf("Error reading config: %v\n", err)
+			os.Exit(1)
+		}
+		issues := validateConfigData(data)
+		if len(issues) == 0 {
+			fmt.Printf("✅ %s is valid\n", path)
+			return
+		}
+		for _, issue := range issues {
+			if issue.Line == 0 {
+				fmt.Printf("%s: %s\n", path, issue.Message)
+			} else {
+				fmt.Printf("%s:%d: %s\n", path, issue.Line, issue.Message)
+			}
+		}
+		fmt.Printf("\n%d issue(s) found\n", len(issues))
+		os.Exit(1)
+	},
+}
@@ -0,0 +1,131 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Config validation", func() {
+	It("should accept a valid config", func() {
+		Expect(cmd.ValidateConfigDataTest(`repositories:
+  - name: owner/repo
+    konflux: true
+defaults:
+  state: open
+  limit: 30
+cache:
+  ttl: 5m
+display:
+  legend: once
+  columns:
+    title: auto
+templates:
+  thanks: Thanks @{{author}}!
+`)).To(BeEmpty())
+	})
+
+	It("should accept an empty config", func() {
+		Expect(cmd.ValidateConfigDataTest("")).To(BeEmpty())
+	})
+
+	It("should report unknown keys with their line", func() {
+		Expect(cmd.ValidateConfigDataTest(`repositories:
+  - name: owner/repo
+    konflx: true
+defaults:
+  state: open
+colour: true
+`)).To(Equal([]string{
+			`line 3: unknown key "konflx"`,
+			`line 6: unknown key "colour"`,
+		}))
+	})
+
+	It("should report unknown keys of sections without a named type", func() {
+		Expect(cmd.ValidateConfigDataTest("defaults:\n  limitt: 30\n")).To(Equal([]string{
+			`line 2: unknown key "limitt"`,
+		}))
+	})
+
+	It("should report values of the wrong type", func() {
+		issues := cmd.ValidateConfigDataTest("defaults:\n  limit: lots\n")
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0]).To(HavePrefix("line 2: cannot unmarshal"))
+	})
+
+	It("should report YAML that doesn't parse", func() {
+		issues := cmd.ValidateConfigDataTest("repositories:\n  - name: owner/repo\n   konflux: true\n")
+		Expect(issues).To(HaveLen(1))
+		Expect(issues[0]).To(MatchRegexp(`^line \d+: `))
+	})
+
+	It("should report invalid and duplicate repositories", func() {
+		Expect(cmd.ValidateConfigDataTest(`repositories:
+  - name: owner/repo
+  - name: just-a-repo
+  - name: Owner/Repo
+  - konflux: true
+`)).To(Equal([]string{
+			`line 3: invalid repository "just-a-repo", must be owner/repo`,
+			`line 4: duplicate repository "Owner/Repo", already listed on line 2`,
+			`line 5: repository without a name`,
+		}))
+	})
+
+	It("should report bad states, limits and durations", func() {
+		Expect(cmd.ValidateConfigDataTest(`defaults:
+  state: merged
+  limit: -5
+cache:
+  ttl: soon
+`)).To(Equal([]string{
+			`line 2: invalid state "merged", must be one of: open, closed, all`,
+			`line 3: invalid limit -5, must be greater than 0`,
+			`line 5: invalid cache.ttl "soon", must be a duration such as 5m or 1h`,
+		}))
+	})
+
+	It("should report invalid modes, columns and review events", func() {
+		issues := cmd.ValidateConfigDataTest(`display:
+  emoji: sometimes
+  columns:
+    size: 10
+approval:
+  event: MERGE
+`)
+		Expect(issues).To(HaveLen(3))
+		Expect(issues[0]).To(HavePrefix("line 2: "))
+		Expect(issues[1]).To(ContainSubstring(`line 4: unknown column "size"`))
+		Expect(issues[2]).To(ContainSubstring(`line 6: invalid review event "MERGE"`))
+	})
+
+	It("should report invalid Tekton file patterns", func() {
+		Expect(cmd.ValidateConfigDataTest(`repositories:
+  - name: owner/repo
+    tekton_patterns:
+      - .tekton/*-build.yaml
+      - .tekton/[push
+`)).To(Equal([]string{
+			`line 5: invalid Tekton file pattern ".tekton/[push": syntax error in pattern of owner/repo`,
+		}))
+	})
+
+	It("should report invalid and duplicate Konflux component mappings", func() {
+		Expect(cmd.ValidateConfigDataTest(`konflux:
+  components:
+    - repository: owner/repo
+      application: app
+    - repository: "*/repo"
+      application: app
+    - repository: owner/repo
+      application: other
+    - repository: owner/other
+`)).To(Equal([]string{
+			`line 5: invalid component repository "*/repo", must be owner/repo or owner/*`,
+			`line 7: duplicate component mapping of owner/repo, already on line 3`,
+			`line 9: component mapping of owner/other without an application`,
+		}))
+	})
+})
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"sync/atomic"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// runTimeout bounds the time spent on each repository of a multi-repository command (0 for no limit)
+var runTimeout time.Duration
+
+// waitingForInput is set while a prompt waits for an answer, when Ctrl-C should exit right away
+var waitingForInput atomic.Bool
+
+// Execute runs the root command with a context that is cancelled by Ctrl-C, so API calls in flight stop
+// and the remaining work is skipped. A second Ctrl-C, or one while a prompt waits for input, exits immediately.
+func Execute() error {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		stop()
+		if waitingForInput.Load() {
+			_, _ = fmt.Fprintln(streams.ErrOut)
+			os.Exit(130)
+		}
+	}()
+	auditLog = &auditLogger{path: auditLogPath()}
+	return RootCmd.ExecuteContext(ctx)
+}
+
+// commandContext returns the context of a running command, which is only missing when it is run directly
+func commandContext(cmd *cobra.Command) context.Context {
+	if ctx := cmd.Context(); ctx != nil {
+		return ctx
+	}
+	return context.Background()
+}
+
+// withRepositoryTimeout returns the context for the work on one repository, bounded by --timeout
+func withRepositoryTimeout(ctx context.Context) (context.Context, context.CancelFunc) {
+	if runTimeout <= 0 {
+		return context.WithCancel(ctx)
+	}
+	return context.WithTimeout(ctx, runTimeout)
+}
+
+// describeCancellation explains why work on a repository stopped early, or returns "" if err isn't a cancellation
+func describeCancellation(err error) string {
+	switch {
+	case errors.Is(err, context.DeadlineExceeded) && runTimeout > 0:
+		return fmt.Sprintf("it took longer than --timeout %s", runTimeout)
+	case errors.Is(err, context.Canceled):
+		return "interrupted"
+	default:
+		return ""
+	}
+}
+
+// contextRESTClient bounds every request made through it by a context, including the helpers that don't take
+// one, so cancelling a repository's context stops all of its API calls
+type contextRESTClient struct {
+	RESTClientInterface
+	ctx context.Context
+}
+
+// withContext wraps a client so its requests are cancelled with ctx
+func withContext(client RESTClientInterface, ctx context.Context) RESTClientInterface {
+	return &contextRESTClient{RESTClientInterface: client, ctx: ctx}
+}
+
+// merge returns a context that is done when either ctx or the client's context is done
+func (c *contextRESTClient) merge(ctx context.Context) (context.Context, context.CancelFunc) {
+	if ctx == c.ctx || ctx == context.Background() || c.ctx.Err() != nil {
+		return c.ctx, func() {}
+	}
+	merged, cancel := context.WithCancelCause(ctx)
+	stop := context.AfterFunc(c.ctx, func() { cancel(c.ctx.Err()) })
+	return merged, func() {
+		stop()
+		cancel(nil)
+	}
+}
+
+// Get performs a GET request bounded by the context
+func (c *contextRESTClient) Get(path string, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodGet, path, nil, response)
+}
+
+// Post performs a POST request bounded by the context
+func (c *contextRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request bounded by the context
+func (c *contextRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request bounded by the context
+func (c *contextRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request bounded by the context
+func (c *contextRESTClient) Delete(path string, response interface{}) error {
+	return c.DoWithContext(c.ctx, http.MethodDelete, path, nil, response)
+}
+
+// Do performs a request bounded by the context
+func (c *contextRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(c.ctx, method, path, body, response)
+}
+
+// DoWithContext performs a request bounded by both the caller's context and the client's
+func (c *contextRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	ctx, cancel := c.merge(ctx)
+	defer cancel()
+	return c.RESTClientInterface.DoWithContext(ctx, method, path, body, response)
+}
+
+// Request performs a raw request bounded by the context
+func (c *contextRESTClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(c.ctx, method, path, body)
+}
+
+// RequestWithContext performs a raw request bounded by both the caller's context and the client's.
+// The response body is read after it returns, so the merged context is released when the body is closed.
+func (c *contextRESTClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	ctx, cancel := c.merge(ctx)
+	response, err := c.RESTClientInterface.RequestWithContext(ctx, method, path, body)
+	if err != nil || response == nil || response.Body == nil {
+		cancel()
+		return response, err
+	}
+	response.Body = &cancelOnClose{ReadCloser: response.Body, cancel: cancel}
+	return response, nil
+}
+
+// cancelOnClose is a response body that releases the context of its request once closed
+type cancelOnClose struct {
+	io.ReadCloser
+	cancel context.CancelFunc
+}
+
+// Close closes the body and releases the context of its request
+func (b *cancelOnClose) Close() error {
+	defer b.cancel()
+	return b.ReadCloser.Close()
+}
+
+func init() {
+	RootCmd.PersistentFlags().DurationVar(&runTimeout, "timeout", 0, "Skip a repository whose PRs aren't fetched and shown within this time, e.g. 30s or 2m (0 for no limit)")
+}
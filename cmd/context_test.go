@@ -0,0 +1,120 @@
+package cmd_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// contextRecordingClient remembers the context of the last raw request made through it
+type contextRecordingClient struct {
+	*cmd.MockRESTClient
+	ctx context.Context
+}
+
+func (c *contextRecordingClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	c.ctx = ctx
+	return c.MockRESTClient.RequestWithContext(ctx, method, path, body)
+}
+
+var _ = Describe("Cancellation", func() {
+	var mockClient *cmd.MockRESTClient
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1})
+	})
+
+	AfterEach(func() {
+		cmd.SetRunTimeoutTest(0)
+	})
+
+	Describe("a client bound to a context", func() {
+		It("should make requests while the context is live", func() {
+			client := cmd.WithContextTest(mockClient, context.Background())
+
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+			Expect(pr.Number).To(Equal(1))
+		})
+
+		It("should stop the helpers that don't take a context once it is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			client := cmd.WithContextTest(mockClient, ctx)
+			cancel()
+
+			var pr cmd.PullRequest
+			Expect(errors.Is(client.Get("repos/owner/repo/pulls/1", &pr), context.Canceled)).To(BeTrue())
+			Expect(mockClient.Requests).To(BeEmpty())
+		})
+
+		It("should stop requests given a live context once its own is cancelled", func() {
+			ctx, cancel := context.WithCancel(context.Background())
+			client := cmd.WithContextTest(mockClient, ctx)
+			cancel()
+
+			var pr cmd.PullRequest
+			err := client.DoWithContext(context.TODO(), "GET", "repos/owner/repo/pulls/1", nil, &pr)
+			Expect(errors.Is(err, context.Canceled)).To(BeTrue())
+			Expect(mockClient.Requests).To(BeEmpty())
+		})
+
+		It("should stop requests whose own context is cancelled", func() {
+			client := cmd.WithContextTest(mockClient, context.Background())
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			var pr cmd.PullRequest
+			Expect(client.DoWithContext(ctx, "GET", "repos/owner/repo/pulls/1", nil, &pr)).To(MatchError(context.Canceled))
+		})
+
+		It("should release the context of a raw request once its body is closed", func() {
+			recording := &contextRecordingClient{MockRESTClient: mockClient}
+			client := cmd.WithContextTest(recording, context.Background())
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			response, err := client.RequestWithContext(ctx, "GET", "repos/owner/repo/pulls/1", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(io.ReadAll(response.Body)).To(ContainSubstring(`"number":1`))
+			Expect(recording.ctx.Err()).NotTo(HaveOccurred())
+			Expect(response.Body.Close()).To(Succeed())
+			Expect(recording.ctx.Err()).To(MatchError(context.Canceled))
+		})
+	})
+
+	Describe("the repository timeout", func() {
+		It("should not set a deadline without --timeout", func() {
+			ctx, cancel := cmd.WithRepositoryTimeoutTest(context.Background())
+			defer cancel()
+
+			_, hasDeadline := ctx.Deadline()
+			Expect(hasDeadline).To(BeFalse())
+		})
+
+		It("should bound the repository by --timeout", func() {
+			cmd.SetRunTimeoutTest(time.Millisecond)
+			ctx, cancel := cmd.WithRepositoryTimeoutTest(context.Background())
+			defer cancel()
+
+			Eventually(ctx.Done()).Should(BeClosed())
+			Expect(cmd.DescribeCancellationTest(ctx.Err())).To(Equal("it took longer than --timeout 1ms"))
+		})
+
+		It("should describe an interruption", func() {
+			Expect(cmd.DescribeCancellationTest(context.Canceled)).To(Equal("interrupted"))
+		})
+
+		It("should not describe other errors as a cancellation", func() {
+			Expect(cmd.DescribeCancellationTest(errors.New("boom"))).To(BeEmpty())
+			Expect(cmd.DescribeCancellationTest(nil)).To(BeEmpty())
+		})
+	})
+})
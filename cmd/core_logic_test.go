@@ -393,6 +393,13 @@ var _ = Describe("Core Logic Functions", func() {
 				Expect(testPRs[0].Number).To(Equal(2))
 			})
 
+			It("should sort security updates above migration warnings", func() {
+				testPRs[2].Title = "fix(deps): update module golang.org/x/net [SECURITY]"
+				cmd.SortPullRequestsTest(testPRs, "priority")
+				Expect(testPRs[0].Number).To(Equal(3))
+				Expect(testPRs[1].Number).To(Equal(2))
+			})
+
 			It("should maintain order for newest/default sorting", func() {
 				originalNumbers := []int{testPRs[0].Number, testPRs[1].Number, testPRs[2].Number}
 				cmd.SortPullRequestsTest(testPRs, "newest")
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// debugMode logs every API call, cache lookup and raw payload, and keeps the payloads available through rawPayload
+var debugMode bool
+
+// knownMergeableStates are the mergeable_state values ghprs knows how to display
+var knownMergeableStates = map[string]bool{
+	"clean":     true,
+	"dirty":     true,
+	"behind":    true,
+	"blocked":   true,
+	"unstable":  true,
+	"has_hooks": true,
+	"draft":     true,
+	"unknown":   true,
+}
+
+// warnedOnce remembers which schema drift warnings were already logged this run
+var warnedOnce sync.Map
+
+// rawPayloads holds the last raw response body per request path, recorded in debug mode
+var rawPayloads sync.Map
+
+// warnOnce logs a warning the first time it is seen for key
+func warnOnce(key, format string, args ...interface{}) {
+	if _, seen := warnedOnce.LoadOrStore(key, true); seen {
+		return
+	}
+	logger.Warn(fmt.Sprintf(format, args...))
+}
+
+// warnUnexpectedValue reports an enum value ghprs does not know, once per field and value
+func warnUnexpectedValue(field, value string) {
+	warnOnce(field+"="+value, "GitHub API returned unexpected %s %q, it will be shown as unknown", field, value)
+}
+
+// normalizeMergeableState trims a mergeable_state and maps values ghprs does not know to "unknown",
+// so new GitHub states show as unknown instead of as "no rebase needed"
+func normalizeMergeableState(state string) string {
+	state = strings.TrimSpace(state)
+	if state == "" || knownMergeableStates[state] {
+		return state
+	}
+	warnUnexpectedValue("mergeable_state", state)
+	return "unknown"
+}
+
+// rawPayload returns the last raw response body received for a path (debug mode only)
+func rawPayload(path string) ([]byte, bool) {
+	data, ok := rawPayloads.Load(path)
+	if !ok {
+		return nil, false
+	}
+	return data.([]byte), true
+}
+
+// decodeJSON decodes an API response, tolerating fields whose type changed.
+// encoding/json already ignores unknown fields and keeps decoding past a mistyped field,
+// so a type error on a nested field is reported once and the rest of the payload is used.
+// A response whose overall shape doesn't match (e.g. an object instead of a list) is still an error.
+func decodeJSON(path string, data []byte, response interface{}) error {
+	err := json.Unmarshal(data, response)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) && typeErr.Field != "" {
+		warnOnce("type:"+typeErr.Field, "GitHub API returned a %s for field %q (in %s), ignoring it", typeErr.Value, typeErr.Field, path)
+		return nil
+	}
+	return err
+}
+
+// tolerantRESTClient decodes JSON responses with decodeJSON instead of failing on schema drift
+type tolerantRESTClient struct {
+	RESTClientInterface
+}
+
+// withTolerantDecoding wraps a client so responses are decoded tolerantly
+func withTolerantDecoding(client RESTClientInterface) RESTClientInterface {
+	return &tolerantRESTClient{RESTClientInterface: client}
+}
+
+// Get performs a GET request and decodes the response tolerantly
+func (c *tolerantRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post performs a POST request and decodes the response tolerantly
+func (c *tolerantRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request and decodes the response tolerantly
+func (c *tolerantRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request and decodes the response tolerantly
+func (c *tolerantRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request and decodes the response tolerantly
+func (c *tolerantRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do performs a request and decodes the response tolerantly
+func (c *tolerantRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext performs a request, records the raw payload in debug mode and decodes it tolerantly
+func (c *tolerantRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	resp, err := c.RequestWithContext(ctx, method, path, body)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode >= 400 {
+		if resp.Request != nil {
+			return api.HandleHTTPError(resp)
+		}
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if debugMode {
+		rawPayloads.Store(path, data)
+		logger.Debug("API response", "method", method, "path", path, "status", resp.StatusCode, "payload", string(data))
+	}
+
+	if response == nil || resp.StatusCode == http.StatusNoContent || len(data) == 0 {
+		return nil
+	}
+	return decodeJSON(path, data, response)
+}
+
+func init() {
+	RootCmd.PersistentFlags().BoolVar(&debugMode, "debug", false, "Log every GitHub API call, cache hit and miss, and raw payload to stderr")
+}
@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Tolerant Decoding", func() {
+	var mockClient *cmd.MockRESTClient
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+	})
+
+	AfterEach(func() {
+		cmd.SetDebugModeTest(false)
+	})
+
+	Describe("Decoding responses", func() {
+		It("should ignore unknown fields", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, map[string]interface{}{
+				"number":          1,
+				"title":           "Add feature",
+				"mergeable_state": "clean",
+				"brand_new_field": map[string]interface{}{"nested": true},
+			})
+
+			var pr cmd.PullRequest
+			err := cmd.WithTolerantDecodingTest(mockClient).Get("repos/owner/repo/pulls/1", &pr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pr.Number).To(Equal(1))
+			Expect(pr.MergeableState).To(Equal("clean"))
+		})
+
+		It("should keep the rest of the payload when a field changes type", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/2", 200, map[string]interface{}{
+				"number": 2,
+				"title":  "Bump deps",
+				"draft":  "maybe",
+				"labels": []map[string]interface{}{{"name": "lgtm"}},
+			})
+
+			var pr cmd.PullRequest
+			err := cmd.WithTolerantDecodingTest(mockClient).Get("repos/owner/repo/pulls/2", &pr)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(pr.Number).To(Equal(2))
+			Expect(pr.Title).To(Equal("Bump deps"))
+			Expect(pr.Draft).To(BeFalse())
+			Expect(pr.Labels).To(HaveLen(1))
+		})
+
+		It("should still fail when the overall shape of the response changes", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls", 200, map[string]interface{}{"message": "not a list"})
+
+			var prs []cmd.PullRequest
+			err := cmd.WithTolerantDecodingTest(mockClient).Get("repos/owner/repo/pulls", &prs)
+			Expect(err).To(HaveOccurred())
+		})
+
+		It("should return an error for HTTP error responses", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/3", 500, map[string]interface{}{"message": "boom"})
+
+			var pr cmd.PullRequest
+			err := cmd.WithTolerantDecodingTest(mockClient).Get("repos/owner/repo/pulls/3", &pr)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("Raw payload access", func() {
+		It("should record raw payloads only in debug mode", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/4", 200, map[string]interface{}{"number": 4})
+			mockClient.AddResponse("repos/owner/repo/pulls/5", 200, map[string]interface{}{"number": 5})
+			client := cmd.WithTolerantDecodingTest(mockClient)
+
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/4", &pr)).To(Succeed())
+			_, ok := cmd.RawPayloadTest("repos/owner/repo/pulls/4")
+			Expect(ok).To(BeFalse())
+
+			cmd.SetDebugModeTest(true)
+			Expect(client.Get("repos/owner/repo/pulls/5", &pr)).To(Succeed())
+			raw, ok := cmd.RawPayloadTest("repos/owner/repo/pulls/5")
+			Expect(ok).To(BeTrue())
+			Expect(string(raw)).To(ContainSubstring(`"number":5`))
+		})
+	})
+
+	Describe("Mergeable state normalization", func() {
+		It("should keep known states", func() {
+			for _, state := range []string{"clean", "dirty", "behind", "blocked", "unstable", "has_hooks", "draft", "unknown"} {
+				Expect(cmd.NormalizeMergeableStateTest(state)).To(Equal(state))
+			}
+		})
+
+		It("should trim whitespace and keep empty states empty", func() {
+			Expect(cmd.NormalizeMergeableStateTest("  dirty ")).To(Equal("dirty"))
+			Expect(cmd.NormalizeMergeableStateTest("   ")).To(Equal(""))
+		})
+
+		It("should treat unexpected states as unknown", func() {
+			Expect(cmd.NormalizeMergeableStateTest("queued_for_merge")).To(Equal("unknown"))
+		})
+
+		It("should report unexpected states as an unknown rebase status", func() {
+			cache := cmd.NewPRDetailsCacheTest()
+			pr := cmd.PullRequest{Number: 6, MergeableState: "some_new_state"}
+			needs, valid := cmd.NeedsRebaseWithCacheTest(cache, mockClient, "owner", "repo", pr)
+			Expect(needs).To(BeFalse())
+			Expect(valid).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"ghprs/pkg/ghprs"
+)
+
+// DependencyUpdate is a dependency a Renovate or Dependabot PR bumps
+type DependencyUpdate = ghprs.DependencyUpdate
+
+// parseUpdateTypeFilter checks the --update-type values and normalizes them to lower case
+func parseUpdateTypeFilter(values []string) ([]string, error) {
+	var types []string
+	for _, value := range values {
+		updateType := strings.ToLower(strings.TrimSpace(value))
+		if updateType == "" {
+			continue
+		}
+		if !slices.Contains(ghprs.UpdateTypes, updateType) {
+			return nil, fmt.Errorf("invalid update type %q (must be one of: %s)", value, strings.Join(ghprs.UpdateTypes, ", "))
+		}
+		types = append(types, updateType)
+	}
+	return types, nil
+}
+
+// matchesUpdateType reports whether the most disruptive dependency update of a PR is one of types, the values of
+// --update-type. PRs that update no dependency, or whose versions can't be compared, don't match.
+func matchesUpdateType(pr PullRequest, types []string) bool {
+	updateType := ghprs.HighestUpdateType(ghprs.ParseDependencyUpdates(pr.Title, pr.Body))
+	return updateType != "" && slices.Contains(types, updateType)
+}
+
+// dependencyColumn renders the DEP column of a row: the dependency the PR updates, and how many more it does
+func dependencyColumn(row PRRow) string {
+	if len(row.Dependencies) == 0 {
+		return ""
+	}
+	dep := row.Dependencies[0].Package
+	if len(row.Dependencies) > 1 {
+		dep += fmt.Sprintf(" +%d", len(row.Dependencies)-1)
+	}
+	return dep
+}
+
+// semverColumn renders the SEMVER column of a row: its most disruptive update, with the versions of a PR
+// updating a single dependency, e.g. "minor 1.2.0→1.3.0"
+func semverColumn(row PRRow) string {
+	if row.UpdateType == "" || len(row.Dependencies) != 1 {
+		return row.UpdateType
+	}
+	return fmt.Sprintf("%s %s→%s", row.UpdateType, row.Dependencies[0].From, row.Dependencies[0].To)
+}
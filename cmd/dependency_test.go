@@ -0,0 +1,77 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Dependency updates", func() {
+	renovateBody := func(update, change string) string {
+		return "| Package | Type | Update | Change |\n|---|---|---|---|\n" +
+			"| [golang.org/x/net](https://github.com/golang/net) | require | " + update + " | `" + change + "` |\n"
+	}
+	prs := []cmd.PullRequest{
+		{Number: 1, State: "open", Title: "fix(deps): update module golang.org/x/net to v0.21.0", Body: renovateBody("minor", "v0.20.0` -> `v0.21.0")},
+		{Number: 2, State: "open", Title: "Bump lodash from 3.10.1 to 4.17.21", Body: "Bumps [lodash](https://github.com/lodash/lodash) from 3.10.1 to 4.17.21."},
+		{Number: 3, State: "open", Title: "Fix the docs", Body: "Typos."},
+	}
+
+	AfterEach(func() {
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+	})
+
+	It("fills the dependencies of a row, even in fast mode", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--fast"}, false)).To(Succeed())
+		rows := cmd.BuildPRRowsTest(prs, "owner", "repo", cmd.NewMockRESTClient(), false)
+
+		Expect(rows[0].Dependencies).To(HaveLen(1))
+		Expect(rows[0].Dependencies[0].Package).To(Equal("golang.org/x/net"))
+		Expect(rows[0].UpdateType).To(Equal("minor"))
+		Expect(rows[1].UpdateType).To(Equal("major"))
+		Expect(rows[2].Dependencies).To(BeEmpty())
+		Expect(rows[2].UpdateType).To(BeEmpty())
+	})
+
+	It("keeps the PRs of --update-type by their most disruptive update", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--update-type", "major"}, false)).To(Succeed())
+		filtered := cmd.FilterPRsTest(prs, cmd.NewMockRESTClient(), "owner", "repo", false)
+		Expect(filtered).To(HaveLen(1))
+		Expect(filtered[0].Number).To(Equal(2))
+
+		Expect(cmd.UseListFlagsTest([]string{"--update-type", "minor,patch"}, false)).To(Succeed())
+		filtered = cmd.FilterPRsTest(prs, cmd.NewMockRESTClient(), "owner", "repo", false)
+		Expect(filtered).To(HaveLen(1))
+		Expect(filtered[0].Number).To(Equal(1))
+	})
+
+	It("validates --update-type", func() {
+		types, err := cmd.ParseUpdateTypeFilterTest([]string{"MAJOR", " patch"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(types).To(Equal([]string{"major", "patch"}))
+
+		_, err = cmd.ParseUpdateTypeFilterTest([]string{"breaking"})
+		Expect(err).To(MatchError(ContainSubstring("must be one of: major, minor, patch, digest")))
+	})
+
+	It("shows the DEP and SEMVER columns only when rows update dependencies", func() {
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+		defer cmd.ResetIOStreams()
+		Expect(cmd.UseListFlagsTest([]string{"--fast"}, false)).To(Succeed())
+
+		rows := cmd.BuildPRRowsTest(prs, "owner", "repo", cmd.NewMockRESTClient(), false)
+		cmd.RenderPRTableViewTest(rows, "owner", "repo", false, cmd.ViewDetailed, false)
+		Expect(out.String()).To(ContainSubstring("DEP"))
+		Expect(out.String()).To(ContainSubstring("minor 0.20.0→0.21.0"))
+		Expect(out.String()).To(ContainSubstring("major 3.10.1→4.17.21"))
+
+		out.Reset()
+		cmd.RenderPRTableViewTest(rows[2:], "owner", "repo", false, cmd.ViewDetailed, false)
+		Expect(out.String()).NotTo(ContainSubstring("SEMVER"))
+	})
+})
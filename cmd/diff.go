@@ -0,0 +1,221 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"ghprs/internal/render"
+)
+
+// diffView is how diffs are shown
+type diffView struct {
+	// Mode lays diffs out unified, split or word, see render.RenderDiff
+	Mode string
+	// Files are the --diff-file globs choosing the files of a diff that are shown, every file when empty
+	Files []string
+}
+
+// diffRule separates a diff from the output around it
+const diffRule = "═══════════════════════════════════════════════════════════════\n"
+
+// diffFile is the part of a diff that changes one file
+type diffFile struct {
+	// Path is the path of the file after the change
+	Path string
+	Text string
+}
+
+// splitDiffFiles splits a diff into the parts that change each file
+func splitDiffFiles(diff string) []diffFile {
+	var files []diffFile
+	start := -1
+	for offset := 0; offset < len(diff); {
+		end := len(diff)
+		if newline := strings.IndexByte(diff[offset:], '\n'); newline >= 0 {
+			end = offset + newline + 1
+		}
+		if header, ok := strings.CutPrefix(diff[offset:end], "diff --git "); ok {
+			if start >= 0 {
+				files[len(files)-1].Text = diff[start:offset]
+			}
+			filePath := strings.TrimSpace(header)
+			if i := strings.LastIndex(filePath, " b/"); i >= 0 {
+				filePath = filePath[i+len(" b/"):]
+			}
+			files = append(files, diffFile{Path: filePath})
+			start = offset
+		}
+		offset = end
+	}
+	if start >= 0 {
+		files[len(files)-1].Text = diff[start:]
+	}
+	return files
+}
+
+// joinDiffFiles puts the parts of a diff back together
+func joinDiffFiles(files []diffFile) string {
+	var b strings.Builder
+	for _, file := range files {
+		b.WriteString(file.Text)
+	}
+	return b.String()
+}
+
+// validateDiffFileGlobs checks that the --diff-file globs are valid patterns
+func validateDiffFileGlobs(globs []string) error {
+	for _, glob := range globs {
+		if _, err := path.Match(glob, ""); err != nil {
+			return fmt.Errorf("invalid --diff-file %q: %v", glob, err)
+		}
+	}
+	return nil
+}
+
+// matchesDiffFile reports whether a file matches one of globs, every file matching no globs. A glob matches
+// the path of the file, such as .tekton/*.yaml; a glob without a slash also matches its name, such as
+// *.yaml; and a glob ending in a slash matches everything in that directory, such as docs/.
+func matchesDiffFile(file string, globs []string) bool {
+	if len(globs) == 0 {
+		return true
+	}
+	for _, glob := range globs {
+		if matched, _ := path.Match(glob, file); matched {
+			return true
+		}
+		if !strings.Contains(glob, "/") {
+			if matched, _ := path.Match(glob, path.Base(file)); matched {
+				return true
+			}
+		}
+		if strings.HasSuffix(glob, "/") && strings.HasPrefix(file, glob) {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchDiff fetches the diff of a PR
+func fetchDiff(ctx context.Context, owner, repo string, prNumber int) (string, error) {
+	// The go-gh REST client doesn't expose direct HTTP methods for custom Accept headers,
+	// so we use a direct approach: use the .diff URL directly with authentication
+	// We'll construct the URL and use Go's http package but with authentication from go-gh
+	host := hostFor(owner, repo)
+	diffURL := prURL(owner, repo, prNumber) + ".diff"
+
+	// Create an HTTP request
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, diffURL, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create diff request: %v", err)
+	}
+
+	// Use the same token as the API clients: the token override, the chosen account's or go-gh's
+	token, _, err := tokenForHost(host)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch diff: %v", err)
+	}
+	if token != "" {
+		req.Header.Set("Authorization", "token "+token)
+	}
+
+	// Make the request
+	httpClient := &http.Client{}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to fetch diff: %v", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode != 200 {
+		return "", fmt.Errorf("failed to fetch diff: HTTP %d", resp.StatusCode)
+	}
+
+	// Read the diff content
+	diffContent, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("failed to read diff: %v", err)
+	}
+	return string(diffContent), nil
+}
+
+// displayDiff shows the diff of a PR as view lays it out, only the files matching --diff-file. On a terminal a
+// diff of several files is shown a file at a time.
+func displayDiff(ctx context.Context, owner, repo string, prNumber int, view diffView) error {
+	diff, err := fetchDiff(ctx, owner, repo, prNumber)
+	if err != nil {
+		return err
+	}
+
+	allFiles := splitDiffFiles(diff)
+	files := allFiles
+	if len(view.Files) > 0 {
+		files = nil
+		for _, file := range allFiles {
+			if matchesDiffFile(file.Path, view.Files) {
+				files = append(files, file)
+			}
+		}
+	}
+
+	streams.Printf("\n📄 Diff for PR %s", formatPRLink(owner, repo, prNumber))
+	if len(view.Files) > 0 {
+		streams.Printf(" (%d of %d files match %s)", len(files), len(allFiles), strings.Join(view.Files, ", "))
+	}
+	streams.Printf(":\n")
+	switch {
+	case len(files) == 0 && len(allFiles) > 0:
+		streams.Printf("   No changed file matches --diff-file\n")
+	case len(files) > 1 && streams.IsTerminal():
+		browseDiffFiles(files, view.Mode)
+	case len(allFiles) == 0:
+		// Not a diff git would write, show it as it is
+		showDiffText(diff, view.Mode)
+	default:
+		showDiffText(joinDiffFiles(files), view.Mode)
+	}
+	return nil
+}
+
+// browseDiffFiles shows the files of a diff one at a time, moving between them with n and p
+func browseDiffFiles(files []diffFile, mode string) {
+	for i := 0; i < len(files); {
+		streams.Printf("\n📄 File %d/%d: %s\n", i+1, len(files), files[i].Path)
+		showDiffText(files[i].Text, mode)
+		answer, err := prompter.Input(fmt.Sprintf("File %d/%d: [n]ext (default), [p]revious, [a]ll remaining, [q]uit diff: ", i+1, len(files)))
+		if err != nil {
+			return
+		}
+		switch strings.ToLower(answer) {
+		case "", "n", "next":
+			i++
+		case "p", "prev", "previous":
+			if i == 0 {
+				streams.Printf("Already at the first file.\n")
+				continue
+			}
+			i--
+		case "a", "all":
+			if i+1 < len(files) {
+				showDiffText(joinDiffFiles(files[i+1:]), mode)
+			}
+			return
+		case "q", "quit":
+			return
+		default:
+			streams.Printf("Unknown choice %q, use n, p, a or q.\n", answer)
+		}
+	}
+}
+
+// showDiffText lays a diff out in the chosen diff mode between rules, paging it when it is long
+func showDiffText(diff, mode string) {
+	rendered := render.RenderDiff(diff, render.DiffOptions{Mode: mode, Width: streams.Width(), Color: shouldUseColors()})
+	if !strings.HasSuffix(rendered, "\n") {
+		rendered += "\n"
+	}
+	pageOutput(diffRule + rendered + diffRule)
+}
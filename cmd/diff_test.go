@@ -0,0 +1,88 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Diff files", func() {
+	const diff = `diff --git a/.tekton/app-push.yaml b/.tekton/app-push.yaml
+index 1234567..89abcde 100644
+--- a/.tekton/app-push.yaml
++++ b/.tekton/app-push.yaml
+@@ -1 +1 @@
+-image: task-init:0.1
++image: task-init:0.2
+diff --git a/old.txt b/docs/new.txt
+similarity index 90%
+rename from old.txt
+rename to docs/new.txt
+diff --git a/main.go b/main.go
+deleted file mode 100644
+`
+
+	It("should split a diff by file, named by the path after the change", func() {
+		paths, texts := cmd.SplitDiffFilesTest(diff)
+		Expect(paths).To(Equal([]string{".tekton/app-push.yaml", "docs/new.txt", "main.go"}))
+		Expect(strings.Join(texts, "")).To(Equal(diff))
+		Expect(texts[2]).To(Equal("diff --git a/main.go b/main.go\ndeleted file mode 100644\n"))
+	})
+
+	DescribeTable("should match files by path, by name and by directory",
+		func(file string, globs []string, expected bool) {
+			Expect(cmd.MatchesDiffFileTest(file, globs)).To(Equal(expected))
+		},
+		Entry("no globs", "main.go", nil, true),
+		Entry("path", ".tekton/app-push.yaml", []string{".tekton/*"}, true),
+		Entry("name", ".tekton/app-push.yaml", []string{"*.yaml"}, true),
+		Entry("directory", "docs/guide/new.txt", []string{"docs/"}, true),
+		Entry("other directory", "main.go", []string{".tekton/*", "docs/"}, false),
+		Entry("globs with a slash match whole paths", "deploy/app.yaml", []string{"base/*.yaml"}, false),
+	)
+
+	It("should reject invalid globs", func() {
+		Expect(cmd.ValidateDiffFileGlobsTest([]string{"*.yaml", ".tekton/*"})).To(Succeed())
+		Expect(cmd.ValidateDiffFileGlobsTest([]string{"[.yaml"})).To(MatchError(ContainSubstring(`invalid --diff-file "[.yaml"`)))
+	})
+
+	Describe("browsing", func() {
+		var out *bytes.Buffer
+
+		browse := func(answers string) string {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(answers), out, &bytes.Buffer{}), nil)
+			cmd.BrowseDiffFilesTest(diff)
+			return out.String()
+		}
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should move to the next file by default and back with p", func() {
+			output := browse("\np\nn\nn\n")
+			Expect(strings.Count(output, "📄 File 1/3: .tekton/app-push.yaml")).To(Equal(2))
+			Expect(strings.Count(output, "📄 File 2/3: docs/new.txt")).To(Equal(2))
+			Expect(output).To(ContainSubstring("📄 File 3/3: main.go"))
+		})
+
+		It("should say so when going back from the first file", func() {
+			Expect(browse("p\nq\n")).To(ContainSubstring("Already at the first file."))
+		})
+
+		It("should show the remaining files at once with a and stop with q", func() {
+			output := browse("a\n")
+			Expect(output).NotTo(ContainSubstring("File 2/3"))
+			Expect(output).To(ContainSubstring("rename to docs/new.txt"))
+			Expect(output).To(ContainSubstring("deleted file mode 100644"))
+
+			output = browse("q\n")
+			Expect(output).NotTo(ContainSubstring("docs/new.txt"))
+		})
+	})
+})
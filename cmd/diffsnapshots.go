@@ -0,0 +1,393 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// Kinds of changes between two snapshots
+const (
+	ChangeOpened    = "opened"
+	ChangeMerged    = "merged"
+	ChangeClosed    = "closed"
+	ChangeGone      = "gone"
+	ChangeMigration = "migration"
+	ChangeChecks    = "checks"
+)
+
+// diffSnapshotsNoLookup keeps 'diff-snapshots' from asking GitHub what became of the PRs no longer listed
+var diffSnapshotsNoLookup bool
+
+// SnapshotDiff is what changed in the queue between two snapshots, the document of 'ghprs diff-snapshots --output
+// json|yaml'
+type SnapshotDiff struct {
+	SchemaVersion string              `json:"schemaVersion" yaml:"schemaVersion"`
+	From          string              `json:"from" yaml:"from"`
+	To            string              `json:"to" yaml:"to"`
+	Repositories  []RepositoryChanges `json:"repositories" yaml:"repositories"`
+}
+
+// RepositoryChanges are the changes of the PRs of one repository
+type RepositoryChanges struct {
+	Repository string           `json:"repository" yaml:"repository"`
+	Changes    []SnapshotChange `json:"changes" yaml:"changes"`
+}
+
+// SnapshotChange is one change of a PR between two snapshots
+type SnapshotChange struct {
+	Number int    `json:"number" yaml:"number"`
+	Title  string `json:"title" yaml:"title"`
+	URL    string `json:"url" yaml:"url"`
+	// Kind is opened, merged, closed, gone (no longer listed, merged or closed unknown), migration or checks
+	Kind string `json:"kind" yaml:"kind"`
+	// From and To are the check states of a checks change
+	From string `json:"from,omitempty" yaml:"from,omitempty"`
+	To   string `json:"to,omitempty" yaml:"to,omitempty"`
+}
+
+// snapshotPRLookup fetches a PR no longer in the newer snapshot, to tell whether it was merged or closed.
+// Replaced in tests.
+var snapshotPRLookup = func(ctx context.Context, owner, repo string, number int) (*PullRequest, error) {
+	client, err := newAPIClient(hostFor(owner, repo), nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	return fetchPRDetails(ctx, client, owner, repo, number)
+}
+
+// resolveSnapshot finds the snapshot a command line argument names: a file, or the name of a snapshot of dir
+// with or without .json
+func resolveSnapshot(dir, name string) (string, error) {
+	if _, err := os.Stat(name); err == nil {
+		return name, nil
+	}
+	path := filepath.Join(dir, strings.TrimSuffix(name, ".json")+".json")
+	if _, err := os.Stat(path); err != nil {
+		return "", fmt.Errorf("no snapshot %s, see 'ls %s'", name, dir)
+	}
+	return path, nil
+}
+
+// snapshotPaths picks the snapshots to compare: the two given, the one given and the latest, or the two latest
+func snapshotPaths(dir string, args []string) (string, string, error) {
+	switch len(args) {
+	case 2:
+		older, err := resolveSnapshot(dir, args[0])
+		if err != nil {
+			return "", "", err
+		}
+		newer, err := resolveSnapshot(dir, args[1])
+		return older, newer, err
+	case 1:
+		older, err := resolveSnapshot(dir, args[0])
+		if err != nil {
+			return "", "", err
+		}
+		paths, err := listSnapshots(dir)
+		if err != nil {
+			return "", "", err
+		}
+		if len(paths) == 0 {
+			return "", "", fmt.Errorf("no snapshot in %s, take one with 'ghprs snapshot'", dir)
+		}
+		return older, paths[len(paths)-1], nil
+	default:
+		paths, err := listSnapshots(dir)
+		if err != nil {
+			return "", "", err
+		}
+		if len(paths) < 2 {
+			return "", "", fmt.Errorf("%s needs two snapshots to compare, take them with 'ghprs snapshot'", dir)
+		}
+		return paths[len(paths)-2], paths[len(paths)-1], nil
+	}
+}
+
+// snapshotRows gathers the rows of every PR of a snapshot, those of list and konflux, by repository and number
+func snapshotRows(snapshot Snapshot) map[string]map[int]PRRow {
+	rows := make(map[string]map[int]PRRow)
+	for _, output := range []PRListOutput{snapshot.List, snapshot.Konflux} {
+		for _, repoPRs := range output.Repositories {
+			if rows[repoPRs.Repository] == nil {
+				rows[repoPRs.Repository] = make(map[int]PRRow)
+			}
+			for _, row := range repoPRs.PullRequests {
+				rows[repoPRs.Repository][row.Number] = row
+			}
+		}
+	}
+	return rows
+}
+
+// diffSnapshots lists the PRs opened, merged or closed, the new migration warnings and the check state changes
+// between two snapshots, by repository and PR number. PRs no longer listed are looked up with lookup, nil to
+// report them as gone.
+func diffSnapshots(ctx context.Context, older, newer Snapshot, lookup func(ctx context.Context, owner, repo string, number int) (*PullRequest, error)) SnapshotDiff {
+	diff := SnapshotDiff{
+		SchemaVersion: OutputSchemaVersion,
+		From:          older.TakenAt.UTC().Format(snapshotTimeFormat),
+		To:            newer.TakenAt.UTC().Format(snapshotTimeFormat),
+		Repositories:  []RepositoryChanges{},
+	}
+	before, after := snapshotRows(older), snapshotRows(newer)
+
+	var repositories []string
+	for repoSpec := range before {
+		repositories = append(repositories, repoSpec)
+	}
+	for repoSpec := range after {
+		if _, ok := before[repoSpec]; !ok {
+			repositories = append(repositories, repoSpec)
+		}
+	}
+	sort.Strings(repositories)
+
+	for _, repoSpec := range repositories {
+		var changes []SnapshotChange
+		change := func(row PRRow, kind string) SnapshotChange {
+			return SnapshotChange{Number: row.Number, Title: row.Title, URL: row.URL, Kind: kind}
+		}
+		for number, now := range after[repoSpec] {
+			was, existed := before[repoSpec][number]
+			switch {
+			case now.State == "closed" && (!existed || was.State != "closed"):
+				kind := ChangeClosed
+				if now.Merged {
+					kind = ChangeMerged
+				}
+				changes = append(changes, change(now, kind))
+				continue
+			case !existed:
+				changes = append(changes, change(now, ChangeOpened))
+				continue
+			}
+			if now.Migration && !was.Migration {
+				changes = append(changes, change(now, ChangeMigration))
+			}
+			if was.Checks != "" && now.Checks != "" && was.Checks != now.Checks {
+				checks := change(now, ChangeChecks)
+				checks.From, checks.To = was.Checks, now.Checks
+				changes = append(changes, checks)
+			}
+		}
+		// A repository missing from the newer snapshot wasn't snapshotted, which says nothing of its PRs
+		if _, snapshotted := after[repoSpec]; snapshotted {
+			owner, repo, _ := parseRepoSpec(repoSpec)
+			for number, was := range before[repoSpec] {
+				if _, exists := after[repoSpec][number]; exists || was.State == "closed" {
+					continue
+				}
+				kind := ChangeGone
+				if lookup != nil {
+					if pr, err := lookup(ctx, owner, repo, number); err != nil {
+						logger.Warn("Could not look up a PR no longer listed", "repo", repoSpec, "pr", number, "error", err)
+					} else if pr.Merged || pr.MergedAt != "" {
+						kind = ChangeMerged
+					} else if pr.State == "closed" {
+						kind = ChangeClosed
+					}
+				}
+				changes = append(changes, change(was, kind))
+			}
+		}
+		if len(changes) == 0 {
+			continue
+		}
+		sort.SliceStable(changes, func(i, j int) bool {
+			return changes[i].Number < changes[j].Number
+		})
+		diff.Repositories = append(diff.Repositories, RepositoryChanges{Repository: repoSpec, Changes: changes})
+	}
+	return diff
+}
+
+// describeSnapshotChange describes a change for people, with the icons of watch
+func describeSnapshotChange(change SnapshotChange) string {
+	switch change.Kind {
+	case ChangeOpened:
+		return "✨ opened"
+	case ChangeMerged:
+		return "🟣 merged"
+	case ChangeClosed:
+		return "🚫 closed"
+	case ChangeGone:
+		return "👋 no longer listed"
+	case ChangeMigration:
+		return "🚨 new migration warning"
+	case ChangeChecks:
+		icon := "🔁"
+		switch change.To {
+		case checksFailing:
+			icon = "❌"
+		case checksPassing:
+			icon = "✅"
+		}
+		return fmt.Sprintf("%s checks %s → %s", icon, change.From, change.To)
+	default:
+		return change.Kind
+	}
+}
+
+// snapshotDiffSummary counts the changes of each kind, e.g. "3 opened, 2 merged"
+func snapshotDiffSummary(diff SnapshotDiff) string {
+	counts := map[string]int{}
+	for _, repoChanges := range diff.Repositories {
+		for _, change := range repoChanges.Changes {
+			counts[change.Kind]++
+		}
+	}
+	var parts []string
+	for _, kind := range []struct{ kind, label string }{
+		{ChangeOpened, "opened"},
+		{ChangeMerged, "merged"},
+		{ChangeClosed, "closed"},
+		{ChangeGone, "no longer listed"},
+		{ChangeMigration, "new migration warning(s)"},
+		{ChangeChecks, "check change(s)"},
+	} {
+		if counts[kind.kind] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[kind.kind], kind.label))
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// writeSnapshotDiff writes the changes as text, Markdown for status pages and triage notes, or JSON or YAML
+func writeSnapshotDiff(w io.Writer, diff SnapshotDiff, format string) error {
+	switch format {
+	case OutputJSON, OutputYAML:
+		return writeStructuredOutput(w, diff, format)
+	case OutputTable, OutputPlain:
+		if _, err := fmt.Fprintf(w, "Changes between the snapshots of %s and %s\n", diff.From, diff.To); err != nil {
+			return err
+		}
+		if len(diff.Repositories) == 0 {
+			_, err := fmt.Fprintln(w, "\nNo changes")
+			return err
+		}
+		for _, repoChanges := range diff.Repositories {
+			if _, err := fmt.Fprintf(w, "\n%s:\n", repoChanges.Repository); err != nil {
+				return err
+			}
+			for _, change := range repoChanges.Changes {
+				if _, err := fmt.Fprintf(w, "  #%d %s: %s\n", change.Number, describeSnapshotChange(change), change.Title); err != nil {
+					return err
+				}
+			}
+		}
+		_, err := fmt.Fprintf(w, "\n%s\n", snapshotDiffSummary(diff))
+		return err
+	case OutputMarkdown:
+		if _, err := fmt.Fprintf(w, "## Changes between %s and %s\n", diff.From, diff.To); err != nil {
+			return err
+		}
+		if len(diff.Repositories) == 0 {
+			_, err := fmt.Fprintln(w, "\nNo changes")
+			return err
+		}
+		if _, err := fmt.Fprintf(w, "\n%s\n", snapshotDiffSummary(diff)); err != nil {
+			return err
+		}
+		for _, repoChanges := range diff.Repositories {
+			if _, err := fmt.Fprintf(w, "\n### %s\n\n", repoChanges.Repository); err != nil {
+				return err
+			}
+			for _, change := range repoChanges.Changes {
+				if _, err := fmt.Fprintf(w, "- [#%d](%s) %s: %s\n", change.Number, change.URL, describeSnapshotChange(change), change.Title); err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	default:
+		return fmt.Errorf("--output %s isn't supported by diff-snapshots (use table, markdown, json or yaml)", format)
+	}
+}
+
+// diffSnapshotsCmd reports what changed in the queue between two snapshots
+var diffSnapshotsCmd = &cobra.Command{
+	Use:   "diff-snapshots [old] [new]",
+	Short: "Show what changed in the queue between two snapshots",
+	Long: `Compare two snapshots saved by 'ghprs snapshot' and report, by repository, the PRs that were opened,
+merged or closed, that gained a migration warning, or whose checks changed state.
+
+The snapshots are named by the time they were taken (e.g. 20261015-080000) or given as files. Without
+arguments the two latest snapshots are compared, with one that snapshot and the latest.
+
+PRs no longer in the newer snapshot are looked up on GitHub to tell whether they were merged or closed,
+unless --no-lookup is given, which reports them as no longer listed.
+
+Examples:
+  ghprs diff-snapshots
+  ghprs diff-snapshots 20261009-080000
+  ghprs diff-snapshots 20261009-080000 20261016-080000 --output markdown > triage.md
+  ghprs diff-snapshots --no-lookup --output json`,
+	Args:              cobra.MaximumNArgs(2),
+	ValidArgsFunction: completeSnapshotNames,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+
+		olderPath, newerPath, err := snapshotPaths(snapshotsDir(), args)
+		if err != nil {
+			log.Fatal(err)
+		}
+		older, err := loadSnapshot(olderPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		newer, err := loadSnapshot(newerPath)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if newer.TakenAt.Before(older.TakenAt) {
+			older, newer = newer, older
+		}
+
+		lookup := snapshotPRLookup
+		if diffSnapshotsNoLookup {
+			lookup = nil
+		}
+		diff := diffSnapshots(ctx, older, newer, lookup)
+		if err := writeSnapshotDiff(streams.Out, diff, outputFormat); err != nil {
+			log.Fatal(err)
+		}
+	},
+}
+
+// completeSnapshotNames completes the names of the saved snapshots, newest first
+func completeSnapshotNames(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+	if len(args) >= 2 {
+		return nil, cobra.ShellCompDirectiveNoFileComp
+	}
+	paths, _ := listSnapshots(snapshotsDir())
+	var names []cobra.Completion
+	for i := len(paths) - 1; i >= 0; i-- {
+		name := strings.TrimSuffix(filepath.Base(paths[i]), ".json")
+		if strings.HasPrefix(name, toComplete) {
+			names = append(names, name)
+		}
+	}
+	return names, cobra.ShellCompDirectiveNoFileComp
+}
+
+func init() {
+	RootCmd.AddCommand(diffSnapshotsCmd)
+	diffSnapshotsCmd.Flags().BoolVar(&diffSnapshotsNoLookup, "no-lookup", false, "Don't ask GitHub whether the PRs no longer listed were merged or closed")
+}
@@ -0,0 +1,137 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Diffing snapshots", func() {
+	takenAt := time.Date(2026, 10, 9, 8, 0, 0, 0, time.UTC)
+
+	snapshotOf := func(at time.Time, list []cmd.PRRow, konflux []cmd.PRRow) cmd.Snapshot {
+		return cmd.Snapshot{
+			SchemaVersion: cmd.OutputSchemaVersion,
+			TakenAt:       at,
+			List:          cmd.PRListOutput{Repositories: []cmd.RepositoryPRs{{Repository: "owner/app", PullRequests: list}}},
+			Konflux:       cmd.PRListOutput{Konflux: true, Repositories: []cmd.RepositoryPRs{{Repository: "owner/app", PullRequests: konflux}}},
+		}
+	}
+	row := func(number int, title, checks string, migration bool) cmd.PRRow {
+		return cmd.PRRow{Number: number, Title: title, State: "open", Checks: checks, Migration: migration, URL: "https://github.com/owner/app/pull/" + title}
+	}
+	kinds := func(diff cmd.SnapshotDiff) []string {
+		var found []string
+		for _, repoChanges := range diff.Repositories {
+			for _, change := range repoChanges.Changes {
+				found = append(found, fmt.Sprintf("%s#%d %s", repoChanges.Repository, change.Number, change.Kind))
+			}
+		}
+		return found
+	}
+
+	It("reports opened PRs, new migration warnings and check changes", func() {
+		older := snapshotOf(takenAt, []cmd.PRRow{row(1, "one", "pending", false)}, []cmd.PRRow{row(2, "two", "passing", false)})
+		newer := snapshotOf(takenAt.Add(7*24*time.Hour),
+			[]cmd.PRRow{row(1, "one", "failing", false), row(3, "three", "pending", false)},
+			[]cmd.PRRow{row(2, "two", "passing", true)})
+
+		diff := cmd.DiffSnapshotsTest(older, newer, nil)
+		Expect(diff.From).To(Equal("20261009-080000"))
+		Expect(diff.To).To(Equal("20261016-080000"))
+		Expect(kinds(diff)).To(Equal([]string{"owner/app#1 checks", "owner/app#2 migration", "owner/app#3 opened"}))
+		Expect(diff.Repositories[0].Changes[0].From).To(Equal("pending"))
+		Expect(diff.Repositories[0].Changes[0].To).To(Equal("failing"))
+	})
+
+	It("looks up the PRs no longer listed to tell merged from closed", func() {
+		older := snapshotOf(takenAt, []cmd.PRRow{row(1, "one", "", false), row(2, "two", "", false), row(3, "three", "", false)}, nil)
+		newer := snapshotOf(takenAt.Add(time.Hour), nil, nil)
+		lookup := func(ctx context.Context, owner, repo string, number int) (*cmd.PullRequest, error) {
+			switch number {
+			case 1:
+				return &cmd.PullRequest{Number: 1, State: "closed", MergedAt: "2026-10-09T09:00:00Z"}, nil
+			case 2:
+				return &cmd.PullRequest{Number: 2, State: "closed"}, nil
+			default:
+				return nil, errors.New("offline")
+			}
+		}
+
+		Expect(kinds(cmd.DiffSnapshotsTest(older, newer, lookup))).To(Equal([]string{"owner/app#1 merged", "owner/app#2 closed", "owner/app#3 gone"}))
+		Expect(kinds(cmd.DiffSnapshotsTest(older, newer, nil))).To(Equal([]string{"owner/app#1 gone", "owner/app#2 gone", "owner/app#3 gone"}))
+	})
+
+	It("uses the state of PRs the newer snapshot lists as closed", func() {
+		merged := row(1, "one", "", false)
+		merged.State, merged.Merged = "closed", true
+		older := snapshotOf(takenAt, []cmd.PRRow{row(1, "one", "", false)}, nil)
+		newer := snapshotOf(takenAt.Add(time.Hour), []cmd.PRRow{merged}, nil)
+
+		Expect(kinds(cmd.DiffSnapshotsTest(older, newer, nil))).To(Equal([]string{"owner/app#1 merged"}))
+	})
+
+	It("says nothing of the PRs of repositories missing from the newer snapshot", func() {
+		older := snapshotOf(takenAt, []cmd.PRRow{row(1, "one", "", false)}, nil)
+		newer := cmd.Snapshot{TakenAt: takenAt.Add(time.Hour)}
+
+		Expect(cmd.DiffSnapshotsTest(older, newer, nil).Repositories).To(BeEmpty())
+	})
+
+	It("writes the changes as text with a summary, and as Markdown", func() {
+		older := snapshotOf(takenAt, []cmd.PRRow{row(1, "one", "pending", false)}, nil)
+		newer := snapshotOf(takenAt.Add(time.Hour), []cmd.PRRow{row(1, "one", "failing", false), row(2, "two", "", false)}, nil)
+		diff := cmd.DiffSnapshotsTest(older, newer, nil)
+
+		var out bytes.Buffer
+		Expect(cmd.WriteSnapshotDiffTest(&out, diff, cmd.OutputTable)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("owner/app:\n  #1 ❌ checks pending → failing: one\n  #2 ✨ opened: two\n"))
+		Expect(out.String()).To(ContainSubstring("1 opened, 1 check change(s)"))
+
+		out.Reset()
+		Expect(cmd.WriteSnapshotDiffTest(&out, diff, cmd.OutputMarkdown)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("### owner/app\n\n- [#1](https://github.com/owner/app/pull/one) ❌ checks pending → failing: one\n"))
+
+		out.Reset()
+		Expect(cmd.WriteSnapshotDiffTest(&out, cmd.DiffSnapshotsTest(older, older, nil), cmd.OutputTable)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("No changes"))
+
+		Expect(cmd.WriteSnapshotDiffTest(&out, diff, cmd.OutputCSV)).To(MatchError(ContainSubstring("isn't supported by diff-snapshots")))
+	})
+
+	It("picks the snapshots to compare", func() {
+		dir := GinkgoT().TempDir()
+		_, _, err := cmd.SnapshotPathsTest(dir, nil)
+		Expect(err).To(MatchError(ContainSubstring("needs two snapshots")))
+
+		for _, days := range []int{0, 1, 2} {
+			_, err := cmd.SaveSnapshotTest(dir, cmd.Snapshot{TakenAt: takenAt.Add(time.Duration(days) * 24 * time.Hour)})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		older, newer, err := cmd.SnapshotPathsTest(dir, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(older)).To(Equal("20261010-080000.json"))
+		Expect(filepath.Base(newer)).To(Equal("20261011-080000.json"))
+
+		older, newer, err = cmd.SnapshotPathsTest(dir, []string{"20261009-080000"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(older)).To(Equal("20261009-080000.json"))
+		Expect(filepath.Base(newer)).To(Equal("20261011-080000.json"))
+
+		older, newer, err = cmd.SnapshotPathsTest(dir, []string{"20261009-080000.json", filepath.Join(dir, "20261010-080000.json")})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(older)).To(Equal("20261009-080000.json"))
+		Expect(filepath.Base(newer)).To(Equal("20261010-080000.json"))
+
+		_, _, err = cmd.SnapshotPathsTest(dir, []string{"20200101-000000"})
+		Expect(err).To(MatchError(ContainSubstring("no snapshot 20200101-000000")))
+	})
+})
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/spf13/cobra"
+	"golang.org/x/term"
+
+	"ghprs/internal/render"
+)
+
+// emojiProbeTimeout is how long the terminal is given to report the cursor position
+const emojiProbeTimeout = 2 * time.Second
+
+// cursorPositionRE matches a terminal's cursor position report, ESC [ row ; column R
+var cursorPositionRE = regexp.MustCompile(`\x1b\[(\d+);(\d+)R`)
+
+// EmojiMode returns whether the tables show emoji, falling back to auto for unset or invalid values
+func (c *Config) EmojiMode() string {
+	if render.ValidateEmojiMode(c.Display.Emoji) != nil {
+		return render.EmojiAuto
+	}
+	return c.Display.Emoji
+}
+
+// setEmojiMode replaces emoji with ASCII tokens for mode. In auto mode that happens when the output is a
+// terminal that probably can't draw emoji; output to files and pipes keeps its emoji.
+func setEmojiMode(mode string) {
+	switch mode {
+	case render.EmojiNever:
+		render.SetASCII(true)
+	case render.EmojiAlways:
+		render.SetASCII(false)
+	default:
+		render.SetASCII(streams.IsTerminal() && !render.EmojiLikelySupported(os.Getenv))
+	}
+}
+
+// parseCursorColumn returns the column of a cursor position report
+func parseCursorColumn(report string) (int, error) {
+	match := cursorPositionRE.FindStringSubmatch(report)
+	if match == nil {
+		return 0, fmt.Errorf("unexpected cursor position report %q", report)
+	}
+	return strconv.Atoi(match[2])
+}
+
+// measureEmojiWidth prints an emoji at the start of the line and asks the terminal where the cursor ended up,
+// which tells how many columns the emoji really takes
+func measureEmojiWidth(in, out *os.File) (int, error) {
+	if !term.IsTerminal(int(in.Fd())) || !term.IsTerminal(int(out.Fd())) {
+		return 0, errors.New("the probe needs a terminal")
+	}
+	state, err := term.MakeRaw(int(in.Fd()))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = term.Restore(int(in.Fd()), state) }()
+
+	if _, err := fmt.Fprint(out, "\r🟢\x1b[6n"); err != nil {
+		return 0, err
+	}
+	reports := make(chan string, 1)
+	go func() {
+		report, _ := bufio.NewReader(in).ReadString('R')
+		reports <- report
+	}()
+
+	var column int
+	select {
+	case report := <-reports:
+		column, err = parseCursorColumn(report)
+	case <-time.After(emojiProbeTimeout):
+		err = errors.New("the terminal didn't report the cursor position")
+	}
+	_, _ = fmt.Fprint(out, "\r\x1b[K")
+	if err != nil {
+		return 0, err
+	}
+	// The cursor started in column 1
+	return column - 1, nil
+}
+
+// configProbeEmojiCmd measures how the terminal draws emoji and configures the emoji mode to match
+var configProbeEmojiCmd = &cobra.Command{
+	Use:   "probe-emoji",
+	Short: "Check whether the terminal draws emoji and configure the tables to match",
+	Long: `Print an emoji and ask the terminal how wide it was drawn. Terminals or fonts without emoji
+(common over SSH to older hosts) draw them as boxes of the wrong width, which misaligns the
+table columns; then the emoji setting is set to never so ASCII tokens are shown instead.
+Otherwise it is set to always.
+
+Run it in the terminal you use ghprs in. Without probing, emoji are replaced automatically when
+the terminal type or locale suggests they can't be drawn ('ghprs config set emoji auto').`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		width, err := measureEmojiWidth(os.Stdin, os.Stdout)
+		if err != nil {
+			fmt.Printf("Could not probe the terminal: %v\n", err)
+			os.Exit(1)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+		config.Display.Emoji = render.EmojiAlways
+		if width != 2 {
+			config.Display.Emoji = render.EmojiNever
+		}
+		if err := SaveConfig(config); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+
+		if config.Display.Emoji == render.EmojiNever {
+			fmt.Printf("Emoji are drawn %d column(s) wide instead of 2, the tables will show ASCII tokens (emoji: never)\n", width)
+		} else {
+			fmt.Println("Emoji are drawn correctly, the tables will show them (emoji: always)")
+		}
+	},
+}
+
+// setupEmoji draws the tables with or without emoji as the config says
+func setupEmoji() {
+	mode := render.EmojiAuto
+	if config, err := LoadConfig(); err == nil {
+		mode = config.EmojiMode()
+	}
+	setEmojiMode(mode)
+}
@@ -0,0 +1,42 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Emoji mode", func() {
+	AfterEach(func() {
+		render.SetASCII(false)
+	})
+
+	It("should come from the config, falling back to auto", func() {
+		config := cmd.DefaultConfig()
+		Expect(config.EmojiMode()).To(Equal(render.EmojiAuto))
+		config.Display.Emoji = render.EmojiNever
+		Expect(config.EmojiMode()).To(Equal(render.EmojiNever))
+		config.Display.Emoji = "sometimes"
+		Expect(config.EmojiMode()).To(Equal(render.EmojiAuto))
+	})
+
+	It("should switch to ASCII tokens when set to never, and keep emoji for output that isn't a terminal", func() {
+		cmd.SetEmojiModeTest(render.EmojiNever)
+		Expect(render.ASCII()).To(BeTrue())
+		cmd.SetEmojiModeTest(render.EmojiAlways)
+		Expect(render.ASCII()).To(BeFalse())
+		cmd.SetEmojiModeTest(render.EmojiAuto)
+		Expect(render.ASCII()).To(BeFalse())
+	})
+
+	It("should read the column from a cursor position report", func() {
+		column, err := cmd.ParseCursorColumnTest("\x1b[12;3R")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(column).To(Equal(3))
+
+		_, err = cmd.ParseCursorColumnTest("garbage")
+		Expect(err).To(HaveOccurred())
+	})
+})
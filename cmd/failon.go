@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+)
+
+// failOnExitCode is the exit status of list and konflux when a --fail-on condition matched an open PR, so that
+// a CI gate can tell a queue that needs attention from ghprs itself failing, which exits with status 1
+const failOnExitCode = 2
+
+// The conditions of --fail-on
+const (
+	FailOnMigration   = "migration"
+	FailOnSecurity    = "security"
+	FailOnOnHold      = "on-hold"
+	FailOnBlocked     = "blocked"
+	FailOnNeedsRebase = "needs-rebase"
+	// FailOnStale matches PRs not updated within display.stale_after, or within the window of stale:<window>
+	FailOnStale = "stale"
+)
+
+// failOnCondition is a --fail-on condition: a kind and, for stale, the window a PR must have been updated in
+type failOnCondition struct {
+	// Name is the condition as given, e.g. stale:14d
+	Name   string
+	kind   string
+	window time.Duration
+}
+
+// failOnState is what --fail-on found in the repositories checked so far
+type failOnState struct {
+	conditions []failOnCondition
+	// matches lists the PRs each condition matched, by condition name
+	matches map[string][]string
+	// unchecked are the repositories whose PRs and the PRs whose details couldn't be fetched, which the
+	// conditions say nothing about
+	unchecked []string
+}
+
+// failOn is what the --fail-on conditions of the running command matched
+var failOn failOnState
+
+// parseFailOn checks the --fail-on values: migration, security, on-hold, blocked, needs-rebase, stale or
+// stale:<window>, with the window as in --older-than
+func parseFailOn(values []string) ([]failOnCondition, error) {
+	var conditions []failOnCondition
+	for _, value := range values {
+		name := strings.ToLower(strings.TrimSpace(value))
+		kind, window, hasWindow := strings.Cut(name, ":")
+		condition := failOnCondition{Name: name, kind: kind}
+		if slices.ContainsFunc(conditions, func(c failOnCondition) bool { return c.Name == name }) {
+			continue
+		}
+		switch kind {
+		case FailOnMigration, FailOnSecurity, FailOnOnHold, FailOnBlocked, FailOnNeedsRebase:
+			if hasWindow {
+				return nil, fmt.Errorf("invalid --fail-on %q, only stale takes a window", value)
+			}
+		case FailOnStale:
+			if hasWindow {
+				age, err := parseHoldDuration(window)
+				if err != nil || age <= 0 {
+					return nil, fmt.Errorf("invalid --fail-on %q (use e.g. stale:14d, stale:2w or stale:36h)", value)
+				}
+				condition.window = age
+			}
+		default:
+			return nil, fmt.Errorf("invalid --fail-on %q (must be %s, %s, %s, %s, %s or %s[:window])", value,
+				FailOnMigration, FailOnSecurity, FailOnOnHold, FailOnBlocked, FailOnNeedsRebase, FailOnStale)
+		}
+		conditions = append(conditions, condition)
+	}
+	return conditions, nil
+}
+
+// needsDetails reports whether checking the condition takes the full details of a PR, which the list of PRs
+// lacks
+func (c failOnCondition) needsDetails() bool {
+	return c.kind == FailOnBlocked || c.kind == FailOnNeedsRebase
+}
+
+// failOnNeedsDetails reports whether checking any of the conditions takes the full details of the PRs
+func failOnNeedsDetails(conditions []failOnCondition) bool {
+	return slices.ContainsFunc(conditions, failOnCondition.needsDetails)
+}
+
+// matches reports whether an open PR meets the condition, fetching its details into cache when needed. A PR
+// whose mergeable state GitHub hasn't computed yet doesn't match blocked or needs-rebase.
+func (c failOnCondition) matches(client RESTClientInterface, owner, repo string, pr PullRequest, cache *PRDetailsCache, now time.Time) bool {
+	switch c.kind {
+	case FailOnMigration:
+		return hasMigrationWarning(pr)
+	case FailOnSecurity:
+		return hasSecurity(pr)
+	case FailOnOnHold:
+		return isOnHold(pr)
+	case FailOnBlocked:
+		blocked, hasState := isBlockedWithCache(cache, client, owner, repo, pr)
+		return hasState && blocked
+	case FailOnNeedsRebase:
+		rebase, hasState := needsRebaseWithCache(cache, client, owner, repo, pr)
+		return hasState && rebase
+	case FailOnStale:
+		if c.window == 0 {
+			return isStalePR(pr, now)
+		}
+		updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt)
+		return err == nil && now.Sub(updatedAt) > c.window
+	default:
+		return false
+	}
+}
+
+// reset starts checking the conditions of a run
+func (s *failOnState) reset(conditions []failOnCondition) {
+	*s = failOnState{conditions: conditions, matches: make(map[string][]string)}
+}
+
+// active reports whether --fail-on was given
+func (s *failOnState) active() bool {
+	return len(s.conditions) > 0
+}
+
+// check records the open PRs of a repository that meet the conditions, checking concurrency PRs at a time like
+// the rows are built. The details it fetches are kept in cache, so the rows built afterwards don't fetch them
+// again. A PR whose details can't be fetched counts as unchecked.
+func (s *failOnState) check(ctx context.Context, client RESTClientInterface, owner, repo string, pullRequests []PullRequest, cache *PRDetailsCache, concurrency int) {
+	now := time.Now()
+	matched := make([][]string, len(pullRequests))
+	runConcurrently(len(pullRequests), concurrency, func(i int) {
+		if pullRequests[i].State != "open" {
+			return
+		}
+		for _, condition := range s.conditions {
+			if condition.matches(client, owner, repo, pullRequests[i], cache, now) {
+				matched[i] = append(matched[i], condition.Name)
+			}
+		}
+	})
+
+	for i, pr := range pullRequests {
+		link := fmt.Sprintf("%s/%s#%d", owner, repo, pr.Number)
+		for _, name := range matched[i] {
+			s.matches[name] = append(s.matches[name], link)
+		}
+		if err := cache.FetchError(pr.Number); err != nil && pr.State == "open" && failOnNeedsDetails(s.conditions) {
+			logger.DebugContext(ctx, "Could not check the --fail-on conditions of a PR", "pr", link, "error", err)
+			s.skip(link)
+		}
+	}
+}
+
+// skip records a repository or PR the conditions couldn't be checked for
+func (s *failOnState) skip(what string) {
+	if s.active() {
+		s.unchecked = append(s.unchecked, what)
+	}
+}
+
+// report lists the PRs each condition matched and what couldn't be checked on stderr, keeping stdout to the
+// table or the structured output, and returns the exit status of the run: failOnExitCode when a condition
+// matched, 1 when something couldn't be checked, else 0
+func (s *failOnState) report() int {
+	if !s.active() {
+		return 0
+	}
+	matched := false
+	for _, condition := range s.conditions {
+		prs := s.matches[condition.Name]
+		if len(prs) == 0 {
+			continue
+		}
+		matched = true
+		_, _ = fmt.Fprintf(streams.ErrOut, "❌ --fail-on %s: %d open PR(s): %s\n", condition.Name, len(prs), strings.Join(prs, ", "))
+	}
+	if len(s.unchecked) > 0 {
+		_, _ = fmt.Fprintf(streams.ErrOut, "⚠️  --fail-on could not check %s\n", strings.Join(s.unchecked, ", "))
+	}
+	switch {
+	case matched:
+		return failOnExitCode
+	case len(s.unchecked) > 0:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// exitOnFailOn exits with the status of report when a --fail-on condition matched or couldn't be checked
+func exitOnFailOn() {
+	if code := failOn.report(); code != 0 {
+		os.Exit(code)
+	}
+}
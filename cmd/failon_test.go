@@ -0,0 +1,90 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("--fail-on", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		errOut     *bytes.Buffer
+		prs        []cmd.PullRequest
+	)
+	ago := func(d time.Duration) string {
+		return time.Now().Add(-d).Format(time.RFC3339)
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "blocked"})
+		mockClient.AddResponse("repos/owner/repo/pulls/2", 200, cmd.PullRequest{Number: 2, MergeableState: "clean"})
+		mockClient.AddResponse("repos/owner/repo/pulls/3", 200, cmd.PullRequest{Number: 3, MergeableState: "behind"})
+		prs = []cmd.PullRequest{
+			{Number: 1, State: "open", Title: "Update pipelines", UpdatedAt: ago(20 * 24 * time.Hour)},
+			{Number: 2, State: "open", Title: "Bump deps", Body: "⚠️[migration] Rename the task", UpdatedAt: ago(time.Hour)},
+			{Number: 3, State: "open", Title: "Fix CVE-2024-1234", UpdatedAt: ago(3 * 24 * time.Hour)},
+			{Number: 4, State: "closed", Title: "Old migration", Body: "⚠️[migration] done", UpdatedAt: ago(40 * 24 * time.Hour)},
+		}
+		errOut = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), &bytes.Buffer{}, errOut), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should accept the conditions and stale windows", func() {
+		names, err := cmd.ParseFailOnTest([]string{"Migration", "blocked", "stale:14d", "stale", "blocked"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(names).To(Equal([]string{"migration", "blocked", "stale:14d", "stale"}))
+
+		for _, invalid := range []string{"merged", "stale:soon", "stale:0d", "blocked:2d"} {
+			_, err := cmd.ParseFailOnTest([]string{invalid})
+			Expect(err).To(HaveOccurred(), invalid)
+		}
+	})
+
+	It("should exit with status 2 and list the open PRs a condition matched", func() {
+		code, err := cmd.CheckFailOnTest([]string{"migration", "blocked", "stale:14d"}, mockClient, "owner", "repo", prs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(Equal(2))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on migration: 1 open PR(s): owner/repo#2\n"))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on blocked: 1 open PR(s): owner/repo#1\n"))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on stale:14d: 1 open PR(s): owner/repo#1\n"))
+	})
+
+	It("should exit with status 0 when no open PR matches", func() {
+		code, err := cmd.CheckFailOnTest([]string{"on-hold", "stale:30d"}, mockClient, "owner", "repo", prs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(code).To(BeZero())
+		Expect(errOut.String()).To(BeEmpty())
+	})
+
+	It("should match needs-rebase and security updates", func() {
+		code, _ := cmd.CheckFailOnTest([]string{"needs-rebase", "security"}, mockClient, "owner", "repo", prs)
+		Expect(code).To(Equal(2))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on needs-rebase: 1 open PR(s): owner/repo#3\n"))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on security: 1 open PR(s): owner/repo#3\n"))
+	})
+
+	It("should fail when what it had to check couldn't be fetched", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/2", fmt.Errorf("HTTP 502"))
+
+		code, _ := cmd.CheckFailOnTest([]string{"needs-rebase"}, mockClient, "owner", "repo", prs, "owner/other")
+		Expect(code).To(Equal(2))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on could not check owner/other, owner/repo#2\n"))
+
+		errOut.Reset()
+		code, _ = cmd.CheckFailOnTest([]string{"on-hold"}, mockClient, "owner", "repo", prs, "owner/other")
+		Expect(code).To(Equal(1))
+		Expect(errOut.String()).To(ContainSubstring("--fail-on could not check owner/other\n"))
+	})
+})
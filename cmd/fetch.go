@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxPerPage is the largest page size the GitHub REST API accepts
+const maxPerPage = 100
+
+// prFilter returns the PRs of a fetched page that should be kept, in order.
+// It receives the client to use for any per-PR API calls the filtering needs.
+type prFilter func(client RESTClientInterface, page []PullRequest) []PullRequest
+
+// pageProgress shows how far a fetch spanning several pages has got, on a single line of ErrOut that is
+// rewritten before every page. Nothing is shown while the first page loads, or when ErrOut isn't a terminal.
+type pageProgress struct {
+	out   io.Writer
+	repo  string
+	shown bool
+}
+
+// newPageProgress creates the progress of fetching the PRs of owner/repo
+func newPageProgress(owner, repo string) *pageProgress {
+	progress := &pageProgress{repo: owner + "/" + repo}
+	if streams.IsErrTerminal() {
+		progress.out = streams.ErrOut
+	}
+	return progress
+}
+
+// next reports that page is about to be fetched, after fetched PRs of which matched were kept
+func (p *pageProgress) next(page, fetched, matched int) {
+	if p.out == nil || page < 2 {
+		return
+	}
+	p.shown = true
+	if fetched == matched {
+		_, _ = fmt.Fprintf(p.out, "\r\033[K⏳ Fetching PRs of %s: page %d (%d PRs so far)", p.repo, page, fetched)
+		return
+	}
+	_, _ = fmt.Fprintf(p.out, "\r\033[K⏳ Fetching PRs of %s: page %d (%d PRs so far, %d matching)", p.repo, page, fetched, matched)
+}
+
+// done clears the progress line, if one was shown
+func (p *pageProgress) done() {
+	if p.shown {
+		_, _ = fmt.Fprint(p.out, "\r\033[K")
+		p.shown = false
+	}
+}
+
+// fetchPullRequestsREST pages through pull requests from the REST API, keeping only the PRs returned by filter
+// (nil keeps everything), until maxPRs matching PRs are collected or there are no more pages.
+// A maxPRs of 0 fetches every matching PR. Only matching PRs are retained between pages.
+func fetchPullRequestsREST(client RESTClientInterface, owner, repo, state, baseRef string, maxPRs int, filter prFilter) ([]PullRequest, error) {
+	return fetchPullRequestPages(client, owner, repo, pullRequestParams(state, baseRef), time.Time{}, maxPRs, filter)
+}
+
+// fetchUpdatedPullRequestsREST is fetchPullRequestsREST for the PRs updated since a time. PRs are fetched most
+// recently updated first, so paging stops at the first PR updated before since.
+func fetchUpdatedPullRequestsREST(client RESTClientInterface, owner, repo, state, baseRef string, since time.Time, maxPRs int, filter prFilter) ([]PullRequest, error) {
+	params := append(pullRequestParams(state, baseRef), "sort=updated", "direction=desc")
+	return fetchPullRequestPages(client, owner, repo, params, since, maxPRs, filter)
+}
+
+// pullRequestParams returns the query parameters of the PR list for state and target branch
+func pullRequestParams(state, baseRef string) []string {
+	params := []string{}
+	if state != "" {
+		params = append(params, "state="+state)
+	}
+
+	// Apply target branch filter directly to API call if specified
+	if baseRef != "" {
+		params = append(params, "base="+baseRef)
+	}
+	return params
+}
+
+// fetchPullRequestPages pages through the PR list with params. A non-zero since ends the list at the first PR
+// updated before it, which requires params to sort by update time.
+func fetchPullRequestPages(client RESTClientInterface, owner, repo string, params []string, since time.Time, maxPRs int, filter prFilter) ([]PullRequest, error) {
+	basePath := fmt.Sprintf("repos/%s/%s/pulls", owner, repo)
+
+	// Ask for exactly what we need when every PR counts towards the limit, otherwise use full pages
+	perPage := maxPerPage
+	if filter == nil && since.IsZero() && maxPRs > 0 && maxPRs < maxPerPage {
+		perPage = maxPRs
+	}
+	params = append(params, "per_page="+strconv.Itoa(perPage))
+
+	progress := newPageProgress(owner, repo)
+	defer progress.done()
+
+	var pullRequests []PullRequest
+	fetched := 0
+	for page := 1; ; page++ {
+		progress.next(page, fetched, len(pullRequests))
+		path := basePath + "?" + strings.Join(append(params, "page="+strconv.Itoa(page)), "&")
+
+		var pagePRs []PullRequest
+		if err := client.Get(path, &pagePRs); err != nil {
+			return nil, err
+		}
+		fetched += len(pagePRs)
+
+		// The PRs after the first one updated before since are older still
+		inWindow := pagePRs
+		if !since.IsZero() {
+			for i, pr := range pagePRs {
+				if updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt); err == nil && updatedAt.Before(since) {
+					inWindow = pagePRs[:i]
+					break
+				}
+			}
+		}
+
+		matches := inWindow
+		if filter != nil {
+			matches = filter(client, inWindow)
+		}
+		for _, pr := range matches {
+			pullRequests = append(pullRequests, pr)
+			if maxPRs > 0 && len(pullRequests) >= maxPRs {
+				return pullRequests, nil
+			}
+		}
+
+		// A short page, or reaching a PR updated before the window, means there is nothing left to fetch
+		if len(inWindow) < len(pagePRs) || len(pagePRs) < perPage {
+			return pullRequests, nil
+		}
+	}
+}
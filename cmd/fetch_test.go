@@ -0,0 +1,194 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// mockPRPage builds count PRs starting at number start, alternating between two authors
+func mockPRPage(start, count int) []cmd.PullRequest {
+	prs := make([]cmd.PullRequest, count)
+	for i := range prs {
+		author := "human"
+		if (start+i)%2 == 0 {
+			author = "red-hat-konflux[bot]"
+		}
+		prs[i] = cmd.PullRequest{Number: start + i, State: "open", User: cmd.User{Login: author}}
+	}
+	return prs
+}
+
+var _ = Describe("REST PR Fetching", func() {
+	var mockClient *cmd.MockRESTClient
+	const pulls = "repos/owner/repo/pulls?state=open"
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+	})
+
+	It("should request exactly the limit when no predicate is applied", func() {
+		mockClient.AddResponse(pulls+"&per_page=5&page=1", 200, mockPRPage(1, 5))
+
+		prs, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 5, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(5))
+		Expect(mockClient.Requests).To(HaveLen(1))
+	})
+
+	It("should fetch every page in batches of 100 when the limit is 0", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=3", 200, mockPRPage(201, 17))
+
+		prs, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 0, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(217))
+		Expect(mockClient.Requests).To(HaveLen(3))
+	})
+
+	It("should keep paging until the limit is met after author filtering", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 100))
+
+		prs, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 60, "red-hat-konflux[bot]")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(60))
+		for _, pr := range prs {
+			Expect(pr.User.Login).To(Equal("red-hat-konflux[bot]"))
+		}
+		Expect(mockClient.Requests).To(HaveLen(2))
+	})
+
+	It("should stop when pages are exhausted before reaching the limit", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 10))
+
+		prs, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 30, "red-hat-konflux[bot]")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(5))
+	})
+
+	It("should pass the base branch to the API", func() {
+		path := "repos/owner/repo/pulls?state=open&base=main&per_page=3&page=1"
+		mockClient.AddResponse(path, 200, mockPRPage(1, 3))
+
+		_, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "main", 3, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(mockClient.GetLastRequest().URL).To(Equal(path))
+	})
+
+	It("should return API errors", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls", fmt.Errorf("rate limited"))
+
+		_, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 5, "")
+		Expect(err).To(MatchError("rate limited"))
+	})
+
+	Describe("Konflux limit after filtering", func() {
+		It("should keep paginating until the limit of bot PRs is collected", func() {
+			// Only a handful of bot PRs on the first page, the rest on the second
+			firstPage := make([]cmd.PullRequest, 100)
+			for i := range firstPage {
+				firstPage[i] = cmd.PullRequest{Number: i + 1, State: "open", User: cmd.User{Login: "human"}}
+			}
+			firstPage[10].User.Login = "red-hat-konflux[bot]"
+			firstPage[50].User.Login = "red-hat-konflux[bot]"
+			mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, firstPage)
+			mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 20))
+			mockClient.AddResponse("/files", 200, cmd.CreateMockPRFiles(true))
+
+			prs, err := cmd.FetchFilteredPullRequestsTest(mockClient, "owner", "repo", "open", 5, "red-hat-konflux[bot]", true)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prs).To(HaveLen(5))
+			Expect(prs[0].Number).To(Equal(11))
+			Expect(prs[1].Number).To(Equal(51))
+			Expect(mockClient.GetRequestCount("pulls?state=open")).To(Equal(2))
+		})
+
+		It("should return what it found when pages run out", func() {
+			mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 7))
+
+			prs, err := cmd.FetchFilteredPullRequestsTest(mockClient, "owner", "repo", "open", 5, "red-hat-konflux[bot]", false)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prs).To(HaveLen(3))
+		})
+	})
+
+	It("should keep PRs by any of several authors", func() {
+		page := []cmd.PullRequest{
+			{Number: 1, State: "open", User: cmd.User{Login: "renovate[bot]"}},
+			{Number: 2, State: "open", User: cmd.User{Login: "human"}},
+			{Number: 3, State: "open", User: cmd.User{Login: "Dependabot[bot]"}},
+		}
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, page)
+
+		prs, err := cmd.FetchPullRequestsByAuthorsTest(mockClient, "owner", "repo", "open", 5, []string{"renovate[bot]", "dependabot[bot]"}, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(2))
+		Expect(prs[0].Number).To(Equal(1))
+		Expect(prs[1].Number).To(Equal(3))
+	})
+})
+
+var _ = Describe("Paging progress", func() {
+	var mockClient *cmd.MockRESTClient
+	var out, errOut *bytes.Buffer
+	const pulls = "repos/owner/repo/pulls?state=open"
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out, errOut = &bytes.Buffer{}, &bytes.Buffer{}
+		streams := cmd.NewIOStreams(&bytes.Buffer{}, out, errOut)
+		streams.SetErrTerminal(true)
+		cmd.SetIOStreams(streams, nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should show the page being fetched on multi-page fetches and clear it afterwards", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=3", 200, mockPRPage(201, 5))
+
+		prs, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 0, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(205))
+		Expect(errOut.String()).To(ContainSubstring("Fetching PRs of owner/repo: page 2 (100 PRs so far)"))
+		Expect(errOut.String()).To(ContainSubstring("page 3 (200 PRs so far)"))
+		Expect(errOut.String()).To(HaveSuffix("\r\033[K"))
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should count matching PRs separately when filtering", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 10))
+
+		_, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 0, "red-hat-konflux[bot]")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errOut.String()).To(ContainSubstring("page 2 (100 PRs so far, 50 matching)"))
+	})
+
+	It("should show nothing for a single page", func() {
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 40))
+
+		_, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 0, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errOut.String()).To(BeEmpty())
+	})
+
+	It("should show nothing when stderr isn't a terminal", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, errOut), nil)
+		mockClient.AddResponse(pulls+"&per_page=100&page=1", 200, mockPRPage(1, 100))
+		mockClient.AddResponse(pulls+"&per_page=100&page=2", 200, mockPRPage(101, 1))
+
+		_, err := cmd.FetchPullRequestsRESTTest(mockClient, "owner", "repo", "open", "", 0, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(errOut.String()).To(BeEmpty())
+	})
+})
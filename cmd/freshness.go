@@ -0,0 +1,151 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+// prURLPathRE matches the API path of a single PR, with the /api/v3 prefix of GitHub Enterprise Server
+var prURLPathRE = regexp.MustCompile(`/repos/[^/]+/[^/]+/pulls/\d+$`)
+
+// prValidator is the ETag of the last full response for a PR and the body it came with
+type prValidator struct {
+	etag string
+	body []byte
+}
+
+var (
+	prValidatorsMutex sync.Mutex
+	// prValidators maps the URL of a PR, without its query, to the validator of its last full response
+	prValidators = map[string]prValidator{}
+)
+
+// revalidatingTransport makes the fresh reads of a PR conditional. It remembers the ETag and body of every full
+// response for a PR, and a fresh read of a PR it has seen sends If-None-Match with that ETag. GitHub answers 304
+// when the PR is unchanged, which doesn't count against the rate limit, and the transport then returns the
+// remembered body as a 200 so the clients above it decode it like any other response.
+type revalidatingTransport struct {
+	base http.RoundTripper
+}
+
+// RoundTrip performs a request, revalidating the remembered response of a PR for fresh reads
+func (t *revalidatingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet || !prURLPathRE.MatchString(req.URL.Path) {
+		return t.base.RoundTrip(req)
+	}
+	key := req.URL.Host + req.URL.Path
+	prValidatorsMutex.Lock()
+	validator, known := prValidators[key]
+	prValidatorsMutex.Unlock()
+
+	conditional := known && wantsFreshData(req.Context())
+	if conditional {
+		req = req.Clone(req.Context())
+		req.Header.Set("If-None-Match", validator.etag)
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+
+	switch {
+	case conditional && resp.StatusCode == http.StatusNotModified:
+		logger.DebugContext(req.Context(), "PR unchanged since its last response", "path", req.URL.Path)
+		_ = resp.Body.Close()
+		resp.StatusCode, resp.Status = http.StatusOK, "200 OK"
+		resp.Body = io.NopCloser(bytes.NewReader(validator.body))
+		resp.ContentLength = int64(len(validator.body))
+		resp.Header = resp.Header.Clone()
+		resp.Header.Set("Content-Length", strconv.Itoa(len(validator.body)))
+	case resp.StatusCode == http.StatusOK && resp.Header.Get("ETag") != "":
+		body, err := io.ReadAll(resp.Body)
+		_ = resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		prValidatorsMutex.Lock()
+		prValidators[key] = prValidator{etag: resp.Header.Get("ETag"), body: body}
+		prValidatorsMutex.Unlock()
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+	}
+	return resp, nil
+}
+
+// prChanges compares a PR as it was displayed with its current state and describes what changed.
+// Changes that make the action pointless or unsafe (merged, closed, or new commits when approving) are blocking.
+func prChanges(displayed, current PullRequest, action string) (changes []string, blocking bool) {
+	switch {
+	case current.Merged:
+		return []string{"PR was merged since displayed"}, true
+	case current.State == "closed" && displayed.State != "closed":
+		return []string{"PR was closed since displayed"}, true
+	}
+
+	if displayed.Head.SHA != "" && current.Head.SHA != displayed.Head.SHA {
+		changes = append(changes, fmt.Sprintf("PR changed since displayed (head was %s, now %s)",
+			shortSHA(displayed.Head.SHA), shortSHA(current.Head.SHA)))
+		// Approving would approve commits that weren't reviewed
+		if action == "approve" {
+			return changes, true
+		}
+	} else if displayed.UpdatedAt != "" && current.UpdatedAt != displayed.UpdatedAt {
+		changes = append(changes, fmt.Sprintf("PR was updated since displayed (at %s)", current.UpdatedAt))
+	}
+	return changes, false
+}
+
+// confirmPRUnchanged re-fetches the PR right before action modifies it, bypassing cached and prefetched data.
+// Blocking changes stop the action; other changes and failed checks are shown and the user decides. When the PR
+// was fetched before, revalidatingTransport makes the re-fetch a conditional request, so an unchanged PR costs
+// a 304 that doesn't count against the rate limit; otherwise every action costs one GET more.
+func confirmPRUnchanged(client RESTClientInterface, owner, repo string, pr PullRequest, action string) bool {
+	link := formatPRLink(owner, repo, pr.Number)
+
+	var current PullRequest
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pr.Number)
+	if err := client.DoWithContext(withFreshData(context.Background()), http.MethodGet, path, nil, &current); err != nil {
+		if action == "approve" {
+			streams.Printf("❌ Not approving %s: could not check the PR's head commit: %v\n", link, err)
+			return false
+		}
+		streams.Printf("⚠️  Could not check whether %s changed since displayed: %v\n", link, err)
+		return confirmAnyway(action)
+	}
+
+	changes, blocking := prChanges(pr, current, action)
+	if len(changes) == 0 {
+		return true
+	}
+	if blocking {
+		streams.Printf("❌ Not going to %s %s: %s\n", action, link, changes[0])
+		return false
+	}
+	for _, change := range changes {
+		streams.Printf("⚠️  %s: %s\n", link, change)
+	}
+	return confirmAnyway(action)
+}
+
+// confirmAnyway asks whether to go ahead with action despite a warning
+func confirmAnyway(action string) bool {
+	confirmed, err := prompter.Confirm(fmt.Sprintf("Do you still want to %s it?", action))
+	if err != nil || !confirmed {
+		streams.Printf("Cancelled.\n")
+		return false
+	}
+	return true
+}
+
+// shortSHA abbreviates a commit SHA the way git does
+func shortSHA(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
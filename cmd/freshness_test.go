@@ -0,0 +1,132 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("PR freshness", func() {
+	displayed := cmd.PullRequest{
+		Number:    1,
+		State:     "open",
+		UpdatedAt: "2026-01-01T00:00:00Z",
+		Head:      cmd.Branch{SHA: "abc1234def"},
+	}
+
+	It("should report no changes for an unchanged PR", func() {
+		changes, blocking := cmd.PRChangesTest(displayed, displayed, "hold")
+		Expect(changes).To(BeEmpty())
+		Expect(blocking).To(BeFalse())
+	})
+
+	It("should block actions on merged or closed PRs", func() {
+		merged := displayed
+		merged.State = "closed"
+		merged.Merged = true
+		changes, blocking := cmd.PRChangesTest(displayed, merged, "comment on")
+		Expect(changes).To(Equal([]string{"PR was merged since displayed"}))
+		Expect(blocking).To(BeTrue())
+
+		closed := displayed
+		closed.State = "closed"
+		changes, blocking = cmd.PRChangesTest(displayed, closed, "hold")
+		Expect(changes).To(Equal([]string{"PR was closed since displayed"}))
+		Expect(blocking).To(BeTrue())
+	})
+
+	It("should only block approvals when new commits were pushed", func() {
+		pushed := displayed
+		pushed.Head.SHA = "9999999fff"
+		pushed.UpdatedAt = "2026-01-01T01:00:00Z"
+
+		changes, blocking := cmd.PRChangesTest(displayed, pushed, "approve")
+		Expect(changes).To(Equal([]string{"PR changed since displayed (head was abc1234, now 9999999)"}))
+		Expect(blocking).To(BeTrue())
+
+		_, blocking = cmd.PRChangesTest(displayed, pushed, "hold")
+		Expect(blocking).To(BeFalse())
+	})
+
+	It("should warn about other updates without blocking", func() {
+		updated := displayed
+		updated.UpdatedAt = "2026-01-01T01:00:00Z"
+
+		changes, blocking := cmd.PRChangesTest(displayed, updated, "approve")
+		Expect(changes).To(Equal([]string{"PR was updated since displayed (at 2026-01-01T01:00:00Z)"}))
+		Expect(blocking).To(BeFalse())
+	})
+})
+
+var _ = Describe("PR revalidation", func() {
+	var (
+		server       *httptest.Server
+		client       *http.Client
+		ifNoneMatch  []string
+		currentTitle string
+	)
+
+	BeforeEach(func() {
+		ifNoneMatch = nil
+		currentTitle = "first"
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+			etag := `"` + currentTitle + `"`
+			if r.Header.Get("If-None-Match") == etag {
+				w.WriteHeader(http.StatusNotModified)
+				return
+			}
+			w.Header().Set("ETag", etag)
+			_, _ = fmt.Fprintf(w, `{"number": 1, "title": %q}`, currentTitle)
+		}))
+		client = &http.Client{Transport: cmd.NewRevalidatingTransportTest(http.DefaultTransport)}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	get := func(ctx context.Context) (int, string) {
+		request, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/repos/owner/repo/pulls/1", nil)
+		Expect(err).NotTo(HaveOccurred())
+		response, err := client.Do(request)
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = response.Body.Close() }()
+		body, err := io.ReadAll(response.Body)
+		Expect(err).NotTo(HaveOccurred())
+		return response.StatusCode, string(body)
+	}
+
+	It("should answer a fresh read of an unchanged PR from its last response", func() {
+		status, body := get(context.Background())
+		Expect(status).To(Equal(http.StatusOK))
+
+		status, revalidated := get(cmd.WithFreshDataTest(context.Background()))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(revalidated).To(Equal(body))
+		Expect(ifNoneMatch).To(Equal([]string{"", `"first"`}))
+	})
+
+	It("should return the new body when the PR changed", func() {
+		get(context.Background())
+		currentTitle = "second"
+
+		status, body := get(cmd.WithFreshDataTest(context.Background()))
+		Expect(status).To(Equal(http.StatusOK))
+		Expect(body).To(ContainSubstring(`"second"`))
+		Expect(ifNoneMatch).To(Equal([]string{"", `"first"`}))
+	})
+
+	It("should only make fresh reads conditional", func() {
+		get(context.Background())
+		get(context.Background())
+		Expect(ifNoneMatch).To(Equal([]string{"", ""}))
+	})
+})
@@ -0,0 +1,482 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// graphQLPageSize is kept well below 100 because every PR pulls nested reviews, files and checks,
+// and GitHub limits the total number of nodes a single query may request
+const graphQLPageSize = 25
+
+// pullRequestsQuery fetches PRs together with everything the table view needs in one round trip
+const pullRequestsQuery = `
+query($owner: String!, $repo: String!, $states: [PullRequestState!], $baseRefName: String, $first: Int!, $after: String) {
+  repository(owner: $owner, name: $repo) {
+    pullRequests(states: $states, baseRefName: $baseRefName, first: $first, after: $after, orderBy: {field: CREATED_AT, direction: DESC}) {
+      pageInfo { hasNextPage endCursor }
+      nodes {
+        number
+        title
+        state
+        isDraft
+        createdAt
+        updatedAt
+        url
+        body
+        mergeStateStatus
+        additions
+        deletions
+        changedFiles
+        author { __typename login }
+        headRefName
+        headRefOid
+        baseRefName
+        baseRefOid
+        labels(first: 100) { nodes { name } }
+        assignees(first: 100) { nodes { login } }
+        reviewRequests(first: 100) { nodes { requestedReviewer { __typename ... on User { login } ... on Bot { login } } } }
+        reviews(first: 100) { totalCount nodes { state author { __typename login } } }
+        files(first: 100) { totalCount nodes { path changeType } }
+        commits(last: 1) {
+          nodes {
+            commit {
+              statusCheckRollup {
+                contexts(first: 100) {
+                  totalCount
+                  nodes {
+                    __typename
+                    ... on CheckRun { name status conclusion detailsUrl startedAt checkSuite { databaseId } }
+                    ... on StatusContext { context state description targetUrl createdAt }
+                  }
+                }
+              }
+            }
+          }
+        }
+      }
+    }
+  }
+}`
+
+type gqlLogin struct {
+	Typename string `json:"__typename"`
+	Login    string `json:"login"`
+}
+
+// restLogin returns the login as the REST API reports it, where bot accounts carry a "[bot]" suffix
+func (l *gqlLogin) restLogin() string {
+	if l == nil {
+		return ""
+	}
+	if l.Typename == "Bot" && !strings.HasSuffix(l.Login, "[bot]") {
+		return l.Login + "[bot]"
+	}
+	return l.Login
+}
+
+type gqlCheckContext struct {
+	Typename    string     `json:"__typename"`
+	Name        string     `json:"name"`
+	Status      string     `json:"status"`
+	Conclusion  string     `json:"conclusion"`
+	DetailsURL  string     `json:"detailsUrl"`
+	Context     string     `json:"context"`
+	State       string     `json:"state"`
+	Description string     `json:"description"`
+	TargetURL   string     `json:"targetUrl"`
+	StartedAt   *time.Time `json:"startedAt"`
+	CheckSuite  *struct {
+		DatabaseID int64 `json:"databaseId"`
+	} `json:"checkSuite"`
+	CreatedAt *time.Time `json:"createdAt"`
+}
+
+type gqlPullRequest struct {
+	Number           int       `json:"number"`
+	Title            string    `json:"title"`
+	State            string    `json:"state"`
+	IsDraft          bool      `json:"isDraft"`
+	CreatedAt        string    `json:"createdAt"`
+	UpdatedAt        string    `json:"updatedAt"`
+	URL              string    `json:"url"`
+	Body             string    `json:"body"`
+	MergeStateStatus string    `json:"mergeStateStatus"`
+	Additions        int       `json:"additions"`
+	Deletions        int       `json:"deletions"`
+	ChangedFiles     int       `json:"changedFiles"`
+	Author           *gqlLogin `json:"author"`
+	HeadRefName      string    `json:"headRefName"`
+	HeadRefOid       string    `json:"headRefOid"`
+	BaseRefName      string    `json:"baseRefName"`
+	BaseRefOid       string    `json:"baseRefOid"`
+	Labels           struct {
+		Nodes []Label `json:"nodes"`
+	} `json:"labels"`
+	Assignees struct {
+		Nodes []User `json:"nodes"`
+	} `json:"assignees"`
+	ReviewRequests struct {
+		Nodes []struct {
+			RequestedReviewer *gqlLogin `json:"requestedReviewer"`
+		} `json:"nodes"`
+	} `json:"reviewRequests"`
+	Reviews struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			State  string    `json:"state"`
+			Author *gqlLogin `json:"author"`
+		} `json:"nodes"`
+	} `json:"reviews"`
+	Files struct {
+		TotalCount int `json:"totalCount"`
+		Nodes      []struct {
+			Path       string `json:"path"`
+			ChangeType string `json:"changeType"`
+		} `json:"nodes"`
+	} `json:"files"`
+	Commits struct {
+		Nodes []struct {
+			Commit struct {
+				StatusCheckRollup *struct {
+					Contexts struct {
+						TotalCount int               `json:"totalCount"`
+						Nodes      []gqlCheckContext `json:"nodes"`
+					} `json:"contexts"`
+				} `json:"statusCheckRollup"`
+			} `json:"commit"`
+		} `json:"nodes"`
+	} `json:"commits"`
+}
+
+type gqlPullRequestsResponse struct {
+	Repository *struct {
+		PullRequests struct {
+			PageInfo struct {
+				HasNextPage bool   `json:"hasNextPage"`
+				EndCursor   string `json:"endCursor"`
+			} `json:"pageInfo"`
+			Nodes []gqlPullRequest `json:"nodes"`
+		} `json:"pullRequests"`
+	} `json:"repository"`
+}
+
+// graphQLStates maps the REST state filter onto GraphQL PullRequestState values
+func graphQLStates(state string) []string {
+	switch state {
+	case "closed":
+		return []string{"CLOSED", "MERGED"}
+	case "all":
+		return []string{"OPEN", "CLOSED", "MERGED"}
+	default:
+		return []string{"OPEN"}
+	}
+}
+
+// fetchPullRequestsGraphQL fetches up to maxPRs pull requests kept by filter with a single paginated GraphQL query.
+// A maxPRs of 0 fetches every matching PR.
+// Alongside the PRs it returns a client that answers the per-PR REST calls made by the table view
+// (PR details, reviews, files and checks) from the GraphQL data, falling back to restClient for anything else.
+// The queries stop when ctx is done.
+func fetchPullRequestsGraphQL(ctx context.Context, gqlClient GraphQLClientInterface, restClient RESTClientInterface, owner, repo, state, baseRef string, maxPRs int, filter prFilter) ([]PullRequest, RESTClientInterface, error) {
+	prefetched := newPrefetchedRESTClient(restClient)
+	var pullRequests []PullRequest
+
+	variables := map[string]interface{}{
+		"owner":  owner,
+		"repo":   repo,
+		"states": graphQLStates(state),
+	}
+	if baseRef != "" {
+		variables["baseRefName"] = baseRef
+	}
+
+	progress := newPageProgress(owner, repo)
+	defer progress.done()
+
+	var cursor string
+	fetched := 0
+	for page := 1; ; page++ {
+		progress.next(page, fetched, len(pullRequests))
+		pageSize := graphQLPageSize
+		if filter == nil && maxPRs > 0 && maxPRs-len(pullRequests) < pageSize {
+			pageSize = maxPRs - len(pullRequests)
+		}
+		variables["first"] = pageSize
+		if cursor != "" {
+			variables["after"] = cursor
+		}
+
+		var response gqlPullRequestsResponse
+		if err := gqlClient.DoWithContext(ctx, pullRequestsQuery, variables, &response); err != nil {
+			return nil, nil, err
+		}
+		if response.Repository == nil {
+			return nil, nil, fmt.Errorf("repository %s/%s not found", owner, repo)
+		}
+
+		connection := response.Repository.PullRequests
+		fetched += len(connection.Nodes)
+		var pagePRs []PullRequest
+		for _, node := range connection.Nodes {
+			pr := node.toPullRequest()
+			prefetched.addPullRequest(owner, repo, node, pr)
+			pagePRs = append(pagePRs, pr)
+		}
+
+		// Filter with the prefetched client so per-PR checks are answered from the query results
+		if filter != nil {
+			pagePRs = filter(prefetched, pagePRs)
+		}
+		for _, pr := range pagePRs {
+			pullRequests = append(pullRequests, pr)
+			if maxPRs > 0 && len(pullRequests) >= maxPRs {
+				return pullRequests, prefetched, nil
+			}
+		}
+
+		if !connection.PageInfo.HasNextPage {
+			return pullRequests, prefetched, nil
+		}
+		cursor = connection.PageInfo.EndCursor
+	}
+}
+
+// toPullRequest converts a GraphQL PR node into the REST representation used everywhere else
+func (n gqlPullRequest) toPullRequest() PullRequest {
+	state := strings.ToLower(n.State)
+	if state == "merged" {
+		// The REST API reports merged PRs as closed
+		state = "closed"
+	}
+
+	pr := PullRequest{
+		Number:         n.Number,
+		Title:          n.Title,
+		State:          state,
+		Head:           Branch{Ref: n.HeadRefName, SHA: n.HeadRefOid},
+		Base:           Branch{Ref: n.BaseRefName, SHA: n.BaseRefOid},
+		Draft:          n.IsDraft,
+		CreatedAt:      n.CreatedAt,
+		UpdatedAt:      n.UpdatedAt,
+		HTMLURL:        n.URL,
+		Body:           n.Body,
+		MergeableState: strings.ToLower(n.MergeStateStatus),
+		Additions:      n.Additions,
+		Deletions:      n.Deletions,
+		ChangedFiles:   n.ChangedFiles,
+		Labels:         n.Labels.Nodes,
+		Assignees:      n.Assignees.Nodes,
+	}
+	pr.User = User{Login: n.Author.restLogin()}
+	for _, request := range n.ReviewRequests.Nodes {
+		// Requests of teams have no login
+		if login := request.RequestedReviewer.restLogin(); login != "" {
+			pr.RequestedReviewers = append(pr.RequestedReviewers, User{Login: login})
+		}
+	}
+	return pr
+}
+
+// gqlFileStatus maps GraphQL PatchStatus values onto REST file statuses
+func gqlFileStatus(changeType string) string {
+	switch changeType {
+	case "ADDED":
+		return "added"
+	case "DELETED":
+		return "removed"
+	case "RENAMED":
+		return "renamed"
+	case "COPIED":
+		return "copied"
+	case "CHANGED":
+		return "changed"
+	default:
+		return "modified"
+	}
+}
+
+// prefetchedRESTClient serves GET requests from data gathered by the GraphQL query
+// and forwards everything else to the wrapped REST client
+type prefetchedRESTClient struct {
+	RESTClientInterface
+	mutex     sync.RWMutex
+	responses map[string][]byte
+}
+
+func newPrefetchedRESTClient(client RESTClientInterface) *prefetchedRESTClient {
+	return &prefetchedRESTClient{
+		RESTClientInterface: client,
+		responses:           make(map[string][]byte),
+	}
+}
+
+// store records a prefetched response for a REST path
+func (c *prefetchedRESTClient) store(path string, body interface{}) {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return
+	}
+	c.mutex.Lock()
+	c.responses[path] = data
+	c.mutex.Unlock()
+}
+
+// addPullRequest records the REST responses that can be derived from a GraphQL PR node.
+// Collections that were truncated by the query are left out so they are fetched in full over REST.
+func (c *prefetchedRESTClient) addPullRequest(owner, repo string, node gqlPullRequest, pr PullRequest) {
+	prPath := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pr.Number)
+
+	// mergeStateStatus is computed lazily by GitHub, so let REST retry when it isn't known yet
+	if pr.MergeableState != "" && pr.MergeableState != "unknown" {
+		c.store(prPath, pr)
+	}
+
+	if node.Reviews.TotalCount <= len(node.Reviews.Nodes) {
+		reviews := make([]Review, 0, len(node.Reviews.Nodes))
+		for _, r := range node.Reviews.Nodes {
+			reviews = append(reviews, Review{State: r.State, User: User{Login: r.Author.restLogin()}})
+		}
+		c.store(prPath+"/reviews", reviews)
+	}
+
+	if node.Files.TotalCount <= len(node.Files.Nodes) {
+		files := make([]PRFile, 0, len(node.Files.Nodes))
+		for _, f := range node.Files.Nodes {
+			files = append(files, PRFile{Filename: f.Path, Status: gqlFileStatus(f.ChangeType)})
+		}
+		c.store(prPath+"/files", files)
+	}
+
+	if pr.Head.SHA == "" || len(node.Commits.Nodes) == 0 {
+		return
+	}
+	rollup := node.Commits.Nodes[0].Commit.StatusCheckRollup
+	checkRuns := CheckRunsResponse{CheckRuns: []CheckRun{}}
+	statuses := []StatusCheck{}
+	if rollup != nil {
+		if rollup.Contexts.TotalCount > len(rollup.Contexts.Nodes) {
+			return
+		}
+		for _, ctx := range rollup.Contexts.Nodes {
+			switch ctx.Typename {
+			case "CheckRun":
+				checkRun := CheckRun{
+					Name:       ctx.Name,
+					Status:     strings.ToLower(ctx.Status),
+					Conclusion: strings.ToLower(ctx.Conclusion),
+					HTMLURL:    ctx.DetailsURL,
+					StartedAt:  ctx.StartedAt,
+				}
+				if ctx.CheckSuite != nil {
+					checkRun.CheckSuite = &CheckSuiteRef{ID: ctx.CheckSuite.DatabaseID}
+				}
+				checkRuns.CheckRuns = append(checkRuns.CheckRuns, checkRun)
+			case "StatusContext":
+				statuses = append(statuses, StatusCheck{
+					State:       strings.ToLower(ctx.State),
+					Description: ctx.Description,
+					Context:     ctx.Context,
+					TargetURL:   ctx.TargetURL,
+					CreatedAt:   ctx.CreatedAt,
+				})
+			}
+		}
+	}
+	checkRuns.TotalCount = len(checkRuns.CheckRuns)
+
+	commitPath := fmt.Sprintf("repos/%s/%s/commits/%s", owner, repo, pr.Head.SHA)
+	c.store(commitPath+"/check-runs", checkRuns)
+	c.store(commitPath+"/status", struct {
+		Statuses []StatusCheck `json:"statuses"`
+	}{Statuses: statuses})
+}
+
+// invalidate drops all prefetched data, used once anything is modified
+func (c *prefetchedRESTClient) invalidate() {
+	c.mutex.Lock()
+	c.responses = make(map[string][]byte)
+	c.mutex.Unlock()
+}
+
+// lookup returns a prefetched response body for a GET request path
+func (c *prefetchedRESTClient) lookup(method, path string) ([]byte, bool) {
+	if method != http.MethodGet {
+		return nil, false
+	}
+	c.mutex.RLock()
+	defer c.mutex.RUnlock()
+	data, ok := c.responses[path]
+	return data, ok
+}
+
+// Get serves prefetched responses before falling back to REST
+func (c *prefetchedRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post forwards to REST and invalidates prefetched data
+func (c *prefetchedRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put forwards to REST and invalidates prefetched data
+func (c *prefetchedRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch forwards to REST and invalidates prefetched data
+func (c *prefetchedRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete forwards to REST and invalidates prefetched data
+func (c *prefetchedRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do serves prefetched GET responses and forwards everything else to REST
+func (c *prefetchedRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext serves prefetched GET responses and forwards everything else to REST
+func (c *prefetchedRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	if data, ok := c.lookup(method, path); ok && !wantsFreshData(ctx) {
+		if response == nil {
+			return nil
+		}
+		return json.Unmarshal(data, response)
+	}
+	if method != http.MethodGet {
+		c.invalidate()
+	}
+	return c.RESTClientInterface.DoWithContext(ctx, method, path, body, response)
+}
+
+// Request serves prefetched GET responses and forwards everything else to REST
+func (c *prefetchedRESTClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+// RequestWithContext serves prefetched GET responses and forwards everything else to REST
+func (c *prefetchedRESTClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	if data, ok := c.lookup(method, path); ok {
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader(data)),
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+		}, nil
+	}
+	if method != http.MethodGet {
+		c.invalidate()
+	}
+	return c.RESTClientInterface.RequestWithContext(ctx, method, path, body)
+}
@@ -0,0 +1,177 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// gqlPRNode builds a pullRequests node in the shape returned by the GraphQL API
+func gqlPRNode(number int, mergeState string) map[string]interface{} {
+	return map[string]interface{}{
+		"number":           number,
+		"title":            fmt.Sprintf("PR %d", number),
+		"state":            "OPEN",
+		"isDraft":          false,
+		"createdAt":        "2024-01-01T00:00:00Z",
+		"updatedAt":        "2024-01-02T00:00:00Z",
+		"url":              fmt.Sprintf("https://github.com/owner/repo/pull/%d", number),
+		"body":             "body",
+		"mergeStateStatus": mergeState,
+		"author":           map[string]interface{}{"__typename": "Bot", "login": "red-hat-konflux"},
+		"headRefName":      "feature",
+		"headRefOid":       fmt.Sprintf("sha%d", number),
+		"baseRefName":      "main",
+		"baseRefOid":       "base",
+		"labels":           map[string]interface{}{"nodes": []map[string]interface{}{{"name": "lgtm"}}},
+		"assignees":        map[string]interface{}{"nodes": []map[string]interface{}{{"login": "assignee"}}},
+		"reviewRequests": map[string]interface{}{"nodes": []map[string]interface{}{
+			{"requestedReviewer": map[string]interface{}{"__typename": "User", "login": "requested"}},
+			{"requestedReviewer": map[string]interface{}{"__typename": "Team"}},
+		}},
+		"reviews": map[string]interface{}{
+			"totalCount": 1,
+			"nodes":      []map[string]interface{}{{"state": "APPROVED", "author": map[string]interface{}{"login": "reviewer"}}},
+		},
+		"files": map[string]interface{}{
+			"totalCount": 1,
+			"nodes":      []map[string]interface{}{{"path": ".tekton/build-push.yaml", "changeType": "MODIFIED"}},
+		},
+		"commits": map[string]interface{}{
+			"nodes": []map[string]interface{}{{
+				"commit": map[string]interface{}{
+					"statusCheckRollup": map[string]interface{}{
+						"contexts": map[string]interface{}{
+							"totalCount": 2,
+							"nodes": []map[string]interface{}{
+								{"__typename": "CheckRun", "name": "build", "status": "COMPLETED", "conclusion": "SUCCESS"},
+								{"__typename": "StatusContext", "context": "ci/prow", "state": "PENDING"},
+							},
+						},
+					},
+				},
+			}},
+		},
+	}
+}
+
+func gqlPage(hasNext bool, cursor string, nodes ...map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"repository": map[string]interface{}{
+			"pullRequests": map[string]interface{}{
+				"pageInfo": map[string]interface{}{"hasNextPage": hasNext, "endCursor": cursor},
+				"nodes":    nodes,
+			},
+		},
+	}
+}
+
+var _ = Describe("GraphQL PR Fetching", func() {
+	var gqlClient *cmd.MockGraphQLClient
+	var restClient *cmd.MockRESTClient
+
+	BeforeEach(func() {
+		gqlClient = cmd.NewMockGraphQLClient()
+		restClient = cmd.NewMockRESTClient()
+	})
+
+	It("should follow pagination cursors and convert nodes to PRs", func() {
+		gqlClient.AddResponse(gqlPage(true, "cursor1", gqlPRNode(1, "CLEAN")))
+		gqlClient.AddResponse(gqlPage(false, "", gqlPRNode(2, "BEHIND")))
+
+		prs, _, err := cmd.FetchPullRequestsGraphQLTest(gqlClient, restClient, "owner", "repo", "open", "", 0)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(2))
+		Expect(gqlClient.Requests).To(HaveLen(2))
+		Expect(gqlClient.Requests[1].Variables["after"]).To(Equal("cursor1"))
+
+		Expect(prs[0].State).To(Equal("open"))
+		Expect(prs[0].User.Login).To(Equal("red-hat-konflux[bot]"))
+		Expect(prs[0].Head.SHA).To(Equal("sha1"))
+		Expect(prs[1].MergeableState).To(Equal("behind"))
+		Expect(prs[0].Labels).To(ContainElement(cmd.Label{Name: "lgtm"}))
+		Expect(prs[0].Assignees).To(Equal([]cmd.User{{Login: "assignee"}}))
+		Expect(prs[0].RequestedReviewers).To(Equal([]cmd.User{{Login: "requested"}}))
+	})
+
+	It("should stop once the limit is reached", func() {
+		gqlClient.AddResponse(gqlPage(true, "cursor1", gqlPRNode(1, "CLEAN")))
+
+		prs, _, err := cmd.FetchPullRequestsGraphQLTest(gqlClient, restClient, "owner", "repo", "open", "", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(1))
+		Expect(gqlClient.Requests).To(HaveLen(1))
+		Expect(gqlClient.Requests[0].Variables["first"]).To(Equal(1))
+	})
+
+	It("should stop querying once the context is done", func() {
+		gqlClient.AddResponse(gqlPage(false, "", gqlPRNode(1, "CLEAN")))
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, _, err := cmd.FetchPullRequestsGraphQLWithContextTest(ctx, gqlClient, restClient, "owner", "repo", "open", "", 0)
+		Expect(err).To(MatchError(context.Canceled))
+		Expect(gqlClient.Requests).To(BeEmpty())
+	})
+
+	It("should map the REST state filter onto GraphQL states", func() {
+		gqlClient.AddResponse(gqlPage(false, ""))
+
+		_, _, err := cmd.FetchPullRequestsGraphQLTest(gqlClient, restClient, "owner", "repo", "closed", "main", 10)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gqlClient.Requests[0].Variables["states"]).To(Equal([]string{"CLOSED", "MERGED"}))
+		Expect(gqlClient.Requests[0].Variables["baseRefName"]).To(Equal("main"))
+	})
+
+	It("should return GraphQL errors so callers can fall back to REST", func() {
+		gqlClient.AddErrorResponse(fmt.Errorf("something went wrong"))
+
+		_, _, err := cmd.FetchPullRequestsGraphQLTest(gqlClient, restClient, "owner", "repo", "open", "", 10)
+		Expect(err).To(HaveOccurred())
+	})
+
+	Describe("Prefetched REST client", func() {
+		var client cmd.RESTClientInterface
+
+		BeforeEach(func() {
+			gqlClient.AddResponse(gqlPage(false, "", gqlPRNode(1, "BLOCKED")))
+			var err error
+			_, client, err = cmd.FetchPullRequestsGraphQLTest(gqlClient, restClient, "owner", "repo", "open", "", 10)
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("should serve per-PR data without REST calls", func() {
+			Expect(cmd.IsReviewedTest(client, "owner", "repo", 1, nil)).To(BeTrue())
+
+			onlyTekton, files, err := cmd.CheckTektonFilesDetailedTest(client, "owner", "repo", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(onlyTekton).To(BeTrue())
+			Expect(files).To(ConsistOf(".tekton/build-push.yaml"))
+
+			var checkRuns cmd.CheckRunsResponse
+			Expect(client.Get("repos/owner/repo/commits/sha1/check-runs", &checkRuns)).To(Succeed())
+			Expect(checkRuns.CheckRuns).To(HaveLen(1))
+			Expect(checkRuns.CheckRuns[0].Conclusion).To(Equal("success"))
+
+			pr, err := cmd.FetchPRDetailsTest(client, "owner", "repo", 1)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(cmd.IsBlockedTest(*pr)).To(BeTrue())
+
+			Expect(restClient.Requests).To(BeEmpty())
+		})
+
+		It("should fall back to REST and drop prefetched data after a mutation", func() {
+			restClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, cmd.CreateMockReviews(false))
+			restClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+
+			Expect(client.Post("repos/owner/repo/issues/1/comments", bytes.NewReader([]byte(`{}`)), nil)).To(Succeed())
+			Expect(cmd.IsReviewedTest(client, "owner", "repo", 1, nil)).To(BeFalse())
+			Expect(restClient.GetRequestCount("pulls/1/reviews")).To(Equal(1))
+		})
+	})
+})
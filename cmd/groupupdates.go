@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
+// updateGroup is one dependency update, the same packages bumped to the same versions, opened as a PR in
+// several repositories. The first PR is the representative whose diff is reviewed for all of them.
+type updateGroup struct {
+	Updates []DependencyUpdate
+	PRs     []repoPR
+}
+
+// updateKey identifies the update a PR makes by the packages it bumps and the versions it bumps them to,
+// ignoring the versions it bumps them from, which differ between repositories that fell behind. PRs that
+// update no dependency have no key.
+func updateKey(updates []DependencyUpdate) string {
+	parts := make([]string, len(updates))
+	for i, update := range updates {
+		parts[i] = strings.ToLower(update.Package) + "@" + update.To
+	}
+	slices.Sort(parts)
+	return strings.Join(parts, ",")
+}
+
+// groupDependencyUpdates clusters the PRs by the update they make, keeping the updates open in more than one
+// repository, in the order they are first found
+func groupDependencyUpdates(prsByRepo [][]repoPR) []updateGroup {
+	var groups []updateGroup
+	byKey := make(map[string]int)
+	for _, prs := range prsByRepo {
+		for _, pr := range prs {
+			updates := ghprs.ParseDependencyUpdates(pr.PR.Title, pr.PR.Body)
+			key := updateKey(updates)
+			if key == "" {
+				continue
+			}
+			i, ok := byKey[key]
+			if !ok {
+				i = len(groups)
+				byKey[key] = i
+				groups = append(groups, updateGroup{Updates: updates})
+			}
+			groups[i].PRs = append(groups[i].PRs, pr)
+		}
+	}
+
+	var shared []updateGroup
+	for _, group := range groups {
+		if group.repositoryCount() > 1 {
+			shared = append(shared, group)
+		}
+	}
+	return shared
+}
+
+// repositoryCount returns how many repositories the update is open in
+func (g updateGroup) repositoryCount() int {
+	var repos []string
+	for _, pr := range g.PRs {
+		if repoSpec := pr.Owner + "/" + pr.Repo; !slices.Contains(repos, repoSpec) {
+			repos = append(repos, repoSpec)
+		}
+	}
+	return len(repos)
+}
+
+// describe names the update: its package and the version it bumps to, or its first packages and how many more
+func (g updateGroup) describe() string {
+	if len(g.Updates) == 1 {
+		return fmt.Sprintf("%s → %s", g.Updates[0].Package, g.Updates[0].To)
+	}
+	return fmt.Sprintf("%s → %s +%d", g.Updates[0].Package, g.Updates[0].To, len(g.Updates)-1)
+}
+
+// displayUpdateGroups shows one line per update with its type and the repositories and PRs it is open in
+func displayUpdateGroups(groups []updateGroup) {
+	streams.Printf("\n📦 Updates open in several repositories (%d):\n", len(groups))
+	table := render.NewTable(
+		render.Column{Header: "#", Width: len(strconv.Itoa(len(groups)))},
+		render.Column{Header: "UPDATE", Width: 50, Truncate: true},
+		render.Column{Header: "SEMVER", Width: 6},
+		render.Column{Header: "REPOS", Width: 5},
+		render.Column{Header: "PRS", Width: 3},
+	)
+	for i, group := range groups {
+		table.AddRow(strconv.Itoa(i+1), group.describe(), ghprs.HighestUpdateType(group.Updates),
+			strconv.Itoa(group.repositoryCount()), strconv.Itoa(len(group.PRs)))
+	}
+	table.Write(streams.Out)
+}
+
+// selectUpdateGroup asks which update to review, returning its index or -1 to stop
+func selectUpdateGroup(groups []updateGroup) int {
+	for {
+		input, err := prompter.Input(fmt.Sprintf("\nSelect the update to review (1-%d, 0 to quit): ", len(groups)))
+		if err != nil {
+			if err == io.EOF {
+				streams.Printf("\n")
+			}
+			return -1
+		}
+		choice, err := strconv.Atoi(strings.TrimSpace(input))
+		if err != nil || choice < 0 || choice > len(groups) {
+			streams.Printf("Invalid choice '%s'. Please select a number between 0 and %d.\n", input, len(groups))
+			continue
+		}
+		return choice - 1
+	}
+}
+
+// normalizedDiff returns what a PR's files change, one entry per file, sorted: the status of the file and the
+// lines its patch deletes and adds, trimmed and in order, which is the same for the same update in every
+// repository whatever the files are called there. Files without a patch, binary or too large for GitHub to
+// diff, can't be compared and make it fail.
+func normalizedDiff(files []PRFile) ([]string, error) {
+	changes := make([]string, 0, len(files))
+	for _, file := range files {
+		if file.Patch == "" {
+			return nil, fmt.Errorf("%s has no diff to compare (binary or too large)", file.Filename)
+		}
+		lines := []string{file.Status}
+		for _, line := range strings.Split(file.Patch, "\n") {
+			if strings.HasPrefix(line, "+") || strings.HasPrefix(line, "-") {
+				lines = append(lines, line[:1]+strings.TrimSpace(line[1:]))
+			}
+		}
+		changes = append(changes, strings.Join(lines, "\n"))
+	}
+	slices.Sort(changes)
+	return changes, nil
+}
+
+// filesDiff renders the files of a PR as a unified diff
+func filesDiff(files []PRFile) string {
+	var b strings.Builder
+	for _, file := range files {
+		fmt.Fprintf(&b, "diff --git a/%s b/%s\n--- a/%s\n+++ b/%s\n%s\n", file.Filename, file.Filename, file.Filename, file.Filename, file.Patch)
+	}
+	return b.String()
+}
+
+// planUpdateApprovals plans the approvals of an update's PRs, one plan per repository. PRs that can't be
+// approved unseen are skipped: those not open, drafts, on hold, with a migration warning, changing CI or
+// ownership files by an untrusted author, larger than the max_changes of their repository, and those whose
+// normalized diff isn't the representative's, which is given, or can't be compared.
+func planUpdateApprovals(ctx context.Context, opts *listOptions, group updateGroup, reviewed []string, config *Config) []*batchPlan {
+	var plans []*batchPlan
+	byRepo := make(map[string]*batchPlan)
+	representative := group.PRs[0]
+	for i, member := range group.PRs {
+		repoSpec := member.Owner + "/" + member.Repo
+		plan, ok := byRepo[repoSpec]
+		if !ok {
+			plan = &batchPlan{owner: member.Owner, repo: member.Repo}
+			byRepo[repoSpec] = plan
+			plans = append(plans, plan)
+		}
+
+		switch {
+		case member.PR.State != "open":
+			plan.add(member.PR, PlanActionSkip, "not open")
+		case member.PR.Draft:
+			plan.add(member.PR, PlanActionSkip, "draft")
+		case isOnHold(member.PR):
+			plan.add(member.PR, PlanActionSkip, "on hold")
+		case hasMigrationWarning(member.PR):
+			plan.add(member.PR, PlanActionSkip, "migration warning, approve it on its own")
+		default:
+			if reason := batchApprovalBlocker(member, config.TrustedAuthors(), newApprovalConfig(opts, config, repoSpec, true).Review.MaxChanges); reason != "" {
+				plan.add(member.PR, PlanActionSkip, reason)
+				continue
+			}
+			if i == 0 {
+				plan.add(member.PR, PlanActionApprove, "diff reviewed")
+				continue
+			}
+			files, err := ghprs.FetchFiles(ctx, member.Client, member.Owner, member.Repo, member.PR.Number)
+			if err != nil {
+				plan.add(member.PR, PlanActionSkip, fmt.Sprintf("could not check changed files: %v", err))
+				continue
+			}
+			diff, err := normalizedDiff(files)
+			if err != nil {
+				plan.add(member.PR, PlanActionSkip, fmt.Sprintf("can't compare with the reviewed diff: %v", err))
+				continue
+			}
+			if !slices.Equal(diff, reviewed) {
+				plan.add(member.PR, PlanActionSkip, "differs from the reviewed diff")
+				continue
+			}
+			plan.add(member.PR, PlanActionApprove, fmt.Sprintf("same as %s/%s#%d", representative.Owner, representative.Repo, representative.PR.Number))
+		}
+	}
+	return plans
+}
+
+// reviewUpdateGroup shows the PRs of an update and the diff of its representative, then approves the PRs of
+// each repository once their plan is confirmed
+func reviewUpdateGroup(ctx context.Context, opts *listOptions, group updateGroup, config *Config) {
+	representative := group.PRs[0]
+	streams.Printf("\n📦 %s (%s) in %d repositories:\n", group.describe(), ghprs.HighestUpdateType(group.Updates), group.repositoryCount())
+	for _, member := range group.PRs {
+		streams.Printf("   %s/%s %s: %s\n", member.Owner, member.Repo, formatPRLink(member.Owner, member.Repo, member.PR.Number), member.PR.Title)
+	}
+
+	files, err := ghprs.FetchFiles(ctx, representative.Client, representative.Owner, representative.Repo, representative.PR.Number)
+	if err != nil {
+		streams.Printf("❌ Could not fetch the diff of %s/%s#%d, not approving the update: %v\n", representative.Owner, representative.Repo, representative.PR.Number, err)
+		return
+	}
+	streams.Printf("\n📄 Diff of %s/%s %s, the same update is approved in the other repositories:\n",
+		representative.Owner, representative.Repo, formatPRLink(representative.Owner, representative.Repo, representative.PR.Number))
+	showDiffText(filesDiff(files), newApprovalConfig(opts, config, "", true).Diff.Mode)
+	reviewed, err := normalizedDiff(files)
+	if err != nil {
+		streams.Printf("❌ Not approving the update, the other repositories can't be compared with %s/%s#%d: %v\n", representative.Owner, representative.Repo, representative.PR.Number, err)
+		return
+	}
+
+	for _, plan := range planUpdateApprovals(ctx, opts, group, reviewed, config) {
+		if !confirmPlan(plan, false) {
+			continue
+		}
+		settings := newApprovalConfig(opts, config, plan.owner+"/"+plan.repo, true).Review
+		executePlan(plan, func(action PlannedAction) error {
+			index := slices.IndexFunc(group.PRs, func(member repoPR) bool {
+				return member.Owner == plan.owner && member.Repo == plan.repo && member.PR.Number == action.Number
+			})
+			member := group.PRs[index]
+			return postApproval(member.Client, member.Owner, member.Repo, member.PR, settings)
+		})
+	}
+}
+
+// runGroupedUpdates clusters the open Konflux PRs of the repositories by update and lets each update be
+// approved everywhere at once, until every update was reviewed or the user quits
+func runGroupedUpdates(ctx context.Context, opts *listOptions, config *Config, args []string) {
+	repositories := args
+	if len(repositories) == 0 {
+		repositories = config.GetRepositories(true)
+	}
+	if len(repositories) < 2 {
+		log.Fatal("--group-updates needs several repositories, configure them with 'ghprs config add-konflux-repo owner/repo' or give them as arguments")
+	}
+
+	// Every open Konflux PR counts, whatever the list defaults, and which files they change is checked later
+	groups := groupDependencyUpdates(fetchKonfluxPRs(ctx, everyOpenPR(opts), config, repositories))
+	for len(groups) > 0 {
+		displayUpdateGroups(groups)
+		choice := selectUpdateGroup(groups)
+		if choice < 0 {
+			return
+		}
+		reviewUpdateGroup(ctx, opts, groups[choice], config)
+		groups = slices.Delete(groups, choice, choice+1)
+	}
+	streams.Println("\nNo dependency update is open in more than one repository.")
+}
@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Grouped dependency updates", func() {
+	bump := func(number int, from, to string) cmd.PullRequest {
+		return cmd.PullRequest{
+			Number: number, State: "open", Title: "chore(deps): update module golang.org/x/net to v" + to,
+			User: cmd.User{Login: "red-hat-konflux[bot]"},
+			Body: "| Package | Change |\n|---|---|\n| golang.org/x/net | `v" + from + "` -> `v" + to + "` |\n",
+		}
+	}
+	patch := func(to string) []cmd.PRFile {
+		return []cmd.PRFile{{Filename: "go.mod", Patch: "@@ -3 +3 @@\n-\tgolang.org/x/net v0.20.0\n+\tgolang.org/x/net v" + to}}
+	}
+
+	It("clusters the same update across repositories, whatever version it bumps from", func() {
+		groups := cmd.GroupDependencyUpdatesTest([]cmd.CanaryPRTest{
+			{RepoSpec: "owner/app1", PR: bump(1, "0.20.0", "0.21.0")},
+			{RepoSpec: "owner/app2", PR: bump(4, "0.19.0", "0.21.0")},
+			{RepoSpec: "owner/app2", PR: bump(5, "0.19.0", "0.22.0")},
+			{RepoSpec: "owner/app3", PR: cmd.PullRequest{Number: 9, State: "open", Title: "Fix the docs"}},
+		})
+
+		Expect(groups).To(Equal(map[string][]string{
+			"golang.org/x/net → 0.21.0": {"owner/app1#1", "owner/app2#4"},
+		}))
+	})
+
+	Describe("Approving an update", func() {
+		var app1, app2, app3 *cmd.MockRESTClient
+		var in, out *bytes.Buffer
+
+		BeforeEach(func() {
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+
+			app1, app2, app3 = cmd.NewMockRESTClient(), cmd.NewMockRESTClient(), cmd.NewMockRESTClient()
+			for _, client := range []*cmd.MockRESTClient{app1, app2, app3} {
+				client.AddResponse("/reviews", 200, nil)
+			}
+			app1.AddResponse("repos/owner/app1/pulls/1/files", 200, patch("0.21.0"))
+			app2.AddResponse("repos/owner/app2/pulls/4/files", 200, patch("0.21.0"))
+			app3.AddResponse("repos/owner/app3/pulls/7/files", 200, append(patch("0.21.0"), cmd.PRFile{Filename: "main.go", Patch: "+\tpanic(1)"}))
+			app3.AddResponse("repos/owner/app3/pulls/8/files", 200, append(patch("0.21.0"), cmd.PRFile{Filename: "auth.go", Patch: "@@ -9 +8 @@\n-\tcheckToken()"}))
+			app3.AddResponse("repos/owner/app3/pulls/9/files", 200, append(patch("0.21.0"), cmd.PRFile{Filename: "tool", Status: "modified"}))
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("shows the representative diff and approves the PRs making the same changes", func() {
+			in.WriteString("y\ny\ny\n")
+			Expect(cmd.ReviewUpdateGroupTest([]cmd.CanaryPRTest{
+				{RepoSpec: "owner/app1", Client: app1, PR: bump(1, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app2", Client: app2, PR: bump(4, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app3", Client: app3, PR: bump(7, "0.20.0", "0.21.0")},
+			})).To(BeTrue())
+
+			Expect(out.String()).To(ContainSubstring("golang.org/x/net → 0.21.0 (minor) in 3 repositories"))
+			Expect(out.String()).To(ContainSubstring("+\tgolang.org/x/net v0.21.0"))
+			Expect(out.String()).To(ContainSubstring("same as owner/app1#1"))
+			Expect(out.String()).To(ContainSubstring("differs from the reviewed diff"))
+			Expect(app1.GetRequestCount("repos/owner/app1/pulls/1/reviews")).To(Equal(1))
+			Expect(app2.GetRequestCount("repos/owner/app2/pulls/4/reviews")).To(Equal(1))
+			Expect(app3.GetRequestCount("repos/owner/app3/pulls/7/reviews")).To(Equal(0))
+		})
+
+		It("doesn't approve PRs that also delete lines or change files without a diff", func() {
+			in.WriteString("y\ny\ny\n")
+			Expect(cmd.ReviewUpdateGroupTest([]cmd.CanaryPRTest{
+				{RepoSpec: "owner/app1", Client: app1, PR: bump(1, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app3", Client: app3, PR: bump(8, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app3", Client: app3, PR: bump(9, "0.20.0", "0.21.0")},
+			})).To(BeTrue())
+
+			Expect(out.String()).To(ContainSubstring("differs from the reviewed diff"))
+			Expect(out.String()).To(ContainSubstring("tool has no diff to compare (binary or too large)"))
+			Expect(app3.GetRequestCount("repos/owner/app3/pulls/8/reviews")).To(Equal(0))
+			Expect(app3.GetRequestCount("repos/owner/app3/pulls/9/reviews")).To(Equal(0))
+		})
+
+		It("approves nothing when the reviewed diff has a file without a diff", func() {
+			app1.AddResponse("repos/owner/app1/pulls/2/files", 200, []cmd.PRFile{{Filename: "logo.png", Status: "added"}})
+			Expect(cmd.ReviewUpdateGroupTest([]cmd.CanaryPRTest{
+				{RepoSpec: "owner/app1", Client: app1, PR: bump(2, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app2", Client: app2, PR: bump(4, "0.20.0", "0.21.0")},
+			})).To(BeTrue())
+
+			Expect(out.String()).To(ContainSubstring("Not approving the update"))
+			Expect(app1.GetRequestCount("repos/owner/app1/pulls/2/reviews")).To(Equal(0))
+			Expect(app2.GetRequestCount("repos/owner/app2/pulls/4/reviews")).To(Equal(0))
+		})
+
+		It("approves nothing in a repository whose plan isn't confirmed", func() {
+			in.WriteString("y\nn\n")
+			Expect(cmd.ReviewUpdateGroupTest([]cmd.CanaryPRTest{
+				{RepoSpec: "owner/app1", Client: app1, PR: bump(1, "0.20.0", "0.21.0")},
+				{RepoSpec: "owner/app2", Client: app2, PR: bump(4, "0.20.0", "0.21.0")},
+			})).To(BeTrue())
+
+			Expect(app1.GetRequestCount("repos/owner/app1/pulls/1/reviews")).To(Equal(1))
+			Expect(app2.GetRequestCount("repos/owner/app2/pulls/4/reviews")).To(Equal(0))
+		})
+	})
+})
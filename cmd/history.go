@@ -0,0 +1,268 @@
+package cmd
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+// Actions recorded in the audit log
+const (
+	AuditApprove        = "approve"
+	AuditReview         = "review"
+	AuditRequestChanges = "request-changes"
+	AuditComment        = "comment"
+	AuditHold           = "hold"
+	AuditUnhold         = "unhold"
+	AuditLabel          = "label"
+	AuditUnlabel        = "unlabel"
+	AuditClose          = "close"
+	AuditReopen         = "reopen"
+	AuditMerge          = "merge"
+	AuditRequestReview  = "request-review"
+	AuditAssign         = "assign"
+	AuditUpdateBranch   = "update-branch"
+	AuditReact          = "react"
+	AuditRerunChecks    = "rerun-checks"
+	AuditOpenIssue      = "open-issue"
+)
+
+var (
+	// historySince limits 'ghprs history' to the actions taken within this window
+	historySince string
+	// auditLog records the actions ghprs takes on PRs, nil to record none. It is only opened by Execute, so
+	// tests calling the commands directly don't write to the audit log of the user.
+	auditLog *auditLogger
+)
+
+// AuditEntry is an action ghprs took on a PR, one JSON line of the audit log
+type AuditEntry struct {
+	Time       time.Time `json:"time" yaml:"time"`
+	Repository string    `json:"repository" yaml:"repository"`
+	Number     int       `json:"number" yaml:"number"`
+	Action     string    `json:"action" yaml:"action"`
+	// User is the authenticated user the action was taken as, empty if it couldn't be looked up
+	User string `json:"user,omitempty" yaml:"user,omitempty"`
+	// Body is what was posted: the review body or comment, the labels, reviewers or assignees, the reaction, the
+	// checks re-run, the title of an issue or the merge method. Actions taken with a comment, such as /unhold or
+	// approving with a comment, leave it empty since the comment is recorded on its own.
+	Body string `json:"body,omitempty" yaml:"body,omitempty"`
+}
+
+// auditLogger appends the actions taken on PRs to a local JSONL file
+type auditLogger struct {
+	path  string
+	mutex sync.Mutex
+}
+
+// auditLogPath is where the audit log is kept, next to the config file
+func auditLogPath() string {
+	return filepath.Join(stateDir(), "audit.jsonl")
+}
+
+// record appends an action taken on a PR to the audit log. Failing to record only warns, since the action was
+// already taken. A nil logger records nothing.
+func (a *auditLogger) record(client RESTClientInterface, owner, repo string, number int, action, body string) {
+	if a == nil {
+		return
+	}
+	user, err := viewerLogin(client, owner, repo)
+	if err != nil {
+		logger.Debug("Could not look up the user for the audit log", "error", err)
+	}
+	entry := AuditEntry{Time: time.Now().UTC(), Repository: owner + "/" + repo, Number: number, Action: action, User: user, Body: body}
+	if err := a.append(entry); err != nil {
+		logger.Warn("Could not record the action in the audit log", "path", a.path, "action", action, "error", err)
+	}
+}
+
+// append writes an entry as a line of the audit log, creating the file if needed
+func (a *auditLogger) append(entry AuditEntry) error {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	a.mutex.Lock()
+	defer a.mutex.Unlock()
+	if err := os.MkdirAll(filepath.Dir(a.path), 0755); err != nil {
+		return err
+	}
+	file, err := os.OpenFile(a.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	_, err = file.Write(append(line, '\n'))
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// readAuditLog reads the entries of the audit log of repoSpec, or of every repository when it is empty, taken at
+// or after since, oldest first. A missing audit log has no entries and lines that can't be parsed are skipped.
+func readAuditLog(path, repoSpec string, since time.Time) ([]AuditEntry, error) {
+	file, err := os.Open(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = file.Close() }()
+
+	var entries []AuditEntry
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		var entry AuditEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			logger.Warn("Skipping unreadable audit log entry", "path", path, "line", line, "error", err)
+			continue
+		}
+		if repoSpec != "" && !strings.EqualFold(entry.Repository, repoSpec) {
+			continue
+		}
+		if entry.Time.Before(since) {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries, scanner.Err()
+}
+
+// displayHistory shows the entries of the audit log as a table
+func displayHistory(entries []AuditEntry) {
+	if len(entries) == 0 {
+		streams.Println("No actions recorded")
+		return
+	}
+	table := render.NewTable(
+		render.Column{Header: "TIME", Width: 16},
+		render.Column{Header: "REPO", Width: historyRepoWidth(entries), Truncate: true},
+		render.Column{Header: "PR", Width: 6},
+		render.Column{Header: "ACTION", Width: 15},
+		render.Column{Header: "USER", Width: 16, Truncate: true},
+		render.Column{Header: "BODY", Width: 50, Truncate: true},
+	)
+	for _, entry := range entries {
+		table.AddRow(
+			entry.Time.Local().Format("2006-01-02 15:04"),
+			entry.Repository,
+			fmt.Sprintf("#%d", entry.Number),
+			entry.Action,
+			entry.User,
+			strings.Join(strings.Fields(entry.Body), " "))
+	}
+	table.Write(streams.Out)
+	streams.Printf("\n%d action(s)\n", len(entries))
+}
+
+// historyRepoWidth fits the REPO column of the history table to its longest repository, up to 40 characters
+func historyRepoWidth(entries []AuditEntry) int {
+	width := len("REPO")
+	for _, entry := range entries {
+		width = max(width, len(entry.Repository))
+	}
+	return min(width, 40)
+}
+
+// writeHistoryCSV writes the entries of the audit log as CSV
+func writeHistoryCSV(w io.Writer, entries []AuditEntry) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"time", "repository", "number", "action", "user", "body"})
+	for _, entry := range entries {
+		_ = writer.Write([]string{
+			entry.Time.Format(time.RFC3339),
+			entry.Repository,
+			strconv.Itoa(entry.Number),
+			entry.Action,
+			entry.User,
+			entry.Body,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// historyCmd shows what ghprs did to PRs
+var historyCmd = &cobra.Command{
+	Use:   "history",
+	Short: "Show the actions ghprs took on pull requests",
+	Long: `Show the approvals, holds, comments, labels, merges, state changes, review requests, assignments,
+branch updates, reactions, re-run checks and issues ghprs made, from the audit log it keeps next to the
+config. Each action is recorded with when it was taken, the repository and PR, the
+user it was taken as and what was posted, so bulk approvals can be reviewed and exported for compliance.
+
+Use --repo to show the actions on one repository and --since to show only recent ones.
+--output json, yaml or csv exports the actions.
+
+Examples:
+  ghprs history
+  ghprs history --since 7d
+  ghprs history --repo owner/repo --since 2w
+  ghprs history --since 30d --output csv > approvals.csv`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		if outputFormat == OutputPlain || outputFormat == OutputMarkdown {
+			log.Fatalf("--output %s is only supported by list and konflux", outputFormat)
+		}
+		if repoFlag != "" {
+			if _, _, ok := parseRepoSpec(repoFlag); !ok {
+				log.Fatalf("Invalid repository format '%s'. Must be 'owner/repo'", repoFlag)
+			}
+		}
+		var since time.Time
+		if historySince != "" {
+			window, err := parseHoldDuration(historySince)
+			if err != nil {
+				log.Fatalf("Invalid --since %q (use e.g. 7d, 2w or 36h)", historySince)
+			}
+			since = time.Now().Add(-window)
+		}
+
+		entries, err := readAuditLog(auditLogPath(), repoFlag, since)
+		if err != nil {
+			log.Fatalf("Failed to read the audit log: %v", err)
+		}
+		switch outputFormat {
+		case OutputCSV:
+			if err := writeHistoryCSV(streams.Out, entries); err != nil {
+				log.Fatalf("Failed to write csv output: %v", err)
+			}
+		case OutputJSON, OutputYAML:
+			if entries == nil {
+				entries = []AuditEntry{}
+			}
+			if err := writeStructuredOutput(streams.Out, entries, outputFormat); err != nil {
+				log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+			}
+		default:
+			displayHistory(entries)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(historyCmd)
+
+	historyCmd.Flags().StringVar(&historySince, "since", "", "Show only the actions taken within this window, e.g. 7d, 2w or 36h")
+}
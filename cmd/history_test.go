@@ -0,0 +1,126 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Audit log", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		auditPath  string
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("user", 200, cmd.User{Login: "reviewer"})
+		mockClient.AddResponse("repos/owner/repo/pulls/12", 200, cmd.PullRequest{Number: 12, State: "open"})
+		auditPath = filepath.Join(GinkgoT().TempDir(), "audit.jsonl")
+		cmd.EnableAuditLogTest(auditPath)
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.DisableAuditLogTest()
+		cmd.ResetIOStreams()
+	})
+
+	It("should record the actions taken on PRs with the user they were taken as", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/12/comments", 201, map[string]any{})
+		mockClient.AddResponse("repos/owner/repo/issues/12/labels", 200, []cmd.Label{})
+		mockClient.AddResponse("repos/owner/repo/issues/12/labels/needs-rebase", 200, nil)
+		pr := cmd.PullRequest{Number: 12, Labels: []cmd.Label{{Name: "needs-rebase"}}}
+
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{12}, "waiting for the release", true)).To(Equal(0))
+		Expect(cmd.ChangeLabelsTest(mockClient, "owner", "repo", pr, []string{"ok-to-test"}, []string{"needs-rebase"})).To(BeTrue())
+
+		entries, err := cmd.ReadAuditLogTest(auditPath, "", time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(HaveLen(3))
+		Expect(entries[0].Action).To(Equal(cmd.AuditHold))
+		Expect(entries[0].Body).To(ContainSubstring("/hold"))
+		Expect(entries[0].Body).To(ContainSubstring("waiting for the release"))
+		Expect(entries[1].Action).To(Equal(cmd.AuditLabel))
+		Expect(entries[1].Body).To(Equal("ok-to-test"))
+		Expect(entries[2].Action).To(Equal(cmd.AuditUnlabel))
+		Expect(entries[2].Body).To(Equal("needs-rebase"))
+		for _, entry := range entries {
+			Expect(entry.Repository).To(Equal("owner/repo"))
+			Expect(entry.Number).To(Equal(12))
+			Expect(entry.User).To(Equal("reviewer"))
+			Expect(entry.Time).NotTo(BeZero())
+		}
+	})
+
+	It("should not record an action that failed", func() {
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{12}, "", true)).To(Equal(1))
+
+		entries, err := cmd.ReadAuditLogTest(auditPath, "", time.Time{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(entries).To(BeEmpty())
+	})
+
+	Describe("reading it back", func() {
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		BeforeEach(func() {
+			lines := `{"time":"2026-03-01T12:00:00Z","repository":"owner/repo","number":1,"action":"approve","user":"reviewer","body":"/lgtm"}
+not json
+{"time":"2026-03-09T12:00:00Z","repository":"owner/repo","number":2,"action":"hold","user":"reviewer","body":"/hold\n\nwait"}
+
+{"time":"2026-03-09T13:00:00Z","repository":"owner/other","number":3,"action":"merge","user":"reviewer","body":"squash"}
+`
+			Expect(os.WriteFile(auditPath, []byte(lines), 0600)).To(Succeed())
+		})
+
+		It("should filter by repository and time, skipping lines it can't read", func() {
+			entries, err := cmd.ReadAuditLogTest(auditPath, "", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(3))
+
+			entries, err = cmd.ReadAuditLogTest(auditPath, "Owner/Repo", now.Add(-7*24*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Number).To(Equal(2))
+		})
+
+		It("should have no entries when nothing was recorded yet", func() {
+			entries, err := cmd.ReadAuditLogTest(filepath.Join(GinkgoT().TempDir(), "missing.jsonl"), "", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(BeEmpty())
+		})
+
+		It("should show the actions as a table", func() {
+			entries, err := cmd.ReadAuditLogTest(auditPath, "", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			cmd.DisplayHistoryTest(entries)
+
+			Expect(out.String()).To(MatchRegexp(`owner/repo\s+#2\s+hold\s+reviewer\s+/hold wait`))
+			Expect(out.String()).To(ContainSubstring("3 action(s)"))
+		})
+
+		It("should export the actions as CSV", func() {
+			entries, err := cmd.ReadAuditLogTest(auditPath, "", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+
+			var buf bytes.Buffer
+			Expect(cmd.WriteHistoryCSVTest(&buf, entries)).To(Succeed())
+			records, err := csv.NewReader(&buf).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(4))
+			Expect(records[0]).To(Equal([]string{"time", "repository", "number", "action", "user", "body"}))
+			Expect(records[3]).To(Equal([]string{"2026-03-09T13:00:00Z", "owner/other", "3", "merge", "reviewer", "squash"}))
+		})
+	})
+})
@@ -0,0 +1,171 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+)
+
+var (
+	holdComment  string
+	holdUntil    string
+	holdDuration string
+	assumeYes    bool
+)
+
+// holdCmd puts several PRs on hold after showing what will be done
+var holdCmd = &cobra.Command{
+	Use:   "hold [owner/repo] <number>...",
+	Short: "Put several pull requests on hold",
+	Long: `Put pull requests on hold by commenting /hold and labelling them needs-ok-to-test.
+
+A plan of what will happen to each PR is shown first and nothing is changed until it is
+confirmed. PRs that are closed or already on hold are skipped.
+
+A hold can be given an expiry with --until or --for. It is noted in the /hold comment, and
+'ghprs watch' reminds about the hold once it expired (or lifts it with --unhold-expired).
+
+Examples:
+  ghprs hold owner/repo 12 15 20
+  ghprs hold owner/repo 12 15 --comment "waiting for the release branch"
+  ghprs hold owner/repo 12 --until 2025-01-15 # Expires at the start of that day
+  ghprs hold owner/repo 12 --for 7d           # Expires in a week
+  ghprs hold owner/repo 12 15 --yes          # Don't ask for confirmation
+  ghprs hold --repo owner/repo 12 15`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePRArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, numbers := parseBatchArgs(args)
+		expiry, err := parseHoldExpiry(holdUntil, holdDuration, time.Now())
+		if err != nil {
+			log.Fatal(err)
+		}
+		client := newCommandClient(ctx, owner, repo)
+		if failed := holdPRs(ctx, client, owner, repo, numbers, holdComment, expiry, assumeYes); failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// parseBatchArgs parses the "<owner/repo> <number>..." arguments of the batch commands, exiting on invalid input.
+// With --repo the arguments are only PR numbers.
+func parseBatchArgs(args []string) (string, string, []int) {
+	repoSpec := repoFlag
+	if repoSpec == "" {
+		if len(args) < 2 {
+			log.Fatal("Specify the repository as owner/repo (or with --repo) followed by at least one PR number")
+		}
+		repoSpec, args = args[0], args[1:]
+	}
+	owner, repo, ok := parseRepoSpec(repoSpec)
+	if !ok {
+		log.Fatalf("Invalid repository format '%s'. Must be 'owner/repo'", repoSpec)
+	}
+
+	var numbers []int
+	for _, arg := range args {
+		number, err := strconv.Atoi(strings.TrimPrefix(arg, "#"))
+		if err != nil || number <= 0 {
+			log.Fatalf("Invalid PR number '%s'", arg)
+		}
+		numbers = append(numbers, number)
+	}
+	return owner, repo, numbers
+}
+
+// parsePRArgs parses the "[owner/repo] <number>" arguments of the commands that work on a single PR,
+// exiting on invalid input. Without owner/repo the repository given with --repo or else the current one is used.
+func parsePRArgs(args []string) (string, string, int) {
+	var owner, repo string
+	if len(args) == 2 || repoFlag != "" {
+		repoSpec := repoFlag
+		if len(args) == 2 {
+			repoSpec = args[0]
+		}
+		var ok bool
+		if owner, repo, ok = parseRepoSpec(repoSpec); !ok {
+			log.Fatalf("Invalid repository format '%s'. Must be 'owner/repo'", repoSpec)
+		}
+	} else {
+		currentRepo, err := repository.Current()
+		if err != nil {
+			log.Fatal("Could not detect current repository. Specify owner/repo or run from a git repository.")
+		}
+		owner, repo = currentRepo.Owner, currentRepo.Name
+	}
+
+	number, err := strconv.Atoi(strings.TrimPrefix(args[len(args)-1], "#"))
+	if err != nil || number <= 0 {
+		log.Fatalf("Invalid PR number '%s'", args[len(args)-1])
+	}
+	return owner, repo, number
+}
+
+// newCommandClient creates the API client for a command that works on a single repository, exiting on failure
+func newCommandClient(ctx context.Context, owner, repo string) RESTClientInterface {
+	config, err := LoadConfig()
+	if err != nil {
+		config = DefaultConfig()
+	}
+	setRepositoryHosts(config)
+	client, err := newAPIClient(hostFor(owner, repo), newRateLimiter(config.RateLimitThreshold(), streams.ErrOut), nil)
+	if err != nil {
+		log.Fatalf("Failed to create GitHub client: %v", err)
+	}
+	return withContext(client, ctx)
+}
+
+// planHold decides which of the given PRs will be put on hold
+func planHold(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, comment string, expiry time.Time) *batchPlan {
+	plan := &batchPlan{owner: owner, repo: repo}
+	reason := "requested"
+	if comment != "" {
+		reason = comment
+	}
+	if !expiry.IsZero() {
+		reason += fmt.Sprintf(" (until %s)", expiry.Format("Mon Jan 2 15:04"))
+	}
+	for _, number := range numbers {
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		switch {
+		case err != nil:
+			plan.add(PullRequest{Number: number}, PlanActionSkip, fmt.Sprintf("could not fetch PR: %v", err))
+		case pr.State != "open":
+			plan.add(*pr, PlanActionSkip, fmt.Sprintf("PR is %s", pr.State))
+		case isOnHold(*pr):
+			plan.add(*pr, PlanActionSkip, "already on hold")
+		default:
+			plan.add(*pr, PlanActionHold, reason)
+		}
+	}
+	return plan
+}
+
+// holdPRs shows the hold plan, asks for one confirmation and puts the PRs on hold, returning the number of failures
+func holdPRs(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, comment string, expiry time.Time, assumeYes bool) int {
+	plan := planHold(ctx, client, owner, repo, numbers, comment, expiry)
+	if !confirmPlan(plan, assumeYes) {
+		return 0
+	}
+
+	return executePlan(plan, func(action PlannedAction) error {
+		return holdPR(client, owner, repo, action.Number, comment, expiry)
+	})
+}
+
+func init() {
+	RootCmd.AddCommand(holdCmd)
+
+	holdCmd.Flags().StringVar(&holdComment, "comment", "", "Explanation to add below the /hold comment")
+	holdCmd.Flags().StringVar(&holdUntil, "until", "", "Date the hold expires, as YYYY-MM-DD")
+	holdCmd.Flags().StringVar(&holdDuration, "for", "", "How long the hold lasts, e.g. 7d, 2w or 36h")
+	holdCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Carry out the plan without asking for confirmation")
+}
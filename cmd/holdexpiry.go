@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// holdExpiryDateLayout is the layout of --until dates
+const holdExpiryDateLayout = "2006-01-02"
+
+// holdExpiryRE matches the marker ghprs leaves in a /hold comment so the expiry can be read back
+var holdExpiryRE = regexp.MustCompile(`<!-- ghprs:hold-until (\S+) -->`)
+
+// IssueComment is a comment on a PR's conversation
+type IssueComment struct {
+	ID        int64  `json:"id"`
+	Body      string `json:"body"`
+	User      User   `json:"user"`
+	CreatedAt string `json:"created_at"`
+	// Reactions are the reaction counts of the comment
+	Reactions *Reactions `json:"reactions,omitempty"`
+}
+
+// ExpiredHold is a PR whose hold expired
+type ExpiredHold struct {
+	Number int
+	Title  string
+	Expiry time.Time
+}
+
+// parseHoldDuration parses how long a hold lasts: a number of days (7d) or weeks (2w), or a Go duration (36h)
+func parseHoldDuration(value string) (time.Duration, error) {
+	for suffix, unit := range map[string]time.Duration{"d": 24 * time.Hour, "w": 7 * 24 * time.Hour} {
+		if number, ok := strings.CutSuffix(value, suffix); ok {
+			n, err := strconv.Atoi(number)
+			if err != nil || n <= 0 {
+				return 0, fmt.Errorf("invalid hold duration %q", value)
+			}
+			return time.Duration(n) * unit, nil
+		}
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil || duration <= 0 {
+		return 0, fmt.Errorf("invalid hold duration %q (use e.g. 7d, 2w or 36h)", value)
+	}
+	return duration, nil
+}
+
+// parseHoldExpiry returns when a hold given --until or --for expires, or the zero time when neither was given.
+// An --until date expires at the start of that day.
+func parseHoldExpiry(until, duration string, now time.Time) (time.Time, error) {
+	switch {
+	case until != "" && duration != "":
+		return time.Time{}, fmt.Errorf("--until and --for can't be used together")
+	case until != "":
+		if expiry, err := time.Parse(time.RFC3339, until); err == nil {
+			return expiry, nil
+		}
+		expiry, err := time.ParseInLocation(holdExpiryDateLayout, until, now.Location())
+		if err != nil {
+			return time.Time{}, fmt.Errorf("invalid date %q (use YYYY-MM-DD)", until)
+		}
+		if !expiry.After(now) {
+			return time.Time{}, fmt.Errorf("hold expiry %s is not in the future", until)
+		}
+		return expiry, nil
+	case duration != "":
+		d, err := parseHoldDuration(duration)
+		if err != nil {
+			return time.Time{}, err
+		}
+		return now.Add(d), nil
+	default:
+		return time.Time{}, nil
+	}
+}
+
+// holdCommentBody builds the /hold comment, noting the expiry (if any) for people and for ghprs
+func holdCommentBody(additionalComment string, expiry time.Time) string {
+	body := "/hold"
+	if additionalComment != "" {
+		body += "\n\n" + additionalComment
+	}
+	if !expiry.IsZero() {
+		body += fmt.Sprintf("\n\nThis hold expires on %s.\n<!-- ghprs:hold-until %s -->",
+			expiry.Format("Mon Jan 2 2006 15:04 MST"), expiry.UTC().Format(time.RFC3339))
+	}
+	return body
+}
+
+// isProwCommand reports whether a comment contains the Prow command on a line of its own
+func isProwCommand(body, command string) bool {
+	for _, line := range strings.Split(body, "\n") {
+		if strings.TrimSpace(line) == command {
+			return true
+		}
+	}
+	return false
+}
+
+// fetchHoldExpiry returns when the current hold of a PR expires: the expiry of the latest /hold comment, unless it
+// was lifted since. It returns the zero time when the hold doesn't expire. Every page of comments is read, as
+// the latest hold of a long-lived PR can come after hundreds of /retest comments.
+func fetchHoldExpiry(client RESTClientInterface, owner, repo string, prNumber int) (time.Time, error) {
+	ctx := withFreshData(context.Background())
+	comments, err := fetchPages[IssueComment](ctx, client, fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, prNumber))
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	for i := len(comments) - 1; i >= 0; i-- {
+		body := comments[i].Body
+		if isProwCommand(body, "/unhold") || isProwCommand(body, "/hold cancel") {
+			return time.Time{}, nil
+		}
+		if !isProwCommand(body, "/hold") {
+			continue
+		}
+		match := holdExpiryRE.FindStringSubmatch(body)
+		if match == nil {
+			return time.Time{}, nil
+		}
+		return time.Parse(time.RFC3339, match[1])
+	}
+	return time.Time{}, nil
+}
+
+// findExpiredHolds returns the PRs on hold in snapshots whose hold expired by now, leaving out those already reported
+func findExpiredHolds(client RESTClientInterface, owner, repo string, snapshots map[int]WatchSnapshot, now time.Time, reported map[int]time.Time) []ExpiredHold {
+	numbers := make([]int, 0, len(snapshots))
+	for number, snapshot := range snapshots {
+		if snapshot.OnHold {
+			numbers = append(numbers, number)
+		}
+	}
+	sort.Ints(numbers)
+
+	var expired []ExpiredHold
+	for _, number := range numbers {
+		expiry, err := fetchHoldExpiry(client, owner, repo, number)
+		if err != nil {
+			warnOnce("hold-expiry", "could not check when holds expire: %v", err)
+			continue
+		}
+		if expiry.IsZero() || expiry.After(now) || reported[number].Equal(expiry) {
+			continue
+		}
+		reported[number] = expiry
+		expired = append(expired, ExpiredHold{Number: number, Title: snapshots[number].Title, Expiry: expiry})
+	}
+	return expired
+}
+
+// handleExpiredHolds reminds about the expired holds of a repository or, with autoUnhold, takes the PRs off hold
+func handleExpiredHolds(client RESTClientInterface, owner, repo string, expired []ExpiredHold, autoUnhold, notify bool) {
+	if len(expired) == 0 {
+		return
+	}
+
+	streams.Printf("\nExpired holds for %s/%s:\n", owner, repo)
+	for _, hold := range expired {
+		link := formatPRLink(owner, repo, hold.Number)
+		expiredOn := hold.Expiry.Local().Format("Mon Jan 2 15:04")
+		if !autoUnhold {
+			streams.Printf("  ⏰ %s hold expired on %s: %s (ghprs unhold %s/%s %d)\n", link, expiredOn, hold.Title, owner, repo, hold.Number)
+			continue
+		}
+		comment := fmt.Sprintf("The hold expired on %s.", hold.Expiry.Format("Mon Jan 2 2006 15:04 MST"))
+		if err := unholdPR(client, owner, repo, hold.Number, comment, false); err != nil {
+			streams.Printf("  ❌ %s hold expired on %s but could not be lifted: %v\n", link, expiredOn, err)
+			continue
+		}
+		streams.Printf("  ▶️  %s hold expired on %s, took it off hold: %s\n", link, expiredOn, hold.Title)
+	}
+
+	if notify {
+		message := fmt.Sprintf("#%d hold expired", expired[0].Number)
+		if len(expired) > 1 {
+			message += fmt.Sprintf(" (+%d more)", len(expired)-1)
+		}
+		if err := notifier(fmt.Sprintf("ghprs: %s/%s", owner, repo), message); err != nil {
+			warnOnce("notify", "could not send desktop notification: %v", err)
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Hold expiry", func() {
+	now := time.Date(2025, 1, 10, 9, 30, 0, 0, time.UTC)
+
+	Describe("Parsing", func() {
+		It("should expire an --until date at the start of that day", func() {
+			Expect(cmd.ParseHoldExpiryTest("2025-01-15", "", now)).To(Equal(time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)))
+		})
+
+		It("should add a --for duration in days, weeks or Go syntax", func() {
+			Expect(cmd.ParseHoldExpiryTest("", "7d", now)).To(Equal(now.Add(7 * 24 * time.Hour)))
+			Expect(cmd.ParseHoldExpiryTest("", "2w", now)).To(Equal(now.Add(14 * 24 * time.Hour)))
+			Expect(cmd.ParseHoldExpiryTest("", "36h", now)).To(Equal(now.Add(36 * time.Hour)))
+		})
+
+		It("should not expire without --until or --for", func() {
+			Expect(cmd.ParseHoldExpiryTest("", "", now)).To(BeZero())
+		})
+
+		It("should reject invalid expiries", func() {
+			for _, args := range [][2]string{{"2025-01-15", "7d"}, {"15/01/2025", ""}, {"2025-01-01", ""}, {"", "soon"}, {"", "0d"}} {
+				_, err := cmd.ParseHoldExpiryTest(args[0], args[1], now)
+				Expect(err).To(HaveOccurred(), "until %q for %q", args[0], args[1])
+			}
+		})
+	})
+
+	Describe("Reading the expiry back", func() {
+		var mockClient *cmd.MockRESTClient
+		var out *bytes.Buffer
+		expiry := time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, Title: "Bump foo", State: "open"})
+			mockClient.AddResponse("repos/owner/repo/issues/1/labels", 200, []cmd.Label{})
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should note the expiry in the /hold comment", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+			Expect(cmd.HoldPRsUntilTest(mockClient, "owner", "repo", []int{1}, "release freeze", expiry)).To(Equal(0))
+
+			var body string
+			for _, req := range mockClient.Requests {
+				if req.Method == "POST" && strings.HasSuffix(req.URL, "/comments") {
+					body = req.Body
+				}
+			}
+			Expect(body).To(HavePrefix(`{"body":"/hold\n\nrelease freeze\n\nThis hold expires on Wed Jan 15 2025 00:00 UTC.`))
+			Expect(body).To(ContainSubstring(`\u003c!-- ghprs:hold-until 2025-01-15T00:00:00Z --\u003e`))
+		})
+
+		It("should read the expiry of the latest hold", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 200, []cmd.IssueComment{
+				{Body: "/hold\n\n<!-- ghprs:hold-until 2024-12-01T00:00:00Z -->"},
+				{Body: "/unhold"},
+				{Body: "/hold\n\nThis hold expires soon.\n<!-- ghprs:hold-until 2025-01-15T00:00:00Z -->"},
+				{Body: "LGTM once the freeze is over"},
+			})
+			Expect(cmd.FetchHoldExpiryTest(mockClient, "owner", "repo", 1)).To(Equal(expiry))
+		})
+
+		It("should not expire a hold without expiry or one that was lifted", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 200, []cmd.IssueComment{
+				{Body: "/hold\n\n<!-- ghprs:hold-until 2025-01-15T00:00:00Z -->"},
+				{Body: "/hold"},
+			})
+			Expect(cmd.FetchHoldExpiryTest(mockClient, "owner", "repo", 1)).To(BeZero())
+
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 200, []cmd.IssueComment{
+				{Body: "/hold\n\n<!-- ghprs:hold-until 2025-01-15T00:00:00Z -->"},
+				{Body: "/hold cancel"},
+			})
+			Expect(cmd.FetchHoldExpiryTest(mockClient, "owner", "repo", 1)).To(BeZero())
+		})
+
+		It("should find the latest hold past the first page of comments", func() {
+			firstPage := []cmd.IssueComment{{Body: "/hold\n\n<!-- ghprs:hold-until 2024-12-01T00:00:00Z -->"}}
+			for len(firstPage) < 100 {
+				firstPage = append(firstPage, cmd.IssueComment{Body: "/retest"})
+			}
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments?per_page=100&page=1", 200, firstPage)
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments?per_page=100&page=2", 200, []cmd.IssueComment{
+				{Body: "/hold\n\n<!-- ghprs:hold-until 2025-01-15T00:00:00Z -->"},
+			})
+			Expect(cmd.FetchHoldExpiryTest(mockClient, "owner", "repo", 1)).To(Equal(expiry))
+		})
+
+		It("should report each expired hold once and remind how to lift it", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 200, []cmd.IssueComment{
+				{Body: "/hold\n\n<!-- ghprs:hold-until 2025-01-15T00:00:00Z -->"},
+			})
+			snapshots := map[int]cmd.WatchSnapshot{1: {Title: "Bump foo", OnHold: true}, 2: {Title: "Bump bar"}}
+			reported := make(map[int]time.Time)
+
+			Expect(cmd.FindExpiredHoldsTest(mockClient, "owner", "repo", snapshots, expiry.Add(-time.Hour), reported)).To(BeEmpty())
+			expired := cmd.FindExpiredHoldsTest(mockClient, "owner", "repo", snapshots, expiry.Add(time.Hour), reported)
+			Expect(expired).To(Equal([]cmd.ExpiredHold{{Number: 1, Title: "Bump foo", Expiry: expiry}}))
+			Expect(cmd.FindExpiredHoldsTest(mockClient, "owner", "repo", snapshots, expiry.Add(2*time.Hour), reported)).To(BeEmpty())
+
+			cmd.HandleExpiredHoldsTest(mockClient, "owner", "repo", expired, false)
+			Expect(out.String()).To(ContainSubstring("hold expired on"))
+			Expect(out.String()).To(ContainSubstring("ghprs unhold owner/repo 1"))
+			Expect(mockClient.Requests).NotTo(ContainElement(HaveField("Method", "POST")))
+		})
+
+		It("should lift expired holds with a comment when asked to", func() {
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+			cmd.HandleExpiredHoldsTest(mockClient, "owner", "repo", []cmd.ExpiredHold{{Number: 1, Title: "Bump foo", Expiry: expiry}}, true)
+
+			Expect(mockClient.Requests).To(ContainElement(And(
+				HaveField("Method", "POST"),
+				HaveField("Body", `{"body":"/unhold\n\nThe hold expired on Wed Jan 15 2025 00:00 UTC."}`),
+			)))
+			Expect(out.String()).To(ContainSubstring("took it off hold"))
+		})
+	})
+})
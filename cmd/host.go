@@ -0,0 +1,56 @@
+package cmd
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/auth"
+)
+
+var (
+	hostsMutex sync.RWMutex
+	// configuredHost is the host from the top level of the config, used for repositories without their own
+	configuredHost string
+	// repositoryHosts maps "owner/repo" to the host configured for that repository
+	repositoryHosts = map[string]string{}
+	// configuredAccounts maps a host to the account of gh's stored auth configured for it
+	configuredAccounts = map[string]string{}
+)
+
+// setRepositoryHosts remembers which GitHub host each configured repository lives on, the account used on
+// each host and how often the clients of those hosts retry transient failures
+func setRepositoryHosts(config *Config) {
+	hostsMutex.Lock()
+	defer hostsMutex.Unlock()
+	configuredHost = config.Host
+	configuredAccounts = maps.Clone(config.Accounts)
+	configuredRetries = config.APIRetries()
+	repositoryHosts = make(map[string]string)
+	for _, repo := range config.Repositories {
+		if repo.Host != "" {
+			repositoryHosts[repo.Name] = repo.Host
+		}
+	}
+}
+
+// hostFor returns the GitHub host of a repository: its configured host, the configured global host,
+// or else the host gh uses by default (GH_HOST or github.com)
+func hostFor(owner, repo string) string {
+	hostsMutex.RLock()
+	host := repositoryHosts[owner+"/"+repo]
+	if host == "" {
+		host = configuredHost
+	}
+	hostsMutex.RUnlock()
+
+	if host == "" {
+		host, _ = auth.DefaultHost()
+	}
+	return host
+}
+
+// prURL returns the web URL of a pull request on its repository's host
+func prURL(owner, repo string, prNumber int) string {
+	return fmt.Sprintf("https://%s/%s/%s/pull/%d", hostFor(owner, repo), owner, repo, prNumber)
+}
@@ -0,0 +1,63 @@
+package cmd_test
+
+import (
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("GitHub hosts", func() {
+	BeforeEach(func() {
+		GinkgoT().Setenv("GH_HOST", "")
+		config := cmd.DefaultConfig()
+		config.Host = "ghe.example.com"
+		config.Repositories = []cmd.RepositoryConfig{
+			{Name: "owner/public", Host: "github.com"},
+			{Name: "owner/internal"},
+		}
+		cmd.SetRepositoryHostsTest(config)
+	})
+
+	AfterEach(func() {
+		cmd.SetRepositoryHostsTest(cmd.DefaultConfig())
+	})
+
+	It("should build PR URLs on the repository's host", func() {
+		Expect(cmd.PRURLTest("owner", "public", 1)).To(Equal("https://github.com/owner/public/pull/1"))
+		Expect(cmd.PRURLTest("owner", "internal", 2)).To(Equal("https://ghe.example.com/owner/internal/pull/2"))
+		Expect(cmd.PRURLTest("other", "repo", 3)).To(Equal("https://ghe.example.com/other/repo/pull/3"))
+	})
+
+	It("should fall back to gh's default host when none is configured", func() {
+		GinkgoT().Setenv("GH_HOST", "gh.default.example.com")
+		cmd.SetRepositoryHostsTest(cmd.DefaultConfig())
+		Expect(cmd.PRURLTest("owner", "repo", 4)).To(Equal("https://gh.default.example.com/owner/repo/pull/4"))
+	})
+
+	It("should link PR rows to the repository's host", func() {
+		mockClient := cmd.NewMockRESTClient()
+		rows := cmd.BuildPRRowsTest([]cmd.PullRequest{{Number: 5, Title: "Bump", State: "open"}}, "owner", "internal", mockClient, false)
+		Expect(rows).To(HaveLen(1))
+		Expect(rows[0].URL).To(Equal("https://ghe.example.com/owner/internal/pull/5"))
+	})
+
+	It("should not share cached check results between hosts", func() {
+		dir, err := os.MkdirTemp("", "ghprs-host-cache")
+		Expect(err).NotTo(HaveOccurred())
+		defer func() { _ = os.RemoveAll(dir) }()
+
+		mockClient := cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/commits/abc123/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+
+		var checks cmd.CheckRunsResponse
+		for _, host := range []string{"github.com", "ghe.example.com", "github.com"} {
+			client := cmd.WithDiskCacheForHostTest(mockClient, dir, time.Minute, host)
+			Expect(client.Get("repos/owner/repo/commits/abc123/check-runs", &checks)).To(Succeed())
+		}
+		Expect(mockClient.GetRequestCount("repos/owner/repo/commits/abc123/check-runs")).To(Equal(2))
+	})
+})
@@ -19,3 +19,10 @@ type RESTClientInterface interface {
 	Request(method string, path string, body io.Reader) (*http.Response, error)
 	RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error)
 }
+
+// GraphQLClientInterface defines the subset of api.GraphQLClient used by ghprs
+// This allows us to use both the real api.GraphQLClient and our MockGraphQLClient in tests
+type GraphQLClientInterface interface {
+	Do(query string, variables map[string]interface{}, response interface{}) error
+	DoWithContext(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"golang.org/x/term"
+)
+
+// IOStreams holds the input and output streams used by the interactive commands
+type IOStreams struct {
+	In     io.Reader
+	Out    io.Writer
+	ErrOut io.Writer
+
+	// reader is shared by every prompt so buffered input isn't lost between prompts
+	reader *bufio.Reader
+	// terminal overrides whether Out is treated as a terminal when set
+	terminal *bool
+	// errTerminal overrides whether ErrOut is treated as a terminal when set
+	errTerminal *bool
+	// teedOut is Out before Tee wrapped it, which IsTerminal, Width and Height look at
+	teedOut io.Writer
+	// echo receives every line read, so a copy of Out also has the answers typed
+	echo io.Writer
+}
+
+// NewIOStreams creates streams that read from in and write to out and errOut
+func NewIOStreams(in io.Reader, out, errOut io.Writer) *IOStreams {
+	return &IOStreams{In: in, Out: out, ErrOut: errOut, reader: bufio.NewReader(in)}
+}
+
+// DefaultIOStreams returns streams connected to the process's stdin, stdout and stderr
+func DefaultIOStreams() *IOStreams {
+	return NewIOStreams(os.Stdin, os.Stdout, os.Stderr)
+}
+
+// Printf writes formatted output to Out
+func (s *IOStreams) Printf(format string, args ...interface{}) {
+	_, _ = fmt.Fprintf(s.Out, format, args...)
+}
+
+// Println writes a line to Out
+func (s *IOStreams) Println(args ...interface{}) {
+	_, _ = fmt.Fprintln(s.Out, args...)
+}
+
+// Print writes output to Out
+func (s *IOStreams) Print(args ...interface{}) {
+	_, _ = fmt.Fprint(s.Out, args...)
+}
+
+// ReadLine reads one line of input, without its trailing newline.
+// A final line without a newline is returned before io.EOF is reported.
+func (s *IOStreams) ReadLine() (string, error) {
+	line, err := s.reader.ReadString('\n')
+	if err == io.EOF && line != "" {
+		err = nil
+	}
+	line = strings.TrimRight(line, "\r\n")
+	if s.echo != nil && err == nil {
+		_, _ = fmt.Fprintln(s.echo, line)
+	}
+	return line, err
+}
+
+// Tee copies everything written to Out, and every line read, to w until the returned function is called.
+// Out keeps being treated as the terminal it was.
+func (s *IOStreams) Tee(w io.Writer) func() {
+	out := s.Out
+	s.teedOut, s.echo = out, w
+	s.Out = io.MultiWriter(out, w)
+	return func() {
+		s.Out, s.teedOut, s.echo = out, nil, nil
+	}
+}
+
+// outFile returns the file Out writes to, if it is one
+func (s *IOStreams) outFile() (*os.File, bool) {
+	out := s.Out
+	if s.teedOut != nil {
+		out = s.teedOut
+	}
+	file, ok := out.(*os.File)
+	return file, ok
+}
+
+// IsTerminal reports whether Out is a terminal
+func (s *IOStreams) IsTerminal() bool {
+	if s.terminal != nil {
+		return *s.terminal
+	}
+	file, ok := s.outFile()
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// defaultTerminalWidth is the width assumed for output that isn't a terminal of known size
+const defaultTerminalWidth = 120
+
+// Width returns the width of the terminal Out is connected to, else $COLUMNS or a default
+func (s *IOStreams) Width() int {
+	if file, ok := s.outFile(); ok {
+		if width, _, err := term.GetSize(int(file.Fd())); err == nil && width > 0 {
+			return width
+		}
+	}
+	if width, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && width > 0 {
+		return width
+	}
+	return defaultTerminalWidth
+}
+
+// Height returns the height of the terminal Out is connected to, 0 when it isn't known
+func (s *IOStreams) Height() int {
+	if file, ok := s.outFile(); ok {
+		if _, height, err := term.GetSize(int(file.Fd())); err == nil {
+			return height
+		}
+	}
+	return 0
+}
+
+// SetTerminal overrides whether Out is treated as a terminal
+func (s *IOStreams) SetTerminal(isTerminal bool) {
+	s.terminal = &isTerminal
+}
+
+// IsErrTerminal reports whether ErrOut is a terminal, so progress that rewrites its line can be shown
+func (s *IOStreams) IsErrTerminal() bool {
+	if s.errTerminal != nil {
+		return *s.errTerminal
+	}
+	file, ok := s.ErrOut.(*os.File)
+	return ok && term.IsTerminal(int(file.Fd()))
+}
+
+// SetErrTerminal overrides whether ErrOut is treated as a terminal
+func (s *IOStreams) SetErrTerminal(isTerminal bool) {
+	s.errTerminal = &isTerminal
+}
+
+// Prompter asks the user questions
+type Prompter interface {
+	// Input shows prompt and returns the line the user entered, with surrounding whitespace removed
+	Input(prompt string) (string, error)
+	// Confirm shows a y/N prompt and reports whether the user answered yes
+	Confirm(prompt string) (bool, error)
+}
+
+// streamPrompter prompts on IOStreams
+type streamPrompter struct {
+	streams *IOStreams
+}
+
+// NewPrompter creates a prompter that writes prompts to the streams' Out and reads answers from In
+func NewPrompter(streams *IOStreams) Prompter {
+	return &streamPrompter{streams: streams}
+}
+
+// Input shows prompt and reads a line
+func (p *streamPrompter) Input(prompt string) (string, error) {
+	p.streams.Print(prompt)
+	waitingForInput.Store(true)
+	defer waitingForInput.Store(false)
+	line, err := p.streams.ReadLine()
+	return strings.TrimSpace(line), err
+}
+
+// Confirm shows a y/N prompt, treating anything but y/yes as no
+func (p *streamPrompter) Confirm(prompt string) (bool, error) {
+	answer, err := p.Input(prompt + " [y/N]: ")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+var (
+	// streams is where the list and approval commands read input and write output
+	streams = DefaultIOStreams()
+	// prompter asks the interactive questions of the list and approval commands
+	prompter = NewPrompter(streams)
+)
+
+// SetIOStreams replaces the streams and prompter used by commands (used for testing and embedding)
+func SetIOStreams(s *IOStreams, p Prompter) {
+	streams = s
+	if p == nil {
+		p = NewPrompter(s)
+	}
+	prompter = p
+}
+
+// ResetIOStreams restores the process's stdin, stdout and stderr
+func ResetIOStreams() {
+	SetIOStreams(DefaultIOStreams(), nil)
+}
@@ -0,0 +1,374 @@
+package cmd_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+
+	"ghprs/internal/render"
+)
+
+// scriptedPrompter answers prompts from a fixed list and records what was asked
+type scriptedPrompter struct {
+	answers []string
+	prompts []string
+}
+
+func (p *scriptedPrompter) Input(prompt string) (string, error) {
+	p.prompts = append(p.prompts, prompt)
+	if len(p.answers) == 0 {
+		return "", io.EOF
+	}
+	answer := p.answers[0]
+	p.answers = p.answers[1:]
+	return answer, nil
+}
+
+func (p *scriptedPrompter) Confirm(prompt string) (bool, error) {
+	answer, err := p.Input(prompt)
+	return answer == "y", err
+}
+
+var _ = Describe("IO Streams", func() {
+	var out, errOut *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		errOut = &bytes.Buffer{}
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should take the width from $COLUMNS when the output isn't a terminal", func() {
+		streams := cmd.NewIOStreams(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{})
+		GinkgoT().Setenv("COLUMNS", "180")
+		Expect(streams.Width()).To(Equal(180))
+		GinkgoT().Setenv("COLUMNS", "")
+		Expect(streams.Width()).To(Equal(120))
+	})
+
+	Describe("Prompter", func() {
+		It("should read successive lines from a single stream", func() {
+			streams := cmd.NewIOStreams(strings.NewReader("first\n  second  \nlast"), out, errOut)
+			prompter := cmd.NewPrompter(streams)
+
+			answer, err := prompter.Input("One: ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(answer).To(Equal("first"))
+
+			answer, err = prompter.Input("Two: ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(answer).To(Equal("second"))
+
+			answer, err = prompter.Input("Three: ")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(answer).To(Equal("last"))
+
+			_, err = prompter.Input("Four: ")
+			Expect(err).To(Equal(io.EOF))
+			Expect(out.String()).To(Equal("One: Two: Three: Four: "))
+		})
+
+		It("should only confirm on yes", func() {
+			streams := cmd.NewIOStreams(strings.NewReader("y\nYES\nn\n\n"), out, errOut)
+			prompter := cmd.NewPrompter(streams)
+
+			for _, expected := range []bool{true, true, false, false} {
+				confirmed, err := prompter.Confirm("Continue?")
+				Expect(err).NotTo(HaveOccurred())
+				Expect(confirmed).To(Equal(expected))
+			}
+			Expect(out.String()).To(ContainSubstring("Continue? [y/N]: "))
+		})
+	})
+
+	Describe("Repository selection", func() {
+		It("should select the repository the user picks", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("abc\n2\n"), out, errOut), nil)
+			Expect(cmd.PromptForRepositorySelectionTest([]string{"a/one", "b/two"})).To(Equal("b/two"))
+			Expect(out.String()).To(ContainSubstring("Invalid input 'abc'"))
+		})
+
+		It("should cancel when input ends", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), nil)
+			Expect(cmd.PromptForRepositorySelectionTest([]string{"a/one", "b/two"})).To(BeEmpty())
+		})
+	})
+
+	Describe("Approval flow", func() {
+		var mockClient *cmd.MockRESTClient
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+			mockClient.AddResponse("repos/owner/repo/issues/1/labels", 200, []cmd.Label{})
+		})
+
+		pullRequests := func(body string) []cmd.PullRequest {
+			return []cmd.PullRequest{{
+				Number: 1,
+				Title:  "Update dependency",
+				State:  "open",
+				User:   cmd.User{Login: "renovate[bot]"},
+				Body:   body,
+			}}
+		}
+
+		postsTo := func(path string) []string {
+			var bodies []string
+			for _, req := range mockClient.Requests {
+				if req.Method == "POST" && req.URL == path {
+					bodies = append(bodies, req.Body)
+				}
+			}
+			return bodies
+		}
+
+		It("should approve the default PR when the user answers yes", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\ny\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			reviews := postsTo("repos/owner/repo/pulls/1/reviews")
+			Expect(reviews).To(HaveLen(1))
+			Expect(reviews[0]).To(ContainSubstring(`"event":"APPROVE"`))
+			Expect(out.String()).To(ContainSubstring("✅ Approved: 1"))
+			Expect(out.String()).To(ContainSubstring("All PRs have been processed!"))
+		})
+
+		It("should hold a PR with the comment the user enters", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nh\nwaiting on CI\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			comments := postsTo("repos/owner/repo/issues/1/comments")
+			Expect(comments).To(HaveLen(1))
+			Expect(comments[0]).To(ContainSubstring(`/hold\n\nwaiting on CI`))
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("⏸️  Put on hold: 1"))
+		})
+
+		It("should take a PR on hold off hold from the prompt", func() {
+			held := pullRequests("")
+			held[0].Labels = []cmd.Label{{Name: "do-not-merge/hold"}}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nu\nrelease is out\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", held, false)
+
+			Expect(out.String()).To(ContainSubstring("On hold (select to unhold): #1"))
+			Expect(out.String()).To(ContainSubstring("u=unhold"))
+			Expect(postsTo("repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"/unhold\n\nrelease is out"}`}))
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("▶️  Taken off hold: 1"))
+		})
+
+		It("should not approve when the migration warning isn't confirmed", func() {
+			prompter := &scriptedPrompter{answers: []string{"", "y", "n"}}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), prompter)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests("⚠️[migration] breaking change"), false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(prompter.prompts[2]).To(ContainSubstring("migration warnings"))
+			Expect(out.String()).To(ContainSubstring("Approval cancelled due to migration warnings"))
+		})
+
+		It("should ask for the PR number before approving CI changes by an untrusted author", func() {
+			untrusted := pullRequests("")
+			untrusted[0].User.Login = "someone"
+			prompter := &scriptedPrompter{answers: []string{"", "y", "y"}}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), prompter)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", untrusted, false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("someone isn't a trusted author"))
+			Expect(prompter.prompts[2]).To(ContainSubstring("Type the PR number (1)"))
+
+			prompter = &scriptedPrompter{answers: []string{"", "y", "1"}}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), prompter)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", untrusted, false)
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(HaveLen(1))
+		})
+
+		It("should pin the approval to the displayed head commit", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{
+				Number: 1, MergeableState: "clean", Head: cmd.Branch{SHA: "abc1234def"},
+			})
+			prs := pullRequests("")
+			prs[0].Head.SHA = "abc1234def"
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\ny\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			reviews := postsTo("repos/owner/repo/pulls/1/reviews")
+			Expect(reviews).To(HaveLen(1))
+			Expect(reviews[0]).To(ContainSubstring(`"commit_id":"abc1234def"`))
+		})
+
+		It("should not approve a PR whose head changed since it was displayed", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{
+				Number: 1, MergeableState: "clean", Head: cmd.Branch{SHA: "9999999fff"},
+			})
+			prs := pullRequests("")
+			prs[0].Head.SHA = "abc1234def"
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\ny\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("PR changed since displayed (head was abc1234, now 9999999)"))
+		})
+
+		It("should rebase instead of approving when asked", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1/update-branch", 202, map[string]string{})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nr\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/1/update-branch")).To(Equal(1))
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("🔄 Rebased: 1"))
+		})
+
+		It("should close a PR with the comment the user enters", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nx\nsuperseded by #2\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"superseded by #2"}`}))
+			var patches []string
+			for _, req := range mockClient.Requests {
+				if req.Method == "PATCH" {
+					patches = append(patches, req.URL+" "+req.Body)
+				}
+			}
+			Expect(patches).To(Equal([]string{`repos/owner/repo/pulls/1 {"state":"closed"}`}))
+			Expect(out.String()).To(ContainSubstring("🚪 Closed: 1"))
+		})
+
+		It("should request changes with the review the user enters", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nrc\nplease add a test\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(Equal([]string{`{"body":"please add a test","event":"REQUEST_CHANGES"}`}))
+			Expect(out.String()).To(ContainSubstring("🛑 Requested changes on PR #1"))
+			Expect(out.String()).To(ContainSubstring("🛑 Changes requested: 1"))
+		})
+
+		It("should post a comment review and ask again for an empty one", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\ncr\n\ncr\nlooks reasonable, waiting on CI\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(out.String()).To(ContainSubstring("Empty review, skipping."))
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(Equal([]string{`{"body":"looks reasonable, waiting on CI","event":"COMMENT"}`}))
+			Expect(out.String()).To(ContainSubstring("📝 Comment reviews: 1"))
+		})
+
+		It("should approve with a comment review with yc", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nyc\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(Equal([]string{`{"body":"/lgtm","event":"COMMENT"}`}))
+			Expect(out.String()).To(ContainSubstring("✅ Approved: 1"))
+		})
+
+		It("should approve with a plain comment and no review with yr", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]any{})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nyr\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(postsTo("repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"/lgtm"}`}))
+			Expect(out.String()).To(ContainSubstring("✅ Approved: 1"))
+		})
+
+		It("should let the approval variants override the configured review event", func() {
+			empty := ""
+			settings := cmd.ApprovalSettings{Body: &empty, Event: cmd.ReviewEventComment, ExtraComments: []string{"/approve"}}
+
+			review := cmd.ApprovalSettingsForTest(settings, cmd.ApprovalResultApproveReview)
+			Expect(review.ReviewEvent()).To(Equal(cmd.ReviewEventApprove))
+			Expect(review.ReviewBody()).To(BeEmpty())
+
+			commentReview := cmd.ApprovalSettingsForTest(settings, cmd.ApprovalResultApproveCommentReview)
+			Expect(commentReview.ReviewEvent()).To(Equal(cmd.ReviewEventComment))
+			Expect(commentReview.ReviewBody()).To(Equal("/lgtm"))
+			Expect(commentReview.ExtraComments).To(Equal([]string{"/approve"}))
+
+			Expect(cmd.ApprovalSettingsForTest(settings, cmd.ApprovalResultApprove)).To(Equal(settings))
+		})
+
+		It("should not hold a PR that was merged since it was displayed", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, State: "closed", Merged: true})
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nh\n\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/issues/1/comments")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("Not going to hold #1: PR was merged since displayed"))
+		})
+
+		It("should ask before approving a PR that was updated since it was displayed", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{
+				Number: 1, State: "open", MergeableState: "clean", UpdatedAt: "2026-01-01T01:00:00Z",
+			})
+			prs := pullRequests("")
+			prs[0].UpdatedAt = "2026-01-01T00:00:00Z"
+
+			prompter := &scriptedPrompter{answers: []string{"", "y", "n"}}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), prompter)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			Expect(prompter.prompts[2]).To(Equal("Do you still want to approve it?"))
+			Expect(out.String()).To(ContainSubstring("PR was updated since displayed"))
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+		})
+
+		It("should post the configured review and follow-up comments", func() {
+			body := ""
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\ny\n"), out, errOut), nil)
+			cmd.ApprovePRsWithSettingsTest(mockClient, "owner", "repo", pullRequests(""), cmd.ApprovalSettings{
+				Body:          &body,
+				ExtraComments: []string{"/approve"},
+			})
+
+			reviews := postsTo("repos/owner/repo/pulls/1/reviews")
+			Expect(reviews).To(HaveLen(1))
+			Expect(reviews[0]).To(ContainSubstring(`"body":"","event":"APPROVE"`))
+			Expect(postsTo("repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"/approve"}`}))
+		})
+
+		It("should not repeat the legend across approval sessions of one run", func() {
+			cmd.ResetLegendTest(render.LegendOnce)
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nn\n\nn\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(strings.Count(out.String(), "Legend:")).To(Equal(1))
+		})
+
+		It("should stop cleanly when input runs out", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("1\n"), out, errOut), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", pullRequests(""), false)
+
+			Expect(postsTo("repos/owner/repo/pulls/1/reviews")).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("EOF - exiting approval process"))
+			Expect(out.String()).To(ContainSubstring("📊 Total processed: 0"))
+		})
+	})
+})
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	labelsToAdd    []string
+	labelsToRemove []string
+)
+
+// hasLabel reports whether a PR carries a label, ignoring case as GitHub does
+func hasLabel(pr PullRequest, name string) bool {
+	return slices.ContainsFunc(pr.Labels, func(label Label) bool { return strings.EqualFold(label.Name, name) })
+}
+
+// addLabels adds labels to a PR
+func addLabels(client RESTClientInterface, owner, repo string, prNumber int, labels []string) error {
+	labelJSON, err := json.Marshal(LabelRequest{Labels: labels})
+	if err != nil {
+		return fmt.Errorf("failed to marshal label request: %v", err)
+	}
+	labelPath := fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, prNumber)
+	if err := client.Post(labelPath, bytes.NewReader(labelJSON), nil); err != nil {
+		return fmt.Errorf("failed to add labels: %v", err)
+	}
+	auditLog.record(client, owner, repo, prNumber, AuditLabel, strings.Join(labels, ","))
+	return nil
+}
+
+// removeLabel removes a label from a PR
+func removeLabel(client RESTClientInterface, owner, repo string, prNumber int, label string) error {
+	labelPath := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, prNumber, url.PathEscape(label))
+	if err := client.Delete(labelPath, nil); err != nil {
+		return fmt.Errorf("failed to remove label %s: %v", label, err)
+	}
+	auditLog.record(client, owner, repo, prNumber, AuditUnlabel, label)
+	return nil
+}
+
+// changeLabels adds and removes labels of a PR, leaving out the labels it already has or doesn't have, and
+// reports each change. It returns false if any failed.
+func changeLabels(client RESTClientInterface, owner, repo string, pr PullRequest, add, remove []string) bool {
+	link := formatPRLink(owner, repo, pr.Number)
+	ok := true
+
+	var missing []string
+	for _, label := range add {
+		if hasLabel(pr, label) {
+			streams.Printf("   PR %s already has the %s label\n", link, label)
+		} else {
+			missing = append(missing, label)
+		}
+	}
+	if len(missing) > 0 {
+		if err := addLabels(client, owner, repo, pr.Number, missing); err != nil {
+			streams.Printf("❌ Failed to label PR %s: %v\n", link, err)
+			ok = false
+		} else {
+			streams.Printf("🏷️  Added %s to PR %s\n", strings.Join(missing, ", "), link)
+		}
+	}
+
+	for _, label := range remove {
+		if !hasLabel(pr, label) {
+			streams.Printf("   PR %s doesn't have the %s label\n", link, label)
+			continue
+		}
+		if err := removeLabel(client, owner, repo, pr.Number, label); err != nil {
+			streams.Printf("❌ Failed to unlabel PR %s: %v\n", link, err)
+			ok = false
+			continue
+		}
+		streams.Printf("🏷️  Removed %s from PR %s\n", label, link)
+	}
+	return ok
+}
+
+// splitLabels splits comma-separated label names, dropping empty ones
+func splitLabels(value string) []string {
+	var labels []string
+	for _, label := range strings.Split(value, ",") {
+		if label = strings.TrimSpace(label); label != "" {
+			labels = append(labels, label)
+		}
+	}
+	return labels
+}
+
+// promptForLabels asks which labels to add to and remove from a PR at the approval prompt. It only returns the
+// errors of reading the answers.
+func promptForLabels(client RESTClientInterface, owner, repo string, pr PullRequest) error {
+	var current []string
+	for _, label := range pr.Labels {
+		current = append(current, label.Name)
+	}
+	if len(current) > 0 {
+		streams.Printf("   Labels: %s\n", strings.Join(current, ", "))
+	} else {
+		streams.Printf("   No labels\n")
+	}
+
+	add, err := prompter.Input("Labels to add (comma-separated, Enter for none): ")
+	if err != nil {
+		return err
+	}
+	remove, err := prompter.Input("Labels to remove (comma-separated, Enter for none): ")
+	if err != nil {
+		return err
+	}
+	if splitLabels(add) == nil && splitLabels(remove) == nil {
+		streams.Printf("No labels given, nothing changed.\n")
+		return nil
+	}
+	if !confirmPRUnchanged(client, owner, repo, pr, "label") {
+		return nil
+	}
+	changeLabels(client, owner, repo, pr, splitLabels(add), splitLabels(remove))
+	return nil
+}
+
+// labelCmd adds and removes labels of a PR
+var labelCmd = &cobra.Command{
+	Use:   "label [owner/repo] <number>",
+	Short: "Add and remove labels of a pull request",
+	Long: `Add and remove labels of a pull request in one call, e.g. to let Prow test a PR or to clear a stale
+label. Labels the PR already has are not added again and labels it doesn't have are not removed.
+'L' at the approval prompt does the same.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs label 123 --add ok-to-test
+  ghprs label owner/repo 123 --add ok-to-test --remove needs-rebase,do-not-merge/hold
+  ghprs label owner/repo 123 --add lgtm --add approved`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+		if len(labelsToAdd) == 0 && len(labelsToRemove) == 0 {
+			log.Fatal("Give --add or --remove")
+		}
+
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(withFreshData(ctx), client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+		if !changeLabels(client, owner, repo, *pr, labelsToAdd, labelsToRemove) {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(labelCmd)
+
+	labelCmd.Flags().StringSliceVar(&labelsToAdd, "add", nil, "Label to add (repeatable or comma separated)")
+	labelCmd.Flags().StringSliceVar(&labelsToRemove, "remove", nil, "Label to remove (repeatable or comma separated)")
+}
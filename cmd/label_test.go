@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Labeling PRs", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		pr         cmd.PullRequest
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		pr = cmd.PullRequest{Number: 1, Title: "Update deps", State: "open", Labels: []cmd.Label{{Name: "needs-rebase"}, {Name: "area/ci"}}}
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should add and remove several labels in one call", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels", 200, []cmd.Label{})
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels/needs-rebase", 200, []cmd.Label{})
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels/area%2Fci", 200, []cmd.Label{})
+
+		Expect(cmd.ChangeLabelsTest(mockClient, "owner", "repo", pr, []string{"ok-to-test", "lgtm"}, []string{"needs-rebase", "area/ci"})).To(BeTrue())
+
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/labels")).To(Equal([]string{`{"labels":["ok-to-test","lgtm"]}`}))
+		Expect(mockClient.RequestBodies("DELETE", "repos/owner/repo/issues/1/labels/needs-rebase")).To(HaveLen(1))
+		Expect(mockClient.RequestBodies("DELETE", "repos/owner/repo/issues/1/labels/area%2Fci")).To(HaveLen(1))
+		Expect(out.String()).To(ContainSubstring("🏷️  Added ok-to-test, lgtm to PR #1"))
+		Expect(out.String()).To(ContainSubstring("🏷️  Removed needs-rebase from PR #1"))
+	})
+
+	It("should leave out labels the PR already has or doesn't have", func() {
+		Expect(cmd.ChangeLabelsTest(mockClient, "owner", "repo", pr, []string{"Needs-Rebase"}, []string{"ok-to-test"})).To(BeTrue())
+
+		Expect(mockClient.Requests).To(BeEmpty())
+		Expect(out.String()).To(ContainSubstring("PR #1 already has the Needs-Rebase label"))
+		Expect(out.String()).To(ContainSubstring("PR #1 doesn't have the ok-to-test label"))
+	})
+
+	It("should report labels GitHub refuses to change", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels/needs-rebase", 200, []cmd.Label{})
+
+		Expect(cmd.ChangeLabelsTest(mockClient, "owner", "repo", pr, []string{"ok-to-test"}, []string{"needs-rebase"})).To(BeFalse())
+
+		Expect(out.String()).To(ContainSubstring("❌ Failed to label PR #1"))
+		Expect(out.String()).To(ContainSubstring("🏷️  Removed needs-rebase from PR #1"))
+	})
+
+	It("should label from the approval prompt with L without deciding the PR", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, pr)
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels", 200, []cmd.Label{})
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels/needs-rebase", 200, []cmd.Label{})
+
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("\nL\nok-to-test\nneeds-rebase\nn\n"), out, &bytes.Buffer{}), nil)
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, false)
+
+		Expect(out.String()).To(ContainSubstring("Labels: needs-rebase, area/ci"))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/labels")).To(Equal([]string{`{"labels":["ok-to-test"]}`}))
+		Expect(mockClient.RequestBodies("DELETE", "repos/owner/repo/issues/1/labels/needs-rebase")).To(HaveLen(1))
+		Expect(out.String()).To(ContainSubstring("Skipping PR #1"))
+	})
+})
@@ -1,9 +1,10 @@
 package cmd
 
 import (
-	"bufio"
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"log"
@@ -13,11 +14,14 @@ import (
 	"strconv"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/cli/go-gh/v2/pkg/api"
 	"github.com/cli/go-gh/v2/pkg/repository"
 	"github.com/spf13/cobra"
-	"golang.org/x/term"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
 )
 
 // RootCmd represents the base command when called without any subcommands
@@ -26,93 +30,40 @@ var RootCmd = &cobra.Command{
 	Short: "A CLI tool for GitHub Pull Requests",
 	Long: `A CLI application built with Cobra for managing and working with 
 GitHub Pull Requests. This tool provides various commands to interact 
-with GitHub repositories and pull requests.`,
+with GitHub repositories and pull requests.
+
+Every flag can also be set with an environment variable named GHPRS_ and the flag's name in
+capitals, with dashes as underscores, e.g. GHPRS_STATE=all, GHPRS_LIMIT=50, GHPRS_NO_COLOR=1 or
+GHPRS_CONFIG=/etc/ghprs/team.yaml. A flag given on the command line wins over the environment, which wins
+over the config file, which wins over the defaults.`,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Welcome to ghprs!")
-		fmt.Println("Use 'ghprs --help' to see available commands.")
+		streams.Println("Welcome to ghprs!")
+		streams.Println("Use 'ghprs --help' to see available commands.")
 	},
 }
 
-// PullRequest represents a GitHub pull request
-type PullRequest struct {
-	Number         int     `json:"number"`
-	Title          string  `json:"title"`
-	State          string  `json:"state"`
-	User           User    `json:"user"`
-	Head           Branch  `json:"head"`
-	Base           Branch  `json:"base"`
-	Draft          bool    `json:"draft"`
-	CreatedAt      string  `json:"created_at"`
-	UpdatedAt      string  `json:"updated_at"`
-	HTMLURL        string  `json:"html_url"`
-	Body           string  `json:"body"`
-	MergeableState string  `json:"mergeable_state"`
-	Labels         []Label `json:"labels"`
-}
-
-type User struct {
-	Login string `json:"login"`
-}
-
-type Branch struct {
-	Ref string `json:"ref"`
-	SHA string `json:"sha"`
-}
-
-type Label struct {
-	Name string `json:"name"`
-}
-
-// ReviewRequest represents a pull request review request
-type ReviewRequest struct {
-	Body  string `json:"body"`
-	Event string `json:"event"`
-}
-
-// CommentRequest represents a pull request comment request
-type CommentRequest struct {
-	Body string `json:"body"`
-}
-
-// Review represents a pull request review
-type Review struct {
-	State string `json:"state"`
-	User  User   `json:"user"`
-}
-
-// PRFile represents a file changed in a pull request
-type PRFile struct {
-	Filename string `json:"filename"`
-	Status   string `json:"status"` // "added", "modified", "removed", etc.
-}
+// The GitHub API types are shared with the public Go API
+type (
+	PullRequest       = ghprs.PullRequest
+	User              = ghprs.User
+	Branch            = ghprs.Branch
+	Label             = ghprs.Label
+	ReviewRequest     = ghprs.ReviewRequest
+	CommentRequest    = ghprs.CommentRequest
+	Review            = ghprs.Review
+	PRFile            = ghprs.PRFile
+	CheckRun          = ghprs.CheckRun
+	CheckSuiteRef     = ghprs.CheckSuiteRef
+	CheckApp          = ghprs.CheckApp
+	CheckRunsResponse = ghprs.CheckRunsResponse
+	StatusCheck       = ghprs.StatusCheck
+)
 
 // LabelRequest represents a request to add labels to an issue/PR
 type LabelRequest struct {
 	Labels []string `json:"labels"`
 }
 
-// CheckRun represents a GitHub check run
-type CheckRun struct {
-	Name       string `json:"name"`
-	Status     string `json:"status"`     // "queued", "in_progress", "completed"
-	Conclusion string `json:"conclusion"` // "success", "failure", "neutral", "cancelled", "timed_out", "action_required", "skipped"
-	HTMLURL    string `json:"html_url"`
-}
-
-// CheckRunsResponse represents the response from the check runs API
-type CheckRunsResponse struct {
-	TotalCount int        `json:"total_count"`
-	CheckRuns  []CheckRun `json:"check_runs"`
-}
-
-// StatusCheck represents a GitHub status check (legacy)
-type StatusCheck struct {
-	State       string `json:"state"` // "pending", "success", "error", "failure"
-	Description string `json:"description"`
-	Context     string `json:"context"`
-	TargetURL   string `json:"target_url"`
-}
-
 // CheckStatus represents the combined status of all checks
 type CheckStatus struct {
 	Passed    int
@@ -121,24 +72,19 @@ type CheckStatus struct {
 	Cancelled int
 	Skipped   int
 	Total     int
+	// Stale counts the pending checks that have been pending for longer than the stale threshold
+	Stale int
 }
 
+// The root flags shared by every command
 var (
-	state         string
-	limit         int
-	approve       bool
-	current       bool
-	tektonOnly    bool
-	migrationOnly bool
-	securityOnly  bool
-	targetBranch  string
-	sortBy        string
-	showFiles     bool
-	showDiff      bool
-	noColor       bool
-	fastMode      bool
+	noColor      bool
+	outputFormat = OutputTable
 )
 
+// konfluxBotAuthor is the author of the PRs listed by 'ghprs konflux'
+const konfluxBotAuthor = "red-hat-konflux[bot]"
+
 // listCmd represents the list command
 var listCmd = &cobra.Command{
 	Use:   "list [owner/repo]",
@@ -154,20 +100,53 @@ Examples:
   ghprs list microsoft/vscode
   ghprs list --state closed
   ghprs list --limit 5
+  ghprs list --all                          # Fetch every PR, 100 per page (same as --limit 0)
   ghprs list --current                       # Force use current repo, bypass config
   ghprs list --sort-by oldest               # Show oldest PRs first
   ghprs list --sort-by updated               # Sort by last update
+  ghprs list --since 8h                     # Catch up: PRs with activity in the last 8 hours and what changed
+  ghprs list --new-only                     # Only PRs new or updated since they were last listed (🆕)
   ghprs list --security-only                # Show only security/CVE PRs
+  ghprs list --show-snoozed                 # Also show PRs hidden with 'ghprs snooze'
+  ghprs list --author renovate[bot] --author dependabot[bot]  # Show only PRs by these authors
+  ghprs list --review-requested             # Show only PRs waiting for my review
+  ghprs list --mine                         # Show only my PRs (same as --author @me)
+  ghprs list --assignee me                  # Show only PRs assigned to me
+  ghprs list --needs-my-team                # Show only PRs CODEOWNERS asks me or my teams to approve
+  ghprs list --update-type major            # Show only Renovate/Dependabot PRs with a major version bump
   ghprs list --target-branch main           # Show only PRs targeting main branch
+  ghprs list --combined --sort-by oldest    # One table of every configured repository, oldest first
+  ghprs list --query "org:my-org label:lgtm" # PRs a GitHub search finds, in any repository (see 'ghprs search')
   ghprs list --target-branch release/v1.0   # Show only PRs targeting release/v1.0 branch
   ghprs list --limit 10 --target-branch main # Limit to 10 PRs targeting main (efficient API filtering)
   ghprs list --fast                         # Fast mode: skip expensive API calls for quick display
+  ghprs list --use-graphql                  # Fetch everything in one GraphQL query to save API calls
+  ghprs list --no-cache                     # Bypass the on-disk cache of PR details (see 'ghprs cache')
+  ghprs list --verbose                      # Also show the remaining GitHub API quota
+  ghprs list --no-legend                    # Hide the legend (see 'ghprs config set legend')
+  ghprs list --view readiness               # One readiness status per PR instead of the indicator columns
+  ghprs list --readiness ready              # Show only PRs that are ready to merge
+  ghprs list --interactive                  # Toggle filters (t, m, r, g) after the table without new API calls
+  ghprs list --output json | jq '.repositories[].pullRequests[].number'  # Machine-readable output
+  ghprs list --fail-on blocked,stale:14d    # CI gate: exit with status 2 if an open PR is blocked or idle for 14 days
+  ghprs list --output csv > prs.csv          # For spreadsheets, with the columns of --output plain
+  ghprs list --output markdown               # Markdown tables with linked PRs, for status pages
+  ghprs list | cut -f2,3                     # Piped, a tab-separated table (--output plain, --delimiter to change)
   ghprs list --approve                       # Interactively approve PRs (review + /lgtm comment)
+  ghprs list --approve --artifact ~/ghprs-audit  # Keep a JSON and HTML record of what was shown and decided
   ghprs list --approve --show-files          # Approve with detailed file lists
   ghprs list --approve --show-diff           # Approve with detailed diff display
-  ghprs list --approve                       # Interactive approval (use 'f' to view files, 'd' to view diff, 'c' to view checks)`,
+  ghprs list --approve --show-diff --diff-mode word  # Approve with the changed words shown inline
+  ghprs list --approve                       # Interactive approval (use 'f' to view files, 'd' to view diff, 'c' to view checks, 'v' to view comments)`,
+	ValidArgsFunction: completeRepositoryArgs(false),
 	Run: func(cmd *cobra.Command, args []string) {
-		listPullRequests(args, "", false)
+		ctx := commandContext(cmd)
+		listOpts.prepare(cmd)
+		authors := listOpts.Authors
+		if listOpts.Mine {
+			authors = append(authors, meLogin)
+		}
+		listPullRequests(ctx, listOpts, args, authors, false)
 	},
 }
 
@@ -176,6 +155,12 @@ var konfluxCmd = &cobra.Command{
 	Use:   "konflux [owner/repo]",
 	Short: "List Konflux pull requests (authored by red-hat-konflux[bot])",
 	Long: `List pull requests authored by "red-hat-konflux[bot]" for a GitHub repository.
+Additional bot authors (e.g. renovate[bot]) can be configured per repository with
+'ghprs config add-konflux-repo owner/repo --author renovate[bot]'.
+Repositories mapped to Konflux applications with 'ghprs config set-konflux-component' are
+grouped by application, with the component of each PR in its own column.
+With --auto the rules section of the config decides, for each PR, whether to approve, hold,
+label or skip it; the first rule whose conditions all hold wins and every decision is explained.
 
 If no repository is specified, configured default repositories will be used.
 If no default repositories are configured, the current repository will be detected from git remotes.
@@ -186,6 +171,7 @@ Examples:
   ghprs konflux microsoft/vscode
   ghprs konflux --state closed
   ghprs konflux --limit 5
+  ghprs konflux --all                        # Fetch every Konflux PR (same as --limit 0)
   ghprs konflux --current                    # Force use current repo, bypass config
   ghprs konflux --approve                    # Interactively approve Konflux PRs (review + /lgtm comment)
   ghprs konflux --tekton-only                # Show only PRs that EXCLUSIVELY modify Tekton files
@@ -193,49 +179,127 @@ Examples:
   ghprs konflux --security-only              # Show only security/CVE PRs
   ghprs konflux --target-branch main         # Show only Konflux PRs targeting main branch
   ghprs konflux --target-branch release/v1.0 # Show only Konflux PRs targeting release/v1.0 branch
-  ghprs konflux --limit 5 --tekton-only      # Limit to 5 Tekton-only PRs (local filtering)
+  ghprs konflux --limit 5 --tekton-only      # First 5 Tekton-only PRs (keeps paging until 5 are found)
   ghprs konflux --fast                       # Fast mode: skip expensive API calls for quick display
+  ghprs konflux --view readiness             # One readiness status per PR instead of the indicator columns
+  ghprs konflux --readiness ready,needs-review  # Show only PRs that are ready or only wait for a review
+  ghprs konflux --interactive                # Toggle tekton-only, migration-only, needs-rebase and green-checks live
+  ghprs konflux --use-graphql                # Fetch everything in one GraphQL query to save API calls
+  ghprs konflux --output yaml                # Machine-readable output (see 'ghprs schema pr-list')
+  ghprs konflux --fail-on migration          # CI gate: exit with status 2 while a PR with a migration warning is open
   ghprs konflux --sort-by priority           # Sort by priority (security updates first, then migration warnings)
   ghprs konflux --sort-by oldest             # Show oldest PRs first
+  ghprs konflux --since 1d                   # Konflux PRs with activity in the last day and what changed
+  ghprs konflux --new-only                   # Daily triage: only PRs new or updated since the last run (🆕)
   ghprs konflux --approve --show-files       # Approve with detailed file lists
   ghprs konflux --approve --show-diff        # Approve with detailed diff display
+  ghprs konflux --approve --show-commits     # Approve with the commits and their messages (migration details)
   ghprs konflux --approve --show-diff --no-color  # Approve with diff but no colors
-  ghprs konflux --approve                    # Interactive approval (use 'f' to view files, 'd' to view diff, 'c' to view checks)
-  ghprs konflux owner/repo --approve         # Approve Konflux PRs in specific repo`,
+  ghprs konflux --approve --show-diff --diff-mode split  # Approve with the old and new lines side by side
+  ghprs konflux --approve --show-diff --diff-file '.tekton/*'  # Show only the diff of the Tekton pipelines
+  ghprs konflux --approve                    # Interactive approval (use 'f' to view files, 'd' to view diff, 'c' to view checks, 'v' to view comments)
+  ghprs konflux owner/repo --approve         # Approve Konflux PRs in specific repo
+  ghprs konflux --approve --artifact audit/  # Keep a JSON and HTML record of what was shown and decided
+  ghprs konflux --auto                       # Let the configured rules approve, hold or label PRs
+  ghprs konflux --org my-org                 # Dashboard of the Konflux PRs of every repository of my-org
+  ghprs konflux --org my-org --topic konflux # Only the repositories of my-org with the konflux topic
+  ghprs konflux --group-updates              # Approve the same dependency update in every repository at once
+  ghprs konflux adopt                        # Report Konflux configuration missing from the repositories
+  ghprs konflux migrations                   # List the PRs with migration warnings and their migration notes`,
+	ValidArgsFunction: completeRepositoryArgs(false),
 	Run: func(cmd *cobra.Command, args []string) {
-		listPullRequests(args, "red-hat-konflux[bot]", true)
+		ctx := commandContext(cmd)
+		konfluxOpts.prepare(cmd)
+		listPullRequests(ctx, konfluxOpts, args, []string{konfluxBotAuthor}, true)
 	},
 }
 
 // ApprovalConfig controls the behavior of the approval process
 type ApprovalConfig struct {
 	IsKonflux bool
+	// Review controls the review body and event and the comments posted with an approval
+	Review ApprovalSettings
+	// ImagePinning is the image pinning policy Konflux diffs are checked against
+	ImagePinning string
+	// RetestComments are posted to re-run failed checks of Prow-managed repositories
+	RetestComments []string
+	// TrustedAuthors may change CI and ownership files without the approval asking for the PR number
+	TrustedAuthors []string
+	// TektonBaselines are the expected .tekton files of each repository, by "owner/repo"
+	TektonBaselines map[string][]string
+	// CommentTemplates are the comments offered when commenting, by name
+	CommentTemplates map[string]string
+	// ShowFiles, ShowDiff and ShowCommits show the files, diff and commits of each PR before asking
+	ShowFiles   bool
+	ShowDiff    bool
+	ShowCommits bool
+	// Diff is how diffs are shown and which of their files
+	Diff diffView
+	// ExpandChecks lists the groups of checks that passed in the detailed check view too
+	ExpandChecks bool
+	// SkipRedBase keeps the rules from approving PRs whose target branch fails its required checks
+	SkipRedBase bool
+	// Table are the options the PR table between approvals is built and shown with
+	Table *listOptions
+}
+
+// newApprovalConfig builds the approval behavior of a repository from the config and the flags of opts, such as
+// --approve-body and --no-lgtm; an empty repoSpec uses the global approval settings
+func newApprovalConfig(opts *listOptions, config *Config, repoSpec string, isKonflux bool) ApprovalConfig {
+	review := config.ApprovalFor(repoSpec)
+	if opts.NoLGTM {
+		empty := ""
+		review.Body = &empty
+	}
+	if opts.ApproveBody != "" {
+		body := opts.ApproveBody
+		review.Body = &body
+	}
+	if opts.MaxChanges > 0 {
+		review.MaxChanges = opts.MaxChanges
+	}
+	diff := diffView{Mode: opts.DiffMode, Files: opts.DiffFiles}
+	if diff.Mode == "" {
+		diff.Mode = config.DiffMode()
+	}
+	return ApprovalConfig{
+		IsKonflux:        isKonflux,
+		ShowFiles:        opts.ShowFiles,
+		ShowDiff:         opts.ShowDiff,
+		ShowCommits:      opts.ShowCommits,
+		Diff:             diff,
+		ExpandChecks:     opts.ExpandChecks,
+		SkipRedBase:      opts.SkipRedBase,
+		Table:            opts,
+		Review:           review,
+		ImagePinning:     config.ImagePinningPolicy(),
+		RetestComments:   config.RetestComments(),
+		TrustedAuthors:   config.TrustedAuthors(),
+		TektonBaselines:  config.TektonBaselines(),
+		CommentTemplates: config.Templates,
+	}
 }
 
 // promptForRepositorySelection prompts the user to select a repository from a list
 func promptForRepositorySelection(repositories []string) string {
-	fmt.Printf("\n📂 Multiple repositories configured (%d):\n", len(repositories))
+	streams.Printf("\n📂 Multiple repositories configured (%d):\n", len(repositories))
 	for i, repo := range repositories {
-		fmt.Printf("  %d. %s\n", i+1, repo)
+		streams.Printf("  %d. %s\n", i+1, repo)
 	}
-	fmt.Printf("  %d. All repositories\n", len(repositories)+1)
-	fmt.Printf("  0. Cancel\n")
+	streams.Printf("  %d. All repositories\n", len(repositories)+1)
+	streams.Printf("  0. Cancel\n")
 
 	for {
-		fmt.Printf("\nSelect repository (1-%d, %d for all, 0 to cancel) [default: 1]: ", len(repositories), len(repositories)+1)
-
-		reader := bufio.NewReader(os.Stdin)
-		input, err := reader.ReadString('\n')
+		input, err := prompter.Input(fmt.Sprintf("\nSelect repository (1-%d, %d for all, 0 to cancel) [default: 1]: ", len(repositories), len(repositories)+1))
 		if err != nil {
 			if err == io.EOF {
-				fmt.Printf("\n")
+				streams.Printf("\n")
 				return "" // User cancelled or input ended
 			}
-			fmt.Printf("Error reading input: %v\n", err)
+			streams.Printf("Error reading input: %v\n", err)
 			return "" // Exit on any read error
 		}
 
-		input = strings.TrimSpace(input)
 		if input == "" {
 			// Default to first repository
 			return repositories[0]
@@ -243,7 +307,7 @@ func promptForRepositorySelection(repositories []string) string {
 
 		choice, err := strconv.Atoi(input)
 		if err != nil {
-			fmt.Printf("Invalid input '%s'. Please enter a number.\n", input)
+			streams.Printf("Invalid input '%s'. Please enter a number.\n", input)
 			continue
 		}
 
@@ -254,225 +318,627 @@ func promptForRepositorySelection(repositories []string) string {
 		} else if choice == len(repositories)+1 {
 			return "ALL" // Special value to indicate all repositories
 		} else {
-			fmt.Printf("Invalid choice %d. Please select a number between 0 and %d.\n", choice, len(repositories)+1)
+			streams.Printf("Invalid choice %d. Please select a number between 0 and %d.\n", choice, len(repositories)+1)
 		}
 	}
 }
 
-func listPullRequests(args []string, authorFilter string, isKonflux bool) {
+func listPullRequests(ctx context.Context, opts *listOptions, args []string, authors []string, isKonflux bool) {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		log.Fatal(err)
+	}
+	if err := validateView(opts.View); err != nil {
+		log.Fatal(err)
+	}
+	states, err := parseReadinessFilter(opts.Readiness)
+	if err != nil {
+		log.Fatal(err)
+	}
+	opts.Readiness = states
+	if opts.UpdateTypes, err = parseUpdateTypeFilter(opts.UpdateTypes); err != nil {
+		log.Fatal(err)
+	}
+	plainOutput := outputFormat == OutputPlain
+	if plainOutput {
+		if err := validateDelimiter(opts.Delimiter); err != nil {
+			log.Fatal(err)
+		}
+	}
+	// Plain, csv and markdown output collect the same rows as json/yaml output, only written differently
+	structuredOutput := isStructuredOutput(outputFormat) || isRowOutput(outputFormat)
+	if structuredOutput && opts.Approve {
+		log.Fatal("--approve cannot be combined with --output json|yaml|plain|csv|markdown")
+	}
+	if opts.Combined && opts.Approve {
+		log.Fatal("--approve cannot be combined with --combined")
+	}
+	if opts.Auto && (opts.Approve || structuredOutput || opts.Combined) {
+		log.Fatal("--auto cannot be combined with --approve, --output json|yaml|plain|csv|markdown or --combined")
+	}
+	if opts.SkipRedBase && !opts.Auto {
+		log.Fatal("--skip-red-base only applies to --auto")
+	}
+	if opts.Interactive && (opts.Approve || opts.Auto || structuredOutput || opts.Combined) {
+		log.Fatal("--interactive cannot be combined with --approve, --auto, --output json|yaml|plain|csv|markdown or --combined")
+	}
+	if opts.DiffMode != "" {
+		if err := render.ValidateDiffMode(opts.DiffMode); err != nil {
+			log.Fatal(err)
+		}
+	}
+	if err := validateDiffFileGlobs(opts.DiffFiles); err != nil {
+		log.Fatal(err)
+	}
+	opts.activitySince, err = parseSinceWindow(opts.Since, time.Now())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := opts.parseAgeFilters(time.Now()); err != nil {
+		log.Fatal(err)
+	}
+	if opts.Query != "" && (len(args) > 0 || repoFlag != "" || opts.Current) {
+		log.Fatal("A search finds the repositories itself, it cannot be combined with a repository, --repo or --current")
+	}
+	if opts.Offline && (opts.Approve || opts.Auto || opts.Interactive || opts.Combined || opts.Query != "" || opts.Org != "" || opts.Since != "") {
+		log.Fatal("--offline shows the latest snapshot, it cannot be combined with --approve, --auto, --interactive, --combined, --query, --org or --since")
+	}
+	if opts.GroupUpdates && (opts.Approve || opts.Auto || opts.Interactive || structuredOutput || opts.Combined || opts.Offline || opts.Query != "" || opts.Org != "") {
+		log.Fatal("--group-updates approves updates itself, it cannot be combined with --approve, --auto, --interactive, --output json|yaml|plain|csv|markdown, --combined, --offline, --query or --org")
+	}
+	failOnConditions, err := parseFailOn(opts.FailOn)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if len(failOnConditions) > 0 {
+		if opts.Approve || opts.Auto || opts.Interactive || opts.GroupUpdates || opts.Offline {
+			log.Fatal("--fail-on checks the queue as it is, it cannot be combined with --approve, --auto, --interactive, --group-updates or --offline")
+		}
+		if opts.Fast && failOnNeedsDetails(failOnConditions) {
+			log.Fatal("--fail-on blocked and needs-rebase need the PR details, they cannot be combined with --fast")
+		}
+		// A gate must see every PR, not the first page of them
+		if !opts.limitFromFlag {
+			opts.All = true
+		}
+	}
+	failOn.reset(failOnConditions)
+	if len(opts.Topics) > 0 && opts.Org == "" {
+		log.Fatal("--topic only applies to --org")
+	}
+	if opts.Org != "" {
+		if len(args) > 0 || repoFlag != "" || opts.Current || opts.Query != "" {
+			log.Fatal("--org finds the repositories itself, it cannot be combined with a repository, --repo, --current or --query")
+		}
+		if opts.Approve || opts.Auto || opts.Interactive {
+			log.Fatal("--org shows a dashboard, it cannot be combined with --approve, --auto or --interactive")
+		}
+		// The dashboard is one table across the repositories of the organization
+		opts.Combined = !structuredOutput
+	}
+	if opts.ExplainSort {
+		if opts.SortBy == "" {
+			opts.SortBy = "priority"
+		} else if opts.SortBy != "priority" {
+			log.Fatal("--explain-sort only explains --sort-by priority")
+		}
+	}
+
+	// Exit with status 1 when an action of --auto failed, unless --fail-on exits with its own status first
+	defer exitOnRuleFailures()
+	// Exit with the status of --fail-on once everything else is done, the artifact saved first
+	defer exitOnFailOn()
+	// Save what the run shows and decides with --artifact
+	defer startArtifact(opts.Artifact, os.Args[1:])()
+
 	// Load configuration
 	config, err := LoadConfig()
 	if err != nil {
-		log.Printf("Warning: Could not load config: %v", err)
+		logger.Warn("Could not load config, using defaults", "error", err)
 		config = DefaultConfig()
 	}
 
 	// Use config defaults if no explicit values were set
-	if state == "open" && config.Defaults.State != "open" {
-		state = config.Defaults.State
+	applyConfigDefaults(opts, config)
+	setRepositoryHosts(config)
+	setKonfluxComponents(config)
+	setColumnWidths(config)
+	setPriorityWeights(config)
+	staleCheckAfter = config.StaleCheckAfter()
+	stalePRAfter = config.StalePRAfter()
+	snoozedPRs = loadSnoozesForListing(opts.ShowSnoozed)
+	seenPRs = loadSeenForListing()
+	resetBaseStatuses()
+	resetCodeowners()
+	if opts.Auto {
+		rulesFailed = 0
+		if len(config.Rules) == 0 {
+			log.Fatal("--auto needs rules, add a rules section to the config")
+		}
+		if err := ghprs.ValidateRules(config.Rules); err != nil {
+			log.Fatalf("Invalid rules in the config: %v", err)
+		}
+	}
+
+	// Show the legend once per run unless configured otherwise
+	legendMode := config.LegendMode()
+	if opts.NoLegend {
+		legendMode = render.LegendNever
+	}
+	legend.Reset(legendMode)
+
+	// --group-updates clusters the same update across repositories and approves it everywhere at once
+	if opts.GroupUpdates {
+		runGroupedUpdates(ctx, opts, config, args)
+		return
 	}
-	if limit == 30 && config.Defaults.Limit != 30 {
-		limit = config.Defaults.Limit
+
+	// --offline shows the PRs saved by 'ghprs snapshot' instead of asking GitHub
+	if opts.Offline {
+		if err := displaySnapshot(opts, args, isKonflux); err != nil {
+			log.Fatal(err)
+		}
+		return
 	}
 
-	var repositories []string
+	// Pause before the API quota runs out instead of failing part way through
+	limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
 
-	if len(args) > 0 {
-		// Use specified repository
-		repositories = []string{args[0]}
-	} else if current {
-		// Force use of current repository when --current flag is set
-		if currentRepo, err := repository.Current(); err == nil {
-			repositories = []string{fmt.Sprintf("%s/%s", currentRepo.Owner, currentRepo.Name)}
-		} else {
-			log.Fatal("Could not detect current repository. Make sure you're in a git repository.")
+	// Structured output is meant for scripts and the combined table for the whole queue, so they always
+	// cover every configured repository. A search covers the repositories of the PRs it found and --org every
+	// repository of the organization.
+	var repositories []string
+	var searchHits map[string][]int
+	if opts.Query != "" {
+		repositories, searchHits = runSearch(opts, limiter)
+		if len(repositories) == 0 {
+			streams.Printf("No pull requests found for %q\n", searchQualifiers(opts.Query, opts.State))
+			return
+		}
+	} else if opts.Org != "" {
+		repositories = runOrgDiscovery(opts, limiter)
+		if len(repositories) == 0 {
+			streams.Printf("No repositories found in %s\n", opts.Org)
+			return
 		}
 	} else {
-		// Use configured repositories first, then fall back to auto-detection
-		configRepos := config.GetRepositories(isKonflux)
-		if len(configRepos) > 0 {
-			// If there are multiple repositories, prompt the user to select which repository they want to see
-			if len(configRepos) > 1 {
-				selectedRepo := promptForRepositorySelection(configRepos)
-				if selectedRepo == "" {
-					fmt.Println("No repository selected. Exiting.")
-					return
-				}
-				if selectedRepo == "ALL" {
-					repositories = configRepos
-				} else {
-					repositories = []string{selectedRepo}
-				}
-			} else {
-				repositories = configRepos
-			}
-		} else if currentRepo, err := repository.Current(); err == nil {
-			repositories = []string{fmt.Sprintf("%s/%s", currentRepo.Owner, currentRepo.Name)}
-		} else {
-			if isKonflux {
-				log.Fatal("No repositories specified and no Konflux repositories configured. Please specify owner/repo manually, configure Konflux repositories with 'ghprs config add-konflux-repo owner/repo', or run from a git repository.")
-			} else {
-				log.Fatal("No repositories specified and no default repositories configured. Please specify owner/repo manually, configure default repositories with 'ghprs config add-repo owner/repo', or run from a git repository.")
-			}
+		repositories = resolveRepositories(opts, args, config, isKonflux, !structuredOutput && !opts.Combined)
+		if repositories == nil {
+			streams.Println("No repository selected. Exiting.")
+			return
 		}
 	}
+	// With several repositories, one huge repository mustn't use up the quota of the others
+	repoRequestBudget = repoRequestBudgetFor(config, len(repositories))
+
+	// The Konflux dashboard shows the repositories of each configured application together
+	var headers applicationHeaders
+	if isKonflux {
+		repositories, headers.enabled = groupByApplication(repositories)
+	}
+
+	// Collect rows for json/yaml output, which is written once all repositories are processed
+	output := PRListOutput{
+		SchemaVersion: OutputSchemaVersion,
+		Konflux:       isKonflux,
+		Repositories:  []RepositoryPRs{},
+	}
+	// Rows of every repository for --combined, shown in one table once all repositories are processed
+	var combined []combinedRow
+
+	// Reuse PR details from previous runs while they are fresh
+	var cache *diskCache
+	if !opts.NoCache {
+		cache = newDiskCache(getCacheDir(), config.CacheTTL())
+	}
+
+	// An organization has too many repositories to fetch them one at a time
+	var prefetched map[string]repositoryFetch
+	if opts.Org != "" {
+		prefetched = prefetchRepositoryPRs(ctx, opts, repositories, func(fetchCtx context.Context, owner, repo string) ([]PullRequest, error) {
+			client, err := newAPIClientWithTimeout(hostFor(owner, repo), limiter, cache, opts.RequestTimeout)
+			if err != nil {
+				return nil, err
+			}
+			repoAuthors := queueAuthors(config, owner+"/"+repo, authors, isKonflux)
+			prs, _, err := fetchRepositoryPRs(fetchCtx, opts, withContext(client, fetchCtx), owner, repo, repoAuthors, isKonflux)
+			return prs, err
+		})
+	}
 
 	// Process each repository
-	for i, repoSpec := range repositories {
-		// Parse owner/repo from repository spec
-		parts := strings.Split(repoSpec, "/")
-		if len(parts) != 2 {
-			log.Printf("Invalid repository format '%s', skipping. Must be 'owner/repo'", repoSpec)
+	for _, repoSpec := range repositories {
+		owner, repo, ok := parseRepoSpec(repoSpec)
+		if !ok {
+			logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+			failOn.skip(repoSpec)
 			continue
 		}
-		owner := parts[0]
-		repo := parts[1]
 
 		// Create REST API client
-		client, err := api.DefaultRESTClient()
+		client, err := newAPIClientWithTimeout(hostFor(owner, repo), limiter, cache, opts.RequestTimeout)
 		if err != nil {
-			log.Printf("Failed to create GitHub client for %s: %v", repoSpec, err)
+			logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+			failOn.skip(repoSpec)
 			continue
 		}
 
-		// Prepare API request
-		path := fmt.Sprintf("repos/%s/%s/pulls", owner, repo)
+		// A repository is skipped when it takes longer than --timeout, except while approving, which waits
+		// for the user. The client is bound to its context so every call for it stops.
+		func() {
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			if opts.Approve {
+				repoCtx, cancel = context.WithCancel(ctx)
+			}
+			defer cancel()
+
+			repoAuthors := queueAuthors(config, repoSpec, authors, isKonflux)
+			start := time.Now()
+			var pullRequests []PullRequest
+			if fetched, ok := prefetched[repoSpec]; ok {
+				client = withContext(client, repoCtx)
+				pullRequests, err = fetched.PullRequests, fetched.Err
+			} else if searchHits != nil {
+				client = withContext(client, repoCtx)
+				pullRequests, err = fetchSearchedPRs(repoCtx, opts, client, owner, repo, searchHits[repoSpec], repoAuthors, isKonflux)
+			} else {
+				pullRequests, client, err = fetchRepositoryPRs(repoCtx, opts, withContext(client, repoCtx), owner, repo, repoAuthors, isKonflux)
+			}
+			if reason := describeCancellation(repoCtx.Err()); reason != "" {
+				logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+				failOn.skip(repoSpec)
+				return
+			}
+			if err != nil {
+				logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+				failOn.skip(repoSpec)
+				return
+			}
+			logger.Info("Fetched pull requests", "repo", repoSpec, "count", len(pullRequests), "duration", time.Since(start).Round(time.Millisecond))
+			// Remember the PRs for shell completion
+			recordPRs(owner+"/"+repo, pullRequests, searchHits == nil && fetchedEveryOpenPR(opts, repoAuthors, isKonflux, len(pullRequests)))
+
+			// Sort PRs based on the specified sort option, scoring them when sorting by priority
+			var priorityScores map[int]PriorityScore
+			if repoSort := sortFor(opts, owner, repo); repoSort == "priority" {
+				priorityScores = sortPullRequestsWithContext(repoCtx, opts, pullRequests, client, owner, repo, isKonflux)
+			} else if repoSort != "" {
+				sortPullRequests(pullRequests, repoSort)
+			}
 
-		// Add query parameters
-		params := []string{}
-		if state != "" {
-			params = append(params, "state="+state)
-		}
+			// The details --fail-on fetches are reused for the rows
+			details := NewPRDetailsCache()
+			if failOn.active() {
+				failOn.check(repoCtx, client, owner, repo, pullRequests, details, opts.Concurrency)
+			}
 
-		// Apply target branch filter directly to API call if specified
-		if targetBranch != "" {
-			params = append(params, "base="+targetBranch)
-		}
+			if structuredOutput {
+				rows := buildPRRows(repoCtx, opts, pullRequests, owner, repo, client, isKonflux, details)
+				withPriorityScores(rows, priorityScores)
+				repoOutput := RepositoryPRs{Repository: repoSpec, PullRequests: rows}
+				if isKonflux {
+					repoOutput.Application = applicationFor(repoSpec)
+				}
+				output.Repositories = append(output.Repositories, repoOutput)
+				return
+			}
+			// Whatever is shown from here on is seen, so the next run only marks what changed since
+			defer seenPRs.markSeen(repoSpec, pullRequests)
+			if opts.Combined {
+				rows := buildPRRows(repoCtx, opts, pullRequests, owner, repo, client, isKonflux, details)
+				withPriorityScores(rows, priorityScores)
+				combined = append(combined, newCombinedRows(repoSpec, pullRequests, rows)...)
+				return
+			}
+			headers.print(repoSpec)
 
-		// Check if we have filters that require local filtering (can't be done via API)
-		hasLocalFilters := securityOnly || migrationOnly || tektonOnly
+			// Check if any PRs matched
+			if len(pullRequests) == 0 {
+				var filterMsg string
+				if !isKonflux && len(repoAuthors) > 0 {
+					filterMsg = fmt.Sprintf(" by %s", strings.Join(repoAuthors, ", "))
+				}
+				if opts.TargetBranch != "" {
+					filterMsg += fmt.Sprintf(" targeting branch '%s'", opts.TargetBranch)
+				}
+				if opts.SecurityOnly {
+					filterMsg += " with security updates"
+				}
+				if opts.MigrationOnly {
+					filterMsg += " with migration warnings"
+				}
+				if opts.TektonOnly {
+					filterMsg += " with Tekton-only changes"
+				}
+				if len(opts.Readiness) > 0 {
+					filterMsg += fmt.Sprintf(" with readiness %s", strings.Join(opts.Readiness, "/"))
+				}
+				if opts.Since != "" {
+					filterMsg += fmt.Sprintf(" updated in the last %s", opts.Since)
+				}
+				if opts.OlderThan != "" {
+					filterMsg += fmt.Sprintf(" older than %s", opts.OlderThan)
+				}
+				if opts.UpdatedWithin != "" {
+					filterMsg += fmt.Sprintf(" updated within %s", opts.UpdatedWithin)
+				}
+				if opts.NewOnly {
+					filterMsg += " new or updated since last listed"
+				}
+				if opts.NeedsMyTeam {
+					filterMsg += " needing your or your team's approval"
+				}
+				if len(opts.UpdateTypes) > 0 {
+					filterMsg += fmt.Sprintf(" with %s dependency updates", strings.Join(opts.UpdateTypes, "/"))
+				}
 
-		// If we have local filters, fetch more PRs to avoid missing results after filtering
-		// Otherwise, use the normal limit
-		if hasLocalFilters && limit > 0 {
-			// Fetch more PRs when local filtering to avoid missing results
-			fetchLimit := limit * 3 // Fetch 3x more to account for filtering
-			if fetchLimit > 100 {
-				fetchLimit = 100 // GitHub API max per page
+				if isKonflux {
+					streams.Printf("\nNo Konflux pull requests found for %s%s\n", repoSpec, filterMsg)
+				} else {
+					streams.Printf("\nNo %s pull requests found for %s%s\n", stateFor(opts, owner, repo), repoSpec, filterMsg)
+				}
+				return
 			}
-			params = append(params, "per_page="+strconv.Itoa(fetchLimit))
-		} else if limit > 0 {
-			params = append(params, "per_page="+strconv.Itoa(limit))
-		}
 
-		if len(params) > 0 {
-			path += "?" + strings.Join(params, "&")
+			/*
+				// Single repository - show full header
+				if isKonflux {
+					streams.Printf("\n=== %s: Konflux PRs ===\n\n", repoSpec)
+				} else {
+					streams.Printf("\n=== %s: PRs ===\n\n", repoSpec)
+				}
+			*/
+
+			// Let the configured rules decide what to do with each PR
+			if opts.Auto {
+				rulesFailed += applyRules(repoCtx, client, owner, repo, pullRequests, config.Rules, newApprovalConfig(opts, config, repoSpec, isKonflux))
+				return
+			}
+
+			// Handle approval if requested
+			if opts.Approve {
+				// Start approval flow with filtered PRs - table will be displayed there
+				approvePRsWithConfig(repoCtx, client, owner, repo, pullRequests, newApprovalConfig(opts, config, repoSpec, isKonflux), nil)
+				return
+			}
+
+			// Display PR list in table format, then let the filters be toggled with --interactive
+			if opts.Interactive {
+				rows := buildPRRows(repoCtx, opts, pullRequests, owner, repo, client, isKonflux, nil)
+				renderPRTable(opts, rows, owner, repo, isKonflux, legend.Take())
+				browseTable(opts, rows, owner, repo, isKonflux)
+			} else {
+				_ = displayPRTable(repoCtx, opts, pullRequests, owner, repo, client, isKonflux, legend.Take(), details)
+			}
+			if opts.ExplainSort {
+				explainPrioritySort(pullRequests, priorityScores, owner, repo)
+			}
+			if !opts.activitySince.IsZero() {
+				reportActivity(repoCtx, client, owner, repo, pullRequests, opts.activitySince, opts.Concurrency)
+			}
+		}()
+		if ctx.Err() != nil {
+			// Interrupted, the remaining repositories are skipped
+			break
 		}
+	}
 
-		// Make API request
-		var allPullRequests []PullRequest
-		err = client.Get(path, &allPullRequests)
-		if err != nil {
-			log.Printf("Failed to fetch pull requests for %s: %v", repoSpec, err)
-			continue
+	if opts.Combined && !structuredOutput {
+		displayCombinedTable(opts, combined, isKonflux)
+		if opts.Org != "" {
+			displayOrgSummary(opts.Org, combined, repositories, time.Now())
 		}
+	}
 
-		// Filter by author if specified
-		var pullRequests []PullRequest
-		if authorFilter != "" {
-			for _, pr := range allPullRequests {
-				if pr.User.Login == authorFilter {
-					pullRequests = append(pullRequests, pr)
-				}
-			}
-		} else {
-			pullRequests = allPullRequests
+	if structuredOutput {
+		writePRListOutput(opts, output)
+	}
+
+	if hidden := snoozedPRs.hiddenCount(); hidden > 0 && !structuredOutput {
+		streams.Printf("\n💤 %d snoozed PR(s) hidden, use --show-snoozed to show them\n", hidden)
+	}
+	if err := snoozedPRs.save(); err != nil {
+		logger.Warn("Could not save snoozed pull requests", "error", err)
+	}
+	if err := seenPRs.save(); err != nil {
+		logger.Warn("Could not save the pull requests seen", "error", err)
+	}
+
+	if quota := limiter.summary(); quota != "" {
+		logger.Info(quota)
+	}
+}
+
+// writePRListOutput writes the collected rows in the --output format: plain, csv, markdown, json or yaml, with
+// the --delimiter of opts
+func writePRListOutput(opts *listOptions, output PRListOutput) {
+	switch outputFormat {
+	case OutputPlain:
+		if err := writePlainOutput(streams.Out, output, opts.Delimiter); err != nil {
+			log.Fatalf("Failed to write plain output: %v", err)
+		}
+	case OutputCSV:
+		if err := writeCSVOutput(streams.Out, output); err != nil {
+			log.Fatalf("Failed to write csv output: %v", err)
+		}
+	case OutputMarkdown:
+		if err := writeMarkdownOutput(streams.Out, output, opts.Fast); err != nil {
+			log.Fatalf("Failed to write markdown output: %v", err)
 		}
+	default:
+		if err := writeStructuredOutput(streams.Out, output, outputFormat); err != nil {
+			log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+		}
+	}
+}
 
-		// Sort PRs based on the specified sort option
-		if sortBy != "" {
-			sortPullRequests(pullRequests, sortBy)
+// applyConfigDefaults applies the configured state and limit to opts unless they were set on the command line,
+// and remembers the settings each repository overrides
+func applyConfigDefaults(opts *listOptions, config *Config) {
+	if !opts.stateFromFlag && config.Defaults.State != "" {
+		opts.State = config.Defaults.State
+	}
+	if !opts.limitFromFlag && config.Defaults.Limit > 0 {
+		opts.Limit = config.Defaults.Limit
+	}
+	if opts.All {
+		opts.Limit = 0
+	}
+	setRepositorySettings(config)
+}
 
-			// For Konflux PRs with priority sorting, do a more comprehensive sort
-			if isKonflux && sortBy == "priority" {
-				sortPullRequestsWithContext(pullRequests, client, owner, repo, sortBy)
-			}
+// resolveRepositories picks the repositories to work on from the arguments, --current, the config or the git remote.
+// When several repositories are configured and allowPrompt is set, the user chooses; nil means they cancelled.
+func resolveRepositories(opts *listOptions, args []string, config *Config, isKonflux, allowPrompt bool) []string {
+	if len(args) > 0 {
+		// Use specified repository
+		return []string{args[0]}
+	}
+	if repoFlag != "" {
+		return []string{repoFlag}
+	}
+
+	if opts.Current {
+		// Force use of current repository when --current flag is set
+		currentRepo, err := repository.Current()
+		if err != nil {
+			log.Fatal("Could not detect current repository. Make sure you're in a git repository.")
 		}
+		return []string{fmt.Sprintf("%s/%s", currentRepo.Owner, currentRepo.Name)}
+	}
 
-		// Display results
-		if len(pullRequests) == 0 {
-			if isKonflux {
-				fmt.Printf("\nNo Konflux pull requests found for %s\n", repoSpec)
-			} else {
-				fmt.Printf("\nNo %s pull requests found for %s\n", state, repoSpec)
+	// Use configured repositories first, then fall back to auto-detection
+	configRepos := config.GetRepositories(isKonflux)
+	if len(configRepos) > 0 {
+		// If there are multiple repositories, prompt the user to select which repository they want to see
+		if len(configRepos) > 1 && allowPrompt {
+			selectedRepo := promptForRepositorySelection(configRepos)
+			switch selectedRepo {
+			case "":
+				return nil
+			case "ALL":
+				return configRepos
+			default:
+				return []string{selectedRepo}
 			}
-			continue
 		}
+		return configRepos
+	}
 
-		// Apply filtering to PRs
-		filteredPRs := filterPRs(pullRequests, client, owner, repo, isKonflux)
+	if currentRepo, err := repository.Current(); err == nil {
+		return []string{fmt.Sprintf("%s/%s", currentRepo.Owner, currentRepo.Name)}
+	}
 
-		// Apply user's limit after filtering (only if we fetched extra for local filtering)
-		if hasLocalFilters && limit > 0 && len(filteredPRs) > limit {
-			filteredPRs = filteredPRs[:limit]
-		}
+	if isKonflux {
+		log.Fatal("No repositories specified and no Konflux repositories configured. Please specify owner/repo manually, configure Konflux repositories with 'ghprs config add-konflux-repo owner/repo', or run from a git repository.")
+	}
+	log.Fatal("No repositories specified and no default repositories configured. Please specify owner/repo manually, configure default repositories with 'ghprs config add-repo owner/repo', or run from a git repository.")
+	return nil
+}
 
-		// Check if filtering resulted in no PRs
-		if len(filteredPRs) == 0 {
-			var filterMsg string
-			if targetBranch != "" {
-				filterMsg = fmt.Sprintf(" targeting branch '%s'", targetBranch)
-			}
-			if securityOnly {
-				filterMsg += " with security updates"
-			}
-			if migrationOnly {
-				filterMsg += " with migration warnings"
-			}
-			if tektonOnly {
-				filterMsg += " with Tekton-only changes"
-			}
+// parseRepoSpec splits an "owner/repo" repository spec
+func parseRepoSpec(repoSpec string) (string, string, bool) {
+	parts := strings.Split(repoSpec, "/")
+	if len(parts) != 2 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
 
-			if isKonflux {
-				fmt.Printf("\nNo Konflux pull requests found for %s%s\n", repoSpec, filterMsg)
-			} else {
-				fmt.Printf("\nNo %s pull requests found for %s%s\n", state, repoSpec, filterMsg)
+// newAPIClient creates a REST client for host that waits for the rate limit, decodes tolerantly, times out requests,
+// retries transient failures and, unless cache is nil, reuses PR data from the disk cache
+func newAPIClient(host string, limiter *rateLimiter, cache *diskCache) (RESTClientInterface, error) {
+	return newAPIClientWithTimeout(host, limiter, cache, defaultRequestTimeout)
+}
+
+// newAPIClientWithTimeout is newAPIClient with the --request-timeout of a command, 0 for none
+func newAPIClientWithTimeout(host string, limiter *rateLimiter, cache *diskCache, requestTimeout time.Duration) (RESTClientInterface, error) {
+	opts, err := clientOptions(host)
+	if err != nil {
+		return nil, err
+	}
+	restClient, err := api.NewRESTClient(opts)
+	if err != nil {
+		return nil, err
+	}
+	client := withRequestTimeout(withTolerantDecoding(withRateLimitObserver(withIdentityNotice(withAPILogging(restClient), host), limiter)), requestTimeout)
+	client = withRateLimit(withRetries(client, retryCount()), limiter)
+	return withDiskCache(client, cache, host), nil
+}
+
+// fetchRepositoryPRs fetches the PRs of a repository that pass the author and local filters,
+// preferring a single GraphQL query when --use-graphql is set. It returns the client to use for
+// follow-up calls, which serves anything the GraphQL query already fetched.
+func fetchRepositoryPRs(ctx context.Context, opts *listOptions, client RESTClientInterface, owner, repo string, authors []string, isKonflux bool) ([]PullRequest, RESTClientInterface, error) {
+	authors, people, err := resolvePeople(opts, client, owner, repo, authors)
+	if err != nil {
+		return nil, client, err
+	}
+
+	// Apply the author, people and local filters page by page, so the limit counts matching PRs
+	// and paging continues until enough of them are found
+	filter := people.wrap(newPRFilter(ctx, opts, owner, repo, authors, isKonflux))
+
+	// The GraphQL query lists PRs by creation, so --since, which stops at the first PR updated before the
+	// window, always uses REST
+	if !opts.activitySince.IsZero() {
+		pullRequests, err := fetchUpdatedPullRequestsREST(client, owner, repo, stateFor(opts, owner, repo), opts.TargetBranch, opts.activitySince, limitFor(opts, owner, repo), filter)
+		return pullRequests, client, err
+	}
+
+	if opts.UseGraphQL {
+		clientOpts, err := clientOptions(hostFor(owner, repo))
+		var gqlClient *api.GraphQLClient
+		if err == nil {
+			gqlClient, err = api.NewGraphQLClient(clientOpts)
+		}
+		if err == nil {
+			var pullRequests []PullRequest
+			var prefetchedClient RESTClientInterface
+			pullRequests, prefetchedClient, err = fetchPullRequestsGraphQL(ctx, gqlClient, client, owner, repo, stateFor(opts, owner, repo), opts.TargetBranch, limitFor(opts, owner, repo), filter)
+			if err == nil {
+				return pullRequests, prefetchedClient, nil
 			}
-			continue
 		}
+		logger.Warn("GraphQL fetch failed, falling back to REST", "repo", owner+"/"+repo, "error", err)
+	}
 
-		/*
-			// Single repository - show full header
-			if isKonflux {
-				fmt.Printf("\n=== %s: Konflux PRs ===\n\n", repoSpec)
-			} else {
-				fmt.Printf("\n=== %s: PRs ===\n\n", repoSpec)
-			}
-		*/
+	pullRequests, err := fetchPullRequestsREST(client, owner, repo, stateFor(opts, owner, repo), opts.TargetBranch, limitFor(opts, owner, repo), filter)
+	return pullRequests, client, err
+}
 
-		// Handle approval if requested
-		if approve {
-			config := ApprovalConfig{
-				IsKonflux: false,
-			}
+// queueAuthors returns the authors whose PRs are listed for a repository: the given authors and, for the
+// Konflux queue, the additional bot authors configured for the repository. No authors means every author.
+func queueAuthors(config *Config, repoSpec string, authors []string, isKonflux bool) []string {
+	if !isKonflux {
+		if len(authors) == 0 {
+			return config.AuthorFilterFor(repoSpec)
+		}
+		return authors
+	}
+	return append(append([]string{}, authors...), config.AuthorsFor(repoSpec)...)
+}
 
-			if isKonflux {
-				config = ApprovalConfig{
-					IsKonflux: true,
+// newPRFilter builds the page filter for the authors and the local filter flags of opts
+func newPRFilter(ctx context.Context, opts *listOptions, owner, repo string, authors []string, isKonflux bool) prFilter {
+	return func(client RESTClientInterface, page []PullRequest) []PullRequest {
+		if len(authors) > 0 {
+			var byAuthor []PullRequest
+			for _, pr := range page {
+				for _, author := range authors {
+					if strings.EqualFold(pr.User.Login, author) {
+						byAuthor = append(byAuthor, pr)
+						break
+					}
 				}
 			}
-
-			// Start approval flow with filtered PRs - table will be displayed there
-			approvePRsWithConfig(client, owner, repo, filteredPRs, config, nil)
-			continue
+			page = byAuthor
 		}
-
-		// Display PR list in table format
-		if i == 0 {
-			_ = displayPRTable(filteredPRs, owner, repo, client, isKonflux, true, nil)
-		} else {
-			_ = displayPRTable(filteredPRs, owner, repo, client, isKonflux, false, nil)
+		page = snoozedPRs.hide(owner+"/"+repo, page, time.Now())
+		if opts.NewOnly {
+			page = seenPRs.onlyNew(owner+"/"+repo, page)
 		}
+		page = filterPRs(ctx, opts, page, client, owner, repo, isKonflux)
+		return filterPRsByReadiness(ctx, opts, page, client, owner, repo, isKonflux, opts.Readiness)
 	}
 }
 
@@ -486,14 +952,25 @@ const (
 	ApprovalResultHold
 	ApprovalResultQuit
 	ApprovalResultComment
+	ApprovalResultRebase
+	ApprovalResultClose
+	ApprovalResultUnhold
+	ApprovalResultRequestChanges
+	ApprovalResultCommentReview
+	// The approval variants chosen at the prompt, which override the configured review event; the approval flow
+	// reports them as ApprovalResultApprove once posted
+	ApprovalResultApproveReview
+	ApprovalResultApproveCommentReview
+	ApprovalResultApproveComment
 )
 
 // promptForApprovalWithCache prompts the user to approve a specific PR with configurable behavior and optional cache
-func promptForApprovalWithCache(pr PullRequest, owner, repo string, client RESTClientInterface, config ApprovalConfig, cache *PRDetailsCache) ApprovalResult {
-	fmt.Printf("\n🔍 Review PR %s:\n", formatPRLink(owner, repo, pr.Number))
-	fmt.Printf("   Title: %s\n", pr.Title)
-	fmt.Printf("   Author: @%s\n", pr.User.Login)
-	fmt.Printf("   Branch: %s → %s\n", pr.Head.Ref, pr.Base.Ref)
+func promptForApprovalWithCache(ctx context.Context, pr PullRequest, owner, repo string, client RESTClientInterface, config ApprovalConfig, cache *PRDetailsCache) ApprovalResult {
+	streams.Printf("\n🔍 Review PR %s:\n", formatPRLink(owner, repo, pr.Number))
+	streams.Printf("   Title: %s\n", pr.Title)
+	streams.Printf("   Author: @%s\n", pr.User.Login)
+	streams.Printf("   Branch: %s → %s\n", pr.Head.Ref, pr.Base.Ref)
+	warnIfBaseRed(client, owner, repo, pr)
 
 	// Use provided cache or create a new one for PR details to avoid duplicate API calls
 	if cache == nil {
@@ -502,83 +979,119 @@ func promptForApprovalWithCache(pr PullRequest, owner, repo string, client RESTC
 
 	// Show rebase status - fetch full details if needed
 	if needsRebase, hasState := needsRebaseWithCache(cache, client, owner, repo, pr); hasState && needsRebase {
-		fmt.Printf("   🔄 Rebase needed: PR is behind the target branch or has conflicts\n")
+		streams.Printf("   🔄 Rebase needed: PR is behind the target branch or has conflicts\n")
 	}
 	// Only show if there's an issue, otherwise it's assumed to be up to date
 
 	// Show blocked status - fetch full details if needed
 	if isBlocked, hasState := isBlockedWithCache(cache, client, owner, repo, pr); hasState && isBlocked {
-		fmt.Printf("   🚫 Blocked: PR is blocked from merging (failed checks, missing reviews, etc.)\n")
+		streams.Printf("   🚫 Blocked: %s\n", explainBlocked(client, owner, repo, pr))
 	}
 	// Only show if blocked, otherwise it's assumed to be ready for merge
 
 	// Get file count (and optionally display files if --show-files is used)
 	filesPath := fmt.Sprintf("repos/%s/%s/pulls/%d/files", owner, repo, pr.Number)
 	var allFiles []PRFile
+	var structuralChanges []tektonChange
 	err := client.Get(filesPath, &allFiles)
 	if err != nil {
-		fmt.Printf("   ⚠️  Could not fetch file list: %v\n", err)
+		streams.Printf("   ⚠️  Could not fetch file list: %v\n", err)
 	} else {
-		if showFiles {
-			fmt.Printf("   📁 Files changed (%d):\n", len(allFiles))
+		if config.ShowFiles {
+			streams.Printf("   📁 Files changed (%d):\n", len(allFiles))
 			displayFileList(allFiles)
 		} else {
-			fmt.Printf("   📁 Files changed: %d (press 'f' during approval to view)\n", len(allFiles))
+			streams.Printf("   📁 Files changed: %d (press 'f' during approval to view)\n", len(allFiles))
+		}
+		if sensitive := untrustedSensitiveFiles(pr, allFiles, config.TrustedAuthors); len(sensitive) > 0 {
+			displaySensitiveFiles(pr, sensitive)
+		}
+		if owners, err := codeOwnersOf(ctx, client, owner, repo, pr); err == nil && len(owners) > 0 {
+			streams.Printf("   👥 Code owners: %s (press 'a' to request their review)\n", strings.Join(owners, " "))
+		}
+		structuralChanges = tektonStructuralChanges(allFiles, config.TektonBaselines[owner+"/"+repo])
+	}
+
+	// Optionally display the commits if --show-commits is used
+	if config.ShowCommits {
+		if err := displayCommits(ctx, client, owner, repo, pr.Number); err != nil {
+			streams.Printf("   ⚠️  Could not fetch commits: %v\n", err)
 		}
 	}
 
 	// Display check status
 	if pr.Head.SHA != "" {
-		displayCheckStatus(client, owner, repo, pr.Number, pr.Head.SHA)
+		displayCheckStatus(ctx, client, owner, repo, pr.Number, pr.Head.SHA)
 	}
 
 	// Optionally display diff if --show-diff is used
-	if showDiff {
-		err := displayDiff(owner, repo, pr.Number)
+	if config.ShowDiff {
+		err := displayDiff(ctx, owner, repo, pr.Number, config.Diff)
 		if err != nil {
-			fmt.Printf("   ⚠️  Could not fetch diff: %v\n", err)
+			streams.Printf("   ⚠️  Could not fetch diff: %v\n", err)
 		}
 	}
 
 	// Konflux-specific checks
 	// Check for Tekton files
-	onlyTektonFiles, tektonFiles, err := checkTektonFilesDetailed(client, owner, repo, pr.Number)
+	onlyTektonFiles, tektonFiles, err := checkTektonFilesDetailed(ctx, client, owner, repo, pr.Number)
 	if err != nil {
-		fmt.Printf("   ⚠️  Could not check Tekton files: %v\n", err)
+		streams.Printf("   ⚠️  Could not check Tekton files: %v\n", err)
 	} else if onlyTektonFiles {
-		fmt.Printf("   ✅ ONLY modifies Tekton files: %s\n", strings.Join(tektonFiles, ", "))
+		streams.Printf("   ✅ ONLY modifies Tekton files: %s\n", strings.Join(tektonFiles, ", "))
 	} else {
-		fmt.Printf("   ❌ Does NOT exclusively modify target Tekton files\n")
+		streams.Printf("   ❌ Does NOT exclusively modify target Tekton files\n")
 	}
+	displayTektonStructuralChanges(structuralChanges)
 
 	// Check for migration warnings
 	if hasMigrationWarning(pr) {
-		fmt.Printf("   🚨 MIGRATION WARNING: This PR contains migration notes - review carefully!\n")
+		streams.Printf("   🚨 MIGRATION WARNING: This PR contains migration notes - review carefully!\n")
+		writeMigrationNotes(streams.Out, pr, maxPromptMigrationLines)
+	}
+
+	// Check for images whose digest pinning changed against the policy
+	if config.IsKonflux {
+		pinningChanges, err := fetchImagePinningChanges(client, owner, repo, pr.Number, config.ImagePinning)
+		if err != nil {
+			streams.Printf("   ⚠️  Could not check image pinning: %v\n", err)
+		} else {
+			displayPinningChanges(pinningChanges)
+		}
 	}
 
 	// Show hold status if applicable
 	if isOnHold(pr) {
-		fmt.Printf("   ⚠️  Status: ON HOLD (has 'do-not-merge/hold' label)\n")
+		streams.Printf("   ⚠️  Status: ON HOLD (has 'do-not-merge/hold' label)\n")
 	}
 
 	for {
 		// Build prompt based on what's already shown
-		promptOptions := []string{"y/N/q/h/m"}
-		promptHelp := []string{"h=hold", "m=comment"}
+		promptOptions := []string{"y/y!/yc/yr/N/q/h/m/r/x/v/+/o/a/L/rc/cr"}
+		promptHelp := []string{"y!=approve review", "yc=approve as comment review", "yr=approve as plain comment", "h=hold", "m=comment", "r=rebase", "x=close", "v=view conversation", "+=react", "o=open in browser",
+			"a=assign or request review", "L=labels", "rc=request changes", "cr=comment review"}
+		if isOnHold(pr) {
+			promptOptions = append(promptOptions, "u")
+			promptHelp = append(promptHelp, "u=unhold")
+		}
 
-		if !showFiles {
+		if !config.ShowFiles {
 			promptOptions = append(promptOptions, "f")
 			promptHelp = append(promptHelp, "f=show files")
 		}
-		if !showDiff {
+		if !config.ShowDiff {
 			promptOptions = append(promptOptions, "d")
 			promptHelp = append(promptHelp, "d=show diff")
 		}
+		if !config.ShowCommits {
+			promptOptions = append(promptOptions, "l")
+			promptHelp = append(promptHelp, "l=show commit log")
+		}
 
-		// Always show check option if we have a head SHA
+		// Always show check options if we have a head SHA
 		if pr.Head.SHA != "" {
-			promptOptions = append(promptOptions, "c")
-			promptHelp = append(promptHelp, "c=show checks")
+			promptOptions = append(promptOptions, "c", "t")
+			promptHelp = append(promptHelp, "c=show checks (and re-run failed ones)", "t=re-trigger stale checks")
 		}
 
 		promptStr := fmt.Sprintf("\nApprove this PR? [%s]", strings.Join(promptOptions, "/"))
@@ -587,124 +1100,296 @@ func promptForApprovalWithCache(pr PullRequest, owner, repo string, client RESTC
 		}
 		promptStr += ": "
 
-		fmt.Print(promptStr)
-
-		reader := bufio.NewReader(os.Stdin)
-		response, err := reader.ReadString('\n')
+		response, err := prompter.Input(promptStr)
 		if err != nil {
 			// Handle EOF gracefully (e.g., when input is piped and runs out)
 			if err == io.EOF {
-				fmt.Printf("(EOF - exiting approval process)\n")
-				os.Exit(0)
+				streams.Printf("(EOF - exiting approval process)\n")
+				return ApprovalResultQuit
 			}
-			fmt.Printf("Error reading input: %v (skipping PR)\n", err)
+			streams.Printf("Error reading input: %v (skipping PR)\n", err)
 			return ApprovalResultSkip
 		}
 
-		response = strings.TrimSpace(strings.ToLower(response))
+		// 'L' is told apart from 'l', the commit log, before the answer is lowercased
+		if response == "L" {
+			response = "label"
+		}
+		response = strings.ToLower(response)
 
 		switch response {
 		case "y", "yes":
 			return ApprovalResultApprove
+		case "y!":
+			return ApprovalResultApproveReview
+		case "yc":
+			return ApprovalResultApproveCommentReview
+		case "yr":
+			return ApprovalResultApproveComment
 		case "q", "quit":
-			fmt.Println("Quitting approval process.")
+			streams.Println("Quitting approval process.")
 			return ApprovalResultQuit
 		case "h", "hold":
 			// Prompt for additional comment
-			fmt.Printf("Enter an optional comment to add with /hold (or press Enter for none): ")
-			reader := bufio.NewReader(os.Stdin)
-			additionalComment, err := reader.ReadString('\n')
+			additionalComment, err := promptMessage("Enter an optional comment to add with /hold ('e' to use your editor, Enter for none): ")
 			if err != nil {
-				fmt.Printf("Error reading comment: %v\n", err)
+				streams.Printf("Error reading comment: %v\n", err)
 				additionalComment = ""
 			}
-			additionalComment = strings.TrimSpace(additionalComment)
+
+			if !confirmPRUnchanged(client, owner, repo, pr, "hold") {
+				return ApprovalResultSkip
+			}
 
 			// Hold the PR
-			err = holdPR(client, owner, repo, pr.Number, additionalComment)
+			err = holdPR(client, owner, repo, pr.Number, additionalComment, time.Time{})
 			if err != nil {
-				fmt.Printf("❌ Failed to hold PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				streams.Printf("❌ Failed to hold PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
 				continue // Let user try again
 			}
 
-			fmt.Printf("⏸️  Put PR %s on hold\n", formatPRLink(owner, repo, pr.Number))
+			streams.Printf("⏸️  Put PR %s on hold\n", formatPRLink(owner, repo, pr.Number))
 			return ApprovalResultHold
+		case "u", "unhold":
+			if !isOnHold(pr) {
+				streams.Printf("PR %s is not on hold.\n", formatPRLink(owner, repo, pr.Number))
+				continue
+			}
+
+			additionalComment, err := promptMessage("Enter an optional comment to add with /unhold ('e' to use your editor, Enter for none): ")
+			if err != nil {
+				if err == io.EOF {
+					return ApprovalResultQuit
+				}
+				streams.Printf("Error reading comment: %v\n", err)
+				additionalComment = ""
+			}
+
+			if !confirmPRUnchanged(client, owner, repo, pr, "unhold") {
+				return ApprovalResultSkip
+			}
+
+			if err := unholdPR(client, owner, repo, pr.Number, additionalComment, false); err != nil {
+				streams.Printf("❌ Failed to unhold PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				continue // Let user try again
+			}
+
+			streams.Printf("▶️  Took PR %s off hold\n", formatPRLink(owner, repo, pr.Number))
+			return ApprovalResultUnhold
 		case "m", "comment":
-			// Prompt for comment
-			fmt.Printf("Enter your comment: ")
-			reader := bufio.NewReader(os.Stdin)
-			commentText, err := reader.ReadString('\n')
+			// Prompt for comment, offering the configured templates
+			commentText, err := promptForComment(config.CommentTemplates, pr, owner, repo)
 			if err != nil {
-				fmt.Printf("Error reading comment: %v\n", err)
+				streams.Printf("Error reading comment: %v\n", err)
+				if err == io.EOF {
+					return ApprovalResultQuit
+				}
 				continue // Let user try again
 			}
-			commentText = strings.TrimSpace(commentText)
 
 			if commentText == "" {
-				fmt.Printf("Empty comment, skipping.\n")
+				streams.Printf("Empty comment, skipping.\n")
 				continue // Let user try again
 			}
 
+			if !confirmPRUnchanged(client, owner, repo, pr, "comment on") {
+				return ApprovalResultSkip
+			}
+
 			// Add the comment
 			err = addCommentToPR(client, owner, repo, pr.Number, commentText)
 			if err != nil {
-				fmt.Printf("❌ Failed to add comment to PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				streams.Printf("❌ Failed to add comment to PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
 				continue // Let user try again
 			}
 
-			fmt.Printf("💬 Added comment to PR %s\n", formatPRLink(owner, repo, pr.Number))
+			streams.Printf("💬 Added comment to PR %s\n", formatPRLink(owner, repo, pr.Number))
 			return ApprovalResultComment
+		case "rc", "request-changes", "cr", "comment-review":
+			event, result, prompt := ReviewEventComment, ApprovalResultCommentReview, "Enter your review ('e' to use your editor): "
+			if response == "rc" || response == "request-changes" {
+				event, result, prompt = ReviewEventRequestChanges, ApprovalResultRequestChanges, "Enter the changes you request ('e' to use your editor): "
+			}
+			body, err := promptMessage(prompt)
+			if err != nil {
+				streams.Printf("Error reading review: %v\n", err)
+				if err == io.EOF {
+					return ApprovalResultQuit
+				}
+				continue // Let user try again
+			}
+
+			// GitHub rejects these reviews without a body
+			if body == "" {
+				streams.Printf("Empty review, skipping.\n")
+				continue // Let user try again
+			}
+
+			if !confirmPRUnchanged(client, owner, repo, pr, "review") {
+				return ApprovalResultSkip
+			}
+
+			if _, err := ghprs.PostReview(context.Background(), client, owner, repo, pr, body, event); err != nil {
+				streams.Printf("❌ Failed to post review on PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				continue // Let user try again
+			}
+
+			action := AuditReview
+			if result == ApprovalResultRequestChanges {
+				action = AuditRequestChanges
+			}
+			auditLog.record(client, owner, repo, pr.Number, action, body)
+			if result == ApprovalResultRequestChanges {
+				streams.Printf("🛑 Requested changes on PR %s\n", formatPRLink(owner, repo, pr.Number))
+			} else {
+				streams.Printf("📝 Posted a comment review on PR %s\n", formatPRLink(owner, repo, pr.Number))
+			}
+			return result
+		case "r", "rebase":
+			if !confirmPRUnchanged(client, owner, repo, pr, "rebase") {
+				return ApprovalResultSkip
+			}
+
+			message, err := rebasePR(client, owner, repo, pr)
+			if err != nil {
+				streams.Printf("❌ Failed to rebase PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				continue // Let user try again
+			}
+
+			// The head changes with the rebase, so the PR can't be approved until it is reviewed again
+			streams.Printf("🔄 %s for PR %s\n", message, formatPRLink(owner, repo, pr.Number))
+			return ApprovalResultRebase
+		case "x", "close":
+			closingComment, err := promptMessage("Enter an optional comment to post before closing ('e' to use your editor, Enter for none): ")
+			if err != nil {
+				if err == io.EOF {
+					return ApprovalResultQuit
+				}
+				streams.Printf("Error reading comment: %v\n", err)
+				continue // Let user try again
+			}
+
+			if !confirmPRUnchanged(client, owner, repo, pr, "close") {
+				return ApprovalResultSkip
+			}
+
+			if err := setPRState(client, owner, repo, pr.Number, "closed", closingComment); err != nil {
+				streams.Printf("❌ Failed to close PR %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+				continue // Let user try again
+			}
+
+			streams.Printf("🚪 Closed PR %s\n", formatPRLink(owner, repo, pr.Number))
+			return ApprovalResultClose
 		case "f", "files":
-			if showFiles {
-				fmt.Printf("\n📁 File list already shown above.\n")
+			if config.ShowFiles {
+				streams.Printf("\n📁 File list already shown above.\n")
 			} else {
 				// Show detailed file list
-				fmt.Printf("\n📁 Detailed file list for PR %s:\n", formatPRLink(owner, repo, pr.Number))
+				streams.Printf("\n📁 Detailed file list for PR %s:\n", formatPRLink(owner, repo, pr.Number))
 				filesPath := fmt.Sprintf("repos/%s/%s/pulls/%d/files", owner, repo, pr.Number)
 				var files []PRFile
 				err := client.Get(filesPath, &files)
 				if err != nil {
-					fmt.Printf("   ❌ Could not fetch file list: %v\n", err)
+					streams.Printf("   ❌ Could not fetch file list: %v\n", err)
 				} else {
 					displayFileList(files)
-					fmt.Printf("\nTotal: %d files changed\n", len(files))
+					streams.Printf("\nTotal: %d files changed\n", len(files))
 				}
 			}
 			// Continue the loop to ask again
 			continue
 		case "d", "diff":
-			if showDiff {
-				fmt.Printf("\n📄 Diff already shown above.\n")
+			if config.ShowDiff {
+				streams.Printf("\n📄 Diff already shown above.\n")
 			} else {
 				// Show diff
-				err := displayDiff(owner, repo, pr.Number)
+				err := displayDiff(ctx, owner, repo, pr.Number, config.Diff)
 				if err != nil {
-					fmt.Printf("   ❌ Could not fetch diff: %v\n", err)
+					streams.Printf("   ❌ Could not fetch diff: %v\n", err)
 				}
 			}
 			// Continue the loop to ask again
 			continue
-		case "c", "checks":
-			if pr.Head.SHA != "" {
-				displayDetailedCheckStatus(client, owner, repo, pr.Number, pr.Head.SHA)
+		case "l", "log", "commits":
+			if config.ShowCommits {
+				streams.Printf("\n📜 Commits already shown above.\n")
 			} else {
-				fmt.Printf("   ❌ No commit SHA available for check status\n")
+				streams.Println()
+				if err := displayCommits(ctx, client, owner, repo, pr.Number); err != nil {
+					streams.Printf("   ❌ Could not fetch commits: %v\n", err)
+				}
+			}
+			// Continue the loop to ask again
+			continue
+		case "v", "thread", "comments":
+			if err := showConversation(client, owner, repo, pr.Number); err != nil {
+				streams.Printf("   ❌ Could not fetch the conversation: %v\n", err)
 			}
 			// Continue the loop to ask again
 			continue
+		case "+", "react":
+			if err := promptForReaction(client, owner, repo, pr.Number); err == io.EOF {
+				return ApprovalResultQuit
+			}
+			// Continue the loop to ask again
+			continue
+		case "o", "open":
+			if err := promptForOpen(client, owner, repo, pr); err == io.EOF {
+				return ApprovalResultQuit
+			}
+			// Continue the loop to ask again
+			continue
+		case "a", "assign":
+			if err := promptForAssign(ctx, client, owner, repo, pr); err == io.EOF {
+				return ApprovalResultQuit
+			}
+			// Continue the loop to ask again, routing a PR to its owner doesn't decide it
+			continue
+		case "label":
+			if err := promptForLabels(client, owner, repo, pr); err == io.EOF {
+				return ApprovalResultQuit
+			}
+			// Continue the loop to ask again
+			continue
+		case "c", "checks":
+			if pr.Head.SHA == "" {
+				streams.Printf("   ❌ No commit SHA available for check status\n")
+				continue
+			}
+			var failed int
+			paged(func() { failed = displayDetailedCheckStatus(client, owner, repo, pr, config.ExpandChecks) })
+			if failed > 0 {
+				// Failed checks on Konflux PRs are usually flaky infrastructure, so offer to run them again
+				rerun, err := prompter.Confirm(fmt.Sprintf("Re-run the %d failed check(s)?", failed))
+				if err == nil && rerun && confirmPRUnchanged(client, owner, repo, pr, "re-run checks of") {
+					reportRerunChecks(client, owner, repo, pr, config.RetestComments)
+				}
+			}
+			// Continue the loop to ask again
+			continue
+		case "t", "retrigger":
+			if pr.Head.SHA == "" {
+				streams.Printf("   ❌ No commit SHA available for check status\n")
+				continue
+			}
+			if confirmPRUnchanged(client, owner, repo, pr, "re-trigger checks of") {
+				reportRetriggeredChecks(client, owner, repo, pr)
+			}
+			// Continue the loop to ask again, re-triggered checks take a while to finish
+			continue
 		case "", "n", "no":
-			fmt.Printf("Skipping PR %s\n", formatPRLink(owner, repo, pr.Number))
+			streams.Printf("Skipping PR %s\n", formatPRLink(owner, repo, pr.Number))
 			return ApprovalResultSkip
 		default:
-			fmt.Printf("Invalid option '%s'. Please choose from the available options.\n", response)
+			streams.Printf("Invalid option '%s'. Please choose from the available options.\n", response)
 			// Continue the loop to ask again
 			continue
 		}
 	}
 }
 
-func approvePRsWithConfig(client RESTClientInterface, owner, repo string, pullRequests []PullRequest, config ApprovalConfig, cache *PRDetailsCache) {
-	fmt.Printf("\n🎯 Interactive approval mode for %d PRs\n", len(pullRequests))
+func approvePRsWithConfig(ctx context.Context, client RESTClientInterface, owner, repo string, pullRequests []PullRequest, config ApprovalConfig, cache *PRDetailsCache) {
+	streams.Printf("\n🎯 Interactive approval mode for %d PRs\n", len(pullRequests))
 
 	// Keep track of processed PRs to remove them from subsequent displays
 	processedPRs := make(map[int]bool)
@@ -712,14 +1397,18 @@ func approvePRsWithConfig(client RESTClientInterface, owner, repo string, pullRe
 	skippedCount := 0
 	heldCount := 0
 	commentedCount := 0
-
-	shouldDisplayLegend := true
+	rebasedCount := 0
+	closedCount := 0
+	unheldCount := 0
+	changesRequestedCount := 0
+	reviewedCount := 0
 
 	for {
-		// Filter out PRs that can't be approved (closed, draft, on hold) and already processed
+		// Filter out PRs that can't be approved (closed, draft, on hold) and already processed.
+		// PRs on hold can still be selected, to take them off hold.
 		var approvablePRs []PullRequest
+		var heldPRs []PullRequest
 		var displayPRs []PullRequest
-		var prIndexMap = make(map[int]int) // Maps PR number to index in approvablePRs
 
 		for _, pr := range pullRequests {
 			// Skip already processed PRs
@@ -732,148 +1421,205 @@ func approvePRsWithConfig(client RESTClientInterface, owner, repo string, pullRe
 
 			// Add to approvable list if eligible
 			if pr.State == "open" && !pr.Draft && !isOnHold(pr) {
-				prIndexMap[pr.Number] = len(approvablePRs)
 				approvablePRs = append(approvablePRs, pr)
+			} else if pr.State == "open" && !pr.Draft {
+				heldPRs = append(heldPRs, pr)
 			}
 		}
 
 		// Check if we have any PRs left to display
 		if len(displayPRs) == 0 {
-			fmt.Printf("\n✅ All PRs have been processed!\n")
+			streams.Printf("\n✅ All PRs have been processed!\n")
 			break
 		}
 
 		// Display the PR table (excluding processed PRs)
-		fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-		cache = displayPRTable(displayPRs, owner, repo, client, config.IsKonflux, shouldDisplayLegend, cache)
-		shouldDisplayLegend = false // Only display legend once
-		fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+		streams.Printf("═══════════════════════════════════════════════════════════════\n")
+		cache = displayPRTable(ctx, config.Table, displayPRs, owner, repo, client, config.IsKonflux, legend.Take(), cache)
+		streams.Printf("═══════════════════════════════════════════════════════════════\n")
 
 		// Check if we have any approvable PRs left
-		if len(approvablePRs) == 0 {
-			fmt.Printf("❌ No more PRs available for approval (remaining are closed, draft, or on hold)\n")
+		if len(approvablePRs) == 0 && len(heldPRs) == 0 {
+			streams.Printf("❌ No more PRs available for approval (remaining are closed, draft, or on hold)\n")
 			break
 		}
 
 		// Prompt for PR selection
-		fmt.Printf("\n📝 Select PR to approve:\n")
-		fmt.Printf("   Enter PR number (default: %d for first approvable PR)\n", approvablePRs[0].Number)
-		fmt.Printf("   Or press 'q' to quit\n")
-		fmt.Printf("   Available for approval: ")
+		streams.Printf("\n📝 Select PR to approve:\n")
+		if len(approvablePRs) > 0 {
+			streams.Printf("   Enter PR number (default: %d for first approvable PR)\n", approvablePRs[0].Number)
+		} else {
+			streams.Printf("   Enter PR number\n")
+		}
+		streams.Printf("   Or several: a list such as 12,15,20, a range such as 100-110, or 'all'\n")
+		streams.Printf("   Or press 'q' to quit\n")
 
 		var availableNumbers []string
 		for _, pr := range approvablePRs {
 			availableNumbers = append(availableNumbers, fmt.Sprintf("#%d", pr.Number))
 		}
-		fmt.Printf("%s\n", strings.Join(availableNumbers, ", "))
-
-		fmt.Print("\nPR to approve: ")
+		if len(availableNumbers) > 0 {
+			streams.Printf("   Available for approval: %s\n", strings.Join(availableNumbers, ", "))
+		}
+		var heldNumbers []string
+		for _, pr := range heldPRs {
+			heldNumbers = append(heldNumbers, fmt.Sprintf("#%d", pr.Number))
+		}
+		if len(heldNumbers) > 0 {
+			streams.Printf("   On hold (select to unhold): %s\n", strings.Join(heldNumbers, ", "))
+		}
 
-		reader := bufio.NewReader(os.Stdin)
-		input, err := reader.ReadString('\n')
+		input, err := prompter.Input("\nPR to approve: ")
 		if err != nil {
 			if err == io.EOF {
-				fmt.Printf("(EOF - exiting approval process)\n")
+				streams.Printf("(EOF - exiting approval process)\n")
 				break
 			}
-			fmt.Printf("Error reading input: %v\n", err)
+			streams.Printf("Error reading input: %v\n", err)
 			break
 		}
 
-		input = strings.TrimSpace(input)
-
 		// Handle quit
 		if strings.ToLower(input) == "q" || strings.ToLower(input) == "quit" {
-			fmt.Println("Exiting approval process.")
+			streams.Println("Exiting approval process.")
 			break
 		}
 
-		// Determine which PR to approve
-		var selectedPR *PullRequest
+		// Determine which PRs to approve
+		var selected []PullRequest
 
 		if input == "" {
+			if len(approvablePRs) == 0 {
+				streams.Printf("❌ No PR available for approval, enter the number of a PR on hold or 'q' to quit.\n")
+				continue
+			}
 			// Default to first approvable PR
-			selectedPR = &approvablePRs[0]
-			fmt.Printf("Using default PR: #%d\n", selectedPR.Number)
+			selected = approvablePRs[:1]
+			streams.Printf("Using default PR: #%d\n", selected[0].Number)
 		} else {
-			// Parse the PR number (remove # prefix if present)
-			input = strings.TrimPrefix(input, "#")
-
-			prNumber, err := strconv.Atoi(input)
+			selected, err = parsePRSelection(input, approvablePRs, heldPRs)
 			if err != nil {
-				fmt.Printf("❌ Invalid PR number: %s\n", input)
-				fmt.Printf("Press Enter to continue or 'q' to quit.\n")
+				streams.Printf("❌ %v\n", err)
+				streams.Printf("   Available PRs: %s\n", strings.Join(append(availableNumbers, heldNumbers...), ", "))
+				streams.Printf("Press Enter to continue or 'q' to quit.\n")
 				continue
 			}
+			if len(selected) == 1 {
+				streams.Printf("Selected PR: #%d\n", selected[0].Number)
+			}
+		}
 
-			// Find the PR in our approvable list
-			index, exists := prIndexMap[prNumber]
-			if !exists {
-				fmt.Printf("❌ PR #%d is not available for approval (may be closed, draft, on hold, or not exist)\n", prNumber)
-				fmt.Printf("   Available PRs: %s\n", strings.Join(availableNumbers, ", "))
-				fmt.Printf("Press Enter to continue or 'q' to quit.\n")
+		// Several PRs are walked through back to back, or approved together after one confirmation
+		var batchResults map[int]ApprovalResult
+		if len(selected) > 1 {
+			mode, err := promptSelectionMode(selected)
+			if err != nil {
+				if err == io.EOF {
+					streams.Printf("(EOF - exiting approval process)\n")
+					break
+				}
+				streams.Printf("Error reading input: %v\n", err)
+				break
+			}
+			switch mode {
+			case selectionReselect:
 				continue
+			case selectionBatch:
+				if batchResults = approveSelectionBatch(client, owner, repo, selected, config); batchResults == nil {
+					continue
+				}
 			}
-
-			selectedPR = &approvablePRs[index]
-			fmt.Printf("Selected PR: #%d\n", selectedPR.Number)
 		}
 
-		// Now proceed with the approval flow for the selected PR - reuse the cache
-		fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-		result := approveSinglePRWithCache(client, owner, repo, *selectedPR, config, cache)
-
-		// Mark this PR as processed and update counters
-		processedPRs[selectedPR.Number] = true
-		switch result {
-		case ApprovalResultApprove:
-			approvedCount++
-		case ApprovalResultSkip:
-			skippedCount++
-		case ApprovalResultHold:
-			heldCount++
-		case ApprovalResultComment:
-			commentedCount++
-		case ApprovalResultQuit:
-			fmt.Println("Exiting approval process.")
-			goto exitLoop
+		for _, selectedPR := range selected {
+			var result ApprovalResult
+			if batchResults != nil {
+				var tried bool
+				// PRs left out of the batch stay to be selected on their own
+				if result, tried = batchResults[selectedPR.Number]; !tried {
+					continue
+				}
+			} else {
+				// Now proceed with the approval flow for the selected PR - reuse the cache
+				streams.Printf("═══════════════════════════════════════════════════════════════\n")
+				result = approveSinglePRWithCache(ctx, client, owner, repo, selectedPR, config, cache)
+			}
+			runArtifact.decideApproval(owner, repo, selectedPR, result)
+
+			// Mark this PR as processed and update counters
+			processedPRs[selectedPR.Number] = true
+			switch result {
+			case ApprovalResultApprove:
+				approvedCount++
+			case ApprovalResultSkip:
+				skippedCount++
+			case ApprovalResultHold:
+				heldCount++
+			case ApprovalResultComment:
+				commentedCount++
+			case ApprovalResultRebase:
+				rebasedCount++
+			case ApprovalResultClose:
+				closedCount++
+			case ApprovalResultRequestChanges:
+				changesRequestedCount++
+			case ApprovalResultCommentReview:
+				reviewedCount++
+			case ApprovalResultUnhold:
+				unheldCount++
+			case ApprovalResultQuit:
+				streams.Println("Exiting approval process.")
+				goto exitLoop
+			}
 		}
 
-		fmt.Printf("\n")
+		streams.Printf("\n")
 	}
 
 exitLoop:
 	// Print final summary
-	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-	fmt.Printf("📊 Final Approval Summary:\n")
-	fmt.Printf("   ✅ Approved: %d\n", approvedCount)
-	fmt.Printf("   ❌ Skipped: %d\n", skippedCount)
-	fmt.Printf("   ⏸️  Put on hold: %d\n", heldCount)
-	fmt.Printf("   💬 Commented: %d\n", commentedCount)
-	fmt.Printf("   📊 Total processed: %d\n", approvedCount+skippedCount+heldCount+commentedCount)
+	streams.Printf("═══════════════════════════════════════════════════════════════\n")
+	streams.Printf("📊 Final Approval Summary:\n")
+	streams.Printf("   ✅ Approved: %d\n", approvedCount)
+	streams.Printf("   ❌ Skipped: %d\n", skippedCount)
+	streams.Printf("   ⏸️  Put on hold: %d\n", heldCount)
+	streams.Printf("   ▶️  Taken off hold: %d\n", unheldCount)
+	streams.Printf("   💬 Commented: %d\n", commentedCount)
+	streams.Printf("   🔄 Rebased: %d\n", rebasedCount)
+	streams.Printf("   🚪 Closed: %d\n", closedCount)
+	streams.Printf("   🛑 Changes requested: %d\n", changesRequestedCount)
+	streams.Printf("   📝 Comment reviews: %d\n", reviewedCount)
+	streams.Printf("   📊 Total processed: %d\n", approvedCount+skippedCount+heldCount+unheldCount+commentedCount+rebasedCount+closedCount+
+		changesRequestedCount+reviewedCount)
 }
 
 // approveSinglePRWithCache handles the approval process for a single PR with cache reuse
-func approveSinglePRWithCache(client RESTClientInterface, owner, repo string, pr PullRequest, config ApprovalConfig, cache *PRDetailsCache) ApprovalResult {
+func approveSinglePRWithCache(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest, config ApprovalConfig, cache *PRDetailsCache) ApprovalResult {
 	// Build help message based on what's already shown
-	helpOptions := []string{"[y]es to approve", "[N]o to skip (default)", "[h]old", "[q]uit"}
-	if !showFiles {
+	helpOptions := []string{"[y]es to approve", "y! yc yr to approve with a review, a comment review or a comment", "[N]o to skip (default)", "[h]old", "[r]ebase", "[x] to close", "[q]uit"}
+	if isOnHold(pr) {
+		helpOptions = append(helpOptions, "[u]nhold")
+	}
+	if !config.ShowFiles {
 		helpOptions = append(helpOptions, "[f]iles to view")
 	}
-	if !showDiff {
+	if !config.ShowDiff {
 		helpOptions = append(helpOptions, "[d]iff to view")
 	}
+	if !config.ShowCommits {
+		helpOptions = append(helpOptions, "[l]og of commits to view")
+	}
 	helpOptions = append(helpOptions, "[c]hecks to view")
 
-	fmt.Printf("Commands: %s\n", strings.Join(helpOptions, ", "))
-	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
+	streams.Printf("Commands: %s\n", strings.Join(helpOptions, ", "))
+	streams.Printf("═══════════════════════════════════════════════════════════════\n")
 
 	// Check if PR is already approved by current user
 	reviewsPath := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, pr.Number)
 	var reviews []Review
 	err := client.Get(reviewsPath, &reviews)
 	if err != nil {
-		fmt.Printf("⚠️  Could not check existing reviews for %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+		streams.Printf("⚠️  Could not check existing reviews for %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
 		// Continue with prompt despite error
 	} else {
 		// Check if we already have an approval from any user
@@ -886,113 +1632,174 @@ func approveSinglePRWithCache(client RESTClientInterface, owner, repo string, pr
 		}
 
 		if alreadyApproved {
-			fmt.Printf("✅ PR %s is already approved: %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
-			fmt.Printf("Do you want to continue anyway? [y/N]: ")
-
-			reader := bufio.NewReader(os.Stdin)
-			response, err := reader.ReadString('\n')
-			if err != nil || strings.ToLower(strings.TrimSpace(response)) != "y" {
-				fmt.Printf("Skipping already approved PR.\n")
+			streams.Printf("✅ PR %s is already approved: %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
+			confirmed, err := prompter.Confirm("Do you want to continue anyway?")
+			if err != nil || !confirmed {
+				streams.Printf("Skipping already approved PR.\n")
 				return ApprovalResultSkip
 			}
 		}
 	}
 
 	// Prompt user for approval decision - reuse the provided cache
-	result := promptForApprovalWithCache(pr, owner, repo, client, config, cache)
+	result := promptForApprovalWithCache(ctx, pr, owner, repo, client, config, cache)
 	switch result {
 	case ApprovalResultSkip:
-		fmt.Printf("❌ Skipped PR %s\n", formatPRLink(owner, repo, pr.Number))
+		streams.Printf("❌ Skipped PR %s\n", formatPRLink(owner, repo, pr.Number))
 		return ApprovalResultSkip
 	case ApprovalResultHold:
-		fmt.Printf("⏸️  Put PR %s on hold\n", formatPRLink(owner, repo, pr.Number))
+		streams.Printf("⏸️  Put PR %s on hold\n", formatPRLink(owner, repo, pr.Number))
 		return ApprovalResultHold
+	case ApprovalResultUnhold:
+		return ApprovalResultUnhold
 	case ApprovalResultQuit:
 		return ApprovalResultQuit
 	case ApprovalResultComment:
-		fmt.Printf("💬 Added comment to PR %s\n", formatPRLink(owner, repo, pr.Number))
+		streams.Printf("💬 Added comment to PR %s\n", formatPRLink(owner, repo, pr.Number))
 		return ApprovalResultComment
-	case ApprovalResultApprove:
+	case ApprovalResultRebase:
+		return ApprovalResultRebase
+	case ApprovalResultClose:
+		return ApprovalResultClose
+	case ApprovalResultRequestChanges, ApprovalResultCommentReview:
+		return result
+	case ApprovalResultApprove, ApprovalResultApproveReview, ApprovalResultApproveCommentReview, ApprovalResultApproveComment:
 		// Check for migration warnings and ask for additional confirmation
 		if hasMigrationWarning(pr) {
-			fmt.Printf("\n🚨 ⚠️  MIGRATION WARNING DETECTED ⚠️  🚨\n")
-			fmt.Printf("This PR contains migration warnings which may indicate breaking changes or\n")
-			fmt.Printf("require special attention during deployment.\n\n")
-			fmt.Printf("Are you sure you want to approve this PR with migration warnings? [y/N]: ")
-
-			reader := bufio.NewReader(os.Stdin)
-			confirmResponse, err := reader.ReadString('\n')
+			streams.Printf("\n🚨 ⚠️  MIGRATION WARNING DETECTED ⚠️  🚨\n")
+			streams.Printf("This PR contains migration warnings which may indicate breaking changes or\n")
+			streams.Printf("require special attention during deployment.\n\n")
+			confirmed, err := prompter.Confirm("Are you sure you want to approve this PR with migration warnings?")
 			if err != nil {
-				fmt.Printf("Error reading confirmation: %v (skipping PR)\n", err)
+				streams.Printf("Error reading confirmation: %v (skipping PR)\n", err)
 				return ApprovalResultSkip
 			}
 
-			confirmResponse = strings.TrimSpace(strings.ToLower(confirmResponse))
-			if confirmResponse != "y" && confirmResponse != "yes" {
-				fmt.Printf("❌ Approval cancelled due to migration warnings. Skipping PR %s\n", formatPRLink(owner, repo, pr.Number))
+			if !confirmed {
+				streams.Printf("❌ Approval cancelled due to migration warnings. Skipping PR %s\n", formatPRLink(owner, repo, pr.Number))
 				return ApprovalResultSkip
 			}
 
-			fmt.Printf("✅ Confirmed - proceeding with approval despite migration warnings.\n")
+			streams.Printf("✅ Confirmed - proceeding with approval despite migration warnings.\n")
+		}
+		// CI and ownership changes by untrusted authors need the PR number typed
+		if !confirmSensitiveChanges(client, owner, repo, pr, config.TrustedAuthors) {
+			return ApprovalResultSkip
+		}
+		// Bot PRs much larger than expected need the PR number typed too
+		if !confirmLargeChanges(client, owner, repo, pr, config.Review.MaxChanges, config.TrustedAuthors) {
+			return ApprovalResultSkip
+		}
+		// Check for image pinning changes against the policy and ask for additional confirmation
+		if config.IsKonflux {
+			if !confirmPinningChanges(client, owner, repo, pr, config.ImagePinning) {
+				return ApprovalResultSkip
+			}
 		}
 		// Continue with approval process below
 	}
 
-	// Create approval review
-	reviewPath := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, pr.Number)
-	review := ReviewRequest{
-		Body:  "/lgtm",
-		Event: "APPROVE",
+	// Make sure nothing was pushed since the PR was displayed, so unseen commits aren't approved
+	if !confirmPRUnchanged(client, owner, repo, pr, "approve") {
+		return ApprovalResultSkip
 	}
 
-	// Convert review to JSON
-	reviewJSON, err := json.Marshal(review)
-	if err != nil {
-		fmt.Printf("❌ Failed to marshal review for %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
+	streams.Printf("✅ Approving %s: %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
+	post := postApproval
+	if result == ApprovalResultApproveComment {
+		post = postApprovalComment
+	}
+	if err := post(client, owner, repo, pr, approvalSettingsFor(config.Review, result)); err != nil {
+		streams.Printf("❌ Failed to approve %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
 		return ApprovalResultSkip
 	}
+	return ApprovalResultApprove
+}
 
-	fmt.Printf("✅ Approving %s: %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
-
+// postApproval posts the approval review of a PR, pinned to its head commit, followed by the configured
+// follow-up comments, and verifies that the approval landed. A failure to post the review and an approval
+// that verifiably didn't land, such as one Prow refused, are returned.
+func postApproval(client RESTClientInterface, owner, repo string, pr PullRequest, settings ApprovalSettings) error {
 	// Add the approval review
-	err = client.Post(reviewPath, bytes.NewReader(reviewJSON), nil)
+	postedAt := time.Now()
+	posted, err := ghprs.PostReview(context.Background(), client, owner, repo, pr, settings.ReviewBody(), settings.ReviewEvent())
 	if err != nil {
-		fmt.Printf("❌ Failed to approve %s: %v\n", formatPRLink(owner, repo, pr.Number), err)
-		return ApprovalResultSkip
+		return err
 	}
+	auditLog.record(client, owner, repo, pr.Number, AuditApprove, settings.ReviewBody())
+	streams.Printf("   ✓ Successfully approved %s\n", formatPRLink(owner, repo, pr.Number))
 
-	fmt.Printf("   ✓ Successfully approved %s\n", formatPRLink(owner, repo, pr.Number))
-	return ApprovalResultApprove
+	// Post the configured follow-up comments, such as /approve for Prow
+	for _, comment := range settings.ExtraComments {
+		if err := addCommentToPR(client, owner, repo, pr.Number, comment); err != nil {
+			streams.Printf("   ⚠️  Failed to post %q on %s: %v\n", comment, formatPRLink(owner, repo, pr.Number), err)
+			continue
+		}
+		streams.Printf("   ✓ Posted %q\n", comment)
+	}
+	return verifyApproval(client, owner, repo, pr, settings, posted.ID, postedAt)
 }
 
-// isOnHold checks if a PR has the "do-not-merge/hold" label
-func isOnHold(pr PullRequest) bool {
-	for _, label := range pr.Labels {
-		if label.Name == "do-not-merge/hold" {
-			return true
+// approvalSettingsFor returns the settings of the approval variant chosen at the prompt. y! and yc override the
+// configured review event; yc and yr post "/lgtm" when the configured body is empty, since a comment review or
+// a comment needs a body.
+func approvalSettingsFor(settings ApprovalSettings, result ApprovalResult) ApprovalSettings {
+	switch result {
+	case ApprovalResultApproveReview:
+		settings.Event = ReviewEventApprove
+	case ApprovalResultApproveCommentReview:
+		settings.Event = ReviewEventComment
+		fallthrough
+	case ApprovalResultApproveComment:
+		if settings.ReviewBody() == "" {
+			body := defaultApprovalBody
+			settings.Body = &body
 		}
 	}
-	return false
+	return settings
+}
+
+// postApprovalComment approves a PR with a plain comment of the review body instead of a review, for Prow
+// configs that only act on comments, followed by the configured follow-up comments. Like postApproval it
+// verifies that Prow acted on it.
+func postApprovalComment(client RESTClientInterface, owner, repo string, pr PullRequest, settings ApprovalSettings) error {
+	postedAt := time.Now()
+	if err := addCommentToPR(client, owner, repo, pr.Number, settings.ReviewBody()); err != nil {
+		return err
+	}
+	auditLog.record(client, owner, repo, pr.Number, AuditApprove, "")
+	streams.Printf("   ✓ Posted %q on %s\n", settings.ReviewBody(), formatPRLink(owner, repo, pr.Number))
+
+	for _, comment := range settings.ExtraComments {
+		if err := addCommentToPR(client, owner, repo, pr.Number, comment); err != nil {
+			streams.Printf("   ⚠️  Failed to post %q on %s: %v\n", comment, formatPRLink(owner, repo, pr.Number), err)
+			continue
+		}
+		streams.Printf("   ✓ Posted %q\n", comment)
+	}
+	return verifyApproval(client, owner, repo, pr, settings, 0, postedAt)
+}
+
+// isOnHold checks if a PR has the "do-not-merge/hold" label
+func isOnHold(pr PullRequest) bool {
+	return ghprs.IsOnHold(pr)
 }
 
 // needsRebase checks if a PR needs a rebase based on mergeable_state
 func needsRebase(pr PullRequest) bool {
-	switch pr.MergeableState {
-	case "dirty", "behind":
-		return true
-	default:
-		return false
-	}
+	return ghprs.NeedsRebase(pr)
 }
 
 // isBlocked checks if a PR is blocked from merging based on mergeable_state
 func isBlocked(pr PullRequest) bool {
-	return pr.MergeableState == "blocked"
+	return ghprs.IsBlocked(pr)
 }
 
 // PRDetailsCache caches fetched PR details to avoid duplicate API calls
 type PRDetailsCache struct {
 	cache sync.Map
+	// errors holds why the details of a PR couldn't be fetched, by PR number
+	errors sync.Map
 }
 
 // NewPRDetailsCache creates a new PR details cache
@@ -1027,8 +1834,10 @@ func (c *PRDetailsCache) GetOrFetch(client RESTClientInterface, owner, repo stri
 		// If we can't fetch details, cache the original PR to avoid retrying
 		// Note: This is often due to rate limiting or permissions
 		c.cache.Store(prNumber, &originalPR)
+		c.errors.Store(prNumber, err)
 		return &originalPR
 	}
+	c.errors.Delete(prNumber)
 
 	// Only cache if we have a valid mergeable_state
 	// GitHub computes this asynchronously, so it might not be ready on first call
@@ -1039,11 +1848,19 @@ func (c *PRDetailsCache) GetOrFetch(client RESTClientInterface, owner, repo stri
 	return &pr
 }
 
+// FetchError returns why the details of a PR couldn't be fetched, nil when they were or weren't needed
+func (c *PRDetailsCache) FetchError(prNumber int) error {
+	if err, ok := c.errors.Load(prNumber); ok {
+		return err.(error)
+	}
+	return nil
+}
+
 // fetchPRDetails fetches full PR details including mergeable_state
-func fetchPRDetails(client RESTClientInterface, owner, repo string, prNumber int) (*PullRequest, error) {
+func fetchPRDetails(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int) (*PullRequest, error) {
 	var pr PullRequest
 	prPath := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, prNumber)
-	err := client.Get(prPath, &pr)
+	err := client.DoWithContext(ctx, http.MethodGet, prPath, nil, &pr)
 	if err != nil {
 		return nil, err
 	}
@@ -1054,8 +1871,8 @@ func fetchPRDetails(client RESTClientInterface, owner, repo string, prNumber int
 func needsRebaseWithCache(cache *PRDetailsCache, client RESTClientInterface, owner, repo string, pr PullRequest) (bool, bool) {
 	fullPR := cache.GetOrFetch(client, owner, repo, pr.Number, pr)
 	// Return (needsRebase, hasValidState)
-	// Check for empty, whitespace-only, or common GitHub unknown states
-	mergeableState := strings.TrimSpace(fullPR.MergeableState)
+	// Check for empty, whitespace-only, unknown or unrecognised states
+	mergeableState := normalizeMergeableState(fullPR.MergeableState)
 	if mergeableState == "" || mergeableState == "unknown" {
 		return false, false // Unknown state
 	}
@@ -1066,8 +1883,8 @@ func needsRebaseWithCache(cache *PRDetailsCache, client RESTClientInterface, own
 func isBlockedWithCache(cache *PRDetailsCache, client RESTClientInterface, owner, repo string, pr PullRequest) (bool, bool) {
 	fullPR := cache.GetOrFetch(client, owner, repo, pr.Number, pr)
 	// Return (isBlocked, hasValidState)
-	// Check for empty, whitespace-only, or common GitHub unknown states
-	mergeableState := strings.TrimSpace(fullPR.MergeableState)
+	// Check for empty, whitespace-only, unknown or unrecognised states
+	mergeableState := normalizeMergeableState(fullPR.MergeableState)
 	if mergeableState == "" || mergeableState == "unknown" {
 		return false, false // Unknown state
 	}
@@ -1076,10 +1893,18 @@ func isBlockedWithCache(cache *PRDetailsCache, client RESTClientInterface, owner
 
 // isReviewed checks if a PR has any approved reviews or approved/lgtm labels
 func isReviewed(client RESTClientInterface, owner, repo string, prNumber int, labels []Label) bool {
+	// If we can't fetch reviews, assume not reviewed
+	reviewed, _ := fetchReviewed(client, owner, repo, prNumber, labels)
+	return reviewed
+}
+
+// fetchReviewed checks if a PR has any approved reviews or approved/lgtm labels, returning the error when its
+// reviews couldn't be fetched
+func fetchReviewed(client RESTClientInterface, owner, repo string, prNumber int, labels []Label) (bool, error) {
 	// First check for approved/lgtm labels
 	for _, label := range labels {
 		if label.Name == "approved" || label.Name == "lgtm" {
-			return true
+			return true, nil
 		}
 	}
 
@@ -1088,25 +1913,30 @@ func isReviewed(client RESTClientInterface, owner, repo string, prNumber int, la
 	var reviews []Review
 	err := client.Get(reviewsPath, &reviews)
 	if err != nil {
-		// If we can't fetch reviews, assume not reviewed
-		return false
+		return false, err
 	}
 
 	// Check if we have any approved reviews
 	for _, review := range reviews {
 		if review.State == "APPROVED" {
-			return true
+			return true, nil
 		}
 	}
 
-	return false
+	return false, nil
+}
+
+// isTektonFile reports whether a file is one of the Tekton pipelines of a repository: those matching its
+// tekton_patterns, else .tekton/*-pull-request.yaml or .tekton/*-push.yaml
+func isTektonFile(patterns []string, filename string) bool {
+	return ghprs.MatchesTektonPatterns(filename, patterns)
 }
 
 // checkTektonFilesDetailed checks if a PR ONLY modifies specific Tekton files and returns the list
-func checkTektonFilesDetailed(client RESTClientInterface, owner, repo string, prNumber int) (bool, []string, error) {
+func checkTektonFilesDetailed(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int) (bool, []string, error) {
 	filesPath := fmt.Sprintf("repos/%s/%s/pulls/%d/files", owner, repo, prNumber)
 	var files []PRFile
-	err := client.Get(filesPath, &files)
+	err := client.DoWithContext(ctx, http.MethodGet, filesPath, nil, &files)
 	if err != nil {
 		return false, nil, err
 	}
@@ -1114,17 +1944,11 @@ func checkTektonFilesDetailed(client RESTClientInterface, owner, repo string, pr
 	var tektonFiles []string
 	var nonTektonFiles []string
 
+	patterns := settingsOf(owner, repo).TektonPatterns
 	for _, file := range files {
-		// Check if file is in .tekton/ directory and matches our patterns
-		if strings.HasPrefix(file.Filename, ".tekton/") {
-			if strings.HasSuffix(file.Filename, "-pull-request.yaml") || strings.HasSuffix(file.Filename, "-push.yaml") {
-				tektonFiles = append(tektonFiles, file.Filename)
-			} else {
-				// File is in .tekton/ but doesn't match our patterns
-				nonTektonFiles = append(nonTektonFiles, file.Filename)
-			}
+		if isTektonFile(patterns, file.Filename) {
+			tektonFiles = append(tektonFiles, file.Filename)
 		} else {
-			// File is not in .tekton/ directory
 			nonTektonFiles = append(nonTektonFiles, file.Filename)
 		}
 	}
@@ -1136,79 +1960,70 @@ func checkTektonFilesDetailed(client RESTClientInterface, owner, repo string, pr
 
 // hasMigrationWarning checks if a PR contains migration warnings
 func hasMigrationWarning(pr PullRequest) bool {
-	// Check for migration warning patterns in the PR body
-	// ⚠️[migration] or :warning:[migration] or ⚠️migration⚠️ or [migration]
-	bodyLower := strings.ToLower(pr.Body)
-
-	// Look for various migration warning patterns
-	migrationPatterns := []string{
-		"⚠️[migration]",
-		":warning:[migration]",
-		"⚠️migration⚠️",
-		"[migration]",
-	}
-
-	for _, pattern := range migrationPatterns {
-		if strings.Contains(bodyLower, strings.ToLower(pattern)) {
-			return true
-		}
-	}
-
-	return false
+	return ghprs.HasMigrationWarning(pr)
 }
 
 // hasSecurity checks if a PR is a security update based on its title
 func hasSecurity(pr PullRequest) bool {
-	titleUpper := strings.ToUpper(pr.Title)
-	return strings.Contains(titleUpper, "SECURITY") || strings.Contains(titleUpper, "CVE")
+	return ghprs.IsSecurityUpdate(pr)
 }
 
 // hasApprovedLabel checks if a PR has approved/lgtm labels (fast check without API calls)
 func hasApprovedLabel(labels []Label) bool {
-	for _, label := range labels {
-		if label.Name == "approved" || label.Name == "lgtm" {
-			return true
-		}
-	}
-	return false
+	return ghprs.HasApprovedLabel(labels)
 }
 
-// filterPRs applies all the filtering logic to a list of PRs
-func filterPRs(pullRequests []PullRequest, client RESTClientInterface, owner, repo string, isKonflux bool) []PullRequest {
+// filterPRs applies the filters of opts to a list of PRs
+func filterPRs(ctx context.Context, opts *listOptions, pullRequests []PullRequest, client RESTClientInterface, owner, repo string, isKonflux bool) []PullRequest {
 	var filteredPRs []PullRequest
 
-	for _, pr := range pullRequests {
-		// Check for Tekton files if this is a Konflux PR (skip in fast mode)
-		onlyTektonFiles := false
-		if isKonflux && !fastMode {
-			var err error
-			onlyTektonFiles, _, err = checkTektonFilesDetailed(client, owner, repo, pr.Number)
-			if err != nil {
-				// Silently continue if we can't check Tekton files for filtering
-				_ = err
-			}
-		}
+	// Check for Tekton files in parallel if this is a Konflux PR (skip in fast mode)
+	onlyTekton := make([]bool, len(pullRequests))
+	if isKonflux && !opts.Fast {
+		runConcurrently(len(pullRequests), opts.Concurrency, func(i int) {
+			// Silently continue if we can't check Tekton files for filtering
+			onlyTekton[i], _, _ = checkTektonFilesDetailed(ctx, client, owner, repo, pullRequests[i].Number)
+		})
+	}
+
+	for i, pr := range pullRequests {
+		onlyTektonFiles := onlyTekton[i]
 
 		// Check for migration warnings
 		hasMigration := hasMigrationWarning(pr)
 
 		// Skip PRs that don't exclusively modify Tekton files if --tekton-only flag is set
-		if tektonOnly && !onlyTektonFiles {
+		if opts.TektonOnly && !onlyTektonFiles {
 			continue
 		}
 
 		// Skip PRs that don't have migration warnings if --migration-only flag is set
-		if migrationOnly && !hasMigration {
+		if opts.MigrationOnly && !hasMigration {
 			continue
 		}
 
 		// Skip PRs that don't have security updates if --security-only flag is set
-		if securityOnly && !hasSecurity(pr) {
+		if opts.SecurityOnly && !hasSecurity(pr) {
+			continue
+		}
+
+		// Skip PRs that don't target the specified branch if --target-branch is set
+		if opts.TargetBranch != "" && pr.Base.Ref != opts.TargetBranch {
+			continue
+		}
+
+		// Skip PRs opened too recently for --older-than or not updated within --updated-within
+		if !opts.matchesAgeFilters(pr) {
+			continue
+		}
+
+		// Skip PRs whose CODEOWNERS don't include you or your teams if --needs-my-team is set
+		if opts.NeedsMyTeam && !needsMyReview(ctx, client, owner, repo, pr) {
 			continue
 		}
 
-		// Skip PRs that don't target the specified branch if --target-branch is set
-		if targetBranch != "" && pr.Base.Ref != targetBranch {
+		// Skip PRs whose most disruptive dependency update isn't one of --update-type
+		if len(opts.UpdateTypes) > 0 && !matchesUpdateType(pr, opts.UpdateTypes) {
 			continue
 		}
 
@@ -1229,17 +2044,32 @@ func isKonfluxNudge(pr PullRequest) bool {
 	return false
 }
 
-// getCheckStatus fetches and analyzes the status of all checks for a PR
-func getCheckStatus(client RESTClientInterface, owner, repo string, prNumber int, headSHA string) (*CheckStatus, error) {
+// getCheckStatus fetches and analyzes the status of all checks for a PR, warning about the checks that couldn't
+// be fetched
+func getCheckStatus(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int, headSHA string) (*CheckStatus, error) {
+	status, err := fetchCheckStatus(ctx, client, owner, repo, headSHA)
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		for _, failure := range joined.Unwrap() {
+			streams.Printf("   ⚠️  Could not fetch %v\n", failure)
+		}
+	}
+	return status, nil
+}
+
+// fetchCheckStatus fetches and analyzes the status of all checks of a commit. The status counts the checks that
+// could be fetched, the error tells which couldn't.
+func fetchCheckStatus(ctx context.Context, client RESTClientInterface, owner, repo string, headSHA string) (*CheckStatus, error) {
 	status := &CheckStatus{}
+	now := time.Now()
+	var errs []error
 
 	// Get check runs (newer GitHub checks API)
 	checkRunsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, headSHA)
 	var checkRunsResp CheckRunsResponse
-	err := client.Get(checkRunsPath, &checkRunsResp)
+	err := client.DoWithContext(ctx, http.MethodGet, checkRunsPath, nil, &checkRunsResp)
 	if err != nil {
 		// If check runs API fails, we'll try the legacy status API below
-		fmt.Printf("   ⚠️  Could not fetch check runs: %v\n", err)
+		errs = append(errs, fmt.Errorf("check runs: %w", err))
 	} else {
 		for _, checkRun := range checkRunsResp.CheckRuns {
 			status.Total++
@@ -1252,11 +2082,18 @@ func getCheckStatus(client RESTClientInterface, owner, repo string, prNumber int
 					status.Failed++
 				case "cancelled":
 					status.Cancelled++
-				case "skipped", "neutral":
+				case "skipped", "neutral", "stale":
 					status.Skipped++
+				default:
+					warnUnexpectedValue("check run conclusion", checkRun.Conclusion)
 				}
-			case "queued", "in_progress":
+			case "queued", "in_progress", "waiting", "requested", "pending":
 				status.Pending++
+				if age, pending := checkRunPendingAge(checkRun, now); pending && isStale(age) {
+					status.Stale++
+				}
+			default:
+				warnUnexpectedValue("check run status", checkRun.Status)
 			}
 		}
 	}
@@ -1267,9 +2104,9 @@ func getCheckStatus(client RESTClientInterface, owner, repo string, prNumber int
 		State    string        `json:"state"`
 		Statuses []StatusCheck `json:"statuses"`
 	}
-	err = client.Get(statusPath, &statusResp)
+	err = client.DoWithContext(ctx, http.MethodGet, statusPath, nil, &statusResp)
 	if err != nil {
-		fmt.Printf("   ⚠️  Could not fetch status checks: %v\n", err)
+		errs = append(errs, fmt.Errorf("status checks: %w", err))
 	} else {
 		for _, statusCheck := range statusResp.Statuses {
 			status.Total++
@@ -1280,23 +2117,28 @@ func getCheckStatus(client RESTClientInterface, owner, repo string, prNumber int
 				status.Failed++
 			case "pending":
 				status.Pending++
+				if age, pending := statusCheckPendingAge(statusCheck, now); pending && isStale(age) {
+					status.Stale++
+				}
+			default:
+				warnUnexpectedValue("commit status state", statusCheck.State)
 			}
 		}
 	}
 
-	return status, nil
+	return status, errors.Join(errs...)
 }
 
 // displayCheckStatus shows the status of checks for a PR
-func displayCheckStatus(client RESTClientInterface, owner, repo string, prNumber int, headSHA string) {
-	checkStatus, err := getCheckStatus(client, owner, repo, prNumber, headSHA)
+func displayCheckStatus(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int, headSHA string) {
+	checkStatus, err := getCheckStatus(ctx, client, owner, repo, prNumber, headSHA)
 	if err != nil {
-		fmt.Printf("   ⚠️  Could not fetch check status: %v\n", err)
+		streams.Printf("   ⚠️  Could not fetch check status: %v\n", err)
 		return
 	}
 
 	if checkStatus.Total == 0 {
-		fmt.Printf("   ✅ No checks configured\n")
+		streams.Printf("   ✅ No checks configured\n")
 		return
 	}
 
@@ -1311,6 +2153,9 @@ func displayCheckStatus(client RESTClientInterface, owner, repo string, prNumber
 	if checkStatus.Pending > 0 {
 		statusParts = append(statusParts, fmt.Sprintf("🟡 %d pending", checkStatus.Pending))
 	}
+	if checkStatus.Stale > 0 {
+		statusParts = append(statusParts, fmt.Sprintf("⏰ %d pending for over %s (press 't' to re-trigger)", checkStatus.Stale, formatAge(staleCheckAfter)))
+	}
 	if checkStatus.Cancelled > 0 {
 		statusParts = append(statusParts, fmt.Sprintf("⚫ %d cancelled", checkStatus.Cancelled))
 	}
@@ -1330,103 +2175,62 @@ func displayCheckStatus(client RESTClientInterface, owner, repo string, prNumber
 		overallIcon = "⚪"
 	}
 
-	fmt.Printf("   %s Checks (%d total): %s (press 'c' during approval to view details)\n", overallIcon, checkStatus.Total, strings.Join(statusParts, ", "))
+	streams.Printf("   %s Checks (%d total): %s (press 'c' during approval to view details)\n", overallIcon, checkStatus.Total, strings.Join(statusParts, ", "))
 }
 
-// displayDetailedCheckStatus shows detailed information about all checks for a PR
-func displayDetailedCheckStatus(client RESTClientInterface, owner, repo string, prNumber int, headSHA string) {
-	fmt.Printf("\n🔍 Detailed check status for PR %s:\n", formatPRLink(owner, repo, prNumber))
+// displayDetailedCheckStatus shows all checks for a PR grouped by the app or context that reported them, collapsing
+// the groups that passed unless expand (--expand-checks), and returns how many failed
+func displayDetailedCheckStatus(client RESTClientInterface, owner, repo string, pr PullRequest, expand bool) int {
+	streams.Printf("\n🔍 Detailed check status for PR %s:\n", formatPRLink(owner, repo, pr.Number))
+	failed, collapsed := 0, 0
+	now := time.Now()
 
 	// Get check runs (newer GitHub checks API)
-	checkRunsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, headSHA)
+	checkRunsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, pr.Head.SHA)
 	var checkRunsResp CheckRunsResponse
 	err := client.Get(checkRunsPath, &checkRunsResp)
 	if err == nil && len(checkRunsResp.CheckRuns) > 0 {
-		fmt.Printf("\n📋 Check Runs:\n")
+		streams.Printf("\n📋 Check Runs:\n")
 		for _, checkRun := range checkRunsResp.CheckRuns {
-			var icon string
-			var status string
-
-			switch checkRun.Status {
-			case "completed":
-				switch checkRun.Conclusion {
-				case "success":
-					icon = "✅"
-					status = "passed"
-				case "failure", "timed_out", "action_required":
-					icon = "❌"
-					status = fmt.Sprintf("failed (%s)", checkRun.Conclusion)
-				case "cancelled":
-					icon = "⚫"
-					status = "cancelled"
-				case "skipped", "neutral":
-					icon = "⚪"
-					status = fmt.Sprintf("skipped (%s)", checkRun.Conclusion)
-				default:
-					icon = "❓"
-					status = checkRun.Conclusion
-				}
-			case "queued":
-				icon = "🟡"
-				status = "queued"
-			case "in_progress":
-				icon = "🟡"
-				status = "running"
-			default:
-				icon = "❓"
-				status = checkRun.Status
+			if checkRunFailed(checkRun) {
+				failed++
 			}
-
-			fmt.Printf("   %s %s: %s\n", icon, checkRun.Name, status)
 		}
+		collapsed += displayCheckGroups(groupCheckRuns(checkRunsResp.CheckRuns, now), expand)
 	}
 
 	// Get legacy status checks
-	statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status", owner, repo, headSHA)
+	statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status", owner, repo, pr.Head.SHA)
 	var statusResp struct {
 		State    string        `json:"state"`
 		Statuses []StatusCheck `json:"statuses"`
 	}
 	err = client.Get(statusPath, &statusResp)
 	if err == nil && len(statusResp.Statuses) > 0 {
-		fmt.Printf("\n📋 Status Checks:\n")
+		streams.Printf("\n📋 Status Checks:\n")
 		for _, statusCheck := range statusResp.Statuses {
-			var icon string
-			switch statusCheck.State {
-			case "success":
-				icon = "✅"
-			case "failure", "error":
-				icon = "❌"
-			case "pending":
-				icon = "🟡"
-			default:
-				icon = "❓"
-			}
-
-			description := statusCheck.Description
-			if description == "" {
-				description = statusCheck.State
+			if statusCheckFailed(statusCheck) {
+				failed++
 			}
-
-			fmt.Printf("   %s %s: %s\n", icon, statusCheck.Context, description)
 		}
+		collapsed += displayCheckGroups(groupStatusChecks(statusResp.Statuses, now), expand)
 	}
 
-	fmt.Printf("\n")
-}
-
-// holdPR puts a PR on hold by commenting /hold, adding the "needs-ok-to-test" label, and removing "ok-to-test" label if present
-func holdPR(client RESTClientInterface, owner, repo string, prNumber int, additionalComment string) error {
-	// Build the comment body
-	commentBody := "/hold"
-	if additionalComment != "" {
-		commentBody += "\n\n" + additionalComment
+	if collapsed > 0 {
+		streams.Printf("\n   %d passing group(s) collapsed, use --expand-checks to list every check\n", collapsed)
 	}
+	displayRequiredChecks(client, owner, repo, pr, checkRunsResp.CheckRuns, statusResp.Statuses)
+	streams.Printf("\n")
+	return failed
+}
 
+// holdPR puts a PR on hold by commenting /hold, adding the "needs-ok-to-test" label, and removing "ok-to-test" label if present.
+// A non-zero expiry is noted in the comment so watch can remind about the hold once it passed.
+func holdPR(client RESTClientInterface, owner, repo string, prNumber int, additionalComment string, expiry time.Time) error {
 	// Add the /hold comment
 	commentPath := fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, prNumber)
 	comment := CommentRequest{
-		Body: commentBody,
+		Body: holdCommentBody(additionalComment, expiry),
 	}
 
 	commentJSON, err := json.Marshal(comment)
@@ -1461,69 +2265,34 @@ func holdPR(client RESTClientInterface, owner, repo string, prNumber int, additi
 	if err != nil {
 		// Don't fail the whole operation if the label doesn't exist or can't be removed
 		// This is common when the label wasn't present in the first place
-		fmt.Printf("Note: Could not remove 'ok-to-test' label (may not exist): %v\n", err)
+		streams.Printf("Note: Could not remove 'ok-to-test' label (may not exist): %v\n", err)
 	}
 
+	auditLog.record(client, owner, repo, prNumber, AuditHold, comment.Body)
 	return nil
 }
 
 // addCommentToPR adds a comment to a pull request
 func addCommentToPR(client RESTClientInterface, owner, repo string, prNumber int, commentText string) error {
-	commentPath := fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, prNumber)
-	comment := CommentRequest{
-		Body: commentText,
-	}
-
-	// Convert comment to JSON
-	commentJSON, err := json.Marshal(comment)
-	if err != nil {
-		return fmt.Errorf("failed to marshal comment: %v", err)
-	}
-
-	// Add the comment
-	err = client.Post(commentPath, bytes.NewReader(commentJSON), nil)
-	if err != nil {
-		return fmt.Errorf("failed to post comment: %v", err)
+	if err := ghprs.PostComment(context.Background(), client, owner, repo, prNumber, commentText); err != nil {
+		return err
 	}
-
+	auditLog.record(client, owner, repo, prNumber, AuditComment, commentText)
 	return nil
 }
 
 // getStatusIcon returns the appropriate icon and status for a PR
 func getStatusIcon(pr PullRequest) string {
-	onHold := isOnHold(pr)
-
-	if pr.Draft {
-		return "🟡"
-	}
-
-	switch pr.State {
-	case "open":
-		if onHold {
-			return "🔶"
-		}
-		return "🟢"
-	case "closed":
-		return "🔴"
-	case "merged":
-		return "🟣"
-	default:
-		if onHold {
-			return "🔶"
-		}
-		return "⚪"
-	}
+	return statusIcon(pr.State, pr.Draft, isOnHold(pr))
 }
 
-// getStatusIconWithTekton returns the appropriate icon and status for a PR, including Tekton and migration info
-func getStatusIconWithTekton(pr PullRequest, hasTektonFiles bool) string {
-	onHold := isOnHold(pr)
-
-	if pr.Draft {
+// statusIcon returns the status icon for a PR state
+func statusIcon(state string, draft, onHold bool) string {
+	if draft {
 		return "🟡"
 	}
 
-	switch pr.State {
+	switch state {
 	case "open":
 		if onHold {
 			return "🔶"
@@ -1543,125 +2312,44 @@ func getStatusIconWithTekton(pr PullRequest, hasTektonFiles bool) string {
 
 // sortPullRequests sorts PRs based on the specified sort option
 func sortPullRequests(prs []PullRequest, sortBy string) {
+	less := pullRequestLess(sortBy)
+	if less == nil {
+		// Default: newest first, GitHub's default, so no sorting is needed
+		return
+	}
+	sort.Slice(prs, func(i, j int) bool {
+		return less(prs[i], prs[j])
+	})
+}
+
+// pullRequestLess returns how PRs are ordered for sortBy, or nil for the default order, newest first
+func pullRequestLess(sortBy string) func(a, b PullRequest) bool {
 	switch sortBy {
 	case "oldest":
 		// Sort by creation date ascending (oldest first)
-		sort.Slice(prs, func(i, j int) bool {
-			return prs[i].CreatedAt < prs[j].CreatedAt
-		})
+		return func(a, b PullRequest) bool {
+			return a.CreatedAt < b.CreatedAt
+		}
 	case "updated":
 		// Sort by last update descending (most recently updated first)
-		sort.Slice(prs, func(i, j int) bool {
-			return prs[i].UpdatedAt > prs[j].UpdatedAt
-		})
+		return func(a, b PullRequest) bool {
+			return a.UpdatedAt > b.UpdatedAt
+		}
 	case "number":
 		// Sort by PR number ascending (lowest numbers first)
-		sort.Slice(prs, func(i, j int) bool {
-			return prs[i].Number < prs[j].Number
-		})
-	case "priority":
-		// Custom priority sorting: security updates first, then migration warnings, then others by creation date
-		sort.Slice(prs, func(i, j int) bool {
-			iSecurity := hasSecurity(prs[i])
-			jSecurity := hasSecurity(prs[j])
-			iMigration := hasMigrationWarning(prs[i])
-			jMigration := hasMigrationWarning(prs[j])
-
-			// Security updates have highest priority
-			if iSecurity && !jSecurity {
-				return true
-			}
-			if !iSecurity && jSecurity {
-				return false
-			}
-
-			// If both have same security status, migration warnings come next
-			if iMigration && !jMigration {
-				return true
-			}
-			if !iMigration && jMigration {
-				return false
-			}
-
-			// If both have same security and migration status, sort by creation date (newest first)
-			return prs[i].CreatedAt > prs[j].CreatedAt
-		})
-	case "newest":
-		fallthrough
-	default:
-		// Default: Sort by creation date descending (newest first) - GitHub's default
-		// No sorting needed as this is already the API default
-		return
-	}
-}
-
-// sortPullRequestsWithContext sorts PRs with full context including Tekton file information
-func sortPullRequestsWithContext(prs []PullRequest, client RESTClientInterface, owner, repo string, sortBy string) {
-	if sortBy != "priority" {
-		return // Only apply context-aware sorting for priority mode
-	}
-
-	// Create a slice of PR info with additional context
-	type prInfo struct {
-		pr              PullRequest
-		hasSecurity     bool
-		hasMigration    bool
-		onlyTektonFiles bool
-	}
-
-	var prInfos []prInfo
-	for _, pr := range prs {
-		info := prInfo{
-			pr:           pr,
-			hasSecurity:  hasSecurity(pr),
-			hasMigration: hasMigrationWarning(pr),
-		}
-
-		// Check Tekton files (this makes API calls, so only do it for priority sorting)
-		onlyTekton, _, err := checkTektonFilesDetailed(client, owner, repo, pr.Number)
-		if err == nil {
-			info.onlyTektonFiles = onlyTekton
-		}
-
-		prInfos = append(prInfos, info)
-	}
-
-	// Sort by priority: security updates first, then migration warnings, then Tekton-only, then others
-	sort.Slice(prInfos, func(i, j int) bool {
-		iInfo := prInfos[i]
-		jInfo := prInfos[j]
-
-		// 1. Security updates have highest priority
-		if iInfo.hasSecurity && !jInfo.hasSecurity {
-			return true
-		}
-		if !iInfo.hasSecurity && jInfo.hasSecurity {
-			return false
-		}
-
-		// 2. If both have same security status, migration warnings come next
-		if iInfo.hasMigration && !jInfo.hasMigration {
-			return true
-		}
-		if !iInfo.hasMigration && jInfo.hasMigration {
-			return false
-		}
-
-		// 3. If both have same security and migration status, Tekton-only PRs come next
-		if iInfo.onlyTektonFiles && !jInfo.onlyTektonFiles {
-			return true
+		return func(a, b PullRequest) bool {
+			return a.Number < b.Number
 		}
-		if !iInfo.onlyTektonFiles && jInfo.onlyTektonFiles {
-			return false
+	case "priority":
+		// Weighted priority: security updates first, then migration warnings, then others by creation date.
+		// What PRs don't say themselves, such as Tekton-only changes, is added by sortPullRequestsWithContext.
+		now := time.Now()
+		return func(a, b PullRequest) bool {
+			facts := priorityFacts{Now: now}
+			return priorityLess(a, b, scorePriority(a, facts).Score, scorePriority(b, facts).Score)
 		}
-
-		// 4. If both have same security, migration, and Tekton status, sort by creation date (newest first)
-		return iInfo.pr.CreatedAt > jInfo.pr.CreatedAt
-	})
-
-	// Copy back the sorted PRs
-	for i, info := range prInfos {
-		prs[i] = info.pr
+	default:
+		return nil
 	}
 }
 
@@ -1687,126 +2375,8 @@ func displayFileList(files []PRFile) {
 			status = "?"
 			statusColor = "⚪"
 		}
-		fmt.Printf("      %s %s %s\n", statusColor, status, file.Filename)
-	}
-}
-
-// displayDiff shows the diff content for a PR with color coding
-func displayDiff(owner, repo string, prNumber int) error {
-	// The go-gh REST client doesn't expose direct HTTP methods for custom Accept headers,
-	// so we use a direct approach: use the .diff URL directly with authentication
-	// We'll construct the URL and use Go's http package but with authentication from go-gh
-	diffURL := fmt.Sprintf("https://github.com/%s/%s/pull/%d.diff", owner, repo, prNumber)
-
-	// Create an HTTP request
-	req, err := http.NewRequest("GET", diffURL, nil)
-	if err != nil {
-		return fmt.Errorf("failed to create diff request: %v", err)
-	}
-
-	// Try to get authentication token from environment (same as go-gh uses)
-	if token := os.Getenv("GH_TOKEN"); token != "" {
-		req.Header.Set("Authorization", "token "+token)
-	} else if token := os.Getenv("GITHUB_TOKEN"); token != "" {
-		req.Header.Set("Authorization", "token "+token)
-	}
-
-	// Make the request
-	httpClient := &http.Client{}
-	resp, err := httpClient.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to fetch diff: %v", err)
-	}
-	defer func() { _ = resp.Body.Close() }()
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf("failed to fetch diff: HTTP %d", resp.StatusCode)
-	}
-
-	// Read the diff content
-	diffContent, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("failed to read diff: %v", err)
-	}
-
-	// Display the diff with color coding
-	fmt.Printf("\n📄 Diff for PR %s:\n", formatPRLink(owner, repo, prNumber))
-	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-
-	// Apply color coding to the diff (unless colors are disabled)
-	if shouldUseColors() {
-		colorizedDiff := colorizeGitDiff(string(diffContent))
-		fmt.Print(colorizedDiff)
-	} else {
-		fmt.Print(string(diffContent))
-	}
-
-	fmt.Printf("═══════════════════════════════════════════════════════════════\n")
-
-	return nil
-}
-
-// colorizeGitDiff adds ANSI color codes to diff output similar to git diff
-func colorizeGitDiff(diff string) string {
-	// ANSI color codes
-	const (
-		reset   = "\033[0m"
-		bold    = "\033[1m"
-		red     = "\033[31m"
-		green   = "\033[32m"
-		yellow  = "\033[33m"
-		blue    = "\033[34m"
-		magenta = "\033[35m"
-		cyan    = "\033[36m"
-		white   = "\033[37m"
-		dimGray = "\033[90m"
-	)
-
-	lines := strings.Split(diff, "\n")
-	var colorizedLines []string
-
-	for _, line := range lines {
-		switch {
-		case strings.HasPrefix(line, "diff --git"):
-			// File header - bold white
-			colorizedLines = append(colorizedLines, bold+white+line+reset)
-		case strings.HasPrefix(line, "index "):
-			// Index line - dim gray
-			colorizedLines = append(colorizedLines, dimGray+line+reset)
-		case strings.HasPrefix(line, "--- "):
-			// Old file - red
-			colorizedLines = append(colorizedLines, red+line+reset)
-		case strings.HasPrefix(line, "+++ "):
-			// New file - green
-			colorizedLines = append(colorizedLines, green+line+reset)
-		case strings.HasPrefix(line, "@@"):
-			// Hunk header - cyan
-			colorizedLines = append(colorizedLines, cyan+line+reset)
-		case strings.HasPrefix(line, "+"):
-			// Added lines - green
-			colorizedLines = append(colorizedLines, green+line+reset)
-		case strings.HasPrefix(line, "-"):
-			// Removed lines - red
-			colorizedLines = append(colorizedLines, red+line+reset)
-		case strings.HasPrefix(line, "new file mode"):
-			// New file mode - green
-			colorizedLines = append(colorizedLines, green+line+reset)
-		case strings.HasPrefix(line, "deleted file mode"):
-			// Deleted file mode - red
-			colorizedLines = append(colorizedLines, red+line+reset)
-		case strings.HasPrefix(line, "rename from") || strings.HasPrefix(line, "rename to"):
-			// Rename operations - yellow
-			colorizedLines = append(colorizedLines, yellow+line+reset)
-		case strings.HasPrefix(line, "similarity index") || strings.HasPrefix(line, "dissimilarity index"):
-			// Similarity index - dim gray
-			colorizedLines = append(colorizedLines, dimGray+line+reset)
-		default:
-			// Context lines - no color
-			colorizedLines = append(colorizedLines, line)
-		}
+		streams.Printf("      %s %s %s\n", statusColor, status, file.Filename)
 	}
-
-	return strings.Join(colorizedLines, "\n")
 }
 
 // shouldUseColors determines if we should colorize output
@@ -1822,406 +2392,322 @@ func shouldUseColors() bool {
 	}
 
 	// Check if output is going to a terminal
-	return term.IsTerminal(int(os.Stdout.Fd()))
+	return streams.IsTerminal()
 }
 
 // formatPRLink creates a clickable link for a PR number using OSC 8 escape sequences
 func formatPRLink(owner, repo string, prNumber int) string {
 	// Check if we should use terminal features (similar to color check)
-	if noColor || os.Getenv("NO_COLOR") != "" || !term.IsTerminal(int(os.Stdout.Fd())) {
+	if noColor || os.Getenv("NO_COLOR") != "" || !streams.IsTerminal() {
 		return fmt.Sprintf("#%d", prNumber)
 	}
 
-	url := fmt.Sprintf("https://github.com/%s/%s/pull/%d", owner, repo, prNumber)
+	url := prURL(owner, repo, prNumber)
 	return fmt.Sprintf("\033]8;;%s\033\\#%d\033]8;;\033\\", url, prNumber)
 }
 
-// truncateString truncates a string to a maximum display width with ellipsis
-func TruncateString(s string, maxWidth int) string {
-	if DisplayWidth(s) <= maxWidth {
-		return s
+// legend is the legend state of the current run
+var legend = render.NewLegend(render.LegendOnce)
+
+// displayPRTable displays PRs in a table format using an optional existing cache
+func displayPRTable(ctx context.Context, opts *listOptions, pullRequests []PullRequest, owner, repo string, client RESTClientInterface, isKonflux bool,
+	shouldDisplayLegend bool, cache *PRDetailsCache) *PRDetailsCache {
+	// Use existing cache or create a new one
+	if cache == nil {
+		cache = NewPRDetailsCache()
 	}
-	if maxWidth <= 3 {
-		// If maxWidth is very small, just truncate by runes
-		runes := []rune(s)
-		if len(runes) <= maxWidth {
-			return s
-		}
-		return string(runes[:maxWidth])
+
+	if len(pullRequests) == 0 {
+		return cache
 	}
 
-	// Truncate to fit within maxWidth - 3 (for "...")
-	targetWidth := maxWidth - 3
-	runes := []rune(s)
-	currentWidth := 0
+	rows := buildPRRows(ctx, opts, pullRequests, owner, repo, client, isKonflux, cache)
+	renderPRTable(opts, rows, owner, repo, isKonflux, shouldDisplayLegend)
 
-	for i, r := range runes {
-		charWidth := 1
-		if r >= 0x1F600 && r <= 0x1F64F || // Emoticons
-			r >= 0x1F300 && r <= 0x1F5FF || // Misc Symbols and Pictographs
-			r >= 0x1F680 && r <= 0x1F6FF || // Transport and Map
-			r >= 0x1F1E0 && r <= 0x1F1FF || // Regional indicators
-			r >= 0x2600 && r <= 0x26FF || // Misc symbols
-			r >= 0x2700 && r <= 0x27BF { // Dingbats
-			charWidth = 2
-		}
+	// Return the cache for potential reuse in approval flow
+	return cache
+}
 
-		if currentWidth+charWidth > targetWidth {
-			return string(runes[:i]) + "..."
-		}
-		currentWidth += charWidth
+// buildPRRows gathers everything shown in the PR table into structured rows, making any API calls needed
+func buildPRRows(ctx context.Context, opts *listOptions, pullRequests []PullRequest, owner, repo string, client RESTClientInterface, isKonflux bool, cache *PRDetailsCache) []PRRow {
+	if cache == nil {
+		cache = NewPRDetailsCache()
 	}
 
-	return s
+	// Each row needs several API calls, so enrich PRs in parallel
+	// Rows past the request budget of the repository are only partly loaded
+	withinBudget := rowsWithinBudget(opts, len(pullRequests), isKonflux)
+	rows := make([]PRRow, len(pullRequests))
+	runConcurrently(len(pullRequests), opts.Concurrency, func(i int) {
+		rows[i] = buildPRRow(ctx, opts, pullRequests[i], owner, repo, client, isKonflux, cache, i >= withinBudget)
+	})
+	return rows
 }
 
-// displayWidth calculates the visual width of a string in the terminal
-func DisplayWidth(s string) int {
-	// Remove ANSI escape sequences (including OSC 8 sequences for links)
-	cleanString := StripANSISequences(s)
-
-	width := 0
-	for _, r := range cleanString {
-		// Most emojis and some Unicode characters take 2 character widths
-		if r >= 0x1F600 && r <= 0x1F64F || // Emoticons
-			r >= 0x1F300 && r <= 0x1F5FF || // Misc Symbols and Pictographs
-			r >= 0x1F680 && r <= 0x1F6FF || // Transport and Map
-			r >= 0x1F7E0 && r <= 0x1F7EB || // Geometric Shapes Extended (colored circles)
-			r >= 0x1F1E0 && r <= 0x1F1FF || // Regional indicators
-			r >= 0x2600 && r <= 0x26FF || // Misc symbols
-			r >= 0x2700 && r <= 0x27BF || // Dingbats
-			r == 0x200D || // Zero width joiner
-			r >= 0xFE0F && r <= 0xFE0F { // Variation selectors
-			width += 2
-		} else if r >= 0x20 { // Printable ASCII and most Unicode
-			width += 1
+// buildPRRow gathers the table data for a single PR. A partial row skips the API calls, as in fast mode.
+func buildPRRow(ctx context.Context, opts *listOptions, pr PullRequest, owner, repo string, client RESTClientInterface, isKonflux bool, cache *PRDetailsCache, partial bool) PRRow {
+	row := PRRow{
+		Number:    pr.Number,
+		Title:     pr.Title,
+		Author:    pr.User.Login,
+		URL:       prURL(owner, repo, pr.Number),
+		Branch:    pr.Head.Ref,
+		Target:    pr.Base.Ref,
+		State:     pr.State,
+		Merged:    pr.Merged || pr.MergedAt != "",
+		CreatedAt: pr.CreatedAt,
+		UpdatedAt: pr.UpdatedAt,
+		Stale:     isStalePR(pr, time.Now()),
+		Draft:     pr.Draft,
+		OnHold:    isOnHold(pr),
+		Nudge:     isKonfluxNudge(pr),
+		Security:  hasSecurity(pr),
+		Migration: hasMigrationWarning(pr),
+		New:       seenPRs.isNew(owner+"/"+repo, pr),
+		Partial:   partial && !opts.Fast,
+	}
+	skipAPI := opts.Fast || partial
+	if pr.HTMLURL != "" {
+		row.URL = pr.HTMLURL
+	}
+	if isKonflux {
+		if mapping, ok := konfluxComponentFor(owner, repo, pr.Base.Ref); ok {
+			row.Application, row.Component = mapping.Application, mapping.Component
 		}
-		// Control characters (< 0x20) don't add width
 	}
-	return width
-}
-
-// stripANSISequences removes ANSI escape sequences from a string
-func StripANSISequences(s string) string {
-	result := strings.Builder{}
-	i := 0
-	runes := []rune(s)
-
-	for i < len(runes) {
-		if runes[i] == '\033' && i+1 < len(runes) { // ESC character
-			i++ // Skip the ESC
+	// The dependencies come from the body the list already has, so fast mode shows them too
+	row.Dependencies = ghprs.ParseDependencyUpdates(pr.Title, pr.Body)
+	row.UpdateType = ghprs.HighestUpdateType(row.Dependencies)
 
-			if i < len(runes) && runes[i] == ']' { // OSC sequence (like ]8;;URL\033\\)
-				i++ // Skip the ]
-				// Skip everything until we find the terminator
-				for i < len(runes) {
-					if runes[i] == '\007' { // BEL terminator
-						i++
-						break
-					} else if runes[i] == '\033' && i+1 < len(runes) && runes[i+1] == '\\' { // ST terminator
-						i += 2 // Skip \033\
-						break
-					}
-					i++
-				}
-			} else if i < len(runes) && runes[i] == '[' { // CSI sequence (like [31m)
-				i++ // Skip the [
-				// Skip until we find the final byte (@ to ~)
-				for i < len(runes) {
-					if runes[i] >= 0x40 && runes[i] <= 0x7E {
-						i++
-						break
-					}
-					i++
-				}
-			} else {
-				// Other escape sequences, skip until final byte
-				for i < len(runes) {
-					if runes[i] >= 0x40 && runes[i] <= 0x7E {
-						i++
-						break
-					}
-					i++
-				}
-			}
+	// Check for Tekton files if this is a Konflux PR (skip in fast mode)
+	// Note: This may be redundant if already filtered, but needed for display logic
+	if isKonflux && !skipAPI {
+		onlyTektonFiles, _, err := checkTektonFilesDetailed(ctx, client, owner, repo, pr.Number)
+		if err == nil {
+			row.TektonOnly = &onlyTektonFiles
 		} else {
-			result.WriteRune(runes[i])
-			i++
+			// Leave unknown if we can't check Tekton files for table display
+			recordLookupError(&row, lookupFiles, err)
 		}
 	}
 
-	return result.String()
-}
+	// Determine reviewed status (skip expensive API call in fast mode)
+	if skipAPI {
+		// In fast mode, only check labels (no API call to fetch reviews)
+		if hasApprovedLabel(pr.Labels) {
+			row.Reviewed = boolPtr(true)
+		}
+	} else if reviewed, err := fetchReviewed(client, owner, repo, pr.Number, pr.Labels); err == nil {
+		row.Reviewed = boolPtr(reviewed)
+	} else {
+		// Unknown rather than not reviewed
+		recordLookupError(&row, lookupReviews, err)
+	}
 
-// padString pads a string to a specific width, accounting for actual display width
-func PadString(s string, width int) string {
-	currentWidth := DisplayWidth(s)
-	if currentWidth >= width {
-		return s
+	// Determine rebase and blocked status and the size of the changes (skip in fast mode)
+	if !skipAPI {
+		if needsRebase, hasState := needsRebaseWithCache(cache, client, owner, repo, pr); hasState {
+			row.NeedsRebase = boolPtr(needsRebase)
+		}
+		if isBlocked, hasState := isBlockedWithCache(cache, client, owner, repo, pr); hasState {
+			row.Blocked = boolPtr(isBlocked)
+		}
+		// The details are cached by now unless GitHub hasn't computed the mergeable state yet
+		if fullPR := cache.GetOrFetch(client, owner, repo, pr.Number, pr); hasChangeCounts(*fullPR) {
+			row.Additions, row.Deletions, row.ChangedFiles = fullPR.Additions, fullPR.Deletions, fullPR.ChangedFiles
+			row.Size = prSize(fullPR.Additions + fullPR.Deletions)
+		}
+		if err := cache.FetchError(pr.Number); err != nil {
+			recordLookupError(&row, lookupDetails, err)
+		}
+		// Repositories without a CODEOWNERS file cost a lookup of it per target branch, not per PR
+		if owners, err := codeOwnersOf(ctx, client, owner, repo, pr); err == nil {
+			row.Owners = owners
+		} else {
+			recordLookupError(&row, lookupOwners, err)
+		}
 	}
-	padding := width - currentWidth
-	return s + strings.Repeat(" ", padding)
-}
 
-// displayLegend shows what the various emojis and symbols mean in the table
-func displayLegend(isKonflux bool) {
-	fmt.Println("\nLegend:")
-	fmt.Println("  Status: 🟢 open  🟡 draft  🔶 on hold  🔴 closed  🟣 merged")
-	fmt.Println("  Reviewed: ✅ approved  ❌ not approved  - labels only (fast mode)")
-	fmt.Println("  Rebase: 🔄 needs rebase  ? unknown  - skipped (fast mode)  (empty = up to date)")
-	fmt.Println("  Blocked: 🚫 blocked from merging  ? unknown  - skipped (fast mode)  (empty = not blocked)")
-	fmt.Println("  Nudge: 👉 konflux nudge PR  (empty = not a nudge)")
-	fmt.Println("  Security: 🔒 security/CVE update  (empty = not security)")
-	if isKonflux {
-		fmt.Println("  Tekton: ✅ exclusively Tekton files  ❌ mixed/other files  - skipped (fast mode)")
-		fmt.Println("  🚨 = migration warning")
+	// Roll everything up into one readiness state, which also needs the checks (skip fetching them in fast mode).
+	// The checks are fetched up front for --interactive too, so the green-checks filter needs no API call.
+	if (opts.readinessRequested() || opts.Interactive) && !skipAPI {
+		// Checks that were only partly fetched could hide a failure, so they are unknown
+		if status, err := fetchCheckStatus(ctx, client, owner, repo, pr.Head.SHA); err == nil {
+			row.Checks = checksSummary(status)
+		} else {
+			recordLookupError(&row, lookupChecks, err)
+		}
+	}
+	if opts.readinessRequested() {
+		row.Readiness = prReadiness(row, pr)
 	}
-	fmt.Println()
+
+	return row
 }
 
-// displayPRTableWithCache displays PRs in a table format using an optional existing cache
-func displayPRTable(pullRequests []PullRequest, owner, repo string, client RESTClientInterface, isKonflux bool,
-	shouldDisplayLegend bool, cache *PRDetailsCache) *PRDetailsCache {
-	// Use existing cache or create a new one
-	if cache == nil {
-		cache = NewPRDetailsCache()
-	}
+// boolPtr returns a pointer to a bool value, used for tri-state row fields
+func boolPtr(b bool) *bool {
+	return &b
+}
 
-	if len(pullRequests) == 0 {
-		return cache
+// renderPRTable prints previously built rows as a table in the view of opts
+func renderPRTable(opts *listOptions, rows []PRRow, owner, repo string, isKonflux bool, shouldDisplayLegend bool) {
+	defer reportLookupErrors(rows, owner, repo)
+	defer reportPartialRows(rows, owner, repo)
+	if opts.View == ViewReadiness {
+		renderReadinessTable(rows, owner, repo, isKonflux, shouldDisplayLegend, opts.Fast)
+		return
 	}
 
 	// Display legend first if requested
 	if shouldDisplayLegend {
-		displayLegend(isKonflux)
+		render.WriteLegend(streams.Out, isKonflux)
 	}
 
 	// Display header
 	if isKonflux {
-		fmt.Printf("\n=== %s: Konflux PRs ===\n", repo)
+		streams.Printf("\n=== %s: Konflux PRs ===\n", repo)
 	} else {
-		fmt.Printf("\n=== %s: PRs ===\n", repo)
+		streams.Printf("\n=== %s: PRs ===\n", repo)
 	}
 
-	// Define column widths - compact but readable
+	// Define column widths - compact but readable, with the text columns configurable
+	titleWidth, authorWidth, branchWidth, targetWidth := textColumnWidths(rows)
 	const (
 		statusWidth   = 2  // Emoji width
 		prWidth       = 6  // "#1234"
-		titleWidth    = 41 // Full title width
-		authorWidth   = 16 // Author names
-		branchWidth   = 14 // Source branch names
-		targetWidth   = 12 // Target branch names
 		stateWidth    = 10 // "STATUS"
 		reviewedWidth = 8  // "REVIEWED"
 		rebaseWidth   = 6  // "REBASE"
 		blockedWidth  = 7  // "BLOCKED"
 		nudgeWidth    = 5  // "NUDGE"
 		securityWidth = 8  // "SECURITY"
-		tektonWidth   = 6  // "TEKTON"
+		ageWidth      = 7  // "120d 🐌"
+		sizeWidth     = 4  // "SIZE"
+	)
+	now := time.Now()
+	table := render.NewTable(
+		render.Column{Header: "REPO", Width: repoColumnWidth(rows), Truncate: true},
+		render.Column{Header: "COMPONENT", Width: componentColumnWidth(rows), Truncate: true},
+		render.Column{Header: "ST", Width: statusWidth},
+		render.Column{Header: "PR", Width: prWidth},
+		render.Column{Header: "TITLE", Width: titleWidth, Truncate: true},
+		render.Column{Header: "AUTHOR", Width: authorWidth, Truncate: true},
+		render.Column{Header: "BRANCH", Width: branchWidth, Truncate: true},
+		render.Column{Header: "TARGET", Width: targetWidth, Truncate: true},
+		render.Column{Header: "OWNERS", Width: ownersColumnWidth(rows), Truncate: true},
+		render.Column{Header: "AGE", Width: ageWidth},
+		render.Column{Header: "SIZE", Width: sizeWidth},
+		render.Column{Header: "DEP", Width: dependencyColumnWidth(rows), Truncate: true},
+		render.Column{Header: "SEMVER", Width: semverColumnWidth(rows), Truncate: true},
+		render.Column{Header: "STATUS", Width: stateWidth, Truncate: true},
+		render.Column{Header: "REVIEWED", Width: reviewedWidth},
+		render.Column{Header: "REBASE", Width: rebaseWidth},
+		render.Column{Header: "BLOCKED", Width: blockedWidth},
+		render.Column{Header: "NUDGE", Width: nudgeWidth},
+		render.Column{Header: "SECURITY", Width: securityWidth},
+		render.Column{Header: "TEKTON", Width: tektonColumnWidth(isKonflux)},
 	)
-
-	// Print table header
-	fmt.Printf("%s %s %s %s %s %s %s %s %s %s %s %s",
-		PadString("ST", statusWidth),
-		PadString("PR", prWidth),
-		PadString("TITLE", titleWidth),
-		PadString("AUTHOR", authorWidth),
-		PadString("BRANCH", branchWidth),
-		PadString("TARGET", targetWidth),
-		PadString("STATUS", stateWidth),
-		PadString("REVIEWED", reviewedWidth),
-		PadString("REBASE", rebaseWidth),
-		PadString("BLOCKED", blockedWidth),
-		PadString("NUDGE", nudgeWidth),
-		PadString("SECURITY", securityWidth))
-	if isKonflux {
-		fmt.Printf(" %s", PadString("TEKTON", tektonWidth))
-	}
-	fmt.Printf("\n")
-
-	// Print separator line
-	fmt.Printf("%s %s %s %s %s %s %s %s %s %s %s %s",
-		PadString(strings.Repeat("-", statusWidth), statusWidth),
-		PadString(strings.Repeat("-", prWidth), prWidth),
-		PadString(strings.Repeat("-", titleWidth), titleWidth),
-		PadString(strings.Repeat("-", authorWidth), authorWidth),
-		PadString(strings.Repeat("-", branchWidth), branchWidth),
-		PadString(strings.Repeat("-", targetWidth), targetWidth),
-		PadString(strings.Repeat("-", stateWidth), stateWidth),
-		PadString(strings.Repeat("-", reviewedWidth), reviewedWidth),
-		PadString(strings.Repeat("-", rebaseWidth), rebaseWidth),
-		PadString(strings.Repeat("-", blockedWidth), blockedWidth),
-		PadString(strings.Repeat("-", nudgeWidth), nudgeWidth),
-		PadString(strings.Repeat("-", securityWidth), securityWidth))
-	if isKonflux {
-		fmt.Printf(" %s", PadString(strings.Repeat("-", tektonWidth), tektonWidth))
-	}
-	fmt.Printf("\n")
 
 	// Display each PR as a table row (PRs are already filtered)
-	for _, pr := range pullRequests {
-		// Check for Tekton files if this is a Konflux PR (skip in fast mode)
-		// Note: This may be redundant if already filtered, but needed for display logic
-		onlyTektonFiles := false
-		if isKonflux && !fastMode {
-			var err error
-			onlyTektonFiles, _, err = checkTektonFilesDetailed(client, owner, repo, pr.Number)
-			if err != nil {
-				// Silently continue if we can't check Tekton files for table display
-				// Error is intentionally ignored for display purposes
-				_ = err
-			}
-		}
-
-		// Check for migration warnings (needed for display)
-		hasMigration := hasMigrationWarning(pr)
-
-		// Get status icon
-		var icon string
-		if isKonflux {
-			icon = getStatusIconWithTekton(pr, onlyTektonFiles)
-		} else {
-			icon = getStatusIcon(pr)
-		}
-
-		// Prepare table data
-		prLink := formatPRLink(owner, repo, pr.Number)
-		title := TruncateString(pr.Title, titleWidth)
-		author := TruncateString(pr.User.Login, authorWidth)
-		branch := TruncateString(pr.Head.Ref, branchWidth)
-		target := TruncateString(pr.Base.Ref, targetWidth)
-
+	for _, row := range rows {
 		// Determine status text
 		status := ""
-		if pr.Draft {
+		if row.Draft {
 			status = "draft"
-		} else if isOnHold(pr) {
+		} else if row.OnHold {
 			status = "on hold"
 		} else {
-			status = pr.State
+			status = row.State
 		}
-		if hasMigration {
+		if row.Migration {
 			status += " 🚨"
 		}
-		status = TruncateString(status, stateWidth)
 
-		// Determine reviewed status (skip expensive API call in fast mode)
-		reviewedStatus := ""
-		if fastMode {
-			// In fast mode, only check labels (no API call to fetch reviews)
-			if hasApprovedLabel(pr.Labels) {
-				reviewedStatus = "✅"
-			} else {
-				reviewedStatus = "-" // Unknown in fast mode
-			}
-		} else {
-			if isReviewed(client, owner, repo, pr.Number, pr.Labels) {
+		// Reviewed is unknown in fast mode, where it is based on labels alone, and when the reviews couldn't be fetched
+		reviewedStatus := "-"
+		if row.Reviewed != nil {
+			if *row.Reviewed {
 				reviewedStatus = "✅"
 			} else {
 				reviewedStatus = "❌"
 			}
 		}
 
-		// Determine rebase status (skip in fast mode)
-		rebaseStatus := ""
-		if fastMode {
-			rebaseStatus = "-" // Skip in fast mode
-		} else {
-			needsRebase, hasState := needsRebaseWithCache(cache, client, owner, repo, pr)
-			if !hasState {
-				rebaseStatus = "?" // Unknown state (API limit/error)
-			} else if needsRebase {
-				rebaseStatus = "🔄"
-			}
-			// Leave empty if no rebase needed and state is valid
-		}
-
-		// Determine blocked status (skip in fast mode)
-		blockedStatus := ""
-		if fastMode {
-			blockedStatus = "-" // Skip in fast mode
-		} else {
-			isBlocked, hasState := isBlockedWithCache(cache, client, owner, repo, pr)
-			if !hasState {
-				blockedStatus = "?" // Unknown state (API limit/error)
-			} else if isBlocked {
-				blockedStatus = "🚫"
-			}
-			// Leave empty if not blocked and state is valid
-		}
-
 		// Determine nudge status
 		nudgeStatus := ""
-		if isKonfluxNudge(pr) {
+		if row.Nudge {
 			nudgeStatus = "👉"
 		}
 
 		// Determine security status
 		securityStatus := ""
-		if hasSecurity(pr) {
+		if row.Security {
 			securityStatus = "🔒"
 		}
 
-		// Print the row with proper padding
-		fmt.Printf("%s %s %s %s %s %s %s %s %s %s %s %s",
-			PadString(icon, statusWidth),
-			PadString(prLink, prWidth),
-			PadString(title, titleWidth),
-			PadString(author, authorWidth),
-			PadString(branch, branchWidth),
-			PadString(target, targetWidth),
-			PadString(status, stateWidth),
-			PadString(reviewedStatus, reviewedWidth),
-			PadString(rebaseStatus, rebaseWidth),
-			PadString(blockedStatus, blockedWidth),
-			PadString(nudgeStatus, nudgeWidth),
-			PadString(securityStatus, securityWidth))
-
-		if isKonflux {
-			tektonStatus := ""
-			if onlyTektonFiles {
-				tektonStatus = "✅"
-			} else {
-				tektonStatus = "❌"
-			}
-			fmt.Printf(" %s", PadString(tektonStatus, tektonWidth))
-		}
+		// Leave rebase/blocked empty when the state is valid and there is nothing to flag
+		table.AddRow(
+			row.Repository,
+			row.Component,
+			statusIcon(row.State, row.Draft, row.OnHold),
+			rowPRLink(row, owner, repo),
+			rowTitle(row),
+			row.Author,
+			row.Branch,
+			row.Target,
+			lookupColumn(row, lookupOwners, ownersColumn(row)),
+			ageColumn(row, now),
+			lookupColumn(row, lookupDetails, sizeColumn(row, opts.Fast)),
+			dependencyColumn(row),
+			semverColumn(row),
+			status,
+			lookupColumn(row, lookupReviews, reviewedStatus),
+			lookupColumn(row, lookupDetails, triStateColumn(row.NeedsRebase, "🔄", opts.Fast)),
+			lookupColumn(row, lookupDetails, triStateColumn(row.Blocked, "🚫", opts.Fast)),
+			nudgeStatus,
+			securityStatus,
+			lookupColumn(row, lookupFiles, tektonColumn(row, opts.Fast)))
+	}
+	table.Write(streams.Out)
+}
 
-		fmt.Printf("\n")
+// tektonColumnWidth returns the width of the TEKTON column, which only Konflux tables have
+func tektonColumnWidth(isKonflux bool) int {
+	if !isKonflux {
+		return 0
 	}
+	return 6 // "TEKTON"
+}
 
-	// Return the cache for potential reuse in approval flow
-	return cache
+// tektonColumn renders whether a Konflux PR only changes Tekton files, "-" when skipped in fast mode
+func tektonColumn(row PRRow, fast bool) string {
+	switch {
+	case row.TektonOnly == nil && fast:
+		return "-"
+	case row.TektonOnly != nil && *row.TektonOnly:
+		return "✅"
+	default:
+		return "❌"
+	}
+}
+
+// triStateColumn renders a tri-state flag: icon when set, empty when clear,
+// "-" when skipped in fast mode and "?" when the state could not be determined
+func triStateColumn(value *bool, icon string, fast bool) string {
+	if value == nil {
+		if fast {
+			return "-"
+		}
+		return "?"
+	}
+	if *value {
+		return icon
+	}
+	return ""
 }
 
 func init() {
 	RootCmd.AddCommand(listCmd)
 	RootCmd.AddCommand(konfluxCmd)
-
-	// Add flags to both commands
-	listCmd.Flags().StringVarP(&state, "state", "s", "open", "Filter by state: open, closed, all")
-	listCmd.Flags().IntVarP(&limit, "limit", "l", 30, "Maximum number of pull requests to show (when using text filters, more PRs are fetched to avoid missing results)")
-	listCmd.Flags().BoolVarP(&current, "current", "c", false, "Use current repository, bypass config")
-	listCmd.Flags().StringVar(&sortBy, "sort-by", "", "Sort PRs by: newest (default), oldest, updated, number, priority (security updates first)")
-	listCmd.Flags().BoolVarP(&approve, "approve", "a", false, "Interactively approve pull requests (review + /lgtm comment)")
-	listCmd.Flags().BoolVarP(&securityOnly, "security-only", "", false, "Show only PRs that contain security updates (SECURITY or CVE in title)")
-	listCmd.Flags().StringVar(&targetBranch, "target-branch", "", "Filter PRs by target branch (e.g., main, dev, release/v1.0)")
-	listCmd.Flags().BoolVar(&fastMode, "fast", false, "Fast mode: skip expensive API calls (rebase, blocked, review status)")
-	listCmd.Flags().BoolVarP(&showFiles, "show-files", "f", false, "Show detailed file list during approval process")
-	listCmd.Flags().BoolVarP(&showDiff, "show-diff", "d", false, "Show detailed diff during approval process")
-	listCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable color output in diff display")
-
-	konfluxCmd.Flags().StringVarP(&state, "state", "s", "open", "Filter by state: open, closed, all")
-	konfluxCmd.Flags().IntVarP(&limit, "limit", "l", 30, "Maximum number of pull requests to show (when using text filters, more PRs are fetched to avoid missing results)")
-	konfluxCmd.Flags().BoolVarP(&current, "current", "c", false, "Use current repository, bypass config")
-	konfluxCmd.Flags().BoolVarP(&approve, "approve", "a", false, "Interactively approve Konflux pull requests (review + /lgtm comment)")
-	konfluxCmd.Flags().BoolVarP(&tektonOnly, "tekton-only", "t", false, "Show only PRs that EXCLUSIVELY modify Tekton files (.tekton/*-pull-request.yaml or *-push.yaml)")
-	konfluxCmd.Flags().BoolVarP(&migrationOnly, "migration-only", "m", false, "Show only PRs that contain migration warnings")
-	konfluxCmd.Flags().BoolVarP(&securityOnly, "security-only", "", false, "Show only PRs that contain security updates (SECURITY or CVE in title)")
-	konfluxCmd.Flags().StringVar(&targetBranch, "target-branch", "", "Filter PRs by target branch (e.g., main, dev, release/v1.0)")
-	konfluxCmd.Flags().BoolVar(&fastMode, "fast", false, "Fast mode: skip expensive API calls (rebase, blocked, review status, Tekton file checks)")
-	konfluxCmd.Flags().StringVar(&sortBy, "sort-by", "", "Sort PRs by: newest (default), oldest, updated, number, priority (security updates first)")
-	konfluxCmd.Flags().BoolVarP(&showFiles, "show-files", "f", false, "Show detailed file list during approval process")
-	konfluxCmd.Flags().BoolVarP(&showDiff, "show-diff", "d", false, "Show detailed diff during approval process")
-	konfluxCmd.Flags().BoolVar(&noColor, "no-color", false, "Disable color output in diff display")
 }
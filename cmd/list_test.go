@@ -5,6 +5,8 @@ import (
 	. "github.com/onsi/gomega"
 
 	"ghprs/cmd"
+
+	"ghprs/internal/render"
 )
 
 var _ = Describe("Listing Functionality", func() {
@@ -603,4 +605,44 @@ var _ = Describe("Listing Functionality", func() {
 			Expect(cmd.GetStatusIconTest(cleanPR)).To(Equal("🟢")) // Green for ready
 		})
 	})
+
+	Describe("Legend visibility", func() {
+		AfterEach(func() {
+			cmd.ResetLegendTest(render.LegendOnce)
+		})
+
+		It("should show the legend only before the first table by default", func() {
+			cmd.ResetLegendTest(render.LegendOnce)
+			Expect(cmd.TakeLegendTest()).To(BeTrue())
+			Expect(cmd.TakeLegendTest()).To(BeFalse())
+			Expect(cmd.TakeLegendTest()).To(BeFalse())
+		})
+
+		It("should show the legend again after a new run starts", func() {
+			cmd.ResetLegendTest(render.LegendOnce)
+			Expect(cmd.TakeLegendTest()).To(BeTrue())
+			cmd.ResetLegendTest(render.LegendOnce)
+			Expect(cmd.TakeLegendTest()).To(BeTrue())
+		})
+
+		It("should show the legend before every table in always mode", func() {
+			cmd.ResetLegendTest(render.LegendAlways)
+			Expect(cmd.TakeLegendTest()).To(BeTrue())
+			Expect(cmd.TakeLegendTest()).To(BeTrue())
+		})
+
+		It("should never show the legend in never mode", func() {
+			cmd.ResetLegendTest(render.LegendNever)
+			Expect(cmd.TakeLegendTest()).To(BeFalse())
+		})
+
+		It("should fall back to once for unset or invalid config values", func() {
+			config := cmd.DefaultConfig()
+			Expect(config.LegendMode()).To(Equal(render.LegendOnce))
+			config.Display.Legend = "sometimes"
+			Expect(config.LegendMode()).To(Equal(render.LegendOnce))
+			config.Display.Legend = render.LegendNever
+			Expect(config.LegendMode()).To(Equal(render.LegendNever))
+		})
+	})
 })
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// logLevel is the level logged to stderr: warnings by default, progress and timing with --verbose,
+// every API call and cache lookup with --debug
+var logLevel = new(slog.LevelVar)
+
+// logger logs diagnostics to stderr, keeping stdout for tables and structured output
+var logger = slog.New(slog.NewTextHandler(errOutWriter{}, &slog.HandlerOptions{
+	Level: logLevel,
+	ReplaceAttr: func(groups []string, attr slog.Attr) slog.Attr {
+		// A CLI run is short, the time of each line is noise
+		if len(groups) == 0 && attr.Key == slog.TimeKey {
+			return slog.Attr{}
+		}
+		return attr
+	},
+}))
+
+// errOutWriter writes to the current ErrOut stream, so logs follow the streams when they are replaced
+type errOutWriter struct{}
+
+func (errOutWriter) Write(p []byte) (int, error) {
+	return streams.ErrOut.Write(p)
+}
+
+// setupLogging sets the log level from --verbose and --debug
+func setupLogging() {
+	switch {
+	case debugMode:
+		logLevel.Set(slog.LevelDebug)
+	case verbose:
+		logLevel.Set(slog.LevelInfo)
+	default:
+		logLevel.Set(slog.LevelWarn)
+	}
+}
+
+// loggingRESTClient logs every API request with its status, duration and the remaining quota at debug level
+type loggingRESTClient struct {
+	RESTClientInterface
+}
+
+// withAPILogging wraps the raw REST client so every request is logged
+func withAPILogging(client RESTClientInterface) RESTClientInterface {
+	return &loggingRESTClient{RESTClientInterface: client}
+}
+
+// Request performs a request and logs it
+func (c *loggingRESTClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+// RequestWithContext performs a request and logs it
+func (c *loggingRESTClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	start := time.Now()
+	resp, err := c.RESTClientInterface.RequestWithContext(ctx, method, path, body)
+	if !logger.Enabled(ctx, slog.LevelDebug) {
+		return resp, err
+	}
+
+	attrs := []any{"method", method, "path", path, "duration", time.Since(start).Round(time.Millisecond)}
+	var header http.Header
+	var httpErr *api.HTTPError
+	switch {
+	case resp != nil:
+		attrs = append(attrs, "status", resp.StatusCode)
+		header = resp.Header
+	case errors.As(err, &httpErr):
+		attrs = append(attrs, "status", httpErr.StatusCode)
+		header = httpErr.Headers
+	case err != nil:
+		attrs = append(attrs, "error", err)
+	}
+	if remaining := header.Get("X-RateLimit-Remaining"); remaining != "" {
+		attrs = append(attrs, "quota_remaining", remaining)
+	}
+	logger.DebugContext(ctx, "API request", attrs...)
+	return resp, err
+}
@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"bytes"
+	"net/http"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Logging", func() {
+	var (
+		mockClient  *cmd.MockRESTClient
+		out, errOut *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out, errOut = &bytes.Buffer{}, &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), nil)
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponseWithHeaders("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"},
+			http.Header{"X-Ratelimit-Remaining": []string{"4321"}})
+	})
+
+	AfterEach(func() {
+		cmd.SetLogFlagsTest(false, false)
+		cmd.ResetIOStreams()
+	})
+
+	It("should log warnings to stderr by default and nothing else", func() {
+		cmd.SetLogFlagsTest(false, false)
+		var pr cmd.PullRequest
+		Expect(cmd.WithAPILoggingTest(mockClient).Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		cmd.LogWarningTest("something is off")
+
+		Expect(out.String()).To(BeEmpty())
+		Expect(errOut.String()).To(Equal("level=WARN msg=\"something is off\"\n"))
+	})
+
+	It("should log every API call with its status, timing and quota with --debug", func() {
+		cmd.SetLogFlagsTest(false, true)
+		var pr cmd.PullRequest
+		Expect(cmd.WithTolerantDecodingTest(cmd.WithAPILoggingTest(mockClient)).Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+
+		Expect(out.String()).To(BeEmpty())
+		Expect(errOut.String()).To(ContainSubstring("level=DEBUG msg=\"API request\" method=GET path=repos/owner/repo/pulls/1 duration="))
+		Expect(errOut.String()).To(ContainSubstring("status=200 quota_remaining=4321"))
+		Expect(errOut.String()).To(ContainSubstring("msg=\"API response\""))
+	})
+
+	It("should log cache hits and misses with --debug", func() {
+		cmd.SetLogFlagsTest(false, true)
+		checksPath := "repos/owner/repo/commits/abc123/check-runs"
+		mockClient.AddResponse(checksPath, 200, cmd.CreateMockCheckRuns(1, 0, 0))
+		client := cmd.WithDiskCacheTest(mockClient, GinkgoT().TempDir(), time.Hour)
+
+		var checks cmd.CheckRunsResponse
+		Expect(client.Get(checksPath, &checks)).To(Succeed())
+		Expect(client.Get(checksPath, &checks)).To(Succeed())
+
+		Expect(errOut.String()).To(ContainSubstring("msg=\"Cache miss\" path=" + checksPath))
+		Expect(errOut.String()).To(ContainSubstring("msg=\"Cache hit\" path=" + checksPath))
+	})
+
+	It("should not log API calls with --verbose", func() {
+		cmd.SetLogFlagsTest(true, false)
+		var pr cmd.PullRequest
+		Expect(cmd.WithAPILoggingTest(mockClient).Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(errOut.String()).To(BeEmpty())
+	})
+})
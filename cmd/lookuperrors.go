@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"maps"
+	"slices"
+	"strings"
+)
+
+// The lookups filling in the details of a row. When one fails, the columns it fills in show errorCell instead
+// of a value that would read as a real answer, such as ❌ for reviews that couldn't be fetched.
+const (
+	// lookupReviews fills in REVIEWED
+	lookupReviews = "reviews"
+	// lookupDetails fills in REBASE, BLOCKED and SIZE
+	lookupDetails = "details"
+	// lookupFiles fills in TEKTON
+	lookupFiles = "files"
+	// lookupOwners fills in OWNERS
+	lookupOwners = "owners"
+	// lookupChecks fills in the checks the readiness depends on
+	lookupChecks = "checks"
+)
+
+// errorCell is shown in the columns of a lookup that failed
+const errorCell = "err"
+
+// recordLookupError remembers that a lookup of a row failed, so its columns show errorCell and the footnote
+// under the table tells why
+func recordLookupError(row *PRRow, lookup string, err error) {
+	if row.Errors == nil {
+		row.Errors = make(map[string]string)
+	}
+	row.Errors[lookup] = err.Error()
+	logger.Debug("Could not determine a column", "pr", row.Number, "lookup", lookup, "error", err)
+}
+
+// lookupColumn renders the cell of a column filled in by lookup: errorCell when the lookup failed, else cell
+func lookupColumn(row PRRow, lookup, cell string) string {
+	if _, failed := row.Errors[lookup]; failed {
+		return errorCell
+	}
+	return cell
+}
+
+// plainErrors lists the lookups of a row that failed for plain output, separated by commas
+func plainErrors(row PRRow) string {
+	return strings.Join(slices.Sorted(maps.Keys(row.Errors)), ",")
+}
+
+// reportLookupErrors summarizes under a table the lookups that failed, per lookup the PRs it failed for and the
+// first error, so "not reviewed" can be told from "couldn't find out"
+func reportLookupErrors(rows []PRRow, owner, repo string) {
+	failed := make(map[string][]string)
+	reasons := make(map[string]string)
+	for _, row := range rows {
+		for lookup, reason := range row.Errors {
+			failed[lookup] = append(failed[lookup], rowPRLink(row, owner, repo))
+			if _, ok := reasons[lookup]; !ok {
+				reasons[lookup] = reason
+			}
+		}
+	}
+	if len(failed) == 0 {
+		return
+	}
+	streams.Printf("⚠️  Some values could not be determined and show as %s:\n", errorCell)
+	for _, lookup := range slices.Sorted(maps.Keys(failed)) {
+		streams.Printf("   %s of %s: %s\n", lookup, strings.Join(failed[lookup], ", "), firstLine(reasons[lookup]))
+	}
+	streams.Printf("   Run again to retry them, with --debug to see the failed requests\n")
+}
+
+// firstLine returns the first line of a message
+func firstLine(message string) string {
+	first, _, _ := strings.Cut(message, "\n")
+	return first
+}
@@ -0,0 +1,105 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Failed lookups", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+	pr := cmd.PullRequest{Number: 7, Title: "Update deps", State: "open", Head: cmd.Branch{Ref: "deps", SHA: "abc"}, Base: cmd.Branch{Ref: "main"}}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/7/reviews", 200, cmd.CreateMockReviews(false))
+		mockClient.AddResponse("repos/owner/repo/pulls/7/files", 200, cmd.CreateMockPRFiles(true))
+		mockClient.AddResponse("repos/owner/repo/pulls/7", 200, cmd.PullRequest{Number: 7, MergeableState: "clean", Additions: 3})
+		mockClient.AddErrorResponse("repos/owner/repo/contents/", &api.HTTPError{StatusCode: http.StatusNotFound})
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should fill in every column when the lookups succeed", func() {
+		rows := cmd.BuildPRRowsTest([]cmd.PullRequest{pr}, "owner", "repo", mockClient, true)
+		Expect(rows[0].Errors).To(BeEmpty())
+		Expect(rows[0].Reviewed).To(HaveValue(BeFalse()))
+	})
+
+	It("should leave reviewed unknown rather than false when the reviews can't be fetched", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/7/reviews", fmt.Errorf("HTTP 502"))
+
+		rows := cmd.BuildPRRowsTest([]cmd.PullRequest{pr}, "owner", "repo", mockClient, true)
+		Expect(rows[0].Reviewed).To(BeNil())
+		Expect(rows[0].Errors).To(Equal(map[string]string{"reviews": "HTTP 502"}))
+	})
+
+	It("should record the PR details and files that can't be fetched", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/7/files", fmt.Errorf("HTTP 500"))
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/7", fmt.Errorf("connection reset by peer"))
+		mockClient.AddResponse("repos/owner/repo/pulls/7/reviews", 200, cmd.CreateMockReviews(false))
+
+		rows := cmd.BuildPRRowsTest([]cmd.PullRequest{pr}, "owner", "repo", mockClient, true)
+		Expect(rows[0].TektonOnly).To(BeNil())
+		Expect(rows[0].NeedsRebase).To(BeNil())
+		Expect(rows[0].Errors).To(HaveKeyWithValue("files", "HTTP 500"))
+		Expect(rows[0].Errors).To(HaveKeyWithValue("details", "connection reset by peer"))
+	})
+
+	It("should show err in the columns of failed lookups and list them under the table", func() {
+		no := false
+		rows := []cmd.PRRow{
+			{Number: 7, Title: "Update deps", State: "open", Errors: map[string]string{"reviews": "HTTP 502: Bad Gateway", "details": "HTTP 502"}},
+			{Number: 8, Title: "Fix docs", State: "open", Reviewed: &no, Errors: map[string]string{"details": "HTTP 503\nretry later"}},
+			{Number: 9, Title: "Fix tests", State: "open", Reviewed: &no, NeedsRebase: &no, Blocked: &no, Size: "S"},
+		}
+		cmd.RenderPRTableTest(rows, "owner", "repo")
+
+		lines := strings.Split(out.String(), "\n")
+		row7 := lines[slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "#7") })]
+		Expect(strings.Count(row7, "err")).To(Equal(4))
+		row8 := lines[slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "#8") })]
+		Expect(row8).To(ContainSubstring("❌"))
+		Expect(strings.Count(row8, "err")).To(Equal(3))
+		row9 := lines[slices.IndexFunc(lines, func(line string) bool { return strings.Contains(line, "#9") })]
+		Expect(row9).NotTo(ContainSubstring("err"))
+
+		Expect(out.String()).To(ContainSubstring("Some values could not be determined and show as err:"))
+		Expect(out.String()).To(ContainSubstring("details of #7, #8: HTTP 502\n"))
+		Expect(out.String()).To(ContainSubstring("reviews of #7: HTTP 502: Bad Gateway\n"))
+	})
+
+	It("should not add a footnote when every lookup succeeded", func() {
+		no := false
+		cmd.RenderPRTableTest([]cmd.PRRow{{Number: 9, State: "open", Reviewed: &no}}, "owner", "repo")
+		Expect(out.String()).NotTo(ContainSubstring("could not be determined"))
+	})
+
+	It("should list the failed lookups in plain output", func() {
+		doc := cmd.PRListOutput{Repositories: []cmd.RepositoryPRs{{Repository: "owner/repo", PullRequests: []cmd.PRRow{
+			{Number: 7, Errors: map[string]string{"reviews": "HTTP 502", "checks": "check runs: HTTP 500"}},
+			{Number: 8},
+		}}}}
+		var b bytes.Buffer
+		Expect(cmd.WritePlainOutputTest(&b, doc, "\t")).To(Succeed())
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		Expect(lines[0]).To(HaveSuffix("\tERRORS"))
+		Expect(lines[1]).To(HaveSuffix("\tchecks,reviews"))
+		Expect(lines[2]).To(HaveSuffix("\t"))
+	})
+})
@@ -0,0 +1,74 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Merge methods GitHub accepts, in the order GitHub offers them
+var mergeMethods = []string{"merge", "squash", "rebase"}
+
+// RepoMergeSettings holds the merge methods a repository allows. GitHub only returns them to users who can
+// push to the repository, so a missing setting means it is unknown rather than disallowed.
+type RepoMergeSettings struct {
+	AllowMergeCommit *bool `json:"allow_merge_commit,omitempty"`
+	AllowSquashMerge *bool `json:"allow_squash_merge,omitempty"`
+	AllowRebaseMerge *bool `json:"allow_rebase_merge,omitempty"`
+}
+
+// allowedMethods returns the merge methods the repository allows, in the order GitHub offers them
+func (s RepoMergeSettings) allowedMethods() []string {
+	allowed := map[string]*bool{"merge": s.AllowMergeCommit, "squash": s.AllowSquashMerge, "rebase": s.AllowRebaseMerge}
+	var methods []string
+	for _, method := range mergeMethods {
+		if setting := allowed[method]; setting == nil || *setting {
+			methods = append(methods, method)
+		}
+	}
+	return methods
+}
+
+// fetchMergeSettings fetches the merge methods a repository allows
+func fetchMergeSettings(client RESTClientInterface, owner, repo string) (RepoMergeSettings, error) {
+	var settings RepoMergeSettings
+	if err := client.Get(fmt.Sprintf("repos/%s/%s", owner, repo), &settings); err != nil {
+		return RepoMergeSettings{}, err
+	}
+	return settings, nil
+}
+
+// chooseMergeMethod returns the merge method to use for a repository: the requested method if the
+// repository allows it, or the repository's preferred method, the first one GitHub offers, if none was requested
+func chooseMergeMethod(settings RepoMergeSettings, requested string) (string, error) {
+	allowed := settings.allowedMethods()
+	if len(allowed) == 0 {
+		return "", fmt.Errorf("the repository doesn't allow any merge method")
+	}
+	if requested == "" {
+		return allowed[0], nil
+	}
+	for _, method := range allowed {
+		if method == requested {
+			return method, nil
+		}
+	}
+	return "", fmt.Errorf("the repository doesn't allow the %s merge method (allowed: %s)", requested, strings.Join(allowed, ", "))
+}
+
+// resolveMergeMethod picks the merge method for a PR of a repository from its settings. When the settings
+// can't be fetched the requested method is used as is and GitHub decides.
+func resolveMergeMethod(client RESTClientInterface, owner, repo, requested string) (string, error) {
+	settings, err := fetchMergeSettings(client, owner, repo)
+	if err != nil {
+		logger.Warn("Could not fetch the allowed merge methods", "repo", owner+"/"+repo, "error", err)
+		return requested, nil
+	}
+	method, err := chooseMergeMethod(settings, requested)
+	if err != nil {
+		return "", fmt.Errorf("%s/%s: %w", owner, repo, err)
+	}
+	if allowed := settings.allowedMethods(); len(allowed) < len(mergeMethods) {
+		streams.Printf("   🔀 %s/%s allows %s merges, using %s\n", owner, repo, strings.Join(allowed, " and "), method)
+	}
+	return method, nil
+}
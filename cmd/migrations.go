@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"log"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
+// maxPromptMigrationLines bounds the migration notes boxed in the approval prompt, the rest is read on the PR
+const maxPromptMigrationLines = 25
+
+// MigrationPR is an open Konflux PR with a migration warning and the notes found in its body
+type MigrationPR struct {
+	Repository string               `json:"repository" yaml:"repository"`
+	Number     int                  `json:"number" yaml:"number"`
+	Title      string               `json:"title" yaml:"title"`
+	URL        string               `json:"url" yaml:"url"`
+	CreatedAt  string               `json:"created_at" yaml:"created_at"`
+	Notes      ghprs.MigrationNotes `json:"notes" yaml:"notes"`
+}
+
+// migrationNoteLines returns the lines of the notes to box, their guides last, cut to maxLines lines (0 for all)
+// with a pointer to the PR for the rest. Notes that couldn't be found say so.
+func migrationNoteLines(notes ghprs.MigrationNotes, maxLines int) []string {
+	if notes.Empty() {
+		return []string{"No migration instructions found in the PR description, read the PR before approving."}
+	}
+	lines := notes.Lines
+	if maxLines > 0 && len(lines) > maxLines {
+		lines = append(lines[:maxLines:maxLines], fmt.Sprintf("… %d more line(s), see the PR", len(notes.Lines)-maxLines))
+	}
+	if len(notes.Guides) > 0 {
+		if len(lines) > 0 {
+			lines = append(lines[:len(lines):len(lines)], "")
+		}
+		for _, guide := range notes.Guides {
+			lines = append(lines, "📖 "+guide)
+		}
+	}
+	return lines
+}
+
+// writeMigrationNotes boxes the migration notes of a PR
+func writeMigrationNotes(w io.Writer, pr PullRequest, maxLines int) {
+	lines := migrationNoteLines(ghprs.ExtractMigrationNotes(pr.Body), maxLines)
+	render.WriteBox(w, "🚨 Migration notes", lines, streams.Width(), shouldUseColors())
+}
+
+// collectMigrationPRs keeps the PRs with a migration warning, with their notes, in the order given
+func collectMigrationPRs(prsByRepo [][]repoPR) []MigrationPR {
+	migrations := []MigrationPR{}
+	for _, prs := range prsByRepo {
+		for _, pr := range prs {
+			if !hasMigrationWarning(pr.PR) {
+				continue
+			}
+			migrations = append(migrations, MigrationPR{
+				Repository: pr.Owner + "/" + pr.Repo,
+				Number:     pr.PR.Number,
+				Title:      pr.PR.Title,
+				URL:        prURL(pr.Owner, pr.Repo, pr.PR.Number),
+				CreatedAt:  pr.PR.CreatedAt,
+				Notes:      ghprs.ExtractMigrationNotes(pr.PR.Body),
+			})
+		}
+	}
+	return migrations
+}
+
+// displayMigrationPRs lists the migration PRs, each with its notes boxed
+func displayMigrationPRs(migrations []MigrationPR) {
+	if len(migrations) == 0 {
+		streams.Println("No open Konflux PRs with migration warnings")
+		return
+	}
+	for _, migration := range migrations {
+		owner, repo, _ := parseRepoSpec(migration.Repository)
+		streams.Printf("\n%s %s: %s\n", migration.Repository, formatPRLink(owner, repo, migration.Number), migration.Title)
+		render.WriteBox(streams.Out, "🚨 Migration notes", migrationNoteLines(migration.Notes, 0), streams.Width(), shouldUseColors())
+	}
+	streams.Printf("\n%d open Konflux PR(s) with migration warnings\n", len(migrations))
+}
+
+// konfluxMigrationsCmd lists the open Konflux PRs with migration warnings and their migration notes
+var konfluxMigrationsCmd = &cobra.Command{
+	Use:   "migrations [owner/repo...]",
+	Short: "List open Konflux PRs with migration warnings and their migration notes",
+	Long: `List the open Konflux PRs of the configured Konflux repositories, or the given ones, whose
+description carries a migration warning, each with the migration instructions found in it: the
+section under a migration heading, else the paragraphs and update table rows with the warning,
+and the migration guides they link to. Use it to plan the manual steps before approving.
+
+--output json or yaml exports the PRs and their notes.
+
+Examples:
+  ghprs konflux migrations
+  ghprs konflux migrations my-org/operator
+  ghprs konflux migrations --output json`,
+	ValidArgsFunction: completeRepositoryArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		if isRowOutput(outputFormat) {
+			log.Fatalf("--output %s is only supported by list and konflux", outputFormat)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+
+		repositories := args
+		if len(repositories) == 0 {
+			repositories = config.GetRepositories(true)
+		}
+		if len(repositories) == 0 {
+			log.Fatal("No repositories specified and no Konflux repositories configured. Specify owner/repo or configure Konflux repositories with 'ghprs config add-konflux-repo owner/repo'.")
+		}
+
+		// Every open Konflux PR counts, whatever the list defaults, and which files they change doesn't matter
+		migrations := collectMigrationPRs(fetchKonfluxPRs(commandContext(cmd), everyOpenPR(newListOptions()), config, repositories))
+		if isStructuredOutput(outputFormat) {
+			if err := writeStructuredOutput(streams.Out, migrations, outputFormat); err != nil {
+				log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+			}
+			return
+		}
+		displayMigrationPRs(migrations)
+	},
+}
+
+func init() {
+	konfluxCmd.AddCommand(konfluxMigrationsCmd)
+}
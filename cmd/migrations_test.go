@@ -0,0 +1,70 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Konflux migrations", func() {
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	migrationBody := "| Package | Change | Notes |\n|---|---|---|\n" +
+		"| task-buildah | `0.2` -> `0.4` | :warning:[migration](https://example.com/buildah/0.4/MIGRATION.md):warning: |\n"
+
+	It("keeps the PRs with migration warnings and extracts their notes", func() {
+		migrations := cmd.CollectMigrationPRsTest("owner", "repo", []cmd.PullRequest{
+			{Number: 1, Title: "Update task-buildah", Body: migrationBody},
+			{Number: 2, Title: "Update task-git-clone", Body: "No migration needed."},
+		})
+
+		Expect(migrations).To(HaveLen(1))
+		Expect(migrations[0].Repository).To(Equal("owner/repo"))
+		Expect(migrations[0].Number).To(Equal(1))
+		Expect(migrations[0].URL).To(Equal("https://github.com/owner/repo/pull/1"))
+		Expect(migrations[0].Notes.Lines).To(Equal([]string{"task-buildah 0.2 -> 0.4 ⚠️migration⚠️"}))
+		Expect(migrations[0].Notes.Guides).To(Equal([]string{"https://example.com/buildah/0.4/MIGRATION.md"}))
+	})
+
+	It("lists the migration PRs with their notes boxed", func() {
+		cmd.DisplayMigrationPRsTest(cmd.CollectMigrationPRsTest("owner", "repo", []cmd.PullRequest{
+			{Number: 1, Title: "Update task-buildah", Body: migrationBody},
+		}))
+
+		Expect(out.String()).To(ContainSubstring("owner/repo #1: Update task-buildah"))
+		Expect(out.String()).To(ContainSubstring("🚨 Migration notes"))
+		Expect(out.String()).To(ContainSubstring("│ task-buildah 0.2 -> 0.4"))
+		Expect(out.String()).To(ContainSubstring("📖 https://example.com/buildah/0.4/MIGRATION.md"))
+		Expect(out.String()).To(ContainSubstring("1 open Konflux PR(s) with migration warnings"))
+	})
+
+	It("says when no PR has a migration warning", func() {
+		cmd.DisplayMigrationPRsTest(cmd.CollectMigrationPRsTest("owner", "repo", nil))
+		Expect(out.String()).To(ContainSubstring("No open Konflux PRs with migration warnings"))
+	})
+
+	It("cuts long notes in the approval prompt", func() {
+		body := "## Migration\n" + strings.Repeat("step\n", 30)
+		cmd.WriteMigrationNotesTest(out, cmd.PullRequest{Body: body}, 25)
+		Expect(strings.Count(out.String(), "│ step")).To(Equal(25))
+		Expect(out.String()).To(ContainSubstring("… 5 more line(s), see the PR"))
+	})
+
+	It("points to the PR when the notes can't be found", func() {
+		cmd.WriteMigrationNotesTest(out, cmd.PullRequest{Body: "## Migration notes\n\n## Changes\n⚠️[migration]"}, 25)
+		Expect(out.String()).To(ContainSubstring("No migration instructions found"))
+	})
+})
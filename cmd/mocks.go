@@ -24,6 +24,7 @@ type MockRESTClient struct {
 type MockResponse struct {
 	StatusCode int
 	Body       interface{}
+	Headers    http.Header
 	Error      error
 }
 
@@ -50,6 +51,15 @@ func (m *MockRESTClient) AddResponse(urlPattern string, statusCode int, body int
 	}
 }
 
+// AddResponseWithHeaders adds a mock response for a URL pattern that also sets response headers
+func (m *MockRESTClient) AddResponseWithHeaders(urlPattern string, statusCode int, body interface{}, headers http.Header) {
+	m.Responses[urlPattern] = &MockResponse{
+		StatusCode: statusCode,
+		Body:       body,
+		Headers:    headers,
+	}
+}
+
 // AddErrorResponse adds a mock error response
 func (m *MockRESTClient) AddErrorResponse(urlPattern string, err error) {
 	m.Responses[urlPattern] = &MockResponse{
@@ -94,9 +104,11 @@ func (m *MockRESTClient) Request(method string, path string, body io.Reader) (*h
 			return nil, matchedResponse.Error
 		}
 
-		// Create HTTP response
+		// Create HTTP response, passing raw []byte bodies (such as logs) through as they are
 		var responseBody []byte
-		if matchedResponse.Body != nil {
+		if raw, ok := matchedResponse.Body.([]byte); ok {
+			responseBody = raw
+		} else if matchedResponse.Body != nil {
 			responseBody, _ = json.Marshal(matchedResponse.Body)
 		}
 
@@ -106,6 +118,9 @@ func (m *MockRESTClient) Request(method string, path string, body io.Reader) (*h
 			Header:     make(http.Header),
 		}
 		httpResponse.Header.Set("Content-Type", "application/json")
+		for key, values := range matchedResponse.Headers {
+			httpResponse.Header[key] = values
+		}
 
 		return httpResponse, nil
 	}
@@ -120,7 +135,10 @@ func (m *MockRESTClient) Request(method string, path string, body io.Reader) (*h
 
 // RequestWithContext implements the RESTClientInterface interface
 func (m *MockRESTClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
-	// For mock purposes, we ignore the context
+	// A done context fails the request before it is made, like the real client
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
 	return m.Request(method, path, body)
 }
 
@@ -164,6 +182,10 @@ func (m *MockRESTClient) Post(path string, body io.Reader, response interface{})
 		if err != nil {
 			return err
 		}
+		// Like a 204, a response without a body leaves response untouched
+		if len(respBody) == 0 {
+			return nil
+		}
 		return json.Unmarshal(respBody, response)
 	}
 
@@ -187,6 +209,10 @@ func (m *MockRESTClient) Put(path string, body io.Reader, response interface{})
 		if err != nil {
 			return err
 		}
+		// Like a 204, a response without a body leaves response untouched
+		if len(respBody) == 0 {
+			return nil
+		}
 		return json.Unmarshal(respBody, response)
 	}
 
@@ -210,6 +236,10 @@ func (m *MockRESTClient) Patch(path string, body io.Reader, response interface{}
 		if err != nil {
 			return err
 		}
+		// Like a 204, a response without a body leaves response untouched
+		if len(respBody) == 0 {
+			return nil
+		}
 		return json.Unmarshal(respBody, response)
 	}
 
@@ -233,6 +263,10 @@ func (m *MockRESTClient) Delete(path string, response interface{}) error {
 		if err != nil {
 			return err
 		}
+		// Like a 204, a response without a body leaves response untouched
+		if len(respBody) == 0 {
+			return nil
+		}
 		return json.Unmarshal(respBody, response)
 	}
 
@@ -256,6 +290,10 @@ func (m *MockRESTClient) Do(method string, path string, body io.Reader, response
 		if err != nil {
 			return err
 		}
+		// Like a 204, a response without a body leaves response untouched
+		if len(respBody) == 0 {
+			return nil
+		}
 		return json.Unmarshal(respBody, response)
 	}
 
@@ -264,7 +302,10 @@ func (m *MockRESTClient) Do(method string, path string, body io.Reader, response
 
 // DoWithContext implements the generic DoWithContext method
 func (m *MockRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
-	// For mock purposes, we ignore the context
+	// A done context fails the request before it is made, like the real client
+	if err := ctx.Err(); err != nil {
+		return err
+	}
 	return m.Do(method, path, body, response)
 }
 
@@ -282,6 +323,20 @@ func (m *MockRESTClient) GetRequestCount(urlPattern string) int {
 	return count
 }
 
+// RequestBodies returns the bodies of the requests made with method to exactly path, in order
+func (m *MockRESTClient) RequestBodies(method, path string) []string {
+	m.mutex.RLock()
+	defer m.mutex.RUnlock()
+
+	var bodies []string
+	for _, req := range m.Requests {
+		if req.Method == method && req.URL == path {
+			bodies = append(bodies, req.Body)
+		}
+	}
+	return bodies
+}
+
 // GetLastRequest returns the most recent request made
 func (m *MockRESTClient) GetLastRequest() *MockRequest {
 	m.mutex.RLock()
@@ -459,3 +514,64 @@ func SetupMockResponses(client *MockRESTClient, owner, repo string) {
 	// Mock diff endpoint
 	client.AddResponse(".diff", 200, "+added line\n-removed line\n unchanged line")
 }
+
+// MockGraphQLClient implements GraphQLClientInterface for testing
+type MockGraphQLClient struct {
+	// Responses are returned in order, one per query
+	Responses []*MockResponse
+	// Requests stores all queries made for verification
+	Requests []MockGraphQLRequest
+	// mutex protects concurrent access to the Requests slice
+	mutex sync.Mutex
+}
+
+type MockGraphQLRequest struct {
+	Query     string
+	Variables map[string]interface{}
+}
+
+// NewMockGraphQLClient creates a new mock GraphQL client
+func NewMockGraphQLClient() *MockGraphQLClient {
+	return &MockGraphQLClient{}
+}
+
+// AddResponse queues the "data" payload for the next query
+func (m *MockGraphQLClient) AddResponse(data interface{}) {
+	m.Responses = append(m.Responses, &MockResponse{StatusCode: 200, Body: data})
+}
+
+// AddErrorResponse queues an error for the next query
+func (m *MockGraphQLClient) AddErrorResponse(err error) {
+	m.Responses = append(m.Responses, &MockResponse{Error: err})
+}
+
+// Do implements the GraphQLClientInterface interface
+func (m *MockGraphQLClient) Do(query string, variables map[string]interface{}, response interface{}) error {
+	m.mutex.Lock()
+	index := len(m.Requests)
+	m.Requests = append(m.Requests, MockGraphQLRequest{Query: query, Variables: variables})
+	m.mutex.Unlock()
+
+	if index >= len(m.Responses) {
+		return fmt.Errorf("no mock GraphQL response for request %d", index+1)
+	}
+
+	mockResponse := m.Responses[index]
+	if mockResponse.Error != nil {
+		return mockResponse.Error
+	}
+
+	data, err := json.Marshal(mockResponse.Body)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, response)
+}
+
+// DoWithContext implements the GraphQLClientInterface interface
+func (m *MockGraphQLClient) DoWithContext(ctx context.Context, query string, variables map[string]interface{}, response interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return m.Do(query, variables, response)
+}
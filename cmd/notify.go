@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Reasons a PR is alerted on
+const (
+	alertMigration = "migration warning"
+	alertFailing   = "failing checks"
+)
+
+// webhookTimeout bounds each webhook post, so an unreachable endpoint doesn't hold up watch
+const webhookTimeout = 10 * time.Second
+
+var notifyDryRun bool
+
+// NotificationsConfig sets where alerts about PRs needing attention are posted
+type NotificationsConfig struct {
+	// SlackWebhook is a Slack incoming webhook URL alerts are posted to as messages
+	SlackWebhook string `yaml:"slack_webhook,omitempty"`
+	// Webhooks are URLs alerts are posted to as JSON
+	Webhooks []string `yaml:"webhooks,omitempty"`
+}
+
+// Alert is a PR that newly has a migration warning or failing checks, as posted to generic webhooks
+type Alert struct {
+	Repository string   `json:"repository"`
+	Number     int      `json:"number"`
+	Title      string   `json:"title"`
+	URL        string   `json:"url"`
+	Reasons    []string `json:"reasons"`
+}
+
+// WebhookPayload is the JSON body posted to generic webhooks
+type WebhookPayload struct {
+	Source string  `json:"source"`
+	Alerts []Alert `json:"alerts"`
+}
+
+// webhookNotifier posts alerts to the configured Slack and generic webhooks
+type webhookNotifier struct {
+	slackWebhook string
+	webhooks     []string
+	client       *http.Client
+}
+
+// newWebhookNotifier returns a notifier for the configured webhooks, nil when none is configured
+func newWebhookNotifier(config NotificationsConfig) *webhookNotifier {
+	if config.SlackWebhook == "" && len(config.Webhooks) == 0 {
+		return nil
+	}
+	return &webhookNotifier{
+		slackWebhook: config.SlackWebhook,
+		webhooks:     config.Webhooks,
+		client:       &http.Client{Timeout: webhookTimeout},
+	}
+}
+
+// send posts alerts to every webhook, trying them all and returning their errors
+func (n *webhookNotifier) send(ctx context.Context, alerts []Alert) error {
+	var errs []error
+	if n.slackWebhook != "" {
+		if err := n.post(ctx, n.slackWebhook, map[string]string{"text": slackMessage(alerts)}); err != nil {
+			errs = append(errs, fmt.Errorf("slack webhook: %w", err))
+		}
+	}
+	for _, webhook := range n.webhooks {
+		if err := n.post(ctx, webhook, WebhookPayload{Source: "ghprs", Alerts: alerts}); err != nil {
+			errs = append(errs, fmt.Errorf("webhook %s: %w", webhook, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// post sends payload as JSON to webhook
+func (n *webhookNotifier) post(ctx context.Context, webhook string, payload interface{}) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, webhook, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := n.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// slackMessage formats alerts as a Slack message, one linked PR per line
+func slackMessage(alerts []Alert) string {
+	var message strings.Builder
+	message.WriteString("ghprs: PRs need attention")
+	for _, alert := range alerts {
+		fmt.Fprintf(&message, "\n• <%s|%s#%d> %s: %s", alert.URL, alert.Repository, alert.Number, alert.Title, strings.Join(alert.Reasons, ", "))
+	}
+	return message.String()
+}
+
+// isWebhookURL reports whether value is an absolute http(s) URL a webhook can be posted to
+func isWebhookURL(value string) bool {
+	parsed, err := url.Parse(value)
+	return err == nil && (parsed.Scheme == "https" || parsed.Scheme == "http") && parsed.Host != ""
+}
+
+// alertReasons returns why a PR needs attention: a migration warning or failing checks
+func alertReasons(snapshot WatchSnapshot) []string {
+	var reasons []string
+	if snapshot.Migration {
+		reasons = append(reasons, alertMigration)
+	}
+	if snapshot.Checks == checksFailing {
+		reasons = append(reasons, alertFailing)
+	}
+	return reasons
+}
+
+// newAlerts returns the PRs of current with a reason for attention they didn't have in previous, ordered by
+// PR number. Every reason of a PR missing from previous is new.
+func newAlerts(owner, repo string, previous, current map[int]WatchSnapshot) []Alert {
+	var alerts []Alert
+	for number, now := range current {
+		before := alertReasons(previous[number])
+		var reasons []string
+		for _, reason := range alertReasons(now) {
+			if !slices.Contains(before, reason) {
+				reasons = append(reasons, reason)
+			}
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+		alerts = append(alerts, Alert{
+			Repository: owner + "/" + repo,
+			Number:     number,
+			Title:      now.Title,
+			URL:        prURL(owner, repo, number),
+			Reasons:    reasons,
+		})
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].Number < alerts[j].Number
+	})
+	return alerts
+}
+
+// sendAlerts posts alerts with webhooks, warning once when they can't be posted
+func sendAlerts(ctx context.Context, webhooks *webhookNotifier, alerts []Alert) error {
+	if webhooks == nil || len(alerts) == 0 {
+		return nil
+	}
+	err := webhooks.send(ctx, alerts)
+	if err != nil {
+		warnOnce("webhook", "could not post alerts: %v", err)
+	}
+	return err
+}
+
+// notifyStatePath is where 'ghprs notify' remembers what it saw, outside the entries 'ghprs cache clear' removes
+func notifyStatePath() string {
+	return filepath.Join(getCacheDir(), "notify", "state.json")
+}
+
+// loadNotifyState reads the PR snapshots of the previous 'ghprs notify' run, by repository
+func loadNotifyState(path string) (map[string]map[int]WatchSnapshot, error) {
+	state := make(map[string]map[int]WatchSnapshot)
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// saveNotifyState writes the PR snapshots 'ghprs notify' saw, by repository
+func saveNotifyState(path string, state map[string]map[int]WatchSnapshot) error {
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
+
+// notifyCmd represents the notify command
+var notifyCmd = &cobra.Command{
+	Use:   "notify [owner/repo...]",
+	Short: "Post Konflux PRs with migration warnings or failing checks to Slack or webhooks",
+	Long: `Check the Konflux PRs of the configured Konflux repositories, or the given ones, and post those
+that newly have a migration warning or failing checks to the configured webhooks, with a link and
+summary of each. PRs are only posted again once they recover and need attention again, so it can
+run from cron. 'ghprs watch --notify' posts the same alerts as it refreshes.
+
+Configure the webhooks with:
+  ghprs config set slack-webhook https://hooks.slack.com/services/...
+  ghprs config set webhooks https://example.com/hook
+
+Generic webhooks receive {"source": "ghprs", "alerts": [{"repository", "number", "title", "url",
+"reasons"}]}.
+
+Examples:
+  ghprs notify
+  ghprs notify owner/repo --dry-run         # Print the alerts without posting or remembering them`,
+	ValidArgsFunction: completeRepositoryArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		opts := newListOptions()
+		applyConfigDefaults(opts, config)
+		setRepositoryHosts(config)
+
+		webhooks := newWebhookNotifier(config.Notifications)
+		if webhooks == nil && !notifyDryRun {
+			log.Fatal("No webhooks configured. Configure one with 'ghprs config set slack-webhook <url>' or 'ghprs config set webhooks <url>'")
+		}
+
+		statePath := notifyStatePath()
+		previous, err := loadNotifyState(statePath)
+		if err != nil {
+			log.Fatalf("Failed to read what was already notified: %v", err)
+		}
+
+		repositories := resolveRepositories(opts, args, config, true, false)
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		failed := false
+		for _, repoSpec := range repositories {
+			owner, repo, ok := parseRepoSpec(repoSpec)
+			if !ok {
+				logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+				continue
+			}
+
+			// Check results change without the PR being updated, so the disk cache isn't used
+			client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+			if err != nil {
+				logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+				failed = true
+				continue
+			}
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			_, _, snapshots, err := fetchWatchSnapshots(repoCtx, opts, withContext(client, repoCtx), owner, repo, queueAuthors(config, repoSpec, []string{konfluxBotAuthor}, true), true)
+			cancel()
+			if err != nil {
+				logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+				failed = true
+				continue
+			}
+
+			alerts := newAlerts(owner, repo, previous[repoSpec], snapshots)
+			for _, alert := range alerts {
+				streams.Printf("📣 %s#%d %s: %s\n", alert.Repository, alert.Number, alert.Title, strings.Join(alert.Reasons, ", "))
+			}
+			if notifyDryRun {
+				continue
+			}
+			if err := sendAlerts(ctx, webhooks, alerts); err != nil {
+				// Not remembered, so the alerts are posted again next time
+				failed = true
+				continue
+			}
+			previous[repoSpec] = snapshots
+		}
+
+		if !notifyDryRun {
+			if err := saveNotifyState(statePath, previous); err != nil {
+				log.Fatalf("Failed to remember what was notified: %v", err)
+			}
+		}
+		if failed {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(notifyCmd)
+
+	notifyCmd.Flags().BoolVar(&notifyDryRun, "dry-run", false, "Print the alerts without posting or remembering them")
+}
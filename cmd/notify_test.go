@@ -0,0 +1,100 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Notifications", func() {
+	Describe("New alerts", func() {
+		It("should alert on every reason of a PR seen for the first time", func() {
+			current := map[int]cmd.WatchSnapshot{
+				2: {Title: "Update task-buildah", Checks: "failing", Migration: true},
+				1: {Title: "Update task-init", Checks: "passing"},
+			}
+
+			alerts := cmd.NewAlertsTest("owner", "repo", nil, current)
+			Expect(alerts).To(HaveLen(1))
+			Expect(alerts[0].Repository).To(Equal("owner/repo"))
+			Expect(alerts[0].Number).To(Equal(2))
+			Expect(alerts[0].URL).To(HaveSuffix("/owner/repo/pull/2"))
+			Expect(alerts[0].Reasons).To(Equal([]string{"migration warning", "failing checks"}))
+		})
+
+		It("should only alert on reasons a PR didn't already have", func() {
+			previous := map[int]cmd.WatchSnapshot{
+				1: {Title: "First", Checks: "failing"},
+				2: {Title: "Second", Checks: "pending"},
+			}
+			current := map[int]cmd.WatchSnapshot{
+				1: {Title: "First", Checks: "failing", Migration: true},
+				2: {Title: "Second", Checks: "failing"},
+			}
+
+			alerts := cmd.NewAlertsTest("owner", "repo", previous, current)
+			Expect(alerts).To(HaveLen(2))
+			Expect(alerts[0].Reasons).To(Equal([]string{"migration warning"}))
+			Expect(alerts[1].Reasons).To(Equal([]string{"failing checks"}))
+			Expect(cmd.NewAlertsTest("owner", "repo", current, current)).To(BeEmpty())
+		})
+	})
+
+	Describe("Posting alerts", func() {
+		alerts := []cmd.Alert{{Repository: "owner/repo", Number: 2, Title: "Update task-buildah", URL: "https://github.com/owner/repo/pull/2", Reasons: []string{"migration warning"}}}
+
+		It("should post a Slack message and the alerts as JSON", func() {
+			bodies := make(map[string][]byte)
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				bodies[r.URL.Path], _ = io.ReadAll(r.Body)
+			}))
+			defer server.Close()
+
+			err := cmd.SendAlertsTest(cmd.NotificationsConfig{SlackWebhook: server.URL + "/slack", Webhooks: []string{server.URL + "/hook"}}, alerts)
+			Expect(err).NotTo(HaveOccurred())
+
+			var slack map[string]string
+			Expect(json.Unmarshal(bodies["/slack"], &slack)).To(Succeed())
+			Expect(slack["text"]).To(ContainSubstring("<https://github.com/owner/repo/pull/2|owner/repo#2> Update task-buildah: migration warning"))
+
+			var payload cmd.WebhookPayload
+			Expect(json.Unmarshal(bodies["/hook"], &payload)).To(Succeed())
+			Expect(payload).To(Equal(cmd.WebhookPayload{Source: "ghprs", Alerts: alerts}))
+		})
+
+		It("should try every webhook and report the ones that failed", func() {
+			posted := 0
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				posted++
+				if r.URL.Path == "/broken" {
+					w.WriteHeader(http.StatusInternalServerError)
+				}
+			}))
+			defer server.Close()
+
+			err := cmd.SendAlertsTest(cmd.NotificationsConfig{Webhooks: []string{server.URL + "/broken", server.URL + "/hook"}}, alerts)
+			Expect(err).To(MatchError(ContainSubstring("HTTP 500")))
+			Expect(posted).To(Equal(2))
+		})
+
+		It("should do nothing without webhooks", func() {
+			Expect(cmd.SendAlertsTest(cmd.NotificationsConfig{}, alerts)).To(Succeed())
+		})
+	})
+
+	It("should remember the PRs it saw", func() {
+		path := filepath.Join(GinkgoT().TempDir(), "notify", "state.json")
+		state := map[string]map[int]cmd.WatchSnapshot{"owner/repo": {2: {Title: "Update", Checks: "failing", Migration: true}}}
+
+		loaded, err := cmd.NotifyStateTest(path, state)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded).To(Equal(state))
+	})
+})
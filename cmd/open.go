@@ -0,0 +1,169 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	"github.com/cli/go-gh/v2/pkg/browser"
+	"github.com/spf13/cobra"
+)
+
+var openFailedCheck bool
+
+// checkLink is a check with a page that can be opened in the browser
+type checkLink struct {
+	Name string
+	URL  string
+}
+
+// failedCheckLinks returns the failed check runs and status checks that link to a page
+func failedCheckLinks(checkRuns []CheckRun, statusChecks []StatusCheck) []checkLink {
+	var links []checkLink
+	for _, checkRun := range checkRuns {
+		if checkRunFailed(checkRun) && checkRun.HTMLURL != "" {
+			links = append(links, checkLink{Name: checkRun.Name, URL: checkRun.HTMLURL})
+		}
+	}
+	for _, statusCheck := range statusChecks {
+		if statusCheckFailed(statusCheck) && statusCheck.TargetURL != "" {
+			links = append(links, checkLink{Name: statusCheck.Context, URL: statusCheck.TargetURL})
+		}
+	}
+	return links
+}
+
+// openInBrowser opens url with ui.browser, else $GH_BROWSER, the browser of the gh config, $BROWSER or the
+// system's default browser
+func openInBrowser(url string) error {
+	streams.Printf("🌐 Opening %s\n", url)
+	if err := browser.New(uiSettings.Browser, streams.Out, streams.ErrOut).Browse(url); err != nil {
+		return fmt.Errorf("failed to open %s: %w", url, err)
+	}
+	return nil
+}
+
+// chooseLink asks which of links to open when there are several, the first when Enter is pressed. It returns
+// nil when the choice is cancelled with q.
+func chooseLink(prompt string, links []checkLink) (*checkLink, error) {
+	if len(links) == 1 {
+		return &links[0], nil
+	}
+	for i, link := range links {
+		streams.Printf("   %d. %s\n", i+1, link.Name)
+	}
+	for {
+		answer, err := prompter.Input(fmt.Sprintf("%s [1-%d, Enter for 1, q to cancel]: ", prompt, len(links)))
+		if err != nil {
+			return nil, err
+		}
+		if answer == "" {
+			return &links[0], nil
+		}
+		if answer == "q" {
+			return nil, nil
+		}
+		if choice, err := strconv.Atoi(answer); err == nil && choice >= 1 && choice <= len(links) {
+			return &links[choice-1], nil
+		}
+		streams.Printf("Invalid choice %q\n", answer)
+	}
+}
+
+// openFailedCheckPage opens the page of a failed check of a PR, asking which when several failed. It reports
+// whether one failed at all.
+func openFailedCheckPage(client RESTClientInterface, owner, repo string, pr PullRequest) (bool, error) {
+	checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch checks: %v", err)
+	}
+	links := failedCheckLinks(checkRuns, statusChecks)
+	if len(links) == 0 {
+		return false, nil
+	}
+	streams.Printf("❌ %d failed check(s) of PR %s:\n", len(links), formatPRLink(owner, repo, pr.Number))
+	link, err := chooseLink("Open which check?", links)
+	if err != nil || link == nil {
+		return true, err
+	}
+	return true, openInBrowser(link.URL)
+}
+
+// promptForOpen opens the PR in the browser from the approval prompt, or one of its failed checks when it has
+// any and one is chosen. It only returns the errors of reading the answers.
+func promptForOpen(client RESTClientInterface, owner, repo string, pr PullRequest) error {
+	links := []checkLink{{Name: "the pull request", URL: prURL(owner, repo, pr.Number)}}
+	if pr.Head.SHA != "" {
+		checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+		if err != nil {
+			logger.Debug("Could not fetch checks to offer opening them", "pr", pr.Number, "error", err)
+		}
+		for _, link := range failedCheckLinks(checkRuns, statusChecks) {
+			link.Name = "failed check " + link.Name
+			links = append(links, link)
+		}
+	}
+	link, err := chooseLink("Open what?", links)
+	if err != nil || link == nil {
+		return err
+	}
+	if err := openInBrowser(link.URL); err != nil {
+		streams.Printf("❌ %v\n", err)
+	}
+	return nil
+}
+
+// openCmd opens a PR, or one of its failed checks, in the browser
+var openCmd = &cobra.Command{
+	Use:   "open [owner/repo] <number>",
+	Short: "Open a pull request in the browser",
+	Long: `Open a pull request, or with --check one of its failed checks, in the browser, for when reviewing
+in the terminal isn't enough. PRs of repositories on GitHub Enterprise open on the configured host.
+
+The browser is ui.browser (see 'ghprs config set browser'), else $GH_BROWSER, the browser of the
+gh config, $BROWSER or the system's default browser. 'o' at the approval prompt does the same.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs open 123
+  ghprs open owner/repo 123
+  ghprs open owner/repo 123 --check   # Open the page of a failed check, asking which when several failed`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, repo, number := parsePRArgs(args)
+
+		if !openFailedCheck {
+			config, err := LoadConfig()
+			if err != nil {
+				config = DefaultConfig()
+			}
+			setRepositoryHosts(config)
+			if err := openInBrowser(prURL(owner, repo, number)); err != nil {
+				log.Fatal(err)
+			}
+			return
+		}
+
+		ctx := commandContext(cmd)
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+		failed, err := openFailedCheckPage(client, owner, repo, *pr)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if !failed {
+			streams.Printf("✅ No checks of PR %s have failed\n", formatPRLink(owner, repo, number))
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(openCmd)
+
+	openCmd.Flags().BoolVar(&openFailedCheck, "check", false, "Open the page of a failed check instead of the PR")
+}
@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Opening in the browser", func() {
+	const commit = "repos/owner/repo/commits/abc123"
+	var (
+		out        *bytes.Buffer
+		mockClient *cmd.MockRESTClient
+		pr         cmd.PullRequest
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, cmd.CreateMockPRFiles(false))
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+		mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+			{Name: "build", Status: "completed", Conclusion: "success", HTMLURL: "https://github.com/owner/repo/runs/1"},
+			{Name: "e2e", Status: "completed", Conclusion: "failure", HTMLURL: "https://github.com/owner/repo/runs/2"},
+		}})
+		mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{
+			"statuses": []cmd.StatusCheck{{Context: "ci/prow/unit", State: "failure", TargetURL: "https://prow.example.com/unit/3"}},
+		})
+		pr = cmd.PullRequest{Number: 1, Title: "Update", State: "open", Head: cmd.Branch{SHA: "abc123"}}
+		// echo stands in for the browser, printing the URL it was given
+		cmd.SetUISettingsTest(cmd.UIConfig{Browser: "echo"})
+	})
+
+	AfterEach(func() {
+		cmd.SetUISettingsTest(cmd.UIConfig{})
+		cmd.ResetIOStreams()
+	})
+
+	It("should open the PR from the approval prompt when Enter is pressed", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\no\n\nn\n"), out, out), nil)
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, false)
+		Expect(out.String()).To(ContainSubstring("o=open in browser"))
+		Expect(out.String()).To(ContainSubstring("2. failed check e2e"))
+		Expect(out.String()).To(ContainSubstring("3. failed check ci/prow/unit"))
+		Expect(out.String()).To(ContainSubstring("🌐 Opening https://github.com/owner/repo/pull/1\nhttps://github.com/owner/repo/pull/1\n"))
+	})
+
+	It("should open the failed check chosen at the approval prompt", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\no\n3\nn\n"), out, out), nil)
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, false)
+		Expect(out.String()).To(ContainSubstring("🌐 Opening https://prow.example.com/unit/3\n"))
+		Expect(out.String()).To(ContainSubstring("Skipping PR #1"))
+	})
+
+	It("should ask which failed check to open with --check", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("1\n"), out, out), nil)
+
+		failed, err := cmd.OpenFailedCheckPageTest(mockClient, "owner", "repo", pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(BeTrue())
+		Expect(out.String()).To(ContainSubstring("❌ 2 failed check(s) of PR #1"))
+		Expect(out.String()).To(ContainSubstring("🌐 Opening https://github.com/owner/repo/runs/2\n"))
+	})
+
+	It("should report when no check failed", func() {
+		mockClient.AddResponse(commit+"/check-runs", 200, cmd.CheckRunsResponse{})
+		mockClient.AddResponse(commit+"/status", 200, map[string]interface{}{"statuses": []cmd.StatusCheck{}})
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+
+		failed, err := cmd.OpenFailedCheckPageTest(mockClient, "owner", "repo", pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(failed).To(BeFalse())
+		Expect(out.String()).NotTo(ContainSubstring("Opening"))
+	})
+})
@@ -0,0 +1,219 @@
+package cmd
+
+import (
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Flags that apply to every command, registered on the root command
+var (
+	// repoFlag is the repository given with --repo, used when a command isn't given one as an argument
+	repoFlag string
+)
+
+// listOptions holds the flags of a command that lists PRs. list, konflux and watch each have their own
+// instance, so a flag given to one command (or its default) can't leak into another.
+type listOptions struct {
+	State          string
+	Limit          int
+	All            bool
+	Current        bool
+	TargetBranch   string
+	SortBy         string
+	Fast           bool
+	UseGraphQL     bool
+	Concurrency    int
+	RequestTimeout time.Duration
+	NoCache        bool
+	NoLegend       bool
+
+	// Filters, views and the approval flow of list and konflux
+	Authors       []string
+	SecurityOnly  bool
+	TektonOnly    bool
+	MigrationOnly bool
+	View          string
+	Readiness     []string
+	Approve       bool
+	ShowFiles     bool
+	ShowDiff      bool
+	ShowCommits   bool
+	ExpandChecks  bool
+	DiffMode      string
+	DiffFiles     []string
+	ApproveBody   string
+	NoLGTM        bool
+	Combined      bool
+	Auto          bool
+	Since         string
+	Interactive   bool
+	ShowSnoozed   bool
+	Artifact      string
+	NewOnly       bool
+	Delimiter     string
+	ExplainSort   bool
+	SkipRedBase   bool
+	OlderThan     string
+	UpdatedWithin string
+	MaxChanges    int
+
+	// People filters of list
+	ReviewRequested bool
+	Assignee        string
+	Mine            bool
+	// NeedsMyTeam keeps the PRs the CODEOWNERS make need your or your team's approval
+	NeedsMyTeam bool
+	// UpdateTypes keeps the Renovate and Dependabot PRs whose most disruptive update is of these types
+	UpdateTypes []string
+	// GroupUpdates approves the same dependency update across repositories at once (konflux only)
+	GroupUpdates bool
+	// FailOn are the conditions that make the run exit with failOnExitCode when an open PR meets them
+	FailOn []string
+
+	// Query is the GitHub search query of list --query
+	Query string
+
+	// Org and Topics choose the repositories of the konflux --org dashboard
+	Org    string
+	Topics []string
+
+	// Offline shows the latest snapshot of list and konflux instead of fetching PRs
+	Offline bool
+
+	// stateFromFlag and limitFromFlag record whether --state and --limit were given on the command line or in
+	// the environment, so the config defaults don't override them
+	stateFromFlag bool
+	limitFromFlag bool
+	// activitySince is where the --since window starts, zero without --since
+	activitySince time.Time
+	// createdBefore and updatedAfter are where the --older-than and --updated-within windows start, zero when
+	// they aren't given
+	createdBefore time.Time
+	updatedAfter  time.Time
+}
+
+var (
+	listOpts    = newListOptions()
+	konfluxOpts = newListOptions()
+	watchOpts   = newListOptions()
+	searchOpts  = newListOptions()
+)
+
+// newListOptions returns the options of a command listing PRs without flags: the defaults of the flags, for
+// commands such as serve and snapshot that fetch PRs like list
+func newListOptions() *listOptions {
+	return &listOptions{
+		State:          "open",
+		Limit:          30,
+		Concurrency:    defaultConcurrency,
+		RequestTimeout: defaultRequestTimeout,
+		View:           ViewDetailed,
+		Delimiter:      "\t",
+	}
+}
+
+// everyOpenPR returns a copy of opts that fetches every open PR, whatever the list defaults, without the API
+// calls of the table, for commands that only look at the PRs themselves
+func everyOpenPR(opts *listOptions) *listOptions {
+	all := *opts
+	all.State, all.Limit, all.Fast = "open", 0, true
+	return &all
+}
+
+// addFetchFlags registers the flags that choose and fetch PRs, shared by list, konflux and watch
+func addFetchFlags(cmd *cobra.Command, opts *listOptions, isKonflux bool) {
+	cmd.Flags().StringVarP(&opts.State, "state", "s", "open", "Filter by state: open, closed, all")
+	cmd.Flags().IntVarP(&opts.Limit, "limit", "l", 30, "Maximum number of pull requests to show, 0 for no limit (filters are applied while paging, so this counts matching PRs)")
+	cmd.Flags().BoolVarP(&opts.Current, "current", "c", false, "Use current repository, bypass config")
+	cmd.Flags().StringVar(&opts.TargetBranch, "target-branch", "", "Filter PRs by target branch (e.g., main, dev, release/v1.0)")
+	if isKonflux {
+		cmd.Flags().BoolVar(&opts.Fast, "fast", false, "Fast mode: skip expensive API calls (rebase, blocked, review status, Tekton file checks)")
+	} else {
+		cmd.Flags().BoolVar(&opts.Fast, "fast", false, "Fast mode: skip expensive API calls (rebase, blocked, review status)")
+	}
+	cmd.Flags().BoolVar(&opts.UseGraphQL, "use-graphql", false, "Fetch PRs with reviews, files and checks in a single GraphQL query (falls back to REST on error)")
+	cmd.Flags().IntVar(&opts.Concurrency, "concurrency", defaultConcurrency, "Number of PRs to fetch details for in parallel")
+	cmd.Flags().DurationVar(&opts.RequestTimeout, "request-timeout", defaultRequestTimeout, "Timeout for each GitHub API request (0 to disable)")
+	cmd.Flags().BoolVar(&opts.NoLegend, "no-legend", false, "Don't show the legend above the PR table")
+}
+
+// addListFlags registers the flags of list and konflux
+func addListFlags(cmd *cobra.Command, opts *listOptions, isKonflux bool) {
+	addFetchFlags(cmd, opts, isKonflux)
+
+	cmd.Flags().BoolVar(&opts.All, "all", false, "Show all matching pull requests (same as --limit 0)")
+	cmd.Flags().StringVar(&opts.SortBy, "sort-by", "", "Sort PRs by: newest (default), oldest, updated, number, priority (weighted score, security updates first)")
+	cmd.Flags().BoolVar(&opts.ExplainSort, "explain-sort", false, "Show the score --sort-by priority gave each PR and the points of each factor (implies --sort-by priority)")
+	cmd.Flags().BoolVar(&opts.NoCache, "no-cache", false, "Ignore the on-disk PR cache and fetch everything from GitHub")
+	cmd.Flags().BoolVar(&opts.Combined, "combined", false, "Show the PRs of every configured repository in a single table with a REPO column, sorted across repositories")
+	cmd.Flags().BoolVar(&opts.Interactive, "interactive", false, "After the table, toggle filters (t tekton-only, m migration-only, r needs rebase, g green checks) and show it again without new API calls")
+	cmd.Flags().BoolVar(&opts.ShowSnoozed, "show-snoozed", false, "Also show the PRs hidden with 'ghprs snooze'")
+	cmd.Flags().StringVar(&opts.Artifact, "artifact", "", "Save what the run shows (tables, prompts and answers) and decides as JSON and HTML files in this directory, for audits")
+	cmd.Flags().BoolVar(&opts.NewOnly, "new-only", false, "Show only PRs that are new or were updated since they were last listed (marked 🆕)")
+	cmd.Flags().StringVar(&opts.Delimiter, "delimiter", "\t", "Field separator of --output plain")
+	cmd.Flags().StringVar(&opts.OlderThan, "older-than", "", "Show only PRs opened longer ago than this, e.g. 14d or 2w")
+	cmd.Flags().StringVar(&opts.UpdatedWithin, "updated-within", "", "Show only PRs updated within this window, e.g. 2d or 8h")
+	cmd.Flags().StringVar(&opts.Since, "since", "", "Show only PRs updated within this window, e.g. 8h or 2d, most recently updated first, and what changed on them")
+
+	if isKonflux {
+		cmd.Flags().BoolVarP(&opts.Approve, "approve", "a", false, "Interactively approve Konflux pull requests (review + /lgtm comment by default)")
+		cmd.Flags().BoolVarP(&opts.TektonOnly, "tekton-only", "t", false, "Show only PRs that EXCLUSIVELY modify Tekton files (.tekton/*-pull-request.yaml or *-push.yaml, unless the repository sets tekton_patterns)")
+		cmd.Flags().BoolVarP(&opts.MigrationOnly, "migration-only", "m", false, "Show only PRs that contain migration warnings")
+		cmd.Flags().BoolVar(&opts.Auto, "auto", false, "Apply the rules of the config to each PR (approve, hold, label or skip) without asking, printing why; exits with status 1 if an action failed")
+		cmd.Flags().BoolVar(&opts.SkipRedBase, "skip-red-base", false, "With --auto, don't approve PRs whose target branch fails its required checks on its latest commit")
+		cmd.Flags().StringVar(&opts.Org, "org", "", "Show a dashboard of the Konflux PRs of every repository of this organization, with per-repository counts")
+		cmd.Flags().StringSliceVar(&opts.Topics, "topic", nil, "With --org, only include repositories with this topic (repeatable or comma separated)")
+		cmd.Flags().BoolVar(&opts.GroupUpdates, "group-updates", false, "Cluster the same dependency update open in several repositories and approve each cluster after reviewing one diff")
+	} else {
+		cmd.Flags().BoolVarP(&opts.Approve, "approve", "a", false, "Interactively approve pull requests (review + /lgtm comment by default)")
+		cmd.Flags().StringSliceVar(&opts.Authors, "author", nil, "Show only PRs by this author (repeatable or comma separated, @me for yourself)")
+		cmd.Flags().BoolVar(&opts.Mine, "mine", false, "Show only PRs authored by you (same as --author @me)")
+		cmd.Flags().BoolVar(&opts.ReviewRequested, "review-requested", false, "Show only PRs whose review is requested from you")
+		cmd.Flags().StringVar(&opts.Assignee, "assignee", "", "Show only PRs assigned to this user (me for yourself)")
+	}
+	cmd.Flags().BoolVar(&opts.NeedsMyTeam, "needs-my-team", false, "Show only PRs whose changed files CODEOWNERS assigns to you or one of your teams")
+	cmd.Flags().StringSliceVar(&opts.UpdateTypes, "update-type", nil, "Show only Renovate and Dependabot PRs whose most disruptive dependency update is one of these, comma separated (major, minor, patch, digest)")
+	cmd.Flags().BoolVarP(&opts.SecurityOnly, "security-only", "", false, "Show only PRs that contain security updates (SECURITY or CVE in title)")
+	cmd.Flags().StringSliceVar(&opts.FailOn, "fail-on", nil, "Exit with status 2 when an open PR listed meets one of these, comma separated (migration, security, on-hold, blocked, needs-rebase, stale or stale:<window> e.g. stale:14d), for CI gates; checks every PR unless --limit is given")
+	cmd.Flags().StringVar(&opts.View, "view", ViewDetailed, "Table view: detailed (one column per signal) or readiness (a single readiness status per PR)")
+	cmd.Flags().StringSliceVar(&opts.Readiness, "readiness", nil, "Show only PRs with these readiness states, comma separated (ready, needs-review, needs-rebase, checks-failing, blocked, on-hold, frozen)")
+
+	cmd.Flags().BoolVarP(&opts.ShowFiles, "show-files", "f", false, "Show detailed file list during approval process")
+	cmd.Flags().BoolVarP(&opts.ShowDiff, "show-diff", "d", false, "Show detailed diff during approval process")
+	cmd.Flags().BoolVar(&opts.ShowCommits, "show-commits", false, "Show the commits and their messages during approval process")
+	cmd.Flags().BoolVar(&opts.ExpandChecks, "expand-checks", false, "List every check of the detailed check view, including the groups of checks that passed")
+	cmd.Flags().StringSliceVar(&opts.DiffFiles, "diff-file", nil, "Show only the files of the diff matching this glob (repeatable), e.g. '.tekton/*' or '*.yaml'")
+	cmd.Flags().StringVar(&opts.DiffMode, "diff-mode", "", "How diffs are shown: unified, split (side by side) or word (changed words inline); default from config, else unified")
+	cmd.Flags().StringVar(&opts.ApproveBody, "approve-body", "", "Review body to post when approving (overrides the configured body, default /lgtm)")
+	cmd.Flags().IntVar(&opts.MaxChanges, "max-changes", 0, "While approving, require the PR number to be typed for bot and trusted authors' PRs changing more lines than this (default from approval.max_changes)")
+	cmd.Flags().BoolVar(&opts.NoLGTM, "no-lgtm", false, "Approve without a /lgtm review body, e.g. for repositories not managed by Prow")
+}
+
+// prepare readies opts for a run of cmd, remembering whether --state and --limit were given, on the command line
+// or in the environment, so the config defaults don't override them
+func (opts *listOptions) prepare(cmd *cobra.Command) {
+	opts.stateFromFlag, opts.limitFromFlag = cmd.Flags().Changed("state"), cmd.Flags().Changed("limit")
+
+	// Piped or redirected, the table becomes plain output unless --output was given or PRs are acted on
+	if !cmd.Flags().Changed("output") && outputFormat == OutputTable && !streams.IsTerminal() && !opts.Approve && !opts.Auto && !opts.Interactive {
+		outputFormat = OutputPlain
+	}
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVarP(&repoFlag, "repo", "R", "", "Repository to use as owner/repo, instead of the configured or current one")
+	_ = RootCmd.RegisterFlagCompletionFunc("repo", func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		return completeRepositoryArgs(false)(cmd, nil, toComplete)
+	})
+	RootCmd.PersistentFlags().StringVarP(&outputFormat, "output", "o", OutputTable, "Output format: table, json, yaml (list, konflux, stats, security-queue, diff-snapshots, history, konflux migrations, pr show and config list-repos), plain (list and konflux, the default when stdout isn't a terminal), csv (list, konflux and history) or markdown (list, konflux and diff-snapshots)")
+	RootCmd.PersistentFlags().BoolVar(&noColor, "no-color", false, "Disable color output")
+	RootCmd.PersistentFlags().StringVar(&profileFlag, "profile", "", "Config profile to use, kept in ~/.config/ghprs/profiles/<name>.yaml (default $"+profileEnvVar+")")
+
+	addListFlags(listCmd, listOpts, false)
+	addListFlags(konfluxCmd, konfluxOpts, true)
+	addListFlags(searchCmd, searchOpts, false)
+	listCmd.Flags().StringVar(&listOpts.Query, "query", "", "List the PRs this GitHub search finds, in any repository, instead of those of the configured repositories (see 'ghprs search')")
+	// watch --konflux checks Tekton files too
+	addFetchFlags(watchCmd, watchOpts, true)
+}
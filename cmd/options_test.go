@@ -0,0 +1,77 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Command options", func() {
+	AfterEach(func() {
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+	})
+
+	It("should register the global flags on the root command only", func() {
+		for _, name := range []string{"repo", "output", "no-color", "verbose"} {
+			Expect(cmd.RootCmd.PersistentFlags().Lookup(name)).NotTo(BeNil(), name)
+		}
+		for _, name := range []string{"list", "konflux", "watch"} {
+			command, _, err := cmd.RootCmd.Find([]string{name})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(command.LocalNonPersistentFlags().Lookup("output")).To(BeNil(), name)
+			Expect(command.LocalNonPersistentFlags().Lookup("no-color")).To(BeNil(), name)
+		}
+	})
+
+	It("should give list, konflux and watch separate flags", func() {
+		list, _, _ := cmd.RootCmd.Find([]string{"list"})
+		konflux, _, _ := cmd.RootCmd.Find([]string{"konflux"})
+		watch, _, _ := cmd.RootCmd.Find([]string{"watch"})
+
+		Expect(list.Flags().Set("state", "closed")).To(Succeed())
+		defer func() { _ = list.Flags().Set("state", "open") }()
+
+		Expect(konflux.Flags().Lookup("state").Value.String()).To(Equal("open"))
+		Expect(watch.Flags().Lookup("state").Value.String()).To(Equal("open"))
+		Expect(konflux.Flags().Lookup("tekton-only")).NotTo(BeNil())
+		Expect(list.Flags().Lookup("tekton-only")).To(BeNil())
+		Expect(list.Flags().Lookup("author")).NotTo(BeNil())
+	})
+
+	It("should let the config defaults apply only to flags that weren't given", func() {
+		config := cmd.DefaultConfig()
+		config.Defaults.State = "all"
+		config.Defaults.Limit = 50
+
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+		state, limit := cmd.ApplyConfigDefaultsTest(config)
+		Expect(state).To(Equal("all"))
+		Expect(limit).To(Equal(50))
+
+		Expect(cmd.UseListFlagsTest([]string{"--state", "open", "--limit", "30"}, false)).To(Succeed())
+		state, limit = cmd.ApplyConfigDefaultsTest(config)
+		Expect(state).To(Equal("open"))
+		Expect(limit).To(Equal(30))
+	})
+
+	It("should treat --all as no limit", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--all"}, true)).To(Succeed())
+		_, limit := cmd.ApplyConfigDefaultsTest(cmd.DefaultConfig())
+		Expect(limit).To(Equal(0))
+	})
+
+	Describe("Batch arguments", func() {
+		It("should take the repository from the first argument", func() {
+			owner, repo, numbers := cmd.ParseBatchArgsTest([]string{"owner/repo", "12", "#15"}, "")
+			Expect(owner + "/" + repo).To(Equal("owner/repo"))
+			Expect(numbers).To(Equal([]int{12, 15}))
+		})
+
+		It("should take the repository from --repo", func() {
+			owner, repo, numbers := cmd.ParseBatchArgsTest([]string{"12", "15"}, "other/repo")
+			Expect(owner + "/" + repo).To(Equal("other/repo"))
+			Expect(numbers).To(Equal([]int{12, 15}))
+		})
+	})
+})
@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"ghprs/internal/render"
+)
+
+// OrgRepository is the part of a repository of an organization the dashboard looks at
+type OrgRepository struct {
+	FullName string   `json:"full_name"`
+	Archived bool     `json:"archived"`
+	Topics   []string `json:"topics"`
+}
+
+// discoverOrgRepositories lists the repositories of an organization that aren't archived and, when topics are
+// given, have one of them
+func discoverOrgRepositories(client RESTClientInterface, org string, topics []string) ([]string, error) {
+	var repositories []string
+	for page := 1; ; page++ {
+		var pageRepos []OrgRepository
+		path := fmt.Sprintf("orgs/%s/repos?type=all&sort=full_name&per_page=%d&page=%d", org, maxPerPage, page)
+		if err := client.Get(path, &pageRepos); err != nil {
+			return nil, err
+		}
+		for _, repo := range pageRepos {
+			if repo.Archived {
+				continue
+			}
+			if len(topics) > 0 && !slices.ContainsFunc(repo.Topics, func(topic string) bool {
+				return slices.ContainsFunc(topics, func(wanted string) bool { return strings.EqualFold(topic, wanted) })
+			}) {
+				continue
+			}
+			repositories = append(repositories, repo.FullName)
+		}
+		if len(pageRepos) < maxPerPage {
+			return repositories, nil
+		}
+	}
+}
+
+// runOrgDiscovery finds the repositories of the --org dashboard of opts, exiting on failure
+func runOrgDiscovery(opts *listOptions, limiter *rateLimiter) []string {
+	client, err := newAPIClientWithTimeout(hostFor(opts.Org, ""), limiter, nil, opts.RequestTimeout)
+	if err != nil {
+		log.Fatalf("Failed to create GitHub client: %v", err)
+	}
+	repositories, err := discoverOrgRepositories(client, opts.Org, opts.Topics)
+	if err != nil {
+		log.Fatalf("Failed to list the repositories of %s: %v", opts.Org, err)
+	}
+	logger.Info("Found repositories", "org", opts.Org, "topics", opts.Topics, "count", len(repositories))
+	return repositories
+}
+
+// repositoryFetch is the outcome of fetching the PRs of a repository ahead of showing them
+type repositoryFetch struct {
+	PullRequests []PullRequest
+	Err          error
+}
+
+// prefetchRepositoryPRs fetches the PRs of every repository concurrently, so an organization with many
+// repositories isn't listed one repository at a time. Each fetch gets the --timeout of its repository. The
+// table is still built repository by repository, with a client of its own, so with --use-graphql what the query
+// fetched besides the PRs is fetched again over REST.
+func prefetchRepositoryPRs(ctx context.Context, opts *listOptions, repositories []string, fetch func(ctx context.Context, owner, repo string) ([]PullRequest, error)) map[string]repositoryFetch {
+	fetches := make([]repositoryFetch, len(repositories))
+	runConcurrently(len(repositories), opts.Concurrency, func(i int) {
+		owner, repo, ok := parseRepoSpec(repositories[i])
+		if !ok {
+			return
+		}
+		fetchCtx, cancel := withRepositoryTimeout(ctx)
+		defer cancel()
+		prs, err := fetch(fetchCtx, owner, repo)
+		if reason := describeCancellation(fetchCtx.Err()); reason != "" {
+			err = fmt.Errorf("skipped, %s", reason)
+		}
+		fetches[i] = repositoryFetch{PullRequests: prs, Err: err}
+	})
+
+	byRepo := make(map[string]repositoryFetch, len(repositories))
+	for i, repoSpec := range repositories {
+		byRepo[repoSpec] = fetches[i]
+	}
+	return byRepo
+}
+
+// pendingRow reports whether a row still waits for someone: open, not a draft, not on hold and not approved
+func pendingRow(row PRRow) bool {
+	return row.State == "open" && !row.Draft && !row.OnHold && (row.Reviewed == nil || !*row.Reviewed)
+}
+
+// displayOrgSummary shows how many PRs each repository of the dashboard of org has and the PR waiting longest
+func displayOrgSummary(org string, combined []combinedRow, repositories []string, now time.Time) {
+	counts := make(map[string]int)
+	pending := make(map[string]int)
+	var oldest *combinedRow
+	for i, row := range combined {
+		counts[row.Row.Repository]++
+		if !pendingRow(row.Row) {
+			continue
+		}
+		pending[row.Row.Repository]++
+		if oldest == nil || row.PR.CreatedAt < oldest.PR.CreatedAt {
+			oldest = &combined[i]
+		}
+	}
+
+	var withPRs []string
+	for _, repoSpec := range repositories {
+		if counts[repoSpec] > 0 {
+			withPRs = append(withPRs, repoSpec)
+		}
+	}
+	sort.SliceStable(withPRs, func(i, j int) bool { return counts[withPRs[i]] > counts[withPRs[j]] })
+
+	streams.Printf("\n=== %s: Konflux PRs per repository ===\n", org)
+	table := render.NewTable(
+		render.Column{Header: "REPO", Width: 40, Truncate: true},
+		render.Column{Header: "PRS", Width: 4},
+		render.Column{Header: "PENDING", Width: 7},
+	)
+	for _, repoSpec := range withPRs {
+		table.AddRow(repoSpec, fmt.Sprintf("%d", counts[repoSpec]), fmt.Sprintf("%d", pending[repoSpec]))
+	}
+	table.Write(streams.Out)
+	if without := len(repositories) - len(withPRs); without > 0 {
+		streams.Printf("%d of %d repositories have no Konflux PRs\n", without, len(repositories))
+	}
+
+	if oldest == nil {
+		streams.Printf("\n✅ No Konflux PR is waiting\n")
+		return
+	}
+	age := "unknown"
+	if createdAt, err := time.Parse(time.RFC3339, oldest.PR.CreatedAt); err == nil {
+		age = formatAge(now.Sub(createdAt))
+	}
+	streams.Printf("\n⏳ Oldest pending PR: %s %s (open for %s)\n", rowPRLink(oldest.Row, "", ""), oldest.PR.Title, age)
+}
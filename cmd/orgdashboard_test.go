@@ -0,0 +1,94 @@
+package cmd_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Konflux organization dashboard", func() {
+	Describe("discovering repositories", func() {
+		var mockClient *cmd.MockRESTClient
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse("orgs/my-org/repos", 200, []cmd.OrgRepository{
+				{FullName: "my-org/api", Topics: []string{"konflux", "go"}},
+				{FullName: "my-org/old", Archived: true, Topics: []string{"konflux"}},
+				{FullName: "my-org/docs", Topics: []string{"docs"}},
+				{FullName: "my-org/ui", Topics: []string{"Konflux"}},
+			})
+		})
+
+		It("should list the repositories that aren't archived", func() {
+			repositories, err := cmd.DiscoverOrgRepositoriesTest(mockClient, "my-org", nil)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repositories).To(Equal([]string{"my-org/api", "my-org/docs", "my-org/ui"}))
+			Expect(mockClient.GetLastRequest().URL).To(Equal("orgs/my-org/repos?type=all&sort=full_name&per_page=100&page=1"))
+		})
+
+		It("should only list the repositories with one of the topics", func() {
+			repositories, err := cmd.DiscoverOrgRepositoriesTest(mockClient, "my-org", []string{"konflux"})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(repositories).To(Equal([]string{"my-org/api", "my-org/ui"}))
+		})
+
+		It("should return the error of the API", func() {
+			_, err := cmd.DiscoverOrgRepositoriesTest(mockClient, "other-org", nil)
+			Expect(err).To(HaveOccurred())
+		})
+	})
+
+	Describe("the summary", func() {
+		var out *bytes.Buffer
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+		approved := true
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should count the PRs of each repository and highlight the oldest pending one", func() {
+			prs := []cmd.PullRequest{
+				{Number: 1, Title: "Update api pipelines", CreatedAt: "2026-03-01T12:00:00Z"},
+				{Number: 2, Title: "Update api images", CreatedAt: "2026-02-01T12:00:00Z"},
+				{Number: 3, Title: "Update ui pipelines", CreatedAt: "2026-03-05T12:00:00Z"},
+				{Number: 4, Title: "Held ui update", CreatedAt: "2026-01-01T12:00:00Z"},
+			}
+			rows := []cmd.PRRow{
+				{Repository: "my-org/api", Number: 1, State: "open"},
+				{Repository: "my-org/api", Number: 2, State: "open", Reviewed: &approved},
+				{Repository: "my-org/ui", Number: 3, State: "open"},
+				{Repository: "my-org/ui", Number: 4, State: "open", OnHold: true},
+			}
+
+			cmd.DisplayOrgSummaryTest("my-org", []string{"my-org/api", "my-org/docs", "my-org/ui"}, prs, rows, now)
+
+			Expect(out.String()).To(ContainSubstring("=== my-org: Konflux PRs per repository ==="))
+			Expect(out.String()).To(MatchRegexp(`my-org/api\s+2\s+1`))
+			Expect(out.String()).To(MatchRegexp(`my-org/ui\s+2\s+1`))
+			Expect(out.String()).To(ContainSubstring("1 of 3 repositories have no Konflux PRs"))
+			Expect(out.String()).To(ContainSubstring("⏳ Oldest pending PR:"))
+			Expect(out.String()).To(ContainSubstring("Update api pipelines (open for 9d0h)"))
+		})
+
+		It("should say when no PR is waiting", func() {
+			prs := []cmd.PullRequest{{Number: 1, CreatedAt: "2026-03-01T12:00:00Z"}}
+			rows := []cmd.PRRow{{Repository: "my-org/api", Number: 1, State: "open", Draft: true}}
+
+			cmd.DisplayOrgSummaryTest("my-org", []string{"my-org/api"}, prs, rows, now)
+
+			Expect(out.String()).To(ContainSubstring("✅ No Konflux PR is waiting"))
+			Expect(out.String()).NotTo(ContainSubstring("have no Konflux PRs"))
+		})
+	})
+})
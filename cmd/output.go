@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// OutputSchemaVersion is bumped whenever the shape of the machine-readable output changes
+const OutputSchemaVersion = "1"
+
+// PRListOutput is the document emitted by 'ghprs list' and 'ghprs konflux' in json/yaml output mode
+type PRListOutput struct {
+	SchemaVersion string          `json:"schemaVersion" yaml:"schemaVersion"`
+	Konflux       bool            `json:"konflux" yaml:"konflux"`
+	Repositories  []RepositoryPRs `json:"repositories" yaml:"repositories"`
+	// AsOf is when the PRs were fetched, only set when they are shown from a snapshot with --offline
+	AsOf string `json:"asOf,omitempty" yaml:"asOf,omitempty"`
+}
+
+// RepositoryPRs holds the PR rows for a single repository
+type RepositoryPRs struct {
+	Repository string `json:"repository" yaml:"repository"`
+	// Application is the Konflux application the repository is grouped under, only set for Konflux PRs
+	Application  string  `json:"application,omitempty" yaml:"application,omitempty"`
+	PullRequests []PRRow `json:"pullRequests" yaml:"pullRequests"`
+}
+
+// PRRow is the structured form of a single row in the PR table.
+// Pointer fields are nil when the value is unknown (e.g. skipped in fast mode or the API call failed).
+type PRRow struct {
+	// Repository is only filled in for the combined table of several repositories
+	Repository string `json:"repository,omitempty" yaml:"repository,omitempty"`
+	Number     int    `json:"number" yaml:"number"`
+	Title      string `json:"title" yaml:"title"`
+	Author     string `json:"author" yaml:"author"`
+	URL        string `json:"url" yaml:"url"`
+	Branch     string `json:"branch" yaml:"branch"`
+	Target     string `json:"target" yaml:"target"`
+	State      string `json:"state" yaml:"state"`
+	// Merged is set for closed PRs that were merged
+	Merged bool `json:"merged,omitempty" yaml:"merged,omitempty"`
+	// CreatedAt and UpdatedAt are when the PR was opened and last updated, as GitHub reports them
+	CreatedAt string `json:"createdAt,omitempty" yaml:"createdAt,omitempty"`
+	UpdatedAt string `json:"updatedAt,omitempty" yaml:"updatedAt,omitempty"`
+	// Size is the size bucket of the lines the PR adds and deletes, XS to XL, empty when unknown
+	Size         string `json:"size,omitempty" yaml:"size,omitempty"`
+	Additions    int    `json:"additions,omitempty" yaml:"additions,omitempty"`
+	Deletions    int    `json:"deletions,omitempty" yaml:"deletions,omitempty"`
+	ChangedFiles int    `json:"changedFiles,omitempty" yaml:"changedFiles,omitempty"`
+	// Owners are the teams and users the CODEOWNERS of the target branch ask to approve what the PR changes
+	Owners []string `json:"owners,omitempty" yaml:"owners,omitempty"`
+	// Dependencies are what a Renovate or Dependabot PR updates, and UpdateType the most disruptive of them
+	Dependencies []DependencyUpdate `json:"dependencies,omitempty" yaml:"dependencies,omitempty"`
+	UpdateType   string             `json:"updateType,omitempty" yaml:"updateType,omitempty"`
+	// Stale is set for open PRs not updated within display.stale_after
+	Stale       bool  `json:"stale" yaml:"stale"`
+	Draft       bool  `json:"draft" yaml:"draft"`
+	OnHold      bool  `json:"onHold" yaml:"onHold"`
+	Reviewed    *bool `json:"reviewed" yaml:"reviewed"`
+	NeedsRebase *bool `json:"needsRebase" yaml:"needsRebase"`
+	Blocked     *bool `json:"blocked" yaml:"blocked"`
+	Nudge       bool  `json:"nudge" yaml:"nudge"`
+	Security    bool  `json:"security" yaml:"security"`
+	Migration   bool  `json:"migration" yaml:"migration"`
+	TektonOnly  *bool `json:"tektonOnly,omitempty" yaml:"tektonOnly,omitempty"`
+	// New is set when the PR wasn't listed before or was updated since it was last listed
+	New bool `json:"new" yaml:"new"`
+	// Application and Component are the configured Konflux application and component of a Konflux PR
+	Application string `json:"application,omitempty" yaml:"application,omitempty"`
+	Component   string `json:"component,omitempty" yaml:"component,omitempty"`
+	// Checks and Readiness are only filled in when the readiness view or filter is used, Checks also with --interactive
+	Checks    string `json:"checks,omitempty" yaml:"checks,omitempty"`
+	Readiness string `json:"readiness,omitempty" yaml:"readiness,omitempty"`
+	// Partial is set when the request budget of the repository ran out before the details of the PR were fetched,
+	// leaving reviewed, rebase, blocked, Tekton and check state unknown
+	Partial bool `json:"partial,omitempty" yaml:"partial,omitempty"`
+	// Errors maps the lookups that failed, such as reviews or checks, to their error. The values they fill in
+	// are unknown rather than false.
+	Errors map[string]string `json:"errors,omitempty" yaml:"errors,omitempty"`
+	// Priority is only filled in when sorting by priority
+	Priority *PriorityScore `json:"priority,omitempty" yaml:"priority,omitempty"`
+}
+
+// Supported values for the --output flag
+const (
+	OutputTable = "table"
+	OutputJSON  = "json"
+	OutputYAML  = "yaml"
+	// OutputPlain is a delimited table without padding or emoji, for cut, awk and spreadsheets. It is used
+	// instead of the table when stdout isn't a terminal.
+	OutputPlain = "plain"
+	// OutputCSV has the columns of plain output, quoted for spreadsheets
+	OutputCSV = "csv"
+	// OutputMarkdown is the table as Markdown with linked PRs, for status pages
+	OutputMarkdown = "markdown"
+)
+
+// validateOutputFormat checks that the --output flag has a supported value
+func validateOutputFormat(format string) error {
+	switch format {
+	case OutputTable, OutputJSON, OutputYAML, OutputPlain, OutputCSV, OutputMarkdown:
+		return nil
+	default:
+		return fmt.Errorf("invalid output format %q (must be one of: table, json, yaml, plain, csv, markdown)", format)
+	}
+}
+
+// isRowOutput reports whether the output writes the rows of the PR list as text, which only list and konflux do
+func isRowOutput(format string) bool {
+	return format == OutputPlain || format == OutputCSV || format == OutputMarkdown
+}
+
+// isStructuredOutput reports whether the output is meant for machines rather than the terminal
+func isStructuredOutput(format string) bool {
+	return format == OutputJSON || format == OutputYAML
+}
+
+// writeStructuredOutput encodes an output document, such as the PR list, in the requested format
+func writeStructuredOutput(w io.Writer, doc any, format string) error {
+	switch format {
+	case OutputJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(doc)
+	case OutputYAML:
+		encoder := yaml.NewEncoder(w)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(doc); err != nil {
+			return err
+		}
+		return encoder.Close()
+	default:
+		return fmt.Errorf("unsupported structured output format %q", format)
+	}
+}
+
+// plainColumns are the columns of plain output, always all of them in this order so scripts can rely on
+// their position
+var plainColumns = []string{
+	"REPOSITORY", "NUMBER", "TITLE", "AUTHOR", "BRANCH", "TARGET", "STATE", "DRAFT", "ON_HOLD", "REVIEWED",
+	"NEEDS_REBASE", "BLOCKED", "NUDGE", "SECURITY", "MIGRATION", "TEKTON_ONLY", "APPLICATION", "COMPONENT",
+	"CHECKS", "READINESS", "NEW", "URL", "CREATED_AT", "UPDATED_AT", "STALE",
+	"SIZE", "ADDITIONS", "DELETIONS", "CHANGED_FILES", "OWNERS",
+	"DEP", "SEMVER", "ERRORS",
+}
+
+// validateDelimiter checks the --delimiter of plain output
+func validateDelimiter(delimiter string) error {
+	if delimiter == "" || strings.ContainsAny(delimiter, "\r\n") {
+		return fmt.Errorf("invalid --delimiter %q (must be non-empty and on one line)", delimiter)
+	}
+	return nil
+}
+
+// writePlainOutput writes the PR list as a header line and one line per PR, with the fields separated by
+// delimiter. Unknown values are empty.
+func writePlainOutput(w io.Writer, doc PRListOutput, delimiter string) error {
+	if _, err := fmt.Fprintln(w, strings.Join(plainColumns, delimiter)); err != nil {
+		return err
+	}
+	for _, repository := range doc.Repositories {
+		for _, row := range repository.PullRequests {
+			fields := plainFields(repository.Repository, row)
+			for i, field := range fields {
+				fields[i] = plainField(field, delimiter)
+			}
+			if _, err := fmt.Fprintln(w, strings.Join(fields, delimiter)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// plainFields returns the fields of a row in the order of plainColumns
+func plainFields(repository string, row PRRow) []string {
+	return []string{
+		repository, strconv.Itoa(row.Number), row.Title, row.Author, row.Branch, row.Target,
+		row.State, strconv.FormatBool(row.Draft), strconv.FormatBool(row.OnHold), plainBool(row.Reviewed),
+		plainBool(row.NeedsRebase), plainBool(row.Blocked), strconv.FormatBool(row.Nudge),
+		strconv.FormatBool(row.Security), strconv.FormatBool(row.Migration), plainBool(row.TektonOnly),
+		row.Application, row.Component, row.Checks, row.Readiness, strconv.FormatBool(row.New), row.URL,
+		row.CreatedAt, row.UpdatedAt, strconv.FormatBool(row.Stale),
+		row.Size, plainCount(row.Size, row.Additions), plainCount(row.Size, row.Deletions), plainCount(row.Size, row.ChangedFiles),
+		ownersColumn(row),
+		dependencyColumn(row), row.UpdateType, plainErrors(row),
+	}
+}
+
+// writeCSVOutput writes the PR list as CSV with the columns of plain output, quoting fields as needed
+func writeCSVOutput(w io.Writer, doc PRListOutput) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(plainColumns); err != nil {
+		return err
+	}
+	for _, repository := range doc.Repositories {
+		for _, row := range repository.PullRequests {
+			if err := writer.Write(plainFields(repository.Repository, row)); err != nil {
+				return err
+			}
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+// writeMarkdownOutput writes the PR list as a Markdown table per repository, with the PRs linked and the
+// indicator columns of the table, marking what fast mode skipped with "-"
+func writeMarkdownOutput(w io.Writer, doc PRListOutput, fast bool) error {
+	headers := []string{"PR", "Title", "Author", "Target", "Status", "Reviewed", "Rebase", "Blocked", "Migration"}
+	if doc.Konflux {
+		headers = append(headers, "Tekton")
+	}
+	var b strings.Builder
+	for i, repository := range doc.Repositories {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "### %s\n\n", repository.Repository)
+		if len(repository.PullRequests) == 0 {
+			b.WriteString("No pull requests\n")
+			continue
+		}
+		writeMarkdownRow(&b, headers)
+		writeMarkdownRow(&b, slices.Repeat([]string{"---"}, len(headers)))
+		for _, row := range repository.PullRequests {
+			status := row.State
+			if row.Draft {
+				status = "draft"
+			} else if row.OnHold {
+				status = "on hold"
+			}
+			reviewed := "-"
+			if row.Reviewed != nil {
+				reviewed = "❌"
+				if *row.Reviewed {
+					reviewed = "✅"
+				}
+			}
+			reviewed = lookupColumn(row, lookupReviews, reviewed)
+			migration := ""
+			if row.Migration {
+				migration = "🚨"
+			}
+			cells := []string{
+				fmt.Sprintf("[#%d](%s)", row.Number, row.URL), row.Title, row.Author, row.Target,
+				statusIcon(row.State, row.Draft, row.OnHold) + " " + status, reviewed,
+				lookupColumn(row, lookupDetails, triStateColumn(row.NeedsRebase, "🔄", fast)),
+				lookupColumn(row, lookupDetails, triStateColumn(row.Blocked, "🚫", fast)), migration,
+			}
+			if doc.Konflux {
+				cells = append(cells, lookupColumn(row, lookupFiles, tektonColumn(row, fast)))
+			}
+			writeMarkdownRow(&b, cells)
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMarkdownRow writes a row of a Markdown table, escaping what would end a cell or the row
+func writeMarkdownRow(b *strings.Builder, cells []string) {
+	replacer := strings.NewReplacer("|", "\\|", "\r", " ", "\n", " ")
+	b.WriteString("|")
+	for _, cell := range cells {
+		fmt.Fprintf(b, " %s |", replacer.Replace(cell))
+	}
+	b.WriteString("\n")
+}
+
+// plainCount formats a change count of a row, empty when its size is unknown
+func plainCount(size string, count int) string {
+	if size == "" {
+		return ""
+	}
+	return strconv.Itoa(count)
+}
+
+// plainBool formats a tri-state row field, empty when unknown
+func plainBool(value *bool) string {
+	if value == nil {
+		return ""
+	}
+	return strconv.FormatBool(*value)
+}
+
+// plainField replaces line breaks, tabs and the delimiter in a field with spaces so it can't shift the columns
+func plainField(field, delimiter string) string {
+	field = strings.NewReplacer("\r", " ", "\n", " ", "\t", " ").Replace(field)
+	return strings.ReplaceAll(field, delimiter, " ")
+}
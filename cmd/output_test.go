@@ -0,0 +1,181 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"gopkg.in/yaml.v3"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Structured Output", func() {
+	Describe("Output format validation", func() {
+		It("should accept supported formats", func() {
+			for _, format := range []string{"table", "json", "yaml", "plain", "csv", "markdown"} {
+				Expect(cmd.ValidateOutputFormatTest(format)).To(Succeed())
+			}
+		})
+
+		It("should reject unsupported formats", func() {
+			err := cmd.ValidateOutputFormatTest("xml")
+			Expect(err).To(HaveOccurred())
+			Expect(err.Error()).To(ContainSubstring("xml"))
+		})
+	})
+
+	Describe("Building PR rows", func() {
+		var mockClient *cmd.MockRESTClient
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+		})
+
+		It("should populate row fields from the PR and API responses", func() {
+			pr := cmd.PullRequest{
+				Number:         42,
+				Title:          "fix(deps): update CVE-2024-1234",
+				State:          "open",
+				User:           cmd.User{Login: "red-hat-konflux[bot]"},
+				Head:           cmd.Branch{Ref: "konflux/update", SHA: "abc"},
+				Base:           cmd.Branch{Ref: "main"},
+				Body:           "⚠️[migration] read the notes",
+				MergeableState: "behind",
+				Labels:         []cmd.Label{{Name: "konflux-nudge"}},
+			}
+			mockClient.AddResponse("repos/owner/repo/pulls/42/reviews", 200, cmd.CreateMockReviews(true))
+			mockClient.AddResponse("repos/owner/repo/pulls/42/files", 200, cmd.CreateMockPRFiles(true))
+
+			rows := cmd.BuildPRRowsTest([]cmd.PullRequest{pr}, "owner", "repo", mockClient, true)
+			Expect(rows).To(HaveLen(1))
+
+			row := rows[0]
+			Expect(row.Number).To(Equal(42))
+			Expect(row.Author).To(Equal("red-hat-konflux[bot]"))
+			Expect(row.URL).To(Equal("https://github.com/owner/repo/pull/42"))
+			Expect(row.Security).To(BeTrue())
+			Expect(row.Migration).To(BeTrue())
+			Expect(row.Nudge).To(BeTrue())
+			Expect(row.Reviewed).To(HaveValue(BeTrue()))
+			Expect(row.NeedsRebase).To(HaveValue(BeTrue()))
+			Expect(row.Blocked).To(HaveValue(BeFalse()))
+			Expect(row.TektonOnly).To(HaveValue(BeTrue()))
+		})
+
+		It("should leave mergeable flags unknown when the state is unavailable", func() {
+			pr := cmd.PullRequest{Number: 7, State: "open"}
+
+			rows := cmd.BuildPRRowsTest([]cmd.PullRequest{pr}, "owner", "repo", mockClient, false)
+			Expect(rows[0].NeedsRebase).To(BeNil())
+			Expect(rows[0].Blocked).To(BeNil())
+			Expect(rows[0].TektonOnly).To(BeNil())
+		})
+	})
+
+	Describe("Encoding", func() {
+		var doc cmd.PRListOutput
+
+		BeforeEach(func() {
+			reviewed := true
+			doc = cmd.PRListOutput{
+				SchemaVersion: cmd.OutputSchemaVersion,
+				Repositories: []cmd.RepositoryPRs{
+					{
+						Repository: "owner/repo",
+						PullRequests: []cmd.PRRow{
+							{Number: 1, Title: "First", State: "open", Reviewed: &reviewed},
+						},
+					},
+				},
+			}
+		})
+
+		It("should write JSON that round-trips", func() {
+			var buf bytes.Buffer
+			Expect(cmd.WriteStructuredOutputTest(&buf, doc, "json")).To(Succeed())
+
+			var decoded cmd.PRListOutput
+			Expect(json.Unmarshal(buf.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(doc))
+			Expect(buf.String()).To(ContainSubstring(`"needsRebase": null`))
+		})
+
+		It("should write YAML that round-trips", func() {
+			var buf bytes.Buffer
+			Expect(cmd.WriteStructuredOutputTest(&buf, doc, "yaml")).To(Succeed())
+
+			var decoded cmd.PRListOutput
+			Expect(yaml.Unmarshal(buf.Bytes(), &decoded)).To(Succeed())
+			Expect(decoded).To(Equal(doc))
+		})
+
+		It("should reject the table format", func() {
+			var buf bytes.Buffer
+			Expect(cmd.WriteStructuredOutputTest(&buf, doc, "table")).NotTo(Succeed())
+		})
+
+		It("should write plain output with a header and every column, unknown values empty", func() {
+			var buf bytes.Buffer
+			Expect(cmd.WritePlainOutputTest(&buf, doc, "\t")).To(Succeed())
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			Expect(lines).To(HaveLen(2))
+			header, fields := strings.Split(lines[0], "\t"), strings.Split(lines[1], "\t")
+			Expect(header[:3]).To(Equal([]string{"REPOSITORY", "NUMBER", "TITLE"}))
+			Expect(fields).To(HaveLen(len(header)))
+			Expect(fields[:3]).To(Equal([]string{"owner/repo", "1", "First"}))
+			Expect(fields[9]).To(Equal("true")) // REVIEWED
+			Expect(fields[10]).To(BeEmpty())    // NEEDS_REBASE is unknown
+			Expect(buf.String()).NotTo(ContainSubstring("✅"))
+		})
+
+		It("should keep the columns in place when a field contains the delimiter or a line break", func() {
+			doc.Repositories[0].PullRequests[0].Title = "chore: a,b\nc\td"
+			var buf bytes.Buffer
+			Expect(cmd.WritePlainOutputTest(&buf, doc, ",")).To(Succeed())
+
+			lines := strings.Split(strings.TrimSuffix(buf.String(), "\n"), "\n")
+			Expect(lines).To(HaveLen(2))
+			Expect(strings.Split(lines[1], ",")).To(HaveLen(len(strings.Split(lines[0], ","))))
+			Expect(lines[1]).To(ContainSubstring("chore: a b c d"))
+		})
+
+		It("should write CSV with the columns of plain output, quoting fields", func() {
+			doc.Repositories[0].PullRequests[0].Title = "chore: a, \"b\""
+			var buf bytes.Buffer
+			Expect(cmd.WriteCSVOutputTest(&buf, doc)).To(Succeed())
+
+			records, err := csv.NewReader(&buf).ReadAll()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(records).To(HaveLen(2))
+			Expect(records[0][:3]).To(Equal([]string{"REPOSITORY", "NUMBER", "TITLE"}))
+			Expect(records[1]).To(HaveLen(len(records[0])))
+			Expect(records[1][:3]).To(Equal([]string{"owner/repo", "1", "chore: a, \"b\""}))
+			Expect(records[1][9]).To(Equal("true")) // REVIEWED
+		})
+
+		It("should write a Markdown table per repository with linked PRs", func() {
+			doc.Konflux = true
+			doc.Repositories[0].PullRequests[0].URL = "https://github.com/owner/repo/pull/1"
+			doc.Repositories[0].PullRequests[0].Title = "Update a | b"
+			doc.Repositories[0].PullRequests[0].Migration = true
+			doc.Repositories = append(doc.Repositories, cmd.RepositoryPRs{Repository: "owner/empty"})
+			var buf bytes.Buffer
+			Expect(cmd.WriteMarkdownOutputTest(&buf, doc)).To(Succeed())
+
+			Expect(buf.String()).To(ContainSubstring("### owner/repo\n\n| PR | Title | Author | Target | Status | Reviewed | Rebase | Blocked | Migration | Tekton |\n"))
+			Expect(buf.String()).To(ContainSubstring("| [#1](https://github.com/owner/repo/pull/1) | Update a \\| b |"))
+			Expect(buf.String()).To(ContainSubstring("| ✅ | ? | ? | 🚨 | ❌ |"))
+			Expect(buf.String()).To(ContainSubstring("### owner/empty\n\nNo pull requests\n"))
+		})
+
+		It("should reject an empty delimiter", func() {
+			Expect(cmd.ValidateDelimiterTest("")).NotTo(Succeed())
+			Expect(cmd.ValidateDelimiterTest(";")).To(Succeed())
+		})
+	})
+})
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+)
+
+// meLogin stands for the authenticated user wherever a login is expected, as in gh
+const meLogin = "@me"
+
+var (
+	viewerLoginsMutex sync.Mutex
+	// viewerLogins maps a GitHub host to the login of the authenticated user there
+	viewerLogins = map[string]string{}
+)
+
+// isMe reports whether login stands for the authenticated user
+func isMe(login string) bool {
+	return login == meLogin || strings.EqualFold(login, "me")
+}
+
+// viewerLogin returns the login of the user authenticated with the host of owner/repo
+func viewerLogin(client RESTClientInterface, owner, repo string) (string, error) {
+	host := hostFor(owner, repo)
+	viewerLoginsMutex.Lock()
+	defer viewerLoginsMutex.Unlock()
+	if login, ok := viewerLogins[host]; ok {
+		return login, nil
+	}
+	var user User
+	if err := client.Get("user", &user); err != nil {
+		return "", fmt.Errorf("failed to look up the authenticated user: %w", err)
+	}
+	if user.Login == "" {
+		return "", fmt.Errorf("failed to look up the authenticated user")
+	}
+	viewerLogins[host] = user.Login
+	return user.Login, nil
+}
+
+// peopleFilter selects PRs by who is asked to review them and who they are assigned to
+type peopleFilter struct {
+	// ReviewRequested is the login whose review must be requested
+	ReviewRequested string
+	// Assignee is the login the PR must be assigned to
+	Assignee string
+}
+
+// resolvePeople replaces @me in authors with the authenticated user's login and builds the people filter from the
+// --review-requested and --assignee flags of opts, looking the user up only when needed
+func resolvePeople(opts *listOptions, client RESTClientInterface, owner, repo string, authors []string) ([]string, peopleFilter, error) {
+	var people peopleFilter
+	needsViewer := opts.ReviewRequested || isMe(opts.Assignee) || slices.ContainsFunc(authors, isMe)
+	if !needsViewer {
+		people.Assignee = opts.Assignee
+		return authors, people, nil
+	}
+
+	login, err := viewerLogin(client, owner, repo)
+	if err != nil {
+		return nil, people, err
+	}
+	resolved := make([]string, len(authors))
+	for i, author := range authors {
+		resolved[i] = author
+		if isMe(author) {
+			resolved[i] = login
+		}
+	}
+	if opts.ReviewRequested {
+		people.ReviewRequested = login
+	}
+	people.Assignee = opts.Assignee
+	if isMe(opts.Assignee) {
+		people.Assignee = login
+	}
+	return resolved, people, nil
+}
+
+// empty reports whether the filter keeps every PR
+func (f peopleFilter) empty() bool {
+	return f.ReviewRequested == "" && f.Assignee == ""
+}
+
+// matches reports whether a PR passes the filter
+func (f peopleFilter) matches(pr PullRequest) bool {
+	hasLogin := func(users []User, login string) bool {
+		return slices.ContainsFunc(users, func(user User) bool { return strings.EqualFold(user.Login, login) })
+	}
+	if f.ReviewRequested != "" && !hasLogin(pr.RequestedReviewers, f.ReviewRequested) {
+		return false
+	}
+	if f.Assignee != "" && !hasLogin(pr.Assignees, f.Assignee) {
+		return false
+	}
+	return true
+}
+
+// wrap returns a page filter that applies the people filter before filter, whose checks may need API calls
+func (f peopleFilter) wrap(filter prFilter) prFilter {
+	if f.empty() {
+		return filter
+	}
+	return func(client RESTClientInterface, page []PullRequest) []PullRequest {
+		var kept []PullRequest
+		for _, pr := range page {
+			if f.matches(pr) {
+				kept = append(kept, pr)
+			}
+		}
+		return filter(client, kept)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("People filters", func() {
+	var mockClient *cmd.MockRESTClient
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("user", 200, cmd.User{Login: "me-dev"})
+		mockClient.AddResponse("repos/owner/repo/pulls?state=open", 200, []cmd.PullRequest{
+			{Number: 1, State: "open", User: cmd.User{Login: "me-dev"}},
+			{Number: 2, State: "open", User: cmd.User{Login: "other"}, RequestedReviewers: []cmd.User{{Login: "Me-Dev"}}},
+			{Number: 3, State: "open", User: cmd.User{Login: "other"}, Assignees: []cmd.User{{Login: "me-dev"}}, RequestedReviewers: []cmd.User{{Login: "someone"}}},
+			{Number: 4, State: "open", User: cmd.User{Login: "other"}, Assignees: []cmd.User{{Login: "someone"}}},
+		})
+	})
+
+	It("should list PRs whose review is requested from the authenticated user", func() {
+		prs, err := cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", nil, true, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(prs)).To(Equal([]int{2}))
+	})
+
+	It("should list PRs assigned to a user or to the authenticated user", func() {
+		prs, err := cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", nil, false, "me")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(prs)).To(Equal([]int{3}))
+
+		prs, err = cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", nil, false, "someone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(prs)).To(Equal([]int{4}))
+	})
+
+	It("should resolve @me among the authors", func() {
+		prs, err := cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", []string{"@me"}, false, "")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(prs)).To(Equal([]int{1}))
+	})
+
+	It("should not look up the authenticated user when no filter needs it", func() {
+		prs, err := cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", []string{"other"}, false, "someone")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(prs)).To(Equal([]int{4}))
+		Expect(mockClient.Requests).NotTo(ContainElement(HaveField("URL", "user")))
+	})
+
+	It("should fail when the authenticated user can't be looked up", func() {
+		mockClient.AddResponse("user", 401, map[string]string{"message": "Bad credentials"})
+		_, err := cmd.FetchPeoplePRsTest(mockClient, "owner", "repo", nil, true, "")
+		Expect(err).To(MatchError(ContainSubstring("authenticated user")))
+	})
+})
@@ -0,0 +1,170 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// Image pinning policies: which change of an image reference in a Konflux diff is flagged
+const (
+	// PinningDigest expects images to stay pinned by digest and flags switches to a floating tag
+	PinningDigest = "digest"
+	// PinningTag expects images to be referenced by tag and flags switches to a digest
+	PinningTag = "tag"
+	// PinningOff disables the check
+	PinningOff = "off"
+)
+
+// imageRefRE matches image references such as quay.io/org/image:tag, quay.io/org/image@sha256:... or both.
+// The registry must look like a host name so paths such as .tekton/build.yaml aren't mistaken for images.
+var imageRefRE = regexp.MustCompile(`((?:[a-z0-9-]+\.)+[a-z0-9-]+(?::\d+)?/[a-z0-9._/-]+)(?::([A-Za-z0-9_][A-Za-z0-9_.-]{0,127}))?(?:@(sha256:[a-f0-9]{64}))?`)
+
+// imageRef is an image reference found in a diff
+type imageRef struct {
+	Name   string
+	Tag    string
+	Digest string
+}
+
+// pinned reports whether the reference is pinned by digest
+func (r imageRef) pinned() bool {
+	return r.Digest != ""
+}
+
+// String describes how the image is referenced, e.g. "digest sha256:0123456789ab" or "tag latest"
+func (r imageRef) String() string {
+	if r.pinned() {
+		return "digest " + r.Digest[:min(len(r.Digest), len("sha256:")+12)]
+	}
+	if r.Tag == "" {
+		return "no tag (latest)"
+	}
+	return "tag " + r.Tag
+}
+
+// PinningChange is an image whose reference changed against the pinning policy
+type PinningChange struct {
+	File  string
+	Image string
+	From  string
+	To    string
+}
+
+// validatePinningPolicy checks that policy is a known image pinning policy
+func validatePinningPolicy(policy string) error {
+	switch policy {
+	case PinningDigest, PinningTag, PinningOff:
+		return nil
+	default:
+		return fmt.Errorf("invalid image pinning policy %q (must be %s, %s or %s)", policy, PinningDigest, PinningTag, PinningOff)
+	}
+}
+
+// findImageRefs returns the image references on a line of a diff
+func findImageRefs(line string) []imageRef {
+	var refs []imageRef
+	for _, match := range imageRefRE.FindAllStringSubmatchIndex(line, -1) {
+		// URLs look like image references too
+		if strings.HasSuffix(line[:match[0]], "://") {
+			continue
+		}
+		ref := imageRef{Name: line[match[2]:match[3]]}
+		if match[4] >= 0 {
+			ref.Tag = line[match[4]:match[5]]
+		}
+		if match[6] >= 0 {
+			ref.Digest = line[match[6]:match[7]]
+		}
+		refs = append(refs, ref)
+	}
+	return refs
+}
+
+// imagePinningChanges compares the image references removed and added by the patch of each file and
+// returns the images whose pinning changed against policy
+func imagePinningChanges(files []PRFile, policy string) []PinningChange {
+	if policy == PinningOff {
+		return nil
+	}
+
+	var changes []PinningChange
+	for _, file := range files {
+		removed := make(map[string]imageRef)
+		var added []imageRef
+		for _, line := range strings.Split(file.Patch, "\n") {
+			switch {
+			case strings.HasPrefix(line, "---"), strings.HasPrefix(line, "+++"):
+				continue
+			case strings.HasPrefix(line, "-"):
+				for _, ref := range findImageRefs(line) {
+					removed[ref.Name] = ref
+				}
+			case strings.HasPrefix(line, "+"):
+				added = append(added, findImageRefs(line)...)
+			}
+		}
+
+		reported := make(map[string]bool)
+		for _, to := range added {
+			from, ok := removed[to.Name]
+			if !ok || reported[to.Name] || from.pinned() == to.pinned() {
+				continue
+			}
+			if (policy == PinningDigest && from.pinned()) || (policy == PinningTag && to.pinned()) {
+				reported[to.Name] = true
+				changes = append(changes, PinningChange{File: file.Filename, Image: to.Name, From: from.String(), To: to.String()})
+			}
+		}
+	}
+	return changes
+}
+
+// fetchImagePinningChanges fetches the patches of a PR's files and returns its image pinning changes
+func fetchImagePinningChanges(client RESTClientInterface, owner, repo string, prNumber int, policy string) ([]PinningChange, error) {
+	if policy == PinningOff {
+		return nil, nil
+	}
+	// The files prefetched by GraphQL don't include their patches
+	ctx := withFreshData(context.Background())
+	filesPath := fmt.Sprintf("repos/%s/%s/pulls/%d/files?per_page=%d", owner, repo, prNumber, maxPerPage)
+	var files []PRFile
+	if err := client.DoWithContext(ctx, http.MethodGet, filesPath, nil, &files); err != nil {
+		return nil, err
+	}
+	return imagePinningChanges(files, policy), nil
+}
+
+// displayPinningChanges warns about the image pinning changes of a PR
+func displayPinningChanges(changes []PinningChange) {
+	for _, change := range changes {
+		streams.Printf("   🔓 IMAGE PINNING: %s changed from %s to %s in %s\n", change.Image, change.From, change.To, change.File)
+	}
+}
+
+// confirmPinningChanges asks for confirmation before approving a PR that changes image pinning against policy.
+// It reports whether the approval should go ahead.
+func confirmPinningChanges(client RESTClientInterface, owner, repo string, pr PullRequest, policy string) bool {
+	changes, err := fetchImagePinningChanges(client, owner, repo, pr.Number, policy)
+	if err != nil || len(changes) == 0 {
+		// The check was already reported when the PR was shown
+		return true
+	}
+
+	streams.Printf("\n🔓 ⚠️  IMAGE PINNING CHANGED ⚠️  🔓\n")
+	streams.Printf("This PR changes how %d image(s) are referenced against the %s pinning policy:\n", len(changes), policy)
+	displayPinningChanges(changes)
+	confirmed, err := prompter.Confirm("\nAre you sure you want to approve this PR with image pinning changes?")
+	if err != nil {
+		streams.Printf("Error reading confirmation: %v (skipping PR)\n", err)
+		return false
+	}
+	if !confirmed {
+		streams.Printf("❌ Approval cancelled due to image pinning changes. Skipping PR %s\n", formatPRLink(owner, repo, pr.Number))
+		return false
+	}
+	streams.Printf("✅ Confirmed - proceeding with approval despite image pinning changes.\n")
+	return true
+}
@@ -0,0 +1,103 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Image pinning", func() {
+	const digest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	const otherDigest = "sha256:fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+	const task = "quay.io/konflux-ci/tekton-catalog/task-buildah"
+
+	patch := func(lines ...string) cmd.PRFile {
+		return cmd.PRFile{Filename: ".tekton/app-push.yaml", Patch: strings.Join(lines, "\n")}
+	}
+
+	It("should flag images unpinned from a digest under the digest policy", func() {
+		files := []cmd.PRFile{patch(
+			"@@ -10,7 +10,7 @@ spec:",
+			"-      value: "+task+":0.4@"+digest,
+			"+      value: "+task+":0.5",
+		)}
+		changes := cmd.ImagePinningChangesTest(files, cmd.PinningDigest)
+		Expect(changes).To(Equal([]cmd.PinningChange{{
+			File: ".tekton/app-push.yaml", Image: task, From: "digest sha256:0123456789ab", To: "tag 0.5",
+		}}))
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningTag)).To(BeEmpty())
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningOff)).To(BeEmpty())
+	})
+
+	It("should not flag the usual digest updates of Konflux", func() {
+		files := []cmd.PRFile{patch(
+			"-      value: "+task+":0.4@"+digest,
+			"+      value: "+task+":0.4@"+otherDigest,
+			" # see https://quay.io/repository/konflux-ci/tekton-catalog",
+		)}
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningDigest)).To(BeEmpty())
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningTag)).To(BeEmpty())
+	})
+
+	It("should flag images pinned to a digest under the tag policy", func() {
+		files := []cmd.PRFile{patch(
+			"-  image: registry.example.com:5000/team/app",
+			"+  image: registry.example.com:5000/team/app@"+digest,
+		)}
+		changes := cmd.ImagePinningChangesTest(files, cmd.PinningTag)
+		Expect(changes).To(HaveLen(1))
+		Expect(changes[0].Image).To(Equal("registry.example.com:5000/team/app"))
+		Expect(changes[0].From).To(Equal("no tag (latest)"))
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningDigest)).To(BeEmpty())
+	})
+
+	It("should ignore file names and URLs", func() {
+		files := []cmd.PRFile{patch(
+			"--- a/.tekton/app-push.yaml",
+			"+++ b/.tekton/app-push.yaml",
+			"-    url: https://quay.io/konflux-ci/tekton-catalog@"+digest,
+			"+    url: https://quay.io/konflux-ci/tekton-catalog",
+		)}
+		Expect(cmd.ImagePinningChangesTest(files, cmd.PinningDigest)).To(BeEmpty())
+	})
+
+	Describe("Confirming an approval", func() {
+		var mockClient *cmd.MockRESTClient
+		var in, out *bytes.Buffer
+		pr := cmd.PullRequest{Number: 4}
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+			mockClient.AddResponse("repos/owner/repo/pulls/4/files", 200, []cmd.PRFile{patch(
+				"-      value: "+task+":0.4@"+digest,
+				"+      value: "+task+":latest",
+			)})
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should skip the PR unless the change is confirmed", func() {
+			in.WriteString("n\n")
+			Expect(cmd.ConfirmPinningChangesTest(mockClient, "owner", "repo", pr, cmd.PinningDigest)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring(task + " changed from digest sha256:0123456789ab to tag latest"))
+		})
+
+		It("should approve after confirmation", func() {
+			in.WriteString("y\n")
+			Expect(cmd.ConfirmPinningChangesTest(mockClient, "owner", "repo", pr, cmd.PinningDigest)).To(BeTrue())
+		})
+
+		It("should not ask when nothing changed against the policy", func() {
+			Expect(cmd.ConfirmPinningChangesTest(mockClient, "owner", "repo", pr, cmd.PinningTag)).To(BeTrue())
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
+})
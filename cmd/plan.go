@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+
+	"ghprs/internal/render"
+)
+
+// Actions that can appear in a batch plan
+const (
+	PlanActionHold    = "hold"
+	PlanActionUnhold  = "unhold"
+	PlanActionApprove = "approve"
+	PlanActionSkip    = "skip"
+)
+
+// PlannedAction is one step of a batch operation: what will be done to a PR and why
+type PlannedAction struct {
+	Number int
+	Title  string
+	Action string
+	Reason string
+}
+
+// batchPlan lists everything a batch operation is about to do to one repository's PRs,
+// so it can be reviewed and confirmed once before anything is changed
+type batchPlan struct {
+	owner   string
+	repo    string
+	actions []PlannedAction
+}
+
+// add appends a step to the plan
+func (p *batchPlan) add(pr PullRequest, action, reason string) {
+	p.actions = append(p.actions, PlannedAction{Number: pr.Number, Title: pr.Title, Action: action, Reason: reason})
+}
+
+// pending returns the steps that will change something, leaving out skipped PRs
+func (p *batchPlan) pending() []PlannedAction {
+	var pending []PlannedAction
+	for _, action := range p.actions {
+		if action.Action != PlanActionSkip {
+			pending = append(pending, action)
+		}
+	}
+	return pending
+}
+
+// renderPlan prints the plan as a table of PR, action and reason
+func renderPlan(plan *batchPlan) {
+	streams.Printf("\n=== %s/%s: planned actions ===\n", plan.owner, plan.repo)
+	table := render.NewTable(
+		render.Column{Header: "PR", Width: 6},
+		render.Column{Header: "TITLE", Width: 41, Truncate: true},
+		render.Column{Header: "ACTION", Width: 8},
+		render.Column{Header: "REASON", Width: 6},
+	)
+	for _, action := range plan.actions {
+		table.AddRow(formatPRLink(plan.owner, plan.repo, action.Number), action.Title, action.Action, action.Reason)
+	}
+	table.Write(streams.Out)
+}
+
+// confirmPlan shows the plan and asks once whether to carry it out.
+// It returns false without asking when the plan has nothing to do, and true without asking when assumeYes is set.
+func confirmPlan(plan *batchPlan, assumeYes bool) bool {
+	renderPlan(plan)
+
+	pending := len(plan.pending())
+	if pending == 0 {
+		streams.Println("\nNothing to do.")
+		return false
+	}
+	if assumeYes {
+		return true
+	}
+
+	confirmed, err := prompter.Confirm(fmt.Sprintf("\nProceed with %d action(s) on %s/%s?", pending, plan.owner, plan.repo))
+	if err != nil || !confirmed {
+		streams.Println("Cancelled, no changes were made.")
+		return false
+	}
+	return true
+}
+
+// executePlan runs each pending step of the plan and prints a summary, returning the number of steps that failed
+func executePlan(plan *batchPlan, run func(action PlannedAction) error) int {
+	succeeded, failed := 0, 0
+	for _, action := range plan.pending() {
+		link := formatPRLink(plan.owner, plan.repo, action.Number)
+		if err := run(action); err != nil {
+			streams.Printf("   ❌ %s %s: %v\n", action.Action, link, err)
+			failed++
+			continue
+		}
+		streams.Printf("   ✓ %s %s\n", action.Action, link)
+		succeeded++
+	}
+
+	streams.Printf("\n📊 Succeeded: %d, failed: %d, skipped: %d\n", succeeded, failed, len(plan.actions)-succeeded-failed)
+	return failed
+}
@@ -0,0 +1,120 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Batch plans", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, Title: "Bump foo", State: "open"})
+		mockClient.AddResponse("repos/owner/repo/pulls/2", 200, cmd.PullRequest{Number: 2, Title: "Bump bar", State: "closed"})
+		mockClient.AddResponse("repos/owner/repo/pulls/3", 200, cmd.PullRequest{
+			Number: 3, Title: "Bump baz", State: "open", Labels: []cmd.Label{{Name: "do-not-merge/hold"}},
+		})
+		mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+		mockClient.AddResponse("repos/owner/repo/issues/1/labels", 200, []cmd.Label{})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	holdComments := func() []string {
+		var bodies []string
+		for _, req := range mockClient.Requests {
+			if req.Method == "POST" && strings.HasSuffix(req.URL, "/comments") {
+				bodies = append(bodies, req.Body)
+			}
+		}
+		return bodies
+	}
+
+	It("should plan a hold only for open PRs that aren't on hold", func() {
+		actions := cmd.PlanHoldTest(mockClient, "owner", "repo", []int{1, 2, 3}, "")
+		Expect(actions).To(Equal([]cmd.PlannedAction{
+			{Number: 1, Title: "Bump foo", Action: cmd.PlanActionHold, Reason: "requested"},
+			{Number: 2, Title: "Bump bar", Action: cmd.PlanActionSkip, Reason: "PR is closed"},
+			{Number: 3, Title: "Bump baz", Action: cmd.PlanActionSkip, Reason: "already on hold"},
+		}))
+	})
+
+	It("should show the plan and change nothing when it isn't confirmed", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("n\n"), out, out), nil)
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{1, 2}, "release freeze", false)).To(Equal(0))
+
+		Expect(out.String()).To(ContainSubstring("planned actions"))
+		Expect(out.String()).To(ContainSubstring("release freeze"))
+		Expect(out.String()).To(ContainSubstring("Proceed with 1 action(s) on owner/repo? [y/N]"))
+		Expect(out.String()).To(ContainSubstring("no changes were made"))
+		Expect(holdComments()).To(BeEmpty())
+	})
+
+	It("should carry out the plan after a single confirmation", func() {
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("y\n"), out, out), nil)
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{1, 3}, "release freeze", false)).To(Equal(0))
+
+		Expect(holdComments()).To(Equal([]string{`{"body":"/hold\n\nrelease freeze"}`}))
+		Expect(out.String()).To(ContainSubstring("Succeeded: 1, failed: 0, skipped: 1"))
+	})
+
+	It("should not ask when confirmation is assumed or there is nothing to do", func() {
+		prompter := &scriptedPrompter{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), prompter)
+
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{1}, "", true)).To(Equal(0))
+		Expect(cmd.HoldPRsTest(mockClient, "owner", "repo", []int{3}, "", false)).To(Equal(0))
+
+		Expect(prompter.prompts).To(BeEmpty())
+		Expect(holdComments()).To(HaveLen(1))
+		Expect(out.String()).To(ContainSubstring("Nothing to do."))
+	})
+
+	Describe("Unholding", func() {
+		BeforeEach(func() {
+			mockClient.AddResponse("repos/owner/repo/issues/3/comments", 201, map[string]interface{}{})
+			mockClient.AddResponse("repos/owner/repo/issues/3/labels/do-not-merge%2Fhold", 200, []cmd.Label{})
+		})
+
+		It("should plan an unhold only for open PRs on hold", func() {
+			actions := cmd.PlanUnholdTest(mockClient, "owner", "repo", []int{1, 2, 3}, "")
+			Expect(actions).To(Equal([]cmd.PlannedAction{
+				{Number: 1, Title: "Bump foo", Action: cmd.PlanActionSkip, Reason: "not on hold"},
+				{Number: 2, Title: "Bump bar", Action: cmd.PlanActionSkip, Reason: "PR is closed"},
+				{Number: 3, Title: "Bump baz", Action: cmd.PlanActionUnhold, Reason: "requested"},
+			}))
+		})
+
+		It("should comment /unhold with the explanation", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("y\n"), out, out), nil)
+			Expect(cmd.UnholdPRsTest(mockClient, "owner", "repo", []int{1, 3}, "release is out", false, false)).To(Equal(0))
+
+			Expect(holdComments()).To(Equal([]string{`{"body":"/unhold\n\nrelease is out"}`}))
+			Expect(mockClient.GetRequestCount("labels/do-not-merge%2Fhold")).To(Equal(0))
+			Expect(out.String()).To(ContainSubstring("Succeeded: 1, failed: 0, skipped: 1"))
+		})
+
+		It("should remove the hold label directly when asked to", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+			Expect(cmd.UnholdPRsTest(mockClient, "owner", "repo", []int{3}, "release is out", true, true)).To(Equal(0))
+
+			Expect(mockClient.Requests).To(ContainElement(And(
+				HaveField("Method", "DELETE"),
+				HaveField("URL", "repos/owner/repo/issues/3/labels/do-not-merge%2Fhold"),
+			)))
+			Expect(holdComments()).To(Equal([]string{`{"body":"release is out"}`}))
+		})
+	})
+})
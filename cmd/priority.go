@@ -0,0 +1,284 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// PriorityScore is how urgent the priority sort found a PR: the sum of the points each factor gave it
+type PriorityScore struct {
+	Score float64 `json:"score" yaml:"score"`
+	// Factors maps the name of each factor that gave points to the points it gave
+	Factors map[string]float64 `json:"factors" yaml:"factors"`
+}
+
+// priorityFacts are what the priority factors measure that a PR doesn't say itself
+type priorityFacts struct {
+	// TektonOnly is set when a Konflux PR only changes Tekton files
+	TektonOnly bool
+	// FailedChecks is how many checks of the PR failed, only fetched when the failing-checks weight isn't 0
+	FailedChecks int
+	Now          time.Time
+}
+
+// priorityFactor is one thing the priority sort weighs. Measure returns an amount the weight multiplies,
+// 1 or 0 for yes or no.
+type priorityFactor struct {
+	Name          string
+	DefaultWeight float64
+	Measure       func(pr PullRequest, facts priorityFacts) float64
+}
+
+// priorityFactors are what the priority sort weighs, highest weight first. The defaults keep security
+// updates above migration warnings above Tekton-only PRs; staleness and failing checks only count once
+// given a weight.
+var priorityFactors = []priorityFactor{
+	// A security update
+	{
+		Name:          "security",
+		DefaultWeight: 1000,
+		Measure: func(pr PullRequest, _ priorityFacts) float64 {
+			return boolWeight(hasSecurity(pr))
+		},
+	},
+	// A PR with a migration warning
+	{
+		Name:          "migration",
+		DefaultWeight: 100,
+		Measure: func(pr PullRequest, _ priorityFacts) float64 {
+			return boolWeight(hasMigrationWarning(pr))
+		},
+	},
+	// A Konflux PR that only changes Tekton files
+	{
+		Name:          "tekton-only",
+		DefaultWeight: 10,
+		Measure: func(_ PullRequest, facts priorityFacts) float64 {
+			return boolWeight(facts.TektonOnly)
+		},
+	},
+	// Per failed check
+	{
+		Name:          "failing-checks",
+		DefaultWeight: 0,
+		Measure: func(_ PullRequest, facts priorityFacts) float64 {
+			return float64(facts.FailedChecks)
+		},
+	},
+	// Per day since the PR was last updated
+	{
+		Name:          "staleness",
+		DefaultWeight: 0,
+		Measure: func(pr PullRequest, facts priorityFacts) float64 {
+			updated := pr.UpdatedAt
+			if updated == "" {
+				updated = pr.CreatedAt
+			}
+			updatedAt, err := time.Parse(time.RFC3339, updated)
+			if err != nil || facts.Now.Before(updatedAt) {
+				return 0
+			}
+			return facts.Now.Sub(updatedAt).Hours() / 24
+		},
+	},
+}
+
+var (
+	priorityWeightsMutex sync.RWMutex
+	// configuredPriorityWeights maps a factor name to its configured weight, replacing its default
+	configuredPriorityWeights = map[string]float64{}
+)
+
+func boolWeight(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// findPriorityFactor returns the priority factor called name
+func findPriorityFactor(name string) (priorityFactor, bool) {
+	for _, factor := range priorityFactors {
+		if factor.Name == name {
+			return factor, true
+		}
+	}
+	return priorityFactor{}, false
+}
+
+// priorityFactorNames lists the factors the priority sort weighs
+func priorityFactorNames() []string {
+	names := make([]string, len(priorityFactors))
+	for i, factor := range priorityFactors {
+		names[i] = factor.Name
+	}
+	return names
+}
+
+// parsePriorityWeight parses a factor=weight setting of the priority sort
+func parsePriorityWeight(setting string) (string, float64, error) {
+	name, value, ok := strings.Cut(setting, "=")
+	if !ok {
+		return "", 0, fmt.Errorf("missing '=' in %q", setting)
+	}
+	name = strings.TrimSpace(name)
+	if _, ok := findPriorityFactor(name); !ok {
+		return "", 0, fmt.Errorf("unknown factor %q (use %s)", name, strings.Join(priorityFactorNames(), ", "))
+	}
+	weight, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil {
+		return "", 0, fmt.Errorf("weight %q of %s is not a number", value, name)
+	}
+	return name, weight, nil
+}
+
+// setPriorityWeights remembers the priority weights from the config, ignoring unknown factors
+func setPriorityWeights(config *Config) {
+	priorityWeightsMutex.Lock()
+	defer priorityWeightsMutex.Unlock()
+	configuredPriorityWeights = map[string]float64{}
+	for name, weight := range config.Priority.Weights {
+		if _, ok := findPriorityFactor(name); ok {
+			configuredPriorityWeights[name] = weight
+		}
+	}
+}
+
+// priorityWeight returns the weight of a factor, its default unless the config sets one
+func priorityWeight(factor priorityFactor) float64 {
+	priorityWeightsMutex.RLock()
+	defer priorityWeightsMutex.RUnlock()
+	if weight, ok := configuredPriorityWeights[factor.Name]; ok {
+		return weight
+	}
+	return factor.DefaultWeight
+}
+
+// scorePriority adds up the weighted factors of a PR
+func scorePriority(pr PullRequest, facts priorityFacts) PriorityScore {
+	score := PriorityScore{Factors: map[string]float64{}}
+	for _, factor := range priorityFactors {
+		weight := priorityWeight(factor)
+		if weight == 0 {
+			continue
+		}
+		if points := weight * factor.Measure(pr, facts); points != 0 {
+			score.Factors[factor.Name] = points
+			score.Score += points
+		}
+	}
+	return score
+}
+
+// priorityLess orders PRs by descending score, newest first when they score the same
+func priorityLess(a, b PullRequest, aScore, bScore float64) bool {
+	if aScore != bScore {
+		return aScore > bScore
+	}
+	return a.CreatedAt > b.CreatedAt
+}
+
+// sortPullRequestsWithContext sorts PRs by priority with what their PRs don't say themselves: whether a
+// Konflux PR only changes Tekton files and, when weighed, how many checks failed. It returns the score of
+// each PR by number.
+func sortPullRequestsWithContext(ctx context.Context, opts *listOptions, prs []PullRequest, client RESTClientInterface, owner, repo string, isKonflux bool) map[int]PriorityScore {
+	tektonFactor, _ := findPriorityFactor("tekton-only")
+	checksFactor, _ := findPriorityFactor("failing-checks")
+	// Both need API calls for every PR, so they are only gathered when they count
+	checkTekton := isKonflux && !opts.Fast && priorityWeight(tektonFactor) != 0
+	checkFailures := priorityWeight(checksFactor) != 0
+
+	now := time.Now()
+	scores := make([]PriorityScore, len(prs))
+	runConcurrently(len(prs), opts.Concurrency, func(i int) {
+		facts := priorityFacts{Now: now}
+		if checkTekton {
+			if onlyTekton, _, err := checkTektonFilesDetailed(ctx, client, owner, repo, prs[i].Number); err == nil {
+				facts.TektonOnly = onlyTekton
+			}
+		}
+		if checkFailures && prs[i].Head.SHA != "" {
+			if status, err := getCheckStatus(ctx, client, owner, repo, prs[i].Number, prs[i].Head.SHA); err == nil {
+				facts.FailedChecks = status.Failed
+			}
+		}
+		scores[i] = scorePriority(prs[i], facts)
+	})
+
+	indexes := make([]int, len(prs))
+	for i := range indexes {
+		indexes[i] = i
+	}
+	sort.SliceStable(indexes, func(i, j int) bool {
+		return priorityLess(prs[indexes[i]], prs[indexes[j]], scores[indexes[i]].Score, scores[indexes[j]].Score)
+	})
+
+	sorted := make([]PullRequest, len(prs))
+	byNumber := make(map[int]PriorityScore, len(prs))
+	for i, index := range indexes {
+		sorted[i] = prs[index]
+		byNumber[prs[index].Number] = scores[index]
+	}
+	copy(prs, sorted)
+	return byNumber
+}
+
+// withPriorityScores fills in the priority score of each row that has one
+func withPriorityScores(rows []PRRow, scores map[int]PriorityScore) {
+	for i := range rows {
+		if score, ok := scores[rows[i].Number]; ok {
+			rows[i].Priority = &score
+		}
+	}
+}
+
+// explainPriority shows the points each factor gave the rows, in the order they were sorted
+func explainPriority(rows []PRRow, owner, repo string) {
+	if len(rows) == 0 {
+		return
+	}
+	streams.Println("\n🔢 Priority scores:")
+	for _, row := range rows {
+		var score PriorityScore
+		if row.Priority != nil {
+			score = *row.Priority
+		}
+		var parts []string
+		for _, factor := range priorityFactors {
+			if points, ok := score.Factors[factor.Name]; ok {
+				parts = append(parts, fmt.Sprintf("%s %s", factor.Name, formatPoints(points)))
+			}
+		}
+		breakdown := "no factor applies"
+		if len(parts) > 0 {
+			breakdown = strings.Join(parts, ", ")
+		}
+		streams.Printf("   %s %s: %s\n", rowPRLink(row, owner, repo), formatPoints(score.Score), breakdown)
+	}
+	weights := make([]string, len(priorityFactors))
+	for i, factor := range priorityFactors {
+		weights[i] = factor.Name + "=" + formatPoints(priorityWeight(factor))
+	}
+	streams.Printf("   Weights: %s (set with 'ghprs config set priority-weight factor=weight')\n", strings.Join(weights, ", "))
+}
+
+// explainPrioritySort shows how the priority sort scored the PRs of a repository
+func explainPrioritySort(prs []PullRequest, scores map[int]PriorityScore, owner, repo string) {
+	rows := make([]PRRow, len(prs))
+	for i, pr := range prs {
+		rows[i] = PRRow{Number: pr.Number}
+	}
+	withPriorityScores(rows, scores)
+	explainPriority(rows, owner, repo)
+}
+
+// formatPoints shows points or a weight with at most one decimal, e.g. 1000 or 12.5
+func formatPoints(points float64) string {
+	return strconv.FormatFloat(math.Round(points*10)/10, 'f', -1, 64)
+}
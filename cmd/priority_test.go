@@ -0,0 +1,122 @@
+package cmd_test
+
+import (
+	"bytes"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Priority sort", func() {
+	var mockClient *cmd.MockRESTClient
+
+	daysAgo := func(days int) string {
+		return time.Now().Add(-time.Duration(days) * 24 * time.Hour).UTC().Format(time.RFC3339)
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		cmd.SetPriorityWeightsTest(cmd.DefaultConfig())
+	})
+
+	AfterEach(func() {
+		cmd.SetPriorityWeightsTest(cmd.DefaultConfig())
+	})
+
+	It("should order security updates, migration warnings and Tekton-only PRs by default, newest first otherwise", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/3/files", 200, cmd.CreateMockPRFiles(true))
+		mockClient.AddResponse("repos/owner/repo/pulls/4/files", 200, cmd.CreateMockPRFiles(false))
+		prs := []cmd.PullRequest{
+			{Number: 1, Title: "Update deps", CreatedAt: "2025-01-01T00:00:00Z"},
+			{Number: 2, Title: "Update deps", CreatedAt: "2025-01-02T00:00:00Z"},
+			{Number: 3, Title: "Update pipelines", CreatedAt: "2025-01-03T00:00:00Z"},
+			{Number: 4, Title: "Update image", Body: "⚠️[migration]", CreatedAt: "2025-01-04T00:00:00Z"},
+			{Number: 5, Title: "[SECURITY] Update x", CreatedAt: "2025-01-05T00:00:00Z"},
+		}
+
+		scores := cmd.SortByPriorityTest(mockClient, "owner", "repo", prs, true)
+
+		Expect(prNumbers(prs)).To(Equal([]int{5, 4, 3, 2, 1}))
+		Expect(scores[5].Score).To(Equal(1000.0))
+		Expect(scores[4].Factors).To(Equal(map[string]float64{"migration": 100}))
+		Expect(scores[3].Factors).To(Equal(map[string]float64{"tekton-only": 10}))
+		Expect(scores[1].Score).To(BeZero())
+	})
+
+	It("should weigh staleness and failing checks once configured", func() {
+		mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+		mockClient.AddResponse("repos/owner/repo/commits/sha2/check-runs", 200, cmd.CreateMockCheckRuns(0, 2, 0))
+		mockClient.AddResponse("repos/owner/repo/commits/sha3/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+		config := cmd.DefaultConfig()
+		config.Priority.Weights = map[string]float64{"staleness": 1, "failing-checks": -50}
+		cmd.SetPriorityWeightsTest(config)
+
+		prs := []cmd.PullRequest{
+			{Number: 1, UpdatedAt: daysAgo(1), CreatedAt: daysAgo(1)},
+			{Number: 2, UpdatedAt: daysAgo(30), CreatedAt: daysAgo(30)},
+			{Number: 3, UpdatedAt: daysAgo(10), CreatedAt: daysAgo(10)},
+		}
+		prs[0].Head.SHA, prs[1].Head.SHA, prs[2].Head.SHA = "sha1", "sha2", "sha3"
+
+		scores := cmd.SortByPriorityTest(mockClient, "owner", "repo", prs, false)
+
+		Expect(prNumbers(prs)).To(Equal([]int{3, 1, 2}))
+		Expect(scores[2].Factors["failing-checks"]).To(Equal(-100.0))
+		Expect(scores[2].Factors["staleness"]).To(BeNumerically("~", 30, 0.1))
+	})
+
+	It("should not fetch checks or files for factors that don't count", func() {
+		config := cmd.DefaultConfig()
+		config.Priority.Weights = map[string]float64{"tekton-only": 0}
+		cmd.SetPriorityWeightsTest(config)
+
+		prs := []cmd.PullRequest{{Number: 1}, {Number: 2}}
+		prs[0].Head.SHA = "sha1"
+		cmd.SortByPriorityTest(mockClient, "owner", "repo", prs, true)
+
+		Expect(mockClient.Requests).To(BeEmpty())
+	})
+
+	It("should parse factor=weight settings", func() {
+		factor, weight, err := cmd.ParsePriorityWeightTest("failing-checks=-12.5")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(factor).To(Equal("failing-checks"))
+		Expect(weight).To(Equal(-12.5))
+
+		_, _, err = cmd.ParsePriorityWeightTest("reviews=3")
+		Expect(err).To(MatchError(ContainSubstring("unknown factor")))
+		_, _, err = cmd.ParsePriorityWeightTest("staleness=high")
+		Expect(err).To(HaveOccurred())
+		_, _, err = cmd.ParsePriorityWeightTest("staleness")
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should explain the score of each PR and the weights", func() {
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+		defer cmd.ResetIOStreams()
+
+		prs := []cmd.PullRequest{
+			{Number: 7, Title: "[SECURITY] CVE-2025-1 fix", Body: "⚠️[migration]"},
+			{Number: 8, Title: "Update deps"},
+		}
+		scores := cmd.SortByPriorityTest(mockClient, "owner", "repo", prs, false)
+		cmd.ExplainPrioritySortTest(prs, scores, "owner", "repo")
+
+		Expect(out.String()).To(ContainSubstring("1100: security 1000, migration 100"))
+		Expect(out.String()).To(ContainSubstring("0: no factor applies"))
+		Expect(out.String()).To(ContainSubstring("Weights: security=1000, migration=100, tekton-only=10, failing-checks=0, staleness=0"))
+	})
+
+	It("should include the score in JSON rows", func() {
+		row := cmd.PRRow{Number: 1, Priority: &cmd.PriorityScore{Score: 100, Factors: map[string]float64{"migration": 100}}}
+		output := cmd.PRListOutput{Repositories: []cmd.RepositoryPRs{{Repository: "owner/repo", PullRequests: []cmd.PRRow{row}}}}
+		out := &bytes.Buffer{}
+		Expect(cmd.WriteStructuredOutputTest(out, output, cmd.OutputJSON)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring(`"priority": {`))
+		Expect(out.String()).To(ContainSubstring(`"migration": 100`))
+	})
+})
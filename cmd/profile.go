@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// profileEnvVar selects the config profile when --profile isn't given
+const profileEnvVar = "GHPRS_PROFILE"
+
+// profilesDir is the directory next to the config file that holds a config file per profile
+const profilesDir = "profiles"
+
+// profileFlag is the config profile of --profile
+var profileFlag string
+
+// profileNameRE matches the names of profiles, which become file names
+var profileNameRE = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]*$`)
+
+// activeProfile returns the config profile of --profile or else $GHPRS_PROFILE, "" for the default config
+func activeProfile() string {
+	if profileFlag != "" {
+		return profileFlag
+	}
+	return os.Getenv(profileEnvVar)
+}
+
+// validateProfileName checks that a profile name can be used as a file name, "" being the default config
+func validateProfileName(name string) error {
+	if name != "" && !profileNameRE.MatchString(name) {
+		return fmt.Errorf("invalid profile %q (use letters, digits, '.', '_' and '-')", name)
+	}
+	return nil
+}
+
+// listProfiles returns the names of the profiles that have a config file, sorted
+func listProfiles() ([]string, error) {
+	dir, ok := configDir()
+	if !ok {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(filepath.Join(dir, profilesDir))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var profiles []string
+	for _, entry := range entries {
+		if name, ok := strings.CutSuffix(entry.Name(), ".yaml"); ok && !entry.IsDir() && validateProfileName(name) == nil {
+			profiles = append(profiles, name)
+		}
+	}
+	sort.Strings(profiles)
+	return profiles, nil
+}
+
+// configProfilesCmd lists the config profiles
+var configProfilesCmd = &cobra.Command{
+	Use:   "profiles",
+	Short: "List the config profiles",
+	Long: `List the config profiles, marking the one in use. A profile is a config file of its own, with its
+own repositories, defaults, hosts, approval settings and rules, kept in ~/.config/ghprs/profiles/<name>.yaml.
+Select one with --profile or $GHPRS_PROFILE; without either the default config is used.
+
+Examples:
+  ghprs --profile work config init
+  ghprs --profile work config add-repo my-org/service
+  ghprs --profile work list
+  GHPRS_PROFILE=work ghprs konflux`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		profiles, err := listProfiles()
+		if err != nil {
+			fmt.Printf("Error listing profiles: %v\n", err)
+			os.Exit(1)
+		}
+		active := activeProfile()
+		marker := func(name string) string {
+			if name == active {
+				return "* "
+			}
+			return "  "
+		}
+		fmt.Printf("%s(default)\n", marker(""))
+		for _, name := range profiles {
+			fmt.Printf("%s%s\n", marker(name), name)
+		}
+		if len(profiles) == 0 {
+			fmt.Println("\nNo profiles yet, create one with 'ghprs --profile <name> config init'.")
+		}
+	},
+}
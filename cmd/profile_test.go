@@ -0,0 +1,83 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Config profiles", func() {
+	var tempDir string
+
+	BeforeEach(func() {
+		tempDir = GinkgoT().TempDir()
+		GinkgoT().Setenv("HOME", tempDir)
+		GinkgoT().Setenv("GHPRS_PROFILE", "")
+		cmd.ResetConfigPath()
+	})
+
+	AfterEach(func() {
+		cmd.SetProfileTest("")
+	})
+
+	It("should keep each profile in a config file of its own", func() {
+		defaultConfig := cmd.DefaultConfig()
+		defaultConfig.Repositories = []cmd.RepositoryConfig{{Name: "me/personal"}}
+		Expect(cmd.SaveConfig(defaultConfig)).To(Succeed())
+
+		cmd.SetProfileTest("work")
+		Expect(cmd.GetConfigPath()).To(Equal(filepath.Join(tempDir, ".config", "ghprs", "profiles", "work.yaml")))
+		workConfig := cmd.DefaultConfig()
+		workConfig.Repositories = []cmd.RepositoryConfig{{Name: "my-org/service", Konflux: true}}
+		workConfig.Host = "github.example.com"
+		Expect(cmd.SaveConfig(workConfig)).To(Succeed())
+
+		loaded, err := cmd.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Repositories).To(Equal(workConfig.Repositories))
+		Expect(loaded.Host).To(Equal("github.example.com"))
+
+		cmd.SetProfileTest("")
+		loaded, err = cmd.LoadConfig()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(loaded.Repositories).To(Equal(defaultConfig.Repositories))
+	})
+
+	It("should select the profile of $GHPRS_PROFILE unless --profile is given", func() {
+		GinkgoT().Setenv("GHPRS_PROFILE", "team")
+		Expect(cmd.GetConfigPath()).To(HaveSuffix(filepath.Join("profiles", "team.yaml")))
+
+		cmd.SetProfileTest("work")
+		Expect(cmd.GetConfigPath()).To(HaveSuffix(filepath.Join("profiles", "work.yaml")))
+	})
+
+	It("should refuse a profile that doesn't exist instead of using the defaults", func() {
+		cmd.SetProfileTest("wrok")
+		_, err := cmd.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring(`profile "wrok" doesn't exist`)))
+	})
+
+	It("should refuse profile names that aren't file names", func() {
+		cmd.SetProfileTest("../config")
+		_, err := cmd.LoadConfig()
+		Expect(err).To(MatchError(ContainSubstring("invalid profile")))
+		Expect(cmd.SaveConfig(cmd.DefaultConfig())).NotTo(Succeed())
+	})
+
+	It("should list the profiles and keep the local state next to the default config", func() {
+		for _, name := range []string{"work", "oss"} {
+			cmd.SetProfileTest(name)
+			Expect(cmd.SaveConfig(cmd.DefaultConfig())).To(Succeed())
+			Expect(cmd.StateDirTest()).To(Equal(filepath.Join(tempDir, ".config", "ghprs")))
+		}
+		Expect(os.WriteFile(filepath.Join(tempDir, ".config", "ghprs", "profiles", "notes.txt"), nil, 0644)).To(Succeed())
+
+		profiles, err := cmd.ListProfilesTest()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(profiles).To(Equal([]string{"oss", "work"}))
+	})
+})
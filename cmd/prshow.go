@@ -0,0 +1,386 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"slices"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
+// PRDetail is everything the approval prompt knows about one PR: its row as the list shows it and the labels,
+// checks, files, Tekton and migration analysis and reviews behind it
+type PRDetail struct {
+	PRRow  `yaml:",inline"`
+	Labels []string `json:"labels" yaml:"labels"`
+	// BlockedReason says what a blocked PR waits on
+	BlockedReason string `json:"blockedReason,omitempty" yaml:"blockedReason,omitempty"`
+	// CheckResults are the check runs and status checks of the head commit, Checks their summary
+	CheckResults []CheckResult `json:"checkResults" yaml:"checkResults"`
+	// Files are the files the PR changes, without their patches
+	Files []PRFile `json:"files" yaml:"files"`
+	// SensitiveFiles are the CI and ownership files changed by an author that isn't trusted
+	SensitiveFiles []string `json:"sensitiveFiles,omitempty" yaml:"sensitiveFiles,omitempty"`
+	// TektonFiles are the Tekton pipelines Konflux updates that the PR changes, TektonOnly whether it changes
+	// nothing else
+	TektonFiles []string `json:"tektonFiles,omitempty" yaml:"tektonFiles,omitempty"`
+	// TektonChanges are the .tekton pipelines the PR adds, removes or renames, or that are missing from the
+	// configured baseline
+	TektonChanges  []TektonChange        `json:"tektonChanges,omitempty" yaml:"tektonChanges,omitempty"`
+	MigrationNotes *ghprs.MigrationNotes `json:"migrationNotes,omitempty" yaml:"migrationNotes,omitempty"`
+	Reviews        []Review              `json:"reviews" yaml:"reviews"`
+}
+
+// CheckResult is one check run or status check of a PR
+type CheckResult struct {
+	Name string `json:"name" yaml:"name"`
+	// State is passed, failed, pending, cancelled or skipped
+	State string `json:"state" yaml:"state"`
+	// Detail is the conclusion of a check run or the description of a status check
+	Detail string `json:"detail,omitempty" yaml:"detail,omitempty"`
+	URL    string `json:"url,omitempty" yaml:"url,omitempty"`
+}
+
+// TektonChange is a structural change of the .tekton pipelines of a repository
+type TektonChange struct {
+	File   string `json:"file" yaml:"file"`
+	Change string `json:"change" yaml:"change"`
+}
+
+// The states of a CheckResult
+const (
+	checkPassed    = "passed"
+	checkFailed    = "failed"
+	checkPending   = "pending"
+	checkCancelled = "cancelled"
+	checkSkipped   = "skipped"
+)
+
+// checkRunResult reduces a check run to its result, counted the same way as in the check summary of the list
+func checkRunResult(checkRun CheckRun) CheckResult {
+	result := CheckResult{Name: checkRun.Name, Detail: checkRun.Conclusion, URL: checkRun.HTMLURL}
+	switch {
+	case checkRun.Status != "completed":
+		result.State, result.Detail = checkPending, checkRun.Status
+	case checkRunFailed(checkRun):
+		result.State = checkFailed
+	case checkRun.Conclusion == "success":
+		result.State = checkPassed
+	case checkRun.Conclusion == "cancelled":
+		result.State = checkCancelled
+	default:
+		result.State = checkSkipped
+	}
+	return result
+}
+
+// statusCheckResult reduces a status check to its result
+func statusCheckResult(statusCheck StatusCheck) CheckResult {
+	result := CheckResult{Name: statusCheck.Context, Detail: statusCheck.Description, URL: statusCheck.TargetURL}
+	switch {
+	case statusCheckFailed(statusCheck):
+		result.State = checkFailed
+	case statusCheck.State == "success":
+		result.State = checkPassed
+	default:
+		result.State = checkPending
+	}
+	return result
+}
+
+// checkResultIcon returns the emoji shown next to a check result
+func checkResultIcon(state string) string {
+	switch state {
+	case checkPassed:
+		return "✅"
+	case checkFailed:
+		return "❌"
+	case checkPending:
+		return "🟡"
+	case checkCancelled:
+		return "⚫"
+	default:
+		return "⚪"
+	}
+}
+
+// summarizeCheckResults reduces check results to the summary the list shows in its CHECKS column
+func summarizeCheckResults(results []CheckResult) string {
+	status := &CheckStatus{Total: len(results)}
+	for _, result := range results {
+		switch result.State {
+		case checkFailed:
+			status.Failed++
+		case checkPending:
+			status.Pending++
+		}
+	}
+	return checksSummary(status)
+}
+
+// collectPRDetail looks up everything the approval prompt shows about a PR. Lookups that fail are recorded in
+// the errors of its row, like in the list, and leave what they fill in unknown.
+func collectPRDetail(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest, config *Config) PRDetail {
+	repoSpec := owner + "/" + repo
+	// The Tekton analysis of Konflux rows is done below from the files fetched for the file list
+	detail := PRDetail{
+		PRRow:        buildPRRow(ctx, newListOptions(), pr, owner, repo, client, false, NewPRDetailsCache(), false),
+		Labels:       []string{},
+		CheckResults: []CheckResult{},
+		Files:        []PRFile{},
+		Reviews:      []Review{},
+	}
+	if slices.Contains(config.GetRepositories(true), repoSpec) {
+		if mapping, ok := konfluxComponentFor(owner, repo, pr.Base.Ref); ok {
+			detail.Application, detail.Component = mapping.Application, mapping.Component
+		}
+	}
+	for _, label := range pr.Labels {
+		detail.Labels = append(detail.Labels, label.Name)
+	}
+	if detail.Blocked != nil && *detail.Blocked {
+		detail.BlockedReason = explainBlocked(client, owner, repo, pr)
+	}
+
+	// The files are fetched once for the file list, the Tekton analysis and the sensitive files
+	files, err := ghprs.FetchFiles(ctx, client, owner, repo, pr.Number)
+	if err != nil {
+		recordLookupError(&detail.PRRow, lookupFiles, err)
+	} else {
+		var otherFiles bool
+		patterns := config.TektonPatternsFor(repoSpec)
+		for _, file := range files {
+			file.Patch = ""
+			detail.Files = append(detail.Files, file)
+			if isTektonFile(patterns, file.Filename) {
+				detail.TektonFiles = append(detail.TektonFiles, file.Filename)
+			} else {
+				otherFiles = true
+			}
+		}
+		detail.TektonOnly = boolPtr(len(detail.TektonFiles) > 0 && !otherFiles)
+		detail.SensitiveFiles = untrustedSensitiveFiles(pr, files, config.TrustedAuthors())
+		for _, change := range tektonStructuralChanges(files, config.TektonBaselines()[repoSpec]) {
+			detail.TektonChanges = append(detail.TektonChanges, TektonChange(change))
+		}
+	}
+
+	if pr.Head.SHA != "" {
+		checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+		if err != nil {
+			recordLookupError(&detail.PRRow, lookupChecks, err)
+		} else {
+			for _, checkRun := range checkRuns {
+				detail.CheckResults = append(detail.CheckResults, checkRunResult(checkRun))
+			}
+			for _, statusCheck := range statusChecks {
+				detail.CheckResults = append(detail.CheckResults, statusCheckResult(statusCheck))
+			}
+			detail.Checks = summarizeCheckResults(detail.CheckResults)
+		}
+	}
+
+	// The row only fetched the reviews when no label approves the PR, and stopped at the first approval
+	var reviews []Review
+	if err := client.Get(fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, pr.Number), &reviews); err != nil {
+		recordLookupError(&detail.PRRow, lookupReviews, err)
+	} else {
+		detail.Reviews = append(detail.Reviews, reviews...)
+		approved := slices.ContainsFunc(reviews, func(review Review) bool { return review.State == "APPROVED" })
+		detail.Reviewed = boolPtr(approved || hasApprovedLabel(pr.Labels))
+		delete(detail.Errors, lookupReviews)
+	}
+	detail.Readiness = prReadiness(detail.PRRow, pr)
+
+	if hasMigrationWarning(pr) {
+		notes := ghprs.ExtractMigrationNotes(pr.Body)
+		detail.MigrationNotes = &notes
+	}
+
+	return detail
+}
+
+// describeTriState describes a tri-state field of a PR, or the lookup that failed to fill it in
+func describeTriState(row PRRow, lookup string, value *bool) string {
+	switch {
+	case row.Errors[lookup] != "":
+		return fmt.Sprintf("%s (%s)", errorCell, firstLine(row.Errors[lookup]))
+	case value == nil:
+		return "unknown"
+	case *value:
+		return "yes"
+	default:
+		return "no"
+	}
+}
+
+// displayPRDetail shows what the approval prompt shows about a PR, in full and without prompting
+func displayPRDetail(detail PRDetail, owner, repo string) {
+	streams.Printf("🔍 PR %s: %s\n", formatPRLink(owner, repo, detail.Number), detail.Title)
+	streams.Printf("   URL: %s\n", detail.URL)
+	streams.Printf("   Author: @%s\n", detail.Author)
+	streams.Printf("   Branch: %s → %s\n", detail.Branch, detail.Target)
+	state := detail.State
+	switch {
+	case detail.Merged:
+		state = "merged"
+	case detail.Draft:
+		state += " (draft)"
+	}
+	streams.Printf("   State: %s, readiness %s %s\n", state, readinessIcon(detail.Readiness), detail.Readiness)
+	if detail.CreatedAt != "" {
+		streams.Printf("   Created: %s, updated: %s\n", detail.CreatedAt, detail.UpdatedAt)
+	}
+	if len(detail.Labels) > 0 {
+		streams.Printf("   Labels: %s\n", strings.Join(detail.Labels, ", "))
+	}
+	if detail.OnHold {
+		streams.Printf("   ⚠️  Status: ON HOLD (has 'do-not-merge/hold' label)\n")
+	}
+	if detail.Size != "" {
+		streams.Printf("   Size: %s (+%d -%d in %d file(s))\n", detail.Size, detail.Additions, detail.Deletions, detail.ChangedFiles)
+	}
+	if len(detail.Owners) > 0 {
+		streams.Printf("   👥 Code owners: %s\n", strings.Join(detail.Owners, " "))
+	}
+	for _, update := range detail.Dependencies {
+		line := fmt.Sprintf("   📦 %s → %s", update.Package, update.To)
+		if update.From != "" {
+			line = fmt.Sprintf("   📦 %s %s → %s", update.Package, update.From, update.To)
+		}
+		if update.Type != "" {
+			line += fmt.Sprintf(" (%s)", update.Type)
+		}
+		streams.Printf("%s\n", line)
+	}
+	if detail.Security {
+		streams.Printf("   🔒 Security update\n")
+	}
+
+	streams.Printf("   🔄 Needs rebase: %s\n", describeTriState(detail.PRRow, lookupDetails, detail.NeedsRebase))
+	blocked := describeTriState(detail.PRRow, lookupDetails, detail.Blocked)
+	if detail.BlockedReason != "" {
+		blocked += ": " + detail.BlockedReason
+	}
+	streams.Printf("   🚫 Blocked: %s\n", blocked)
+
+	if err := detail.Errors[lookupChecks]; err != "" {
+		streams.Printf("\n📋 Checks: %s (%s)\n", errorCell, firstLine(err))
+	} else {
+		streams.Printf("\n📋 Checks: %s (%d)\n", detail.Checks, len(detail.CheckResults))
+		for _, result := range detail.CheckResults {
+			line := fmt.Sprintf("   %s %s: %s", checkResultIcon(result.State), result.Name, result.State)
+			if result.Detail != "" && result.Detail != result.State {
+				line += fmt.Sprintf(" (%s)", result.Detail)
+			}
+			streams.Printf("%s\n", line)
+		}
+	}
+
+	if err := detail.Errors[lookupFiles]; err != "" {
+		streams.Printf("\n📁 Files changed: %s (%s)\n", errorCell, firstLine(err))
+	} else {
+		streams.Printf("\n📁 Files changed (%d):\n", len(detail.Files))
+		displayFileList(detail.Files)
+		if len(detail.SensitiveFiles) > 0 {
+			displaySensitiveFiles(PullRequest{User: User{Login: detail.Author}}, detail.SensitiveFiles)
+		}
+		if *detail.TektonOnly {
+			streams.Printf("   ✅ ONLY modifies Tekton files: %s\n", strings.Join(detail.TektonFiles, ", "))
+		} else {
+			streams.Printf("   ❌ Does NOT exclusively modify target Tekton files\n")
+		}
+		changes := make([]tektonChange, len(detail.TektonChanges))
+		for i, change := range detail.TektonChanges {
+			changes[i] = tektonChange(change)
+		}
+		displayTektonStructuralChanges(changes)
+	}
+
+	if detail.MigrationNotes != nil {
+		streams.Printf("\n   🚨 MIGRATION WARNING: This PR contains migration notes - review carefully!\n")
+		render.WriteBox(streams.Out, "🚨 Migration notes", migrationNoteLines(*detail.MigrationNotes, 0), streams.Width(), shouldUseColors())
+	}
+
+	if err := detail.Errors[lookupReviews]; err != "" {
+		streams.Printf("\n👀 Reviews: %s (%s)\n", errorCell, firstLine(err))
+	} else if len(detail.Reviews) == 0 {
+		streams.Printf("\n👀 Reviews: none\n")
+	} else {
+		streams.Printf("\n👀 Reviews (%d):\n", len(detail.Reviews))
+		for _, review := range detail.Reviews {
+			streams.Printf("   @%s: %s %s\n", review.User.Login, review.State, review.SubmittedAt)
+		}
+	}
+
+	if len(detail.Errors) > 0 {
+		streams.Printf("\n")
+		reportLookupErrors([]PRRow{detail.PRRow}, owner, repo)
+	}
+}
+
+// prShowCmd prints everything the approval prompt knows about a PR without prompting
+var prShowCmd = &cobra.Command{
+	Use:   "show [owner/repo] <number>",
+	Short: "Show everything known about a pull request",
+	Long: `Show everything the approval prompt knows about a pull request without prompting: its metadata,
+labels, size, code owners and dependency updates, whether it needs a rebase or is blocked and why,
+every check with its result, the files it changes with the Tekton analysis, its migration notes
+and its reviews.
+
+--output json or yaml prints the same as one document for scripts and CI gates, e.g. to wait
+for a PR to be ready. Lookups that failed are listed under errors and leave what they fill in
+unknown, so an unknown value is never mistaken for a real answer.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs pr show 123
+  ghprs pr show owner/repo 123
+  ghprs pr show owner/repo 123 --output json | jq -r .readiness`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		if isRowOutput(outputFormat) {
+			log.Fatalf("--output %s is only supported by list and konflux", outputFormat)
+		}
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+
+		config, err := LoadConfig()
+		if err != nil {
+			config = DefaultConfig()
+		}
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+
+		detail := collectPRDetail(ctx, client, owner, repo, *pr, config)
+		if isStructuredOutput(outputFormat) {
+			if err := writeStructuredOutput(streams.Out, detail, outputFormat); err != nil {
+				log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+			}
+			return
+		}
+		paged(func() { displayPRDetail(detail, owner, repo) })
+	},
+}
+
+func init() {
+	prCmd := &cobra.Command{
+		Use:   "pr",
+		Short: "Work with a single pull request",
+	}
+	prCmd.AddCommand(prShowCmd)
+	RootCmd.AddCommand(prCmd)
+}
@@ -0,0 +1,120 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("pr show", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		pr         cmd.PullRequest
+	)
+
+	BeforeEach(func() {
+		pr = cmd.PullRequest{
+			Number: 7, Title: "Update Konflux references", State: "open", MergeableState: "behind",
+			User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{Ref: "konflux/references", SHA: "abc"}, Base: cmd.Branch{Ref: "main"},
+			Labels: []cmd.Label{{Name: "ok-to-test"}}, Additions: 4, Deletions: 2, ChangedFiles: 2,
+		}
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/7/files", 200, cmd.CreateMockPRFiles(true))
+		mockClient.AddResponse("repos/owner/repo/pulls/7/reviews", 200, cmd.CreateMockReviews(true))
+		mockClient.AddResponse("repos/owner/repo/commits/abc/check-runs", 200, cmd.CreateMockCheckRuns(1, 1, 0))
+		mockClient.AddResponse("repos/owner/repo/commits/abc/status", 200, map[string]interface{}{
+			"statuses": []cmd.StatusCheck{{Context: "ci/prow/unit", State: "pending", Description: "Job triggered."}},
+		})
+		mockClient.AddErrorResponse("repos/owner/repo/contents/", &api.HTTPError{StatusCode: http.StatusNotFound})
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should collect the state, checks, files and reviews of a PR", func() {
+		detail := cmd.CollectPRDetailTest(mockClient, "owner", "repo", pr, cmd.DefaultConfig())
+
+		Expect(detail.Errors).To(BeEmpty())
+		Expect(detail.Labels).To(Equal([]string{"ok-to-test"}))
+		Expect(detail.NeedsRebase).To(HaveValue(BeTrue()))
+		Expect(detail.Reviewed).To(HaveValue(BeTrue()))
+		Expect(detail.Reviews).To(HaveLen(1))
+		Expect(detail.Size).To(Equal("XS"))
+		Expect(detail.Checks).To(Equal("failing"))
+		Expect(detail.CheckResults).To(Equal([]cmd.CheckResult{
+			{Name: "test-passed-1", State: "passed", Detail: "success", URL: "https://github.com/owner/repo/runs/1"},
+			{Name: "test-failed-1", State: "failed", Detail: "failure", URL: "https://github.com/owner/repo/runs/2"},
+			{Name: "ci/prow/unit", State: "pending", Detail: "Job triggered."},
+		}))
+		Expect(detail.Files).To(HaveLen(2))
+		Expect(detail.TektonOnly).To(HaveValue(BeTrue()))
+		Expect(detail.TektonFiles).To(ConsistOf(".tekton/pipeline-pull-request.yaml", ".tekton/build-push.yaml"))
+		Expect(detail.TektonChanges).To(Equal([]cmd.TektonChange{{File: ".tekton/build-push.yaml", Change: "added"}}))
+		Expect(detail.Readiness).To(Equal(cmd.ReadinessNeedsRebase))
+	})
+
+	It("should record the lookups that failed instead of reporting them as answers", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/7/reviews", fmt.Errorf("HTTP 502"))
+		mockClient.AddErrorResponse("repos/owner/repo/commits/abc/check-runs", fmt.Errorf("HTTP 500"))
+
+		detail := cmd.CollectPRDetailTest(mockClient, "owner", "repo", pr, cmd.DefaultConfig())
+		Expect(detail.Reviewed).To(BeNil())
+		Expect(detail.Checks).To(BeEmpty())
+		Expect(detail.Errors).To(HaveKeyWithValue("reviews", "HTTP 502"))
+		Expect(detail.Errors).To(HaveKey("checks"))
+
+		cmd.DisplayPRDetailTest(detail, "owner", "repo")
+		Expect(out.String()).To(ContainSubstring("📋 Checks: err (failed to fetch check runs: HTTP 500)"))
+		Expect(out.String()).To(ContainSubstring("👀 Reviews: err (HTTP 502)"))
+		Expect(out.String()).To(ContainSubstring("Some values could not be determined"))
+	})
+
+	It("should show the PR with its checks, files and reviews", func() {
+		pr.Body = "⚠️[migration] Run the migration script before merging"
+		cmd.DisplayPRDetailTest(cmd.CollectPRDetailTest(mockClient, "owner", "repo", pr, cmd.DefaultConfig()), "owner", "repo")
+
+		output := out.String()
+		Expect(output).To(ContainSubstring("Branch: konflux/references → main"))
+		Expect(output).To(ContainSubstring("Labels: ok-to-test"))
+		Expect(output).To(ContainSubstring("🔄 Needs rebase: yes"))
+		Expect(output).To(ContainSubstring("📋 Checks: failing (3)"))
+		Expect(output).To(ContainSubstring("❌ test-failed-1: failed (failure)"))
+		Expect(output).To(ContainSubstring("🟡 ci/prow/unit: pending (Job triggered.)"))
+		Expect(output).To(ContainSubstring("📁 Files changed (2):"))
+		Expect(output).To(ContainSubstring("✅ ONLY modifies Tekton files"))
+		Expect(output).To(ContainSubstring("MIGRATION WARNING"))
+		Expect(output).To(ContainSubstring("@reviewer1: APPROVED"))
+		Expect(output).NotTo(ContainSubstring("could not be determined"))
+	})
+
+	It("should export the PR as one JSON document without the patches", func() {
+		files := cmd.CreateMockPRFiles(false)
+		files[0].Patch = "@@ -1 +1 @@"
+		mockClient.AddResponse("repos/owner/repo/pulls/7/files", 200, files)
+
+		detail := cmd.CollectPRDetailTest(mockClient, "owner", "repo", pr, cmd.DefaultConfig())
+		data, err := json.Marshal(detail)
+		Expect(err).NotTo(HaveOccurred())
+
+		var doc map[string]interface{}
+		Expect(json.Unmarshal(data, &doc)).To(Succeed())
+		Expect(doc).To(HaveKeyWithValue("number", BeNumerically("==", 7)))
+		Expect(doc).To(HaveKeyWithValue("readiness", cmd.ReadinessNeedsRebase))
+		Expect(doc).To(HaveKeyWithValue("tektonOnly", false))
+		Expect(doc).To(HaveKey("checkResults"))
+		Expect(doc).NotTo(HaveKey("errors"))
+		Expect(string(data)).NotTo(ContainSubstring("@@"))
+	})
+})
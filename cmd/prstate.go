@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/spf13/cobra"
+)
+
+// Plan actions of the close and reopen commands
+const (
+	PlanActionClose  = "close"
+	PlanActionReopen = "reopen"
+)
+
+// stateComment is the comment posted by the close and reopen commands before changing the state
+var stateComment string
+
+// PullRequestUpdate represents a request to update a pull request
+type PullRequestUpdate struct {
+	State string `json:"state,omitempty"`
+}
+
+// closeCmd closes several PRs after showing what will be done
+var closeCmd = &cobra.Command{
+	Use:   "close [owner/repo] <number>...",
+	Short: "Close pull requests",
+	Long: `Close pull requests without merging them, optionally explaining why in a comment.
+
+A plan of what will happen to each PR is shown first and nothing is changed until it is
+confirmed. PRs that are already closed are skipped.
+
+Examples:
+  ghprs close owner/repo 12 15 20
+  ghprs close owner/repo 12 --comment "Superseded by #30"
+  ghprs close owner/repo 12 15 --yes         # Don't ask for confirmation`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePRArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, numbers := parseBatchArgs(args)
+		client := newCommandClient(ctx, owner, repo)
+		if failed := changePRStates(ctx, client, owner, repo, numbers, "closed", stateComment, assumeYes); failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// reopenCmd reopens several closed PRs after showing what will be done
+var reopenCmd = &cobra.Command{
+	Use:   "reopen [owner/repo] <number>...",
+	Short: "Reopen closed pull requests",
+	Long: `Reopen closed pull requests, optionally explaining why in a comment.
+
+A plan of what will happen to each PR is shown first and nothing is changed until it is
+confirmed. PRs that are open or merged are skipped.
+
+Examples:
+  ghprs reopen owner/repo 12
+  ghprs reopen owner/repo 12 15 --comment "Still needed for the release"`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePRArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, numbers := parseBatchArgs(args)
+		client := newCommandClient(ctx, owner, repo)
+		if failed := changePRStates(ctx, client, owner, repo, numbers, "open", stateComment, assumeYes); failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// planStateChange decides which of the given PRs will be closed or reopened
+func planStateChange(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, state, comment string) *batchPlan {
+	action := PlanActionClose
+	if state == "open" {
+		action = PlanActionReopen
+	}
+
+	reason := comment
+	if reason == "" {
+		reason = "requested"
+	}
+
+	plan := &batchPlan{owner: owner, repo: repo}
+	for _, number := range numbers {
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		switch {
+		case err != nil:
+			plan.add(PullRequest{Number: number}, PlanActionSkip, fmt.Sprintf("could not fetch PR: %v", err))
+		case pr.Merged:
+			plan.add(*pr, PlanActionSkip, "PR is merged")
+		case pr.State == state:
+			plan.add(*pr, PlanActionSkip, fmt.Sprintf("PR is already %s", state))
+		default:
+			plan.add(*pr, action, reason)
+		}
+	}
+	return plan
+}
+
+// changePRStates shows the plan, asks for one confirmation and closes or reopens the PRs, returning the number of failures
+func changePRStates(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, state, comment string, assumeYes bool) int {
+	plan := planStateChange(ctx, client, owner, repo, numbers, state, comment)
+	if !confirmPlan(plan, assumeYes) {
+		return 0
+	}
+
+	return executePlan(plan, func(action PlannedAction) error {
+		return setPRState(client, owner, repo, action.Number, state, comment)
+	})
+}
+
+// setPRState closes or reopens a PR, first posting comment unless it is empty
+func setPRState(client RESTClientInterface, owner, repo string, prNumber int, state, comment string) error {
+	if comment != "" {
+		if err := addCommentToPR(client, owner, repo, prNumber, comment); err != nil {
+			return err
+		}
+	}
+
+	updateJSON, err := json.Marshal(PullRequestUpdate{State: state})
+	if err != nil {
+		return fmt.Errorf("failed to marshal pull request update: %v", err)
+	}
+
+	prPath := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, prNumber)
+	if err := client.Patch(prPath, bytes.NewReader(updateJSON), nil); err != nil {
+		return fmt.Errorf("failed to set state to %s: %v", state, err)
+	}
+	action := AuditClose
+	if state == "open" {
+		action = AuditReopen
+	}
+	auditLog.record(client, owner, repo, prNumber, action, "")
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(closeCmd)
+	RootCmd.AddCommand(reopenCmd)
+
+	closeCmd.Flags().StringVar(&stateComment, "comment", "", "Comment to post before closing")
+	closeCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Carry out the plan without asking for confirmation")
+	reopenCmd.Flags().StringVar(&stateComment, "comment", "", "Comment to post before reopening")
+	reopenCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Carry out the plan without asking for confirmation")
+}
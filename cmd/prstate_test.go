@@ -0,0 +1,76 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Closing and reopening PRs", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, Title: "Stale bump", State: "open"})
+		mockClient.AddResponse("repos/owner/repo/pulls/2", 200, cmd.PullRequest{Number: 2, Title: "Old bump", State: "closed"})
+		mockClient.AddResponse("repos/owner/repo/pulls/3", 200, cmd.PullRequest{Number: 3, Title: "Shipped", State: "closed", Merged: true})
+		mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+		mockClient.AddResponse("repos/owner/repo/issues/2/comments", 201, map[string]interface{}{})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should plan to close only open PRs", func() {
+		actions := cmd.PlanStateChangeTest(mockClient, "owner", "repo", []int{1, 2, 3}, "closed", "superseded")
+		Expect(actions).To(Equal([]cmd.PlannedAction{
+			{Number: 1, Title: "Stale bump", Action: cmd.PlanActionClose, Reason: "superseded"},
+			{Number: 2, Title: "Old bump", Action: cmd.PlanActionSkip, Reason: "PR is already closed"},
+			{Number: 3, Title: "Shipped", Action: cmd.PlanActionSkip, Reason: "PR is merged"},
+		}))
+	})
+
+	It("should plan to reopen only closed, unmerged PRs", func() {
+		actions := cmd.PlanStateChangeTest(mockClient, "owner", "repo", []int{1, 2, 3}, "open", "")
+		Expect(actions).To(Equal([]cmd.PlannedAction{
+			{Number: 1, Title: "Stale bump", Action: cmd.PlanActionSkip, Reason: "PR is already open"},
+			{Number: 2, Title: "Old bump", Action: cmd.PlanActionReopen, Reason: "requested"},
+			{Number: 3, Title: "Shipped", Action: cmd.PlanActionSkip, Reason: "PR is merged"},
+		}))
+	})
+
+	It("should comment and then close", func() {
+		Expect(cmd.ChangePRStatesTest(mockClient, "owner", "repo", []int{1, 2}, "closed", "superseded", true)).To(Equal(0))
+
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"superseded"}`}))
+		Expect(mockClient.RequestBodies("PATCH", "repos/owner/repo/pulls/1")).To(Equal([]string{`{"state":"closed"}`}))
+		Expect(mockClient.RequestBodies("PATCH", "repos/owner/repo/pulls/2")).To(BeEmpty())
+	})
+
+	It("should reopen without a comment", func() {
+		Expect(cmd.ChangePRStatesTest(mockClient, "owner", "repo", []int{2}, "open", "", true)).To(Equal(0))
+
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/2/comments")).To(BeEmpty())
+		Expect(mockClient.RequestBodies("PATCH", "repos/owner/repo/pulls/2")).To(Equal([]string{`{"state":"open"}`}))
+	})
+
+	It("should count failures", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, State: "open"})
+		mockClient.AddErrorResponse("repos/owner/repo/issues/1/comments", fmt.Errorf("forbidden"))
+
+		Expect(cmd.ChangePRStatesTest(mockClient, "owner", "repo", []int{1}, "closed", "bye", true)).To(Equal(1))
+		Expect(out.String()).To(ContainSubstring("Succeeded: 0, failed: 1, skipped: 0"))
+	})
+})
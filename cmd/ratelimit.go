@@ -0,0 +1,262 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	// defaultRateLimitThreshold is the remaining quota at which requests pause until the limit resets
+	defaultRateLimitThreshold = 50
+	// maxRateLimitRetries bounds how often a request rejected by a rate limit is retried
+	maxRateLimitRetries = 3
+	// secondaryRateLimitDelay is the backoff used when GitHub rejects a request without saying how long to wait
+	secondaryRateLimitDelay = time.Minute
+)
+
+// verbose logs progress, timing and the remaining API quota to stderr
+var verbose bool
+
+// rateLimiter tracks the REST API quota reported by GitHub and pauses requests when it runs low.
+// One limiter is shared by every client in a run since they all use the same token.
+type rateLimiter struct {
+	mutex     sync.Mutex
+	threshold int
+	out       io.Writer
+	sleep     func(ctx context.Context, d time.Duration) error
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+	announced time.Time
+}
+
+// newRateLimiter creates a limiter that pauses once the remaining quota drops to threshold
+func newRateLimiter(threshold int, out io.Writer) *rateLimiter {
+	return &rateLimiter{threshold: threshold, out: out, sleep: sleepContext}
+}
+
+// sleepContext waits for d or until ctx is done
+func sleepContext(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// observe records the quota from the X-RateLimit-* headers of a response
+func (l *rateLimiter) observe(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	limit, _ := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	resetUnix, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	reset := time.Unix(resetUnix, 0)
+	// Responses can arrive out of order, so only let an older quota window or a lower count replace what we know
+	if l.known && !reset.After(l.reset) && remaining > l.remaining {
+		return
+	}
+	l.known = true
+	l.limit = limit
+	l.remaining = remaining
+	l.reset = reset
+}
+
+// wait pauses until the quota resets when the remaining quota is at or below the threshold
+func (l *rateLimiter) wait(ctx context.Context) error {
+	l.mutex.Lock()
+	if !l.known || l.remaining > l.threshold {
+		l.mutex.Unlock()
+		return nil
+	}
+	delay := time.Until(l.reset)
+	if delay <= 0 {
+		// The window has reset, so the old count no longer applies
+		l.known = false
+		l.mutex.Unlock()
+		return nil
+	}
+	if !l.announced.Equal(l.reset) {
+		l.announced = l.reset
+		_, _ = fmt.Fprintf(l.out, "⏳ GitHub API rate limit nearly exhausted (%d remaining), pausing %s until it resets at %s\n",
+			l.remaining, delay.Round(time.Second), l.reset.Format("15:04:05"))
+	}
+	l.mutex.Unlock()
+	return l.sleep(ctx, delay)
+}
+
+// retryDelay returns how long to back off before retrying a request GitHub rejected because of a rate limit
+func (l *rateLimiter) retryDelay(err error) (time.Duration, bool) {
+	var httpErr *api.HTTPError
+	if !errors.As(err, &httpErr) {
+		return 0, false
+	}
+	if httpErr.StatusCode != http.StatusForbidden && httpErr.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+
+	// Secondary rate limits say how long to wait
+	if seconds, err := strconv.Atoi(httpErr.Headers.Get("Retry-After")); err == nil {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	// Primary rate limit: the quota is used up until the reset time
+	if httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+		resetUnix, err := strconv.ParseInt(httpErr.Headers.Get("X-RateLimit-Reset"), 10, 64)
+		if err != nil {
+			return secondaryRateLimitDelay, true
+		}
+		delay := time.Until(time.Unix(resetUnix, 0))
+		if delay < time.Second {
+			delay = time.Second
+		}
+		return delay, true
+	}
+
+	if httpErr.StatusCode == http.StatusTooManyRequests {
+		return secondaryRateLimitDelay, true
+	}
+	return 0, false
+}
+
+// summary describes the remaining quota, or returns "" if no response reported it yet
+func (l *rateLimiter) summary() string {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	if !l.known {
+		return ""
+	}
+	return fmt.Sprintf("GitHub API quota: %d/%d requests remaining, resets at %s",
+		l.remaining, l.limit, l.reset.Format("15:04:05"))
+}
+
+// rateLimitObserverClient records the quota headers of every response, including error responses
+type rateLimitObserverClient struct {
+	RESTClientInterface
+	limiter *rateLimiter
+}
+
+// withRateLimitObserver wraps the raw REST client so every response updates the limiter
+func withRateLimitObserver(client RESTClientInterface, limiter *rateLimiter) RESTClientInterface {
+	return &rateLimitObserverClient{RESTClientInterface: client, limiter: limiter}
+}
+
+// Request performs a request and records its quota headers
+func (c *rateLimitObserverClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+// RequestWithContext performs a request and records its quota headers
+func (c *rateLimitObserverClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	resp, err := c.RESTClientInterface.RequestWithContext(ctx, method, path, body)
+	var httpErr *api.HTTPError
+	if resp != nil {
+		c.limiter.observe(resp.Header)
+	} else if errors.As(err, &httpErr) {
+		c.limiter.observe(httpErr.Headers)
+	}
+	return resp, err
+}
+
+// rateLimitedRESTClient pauses requests while the quota is low and retries requests rejected by a rate limit.
+// It sits outside the per-request timeout so that pausing doesn't count against it.
+type rateLimitedRESTClient struct {
+	RESTClientInterface
+	limiter *rateLimiter
+}
+
+// withRateLimit wraps a client so requests wait for the rate limit (a nil limiter disables waiting)
+func withRateLimit(client RESTClientInterface, limiter *rateLimiter) RESTClientInterface {
+	if limiter == nil {
+		return client
+	}
+	return &rateLimitedRESTClient{RESTClientInterface: client, limiter: limiter}
+}
+
+// Get performs a GET request once the rate limit allows it
+func (c *rateLimitedRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post performs a POST request once the rate limit allows it
+func (c *rateLimitedRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request once the rate limit allows it
+func (c *rateLimitedRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request once the rate limit allows it
+func (c *rateLimitedRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request once the rate limit allows it
+func (c *rateLimitedRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do performs a request once the rate limit allows it
+func (c *rateLimitedRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext performs a request once the rate limit allows it, backing off and retrying if it is rejected
+func (c *rateLimitedRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	// Buffer the body so it can be sent again on retry
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := c.limiter.wait(ctx); err != nil {
+			return err
+		}
+
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		err := c.RESTClientInterface.DoWithContext(ctx, method, path, reqBody, response)
+		if err == nil || attempt >= maxRateLimitRetries {
+			return err
+		}
+
+		delay, limited := c.limiter.retryDelay(err)
+		if !limited {
+			return err
+		}
+		_, _ = fmt.Fprintf(c.limiter.out, "⏳ GitHub API rate limit hit, retrying in %s (attempt %d/%d)\n",
+			delay.Round(time.Second), attempt+1, maxRateLimitRetries)
+		if err := c.limiter.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	RootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", false, "Log progress, timing and the remaining GitHub API quota to stderr")
+}
@@ -0,0 +1,130 @@
+package cmd_test
+
+import (
+	"bytes"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// rateLimitHeaders builds the quota headers GitHub sends with every REST response
+func rateLimitHeaders(remaining int, reset time.Time) http.Header {
+	headers := http.Header{}
+	headers.Set("X-RateLimit-Limit", "5000")
+	headers.Set("X-RateLimit-Remaining", strconv.Itoa(remaining))
+	headers.Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+	return headers
+}
+
+var _ = Describe("Rate Limiting", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		sleeps     []time.Duration
+		client     cmd.RESTClientInterface
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		sleeps = nil
+		client = cmd.WithRateLimitTest(mockClient, 10, out, func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		})
+	})
+
+	It("should not pause while plenty of quota remains", func() {
+		mockClient.AddResponseWithHeaders("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1},
+			rateLimitHeaders(4000, time.Now().Add(time.Hour)))
+
+		var pr cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(sleeps).To(BeEmpty())
+		Expect(out.String()).To(BeEmpty())
+	})
+
+	It("should pause until the reset once the quota is within the threshold", func() {
+		mockClient.AddResponseWithHeaders("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1},
+			rateLimitHeaders(5, time.Now().Add(10*time.Minute)))
+
+		var pr cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(sleeps).To(BeEmpty())
+
+		Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(sleeps).To(HaveLen(1))
+		Expect(sleeps[0]).To(BeNumerically("~", 10*time.Minute, 5*time.Second))
+		Expect(out.String()).To(ContainSubstring("rate limit nearly exhausted (5 remaining)"))
+	})
+
+	It("should announce a pause only once per quota window", func() {
+		mockClient.AddResponseWithHeaders("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1},
+			rateLimitHeaders(1, time.Now().Add(time.Minute)))
+
+		var pr cmd.PullRequest
+		for i := 0; i < 3; i++ {
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		}
+		Expect(sleeps).To(HaveLen(2))
+		Expect(bytes.Count(out.Bytes(), []byte("nearly exhausted"))).To(Equal(1))
+	})
+
+	It("should back off and retry when a secondary rate limit is hit", func() {
+		headers := http.Header{}
+		headers.Set("Retry-After", "30")
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/2", &api.HTTPError{StatusCode: http.StatusForbidden, Headers: headers})
+
+		var pr cmd.PullRequest
+		err := client.Get("repos/owner/repo/pulls/2", &pr)
+		Expect(err).To(HaveOccurred())
+		Expect(sleeps).To(HaveLen(3))
+		Expect(sleeps[0]).To(Equal(30 * time.Second))
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/2")).To(Equal(4))
+		Expect(out.String()).To(ContainSubstring("retrying in 30s"))
+	})
+
+	It("should wait for the reset when the primary rate limit is exhausted", func() {
+		headers := rateLimitHeaders(0, time.Now().Add(2*time.Minute))
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/3", &api.HTTPError{StatusCode: http.StatusForbidden, Headers: headers})
+
+		var pr cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls/3", &pr)).NotTo(Succeed())
+		Expect(sleeps).NotTo(BeEmpty())
+		Expect(sleeps[0]).To(BeNumerically("~", 2*time.Minute, 5*time.Second))
+	})
+
+	It("should not retry other errors", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/4", &api.HTTPError{StatusCode: http.StatusForbidden, Headers: http.Header{}})
+
+		var pr cmd.PullRequest
+		Expect(client.Get("repos/owner/repo/pulls/4", &pr)).NotTo(Succeed())
+		Expect(sleeps).To(BeEmpty())
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/4")).To(Equal(1))
+	})
+
+	It("should resend the request body on retry", func() {
+		headers := http.Header{}
+		headers.Set("Retry-After", "1")
+		mockClient.AddErrorResponse("repos/owner/repo/issues/5/comments", &api.HTTPError{StatusCode: http.StatusTooManyRequests, Headers: headers})
+
+		err := client.Post("repos/owner/repo/issues/5/comments", bytes.NewBufferString(`{"body":"/lgtm"}`), nil)
+		Expect(err).To(HaveOccurred())
+		for _, req := range mockClient.Requests {
+			Expect(req.Body).To(Equal(`{"body":"/lgtm"}`))
+		}
+	})
+
+	It("should summarize the remaining quota", func() {
+		reset := time.Now().Add(time.Hour)
+		Expect(cmd.RateLimitSummaryTest(rateLimitHeaders(4321, reset))).To(
+			Equal("GitHub API quota: 4321/5000 requests remaining, resets at " + reset.Format("15:04:05")))
+		Expect(cmd.RateLimitSummaryTest(http.Header{})).To(BeEmpty())
+	})
+})
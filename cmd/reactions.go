@@ -0,0 +1,142 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Reactions ghprs offers at the approval prompt, as named by the reactions API
+const (
+	reactionThumbsUp = "+1"
+	reactionRocket   = "rocket"
+)
+
+// Reactions are the reaction counts of a PR or comment
+type Reactions struct {
+	TotalCount int `json:"total_count"`
+	PlusOne    int `json:"+1"`
+	MinusOne   int `json:"-1"`
+	Laugh      int `json:"laugh"`
+	Hooray     int `json:"hooray"`
+	Confused   int `json:"confused"`
+	Heart      int `json:"heart"`
+	Rocket     int `json:"rocket"`
+	Eyes       int `json:"eyes"`
+}
+
+// ReactionRequest is the body of a request to react to a PR or comment
+type ReactionRequest struct {
+	Content string `json:"content"`
+}
+
+// summary shows the reactions given, e.g. "👍 2  🚀 1", or "" when there are none
+func (r *Reactions) summary() string {
+	if r == nil || r.TotalCount == 0 {
+		return ""
+	}
+	counts := []struct {
+		emoji string
+		count int
+	}{
+		{"👍", r.PlusOne}, {"👎", r.MinusOne}, {"😄", r.Laugh}, {"🎉", r.Hooray},
+		{"😕", r.Confused}, {"❤️", r.Heart}, {"🚀", r.Rocket}, {"👀", r.Eyes},
+	}
+	var parts []string
+	for _, reaction := range counts {
+		if reaction.count > 0 {
+			parts = append(parts, fmt.Sprintf("%s %d", reaction.emoji, reaction.count))
+		}
+	}
+	return strings.Join(parts, "  ")
+}
+
+// reactionEmoji returns the emoji of a reaction offered at the approval prompt
+func reactionEmoji(content string) string {
+	if content == reactionRocket {
+		return "🚀"
+	}
+	return "👍"
+}
+
+// fetchPRReactions fetches the reaction counts of a PR, which only its issue has
+func fetchPRReactions(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int) (*Reactions, error) {
+	var issue struct {
+		Reactions *Reactions `json:"reactions"`
+	}
+	if err := client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s/issues/%d", owner, repo, prNumber), nil, &issue); err != nil {
+		return nil, err
+	}
+	return issue.Reactions, nil
+}
+
+// latestBotComment returns the most recent comment of comments made by a bot, nil if there is none
+func latestBotComment(comments []IssueComment) *IssueComment {
+	for i := len(comments) - 1; i >= 0; i-- {
+		if strings.HasSuffix(comments[i].User.Login, "[bot]") {
+			return &comments[i]
+		}
+	}
+	return nil
+}
+
+// addReaction reacts to a PR, or to one of its comments when commentID isn't 0
+func addReaction(client RESTClientInterface, owner, repo string, prNumber int, commentID int64, content string) error {
+	path := fmt.Sprintf("repos/%s/%s/issues/%d/reactions", owner, repo, prNumber)
+	if commentID != 0 {
+		path = fmt.Sprintf("repos/%s/%s/issues/comments/%d/reactions", owner, repo, commentID)
+	}
+	requestJSON, err := json.Marshal(ReactionRequest{Content: content})
+	if err != nil {
+		return fmt.Errorf("failed to marshal reaction: %v", err)
+	}
+	if err := client.Post(path, bytes.NewReader(requestJSON), nil); err != nil {
+		return fmt.Errorf("failed to add reaction: %v", err)
+	}
+	auditLog.record(client, owner, repo, prNumber, AuditReact, content)
+	return nil
+}
+
+// promptForReaction asks for a 👍 or 🚀 and whether it goes on the PR or its latest bot comment, and adds it.
+// It only returns the errors of reading the answers.
+func promptForReaction(client RESTClientInterface, owner, repo string, prNumber int) error {
+	answer, err := prompter.Input("React with 👍 or 🚀? [1=👍, 2=🚀, Enter to cancel]: ")
+	if err != nil {
+		return err
+	}
+	var content string
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "1", "+1", "👍":
+		content = reactionThumbsUp
+	case "2", "rocket", "🚀":
+		content = reactionRocket
+	default:
+		streams.Printf("No reaction added.\n")
+		return nil
+	}
+
+	link := formatPRLink(owner, repo, prNumber)
+	target, commentID := "PR "+link, int64(0)
+	comments, err := fetchPages[IssueComment](withFreshData(context.Background()), client, fmt.Sprintf("repos/%s/%s/issues/%d/comments?sort=created", owner, repo, prNumber))
+	if err != nil {
+		streams.Printf("   ⚠️  Could not fetch comments, reacting to the PR: %v\n", err)
+	} else if comment := latestBotComment(comments); comment != nil {
+		answer, err := prompter.Input(fmt.Sprintf("React to the PR (p) or the latest comment by @%s (b)? [P/b]: ", comment.User.Login))
+		if err != nil {
+			return err
+		}
+		if strings.ToLower(strings.TrimSpace(answer)) == "b" {
+			target, commentID = fmt.Sprintf("the latest comment by @%s on %s", comment.User.Login, link), comment.ID
+		}
+	}
+
+	if err := addReaction(client, owner, repo, prNumber, commentID, content); err != nil {
+		streams.Printf("❌ %v\n", err)
+		return nil
+	}
+	streams.Printf("%s Reacted to %s\n", reactionEmoji(content), target)
+	return nil
+}
@@ -0,0 +1,233 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
+// Readiness states, the single answer to "what does this PR need before it can merge?"
+const (
+	ReadinessReady         = ghprs.ReadinessReady
+	ReadinessNeedsReview   = ghprs.ReadinessNeedsReview
+	ReadinessNeedsRebase   = ghprs.ReadinessNeedsRebase
+	ReadinessChecksFailing = ghprs.ReadinessChecksFailing
+	ReadinessBlocked       = ghprs.ReadinessBlocked
+	ReadinessOnHold        = ghprs.ReadinessOnHold
+	ReadinessFrozen        = ghprs.ReadinessFrozen
+)
+
+// allReadinessStates lists the readiness states in the order they take precedence
+var allReadinessStates = []string{
+	ReadinessOnHold,
+	ReadinessFrozen,
+	ReadinessNeedsRebase,
+	ReadinessChecksFailing,
+	ReadinessNeedsReview,
+	ReadinessBlocked,
+	ReadinessReady,
+}
+
+// Supported values for the --view flag
+const (
+	ViewDetailed  = "detailed"
+	ViewReadiness = "readiness"
+)
+
+// validateView checks that the --view flag has a supported value
+func validateView(view string) error {
+	switch view {
+	case ViewDetailed, ViewReadiness:
+		return nil
+	default:
+		return fmt.Errorf("invalid view %q (must be one of: detailed, readiness)", view)
+	}
+}
+
+// parseReadinessFilter normalizes the --readiness values, accepting any case and dashes for underscores
+func parseReadinessFilter(values []string) ([]string, error) {
+	var states []string
+	for _, value := range values {
+		state := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(value), "-", "_"))
+		if state == "" {
+			continue
+		}
+		known := false
+		for _, s := range allReadinessStates {
+			if s == state {
+				known = true
+				break
+			}
+		}
+		if !known {
+			return nil, fmt.Errorf("invalid readiness %q (must be one of: %s)", value, strings.ToLower(strings.Join(allReadinessStates, ", ")))
+		}
+		states = append(states, state)
+	}
+	return states, nil
+}
+
+// readinessRequested reports whether rows need a readiness state, which costs an extra API call per PR for the checks
+func (opts *listOptions) readinessRequested() bool {
+	return opts.View == ViewReadiness || len(opts.Readiness) > 0
+}
+
+// isFrozen reports whether a PR is a draft or carries a do-not-merge label other than the hold label
+func isFrozen(pr PullRequest) bool {
+	return ghprs.IsFrozen(pr)
+}
+
+// prReadiness rolls the signals of a row up into one readiness state, see ghprs.Readiness for the precedence
+func prReadiness(row PRRow, pr PullRequest) string {
+	return ghprs.Readiness(ghprs.ReadinessSignals{
+		Open:          row.State == "open",
+		OnHold:        row.OnHold,
+		Frozen:        isFrozen(pr),
+		NeedsRebase:   row.NeedsRebase,
+		ChecksFailing: row.Checks == checksFailing,
+		Reviewed:      row.Reviewed,
+		Blocked:       row.Blocked,
+	})
+}
+
+// filterRowsByReadiness keeps the rows whose readiness is one of states
+func filterRowsByReadiness(rows []PRRow, states []string) []PRRow {
+	if len(states) == 0 {
+		return rows
+	}
+
+	var filtered []PRRow
+	for _, row := range rows {
+		for _, state := range states {
+			if row.Readiness == state {
+				filtered = append(filtered, row)
+				break
+			}
+		}
+	}
+	return filtered
+}
+
+// filterPRsByReadiness keeps the PRs whose readiness is one of states, building their rows to find out
+func filterPRsByReadiness(ctx context.Context, opts *listOptions, pullRequests []PullRequest, client RESTClientInterface, owner, repo string, isKonflux bool, states []string) []PullRequest {
+	if len(states) == 0 || len(pullRequests) == 0 {
+		return pullRequests
+	}
+
+	keep := make(map[int]bool)
+	for _, row := range filterRowsByReadiness(buildPRRows(ctx, opts, pullRequests, owner, repo, client, isKonflux, nil), states) {
+		keep[row.Number] = true
+	}
+
+	var filtered []PullRequest
+	for _, pr := range pullRequests {
+		if keep[pr.Number] {
+			filtered = append(filtered, pr)
+		}
+	}
+	return filtered
+}
+
+// readinessIcon returns the emoji shown next to a readiness state
+func readinessIcon(readiness string) string {
+	switch readiness {
+	case ReadinessReady:
+		return "✅"
+	case ReadinessNeedsReview:
+		return "👀"
+	case ReadinessNeedsRebase:
+		return "🔄"
+	case ReadinessChecksFailing:
+		return "❌"
+	case ReadinessBlocked:
+		return "🚫"
+	case ReadinessOnHold:
+		return "🔶"
+	case ReadinessFrozen:
+		return "🧊"
+	default:
+		return ""
+	}
+}
+
+// renderReadinessTable prints previously built rows with a single readiness column instead of the indicator columns,
+// marking what fast mode skipped with "-"
+func renderReadinessTable(rows []PRRow, owner, repo string, isKonflux bool, shouldDisplayLegend, fast bool) {
+	if shouldDisplayLegend {
+		render.WriteReadinessLegend(streams.Out, isKonflux)
+	}
+
+	if isKonflux {
+		streams.Printf("\n=== %s: Konflux PRs ===\n", repo)
+	} else {
+		streams.Printf("\n=== %s: PRs ===\n", repo)
+	}
+
+	titleWidth, authorWidth, branchWidth, targetWidth := textColumnWidths(rows)
+	const (
+		statusWidth    = 2  // Emoji width
+		prWidth        = 6  // "#1234"
+		readinessWidth = 17 // "❌ CHECKS_FAILING"
+		securityWidth  = 8  // "SECURITY"
+		ageWidth       = 7  // "120d 🐌"
+		sizeWidth      = 4  // "SIZE"
+	)
+	now := time.Now()
+	table := render.NewTable(
+		render.Column{Header: "REPO", Width: repoColumnWidth(rows), Truncate: true},
+		render.Column{Header: "COMPONENT", Width: componentColumnWidth(rows), Truncate: true},
+		render.Column{Header: "ST", Width: statusWidth},
+		render.Column{Header: "PR", Width: prWidth},
+		render.Column{Header: "TITLE", Width: titleWidth, Truncate: true},
+		render.Column{Header: "AUTHOR", Width: authorWidth, Truncate: true},
+		render.Column{Header: "BRANCH", Width: branchWidth, Truncate: true},
+		render.Column{Header: "TARGET", Width: targetWidth, Truncate: true},
+		render.Column{Header: "OWNERS", Width: ownersColumnWidth(rows), Truncate: true},
+		render.Column{Header: "AGE", Width: ageWidth},
+		render.Column{Header: "SIZE", Width: sizeWidth},
+		render.Column{Header: "DEP", Width: dependencyColumnWidth(rows), Truncate: true},
+		render.Column{Header: "SEMVER", Width: semverColumnWidth(rows), Truncate: true},
+		render.Column{Header: "STATUS", Width: readinessWidth},
+		render.Column{Header: "SECURITY", Width: securityWidth},
+		render.Column{Header: "TEKTON", Width: tektonColumnWidth(isKonflux)},
+	)
+
+	for _, row := range rows {
+		// Closed and merged PRs have no readiness, so show their state instead
+		status := row.State
+		if row.Readiness != "" {
+			status = readinessIcon(row.Readiness) + " " + row.Readiness
+		}
+		if row.Migration {
+			status += " 🚨"
+		}
+
+		securityStatus := ""
+		if row.Security {
+			securityStatus = "🔒"
+		}
+
+		table.AddRow(
+			row.Repository,
+			row.Component,
+			statusIcon(row.State, row.Draft, row.OnHold),
+			rowPRLink(row, owner, repo),
+			rowTitle(row),
+			row.Author,
+			row.Branch,
+			row.Target,
+			lookupColumn(row, lookupOwners, ownersColumn(row)),
+			ageColumn(row, now),
+			lookupColumn(row, lookupDetails, sizeColumn(row, fast)),
+			dependencyColumn(row),
+			semverColumn(row),
+			status,
+			securityStatus,
+			lookupColumn(row, lookupFiles, tektonColumn(row, fast)))
+	}
+	table.Write(streams.Out)
+}
@@ -0,0 +1,134 @@
+package cmd_test
+
+import (
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Readiness", func() {
+	yes, no := true, false
+
+	Describe("Rolling up the signals", func() {
+		var row cmd.PRRow
+
+		BeforeEach(func() {
+			row = cmd.PRRow{State: "open", Reviewed: &yes, NeedsRebase: &no, Blocked: &no, Checks: "passing"}
+		})
+
+		It("should be ready when nothing is left to do", func() {
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessReady))
+		})
+
+		It("should have no readiness for closed PRs", func() {
+			row.State = "closed"
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(BeEmpty())
+		})
+
+		It("should need a review when not reviewed or the review state is unknown", func() {
+			row.Reviewed = &no
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessNeedsReview))
+			row.Reviewed = nil
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessNeedsReview))
+		})
+
+		It("should report blocked reviewed PRs", func() {
+			row.Blocked = &yes
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessBlocked))
+		})
+
+		It("should prefer failing checks over review and blocked state", func() {
+			row.Checks = "failing"
+			row.Reviewed = &no
+			row.Blocked = &yes
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessChecksFailing))
+		})
+
+		It("should prefer a needed rebase over failing checks", func() {
+			row.Checks = "failing"
+			row.NeedsRebase = &yes
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{})).To(Equal(cmd.ReadinessNeedsRebase))
+		})
+
+		It("should freeze drafts and do-not-merge PRs", func() {
+			row.NeedsRebase = &yes
+			Expect(cmd.PRReadinessTest(row, cmd.PullRequest{Draft: true})).To(Equal(cmd.ReadinessFrozen))
+
+			pr := cmd.PullRequest{Labels: []cmd.Label{{Name: "do-not-merge/work-in-progress"}}}
+			Expect(cmd.PRReadinessTest(row, pr)).To(Equal(cmd.ReadinessFrozen))
+		})
+
+		It("should put hold above everything else", func() {
+			row.OnHold = true
+			pr := cmd.PullRequest{Draft: true, Labels: []cmd.Label{{Name: "do-not-merge/hold"}}}
+			Expect(cmd.PRReadinessTest(row, pr)).To(Equal(cmd.ReadinessOnHold))
+		})
+	})
+
+	Describe("Flags", func() {
+		It("should accept the supported views", func() {
+			Expect(cmd.ValidateViewTest("detailed")).To(Succeed())
+			Expect(cmd.ValidateViewTest("readiness")).To(Succeed())
+			Expect(cmd.ValidateViewTest("compact")).To(MatchError(ContainSubstring("compact")))
+		})
+
+		It("should normalize readiness filters", func() {
+			states, err := cmd.ParseReadinessFilterTest([]string{"ready", "needs-review", " CHECKS_FAILING ", ""})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(states).To(Equal([]string{cmd.ReadinessReady, cmd.ReadinessNeedsReview, cmd.ReadinessChecksFailing}))
+		})
+
+		It("should reject unknown readiness states", func() {
+			_, err := cmd.ParseReadinessFilterTest([]string{"ready", "mergeable"})
+			Expect(err).To(MatchError(ContainSubstring("mergeable")))
+		})
+	})
+
+	Describe("Building and filtering rows", func() {
+		var mockClient *cmd.MockRESTClient
+		var prs []cmd.PullRequest
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			prs = []cmd.PullRequest{
+				{Number: 1, State: "open", Head: cmd.Branch{SHA: "sha1"}, Labels: []cmd.Label{{Name: "lgtm"}}},
+				{Number: 2, State: "open", Head: cmd.Branch{SHA: "sha2"}, Labels: []cmd.Label{{Name: "lgtm"}}},
+				{Number: 3, State: "open", Head: cmd.Branch{SHA: "sha3"}},
+			}
+			for _, pr := range prs {
+				mockClient.AddResponse(fmt.Sprintf("repos/owner/repo/pulls/%d", pr.Number), 200, cmd.PullRequest{Number: pr.Number, State: "open", MergeableState: "clean"})
+				mockClient.AddResponse(fmt.Sprintf("repos/owner/repo/pulls/%d/reviews", pr.Number), 200, []cmd.Review{})
+			}
+			mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CreateMockCheckRuns(2, 0, 0))
+			mockClient.AddResponse("repos/owner/repo/commits/sha2/check-runs", 200, cmd.CreateMockCheckRuns(1, 1, 0))
+			mockClient.AddResponse("repos/owner/repo/commits/sha3/check-runs", 200, cmd.CreateMockCheckRuns(1, 0, 0))
+			mockClient.AddResponse("/status", 200, map[string]interface{}{"state": "success", "statuses": []interface{}{}})
+		})
+
+		It("should fill in checks and readiness in the readiness view", func() {
+			rows := cmd.BuildPRRowsWithReadinessTest(prs, "owner", "repo", mockClient)
+			Expect(rows).To(HaveLen(3))
+			Expect(rows[0].Checks).To(Equal("passing"))
+			Expect(rows[0].Readiness).To(Equal(cmd.ReadinessReady))
+			Expect(rows[1].Checks).To(Equal("failing"))
+			Expect(rows[1].Readiness).To(Equal(cmd.ReadinessChecksFailing))
+			Expect(rows[2].Readiness).To(Equal(cmd.ReadinessNeedsReview))
+		})
+
+		It("should leave readiness out of the default rows", func() {
+			rows := cmd.BuildPRRowsTest(prs, "owner", "repo", mockClient, false)
+			Expect(rows[0].Checks).To(BeEmpty())
+			Expect(rows[0].Readiness).To(BeEmpty())
+		})
+
+		It("should keep only PRs with the requested readiness", func() {
+			filtered := cmd.FilterPRsByReadinessTest(prs, mockClient, "owner", "repo", []string{cmd.ReadinessReady, cmd.ReadinessNeedsReview})
+			Expect(filtered).To(HaveLen(2))
+			Expect(filtered[0].Number).To(Equal(1))
+			Expect(filtered[1].Number).To(Equal(3))
+		})
+	})
+})
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// prowLabels are labels only Prow manages, used to recognize Prow-managed repositories
+var prowLabels = []string{"lgtm", "approved", "needs-rebase", "ok-to-test", "needs-ok-to-test"}
+
+// UpdateBranchRequest represents a request to update a PR branch with its base branch
+type UpdateBranchRequest struct {
+	// ExpectedHeadSHA makes GitHub refuse the update if the PR changed in the meantime
+	ExpectedHeadSHA string `json:"expected_head_sha,omitempty"`
+}
+
+// rebaseCmd updates a PR branch with its base branch
+var rebaseCmd = &cobra.Command{
+	Use:   "rebase [owner/repo] <number>",
+	Short: "Update a pull request branch with its target branch",
+	Long: `Update a pull request branch with the latest changes from its target branch using GitHub's
+"update branch" API. When GitHub can't update the branch (for example because of conflicts or
+missing permissions) and the repository is managed by Prow, a /rebase comment is posted instead.
+
+Without owner/repo the repository given with --repo or else the current repository is used.
+
+Examples:
+  ghprs rebase 123
+  ghprs rebase owner/repo 123`,
+	Args:              cobra.RangeArgs(1, 2),
+	ValidArgsFunction: completePRArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, number := parsePRArgs(args)
+
+		client := newCommandClient(ctx, owner, repo)
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		if err != nil {
+			log.Fatalf("Failed to fetch PR #%d: %v", number, err)
+		}
+
+		message, err := rebasePR(client, owner, repo, *pr)
+		if err != nil {
+			streams.Printf("❌ Failed to rebase PR %s: %v\n", formatPRLink(owner, repo, number), err)
+			os.Exit(1)
+		}
+		streams.Printf("🔄 %s for PR %s\n", message, formatPRLink(owner, repo, number))
+	},
+}
+
+// isProwManaged reports whether a PR carries labels that only Prow sets
+func isProwManaged(pr PullRequest) bool {
+	for _, label := range pr.Labels {
+		if strings.HasPrefix(label.Name, "do-not-merge/") {
+			return true
+		}
+		for _, prowLabel := range prowLabels {
+			if label.Name == prowLabel {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// rebasePR asks GitHub to update the PR branch, falling back to a /rebase comment for Prow-managed repositories.
+// It returns a description of what was done.
+func rebasePR(client RESTClientInterface, owner, repo string, pr PullRequest) (string, error) {
+	requestJSON, err := json.Marshal(UpdateBranchRequest{ExpectedHeadSHA: pr.Head.SHA})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal update branch request: %v", err)
+	}
+
+	updatePath := fmt.Sprintf("repos/%s/%s/pulls/%d/update-branch", owner, repo, pr.Number)
+	updateErr := client.Put(updatePath, bytes.NewReader(requestJSON), nil)
+	if updateErr == nil {
+		auditLog.record(client, owner, repo, pr.Number, AuditUpdateBranch, "")
+		return "Branch update requested", nil
+	}
+
+	if !isProwManaged(pr) {
+		return "", fmt.Errorf("failed to update branch: %v", updateErr)
+	}
+
+	if err := addCommentToPR(client, owner, repo, pr.Number, "/rebase"); err != nil {
+		return "", fmt.Errorf("failed to update branch (%v) and to post /rebase comment: %v", updateErr, err)
+	}
+	return fmt.Sprintf("Posted /rebase comment (branch update failed: %v)", updateErr), nil
+}
+
+func init() {
+	RootCmd.AddCommand(rebaseCmd)
+}
@@ -0,0 +1,45 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Rebase", func() {
+	var mockClient *cmd.MockRESTClient
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]interface{}{})
+	})
+
+	It("should update the branch, guarded by the expected head", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/update-branch", 202, map[string]string{"message": "Updating pull request branch."})
+
+		message, err := cmd.RebasePRTest(mockClient, "owner", "repo", cmd.PullRequest{Number: 1, Head: cmd.Branch{SHA: "abc123"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("Branch update requested"))
+		Expect(mockClient.RequestBodies("PUT", "repos/owner/repo/pulls/1/update-branch")).To(Equal([]string{`{"expected_head_sha":"abc123"}`}))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/comments")).To(BeEmpty())
+	})
+
+	It("should fall back to a /rebase comment on Prow-managed repositories", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/update-branch", 422, map[string]string{"message": "merge conflict"})
+
+		pr := cmd.PullRequest{Number: 1, Labels: []cmd.Label{{Name: "needs-rebase"}}}
+		message, err := cmd.RebasePRTest(mockClient, "owner", "repo", pr)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(ContainSubstring("Posted /rebase comment"))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/comments")).To(Equal([]string{`{"body":"/rebase"}`}))
+	})
+
+	It("should report the failure on other repositories", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls/1/update-branch", 422, map[string]string{"message": "merge conflict"})
+
+		_, err := cmd.RebasePRTest(mockClient, "owner", "repo", cmd.PullRequest{Number: 1, Labels: []cmd.Label{{Name: "dependencies"}}})
+		Expect(err).To(MatchError(ContainSubstring("failed to update branch")))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/repo/issues/1/comments")).To(BeEmpty())
+	})
+})
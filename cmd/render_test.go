@@ -0,0 +1,63 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+	"ghprs/internal/golden"
+)
+
+var _ = Describe("Rendered PR tables", func() {
+	var (
+		out  *bytes.Buffer
+		rows []cmd.PRRow
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+		cmd.SetColumnWidthsTest(cmd.DefaultConfig())
+
+		yes, no := true, false
+		opened := func(ago time.Duration) string { return time.Now().Add(-ago).UTC().Format(time.RFC3339) }
+		rows = []cmd.PRRow{
+			{Number: 101, Title: "chore(deps): update konflux references to v0.4 for every pipeline", Author: "red-hat-konflux[bot]",
+				Branch: "konflux/references/main", Target: "main", State: "open", Reviewed: &yes, NeedsRebase: &no, Blocked: &no,
+				TektonOnly: &yes, Readiness: cmd.ReadinessReady, CreatedAt: opened(12 * 24 * time.Hour), Size: "XS"},
+			{Number: 102, Title: "fix(deps): update module golang.org/x/net [SECURITY]", Author: "red-hat-konflux[bot]",
+				Branch: "konflux/mintmaker/main/golang.org-x-net", Target: "release-1.2", State: "open", OnHold: true, Reviewed: &no,
+				NeedsRebase: &yes, Blocked: &yes, Security: true, Migration: true, TektonOnly: &no, Readiness: cmd.ReadinessOnHold,
+				CreatedAt: opened(120 * 24 * time.Hour), Stale: true, Size: "XL"},
+			{Number: 103, Title: "Update Konflux nudge", Author: "someone", Branch: "nudge", Target: "main", State: "open", Draft: true, New: true,
+				Reviewed: &no, Nudge: true, Readiness: cmd.ReadinessFrozen, CreatedAt: opened(3*time.Hour + time.Minute)},
+		}
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	DescribeTable("layouts",
+		func(isKonflux bool, view string, withLegend bool, path string) {
+			cmd.RenderPRTableViewTest(rows, "owner", "repo", isKonflux, view, withLegend)
+			Expect(golden.Check(path, out.Bytes())).To(Succeed())
+		},
+		Entry("detailed", false, cmd.ViewDetailed, false, "testdata/pr-table.golden"),
+		Entry("detailed Konflux with the legend", true, cmd.ViewDetailed, true, "testdata/pr-table-konflux.golden"),
+		Entry("readiness", false, cmd.ViewReadiness, false, "testdata/pr-table-readiness.golden"),
+		Entry("readiness Konflux with the legend", true, cmd.ViewReadiness, true, "testdata/pr-table-readiness-konflux.golden"),
+	)
+
+	It("should add the REPO and COMPONENT columns when rows have them", func() {
+		rows[0].Repository, rows[0].Component = "owner/repo", "app-main"
+		rows[1].Repository = "owner/another-repository-with-a-long-name"
+		rows[2].Repository = "other/repo"
+		cmd.RenderPRTableViewTest(rows, "", "All repositories", true, cmd.ViewDetailed, false)
+		Expect(golden.Check("testdata/pr-table-combined.golden", out.Bytes())).To(Succeed())
+	})
+})
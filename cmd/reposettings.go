@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+)
+
+// sortOrders are the orders of --sort-by and of the sort setting of a repository
+var sortOrders = []string{"newest", "oldest", "updated", "number", "priority"}
+
+var (
+	repoSettingsMutex sync.RWMutex
+	// repositorySettings maps "owner/repo" to the configuration of that repository, for the settings it overrides
+	repositorySettings = map[string]RepositoryConfig{}
+)
+
+// validateSortOrder checks that order is one of the orders PRs can be sorted in
+func validateSortOrder(order string) error {
+	if !slices.Contains(sortOrders, order) {
+		return fmt.Errorf("invalid sort order %q, must be one of: newest, oldest, updated, number, priority", order)
+	}
+	return nil
+}
+
+// setRepositorySettings remembers the settings each configured repository overrides
+func setRepositorySettings(config *Config) {
+	repoSettingsMutex.Lock()
+	defer repoSettingsMutex.Unlock()
+	repositorySettings = make(map[string]RepositoryConfig)
+	for _, repo := range config.Repositories {
+		repositorySettings[repo.Name] = repo
+	}
+}
+
+// settingsOf returns the configuration of a repository, empty when it isn't configured
+func settingsOf(owner, repo string) RepositoryConfig {
+	repoSettingsMutex.RLock()
+	defer repoSettingsMutex.RUnlock()
+	return repositorySettings[owner+"/"+repo]
+}
+
+// stateFor returns the state of the PRs to list of a repository: --state, else the repository's state, else the
+// default state
+func stateFor(opts *listOptions, owner, repo string) string {
+	if settings := settingsOf(owner, repo); !opts.stateFromFlag && settings.State != "" {
+		return settings.State
+	}
+	return opts.State
+}
+
+// limitFor returns how many PRs to list of a repository: --limit or --all, else the repository's limit, else the
+// default limit
+func limitFor(opts *listOptions, owner, repo string) int {
+	if settings := settingsOf(owner, repo); !opts.limitFromFlag && !opts.All && settings.Limit > 0 {
+		return settings.Limit
+	}
+	return opts.Limit
+}
+
+// sortFor returns the order the PRs of a repository are shown in: --sort-by, else the repository's sort, else
+// newest first ("")
+func sortFor(opts *listOptions, owner, repo string) string {
+	if opts.SortBy != "" {
+		return opts.SortBy
+	}
+	return settingsOf(owner, repo).Sort
+}
+
+// overriddenBy returns the approval settings with those a repository sets replacing them, field by field
+func (a ApprovalSettings) overriddenBy(repoSettings *ApprovalSettings) ApprovalSettings {
+	if repoSettings == nil {
+		return a
+	}
+	if repoSettings.Body != nil {
+		a.Body = repoSettings.Body
+	}
+	if repoSettings.Event != "" {
+		a.Event = repoSettings.Event
+	}
+	if repoSettings.ExtraComments != nil {
+		a.ExtraComments = repoSettings.ExtraComments
+	}
+	if repoSettings.VerifyTimeout != "" {
+		a.VerifyTimeout = repoSettings.VerifyTimeout
+	}
+	if repoSettings.MaxChanges != 0 {
+		a.MaxChanges = repoSettings.MaxChanges
+	}
+	return a
+}
+
+// ApprovalFor returns the approval settings of a repository: the global ones with the repository's replacing them
+func (c *Config) ApprovalFor(repo string) ApprovalSettings {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			return c.Approval.overriddenBy(existingRepo.Approval)
+		}
+	}
+	return c.Approval
+}
+
+// TektonPatternsFor returns the Tekton file patterns of a repository, none when it looks for the default pipelines
+func (c *Config) TektonPatternsFor(repo string) []string {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			return existingRepo.TektonPatterns
+		}
+	}
+	return nil
+}
+
+// AuthorFilterFor returns the authors whose PRs 'ghprs list' shows of a repository when --author isn't given
+func (c *Config) AuthorFilterFor(repo string) []string {
+	for _, existingRepo := range c.Repositories {
+		if existingRepo.Name == repo {
+			return existingRepo.AuthorFilter
+		}
+	}
+	return nil
+}
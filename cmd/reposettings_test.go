@@ -0,0 +1,123 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Per-repository settings", func() {
+	var config *cmd.Config
+
+	BeforeEach(func() {
+		config = cmd.DefaultConfig()
+		config.Defaults.State = "open"
+		config.Defaults.Limit = 30
+		body := "/lgtm"
+		config.Approval = cmd.ApprovalSettings{Body: &body, ExtraComments: []string{"/approve"}}
+		repoBody := "Approved for the release"
+		config.Repositories = []cmd.RepositoryConfig{
+			{Name: "owner/plain"},
+			{
+				Name:         "owner/busy",
+				State:        "all",
+				Limit:        100,
+				Sort:         "oldest",
+				AuthorFilter: []string{"alice", "bob"},
+				Template:     []string{".tekton/custom.yaml"},
+				Approval:     &cmd.ApprovalSettings{Body: &repoBody, Event: cmd.ReviewEventComment},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+		cmd.ApplyConfigDefaultsTest(cmd.DefaultConfig())
+	})
+
+	It("should list a repository with its own state, limit and sort order", func() {
+		Expect(cmd.UseListFlagsTest(nil, false)).To(Succeed())
+		cmd.ApplyConfigDefaultsTest(config)
+
+		state, limit, sortBy := cmd.RepositoryListSettingsTest("owner", "busy")
+		Expect(state).To(Equal("all"))
+		Expect(limit).To(Equal(100))
+		Expect(sortBy).To(Equal("oldest"))
+
+		state, limit, sortBy = cmd.RepositoryListSettingsTest("owner", "plain")
+		Expect(state).To(Equal("open"))
+		Expect(limit).To(Equal(30))
+		Expect(sortBy).To(BeEmpty())
+	})
+
+	It("should let the flags win over the settings of a repository", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--state", "closed", "--limit", "5", "--sort-by", "number"}, false)).To(Succeed())
+		cmd.ApplyConfigDefaultsTest(config)
+
+		state, limit, sortBy := cmd.RepositoryListSettingsTest("owner", "busy")
+		Expect(state).To(Equal("closed"))
+		Expect(limit).To(Equal(5))
+		Expect(sortBy).To(Equal("number"))
+	})
+
+	It("should let --all win over the limit of a repository", func() {
+		Expect(cmd.UseListFlagsTest([]string{"--all"}, false)).To(Succeed())
+		cmd.ApplyConfigDefaultsTest(config)
+
+		_, limit, _ := cmd.RepositoryListSettingsTest("owner", "busy")
+		Expect(limit).To(Equal(0))
+	})
+
+	It("should look for the Tekton pipelines of a repository by its patterns", func() {
+		config.Repositories[1].TektonPatterns = []string{".tekton/*-build.yaml"}
+		cmd.ApplyConfigDefaultsTest(config)
+		mockClient := cmd.NewMockRESTClient()
+		mockClient.AddResponse("files", 200, []cmd.PRFile{{Filename: ".tekton/app-build.yaml"}})
+
+		onlyTekton, tektonFiles, err := cmd.CheckTektonFilesDetailedTest(mockClient, "owner", "busy", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(onlyTekton).To(BeTrue())
+		Expect(tektonFiles).To(Equal([]string{".tekton/app-build.yaml"}))
+
+		onlyTekton, _, err = cmd.CheckTektonFilesDetailedTest(mockClient, "owner", "plain", 1)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(onlyTekton).To(BeFalse())
+	})
+
+	It("should filter the authors of a repository unless --author is given", func() {
+		Expect(cmd.QueueAuthorsTest(config, "owner/busy", nil, false)).To(Equal([]string{"alice", "bob"}))
+		Expect(cmd.QueueAuthorsTest(config, "owner/busy", []string{"carol"}, false)).To(Equal([]string{"carol"}))
+		Expect(cmd.QueueAuthorsTest(config, "owner/plain", nil, false)).To(BeEmpty())
+	})
+
+	It("should replace the approval settings a repository sets, field by field", func() {
+		approval := config.ApprovalFor("owner/busy")
+		Expect(approval.ReviewBody()).To(Equal("Approved for the release"))
+		Expect(approval.ReviewEvent()).To(Equal(cmd.ReviewEventComment))
+		Expect(approval.ExtraComments).To(Equal([]string{"/approve"}))
+
+		Expect(config.ApprovalFor("owner/plain").ReviewBody()).To(Equal("/lgtm"))
+		Expect(config.ApprovalFor("").ReviewEvent()).To(Equal(cmd.ReviewEventApprove))
+	})
+
+	It("should use the template of a repository over the template of every repository", func() {
+		config.Konflux.Template = []string{".tekton/{component}-push.yaml"}
+		Expect(config.AdoptionTemplateFor("owner/busy")).To(Equal([]string{".tekton/custom.yaml"}))
+		Expect(config.AdoptionTemplateFor("owner/plain")).To(Equal([]string{".tekton/{component}-push.yaml"}))
+	})
+
+	It("should report invalid settings of a repository", func() {
+		Expect(cmd.ValidateConfigDataTest(`repositories:
+  - name: owner/repo
+    state: merged
+    sort: random
+    approval:
+      event: MERGE
+`)).To(Equal([]string{
+			`line 3: invalid state "merged" of owner/repo, must be one of: open, closed, all`,
+			`line 4: invalid sort order "random", must be one of: newest, oldest, updated, number, priority`,
+			`line 6: invalid review event "MERGE", must be one of: APPROVE, COMMENT`,
+		}))
+	})
+})
@@ -0,0 +1,193 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// The states of a required check on the head of a PR
+const (
+	requiredCheckPassed  = "passed"
+	requiredCheckFailing = "failing"
+	requiredCheckPending = "pending"
+	requiredCheckMissing = "missing"
+)
+
+// requiredCheck is a check the branch protection of a PR's base requires and its state on the PR's head
+type requiredCheck struct {
+	Name  string
+	State string
+}
+
+var (
+	requiredContextsMutex sync.Mutex
+	// requiredContexts maps "owner/repo@branch" to the checks the branch protection requires, fetched once per run
+	requiredContexts = map[string][]string{}
+)
+
+// fetchRequiredContexts returns the checks the branch protection of a branch requires, nothing when the branch
+// isn't protected or requires no checks. The branch, unlike its protection, can be read without admin rights.
+func fetchRequiredContexts(client RESTClientInterface, owner, repo, branch string) ([]string, error) {
+	key := fmt.Sprintf("%s/%s@%s", owner, repo, branch)
+	requiredContextsMutex.Lock()
+	defer requiredContextsMutex.Unlock()
+	if contexts, ok := requiredContexts[key]; ok {
+		return contexts, nil
+	}
+
+	var info BranchInfo
+	path := fmt.Sprintf("repos/%s/%s/branches/%s", owner, repo, url.PathEscape(branch))
+	if err := client.DoWithContext(context.Background(), http.MethodGet, path, nil, &info); err != nil {
+		return nil, fmt.Errorf("failed to fetch branch %s: %w", branch, err)
+	}
+	contexts := info.Protection.RequiredStatusChecks.Contexts
+	requiredContexts[key] = contexts
+	return contexts, nil
+}
+
+// requiredCheckStates finds the state of each required check among the check runs and status checks of a commit.
+// Skipped and neutral check runs count as passed, as they do for GitHub.
+func requiredCheckStates(required []string, checkRuns []CheckRun, statusChecks []StatusCheck) []requiredCheck {
+	states := make([]requiredCheck, 0, len(required))
+	for _, name := range required {
+		state := requiredCheckMissing
+		for _, checkRun := range checkRuns {
+			if checkRun.Name != name {
+				continue
+			}
+			switch {
+			case checkRunFailed(checkRun):
+				state = requiredCheckFailing
+			case checkRun.Status != "completed":
+				state = requiredCheckPending
+			default:
+				state = requiredCheckPassed
+			}
+			break
+		}
+		if state == requiredCheckMissing {
+			for _, statusCheck := range statusChecks {
+				if statusCheck.Context != name {
+					continue
+				}
+				switch {
+				case statusCheckFailed(statusCheck):
+					state = requiredCheckFailing
+				case statusCheck.State == "success":
+					state = requiredCheckPassed
+				default:
+					state = requiredCheckPending
+				}
+				break
+			}
+		}
+		states = append(states, requiredCheck{Name: name, State: state})
+	}
+	return states
+}
+
+// describe explains how a required check that didn't pass holds a PR back
+func (c requiredCheck) describe() string {
+	switch c.State {
+	case requiredCheckFailing:
+		return fmt.Sprintf("required check %s failed", c.Name)
+	case requiredCheckMissing:
+		return fmt.Sprintf("waiting on required check %s (not reported yet)", c.Name)
+	default:
+		return fmt.Sprintf("waiting on required check %s", c.Name)
+	}
+}
+
+// icon returns the icon of the state of a required check
+func (c requiredCheck) icon() string {
+	switch c.State {
+	case requiredCheckPassed:
+		return "✅"
+	case requiredCheckFailing:
+		return "❌"
+	case requiredCheckPending:
+		return "🟡"
+	default:
+		return "⏳"
+	}
+}
+
+// blockedReasons explains why a blocked PR can't be merged: its required checks that didn't pass and a missing
+// approval. Without either, another protection rule holds it back.
+func blockedReasons(checks []requiredCheck, reviewed bool) []string {
+	var reasons []string
+	for _, check := range checks {
+		if check.State != requiredCheckPassed {
+			reasons = append(reasons, check.describe())
+		}
+	}
+	if !reviewed {
+		reasons = append(reasons, "missing approval")
+	}
+	if len(reasons) == 0 {
+		reasons = append(reasons, "another branch protection rule, such as required reviewers or resolved conversations")
+	}
+	return reasons
+}
+
+// prRequiredChecks returns the state of the checks the base of a PR requires on its head
+func prRequiredChecks(client RESTClientInterface, owner, repo string, pr PullRequest) ([]requiredCheck, error) {
+	if pr.Base.Ref == "" {
+		return nil, nil
+	}
+	required, err := fetchRequiredContexts(client, owner, repo, pr.Base.Ref)
+	if err != nil || len(required) == 0 {
+		return nil, err
+	}
+	checkRuns, statusChecks, err := fetchChecks(client, owner, repo, pr.Head.SHA)
+	if err != nil {
+		return nil, err
+	}
+	return requiredCheckStates(required, checkRuns, statusChecks), nil
+}
+
+// explainBlocked describes why a blocked PR can't be merged, falling back to the possible reasons when its
+// required checks can't be looked at
+func explainBlocked(client RESTClientInterface, owner, repo string, pr PullRequest) string {
+	checks, err := prRequiredChecks(client, owner, repo, pr)
+	if err != nil {
+		logger.Debug("Could not check the required checks", "repo", owner+"/"+repo, "pr", pr.Number, "error", err)
+		return "PR is blocked from merging (failed checks, missing reviews, etc.)"
+	}
+	reviewed := isReviewed(client, owner, repo, pr.Number, pr.Labels)
+	return strings.Join(blockedReasons(checks, reviewed), ", ")
+}
+
+// displayRequiredChecks lists the checks the base of a PR requires with their state on its head, for the
+// detailed check views. Nothing is shown when the base requires no checks.
+func displayRequiredChecks(client RESTClientInterface, owner, repo string, pr PullRequest, checkRuns []CheckRun, statusChecks []StatusCheck) {
+	if pr.Base.Ref == "" {
+		return
+	}
+	required, err := fetchRequiredContexts(client, owner, repo, pr.Base.Ref)
+	if err != nil {
+		logger.Debug("Could not check the required checks", "repo", owner+"/"+repo, "branch", pr.Base.Ref, "error", err)
+		return
+	}
+	if len(required) == 0 {
+		return
+	}
+
+	streams.Printf("\n🔒 Required by %s:\n", pr.Base.Ref)
+	waiting := 0
+	for _, check := range requiredCheckStates(required, checkRuns, statusChecks) {
+		if check.State == requiredCheckPassed {
+			streams.Printf("   %s %s\n", check.icon(), check.Name)
+			continue
+		}
+		waiting++
+		streams.Printf("   %s %s: %s\n", check.icon(), check.Name, check.State)
+	}
+	if waiting > 0 {
+		streams.Printf("   Merging waits on %d of %d required check(s)\n", waiting, len(required))
+	}
+}
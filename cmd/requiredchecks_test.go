@@ -0,0 +1,100 @@
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Required checks", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		pr         cmd.PullRequest
+	)
+
+	protectMain := func(required ...string) {
+		branch := cmd.BranchInfo{Name: "main"}
+		branch.Protection.RequiredStatusChecks.Contexts = required
+		mockClient.AddResponse("repos/owner/repo/branches/main", 200, branch)
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+		cmd.ResetBaseStatusesTest()
+
+		pr = cmd.PullRequest{Number: 1}
+		pr.Head.SHA = "sha1"
+		pr.Base.Ref = "main"
+		mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+			{Name: "unit", Status: "completed", Conclusion: "success"},
+			{Name: "app-on-pull-request", Status: "completed", Conclusion: "failure"},
+			{Name: "lint", Status: "completed", Conclusion: "skipped"},
+		}})
+		mockClient.AddResponse("repos/owner/repo/commits/sha1/status", 200, map[string]any{"statuses": []cmd.StatusCheck{
+			{Context: "ci/prow/e2e", State: "pending"},
+		}})
+		mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{})
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+		cmd.ResetBaseStatusesTest()
+	})
+
+	It("should list the required checks with their state in the detailed check view", func() {
+		protectMain("unit", "lint", "app-on-pull-request", "ci/prow/e2e", "ci/prow/images")
+
+		cmd.DisplayDetailedPRCheckStatusTest(mockClient, "owner", "repo", pr)
+
+		output := out.String()
+		Expect(output).To(ContainSubstring("🔒 Required by main:\n"))
+		Expect(output).To(ContainSubstring("   ✅ unit\n"))
+		Expect(output).To(ContainSubstring("   ✅ lint\n"))
+		Expect(output).To(ContainSubstring("   ❌ app-on-pull-request: failing\n"))
+		Expect(output).To(ContainSubstring("   🟡 ci/prow/e2e: pending\n"))
+		Expect(output).To(ContainSubstring("   ⏳ ci/prow/images: missing\n"))
+		Expect(output).To(ContainSubstring("Merging waits on 3 of 5 required check(s)"))
+	})
+
+	It("should not show required checks when the base requires none", func() {
+		protectMain()
+
+		cmd.DisplayDetailedPRCheckStatusTest(mockClient, "owner", "repo", pr)
+		Expect(out.String()).NotTo(ContainSubstring("Required by"))
+	})
+
+	It("should tell waiting on required checks from a missing approval", func() {
+		protectMain("unit", "app-on-pull-request", "ci/prow/e2e")
+		Expect(cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)).To(Equal(
+			"required check app-on-pull-request failed, waiting on required check ci/prow/e2e, missing approval"))
+
+		pr.Labels = []cmd.Label{{Name: "approved"}}
+		Expect(cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)).To(Equal(
+			"required check app-on-pull-request failed, waiting on required check ci/prow/e2e"))
+	})
+
+	It("should blame a missing approval when the required checks passed", func() {
+		protectMain("unit")
+		Expect(cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)).To(Equal("missing approval"))
+
+		pr.Labels = []cmd.Label{{Name: "lgtm"}}
+		Expect(cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)).To(ContainSubstring("another branch protection rule"))
+	})
+
+	It("should fetch the required checks of a branch once per run", func() {
+		protectMain("unit")
+		cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)
+		cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)
+		Expect(mockClient.GetRequestCount("repos/owner/repo/branches/main")).To(Equal(1))
+	})
+
+	It("should fall back to the possible reasons when the branch can't be read", func() {
+		Expect(cmd.ExplainBlockedTest(mockClient, "owner", "repo", pr)).To(Equal("PR is blocked from merging (failed checks, missing reviews, etc.)"))
+	})
+})
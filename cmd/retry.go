@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"math/rand/v2"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+const (
+	// defaultAPIRetries is how often a request that failed transiently is retried when neither --retries nor
+	// the config say otherwise
+	defaultAPIRetries = 3
+	// retryBaseDelay is the backoff before the first retry, doubled for each one after it
+	retryBaseDelay = time.Second
+	// maxRetryDelay caps the backoff between two attempts
+	maxRetryDelay = 30 * time.Second
+)
+
+var (
+	// apiRetries is how often a request that failed transiently is retried (--retries)
+	apiRetries = defaultAPIRetries
+	// configuredRetries is rate_limit.retries of the config of the run
+	configuredRetries = defaultAPIRetries
+)
+
+// retryCount returns how often requests that failed transiently are retried: --retries when given, on the
+// command line or in the environment, else the config
+func retryCount() int {
+	if RootCmd.PersistentFlags().Changed("retries") {
+		return apiRetries
+	}
+	hostsMutex.RLock()
+	defer hostsMutex.RUnlock()
+	return configuredRetries
+}
+
+// isTransientError reports whether a request failed in a way that may not happen again: a server error, a
+// secondary rate limit GitHub didn't say how long to wait for, or a network error. Rate limits that say when to
+// retry are left to the rate limiter, and cancelled requests aren't retried.
+func isTransientError(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) {
+		return false
+	}
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		if httpErr.StatusCode >= http.StatusInternalServerError {
+			return true
+		}
+		if httpErr.StatusCode != http.StatusForbidden {
+			return false
+		}
+		if httpErr.Headers.Get("Retry-After") != "" || httpErr.Headers.Get("X-RateLimit-Remaining") == "0" {
+			return false
+		}
+		return isSecondaryRateLimit(httpErr)
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// isSecondaryRateLimit reports whether GitHub turned a request away for going over a secondary rate limit
+func isSecondaryRateLimit(httpErr *api.HTTPError) bool {
+	return httpErr.StatusCode == http.StatusForbidden && strings.Contains(strings.ToLower(httpErr.Message), "secondary rate limit")
+}
+
+// isIdempotent reports whether sending a request again does no more than sending it once, so it can be retried
+// whatever became of the first attempt
+func isIdempotent(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead
+}
+
+// neverReachedServer reports whether a request failed before GitHub acted on it: no connection could be made, or
+// GitHub turned it away with a secondary rate limit. A write that failed any other way, such as with a server
+// error or a lost response, may have gone through, and sending it again could post a review or comment twice.
+func neverReachedServer(err error) bool {
+	var httpErr *api.HTTPError
+	if errors.As(err, &httpErr) {
+		return isSecondaryRateLimit(httpErr)
+	}
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return true
+	}
+	var opErr *net.OpError
+	return errors.As(err, &opErr) && opErr.Op == "dial"
+}
+
+// retryBackoff returns the delay before retry number attempt (from 0): the base delay doubled for each retry,
+// capped, then jittered to between half and all of it so that concurrent requests don't retry in step
+func retryBackoff(attempt int) time.Duration {
+	delay := retryBaseDelay << attempt
+	if delay <= 0 || delay > maxRetryDelay {
+		delay = maxRetryDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// retryRESTClient retries requests that failed transiently, backing off exponentially between attempts. GET and
+// HEAD requests are retried on any transient failure, other requests only when they never reached GitHub. It
+// sits outside the per-request timeout so that each attempt gets its own.
+type retryRESTClient struct {
+	RESTClientInterface
+	retries int
+	backoff func(attempt int) time.Duration
+	sleep   func(ctx context.Context, d time.Duration) error
+}
+
+// withRetries wraps a client so requests that failed transiently are retried up to retries times (0 disables
+// retrying)
+func withRetries(client RESTClientInterface, retries int) RESTClientInterface {
+	if retries <= 0 {
+		return client
+	}
+	return &retryRESTClient{RESTClientInterface: client, retries: retries, backoff: retryBackoff, sleep: sleepContext}
+}
+
+// Get performs a GET request, retrying transient failures
+func (c *retryRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post performs a POST request, retrying transient failures
+func (c *retryRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request, retrying transient failures
+func (c *retryRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request, retrying transient failures
+func (c *retryRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request, retrying transient failures
+func (c *retryRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do performs a request, retrying transient failures
+func (c *retryRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext performs a request, retrying it after a backoff while it fails transiently, the caller's context
+// allows and, for requests that change something, it didn't reach GitHub
+func (c *retryRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	// Buffer the body so it can be sent again on retry
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = io.ReadAll(body); err != nil {
+			return err
+		}
+	}
+
+	for attempt := 0; ; attempt++ {
+		var reqBody io.Reader
+		if payload != nil {
+			reqBody = bytes.NewReader(payload)
+		}
+		err := c.RESTClientInterface.DoWithContext(ctx, method, path, reqBody, response)
+		if err == nil || attempt >= c.retries || ctx.Err() != nil || !isTransientError(err) {
+			return err
+		}
+		if !isIdempotent(method) && !neverReachedServer(err) {
+			return err
+		}
+
+		delay := c.backoff(attempt)
+		logger.InfoContext(ctx, "Transient API failure, retrying", "method", method, "path", path,
+			"error", err, "delay", delay.Round(time.Millisecond), "attempt", attempt+1, "retries", c.retries)
+		if err := c.sleep(ctx, delay); err != nil {
+			return err
+		}
+	}
+}
+
+func init() {
+	RootCmd.PersistentFlags().IntVar(&apiRetries, "retries", defaultAPIRetries, "How often to retry API requests failing with a server, secondary rate limit or network error, backing off exponentially; writes are only retried when they didn't reach GitHub; default from rate_limit.retries, 0 disables retrying")
+}
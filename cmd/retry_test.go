@@ -0,0 +1,168 @@
+package cmd_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// flakyClient fails its first requests with an error before answering from the mock
+type flakyClient struct {
+	*cmd.MockRESTClient
+	failures int
+	err      error
+}
+
+func (c *flakyClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	if c.failures > 0 {
+		c.failures--
+		_, _ = c.MockRESTClient.Request(method, path, body)
+		return c.err
+	}
+	return c.MockRESTClient.DoWithContext(ctx, method, path, body, response)
+}
+
+var _ = Describe("Retrying transient failures", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		sleeps     []time.Duration
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1})
+		sleeps = nil
+	})
+
+	retrying := func(client cmd.RESTClientInterface, retries int) cmd.RESTClientInterface {
+		return cmd.WithRetriesTest(client, retries, func(d time.Duration) {
+			sleeps = append(sleeps, d)
+		})
+	}
+
+	It("should retry a server error until the request succeeds", func() {
+		flaky := &flakyClient{MockRESTClient: mockClient, failures: 2, err: &api.HTTPError{StatusCode: http.StatusBadGateway}}
+
+		var pr cmd.PullRequest
+		Expect(retrying(flaky, 3).Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+		Expect(pr.Number).To(Equal(1))
+		Expect(sleeps).To(HaveLen(2))
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/1")).To(Equal(3))
+	})
+
+	It("should give up after the configured number of retries", func() {
+		flaky := &flakyClient{MockRESTClient: mockClient, failures: 10, err: &api.HTTPError{StatusCode: http.StatusServiceUnavailable}}
+
+		var pr cmd.PullRequest
+		err := retrying(flaky, 2).Get("repos/owner/repo/pulls/1", &pr)
+		var httpErr *api.HTTPError
+		Expect(errors.As(err, &httpErr)).To(BeTrue())
+		Expect(httpErr.StatusCode).To(Equal(http.StatusServiceUnavailable))
+		Expect(sleeps).To(HaveLen(2))
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/1")).To(Equal(3))
+	})
+
+	It("should not retry when retries are disabled", func() {
+		flaky := &flakyClient{MockRESTClient: mockClient, failures: 1, err: &api.HTTPError{StatusCode: http.StatusInternalServerError}}
+
+		var pr cmd.PullRequest
+		Expect(retrying(flaky, 0).DoWithContext(context.Background(), http.MethodGet, "repos/owner/repo/pulls/1", nil, &pr)).NotTo(Succeed())
+		Expect(sleeps).To(BeEmpty())
+	})
+
+	It("should not retry errors that will happen again", func() {
+		mockClient.AddErrorResponse("repos/owner/repo/pulls/2", &api.HTTPError{StatusCode: http.StatusNotFound})
+
+		var pr cmd.PullRequest
+		Expect(retrying(mockClient, 3).Get("repos/owner/repo/pulls/2", &pr)).NotTo(Succeed())
+		Expect(sleeps).To(BeEmpty())
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/2")).To(Equal(1))
+	})
+
+	It("should stop retrying once the caller's context is done", func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		flaky := &flakyClient{MockRESTClient: mockClient, failures: 10, err: &api.HTTPError{StatusCode: http.StatusBadGateway}}
+		client := cmd.WithRetriesTest(flaky, 3, func(time.Duration) { cancel() })
+
+		var pr cmd.PullRequest
+		Expect(client.DoWithContext(ctx, http.MethodGet, "repos/owner/repo/pulls/1", nil, &pr)).NotTo(Succeed())
+		Expect(mockClient.GetRequestCount("repos/owner/repo/pulls/1")).To(Equal(2))
+	})
+
+	It("should resend the request body of a write that never reached GitHub", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/5/comments", 201, nil)
+		flaky := &flakyClient{MockRESTClient: mockClient, failures: 1, err: &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}}
+
+		Expect(retrying(flaky, 3).Post("repos/owner/repo/issues/5/comments", bytes.NewBufferString(`{"body":"/lgtm"}`), nil)).To(Succeed())
+		Expect(mockClient.Requests).To(HaveLen(2))
+		for _, req := range mockClient.Requests {
+			Expect(req.Body).To(Equal(`{"body":"/lgtm"}`))
+		}
+	})
+
+	It("should not retry a write that may have gone through", func() {
+		mockClient.AddResponse("repos/owner/repo/issues/5/comments", 201, nil)
+		for _, err := range []error{io.ErrUnexpectedEOF, context.DeadlineExceeded, &api.HTTPError{StatusCode: http.StatusBadGateway}} {
+			mockClient.Requests = nil
+			flaky := &flakyClient{MockRESTClient: mockClient, failures: 1, err: err}
+
+			Expect(retrying(flaky, 3).Post("repos/owner/repo/issues/5/comments", bytes.NewBufferString(`{"body":"/lgtm"}`), nil)).To(MatchError(err))
+			Expect(mockClient.Requests).To(HaveLen(1))
+		}
+		Expect(sleeps).To(BeEmpty())
+	})
+
+	It("should tell transient failures from lasting ones", func() {
+		retryAfter := http.Header{}
+		retryAfter.Set("Retry-After", "30")
+
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusInternalServerError})).To(BeTrue())
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusBadGateway})).To(BeTrue())
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusForbidden, Headers: http.Header{},
+			Message: "You have exceeded a secondary rate limit. Please wait a few minutes before you try again."})).To(BeTrue())
+		Expect(cmd.IsTransientErrorTest(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection reset by peer")})).To(BeTrue())
+		Expect(cmd.IsTransientErrorTest(io.ErrUnexpectedEOF)).To(BeTrue())
+		Expect(cmd.IsTransientErrorTest(context.DeadlineExceeded)).To(BeTrue())
+
+		// Rate limits that say when to retry are left to the rate limiter
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusForbidden, Headers: retryAfter,
+			Message: "You have exceeded a secondary rate limit."})).To(BeFalse())
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusForbidden, Headers: http.Header{},
+			Message: "Resource not accessible by integration"})).To(BeFalse())
+		Expect(cmd.IsTransientErrorTest(&api.HTTPError{StatusCode: http.StatusNotFound})).To(BeFalse())
+		Expect(cmd.IsTransientErrorTest(context.Canceled)).To(BeFalse())
+		Expect(cmd.IsTransientErrorTest(errors.New("invalid character"))).To(BeFalse())
+	})
+
+	It("should back off exponentially with jitter, up to a cap", func() {
+		for attempt, base := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second} {
+			delay := cmd.RetryBackoffTest(attempt)
+			Expect(delay).To(BeNumerically(">=", base/2))
+			Expect(delay).To(BeNumerically("<=", base))
+		}
+		Expect(cmd.RetryBackoffTest(20)).To(BeNumerically("<=", 30*time.Second))
+		Expect(cmd.RetryBackoffTest(100)).To(BeNumerically(">=", 15*time.Second))
+	})
+
+	It("should read the number of retries from the config", func() {
+		config := cmd.DefaultConfig()
+		Expect(config.APIRetries()).To(Equal(3))
+
+		retries := 0
+		config.RateLimit.Retries = &retries
+		Expect(config.APIRetries()).To(BeZero())
+
+		retries = -1
+		Expect(config.APIRetries()).To(Equal(3))
+	})
+})
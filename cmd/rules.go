@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"ghprs/pkg/ghprs"
+)
+
+// Actions a rule can take
+const (
+	RuleActionApprove = ghprs.RuleActionApprove
+	RuleActionHold    = ghprs.RuleActionHold
+	RuleActionSkip    = ghprs.RuleActionSkip
+	RuleActionLabel   = ghprs.RuleActionLabel
+)
+
+// rulesFailed counts the actions of --auto that failed in the running command
+var rulesFailed int
+
+// exitOnRuleFailures exits with status 1 when an action of --auto failed
+func exitOnRuleFailures() {
+	if rulesFailed > 0 {
+		os.Exit(1)
+	}
+}
+
+// applyRules decides what to do with each PR with the rules and does it without asking, printing the decision
+// audit trail of every PR. It returns the number of actions that failed.
+func applyRules(ctx context.Context, client RESTClientInterface, owner, repo string, pullRequests []PullRequest, rules []Rule, config ApprovalConfig) int {
+	streams.Printf("\n=== %s/%s: applying %d rule(s) to %d PR(s) ===\n", owner, repo, len(rules), len(pullRequests))
+	counts := make(map[string]int)
+	failed := 0
+	for _, pr := range pullRequests {
+		link := formatPRLink(owner, repo, pr.Number)
+		decision := ghprs.Decide(ctx, client, owner, repo, pr, rules, ghprs.DecideOptions{TektonPatterns: settingsOf(owner, repo).TektonPatterns})
+
+		streams.Printf("\n🤖 %s %s\n", link, pr.Title)
+		for _, line := range decision.Trail {
+			streams.Printf("   %s\n", line)
+		}
+		reason := decision.Reason(rules)
+		streams.Printf("   → %s (%s)\n", decision.Action, reason)
+		logger.Info("Rule decision", "repo", owner+"/"+repo, "pr", pr.Number, "action", decision.Action, "reason", reason)
+
+		// Rules run unattended, so drafts and held PRs are left alone and nobody is there to confirm CI or
+		// ownership changes by untrusted authors or routine PRs larger than max_changes
+		if decision.Action == RuleActionApprove {
+			var blocker string
+			switch {
+			case pr.Draft:
+				blocker = "draft"
+			case isOnHold(pr):
+				blocker = "on hold"
+			default:
+				blocker = batchApprovalBlocker(repoPR{Owner: owner, Repo: repo, Client: client, PR: pr}, config.TrustedAuthors, config.Review.MaxChanges)
+			}
+			if blocker != "" {
+				streams.Printf("   🛡️  Not approving: %s\n", blocker)
+				runArtifact.decide(owner, repo, pr, RuleActionSkip, blocker, time.Now())
+				counts[RuleActionSkip]++
+				continue
+			}
+			// Merging more PRs into a broken base branch is pointless
+			if status := redBase(config, client, owner, repo, pr); status != nil {
+				blocker := status.describe()
+				streams.Printf("   🔴 Not approving: %s\n", blocker)
+				runArtifact.decide(owner, repo, pr, RuleActionSkip, blocker, time.Now())
+				counts[RuleActionSkip]++
+				continue
+			}
+		}
+
+		var rule Rule
+		if decision.Rule >= 0 {
+			rule = rules[decision.Rule]
+		}
+		if err := applyRuleAction(client, owner, repo, pr, decision.Action, rule, reason, config); err != nil {
+			streams.Printf("   ❌ Failed to %s %s: %v\n", decision.Action, link, err)
+			failed++
+			continue
+		}
+		runArtifact.decide(owner, repo, pr, decision.Action, reason, time.Now())
+		counts[decision.Action]++
+	}
+
+	streams.Printf("\n📊 Approved: %d, held: %d, labelled: %d, skipped: %d, failed: %d\n",
+		counts[RuleActionApprove], counts[RuleActionHold], counts[RuleActionLabel], counts[RuleActionSkip], failed)
+	return failed
+}
+
+// applyRuleAction carries out the action a rule decided for a PR
+func applyRuleAction(client RESTClientInterface, owner, repo string, pr PullRequest, action string, rule Rule, reason string, config ApprovalConfig) error {
+	switch action {
+	case RuleActionApprove:
+		// Make sure nothing was pushed since the rules were evaluated, so unseen commits aren't approved
+		current, err := fetchPRDetails(withFreshData(context.Background()), client, owner, repo, pr.Number)
+		if err != nil {
+			return err
+		}
+		if current.Head.SHA != pr.Head.SHA {
+			return fmt.Errorf("new commits were pushed since the rules were evaluated")
+		}
+		return postApproval(client, owner, repo, pr, config.Review)
+	case RuleActionHold:
+		if isOnHold(pr) {
+			streams.Printf("   Already on hold\n")
+			return nil
+		}
+		return holdPR(client, owner, repo, pr.Number, "held by "+reason, time.Time{})
+	case RuleActionLabel:
+		if err := addLabels(client, owner, repo, pr.Number, rule.Labels); err != nil {
+			return err
+		}
+		streams.Printf("   ✓ Added the %s label(s)\n", strings.Join(rule.Labels, ", "))
+		return nil
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,170 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Rules", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		yes        = true
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	Describe("validating rules", func() {
+		It("should accept the known actions", func() {
+			Expect(cmd.ValidateRulesTest([]cmd.Rule{
+				{Name: "a", Action: cmd.RuleActionApprove},
+				{Name: "h", Action: cmd.RuleActionHold},
+				{Name: "s", Action: cmd.RuleActionSkip},
+				{Name: "l", Action: cmd.RuleActionLabel, Labels: []string{"ok-to-test"}},
+			})).To(Succeed())
+		})
+
+		It("should reject unknown actions and labelling without labels", func() {
+			Expect(cmd.ValidateRulesTest([]cmd.Rule{{Name: "merge it", Action: "merge"}})).To(MatchError(ContainSubstring(`rule "merge it": invalid action "merge"`)))
+			Expect(cmd.ValidateRulesTest([]cmd.Rule{{Action: cmd.RuleActionLabel}})).To(MatchError(ContainSubstring("rule #1: the label action needs labels")))
+		})
+	})
+
+	Describe("deciding", func() {
+		var pr cmd.PullRequest
+
+		BeforeEach(func() {
+			pr = cmd.PullRequest{Number: 1, Title: "Update tasks", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha1"}}
+			mockClient.AddResponse("repos/owner/repo/pulls/1/files?per_page=100&page=1", 200, []cmd.PRFile{
+				{Filename: ".tekton/app-pull-request.yaml"}, {Filename: ".tekton/app-push.yaml"},
+			})
+			mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CheckRunsResponse{
+				CheckRuns: []cmd.CheckRun{{Name: "build", Status: "completed", Conclusion: "success"}},
+			})
+			mockClient.AddResponse("repos/owner/repo/commits/sha1/status", 200, map[string]any{"statuses": []cmd.StatusCheck{}})
+		})
+
+		It("should pick the first rule whose conditions all hold", func() {
+			rules := []cmd.Rule{
+				{Name: "renovate", When: cmd.RuleConditions{Authors: []string{"renovate[bot]"}}, Action: cmd.RuleActionApprove},
+				{Name: "tekton", When: cmd.RuleConditions{TektonOnly: &yes, ChecksGreen: true, MaxFilesChanged: 2}, Action: cmd.RuleActionApprove},
+				{Name: "rest", Action: cmd.RuleActionHold},
+			}
+			action, rule := cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+			Expect(action).To(Equal(cmd.RuleActionApprove))
+			Expect(rule).To(Equal("tekton"))
+		})
+
+		It("should skip PRs no rule matches", func() {
+			rules := []cmd.Rule{{Name: "small", When: cmd.RuleConditions{MaxFilesChanged: 1}, Action: cmd.RuleActionApprove}}
+			action, rule := cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+			Expect(action).To(Equal(cmd.RuleActionSkip))
+			Expect(rule).To(BeEmpty())
+		})
+
+		It("should honour the label allow and deny lists", func() {
+			pr.Labels = []cmd.Label{{Name: "approved"}, {Name: "do-not-merge/hold"}}
+			rules := []cmd.Rule{
+				{Name: "denied", When: cmd.RuleConditions{DenyLabels: []string{"do-not-merge/hold"}}, Action: cmd.RuleActionApprove},
+				{Name: "allowed", When: cmd.RuleConditions{AllowLabels: []string{"approved"}}, Action: cmd.RuleActionLabel, Labels: []string{"x"}},
+			}
+			_, rule := cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+			Expect(rule).To(Equal("allowed"))
+		})
+
+		It("should not treat pending checks or migration warnings as passing", func() {
+			mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CheckRunsResponse{
+				CheckRuns: []cmd.CheckRun{{Name: "build", Status: "in_progress"}},
+			})
+			rules := []cmd.Rule{{Name: "green", When: cmd.RuleConditions{ChecksGreen: true}, Action: cmd.RuleActionApprove}}
+			action, _ := cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+			Expect(action).To(Equal(cmd.RuleActionSkip))
+
+			pr.Body = "⚠️ [migration] this update needs manual steps"
+			rules = []cmd.Rule{{Name: "safe", When: cmd.RuleConditions{NoMigrationWarning: true}, Action: cmd.RuleActionApprove}}
+			action, _ = cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+			Expect(action).To(Equal(cmd.RuleActionSkip))
+		})
+
+		It("should not treat cancelled or stale checks as passing", func() {
+			rules := []cmd.Rule{{Name: "green", When: cmd.RuleConditions{ChecksGreen: true}, Action: cmd.RuleActionApprove}}
+			for _, conclusion := range []string{"cancelled", "stale"} {
+				mockClient.AddResponse("repos/owner/repo/commits/sha1/check-runs", 200, cmd.CheckRunsResponse{
+					CheckRuns: []cmd.CheckRun{{Name: "lint", Status: "completed", Conclusion: "success"}, {Name: "build", Status: "completed", Conclusion: conclusion}},
+				})
+				action, _ := cmd.RuleDecisionTest(mockClient, "owner", "repo", pr, rules)
+				Expect(action).To(Equal(cmd.RuleActionSkip), conclusion)
+			}
+		})
+	})
+
+	Describe("applying rules", func() {
+		It("should act on each PR and print the audit trail", func() {
+			prs := []cmd.PullRequest{
+				{Number: 1, Title: "Update tasks", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha1"}},
+				{Number: 2, Title: "Update go", User: cmd.User{Login: "renovate[bot]"}, Head: cmd.Branch{SHA: "sha2"}},
+				{Number: 3, Title: "Big change", User: cmd.User{Login: "someone"}, Head: cmd.Branch{SHA: "sha3"}},
+			}
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, prs[0])
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/issues/2/comments", 201, map[string]any{})
+			mockClient.AddResponse("repos/owner/repo/issues/2/labels", 200, []cmd.Label{})
+			rules := []cmd.Rule{
+				{Name: "konflux", When: cmd.RuleConditions{Authors: []string{"red-hat-konflux[bot]"}}, Action: cmd.RuleActionApprove},
+				{Name: "renovate", When: cmd.RuleConditions{Authors: []string{"renovate[bot]"}}, Action: cmd.RuleActionHold},
+			}
+
+			Expect(cmd.ApplyRulesTest(mockClient, "owner", "repo", prs, rules)).To(BeZero())
+
+			var posted []string
+			for _, request := range mockClient.Requests {
+				if request.Method == "POST" {
+					posted = append(posted, request.URL)
+				}
+			}
+			Expect(posted).To(ContainElement("repos/owner/repo/pulls/1/reviews"))
+			Expect(posted).To(ContainElement("repos/owner/repo/issues/2/comments"))
+			Expect(posted).NotTo(ContainElement(ContainSubstring("/3/")))
+			Expect(out.String()).To(ContainSubstring(`rule "konflux": ✓ author red-hat-konflux[bot]`))
+			Expect(out.String()).To(ContainSubstring(`rule "konflux": ✗ author someone`))
+			Expect(out.String()).To(ContainSubstring("→ skip (no rule matched)"))
+			Expect(out.String()).To(ContainSubstring("Approved: 1, held: 1, labelled: 0, skipped: 1, failed: 0"))
+		})
+
+		It("should not approve a PR that changed since it was evaluated", func() {
+			pr := cmd.PullRequest{Number: 1, Title: "Update tasks", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha1"}}
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, Head: cmd.Branch{SHA: "sha2"}})
+			rules := []cmd.Rule{{Name: "all", Action: cmd.RuleActionApprove}}
+
+			Expect(cmd.ApplyRulesTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, rules)).To(Equal(1))
+			Expect(out.String()).To(ContainSubstring("new commits were pushed"))
+		})
+
+		It("should not approve drafts or PRs on hold", func() {
+			prs := []cmd.PullRequest{
+				{Number: 1, Title: "Update tasks", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha1"}, Draft: true},
+				{Number: 2, Title: "Update go", User: cmd.User{Login: "red-hat-konflux[bot]"}, Head: cmd.Branch{SHA: "sha2"}, Labels: []cmd.Label{{Name: "do-not-merge/hold"}}},
+			}
+			rules := []cmd.Rule{{Name: "all", Action: cmd.RuleActionApprove}}
+
+			Expect(cmd.ApplyRulesTest(mockClient, "owner", "repo", prs, rules)).To(BeZero())
+			Expect(mockClient.Requests).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("Not approving: draft"))
+			Expect(out.String()).To(ContainSubstring("Not approving: on hold"))
+			Expect(out.String()).To(ContainSubstring("Approved: 0, held: 0, labelled: 0, skipped: 2, failed: 0"))
+		})
+	})
+})
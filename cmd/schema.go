@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// schemaDefinition describes a document format that ghprs reads or writes
+type schemaDefinition struct {
+	title   string
+	tagName string // struct tag used for field names ("json" or "yaml")
+	root    reflect.Type
+}
+
+// schemaDefinitions is the registry of all published schemas, keyed by the name used on the command line
+var schemaDefinitions = map[string]schemaDefinition{
+	"config": {
+		title:   "ghprs configuration file",
+		tagName: "yaml",
+		root:    reflect.TypeOf(Config{}),
+	},
+	"pr-list": {
+		title:   "ghprs list/konflux output",
+		tagName: "json",
+		root:    reflect.TypeOf(PRListOutput{}),
+	},
+	"security-queue": {
+		title:   "ghprs security-queue output and report",
+		tagName: "json",
+		root:    reflect.TypeOf(SecurityQueueReport{}),
+	},
+	"snapshot-diff": {
+		title:   "ghprs diff-snapshots output",
+		tagName: "json",
+		root:    reflect.TypeOf(SnapshotDiff{}),
+	},
+	"stats": {
+		title:   "ghprs stats output",
+		tagName: "json",
+		root:    reflect.TypeOf(StatsOutput{}),
+	},
+	"pr-show": {
+		title:   "ghprs pr show output",
+		tagName: "json",
+		root:    reflect.TypeOf(PRDetail{}),
+	},
+	"history": {
+		title:   "ghprs history output",
+		tagName: "json",
+		root:    reflect.TypeOf([]AuditEntry{}),
+	},
+	"migrations": {
+		title:   "ghprs konflux migrations output",
+		tagName: "json",
+		root:    reflect.TypeOf([]MigrationPR{}),
+	},
+	"repositories": {
+		title:   "ghprs config list-repos output",
+		tagName: "json",
+		root:    reflect.TypeOf([]RepositoryConfig{}),
+	},
+}
+
+// schemaCmd prints JSON schemas for the config file and machine-readable outputs
+var schemaCmd = &cobra.Command{
+	Use:   "schema [name]",
+	Short: "Print JSON schemas for ghprs config and output formats",
+	Long: `Print the JSON schema for the ghprs configuration file and its JSON/YAML outputs.
+
+The output is deterministic so it can be checked in and diffed as formats evolve.
+Without a name, all schemas are printed in a single document keyed by name.
+
+Available schemas:
+  config         - the configuration file (~/.config/ghprs/config.yaml)
+  history        - the output of 'ghprs history --output json|yaml'
+  migrations     - the output of 'ghprs konflux migrations --output json|yaml'
+  pr-list        - the output of 'ghprs list/konflux --output json|yaml'
+  pr-show        - the output of 'ghprs pr show --output json|yaml'
+  repositories   - the output of 'ghprs config list-repos --output json|yaml'
+  security-queue - the output of 'ghprs security-queue --output json|yaml' and its --report
+  snapshot-diff  - the output of 'ghprs diff-snapshots --output json|yaml'
+  stats          - the output of 'ghprs stats --output json|yaml'
+
+Examples:
+  ghprs schema
+  ghprs schema config
+  ghprs schema pr-list > pr-list.schema.json`,
+	Args:      cobra.MaximumNArgs(1),
+	ValidArgs: SchemaNames(),
+	Run: func(cmd *cobra.Command, args []string) {
+		var data []byte
+		var err error
+		if len(args) == 0 {
+			data, err = GenerateAllSchemas()
+		} else {
+			data, err = GenerateSchema(args[0])
+		}
+		if err != nil {
+			fmt.Printf("Error generating schema: %v\n", err)
+			os.Exit(1)
+		}
+		fmt.Println(string(data))
+	},
+}
+
+// SchemaNames returns the sorted names of all available schemas
+func SchemaNames() []string {
+	names := make([]string, 0, len(schemaDefinitions))
+	for name := range schemaDefinitions {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// GenerateSchema returns the indented JSON schema for the named format
+func GenerateSchema(name string) ([]byte, error) {
+	schema, err := buildSchema(name)
+	if err != nil {
+		return nil, err
+	}
+	return json.MarshalIndent(schema, "", "  ")
+}
+
+// GenerateAllSchemas returns every schema in a single document keyed by name
+func GenerateAllSchemas() ([]byte, error) {
+	all := make(map[string]interface{})
+	for _, name := range SchemaNames() {
+		schema, err := buildSchema(name)
+		if err != nil {
+			return nil, err
+		}
+		all[name] = schema
+	}
+	return json.MarshalIndent(all, "", "  ")
+}
+
+func buildSchema(name string) (map[string]interface{}, error) {
+	def, ok := schemaDefinitions[name]
+	if !ok {
+		return nil, fmt.Errorf("unknown schema %q (available: %s)", name, strings.Join(SchemaNames(), ", "))
+	}
+
+	schema := schemaForType(def.root, def.tagName)
+	schema["$schema"] = "https://json-schema.org/draft/2020-12/schema"
+	schema["$id"] = fmt.Sprintf("https://github.com/tesshuflower/ghprs/schemas/%s.json", name)
+	schema["title"] = def.title
+	return schema, nil
+}
+
+// schemaForType builds a JSON schema fragment for a Go type.
+// Maps are used throughout so that encoding/json emits keys in sorted, stable order.
+func schemaForType(t reflect.Type, tagName string) map[string]interface{} {
+	if t == reflect.TypeOf(time.Time{}) {
+		return map[string]interface{}{"type": "string", "format": "date-time"}
+	}
+	switch t.Kind() {
+	case reflect.Ptr:
+		// Pointers are used for tri-state values, where nil means "unknown"
+		inner := schemaForType(t.Elem(), tagName)
+		if typ, ok := inner["type"].(string); ok {
+			inner["type"] = []string{typ, "null"}
+		}
+		return inner
+	case reflect.Struct:
+		properties := make(map[string]interface{})
+		var required []string
+		for i := 0; i < t.NumField(); i++ {
+			field := t.Field(i)
+			if !field.IsExported() {
+				continue
+			}
+			name, omitEmpty := schemaFieldName(field, tagName)
+			if name == "-" {
+				continue
+			}
+			// Embedded structs without a name of their own are inlined, as encoding/json does and ",inline" asks yaml to
+			if field.Anonymous && field.Type.Kind() == reflect.Struct && strings.Split(field.Tag.Get(tagName), ",")[0] == "" {
+				embedded := schemaForType(field.Type, tagName)
+				for key, value := range embedded["properties"].(map[string]interface{}) {
+					properties[key] = value
+				}
+				if embeddedRequired, ok := embedded["required"].([]string); ok {
+					required = append(required, embeddedRequired...)
+				}
+				continue
+			}
+			properties[name] = schemaForType(field.Type, tagName)
+			if !omitEmpty && field.Type.Kind() != reflect.Ptr {
+				required = append(required, name)
+			}
+		}
+		schema := map[string]interface{}{
+			"type":       "object",
+			"properties": properties,
+		}
+		if len(required) > 0 {
+			sort.Strings(required)
+			schema["required"] = required
+		}
+		return schema
+	case reflect.Slice, reflect.Array:
+		return map[string]interface{}{
+			"type":  "array",
+			"items": schemaForType(t.Elem(), tagName),
+		}
+	case reflect.Map:
+		return map[string]interface{}{
+			"type":                 "object",
+			"additionalProperties": schemaForType(t.Elem(), tagName),
+		}
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]interface{}{"type": "number"}
+	default:
+		return map[string]interface{}{}
+	}
+}
+
+// schemaFieldName returns the serialized name of a struct field and whether it is omitempty
+func schemaFieldName(field reflect.StructField, tagName string) (string, bool) {
+	// yaml.v3 lowercases untagged field names, encoding/json keeps them as-is
+	defaultName := field.Name
+	if tagName == "yaml" {
+		defaultName = strings.ToLower(field.Name)
+	}
+
+	tag := field.Tag.Get(tagName)
+	if tag == "" {
+		return defaultName, false
+	}
+	parts := strings.Split(tag, ",")
+	name := parts[0]
+	if name == "" {
+		name = defaultName
+	}
+	omitEmpty := false
+	for _, opt := range parts[1:] {
+		if opt == "omitempty" {
+			omitEmpty = true
+		}
+	}
+	return name, omitEmpty
+}
+
+func init() {
+	RootCmd.AddCommand(schemaCmd)
+}
@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"encoding/json"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Schema Generation", func() {
+	It("should list the available schemas in sorted order", func() {
+		Expect(cmd.SchemaNames()).To(Equal([]string{"config", "history", "migrations", "pr-list", "pr-show", "repositories", "security-queue", "snapshot-diff", "stats"}))
+	})
+
+	It("should reject unknown schema names", func() {
+		_, err := cmd.GenerateSchema("does-not-exist")
+		Expect(err).To(HaveOccurred())
+		Expect(err.Error()).To(ContainSubstring("unknown schema"))
+	})
+
+	It("should generate identical output on every call", func() {
+		first, err := cmd.GenerateAllSchemas()
+		Expect(err).NotTo(HaveOccurred())
+		for i := 0; i < 5; i++ {
+			again, err := cmd.GenerateAllSchemas()
+			Expect(err).NotTo(HaveOccurred())
+			Expect(again).To(Equal(first))
+		}
+	})
+
+	Describe("config schema", func() {
+		var schema map[string]interface{}
+
+		BeforeEach(func() {
+			data, err := cmd.GenerateSchema("config")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(json.Unmarshal(data, &schema)).To(Succeed())
+		})
+
+		It("should use yaml field names", func() {
+			properties := schema["properties"].(map[string]interface{})
+			Expect(properties).To(HaveKey("repositories"))
+			Expect(properties).To(HaveKey("defaults"))
+
+			repos := properties["repositories"].(map[string]interface{})
+			Expect(repos["type"]).To(Equal("array"))
+			item := repos["items"].(map[string]interface{})
+			itemProps := item["properties"].(map[string]interface{})
+			Expect(itemProps).To(HaveKey("name"))
+			Expect(itemProps).To(HaveKey("konflux"))
+			Expect(item["required"]).To(ConsistOf("name"))
+		})
+
+		It("should include schema metadata", func() {
+			Expect(schema["$schema"]).To(ContainSubstring("json-schema.org"))
+			Expect(schema["title"]).To(Equal("ghprs configuration file"))
+		})
+	})
+
+	Describe("pr-list schema", func() {
+		var row map[string]interface{}
+
+		BeforeEach(func() {
+			data, err := cmd.GenerateSchema("pr-list")
+			Expect(err).NotTo(HaveOccurred())
+			var schema map[string]interface{}
+			Expect(json.Unmarshal(data, &schema)).To(Succeed())
+
+			repos := schema["properties"].(map[string]interface{})["repositories"].(map[string]interface{})
+			prs := repos["items"].(map[string]interface{})["properties"].(map[string]interface{})["pullRequests"].(map[string]interface{})
+			row = prs["items"].(map[string]interface{})
+		})
+
+		It("should describe unknown tri-state values as nullable", func() {
+			props := row["properties"].(map[string]interface{})
+			reviewed := props["reviewed"].(map[string]interface{})
+			Expect(reviewed["type"]).To(ConsistOf("boolean", "null"))
+		})
+
+		It("should mark non-pointer fields as required", func() {
+			Expect(row["required"]).To(ContainElements("number", "title", "author", "state"))
+			Expect(row["required"]).NotTo(ContainElement("reviewed"))
+			Expect(row["required"]).NotTo(ContainElement("tektonOnly"))
+		})
+	})
+
+	Describe("pr-show schema", func() {
+		It("should inline the fields of the row", func() {
+			data, err := cmd.GenerateSchema("pr-show")
+			Expect(err).NotTo(HaveOccurred())
+			var schema map[string]interface{}
+			Expect(json.Unmarshal(data, &schema)).To(Succeed())
+
+			props := schema["properties"].(map[string]interface{})
+			Expect(props).To(HaveKey("number"))
+			Expect(props).To(HaveKey("checkResults"))
+			Expect(props).NotTo(HaveKey("PRRow"))
+			Expect(schema["required"]).To(ContainElements("number", "title", "labels"))
+		})
+	})
+
+	It("should describe times as date-time strings", func() {
+		data, err := cmd.GenerateSchema("history")
+		Expect(err).NotTo(HaveOccurred())
+		var schema map[string]interface{}
+		Expect(json.Unmarshal(data, &schema)).To(Succeed())
+
+		Expect(schema["type"]).To(Equal("array"))
+		entry := schema["items"].(map[string]interface{})["properties"].(map[string]interface{})
+		Expect(entry["time"]).To(Equal(map[string]interface{}{"type": "string", "format": "date-time"}))
+	})
+})
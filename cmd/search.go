@@ -0,0 +1,185 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"net/url"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// maxSearchResults is the most results the GitHub search API returns for a query
+const maxSearchResults = 1000
+
+// searchResultPage is a page of results of the issue search API
+type searchResultPage struct {
+	TotalCount        int          `json:"total_count"`
+	IncompleteResults bool         `json:"incomplete_results"`
+	Items             []searchItem `json:"items"`
+}
+
+// searchItem is the part of a search result that locates the PR it found
+type searchItem struct {
+	Number        int    `json:"number"`
+	RepositoryURL string `json:"repository_url"`
+}
+
+// repository returns the "owner/repo" of the repository the result lives in, from its API URL
+func (i searchItem) repository() (string, bool) {
+	parts := strings.Split(strings.TrimSuffix(i.RepositoryURL, "/"), "/")
+	if len(parts) < 2 {
+		return "", false
+	}
+	owner, repo := parts[len(parts)-2], parts[len(parts)-1]
+	if owner == "" || repo == "" {
+		return "", false
+	}
+	return owner + "/" + repo, true
+}
+
+// searchQualifiers completes a query with the qualifiers it leaves out: is:pr, since only PRs are listed,
+// and the PR state of --state
+func searchQualifiers(query, state string) string {
+	has := func(qualifiers ...string) bool {
+		for _, field := range strings.Fields(strings.ToLower(query)) {
+			for _, qualifier := range qualifiers {
+				if strings.HasPrefix(field, qualifier) {
+					return true
+				}
+			}
+		}
+		return false
+	}
+
+	qualified := strings.TrimSpace(query)
+	if !has("is:pr", "type:pr") {
+		qualified = "is:pr " + qualified
+	}
+	if !has("is:open", "is:closed", "is:merged", "is:unmerged", "state:") {
+		switch state {
+		case "open", "closed":
+			qualified += " is:" + state
+		}
+	}
+	return qualified
+}
+
+// searchPullRequests runs a search and groups the PRs found by repository, in the order the search returned
+// them. maxPRs of 0 returns every result the search API gives, at most maxSearchResults.
+func searchPullRequests(client RESTClientInterface, query string, maxPRs int) ([]string, map[string][]int, error) {
+	if maxPRs <= 0 || maxPRs > maxSearchResults {
+		maxPRs = maxSearchResults
+	}
+	perPage := min(maxPRs, maxPerPage)
+
+	var repositories []string
+	numbers := make(map[string][]int)
+	found := 0
+	for page := 1; found < maxPRs; page++ {
+		var results searchResultPage
+		path := fmt.Sprintf("search/issues?q=%s&per_page=%d&page=%d", url.QueryEscape(query), perPage, page)
+		if err := client.Get(path, &results); err != nil {
+			return nil, nil, err
+		}
+		if results.IncompleteResults {
+			logger.Warn("GitHub timed out running the search, some PRs may be missing", "query", query)
+		}
+		for _, item := range results.Items {
+			if found == maxPRs {
+				break
+			}
+			repoSpec, ok := item.repository()
+			if !ok {
+				continue
+			}
+			if _, seen := numbers[repoSpec]; !seen {
+				repositories = append(repositories, repoSpec)
+			}
+			numbers[repoSpec] = append(numbers[repoSpec], item.Number)
+			found++
+		}
+		if len(results.Items) < perPage || page*perPage >= results.TotalCount {
+			break
+		}
+	}
+	return repositories, numbers, nil
+}
+
+// fetchSearchedPRs fetches the PRs a search found in a repository and applies the author, people and local
+// filters to them like fetchRepositoryPRs, since search results leave out what the table shows, such as the
+// branches of a PR
+func fetchSearchedPRs(ctx context.Context, opts *listOptions, client RESTClientInterface, owner, repo string, numbers []int, authors []string, isKonflux bool) ([]PullRequest, error) {
+	authors, people, err := resolvePeople(opts, client, owner, repo, authors)
+	if err != nil {
+		return nil, err
+	}
+
+	prs := make([]*PullRequest, len(numbers))
+	errs := make([]error, len(numbers))
+	runConcurrently(len(numbers), opts.Concurrency, func(i int) {
+		prs[i], errs[i] = fetchPRDetails(ctx, client, owner, repo, numbers[i])
+	})
+	var pullRequests []PullRequest
+	for i, pr := range prs {
+		if errs[i] != nil {
+			logger.Warn("Could not fetch a PR the search found", "repo", owner+"/"+repo, "number", numbers[i], "error", errs[i])
+			continue
+		}
+		pullRequests = append(pullRequests, *pr)
+	}
+
+	filter := people.wrap(newPRFilter(ctx, opts, owner, repo, authors, isKonflux))
+	return filter(client, pullRequests), nil
+}
+
+// runSearch runs the search of --query or 'ghprs search' with the state and limit of opts, exiting on failure.
+// The search runs on the configured host, or else gh's default one.
+func runSearch(opts *listOptions, limiter *rateLimiter) ([]string, map[string][]int) {
+	client, err := newAPIClientWithTimeout(hostFor("", ""), limiter, nil, opts.RequestTimeout)
+	if err != nil {
+		log.Fatalf("Failed to create GitHub client: %v", err)
+	}
+	query := searchQualifiers(opts.Query, opts.State)
+	logger.Info("Searching pull requests", "query", query, "limit", opts.Limit)
+	repositories, hits, err := searchPullRequests(client, query, opts.Limit)
+	if err != nil {
+		log.Fatalf("Failed to search pull requests: %v", err)
+	}
+	return repositories, hits
+}
+
+// searchCmd lists the PRs a GitHub search finds, in any repository
+var searchCmd = &cobra.Command{
+	Use:   "search <query>",
+	Short: "List the pull requests a GitHub search finds",
+	Long: `List the pull requests a GitHub search finds, in any repository, in the same table as 'ghprs list'.
+The query takes every qualifier of GitHub's issue search, so PRs can be found across organizations and
+repositories that aren't configured. is:pr is added unless the query has it, and so is the state of
+--state unless the query gives one. --limit caps the PRs found across every repository.
+
+Each PR found is fetched for the table, so broad queries are best combined with --limit.
+'ghprs list --query' does the same.
+
+Examples:
+  ghprs search "org:my-org author:app/red-hat-konflux label:lgtm"
+  ghprs search "org:my-org author:app/red-hat-konflux .tekton in:title" --combined
+  ghprs search "repo:owner/one repo:owner/two review-requested:@me"
+  ghprs search "org:my-org label:needs-rebase" --state all --output json`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		searchOpts.prepare(cmd)
+		searchOpts.Query = args[0]
+		authors := searchOpts.Authors
+		if searchOpts.Mine {
+			authors = append(authors, meLogin)
+		}
+		listPullRequests(ctx, searchOpts, nil, authors, false)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(searchCmd)
+}
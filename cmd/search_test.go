@@ -0,0 +1,66 @@
+package cmd_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Searching pull requests", func() {
+	var mockClient *cmd.MockRESTClient
+
+	item := func(repoSpec string, number int) map[string]any {
+		return map[string]any{"number": number, "repository_url": "https://api.github.com/repos/" + repoSpec}
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+	})
+
+	DescribeTable("should complete the query with the qualifiers it leaves out",
+		func(query, state, expected string) {
+			Expect(cmd.SearchQualifiersTest(query, state)).To(Equal(expected))
+		},
+		Entry("PRs in the state of --state", "org:my-org label:lgtm", "open", "is:pr org:my-org label:lgtm is:open"),
+		Entry("no state for --state all", "org:my-org", "all", "is:pr org:my-org"),
+		Entry("the state of the query", "is:merged org:my-org", "open", "is:pr is:merged org:my-org"),
+		Entry("nothing when the query has both", "type:pr is:closed org:my-org", "open", "type:pr is:closed org:my-org"),
+	)
+
+	It("should group the PRs found by repository in the order found", func() {
+		mockClient.AddResponse("search/issues", 200, map[string]any{
+			"total_count": 3,
+			"items":       []map[string]any{item("org/one", 5), item("org/two", 7), item("org/one", 3)},
+		})
+
+		repositories, hits, err := cmd.SearchPullRequestsTest(mockClient, "is:pr org:org", 30)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(repositories).To(Equal([]string{"org/one", "org/two"}))
+		Expect(hits).To(Equal(map[string][]int{"org/one": {5, 3}, "org/two": {7}}))
+		Expect(mockClient.GetLastRequest().URL).To(Equal("search/issues?q=is%3Apr+org%3Aorg&per_page=30&page=1"))
+	})
+
+	It("should stop at the limit", func() {
+		mockClient.AddResponse("search/issues", 200, map[string]any{
+			"total_count": 50,
+			"items":       []map[string]any{item("org/one", 1), item("org/one", 2)},
+		})
+
+		_, hits, err := cmd.SearchPullRequestsTest(mockClient, "is:pr org:org", 2)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(hits).To(Equal(map[string][]int{"org/one": {1, 2}}))
+		Expect(mockClient.GetRequestCount("search/issues")).To(Equal(1))
+	})
+
+	It("should fetch the PRs found and apply the filters of the listing", func() {
+		mockClient.AddResponse("repos/org/one/pulls/5", 200, cmd.PullRequest{Number: 5, State: "open", User: cmd.User{Login: "renovate[bot]"}, Base: cmd.Branch{Ref: "main"}})
+		mockClient.AddResponse("repos/org/one/pulls/3", 200, cmd.PullRequest{Number: 3, State: "open", User: cmd.User{Login: "alice"}})
+
+		prs, err := cmd.FetchSearchedPRsTest(mockClient, "org", "one", []int{5, 3, 9}, []string{"renovate[bot]"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prs).To(HaveLen(1))
+		Expect(prs[0].Number).To(Equal(5))
+		Expect(prs[0].Base.Ref).To(Equal("main"))
+	})
+})
@@ -0,0 +1,499 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+// dependabotAuthor is the author of Dependabot's security update PRs
+const dependabotAuthor = "dependabot[bot]"
+
+// Severities of security PRs
+const (
+	SeverityCritical = "critical"
+	SeverityHigh     = "high"
+	SeverityModerate = "moderate"
+	SeverityLow      = "low"
+	SeverityUnknown  = "unknown"
+)
+
+// severityOrder lists the severities from the most to the least urgent
+var severityOrder = []string{SeverityCritical, SeverityHigh, SeverityModerate, SeverityLow, SeverityUnknown}
+
+// severityIcons mark the severity of a PR in the security queue
+var severityIcons = map[string]string{
+	SeverityCritical: "🔴",
+	SeverityHigh:     "🟠",
+	SeverityModerate: "🟡",
+	SeverityLow:      "🟢",
+	SeverityUnknown:  "⚪",
+}
+
+var (
+	// advisoryRE matches CVE and GitHub security advisory IDs
+	advisoryRE = regexp.MustCompile(`(?i)\b(CVE-\d{4}-\d{4,}|GHSA(?:-[0-9a-z]{4}){3})\b`)
+	// severityRE matches a severity stated in a PR body, such as "Severity: High" or "critical severity"
+	severityRE = regexp.MustCompile(`(?i)\bseverity\b[^a-z\n]{0,20}(critical|high|moderate|medium|low)\b|\b(critical|high|moderate|medium|low)\b[ -]severity\b`)
+	// severityLevelRE matches a severity level in a label such as "severity/high" or "security: critical"
+	severityLevelRE = regexp.MustCompile(`(?i)\b(critical|high|moderate|medium|low)\b`)
+)
+
+// Values of the Approval field of the security queue report
+const (
+	SecurityApprovalApproved = "approved"
+	SecurityApprovalFailed   = "failed"
+	SecurityApprovalSkipped  = "skipped"
+)
+
+var (
+	securityQueueApprove bool
+	securityQueueReport  string
+)
+
+// SecurityQueueReport is the security queue as written by 'ghprs security-queue' in json/yaml output mode and
+// by --report, for compliance tracking
+type SecurityQueueReport struct {
+	SchemaVersion string              `json:"schemaVersion" yaml:"schemaVersion"`
+	GeneratedAt   string              `json:"generatedAt" yaml:"generatedAt"`
+	PullRequests  []SecurityQueueItem `json:"pullRequests" yaml:"pullRequests"`
+}
+
+// SecurityQueueItem is an open security PR in the security queue
+type SecurityQueueItem struct {
+	Repository string   `json:"repository" yaml:"repository"`
+	Number     int      `json:"number" yaml:"number"`
+	Title      string   `json:"title" yaml:"title"`
+	Author     string   `json:"author" yaml:"author"`
+	URL        string   `json:"url" yaml:"url"`
+	Severity   string   `json:"severity" yaml:"severity"`
+	Advisories []string `json:"advisories" yaml:"advisories"`
+	CreatedAt  string   `json:"createdAt" yaml:"createdAt"`
+	AgeSeconds int64    `json:"ageSeconds" yaml:"ageSeconds"`
+	// Approval is what --approve did to the PR: approved, failed or skipped, unset without --approve
+	Approval string `json:"approval,omitempty" yaml:"approval,omitempty"`
+	// ApprovalNote is why an approval failed or was skipped
+	ApprovalNote string `json:"approvalNote,omitempty" yaml:"approvalNote,omitempty"`
+}
+
+// securityQueueCmd lists the open security PRs of every configured repository, most severe first
+var securityQueueCmd = &cobra.Command{
+	Use:   "security-queue [owner/repo...]",
+	Short: "List and approve the open security pull requests of all repositories",
+	Long: `List the open security pull requests of every configured repository in one queue, most severe
+first and oldest first within a severity.
+
+A PR is in the queue when its title mentions a CVE or security, or when it is a Dependabot PR
+that references an advisory (CVE or GHSA) or is labelled as a security update. The severity is
+taken from the PR's labels (e.g. severity/high) or body (e.g. "Severity: critical").
+
+With --approve the PRs are approved in batches, one confirmation per repository. With --report
+the queue, and what --approve did to each PR, is written to a file for compliance tracking; the
+format follows the extension (.json, .yaml, .yml or .csv).
+
+Without repositories every configured repository is used, or else the current repository.
+
+Examples:
+  ghprs security-queue
+  ghprs security-queue owner/repo other/repo
+  ghprs security-queue --approve
+  ghprs security-queue --approve --yes --report security-$(date +%F).csv
+  ghprs security-queue --output json`,
+	ValidArgsFunction: completeRepositoryArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		if isRowOutput(outputFormat) {
+			log.Fatalf("--output %s is only supported by list and konflux", outputFormat)
+		}
+		if securityQueueApprove && isStructuredOutput(outputFormat) {
+			log.Fatal("--approve can't be used with --output json or yaml, use --report to record the approvals")
+		}
+		if securityQueueReport != "" {
+			if _, err := reportFormat(securityQueueReport); err != nil {
+				log.Fatal(err)
+			}
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+		setColumnWidths(config)
+
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		var queue []repoPR
+		for _, repoSpec := range securityQueueRepositories(args, config) {
+			owner, repo, ok := parseRepoSpec(repoSpec)
+			if !ok {
+				logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+				continue
+			}
+			client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+			if err != nil {
+				logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+				continue
+			}
+
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			// The client is bound to the whole run, not the fetch, as it is used again to approve
+			prs, err := fetchSecurityPRs(withContext(client, repoCtx), owner, repo)
+			reason := describeCancellation(repoCtx.Err())
+			cancel()
+			if ctx.Err() != nil {
+				break
+			}
+			switch {
+			case reason != "":
+				logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+			case err != nil:
+				logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+			default:
+				for _, pr := range prs {
+					queue = append(queue, repoPR{Owner: owner, Repo: repo, Client: withContext(client, ctx), PR: pr})
+				}
+			}
+		}
+		sortSecurityQueue(queue)
+
+		now := time.Now()
+		report := newSecurityQueueReport(queue, now)
+		if isStructuredOutput(outputFormat) {
+			if err := writeStructuredOutput(streams.Out, report, outputFormat); err != nil {
+				log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+			}
+		} else {
+			displaySecurityQueue(report)
+		}
+
+		failed := 0
+		if securityQueueApprove {
+			failed = approveSecurityQueue(queue, report, config, assumeYes)
+		}
+		if securityQueueReport != "" {
+			if err := writeSecurityQueueReport(securityQueueReport, report); err != nil {
+				log.Fatalf("Failed to write the report: %v", err)
+			}
+			streams.Printf("\n📝 Wrote the security queue report to %s\n", securityQueueReport)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// securityQueueRepositories returns the repositories of the security queue: the given ones, every configured
+// repository or else the current repository
+func securityQueueRepositories(args []string, config *Config) []string {
+	switch {
+	case len(args) > 0:
+		return args
+	case repoFlag != "":
+		return []string{repoFlag}
+	}
+	if repositories := config.GetRepositories(false); len(repositories) > 0 {
+		return repositories
+	}
+	currentRepo, err := repository.Current()
+	if err != nil {
+		log.Fatal("No repositories specified and no repositories configured. Specify owner/repo or run from a git repository.")
+	}
+	return []string{currentRepo.Owner + "/" + currentRepo.Name}
+}
+
+// fetchSecurityPRs fetches the open security PRs of a repository
+func fetchSecurityPRs(client RESTClientInterface, owner, repo string) ([]PullRequest, error) {
+	return fetchPullRequestsREST(client, owner, repo, "open", "", 0, func(_ RESTClientInterface, page []PullRequest) []PullRequest {
+		return slices.DeleteFunc(page, func(pr PullRequest) bool { return !isSecurityPR(pr) })
+	})
+}
+
+// isSecurityPR reports whether a PR belongs in the security queue: its title mentions a CVE or security,
+// or it is a Dependabot PR referencing an advisory or labelled as a security update
+func isSecurityPR(pr PullRequest) bool {
+	if hasSecurity(pr) {
+		return true
+	}
+	if !strings.EqualFold(pr.User.Login, dependabotAuthor) {
+		return false
+	}
+	return len(securityAdvisories(pr)) > 0 || slices.ContainsFunc(pr.Labels, func(label Label) bool {
+		return strings.Contains(strings.ToLower(label.Name), "security")
+	})
+}
+
+// securityAdvisories returns the CVE and GHSA IDs a PR references, in the order they first appear
+func securityAdvisories(pr PullRequest) []string {
+	advisories := []string{}
+	for _, match := range advisoryRE.FindAllString(pr.Title+"\n"+pr.Body, -1) {
+		id := strings.ToUpper(match)
+		if strings.HasPrefix(id, "GHSA") {
+			id = "GHSA" + strings.ToLower(id[4:])
+		}
+		if !slices.Contains(advisories, id) {
+			advisories = append(advisories, id)
+		}
+	}
+	return advisories
+}
+
+// securitySeverity returns the severity of a security PR from its labels, or else its body
+func securitySeverity(pr PullRequest) string {
+	for _, label := range pr.Labels {
+		name := strings.ToLower(label.Name)
+		if !strings.Contains(name, "severity") && !strings.Contains(name, "security") {
+			continue
+		}
+		if level := severityLevelRE.FindString(name); level != "" {
+			return normalizeSeverity(level)
+		}
+	}
+	if match := severityRE.FindStringSubmatch(pr.Body); match != nil {
+		return normalizeSeverity(cmp.Or(match[1], match[2]))
+	}
+	return SeverityUnknown
+}
+
+// normalizeSeverity maps a severity level to one of the severities, as GitHub calls medium moderate
+func normalizeSeverity(level string) string {
+	level = strings.ToLower(level)
+	if level == "medium" {
+		return SeverityModerate
+	}
+	return level
+}
+
+// sortSecurityQueue sorts the queue by severity, then oldest first
+func sortSecurityQueue(queue []repoPR) {
+	slices.SortStableFunc(queue, func(a, b repoPR) int {
+		if bySeverity := cmp.Compare(slices.Index(severityOrder, securitySeverity(a.PR)), slices.Index(severityOrder, securitySeverity(b.PR))); bySeverity != 0 {
+			return bySeverity
+		}
+		return strings.Compare(a.PR.CreatedAt, b.PR.CreatedAt)
+	})
+}
+
+// newSecurityQueueReport builds the report of the queue as of now
+func newSecurityQueueReport(queue []repoPR, now time.Time) *SecurityQueueReport {
+	report := &SecurityQueueReport{
+		SchemaVersion: OutputSchemaVersion,
+		GeneratedAt:   now.UTC().Format(time.RFC3339),
+		PullRequests:  make([]SecurityQueueItem, 0, len(queue)),
+	}
+	for _, item := range queue {
+		pr := item.PR
+		entry := SecurityQueueItem{
+			Repository: item.Owner + "/" + item.Repo,
+			Number:     pr.Number,
+			Title:      pr.Title,
+			Author:     pr.User.Login,
+			URL:        cmp.Or(pr.HTMLURL, prURL(item.Owner, item.Repo, pr.Number)),
+			Severity:   securitySeverity(pr),
+			Advisories: securityAdvisories(pr),
+			CreatedAt:  pr.CreatedAt,
+		}
+		if createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt); err == nil {
+			entry.AgeSeconds = int64(now.Sub(createdAt).Seconds())
+		}
+		report.PullRequests = append(report.PullRequests, entry)
+	}
+	return report
+}
+
+// displaySecurityQueue prints the security queue as a table
+func displaySecurityQueue(report *SecurityQueueReport) {
+	repositories := make(map[string]bool)
+	for _, item := range report.PullRequests {
+		repositories[item.Repository] = true
+	}
+	if len(report.PullRequests) == 0 {
+		streams.Println("\n🔒 No open security pull requests found")
+		return
+	}
+	streams.Printf("\n=== Security queue: %d open security PR(s) in %d repositories ===\n", len(report.PullRequests), len(repositories))
+
+	repos := make([]string, len(report.PullRequests))
+	titles := make([]string, len(report.PullRequests))
+	for i, item := range report.PullRequests {
+		repos[i], titles[i] = item.Repository, item.Title
+	}
+	const (
+		severityWidth   = 11 // "🔴 critical"
+		prWidth         = 6  // "#1234"
+		ageWidth        = 7  // "12d23h"
+		advisoriesWidth = 10 // "ADVISORIES"
+	)
+	table := render.NewTable(
+		render.Column{Header: "SEVERITY", Width: severityWidth},
+		render.Column{Header: "REPO", Width: columnWidth(render.ColumnRepo, "REPO", repos), Truncate: true},
+		render.Column{Header: "PR", Width: prWidth},
+		render.Column{Header: "TITLE", Width: columnWidth(render.ColumnTitle, "TITLE", titles), Truncate: true},
+		render.Column{Header: "AGE", Width: ageWidth},
+		render.Column{Header: "ADVISORIES", Width: advisoriesWidth},
+	)
+
+	for _, item := range report.PullRequests {
+		prLink := fmt.Sprintf("#%d", item.Number)
+		if owner, repo, ok := parseRepoSpec(item.Repository); ok {
+			prLink = formatPRLink(owner, repo, item.Number)
+		}
+		table.AddRow(
+			severityIcons[item.Severity]+" "+item.Severity,
+			item.Repository,
+			prLink,
+			item.Title,
+			formatAge(time.Duration(item.AgeSeconds)*time.Second),
+			strings.Join(item.Advisories, ", "))
+	}
+	table.Write(streams.Out)
+}
+
+// approveSecurityQueue approves the PRs of the queue one repository at a time, with the approval settings of
+// that repository and after confirming its plan, and records what was done in the report. It returns the number
+// of approvals that failed.
+func approveSecurityQueue(queue []repoPR, report *SecurityQueueReport, config *Config, assumeYes bool) int {
+	var plans []*batchPlan
+	byRepo := make(map[string]*batchPlan)
+	settings := make(map[string]ApprovalSettings)
+	items := make(map[string]*SecurityQueueItem)
+	prs := make(map[string]repoPR)
+	for i, item := range queue {
+		repoSpec := item.Owner + "/" + item.Repo
+		plan, ok := byRepo[repoSpec]
+		if !ok {
+			plan = &batchPlan{owner: item.Owner, repo: item.Repo}
+			byRepo[repoSpec] = plan
+			plans = append(plans, plan)
+			settings[repoSpec] = newApprovalConfig(newListOptions(), config, repoSpec, false).Review
+		}
+		key := fmt.Sprintf("%s#%d", repoSpec, item.PR.Number)
+		items[key], prs[key] = &report.PullRequests[i], item
+
+		switch {
+		case item.PR.Draft:
+			plan.add(item.PR, PlanActionSkip, "draft")
+		case isOnHold(item.PR):
+			plan.add(item.PR, PlanActionSkip, "on hold")
+		default:
+			if reason := batchApprovalBlocker(item, config.TrustedAuthors(), settings[repoSpec].MaxChanges); reason != "" {
+				plan.add(item.PR, PlanActionSkip, reason)
+				continue
+			}
+			plan.add(item.PR, PlanActionApprove, securitySeverity(item.PR)+" security update")
+		}
+	}
+
+	failed := 0
+	for _, plan := range plans {
+		repoSpec := plan.owner + "/" + plan.repo
+		confirmed := confirmPlan(plan, assumeYes)
+		for _, action := range plan.actions {
+			item := items[fmt.Sprintf("%s#%d", repoSpec, action.Number)]
+			item.Approval = SecurityApprovalSkipped
+			switch {
+			case action.Action == PlanActionSkip:
+				item.ApprovalNote = action.Reason
+			case !confirmed:
+				item.ApprovalNote = "not confirmed"
+			}
+		}
+		if !confirmed {
+			continue
+		}
+		failed += executePlan(plan, func(action PlannedAction) error {
+			key := fmt.Sprintf("%s#%d", repoSpec, action.Number)
+			item, pr := items[key], prs[key]
+			if err := postApproval(pr.Client, pr.Owner, pr.Repo, pr.PR, settings[repoSpec]); err != nil {
+				item.Approval, item.ApprovalNote = SecurityApprovalFailed, err.Error()
+				return err
+			}
+			item.Approval = SecurityApprovalApproved
+			return nil
+		})
+	}
+	return failed
+}
+
+// reportFormat returns the format of a report file from its extension
+func reportFormat(path string) (string, error) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return OutputJSON, nil
+	case ".yaml", ".yml":
+		return OutputYAML, nil
+	case ".csv":
+		return OutputCSV, nil
+	default:
+		return "", fmt.Errorf("unsupported report file %q (use a .json, .yaml, .yml or .csv file)", path)
+	}
+}
+
+// writeSecurityQueueReport writes the report to path in the format of its extension
+func writeSecurityQueueReport(path string, report *SecurityQueueReport) error {
+	format, err := reportFormat(path)
+	if err != nil {
+		return err
+	}
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if format != OutputCSV {
+		err = writeStructuredOutput(file, report, format)
+	} else {
+		err = writeSecurityQueueCSV(file, report)
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+// writeSecurityQueueCSV writes the report as CSV, one row per PR with the advisories separated by spaces
+func writeSecurityQueueCSV(w io.Writer, report *SecurityQueueReport) error {
+	writer := csv.NewWriter(w)
+	_ = writer.Write([]string{"repository", "number", "title", "author", "url", "severity", "advisories", "created_at", "age_seconds", "approval", "approval_note", "generated_at"})
+	for _, item := range report.PullRequests {
+		_ = writer.Write([]string{
+			item.Repository,
+			strconv.Itoa(item.Number),
+			item.Title,
+			item.Author,
+			item.URL,
+			item.Severity,
+			strings.Join(item.Advisories, " "),
+			item.CreatedAt,
+			strconv.FormatInt(item.AgeSeconds, 10),
+			item.Approval,
+			item.ApprovalNote,
+			report.GeneratedAt,
+		})
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+func init() {
+	securityQueueCmd.Flags().BoolVar(&securityQueueApprove, "approve", false, "Approve the PRs in batches, confirming once per repository")
+	securityQueueCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Approve without asking for confirmation")
+	securityQueueCmd.Flags().StringVar(&securityQueueReport, "report", "", "Write the queue and what --approve did to a .json, .yaml, .yml or .csv file")
+	RootCmd.AddCommand(securityQueueCmd)
+}
@@ -0,0 +1,180 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Security queue", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		now        time.Time
+	)
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, out), nil)
+		now = time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	Describe("recognizing security PRs", func() {
+		It("should include PRs whose title mentions a CVE or security", func() {
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{Title: "fix(deps): update module x [SECURITY]"})).To(BeTrue())
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{Title: "Fix CVE-2024-1234 in parser"})).To(BeTrue())
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{Title: "Update docs"})).To(BeFalse())
+		})
+
+		It("should include Dependabot PRs that reference an advisory or are labelled security", func() {
+			dependabot := cmd.User{Login: "dependabot[bot]"}
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{User: dependabot, Title: "Bump x", Body: "Fixes GHSA-abcd-efgh-ijkl"})).To(BeTrue())
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{User: dependabot, Title: "Bump x", Labels: []cmd.Label{{Name: "security"}}})).To(BeTrue())
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{User: dependabot, Title: "Bump x"})).To(BeFalse())
+			Expect(cmd.IsSecurityPRTest(cmd.PullRequest{User: cmd.User{Login: "someone"}, Title: "Bump x", Body: "See GHSA-abcd-efgh-ijkl"})).To(BeFalse())
+		})
+
+		It("should collect the advisories a PR references once", func() {
+			pr := cmd.PullRequest{Title: "Fix cve-2024-1234", Body: "CVE-2024-1234 and GHSA-ABCD-efgh-ijkl, CVE-2023-99999"}
+			Expect(cmd.SecurityAdvisoriesTest(pr)).To(Equal([]string{"CVE-2024-1234", "GHSA-abcd-efgh-ijkl", "CVE-2023-99999"}))
+		})
+	})
+
+	Describe("the severity of a PR", func() {
+		It("should come from a severity label first", func() {
+			pr := cmd.PullRequest{Labels: []cmd.Label{{Name: "kind/bug"}, {Name: "severity/High"}}, Body: "Severity: low"}
+			Expect(cmd.SecuritySeverityTest(pr)).To(Equal(cmd.SeverityHigh))
+		})
+
+		It("should come from the body without a label", func() {
+			Expect(cmd.SecuritySeverityTest(cmd.PullRequest{Body: "**Severity**: Critical"})).To(Equal(cmd.SeverityCritical))
+			Expect(cmd.SecuritySeverityTest(cmd.PullRequest{Body: "A medium severity issue"})).To(Equal(cmd.SeverityModerate))
+		})
+
+		It("should be unknown otherwise", func() {
+			Expect(cmd.SecuritySeverityTest(cmd.PullRequest{Body: "Bumps x from 1.0 to 1.1"})).To(Equal(cmd.SeverityUnknown))
+		})
+	})
+
+	Describe("the queue", func() {
+		BeforeEach(func() {
+			mockClient.AddResponse("repos/owner/repo/pulls?state=open&per_page=100&page=1", 200, []cmd.PullRequest{
+				{Number: 1, Title: "Fix CVE-2024-0001", Body: "Severity: low", State: "open", CreatedAt: "2025-06-01T00:00:00Z",
+					User: cmd.User{Login: "renovate[bot]"}, Head: cmd.Branch{SHA: "sha1"}},
+				{Number: 2, Title: "Update docs", State: "open", CreatedAt: "2025-06-02T00:00:00Z"},
+				{Number: 3, Title: "Fix CVE-2024-0003", Body: "Severity: critical", State: "open", CreatedAt: "2025-06-20T00:00:00Z",
+					User: cmd.User{Login: "renovate[bot]"}, Head: cmd.Branch{SHA: "sha3"}},
+			})
+			mockClient.AddResponse("repos/other/repo/pulls?state=open&per_page=100&page=1", 200, []cmd.PullRequest{
+				{Number: 7, Title: "Bump y", Body: "Fixes GHSA-aaaa-bbbb-cccc. Severity: critical", State: "open", CreatedAt: "2025-06-10T00:00:00Z",
+					User: cmd.User{Login: "dependabot[bot]"}, Head: cmd.Branch{SHA: "sha7"}},
+				{Number: 8, Title: "[security] Bump z", State: "open", Draft: true, CreatedAt: "2025-06-11T00:00:00Z",
+					User: cmd.User{Login: "dependabot[bot]"}},
+			})
+			mockClient.AddResponse("repos/owner/repo/pulls/2/files?per_page=100&page=1", 200, []cmd.PRFile{{Filename: "README.md"}})
+		})
+
+		It("should sort the security PRs of every repository by severity, then age", func() {
+			report, err := cmd.SecurityQueueTest(mockClient, []string{"owner/repo", "other/repo"}, now)
+			Expect(err).NotTo(HaveOccurred())
+
+			var order []string
+			for _, item := range report.PullRequests {
+				order = append(order, item.Repository+"#"+item.Severity)
+			}
+			Expect(order).To(Equal([]string{"other/repo#critical", "owner/repo#critical", "owner/repo#low", "other/repo#unknown"}))
+			Expect(report.PullRequests[0].Number).To(Equal(7))
+			Expect(report.PullRequests[0].Advisories).To(Equal([]string{"GHSA-aaaa-bbbb-cccc"}))
+			Expect(report.PullRequests[2].AgeSeconds).To(Equal(int64(29*24*60*60 + 12*60*60)))
+
+			cmd.DisplaySecurityQueueTest(report)
+			Expect(out.String()).To(ContainSubstring("4 open security PR(s) in 2 repositories"))
+			Expect(out.String()).To(ContainSubstring("🔴 critical"))
+			Expect(out.String()).To(ContainSubstring("CVE-2024-0003"))
+		})
+
+		It("should approve the PRs in batches and record what was done", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/pulls/3/reviews", 500, map[string]string{"message": "boom"})
+			mockClient.AddResponse("repos/other/repo/pulls/7/reviews", 200, []cmd.Review{})
+
+			report, failed := cmd.ApproveSecurityQueueTest(mockClient, []string{"owner/repo", "other/repo"}, true)
+			Expect(failed).To(Equal(1))
+
+			approvals := map[int]string{}
+			for _, item := range report.PullRequests {
+				approvals[item.Number] = item.Approval
+			}
+			Expect(approvals).To(Equal(map[int]string{
+				7: cmd.SecurityApprovalApproved,
+				3: cmd.SecurityApprovalFailed,
+				1: cmd.SecurityApprovalApproved,
+				8: cmd.SecurityApprovalSkipped,
+			}))
+			Expect(report.PullRequests[3].ApprovalNote).To(Equal("draft"))
+		})
+
+		It("should approve the PRs of each repository with its approval settings", func() {
+			body := "/lgtm security"
+			config := cmd.DefaultConfig()
+			config.Repositories = []cmd.RepositoryConfig{{Name: "other/repo", Approval: &cmd.ApprovalSettings{Body: &body}}}
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/pulls/3/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/other/repo/pulls/7/reviews", 200, []cmd.Review{})
+
+			_, failed := cmd.ApproveSecurityQueueWithConfigTest(config, mockClient, []string{"owner/repo", "other/repo"}, true)
+			Expect(failed).To(BeZero())
+			Expect(mockClient.RequestBodies("POST", "repos/other/repo/pulls/7/reviews")).To(ConsistOf(ContainSubstring(body)))
+			Expect(mockClient.RequestBodies("POST", "repos/owner/repo/pulls/1/reviews")).To(ConsistOf(Not(ContainSubstring(body))))
+		})
+
+		It("should approve nothing when a plan isn't confirmed", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("n\nn\n"), out, out), nil)
+
+			report, failed := cmd.ApproveSecurityQueueTest(mockClient, []string{"owner/repo", "other/repo"}, false)
+			Expect(failed).To(BeZero())
+			for _, request := range mockClient.Requests {
+				Expect(request.Method).To(Equal("GET"))
+			}
+			Expect(report.PullRequests[0].ApprovalNote).To(Equal("not confirmed"))
+		})
+
+		It("should write the report in the format of its extension", func() {
+			report, err := cmd.SecurityQueueTest(mockClient, []string{"owner/repo"}, now)
+			Expect(err).NotTo(HaveOccurred())
+			dir := GinkgoT().TempDir()
+
+			jsonPath := filepath.Join(dir, "report.json")
+			Expect(cmd.WriteSecurityQueueReportTest(jsonPath, report)).To(Succeed())
+			data, err := os.ReadFile(jsonPath)
+			Expect(err).NotTo(HaveOccurred())
+			var decoded cmd.SecurityQueueReport
+			Expect(json.Unmarshal(data, &decoded)).To(Succeed())
+			Expect(decoded.PullRequests).To(HaveLen(2))
+
+			csvPath := filepath.Join(dir, "report.csv")
+			Expect(cmd.WriteSecurityQueueReportTest(csvPath, report)).To(Succeed())
+			data, err = os.ReadFile(csvPath)
+			Expect(err).NotTo(HaveOccurred())
+			lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+			Expect(lines).To(HaveLen(3))
+			Expect(lines[0]).To(HavePrefix("repository,number,title"))
+			Expect(lines[1]).To(HavePrefix("owner/repo,3,Fix CVE-2024-0003"))
+
+			Expect(cmd.WriteSecurityQueueReportTest(filepath.Join(dir, "report.txt"), report)).To(MatchError(ContainSubstring("unsupported report file")))
+		})
+	})
+})
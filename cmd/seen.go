@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// seenPRs are the PRs already listed, nil to treat none as new
+var seenPRs *seenState
+
+// seenState is the local state file of the PRs list and konflux showed, with the update time they had then
+type seenState struct {
+	path  string
+	mutex sync.Mutex
+	// seen maps "owner/repo#number" to the updated_at of the PR when it was last listed
+	seen    map[string]string
+	changed bool
+}
+
+// seenStatePath is where the listed PRs are kept, next to the config file
+func seenStatePath() string {
+	return filepath.Join(stateDir(), "seen.yaml")
+}
+
+// seenKey identifies a PR in the state file
+func seenKey(repoSpec string, number int) string {
+	return fmt.Sprintf("%s#%d", repoSpec, number)
+}
+
+// loadSeen reads the listed PRs from path, none if it doesn't exist
+func loadSeen(path string) (*seenState, error) {
+	state := &seenState{path: path, seen: make(map[string]string)}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &state.seen); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	if state.seen == nil {
+		state.seen = make(map[string]string)
+	}
+	return state, nil
+}
+
+// isNew reports whether a PR wasn't listed before or was updated since. A nil state treats no PR as new.
+func (s *seenState) isNew(repoSpec string, pr PullRequest) bool {
+	if s == nil {
+		return false
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	updatedAt, ok := s.seen[seenKey(repoSpec, pr.Number)]
+	return !ok || updatedAt != pr.UpdatedAt
+}
+
+// onlyNew returns the PRs of a page that are new or were updated since they were last listed
+func (s *seenState) onlyNew(repoSpec string, pullRequests []PullRequest) []PullRequest {
+	if s == nil {
+		return pullRequests
+	}
+	var unseen []PullRequest
+	for _, pr := range pullRequests {
+		if s.isNew(repoSpec, pr) {
+			unseen = append(unseen, pr)
+		}
+	}
+	return unseen
+}
+
+// markSeen remembers the PRs as listed with their current update time
+func (s *seenState) markSeen(repoSpec string, pullRequests []PullRequest) {
+	if s == nil {
+		return
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	for _, pr := range pullRequests {
+		key := seenKey(repoSpec, pr.Number)
+		if s.seen[key] != pr.UpdatedAt {
+			s.seen[key] = pr.UpdatedAt
+			s.changed = true
+		}
+	}
+}
+
+// save writes the listed PRs back if they changed
+func (s *seenState) save() error {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.changed {
+		return nil
+	}
+	data, err := yaml.Marshal(s.seen)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return err
+	}
+	s.changed = false
+	return nil
+}
+
+// loadSeenForListing loads the PRs list and konflux already showed, warning and treating none as new when
+// they can't be read
+func loadSeenForListing() *seenState {
+	state, err := loadSeen(seenStatePath())
+	if err != nil {
+		logger.Warn("Could not read the pull requests already seen, none are marked new", "error", err)
+		return nil
+	}
+	return state
+}
+
+// rowTitle is the title of a row in the table, marked 🆕 when the PR is new or was updated since last listed
+func rowTitle(row PRRow) string {
+	if row.New {
+		return "🆕 " + row.Title
+	}
+	return row.Title
+}
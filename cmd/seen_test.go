@@ -0,0 +1,54 @@
+package cmd_test
+
+import (
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Seen PRs", func() {
+	var path string
+	prs := []cmd.PullRequest{
+		{Number: 1, UpdatedAt: "2025-01-09T08:00:00Z"},
+		{Number: 2, UpdatedAt: "2025-01-09T08:00:00Z"},
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "seen.yaml")
+	})
+
+	It("should treat every PR as new before anything was listed", func() {
+		unseen, err := cmd.OnlyNewTest(path, "owner/repo", prs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(unseen)).To(Equal([]int{1, 2}))
+	})
+
+	It("should only show PRs not listed before or updated since, per repository", func() {
+		Expect(cmd.MarkSeenTest(path, "owner/repo", prs)).To(Succeed())
+		updated := []cmd.PullRequest{prs[0], {Number: 2, UpdatedAt: "2025-01-10T13:00:00Z"}, {Number: 3, UpdatedAt: "2025-01-10T13:00:00Z"}}
+
+		unseen, err := cmd.OnlyNewTest(path, "owner/repo", updated)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(unseen)).To(Equal([]int{2, 3}))
+
+		unseen, err = cmd.OnlyNewTest(path, "owner/other", prs)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(unseen)).To(Equal([]int{1, 2}))
+	})
+
+	It("should not write the state file when nothing changed", func() {
+		Expect(cmd.MarkSeenTest(path, "owner/repo", nil)).To(Succeed())
+		_, err := os.Stat(path)
+		Expect(os.IsNotExist(err)).To(BeTrue())
+	})
+
+	It("should report a state file it can't parse", func() {
+		Expect(os.WriteFile(path, []byte("not: [valid"), 0644)).To(Succeed())
+		_, err := cmd.OnlyNewTest(path, "owner/repo", prs)
+		Expect(err).To(HaveOccurred())
+	})
+})
@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// parsePRSelection parses what was entered at the PR selection prompt: a PR number, a comma-separated list of
+// numbers and ranges such as "12,15,20" or "100-110", or "all". Ranges and "all" only pick approvable PRs;
+// PRs on hold, to take them off hold, have to be given by number. The PRs are returned in the order given,
+// each once.
+func parsePRSelection(input string, approvable, held []PullRequest) ([]PullRequest, error) {
+	if strings.EqualFold(strings.TrimSpace(input), "all") {
+		if len(approvable) == 0 {
+			return nil, fmt.Errorf("no PR available for approval")
+		}
+		return approvable, nil
+	}
+
+	var selected []PullRequest
+	add := func(pr PullRequest) {
+		if !slices.ContainsFunc(selected, func(s PullRequest) bool { return s.Number == pr.Number }) {
+			selected = append(selected, pr)
+		}
+	}
+	for _, part := range strings.Split(input, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		if from, to, ok := strings.Cut(part, "-"); ok {
+			first, err1 := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(from), "#"))
+			last, err2 := strconv.Atoi(strings.TrimPrefix(strings.TrimSpace(to), "#"))
+			if err1 != nil || err2 != nil || first > last {
+				return nil, fmt.Errorf("invalid PR range: %s", part)
+			}
+			found := false
+			for _, pr := range approvable {
+				if pr.Number >= first && pr.Number <= last {
+					add(pr)
+					found = true
+				}
+			}
+			if !found {
+				return nil, fmt.Errorf("no PR in %s is available for approval", part)
+			}
+			continue
+		}
+
+		number, err := strconv.Atoi(strings.TrimPrefix(part, "#"))
+		if err != nil {
+			return nil, fmt.Errorf("invalid PR number: %s", part)
+		}
+		index := slices.IndexFunc(approvable, func(pr PullRequest) bool { return pr.Number == number })
+		if index >= 0 {
+			add(approvable[index])
+			continue
+		}
+		index = slices.IndexFunc(held, func(pr PullRequest) bool { return pr.Number == number })
+		if index < 0 {
+			return nil, fmt.Errorf("PR #%d is not available for approval (may be closed, draft, or not exist)", number)
+		}
+		add(held[index])
+	}
+	if len(selected) == 0 {
+		return nil, fmt.Errorf("no PR selected")
+	}
+	return selected, nil
+}
+
+// Ways to go through several selected PRs
+const (
+	selectionWalk     = "walk"
+	selectionBatch    = "batch"
+	selectionReselect = "reselect"
+)
+
+// promptSelectionMode asks whether to walk through several selected PRs one by one or approve them all after one
+// confirmation
+func promptSelectionMode(selected []PullRequest) (string, error) {
+	numbers := make([]string, len(selected))
+	for i, pr := range selected {
+		numbers[i] = fmt.Sprintf("#%d", pr.Number)
+	}
+	streams.Printf("Selected %d PRs: %s\n", len(selected), strings.Join(numbers, ", "))
+	answer, err := prompter.Input("[w]alk through them one by one (default), [a]pprove all after one confirmation, or [s]elect again: ")
+	if err != nil {
+		return "", err
+	}
+	switch strings.ToLower(strings.TrimSpace(answer)) {
+	case "a", "all", "approve":
+		return selectionBatch, nil
+	case "s", "select", "q":
+		return selectionReselect, nil
+	default:
+		return selectionWalk, nil
+	}
+}
+
+// batchSelectionBlocker returns why a selected PR can't be approved with the others after one confirmation:
+// whatever would make the approval prompt ask about it on its own. It returns "" for PRs that can be approved.
+func batchSelectionBlocker(client RESTClientInterface, owner, repo string, pr PullRequest, config ApprovalConfig) string {
+	if isOnHold(pr) {
+		return "on hold"
+	}
+	if hasMigrationWarning(pr) {
+		return "migration warning, approve it on its own"
+	}
+	if reason := batchApprovalBlocker(repoPR{Owner: owner, Repo: repo, Client: client, PR: pr}, config.TrustedAuthors, config.Review.MaxChanges); reason != "" {
+		return reason
+	}
+	if config.IsKonflux {
+		if changes, err := fetchImagePinningChanges(client, owner, repo, pr.Number, config.ImagePinning); err == nil && len(changes) > 0 {
+			return "changes image pinning, approve it on its own"
+		}
+	}
+	return ""
+}
+
+// approveSelectionBatch approves the selected PRs after one confirmation of the plan, leaving out those that need
+// a look of their own so they can be selected again. It returns the result of each PR it tried to approve by
+// number, or nil when the plan wasn't confirmed.
+func approveSelectionBatch(client RESTClientInterface, owner, repo string, selected []PullRequest, config ApprovalConfig) map[int]ApprovalResult {
+	plan := &batchPlan{owner: owner, repo: repo}
+	byNumber := make(map[int]PullRequest, len(selected))
+	for _, pr := range selected {
+		byNumber[pr.Number] = pr
+		if reason := batchSelectionBlocker(client, owner, repo, pr, config); reason != "" {
+			plan.add(pr, PlanActionSkip, reason)
+			continue
+		}
+		plan.add(pr, PlanActionApprove, "selected")
+	}
+	if !confirmPlan(plan, false) {
+		return nil
+	}
+
+	results := make(map[int]ApprovalResult, len(selected))
+	executePlan(plan, func(action PlannedAction) error {
+		pr := byNumber[action.Number]
+		results[pr.Number] = ApprovalResultSkip
+		// Make sure nothing was pushed since the PR was displayed, so unseen commits aren't approved
+		if !confirmPRUnchanged(client, owner, repo, pr, "approve") {
+			return fmt.Errorf("not approved")
+		}
+		if err := postApproval(client, owner, repo, pr, config.Review); err != nil {
+			return err
+		}
+		results[pr.Number] = ApprovalResultApprove
+		return nil
+	})
+	return results
+}
@@ -0,0 +1,109 @@
+package cmd_test
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Selecting several PRs to approve", func() {
+	Describe("parsing the selection", func() {
+		approvable := []cmd.PullRequest{{Number: 12}, {Number: 15}, {Number: 20}, {Number: 104}, {Number: 108}}
+		held := []cmd.PullRequest{{Number: 30}}
+
+		DescribeTable("should pick the PRs given",
+			func(input string, expected []int) {
+				selected, err := cmd.ParsePRSelectionTest(input, approvable, held)
+				Expect(err).NotTo(HaveOccurred())
+				Expect(prNumbers(selected)).To(Equal(expected))
+			},
+			Entry("a single number", "#15", []int{15}),
+			Entry("a list in the order given", "20, 12,#15", []int{20, 12, 15}),
+			Entry("a range of approvable PRs", "100-110", []int{104, 108}),
+			Entry("a list with a range, each PR once", "108,100-110", []int{108, 104}),
+			Entry("all approvable PRs", "ALL", []int{12, 15, 20, 104, 108}),
+			Entry("a PR on hold given by number", "12,30", []int{12, 30}),
+		)
+
+		DescribeTable("should reject selections that don't pick available PRs",
+			func(input, message string) {
+				_, err := cmd.ParsePRSelectionTest(input, approvable, held)
+				Expect(err).To(MatchError(ContainSubstring(message)))
+			},
+			Entry("not a number", "12,abc", "invalid PR number: abc"),
+			Entry("a reversed range", "110-100", "invalid PR range: 110-100"),
+			Entry("a range without approvable PRs", "25-40", "no PR in 25-40 is available"),
+			Entry("an unknown PR", "12,99", "PR #99 is not available"),
+		)
+	})
+
+	Describe("in the approval loop", func() {
+		var (
+			mockClient *cmd.MockRESTClient
+			out        *bytes.Buffer
+			prs        []cmd.PullRequest
+		)
+
+		reviewsPosted := func() []string {
+			var urls []string
+			for _, req := range mockClient.Requests {
+				if req.Method == "POST" && strings.HasSuffix(req.URL, "/reviews") {
+					urls = append(urls, req.URL)
+				}
+			}
+			return urls
+		}
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			out = &bytes.Buffer{}
+			prs = nil
+			for _, number := range []int{1, 2, 3} {
+				pr := cmd.PullRequest{Number: number, Title: "Update tekton task bundles", State: "open", User: cmd.User{Login: "renovate[bot]"}}
+				prs = append(prs, pr)
+				mockClient.AddResponse(fmt.Sprintf("repos/owner/repo/pulls/%d", number), 200, pr)
+			}
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should walk through a list of PRs back to back", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("1,3\n\ny\ny\n"), out, &bytes.Buffer{}), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			Expect(reviewsPosted()).To(Equal([]string{"repos/owner/repo/pulls/1/reviews", "repos/owner/repo/pulls/3/reviews"}))
+			Expect(out.String()).To(ContainSubstring("Selected 2 PRs: #1, #3"))
+			Expect(out.String()).To(ContainSubstring("✅ Approved: 2"))
+		})
+
+		It("should approve all after one confirmation, leaving out PRs that need a look of their own", func() {
+			prs[1].Body = "⚠️[migration]"
+
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("all\na\ny\n"), out, &bytes.Buffer{}), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			Expect(reviewsPosted()).To(Equal([]string{"repos/owner/repo/pulls/1/reviews", "repos/owner/repo/pulls/3/reviews"}))
+			Expect(out.String()).To(ContainSubstring("migration warning, approve it on its own"))
+			Expect(out.String()).To(ContainSubstring("Proceed with 2 action(s) on owner/repo?"))
+			Expect(out.String()).To(ContainSubstring("✅ Approved: 2"))
+			// The PR with the migration warning is left to be selected on its own
+			Expect(out.String()).To(ContainSubstring("❌ Skipped: 0"))
+			Expect(out.String()).To(ContainSubstring("Available for approval: #2"))
+		})
+
+		It("should approve nothing when the batch isn't confirmed", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("1-3\na\nn\n"), out, &bytes.Buffer{}), nil)
+			cmd.ApprovePRsTest(mockClient, "owner", "repo", prs, false)
+
+			Expect(reviewsPosted()).To(BeEmpty())
+			Expect(out.String()).To(ContainSubstring("Cancelled, no changes were made."))
+		})
+	})
+})
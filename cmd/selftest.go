@@ -0,0 +1,302 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// selftestLabel is the label the self-test adds and removes again
+const selftestLabel = "ghprs-selftest"
+
+// selftestPR is an existing PR of the sandbox repository to run the self-test on instead of opening one
+var selftestPR int
+
+// Outcomes of a self-test step
+const (
+	SelftestPassed  = "passed"
+	SelftestFailed  = "failed"
+	SelftestSkipped = "skipped"
+)
+
+// SelftestStep is the outcome of exercising one capability against the sandbox repository
+type SelftestStep struct {
+	Name    string
+	Outcome string
+	// Detail is the error of a failed step or why a step was skipped
+	Detail string
+}
+
+// selftest exercises what ghprs does to PRs against a sandbox repository
+type selftest struct {
+	client      RESTClientInterface
+	owner, repo string
+	settings    ApprovalSettings
+	steps       []SelftestStep
+	// pr is the PR the write steps act on, nil when there is none
+	pr *PullRequest
+	// branch is the branch of the PR the self-test opened, deleted again at cleanup
+	branch string
+}
+
+// run runs a step, reporting it as it completes
+func (t *selftest) run(name string, step func() error) {
+	if err := step(); err != nil {
+		streams.Printf("   ❌ %s: %v\n", name, err)
+		t.steps = append(t.steps, SelftestStep{Name: name, Outcome: SelftestFailed, Detail: err.Error()})
+		return
+	}
+	streams.Printf("   ✅ %s\n", name)
+	t.steps = append(t.steps, SelftestStep{Name: name, Outcome: SelftestPassed})
+}
+
+// skip records a step that wasn't run
+func (t *selftest) skip(name, reason string) {
+	streams.Printf("   ⏭️  %s: %s\n", name, reason)
+	t.steps = append(t.steps, SelftestStep{Name: name, Outcome: SelftestSkipped, Detail: reason})
+}
+
+// openPR opens a throwaway PR adding one file on a new branch off the default branch
+func (t *selftest) openPR(now time.Time) error {
+	var repository struct {
+		DefaultBranch string `json:"default_branch"`
+	}
+	if err := t.client.Get(fmt.Sprintf("repos/%s/%s", t.owner, t.repo), &repository); err != nil {
+		return fmt.Errorf("failed to fetch the repository: %v", err)
+	}
+	var ref struct {
+		Object struct {
+			SHA string `json:"sha"`
+		} `json:"object"`
+	}
+	refPath := fmt.Sprintf("repos/%s/%s/git/ref/heads/%s", t.owner, t.repo, url.PathEscape(repository.DefaultBranch))
+	if err := t.client.Get(refPath, &ref); err != nil {
+		return fmt.Errorf("failed to fetch branch %s: %v", repository.DefaultBranch, err)
+	}
+
+	name := fmt.Sprintf("ghprs-selftest-%d", now.Unix())
+	if err := t.post(fmt.Sprintf("repos/%s/%s/git/refs", t.owner, t.repo),
+		map[string]string{"ref": "refs/heads/" + name, "sha": ref.Object.SHA}, nil); err != nil {
+		return fmt.Errorf("failed to create branch %s: %v", name, err)
+	}
+	t.branch = name
+
+	content := base64.StdEncoding.EncodeToString([]byte("Opened by 'ghprs selftest', safe to delete.\n"))
+	filePath := fmt.Sprintf("repos/%s/%s/contents/%s.md", t.owner, t.repo, name)
+	fileJSON, err := json.Marshal(map[string]string{"message": "ghprs selftest", "content": content, "branch": name})
+	if err != nil {
+		return fmt.Errorf("failed to marshal file: %v", err)
+	}
+	if err := t.client.Put(filePath, bytes.NewReader(fileJSON), nil); err != nil {
+		return fmt.Errorf("failed to commit to branch %s: %v", name, err)
+	}
+
+	var pr PullRequest
+	if err := t.post(fmt.Sprintf("repos/%s/%s/pulls", t.owner, t.repo), map[string]string{
+		"title": "ghprs selftest",
+		"head":  name,
+		"base":  repository.DefaultBranch,
+		"body":  "Opened by 'ghprs selftest' to check what ghprs can do here. It is closed again when the self-test ends.",
+	}, &pr); err != nil {
+		return fmt.Errorf("failed to open the PR: %v", err)
+	}
+	t.pr = &pr
+	streams.Printf("      Opened %s\n", formatPRLink(t.owner, t.repo, pr.Number))
+	return nil
+}
+
+// post posts body as JSON
+func (t *selftest) post(path string, body any, response any) error {
+	requestJSON, err := json.Marshal(body)
+	if err != nil {
+		return fmt.Errorf("failed to marshal request: %v", err)
+	}
+	return t.client.Post(path, bytes.NewReader(requestJSON), response)
+}
+
+// approve approves the PR with the configured approval settings. GitHub doesn't let anyone approve their own
+// PR, so those are skipped.
+func (t *selftest) approve() {
+	const name = "approve"
+	const ownPR = "GitHub doesn't let you approve your own PR, give --pr with a sandbox PR opened by someone else"
+	if t.branch != "" {
+		t.skip(name, ownPR)
+		return
+	}
+	login, err := viewerLogin(t.client, t.owner, t.repo)
+	if err != nil {
+		t.run(name, func() error { return err })
+		return
+	}
+	if strings.EqualFold(login, t.pr.User.Login) {
+		t.skip(name, ownPR)
+		return
+	}
+	t.run(name, func() error { return postApproval(t.client, t.owner, t.repo, *t.pr, t.settings) })
+}
+
+// cleanup closes the PR the self-test opened and deletes its branch and the self-test label
+func (t *selftest) cleanup() error {
+	var errs []error
+	if t.branch != "" {
+		if t.pr != nil {
+			if err := setPRState(t.client, t.owner, t.repo, t.pr.Number, "closed", ""); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		refPath := fmt.Sprintf("repos/%s/%s/git/refs/heads/%s", t.owner, t.repo, url.PathEscape(t.branch))
+		if err := t.client.Delete(refPath, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete branch %s: %v", t.branch, err))
+		}
+	}
+	if t.pr != nil {
+		// Adding the label to the PR created it in the repository
+		labelPath := fmt.Sprintf("repos/%s/%s/labels/%s", t.owner, t.repo, url.PathEscape(selftestLabel))
+		if err := t.client.Delete(labelPath, nil); err != nil {
+			errs = append(errs, fmt.Errorf("failed to delete label %s: %v", selftestLabel, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// runSelftest lists, comments on, labels, holds and approves a PR of a sandbox repository and cleans up after
+// itself. Without prNumber it opens a throwaway PR. Steps that need a PR are skipped when there is none.
+func runSelftest(client RESTClientInterface, owner, repo string, prNumber int, settings ApprovalSettings, now time.Time) []SelftestStep {
+	t := &selftest{client: client, owner: owner, repo: repo, settings: settings}
+	streams.Printf("🧪 Self-test against %s/%s\n", owner, repo)
+
+	t.run("list pull requests", func() error {
+		var prs []PullRequest
+		return client.Get(fmt.Sprintf("repos/%s/%s/pulls?state=open&per_page=1", owner, repo), &prs)
+	})
+
+	if prNumber > 0 {
+		t.run(fmt.Sprintf("read PR #%d", prNumber), func() error {
+			pr, err := fetchPRDetails(withFreshData(context.Background()), client, owner, repo, prNumber)
+			t.pr = pr
+			return err
+		})
+	} else {
+		t.run("open a PR", func() error { return t.openPR(now) })
+	}
+
+	writeSteps := []string{"comment", "label", "hold and unhold", "approve"}
+	if t.pr == nil {
+		for _, name := range writeSteps {
+			t.skip(name, "no PR to act on")
+		}
+	} else {
+		number := t.pr.Number
+		t.run("comment", func() error {
+			return addCommentToPR(client, owner, repo, number, "Comment posted by 'ghprs selftest', safe to ignore.")
+		})
+		t.run("label", func() error {
+			if err := addLabels(client, owner, repo, number, []string{selftestLabel}); err != nil {
+				return err
+			}
+			return removeLabel(client, owner, repo, number, selftestLabel)
+		})
+		t.run("hold and unhold", func() error {
+			if err := holdPR(client, owner, repo, number, "ghprs selftest", time.Time{}); err != nil {
+				return err
+			}
+			return unholdPR(client, owner, repo, number, "", false)
+		})
+		t.approve()
+	}
+
+	if t.pr != nil || t.branch != "" {
+		t.run("clean up", t.cleanup)
+	}
+	return t.steps
+}
+
+// reportSelftest summarizes which capabilities work, returning how many steps failed
+func reportSelftest(steps []SelftestStep) int {
+	var passed, failed, skipped []string
+	for _, step := range steps {
+		switch step.Outcome {
+		case SelftestPassed:
+			passed = append(passed, step.Name)
+		case SelftestFailed:
+			failed = append(failed, step.Name)
+		default:
+			skipped = append(skipped, step.Name)
+		}
+	}
+	streams.Printf("\n📊 Self-test summary:\n")
+	if len(passed) > 0 {
+		streams.Printf("   ✅ Works: %s\n", strings.Join(passed, ", "))
+	}
+	if len(failed) > 0 {
+		streams.Printf("   ❌ Fails: %s\n", strings.Join(failed, ", "))
+	}
+	if len(skipped) > 0 {
+		streams.Printf("   ⏭️  Not checked: %s\n", strings.Join(skipped, ", "))
+	}
+	return len(failed)
+}
+
+// selftestCmd checks what ghprs can do with the current token and config against a sandbox repository
+var selftestCmd = &cobra.Command{
+	Use:   "selftest",
+	Short: "Check what ghprs can do against a sandbox repository",
+	Long: `Check what ghprs can do with the current token and config by exercising it against a sandbox
+repository: listing PRs, commenting, labeling, holding, approving with the configured approval settings,
+and cleaning up again. Use it to validate a new environment without touching real PRs.
+
+Without --pr a throwaway PR is opened on a new branch and closed again, with its branch deleted, at the
+end. GitHub doesn't let you approve your own PR, so approving is only checked on a sandbox PR opened by
+someone else, given with --pr; that approval stays.
+
+The sandbox repository must be given with --repo, so a real repository is never written to by accident.
+
+Examples:
+  ghprs selftest --repo my-org/ghprs-sandbox
+  ghprs selftest --repo my-org/ghprs-sandbox --pr 7`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		if repoFlag == "" {
+			log.Fatal("Give the sandbox repository with --repo owner/repo")
+		}
+		owner, repo, ok := parseRepoSpec(repoFlag)
+		if !ok {
+			log.Fatalf("Invalid repository format '%s'. Must be 'owner/repo'", repoFlag)
+		}
+
+		if !assumeYes {
+			confirmed, err := prompter.Confirm(fmt.Sprintf("This writes to %s/%s: it comments on, labels, holds and approves a PR. Continue?", owner, repo))
+			if err != nil || !confirmed {
+				streams.Println("Self-test cancelled.")
+				return
+			}
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			config = DefaultConfig()
+		}
+		client := newCommandClient(ctx, owner, repo)
+		if reportSelftest(runSelftest(client, owner, repo, selftestPR, config.Approval, time.Now())) > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(selftestCmd)
+
+	selftestCmd.Flags().IntVar(&selftestPR, "pr", 0, "Run on this PR of the sandbox repository instead of opening one, to also check approving")
+	selftestCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Run without asking for confirmation")
+}
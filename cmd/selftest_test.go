@@ -0,0 +1,98 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Self-test", func() {
+	var (
+		mockClient *cmd.MockRESTClient
+		out        *bytes.Buffer
+		now        = time.Unix(1700000000, 0)
+	)
+
+	outcomes := func(steps []cmd.SelftestStep) map[string]string {
+		byName := map[string]string{}
+		for _, step := range steps {
+			byName[step.Name] = step.Outcome
+		}
+		return byName
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	It("should open a throwaway PR, act on it and clean up after itself", func() {
+		mockClient.AddResponse("repos/owner/sandbox", 200, map[string]string{"default_branch": "main"})
+		mockClient.AddResponse("repos/owner/sandbox/pulls?state=open", 200, []cmd.PullRequest{})
+		mockClient.AddResponse("repos/owner/sandbox/git/ref/heads/main", 200, map[string]any{"object": map[string]string{"sha": "abc123"}})
+		mockClient.AddResponse("repos/owner/sandbox/pulls", 201, cmd.PullRequest{Number: 5, State: "open"})
+
+		steps, failed := cmd.RunSelftestTest(mockClient, "owner", "sandbox", 0, cmd.ApprovalSettings{}, now)
+
+		Expect(failed).To(Equal(0))
+		Expect(outcomes(steps)).To(Equal(map[string]string{
+			"list pull requests": cmd.SelftestPassed,
+			"open a PR":          cmd.SelftestPassed,
+			"comment":            cmd.SelftestPassed,
+			"label":              cmd.SelftestPassed,
+			"hold and unhold":    cmd.SelftestPassed,
+			"approve":            cmd.SelftestSkipped,
+			"clean up":           cmd.SelftestPassed,
+		}))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/sandbox/git/refs")).To(Equal([]string{`{"ref":"refs/heads/ghprs-selftest-1700000000","sha":"abc123"}`}))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/sandbox/pulls/5/reviews")).To(BeEmpty())
+		Expect(mockClient.RequestBodies("PATCH", "repos/owner/sandbox/pulls/5")).To(Equal([]string{`{"state":"closed"}`}))
+		Expect(mockClient.RequestBodies("DELETE", "repos/owner/sandbox/git/refs/heads/ghprs-selftest-1700000000")).To(HaveLen(1))
+		Expect(mockClient.RequestBodies("DELETE", "repos/owner/sandbox/labels/ghprs-selftest")).To(HaveLen(1))
+		Expect(out.String()).To(ContainSubstring("GitHub doesn't let you approve your own PR"))
+	})
+
+	It("should approve a sandbox PR opened by someone else", func() {
+		mockClient.AddResponse("repos/owner/sandbox", 200, map[string]string{})
+		mockClient.AddResponse("repos/owner/sandbox/pulls?state=open", 200, []cmd.PullRequest{})
+		mockClient.AddResponse("repos/owner/sandbox/pulls/7", 200, cmd.PullRequest{Number: 7, State: "open", User: cmd.User{Login: "teammate"}})
+		mockClient.AddResponse("repos/owner/sandbox/pulls/7/reviews", 200, []cmd.Review{})
+		mockClient.AddResponse("user", 200, cmd.User{Login: "me"})
+
+		steps, failed := cmd.RunSelftestTest(mockClient, "owner", "sandbox", 7, cmd.ApprovalSettings{}, now)
+
+		Expect(failed).To(Equal(0))
+		Expect(outcomes(steps)).To(HaveKeyWithValue("approve", cmd.SelftestPassed))
+		Expect(mockClient.RequestBodies("POST", "repos/owner/sandbox/pulls/7/reviews")).To(HaveLen(1))
+		// The PR isn't the self-test's to close
+		Expect(mockClient.RequestBodies("PATCH", "repos/owner/sandbox/pulls/7")).To(BeEmpty())
+	})
+
+	It("should report what the token can't do and skip what needs a PR", func() {
+		mockClient.AddResponse("repos/owner/sandbox", 403, map[string]string{"message": "Resource not accessible"})
+
+		steps, failed := cmd.RunSelftestTest(mockClient, "owner", "sandbox", 0, cmd.ApprovalSettings{}, now)
+
+		Expect(failed).To(Equal(2))
+		Expect(outcomes(steps)).To(Equal(map[string]string{
+			"list pull requests": cmd.SelftestFailed,
+			"open a PR":          cmd.SelftestFailed,
+			"comment":            cmd.SelftestSkipped,
+			"label":              cmd.SelftestSkipped,
+			"hold and unhold":    cmd.SelftestSkipped,
+			"approve":            cmd.SelftestSkipped,
+		}))
+		Expect(out.String()).To(ContainSubstring("❌ Fails: list pull requests, open a PR"))
+		Expect(out.String()).To(ContainSubstring("⏭️  Not checked: comment, label, hold and unhold, approve"))
+	})
+})
@@ -0,0 +1,323 @@
+package cmd
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/pkg/ghprs"
+)
+
+// serveTokenEnv names the environment variable holding the token that POST /approve requires
+const serveTokenEnv = "GHPRS_SERVE_TOKEN"
+
+// defaultServeAddr only listens locally, exposing the server further is a deliberate choice
+const defaultServeAddr = "127.0.0.1:8080"
+
+var (
+	// serveAddr is the address the server listens on
+	serveAddr string
+	// serveNoCache makes the server fetch everything from GitHub, ignoring the on-disk PR cache
+	serveNoCache bool
+)
+
+// ApproveRequest is the body of POST /approve
+type ApproveRequest struct {
+	// Repository is "owner/repo", one of the configured repositories
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	// HeadSHA is the head commit the caller looked at; the PR isn't approved if it has moved on
+	HeadSHA string `json:"headSha"`
+}
+
+// ApproveResponse is what POST /approve answers after approving a PR
+type ApproveResponse struct {
+	Repository string `json:"repository"`
+	Number     int    `json:"number"`
+	Approved   bool   `json:"approved"`
+	ReviewID   int64  `json:"reviewId,omitempty"`
+	// CommentErrors maps the configured extra comments that couldn't be posted to why
+	CommentErrors map[string]string `json:"commentErrors,omitempty"`
+}
+
+// errorResponse is the body of every error the server answers with
+type errorResponse struct {
+	Error string `json:"error"`
+}
+
+// prServer answers the HTTP API of 'ghprs serve' for the configured repositories
+type prServer struct {
+	config *Config
+	// opts are the options the PRs are listed with, the defaults of list with those of the config
+	opts *listOptions
+	// token guards POST /approve, which is disabled when it is empty
+	token string
+	// newClient creates the GitHub client of a repository, bound to the request's context
+	newClient func(ctx context.Context, owner, repo string) (RESTClientInterface, error)
+}
+
+// handler routes the requests of the server
+func (s *prServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", func(w http.ResponseWriter, r *http.Request) {
+		writeJSON(w, http.StatusOK, map[string]string{"status": "ok"})
+	})
+	mux.HandleFunc("GET /repos/{owner}/{repo}/pulls", s.listPulls)
+	mux.HandleFunc("POST /approve", s.approve)
+	return mux
+}
+
+// configured reports whether a repository is in the config; the server only works on those, so its
+// callers can't use the server's GitHub credentials on anything else
+func (s *prServer) configured(repoSpec string) bool {
+	return slices.ContainsFunc(s.config.Repositories, func(repo RepositoryConfig) bool {
+		return strings.EqualFold(repo.Name, repoSpec)
+	})
+}
+
+// isKonfluxRepository reports whether a repository is configured as a Konflux repository
+func (s *prServer) isKonfluxRepository(repoSpec string) bool {
+	return slices.ContainsFunc(s.config.Repositories, func(repo RepositoryConfig) bool {
+		return strings.EqualFold(repo.Name, repoSpec) && repo.Konflux
+	})
+}
+
+// listPulls answers the enriched rows of the PR table of a repository, the same data as 'ghprs list --output json'.
+// ?konflux=true lists the Konflux queue instead, the default for Konflux repositories.
+func (s *prServer) listPulls(w http.ResponseWriter, r *http.Request) {
+	owner, repo := r.PathValue("owner"), r.PathValue("repo")
+	repoSpec := owner + "/" + repo
+	if !s.configured(repoSpec) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s is not a configured repository", repoSpec))
+		return
+	}
+	isKonflux := s.isKonfluxRepository(repoSpec)
+	if value := r.URL.Query().Get("konflux"); value != "" {
+		isKonflux = value == "true" || value == "1"
+	}
+
+	client, err := s.newClient(r.Context(), owner, repo)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	var authors []string
+	if isKonflux {
+		authors = []string{konfluxBotAuthor}
+	}
+	pullRequests, client, err := fetchRepositoryPRs(r.Context(), s.opts, client, owner, repo, queueAuthors(s.config, repoSpec, authors, isKonflux), isKonflux)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to fetch pull requests: %w", err))
+		return
+	}
+
+	output := RepositoryPRs{Repository: repoSpec, PullRequests: buildPRRows(r.Context(), s.opts, pullRequests, owner, repo, client, isKonflux, nil)}
+	if isKonflux {
+		output.Application = applicationFor(repoSpec)
+	}
+	writeJSON(w, http.StatusOK, output)
+}
+
+// authorized reports whether a request carries the approval token as a bearer token
+func (s *prServer) authorized(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+}
+
+// approve approves a PR as the caller looked at it, with the configured review and extra comments. PRs that
+// are drafts, on hold, or change CI or ownership files without a trusted author are refused like in batches.
+func (s *prServer) approve(w http.ResponseWriter, r *http.Request) {
+	if s.token == "" {
+		writeError(w, http.StatusForbidden, fmt.Errorf("approvals are disabled, set %s to enable them", serveTokenEnv))
+		return
+	}
+	if !s.authorized(r) {
+		writeError(w, http.StatusUnauthorized, errors.New("missing or wrong bearer token"))
+		return
+	}
+
+	var request ApproveRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, 1<<16)).Decode(&request); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+	owner, repo, ok := parseRepoSpec(request.Repository)
+	if !ok || request.Number <= 0 || request.HeadSHA == "" {
+		writeError(w, http.StatusBadRequest, errors.New("repository (owner/repo), number and headSha are required"))
+		return
+	}
+	if !s.configured(request.Repository) {
+		writeError(w, http.StatusNotFound, fmt.Errorf("%s is not a configured repository", request.Repository))
+		return
+	}
+
+	client, err := s.newClient(r.Context(), owner, repo)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err)
+		return
+	}
+	pr, err := fetchPRDetails(withFreshData(r.Context()), client, owner, repo, request.Number)
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to fetch the PR: %w", err))
+		return
+	}
+	if pr.Head.SHA != request.HeadSHA {
+		writeError(w, http.StatusConflict, ghprs.ErrHeadChanged)
+		return
+	}
+	switch {
+	case pr.State != "" && pr.State != "open":
+		writeError(w, http.StatusConflict, fmt.Errorf("the PR is %s", pr.State))
+		return
+	case pr.Draft:
+		writeError(w, http.StatusConflict, errors.New("the PR is a draft"))
+		return
+	case isOnHold(*pr):
+		writeError(w, http.StatusConflict, errors.New("the PR is on hold"))
+		return
+	}
+	settings := s.config.ApprovalFor(request.Repository)
+	if reason := batchApprovalBlocker(repoPR{Owner: owner, Repo: repo, Client: client, PR: *pr}, s.config.TrustedAuthors(), settings.MaxChanges); reason != "" {
+		writeError(w, http.StatusConflict, errors.New(reason))
+		return
+	}
+
+	approval, err := ghprs.Approve(r.Context(), client, owner, repo, *pr, ghprs.ApprovalOptions{
+		Body:          settings.ReviewBody(),
+		Event:         settings.ReviewEvent(),
+		ExtraComments: settings.ExtraComments,
+	})
+	if errors.Is(err, ghprs.ErrHeadChanged) {
+		writeError(w, http.StatusConflict, err)
+		return
+	}
+	if err != nil {
+		writeError(w, http.StatusBadGateway, fmt.Errorf("failed to approve: %w", err))
+		return
+	}
+	logger.Info("Approved PR", "repo", request.Repository, "pr", request.Number, "head", request.HeadSHA)
+	auditLog.record(client, owner, repo, request.Number, AuditApprove, settings.ReviewBody())
+	for _, comment := range settings.ExtraComments {
+		if _, failed := approval.CommentErrors[comment]; !failed {
+			auditLog.record(client, owner, repo, request.Number, AuditComment, comment)
+		}
+	}
+
+	response := ApproveResponse{Repository: request.Repository, Number: request.Number, Approved: true, ReviewID: approval.Review.ID}
+	for comment, err := range approval.CommentErrors {
+		if response.CommentErrors == nil {
+			response.CommentErrors = make(map[string]string)
+		}
+		response.CommentErrors[comment] = err.Error()
+	}
+	writeJSON(w, http.StatusOK, response)
+}
+
+// writeJSON answers with value as JSON
+func writeJSON(w http.ResponseWriter, status int, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		logger.Debug("Could not write the response", "error", err)
+	}
+}
+
+// writeError answers with an error as JSON
+func writeError(w http.ResponseWriter, status int, err error) {
+	logger.Debug("Request failed", "status", status, "error", err)
+	writeJSON(w, status, errorResponse{Error: err.Error()})
+}
+
+// serveCmd runs an HTTP server exposing the PR table and approvals to automation
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serve the enriched PR data and approvals over HTTP for automation",
+	Long: `Run an HTTP server so CI jobs or chat bots can use the PR data and approvals of ghprs without
+running it interactively. Only the configured repositories are served.
+
+Endpoints:
+  GET  /healthz                      reports that the server is up
+  GET  /repos/{owner}/{repo}/pulls   the rows of the PR table as JSON, the same as 'ghprs list --output json'
+                                     (?konflux=true for the Konflux queue, the default for Konflux repositories)
+  POST /approve                      approves a PR with the configured review and extra comments; the body is
+                                     {"repository": "owner/repo", "number": 123, "headSha": "<sha>"} and
+                                     the PR isn't approved when its head moved on from headSha
+
+POST /approve needs the token of the ` + serveTokenEnv + ` environment variable as a bearer token
+(Authorization: Bearer <token>) and is disabled when the variable isn't set. Drafts, PRs on hold and
+PRs that change CI or ownership files without a trusted author are refused.
+
+The server listens on ` + defaultServeAddr + ` unless --addr says otherwise.
+
+Examples:
+  ghprs serve
+  GHPRS_SERVE_TOKEN=$(openssl rand -hex 32) ghprs serve --addr :8080
+  curl localhost:8080/repos/owner/repo/pulls
+  curl -H "Authorization: Bearer $GHPRS_SERVE_TOKEN" -d '{"repository":"owner/repo","number":1,"headSha":"abc"}' localhost:8080/approve`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		if len(config.Repositories) == 0 {
+			log.Fatal("No repositories configured, add them with 'ghprs config add-repo owner/repo'")
+		}
+		opts := newListOptions()
+		applyConfigDefaults(opts, config)
+		setRepositoryHosts(config)
+		setKonfluxComponents(config)
+		staleCheckAfter = config.StaleCheckAfter()
+
+		var cache *diskCache
+		if !serveNoCache {
+			cache = newDiskCache(getCacheDir(), config.CacheTTL())
+		}
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		server := &prServer{
+			config: config,
+			opts:   opts,
+			token:  os.Getenv(serveTokenEnv),
+			newClient: func(ctx context.Context, owner, repo string) (RESTClientInterface, error) {
+				client, err := newAPIClient(hostFor(owner, repo), limiter, cache)
+				if err != nil {
+					return nil, err
+				}
+				return withContext(client, ctx), nil
+			},
+		}
+		if server.token == "" {
+			logger.Warn("Approvals are disabled, set " + serveTokenEnv + " to enable POST /approve")
+		}
+
+		httpServer := &http.Server{Addr: serveAddr, Handler: server.handler(), ReadHeaderTimeout: 10 * time.Second}
+		go func() {
+			<-ctx.Done()
+			shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			_ = httpServer.Shutdown(shutdownCtx)
+		}()
+		streams.Printf("🌐 Serving %d repositories on http://%s\n", len(config.Repositories), serveAddr)
+		if err := httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatal(err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(serveCmd)
+	serveCmd.Flags().StringVar(&serveAddr, "addr", defaultServeAddr, "Address to listen on")
+	serveCmd.Flags().BoolVar(&serveNoCache, "no-cache", false, "Ignore the on-disk PR cache and fetch everything from GitHub")
+}
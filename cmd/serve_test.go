@@ -0,0 +1,149 @@
+package cmd_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Serve", func() {
+	const token = "s3cret"
+	var mockClient *cmd.MockRESTClient
+	var handler http.Handler
+
+	pr := cmd.PullRequest{Number: 1, Title: "Update go", State: "open", User: cmd.User{Login: "renovate[bot]"}, Head: cmd.Branch{SHA: "sha1"}}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		config := cmd.DefaultConfig()
+		config.Repositories = []cmd.RepositoryConfig{{Name: "owner/repo"}}
+		handler = cmd.ServeHandlerTest(config, token, mockClient)
+	})
+
+	serve := func(method, path, auth, body string) *httptest.ResponseRecorder {
+		request := httptest.NewRequest(method, path, strings.NewReader(body))
+		if auth != "" {
+			request.Header.Set("Authorization", "Bearer "+auth)
+		}
+		recorder := httptest.NewRecorder()
+		handler.ServeHTTP(recorder, request)
+		return recorder
+	}
+	approve := func(auth, body string) *httptest.ResponseRecorder {
+		return serve(http.MethodPost, "/approve", auth, body)
+	}
+	posted := func() []string {
+		var urls []string
+		for _, request := range mockClient.Requests {
+			if request.Method == "POST" {
+				urls = append(urls, request.URL)
+			}
+		}
+		return urls
+	}
+
+	It("should report that it is up", func() {
+		Expect(serve(http.MethodGet, "/healthz", "", "").Code).To(Equal(http.StatusOK))
+	})
+
+	It("should list the rows of the PR table of a configured repository", func() {
+		mockClient.AddResponse("repos/owner/repo/pulls?state=open", 200, []cmd.PullRequest{pr})
+
+		response := serve(http.MethodGet, "/repos/owner/repo/pulls", "", "")
+		Expect(response.Code).To(Equal(http.StatusOK))
+		var output cmd.RepositoryPRs
+		Expect(json.Unmarshal(response.Body.Bytes(), &output)).To(Succeed())
+		Expect(output.Repository).To(Equal("owner/repo"))
+		Expect(output.PullRequests).To(HaveLen(1))
+		Expect(output.PullRequests[0].Title).To(Equal("Update go"))
+	})
+
+	It("should only serve configured repositories", func() {
+		Expect(serve(http.MethodGet, "/repos/other/repo/pulls", "", "").Code).To(Equal(http.StatusNotFound))
+		Expect(approve(token, `{"repository":"other/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusNotFound))
+	})
+
+	Describe("approving", func() {
+		BeforeEach(func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, pr)
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, cmd.Review{ID: 42})
+		})
+
+		It("should require the token", func() {
+			Expect(approve("", `{"repository":"owner/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusUnauthorized))
+			Expect(approve("wrong", `{"repository":"owner/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusUnauthorized))
+			Expect(posted()).To(BeEmpty())
+		})
+
+		It("should be disabled without a token", func() {
+			config := cmd.DefaultConfig()
+			config.Repositories = []cmd.RepositoryConfig{{Name: "owner/repo"}}
+			handler = cmd.ServeHandlerTest(config, "", mockClient)
+			Expect(approve("", `{"repository":"owner/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusForbidden))
+		})
+
+		It("should approve the PR at the head the caller looked at", func() {
+			response := approve(token, `{"repository":"owner/repo","number":1,"headSha":"sha1"}`)
+			Expect(response.Code).To(Equal(http.StatusOK))
+			var result cmd.ApproveResponse
+			Expect(json.Unmarshal(response.Body.Bytes(), &result)).To(Succeed())
+			Expect(result.Approved).To(BeTrue())
+			Expect(result.ReviewID).To(Equal(int64(42)))
+			Expect(posted()).To(Equal([]string{"repos/owner/repo/pulls/1/reviews"}))
+		})
+
+		It("should approve with the approval settings of the repository", func() {
+			body := "/lgtm from the dashboard"
+			config := cmd.DefaultConfig()
+			config.Repositories = []cmd.RepositoryConfig{{Name: "owner/repo", Approval: &cmd.ApprovalSettings{Body: &body}}}
+			handler = cmd.ServeHandlerTest(config, token, mockClient)
+
+			Expect(approve(token, `{"repository":"owner/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusOK))
+			bodies := mockClient.RequestBodies("POST", "repos/owner/repo/pulls/1/reviews")
+			Expect(bodies).To(HaveLen(1))
+			Expect(bodies[0]).To(ContainSubstring(body))
+		})
+
+		It("should record the approval in the audit log", func() {
+			auditPath := filepath.Join(GinkgoT().TempDir(), "audit.jsonl")
+			cmd.EnableAuditLogTest(auditPath)
+			defer cmd.DisableAuditLogTest()
+			mockClient.AddResponse("user", 200, cmd.User{Login: "reviewer"})
+
+			Expect(approve(token, `{"repository":"owner/repo","number":1,"headSha":"sha1"}`).Code).To(Equal(http.StatusOK))
+			entries, err := cmd.ReadAuditLogTest(auditPath, "owner/repo", time.Time{})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(entries).To(HaveLen(1))
+			Expect(entries[0].Action).To(Equal(cmd.AuditApprove))
+			Expect(entries[0].Number).To(Equal(1))
+			Expect(entries[0].User).To(Equal("reviewer"))
+		})
+
+		It("should refuse a PR whose head moved on", func() {
+			Expect(approve(token, `{"repository":"owner/repo","number":1,"headSha":"old"}`).Code).To(Equal(http.StatusConflict))
+			Expect(posted()).To(BeEmpty())
+		})
+
+		It("should refuse PRs on hold", func() {
+			held := pr
+			held.Labels = []cmd.Label{{Name: "do-not-merge/hold"}}
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, held)
+			response := approve(token, `{"repository":"owner/repo","number":1,"headSha":"sha1"}`)
+			Expect(response.Code).To(Equal(http.StatusConflict))
+			Expect(response.Body.String()).To(ContainSubstring("on hold"))
+		})
+
+		It("should reject incomplete requests", func() {
+			Expect(approve(token, `{"repository":"owner/repo","number":1}`).Code).To(Equal(http.StatusBadRequest))
+			Expect(approve(token, `not json`).Code).To(Equal(http.StatusBadRequest))
+		})
+	})
+})
@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// envPrefix starts the names of the environment variables that set flags, e.g. GHPRS_STATE for --state
+const envPrefix = "GHPRS_"
+
+// configEnvVar names the environment variable of --config
+const configEnvVar = envPrefix + "CONFIG"
+
+// flagsWithOwnEnv are the flags that read their environment variable themselves, to tell where their value
+// came from
+var flagsWithOwnEnv = map[string]bool{"token": true, "profile": true, "help": true}
+
+// flagEnvVar returns the environment variable that sets a flag: GHPRS_ and the flag's name in capitals, with
+// dashes replaced by underscores
+func flagEnvVar(name string) string {
+	return envPrefix + strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+}
+
+// applyEnvOverrides sets the flags of a command that weren't given on the command line from their environment
+// variables, so a flag wins over the environment, which wins over the config file and the defaults. Flags set
+// this way count as given, so config defaults don't replace them.
+func applyEnvOverrides(cmd *cobra.Command, lookupEnv func(string) (string, bool)) error {
+	var errs []error
+	flags := cmd.Flags()
+	flags.VisitAll(func(flag *pflag.Flag) {
+		if flag.Changed || flagsWithOwnEnv[flag.Name] {
+			return
+		}
+		value, ok := lookupEnv(flagEnvVar(flag.Name))
+		if !ok {
+			return
+		}
+		if err := flags.Set(flag.Name, value); err != nil {
+			errs = append(errs, fmt.Errorf("invalid %s=%q: %v", flagEnvVar(flag.Name), value, err))
+			return
+		}
+		logger.Debug("Flag set from the environment", "flag", flag.Name, "env", flagEnvVar(flag.Name))
+	})
+	return errors.Join(errs...)
+}
+
+func init() {
+	// The environment sets the flags first, so logging, the emoji and the UI settings follow GHPRS_DEBUG,
+	// GHPRS_VERBOSE and GHPRS_CONFIG like they follow the flags
+	RootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyEnvOverrides(cmd, os.LookupEnv); err != nil {
+			return err
+		}
+		setupLogging()
+		setupEmoji()
+		loadUISettings()
+		announceTokenOverride()
+		return nil
+	}
+	RootCmd.PersistentFlags().StringVar(&configPath, "config", "", "Config file to use instead of ~/.config/ghprs/config.yaml and the profiles (or set "+configEnvVar+")")
+}
@@ -0,0 +1,81 @@
+package cmd_test
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/spf13/cobra"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Settings from the environment", func() {
+	var (
+		command *cobra.Command
+		state   string
+		limit   int
+		noColor bool
+		authors []string
+		token   string
+	)
+
+	env := func(values map[string]string) func(string) (string, bool) {
+		return func(name string) (string, bool) {
+			value, ok := values[name]
+			return value, ok
+		}
+	}
+
+	BeforeEach(func() {
+		command = &cobra.Command{Use: "test", Run: func(*cobra.Command, []string) {}}
+		command.Flags().StringVar(&state, "state", "open", "")
+		command.Flags().IntVar(&limit, "limit", 30, "")
+		command.Flags().BoolVar(&noColor, "no-color", false, "")
+		command.Flags().StringSliceVar(&authors, "author", nil, "")
+		command.Flags().StringVar(&token, "token", "", "")
+	})
+
+	It("should set the flags not given from their GHPRS_ variables", func() {
+		Expect(command.ParseFlags([]string{"--state", "closed"})).To(Succeed())
+
+		Expect(cmd.ApplyEnvOverridesTest(command, env(map[string]string{
+			"GHPRS_STATE":    "all",
+			"GHPRS_LIMIT":    "50",
+			"GHPRS_NO_COLOR": "1",
+			"GHPRS_AUTHOR":   "alice,bob",
+		}))).To(Succeed())
+
+		Expect(state).To(Equal("closed"))
+		Expect(limit).To(Equal(50))
+		Expect(noColor).To(BeTrue())
+		Expect(authors).To(Equal([]string{"alice", "bob"}))
+		Expect(command.Flags().Changed("limit")).To(BeTrue())
+	})
+
+	It("should leave the flags that read their variable themselves", func() {
+		Expect(cmd.ApplyEnvOverridesTest(command, env(map[string]string{"GHPRS_TOKEN": "secret"}))).To(Succeed())
+		Expect(token).To(BeEmpty())
+	})
+
+	It("should report values the flag rejects", func() {
+		err := cmd.ApplyEnvOverridesTest(command, env(map[string]string{"GHPRS_LIMIT": "many"}))
+		Expect(err).To(MatchError(ContainSubstring(`invalid GHPRS_LIMIT="many"`)))
+	})
+
+	It("should set up logging and the UI from the environment of a command", func() {
+		configFile := filepath.Join(GinkgoT().TempDir(), "config.yaml")
+		Expect(os.WriteFile(configFile, []byte("ui:\n  pager: most\n"), 0o600)).To(Succeed())
+		GinkgoT().Setenv("GHPRS_DEBUG", "true")
+		GinkgoT().Setenv("GHPRS_CONFIG", configFile)
+		defer cmd.SetUISettingsTest(cmd.UIConfig{})
+		defer cmd.SetLogFlagsTest(false, false)
+
+		Expect(cmd.ExecuteTest(io.Discard, "help")).To(Succeed())
+		Expect(cmd.DebugLoggingTest()).To(BeTrue())
+		Expect(cmd.UISettingsTest().Pager).To(Equal("most"))
+	})
+})
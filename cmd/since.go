@@ -0,0 +1,236 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+)
+
+// maxFailedCheckNames is how many failed checks are named in an activity report
+const maxFailedCheckNames = 3
+
+// TimelineEvent is an event on the timeline of a PR
+type TimelineEvent struct {
+	Event     string `json:"event"`
+	Actor     *User  `json:"actor,omitempty"`
+	CreatedAt string `json:"created_at,omitempty"`
+	// User, State and SubmittedAt describe a review
+	User        *User  `json:"user,omitempty"`
+	State       string `json:"state,omitempty"`
+	SubmittedAt string `json:"submitted_at,omitempty"`
+	// Committer is set on committed events, which have no created_at
+	Committer *GitActor `json:"committer,omitempty"`
+	Label     *Label    `json:"label,omitempty"`
+}
+
+// GitActor is the author or committer of a commit
+type GitActor struct {
+	Name string `json:"name"`
+	Date string `json:"date"`
+}
+
+// time returns when the event happened
+func (e TimelineEvent) time() time.Time {
+	switch {
+	case e.Committer != nil:
+		return parseConversationTime(e.Committer.Date)
+	case e.SubmittedAt != "":
+		return parseConversationTime(e.SubmittedAt)
+	default:
+		return parseConversationTime(e.CreatedAt)
+	}
+}
+
+// login returns who caused the event
+func (e TimelineEvent) login() string {
+	if e.User != nil && e.User.Login != "" {
+		return e.User.Login
+	}
+	if e.Actor != nil {
+		return e.Actor.Login
+	}
+	return ""
+}
+
+// parseSinceWindow returns the start of a --since window ending now, the zero time without a window
+func parseSinceWindow(window string, now time.Time) (time.Time, error) {
+	if window == "" {
+		return time.Time{}, nil
+	}
+	duration, err := parseHoldDuration(window)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --since %q (use e.g. 8h, 2d or 1w)", window)
+	}
+	return now.Add(-duration), nil
+}
+
+// prActivityEvents maps the timeline events reported as they are to their description
+var prActivityEvents = map[string]string{
+	"merged":           "🎉 merged",
+	"closed":           "🚪 closed",
+	"reopened":         "🔓 reopened",
+	"ready_for_review": "📣 marked ready for review",
+	"convert_to_draft": "📝 converted to draft",
+}
+
+// describeActivity lists what happened on a PR since a time: new commits and force pushes, comments,
+// reviews, label changes, state changes and the checks that finished
+func describeActivity(events []TimelineEvent, runs []CheckRun, statuses []StatusCheck, since time.Time) []string {
+	var commits, forcePushes int
+	var commenters, labels, messages []string
+	comments := 0
+	for _, event := range events {
+		if event.time().Before(since) {
+			continue
+		}
+		switch event.Event {
+		case "committed":
+			commits++
+		case "head_ref_force_pushed":
+			forcePushes++
+		case "commented":
+			comments++
+			if login := event.login(); login != "" && !slices.Contains(commenters, login) {
+				commenters = append(commenters, login)
+			}
+		case "reviewed":
+			switch strings.ToLower(event.State) {
+			case "approved":
+				messages = append(messages, "✅ approved by "+event.login())
+			case "changes_requested":
+				messages = append(messages, "✋ changes requested by "+event.login())
+			case "commented":
+				messages = append(messages, "👀 reviewed by "+event.login())
+			}
+		case "labeled", "unlabeled":
+			if event.Label == nil {
+				continue
+			}
+			sign := "+"
+			if event.Event == "unlabeled" {
+				sign = "-"
+			}
+			labels = append(labels, sign+event.Label.Name)
+		default:
+			if message, ok := prActivityEvents[event.Event]; ok {
+				messages = append(messages, message)
+			}
+		}
+	}
+
+	var activity []string
+	if commits > 0 {
+		activity = append(activity, fmt.Sprintf("📝 %s", pluralize(commits, "new commit")))
+	}
+	if forcePushes > 0 {
+		activity = append(activity, fmt.Sprintf("🔀 force-pushed %s", pluralize(forcePushes, "time")))
+	}
+	if comments > 0 {
+		activity = append(activity, fmt.Sprintf("💬 %s by %s", pluralize(comments, "new comment"), strings.Join(commenters, ", ")))
+	}
+	activity = append(activity, messages...)
+	if len(labels) > 0 {
+		activity = append(activity, "🏷️  labels "+strings.Join(labels, ", "))
+	}
+	return append(activity, describeCheckResults(runs, statuses, since)...)
+}
+
+// describeCheckResults lists the checks that finished since a time, naming the failed ones
+func describeCheckResults(runs []CheckRun, statuses []StatusCheck, since time.Time) []string {
+	passed := 0
+	var failed []string
+	for _, run := range runs {
+		if run.Status != "completed" || run.CompletedAt == nil || run.CompletedAt.Before(since) {
+			continue
+		}
+		if checkRunFailed(run) {
+			failed = append(failed, run.Name)
+		} else if run.Conclusion == "success" {
+			passed++
+		}
+	}
+	for _, status := range statuses {
+		if status.CreatedAt == nil || status.CreatedAt.Before(since) {
+			continue
+		}
+		if statusCheckFailed(status) {
+			failed = append(failed, status.Context)
+		} else if status.State == "success" {
+			passed++
+		}
+	}
+
+	var results []string
+	if len(failed) > 0 {
+		names := strings.Join(failed, ", ")
+		if len(failed) > maxFailedCheckNames {
+			names = fmt.Sprintf("%s and %d more", strings.Join(failed[:maxFailedCheckNames], ", "), len(failed)-maxFailedCheckNames)
+		}
+		results = append(results, fmt.Sprintf("❌ %s failed: %s", pluralize(len(failed), "check"), names))
+	}
+	if passed > 0 {
+		results = append(results, fmt.Sprintf("✅ %s passed", pluralize(passed, "check")))
+	}
+	return results
+}
+
+// pluralize returns "1 thing" or "n things"
+func pluralize(n int, noun string) string {
+	if n == 1 {
+		return "1 " + noun
+	}
+	return fmt.Sprintf("%d %ss", n, noun)
+}
+
+// fetchActivity fetches the timeline and the check results of a PR and describes what happened since a
+// time. The timeline is cached on disk like the other PR data, while the PR isn't updated.
+func fetchActivity(ctx context.Context, client RESTClientInterface, owner, repo string, pr PullRequest, since time.Time) ([]string, error) {
+	events, err := fetchPages[TimelineEvent](ctx, client, fmt.Sprintf("repos/%s/%s/issues/%d/timeline", owner, repo, pr.Number))
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch the timeline: %w", err)
+	}
+
+	var runs CheckRunsResponse
+	var statuses struct {
+		Statuses []StatusCheck `json:"statuses"`
+	}
+	if pr.Head.SHA != "" {
+		runsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs", owner, repo, pr.Head.SHA)
+		if err := client.DoWithContext(ctx, http.MethodGet, runsPath, nil, &runs); err != nil {
+			return nil, fmt.Errorf("failed to fetch check runs: %w", err)
+		}
+		statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status", owner, repo, pr.Head.SHA)
+		if err := client.DoWithContext(ctx, http.MethodGet, statusPath, nil, &statuses); err != nil {
+			return nil, fmt.Errorf("failed to fetch status checks: %w", err)
+		}
+	}
+	return describeActivity(events, runs.CheckRuns, statuses.Statuses, since), nil
+}
+
+// reportActivity prints what happened on each PR since a time, in the order of the PR table, fetching the
+// activity of concurrency PRs at a time
+func reportActivity(ctx context.Context, client RESTClientInterface, owner, repo string, prs []PullRequest, since time.Time, concurrency int) {
+	activities := make([][]string, len(prs))
+	errs := make([]error, len(prs))
+	runConcurrently(len(prs), concurrency, func(i int) {
+		activities[i], errs[i] = fetchActivity(ctx, client, owner, repo, prs[i], since)
+	})
+
+	streams.Printf("\n🕘 Activity on %s/%s since %s:\n", owner, repo, since.Local().Format("Jan 2 15:04"))
+	for i, pr := range prs {
+		streams.Printf("  %s %s\n", formatPRLink(owner, repo, pr.Number), pr.Title)
+		switch {
+		case errs[i] != nil:
+			streams.Printf("      ⚠️  %v\n", errs[i])
+		case len(activities[i]) == 0:
+			streams.Printf("      ✏️  updated (no new commits, comments, reviews or check results)\n")
+		default:
+			for _, line := range activities[i] {
+				streams.Printf("      %s\n", line)
+			}
+		}
+	}
+}
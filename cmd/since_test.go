@@ -0,0 +1,180 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Since", func() {
+	now := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	since := now.Add(-8 * time.Hour)
+	at := func(offset time.Duration) string { return since.Add(offset).Format(time.RFC3339) }
+	atTime := func(offset time.Duration) *time.Time {
+		t := since.Add(offset)
+		return &t
+	}
+
+	Describe("Parsing the window", func() {
+		It("should return the start of the window", func() {
+			start, err := cmd.ParseSinceWindowTest("8h", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start).To(Equal(since))
+
+			start, err = cmd.ParseSinceWindowTest("2d", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start).To(Equal(now.Add(-48 * time.Hour)))
+		})
+
+		It("should return the zero time without a window", func() {
+			start, err := cmd.ParseSinceWindowTest("", now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(start.IsZero()).To(BeTrue())
+		})
+
+		It("should reject an invalid window", func() {
+			_, err := cmd.ParseSinceWindowTest("yesterday", now)
+			Expect(err).To(MatchError(ContainSubstring(`invalid --since "yesterday"`)))
+		})
+	})
+
+	Describe("Fetching updated PRs", func() {
+		const pulls = "repos/owner/repo/pulls?state=open&sort=updated&direction=desc&per_page=100"
+
+		It("should stop paging at the first PR updated before the window", func() {
+			page := make([]cmd.PullRequest, 100)
+			for i := range page {
+				page[i] = cmd.PullRequest{Number: 200 - i, State: "open", UpdatedAt: at(time.Duration(2-i) * time.Minute)}
+			}
+			client := cmd.NewMockRESTClient()
+			client.AddResponse(pulls+"&page=1", 200, page)
+
+			prs, err := cmd.FetchUpdatedPullRequestsRESTTest(client, "owner", "repo", since, 0)
+			Expect(err).NotTo(HaveOccurred())
+			// The fourth PR was updated a minute before the window, and so are the ones after it
+			Expect(prs).To(HaveLen(3))
+			Expect(prs[2].Number).To(Equal(198))
+			Expect(client.Requests).To(HaveLen(1))
+		})
+
+		It("should keep paging while every PR is in the window", func() {
+			first := make([]cmd.PullRequest, 100)
+			for i := range first {
+				first[i] = cmd.PullRequest{Number: 300 - i, State: "open", UpdatedAt: at(time.Hour)}
+			}
+			client := cmd.NewMockRESTClient()
+			client.AddResponse(pulls+"&page=1", 200, first)
+			client.AddResponse(pulls+"&page=2", 200, []cmd.PullRequest{
+				{Number: 10, State: "open", UpdatedAt: at(time.Minute)},
+				{Number: 9, State: "open", UpdatedAt: at(-time.Hour)},
+			})
+
+			prs, err := cmd.FetchUpdatedPullRequestsRESTTest(client, "owner", "repo", since, 0)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(prs).To(HaveLen(101))
+			Expect(client.Requests).To(HaveLen(2))
+		})
+	})
+
+	Describe("Describing activity", func() {
+		It("should summarize the events within the window", func() {
+			events := []cmd.TimelineEvent{
+				{Event: "committed", Committer: &cmd.GitActor{Name: "Old", Date: at(-time.Hour)}},
+				{Event: "commented", Actor: &cmd.User{Login: "early"}, CreatedAt: at(-time.Minute)},
+				{Event: "committed", Committer: &cmd.GitActor{Name: "Alice", Date: at(time.Hour)}},
+				{Event: "committed", Committer: &cmd.GitActor{Name: "Alice", Date: at(2 * time.Hour)}},
+				{Event: "head_ref_force_pushed", Actor: &cmd.User{Login: "alice"}, CreatedAt: at(2 * time.Hour)},
+				{Event: "commented", Actor: &cmd.User{Login: "bob"}, CreatedAt: at(3 * time.Hour)},
+				{Event: "commented", Actor: &cmd.User{Login: "carol"}, CreatedAt: at(3 * time.Hour)},
+				{Event: "commented", Actor: &cmd.User{Login: "bob"}, CreatedAt: at(4 * time.Hour)},
+				{Event: "reviewed", User: &cmd.User{Login: "carol"}, State: "approved", SubmittedAt: at(5 * time.Hour)},
+				{Event: "labeled", Actor: &cmd.User{Login: "bot"}, Label: &cmd.Label{Name: "lgtm"}, CreatedAt: at(5 * time.Hour)},
+				{Event: "unlabeled", Actor: &cmd.User{Login: "bob"}, Label: &cmd.Label{Name: "do-not-merge/hold"}, CreatedAt: at(6 * time.Hour)},
+				{Event: "merged", Actor: &cmd.User{Login: "bot"}, CreatedAt: at(7 * time.Hour)},
+			}
+
+			Expect(cmd.DescribeActivityTest(events, nil, nil, since)).To(Equal([]string{
+				"📝 2 new commits",
+				"🔀 force-pushed 1 time",
+				"💬 3 new comments by bob, carol",
+				"✅ approved by carol",
+				"🎉 merged",
+				"🏷️  labels +lgtm, -do-not-merge/hold",
+			}))
+		})
+
+		It("should report the checks that finished within the window", func() {
+			runs := []cmd.CheckRun{
+				{Name: "old", Status: "completed", Conclusion: "failure", CompletedAt: atTime(-time.Hour)},
+				{Name: "unit", Status: "completed", Conclusion: "failure", CompletedAt: atTime(time.Hour)},
+				{Name: "lint", Status: "completed", Conclusion: "success", CompletedAt: atTime(time.Hour)},
+				{Name: "e2e", Status: "in_progress"},
+			}
+			statuses := []cmd.StatusCheck{
+				{Context: "ci/prow/images", State: "error", CreatedAt: atTime(2 * time.Hour)},
+				{Context: "tide", State: "pending", CreatedAt: atTime(2 * time.Hour)},
+			}
+
+			Expect(cmd.DescribeActivityTest(nil, runs, statuses, since)).To(Equal([]string{
+				"❌ 2 checks failed: unit, ci/prow/images",
+				"✅ 1 check passed",
+			}))
+		})
+
+		It("should name only the first failed checks", func() {
+			var runs []cmd.CheckRun
+			for _, name := range []string{"a", "b", "c", "d", "e"} {
+				runs = append(runs, cmd.CheckRun{Name: name, Status: "completed", Conclusion: "failure", CompletedAt: atTime(time.Hour)})
+			}
+			Expect(cmd.DescribeActivityTest(nil, runs, nil, since)).To(Equal([]string{"❌ 5 checks failed: a, b, c and 2 more"}))
+		})
+	})
+
+	Describe("Reporting activity", func() {
+		var out *bytes.Buffer
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should list what changed on each PR", func() {
+			client := cmd.NewMockRESTClient()
+			client.AddResponse("repos/owner/repo/issues/1/timeline?per_page=100&page=1", 200, []cmd.TimelineEvent{
+				{Event: "commented", Actor: &cmd.User{Login: "bob"}, CreatedAt: at(time.Hour)},
+			})
+			client.AddResponse("repos/owner/repo/issues/2/timeline?per_page=100&page=1", 200, []cmd.TimelineEvent{})
+			client.AddResponse("repos/owner/repo/commits/abc/check-runs", 200, cmd.CheckRunsResponse{CheckRuns: []cmd.CheckRun{
+				{Name: "unit", Status: "completed", Conclusion: "failure", CompletedAt: atTime(time.Hour)},
+			}})
+			client.AddResponse("repos/owner/repo/commits/abc/status", 200, map[string]interface{}{"statuses": []cmd.StatusCheck{}})
+
+			cmd.ReportActivityTest(client, "owner", "repo", []cmd.PullRequest{
+				{Number: 1, Title: "Fix the thing", Head: cmd.Branch{SHA: "abc"}},
+				{Number: 2, Title: "Relabelled"},
+			}, since)
+
+			Expect(out.String()).To(ContainSubstring("Activity on owner/repo since"))
+			Expect(out.String()).To(MatchRegexp(`Fix the thing\n\s+💬 1 new comment by bob\n\s+❌ 1 check failed: unit\n`))
+			Expect(out.String()).To(MatchRegexp(`Relabelled\n\s+✏️  updated \(no new commits, comments, reviews or check results\)`))
+		})
+
+		It("should report a PR whose timeline can't be fetched", func() {
+			client := cmd.NewMockRESTClient()
+			client.AddResponse("repos/owner/repo/issues/1/timeline?per_page=100&page=1", 500, map[string]string{"message": "boom"})
+
+			cmd.ReportActivityTest(client, "owner", "repo", []cmd.PullRequest{{Number: 1, Title: "Broken"}}, since)
+
+			Expect(out.String()).To(ContainSubstring("⚠️  failed to fetch the timeline"))
+		})
+	})
+})
@@ -0,0 +1,116 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// sizeBuckets are the sizes of the SIZE column by the lines a PR adds and deletes, each below its limit; larger
+// PRs are XL
+var sizeBuckets = []struct {
+	size  string
+	below int
+}{
+	{"XS", 10},
+	{"S", 30},
+	{"M", 100},
+	{"L", 500},
+}
+
+// prSize returns the size bucket of a PR changing lines lines
+func prSize(lines int) string {
+	for _, bucket := range sizeBuckets {
+		if lines < bucket.below {
+			return bucket.size
+		}
+	}
+	return "XL"
+}
+
+// hasChangeCounts reports whether a PR carries its additions, deletions and changed files, which only its
+// details do, not the PR list
+func hasChangeCounts(pr PullRequest) bool {
+	return pr.ChangedFiles > 0
+}
+
+// sizeColumn renders the SIZE column of a row: its size bucket, "-" when skipped in fast mode and "?" when the
+// PR's details couldn't be fetched
+func sizeColumn(row PRRow, fast bool) string {
+	switch {
+	case row.Size != "":
+		return row.Size
+	case fast:
+		return "-"
+	default:
+		return "?"
+	}
+}
+
+// isRoutineAuthor reports whether a PR's author is a bot or trusted, whose PRs are usually small and approved
+// without much reading
+func isRoutineAuthor(login string, trusted []string) bool {
+	return strings.HasSuffix(login, "[bot]") || isTrustedAuthor(login, trusted)
+}
+
+// confirmLargeChanges asks for the PR number to be typed before a PR by a bot or trusted author that changes
+// more than maxChanges lines is approved, since such PRs are expected to be small. It returns true right away
+// for other PRs and without a limit, and false when the size can't be checked.
+func confirmLargeChanges(client RESTClientInterface, owner, repo string, pr PullRequest, maxChanges int, trusted []string) bool {
+	if maxChanges <= 0 || !isRoutineAuthor(pr.User.Login, trusted) {
+		return true
+	}
+	link := formatPRLink(owner, repo, pr.Number)
+	pr, err := withChangeCounts(client, owner, repo, pr)
+	if err != nil {
+		streams.Printf("❌ Could not check how many lines %s changes, not approving it: %v\n", link, err)
+		return false
+	}
+	lines := pr.Additions + pr.Deletions
+	if lines <= maxChanges {
+		return true
+	}
+
+	streams.Printf("\n📏 ⚠️  LARGER THAN EXPECTED ⚠️  📏\n")
+	streams.Printf("   %s usually opens small PRs, but this one changes %d lines (+%d -%d) in %d file(s), more\n",
+		pr.User.Login, lines, pr.Additions, pr.Deletions, pr.ChangedFiles)
+	streams.Printf("   than the %d allowed by --max-changes or approval.max_changes.\n", maxChanges)
+	answer, err := prompter.Input(fmt.Sprintf("Type the PR number (%d) to approve it anyway: ", pr.Number))
+	if err != nil || strings.TrimPrefix(answer, "#") != strconv.Itoa(pr.Number) {
+		streams.Printf("❌ Approval cancelled due to the size of the changes. Skipping PR %s\n", link)
+		return false
+	}
+	streams.Printf("✅ Confirmed - proceeding with approval despite the size of the changes.\n")
+	return true
+}
+
+// largeChangesBlocker returns why a PR can't be approved in a batch, where nobody types its number, because of its
+// size: it's by a bot or trusted author and changes more than maxChanges lines, or its size couldn't be checked.
+// It returns "" for PRs confirmLargeChanges wouldn't ask about.
+func largeChangesBlocker(item repoPR, maxChanges int, trusted []string) string {
+	if maxChanges <= 0 || !isRoutineAuthor(item.PR.User.Login, trusted) {
+		return ""
+	}
+	pr, err := withChangeCounts(item.Client, item.Owner, item.Repo, item.PR)
+	if err != nil {
+		return fmt.Sprintf("could not check the size of the changes: %v", err)
+	}
+	if lines := pr.Additions + pr.Deletions; lines > maxChanges {
+		return fmt.Sprintf("changes %d lines, more than the %d allowed by max_changes, approve it on its own", lines, maxChanges)
+	}
+	return ""
+}
+
+// withChangeCounts returns pr with its additions, deletions and changed files, fetching its details when it
+// doesn't carry them
+func withChangeCounts(client RESTClientInterface, owner, repo string, pr PullRequest) (PullRequest, error) {
+	if hasChangeCounts(pr) {
+		return pr, nil
+	}
+	details, err := fetchPRDetails(context.Background(), client, owner, repo, pr.Number)
+	if err != nil {
+		return pr, err
+	}
+	return *details, nil
+}
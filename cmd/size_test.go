@@ -0,0 +1,104 @@
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("PR size", func() {
+	DescribeTable("size buckets",
+		func(lines int, size string) {
+			Expect(cmd.PRSizeTest(lines)).To(Equal(size))
+		},
+		Entry("no changes", 0, "XS"),
+		Entry("a few lines", 9, "XS"),
+		Entry("small", 10, "S"),
+		Entry("medium", 99, "M"),
+		Entry("large", 100, "L"),
+		Entry("extra large", 500, "XL"),
+	)
+
+	It("fills the size from the PR's details", func() {
+		mockClient := cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/7", 200, map[string]interface{}{
+			"number": 7, "state": "open", "mergeable_state": "clean",
+			"additions": 40, "deletions": 20, "changed_files": 3,
+		})
+
+		rows := cmd.BuildPRRowsTest([]cmd.PullRequest{{Number: 7, State: "open"}}, "owner", "repo", mockClient, false)
+		Expect(rows[0].Size).To(Equal("M"))
+		Expect(rows[0].Additions).To(Equal(40))
+		Expect(rows[0].Deletions).To(Equal(20))
+		Expect(rows[0].ChangedFiles).To(Equal(3))
+	})
+
+	Describe("Confirming the approval of a large bot PR", func() {
+		var mockClient *cmd.MockRESTClient
+		var in, out *bytes.Buffer
+		trusted := []string{"alice"}
+		pr := func(author string, additions, deletions int) cmd.PullRequest {
+			pullRequest := cmd.PullRequest{Number: 7, Additions: additions, Deletions: deletions}
+			if additions+deletions > 0 {
+				pullRequest.ChangedFiles = 2
+			}
+			pullRequest.User.Login = author
+			return pullRequest
+		}
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should approve once the PR number is typed", func() {
+			in.WriteString("7\n")
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("renovate[bot]", 900, 100), 200, trusted)).To(BeTrue())
+			Expect(out.String()).To(ContainSubstring("changes 1000 lines (+900 -100) in 2 file(s)"))
+		})
+
+		It("should not take a y for the PR number", func() {
+			in.WriteString("y\n")
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("alice", 300, 0), 200, trusted)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("Approval cancelled"))
+		})
+
+		It("should not ask within the limit, without one or about other authors", func() {
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("renovate[bot]", 150, 50), 200, trusted)).To(BeTrue())
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("renovate[bot]", 900, 100), 0, trusted)).To(BeTrue())
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("mallory", 900, 100), 200, trusted)).To(BeTrue())
+			Expect(out.String()).To(BeEmpty())
+		})
+
+		It("should fetch the size when the PR doesn't carry it", func() {
+			mockClient.AddResponse("repos/owner/repo/pulls/7", 200, map[string]interface{}{
+				"number": 7, "additions": 400, "deletions": 0, "changed_files": 1,
+				"user": map[string]interface{}{"login": "renovate[bot]"},
+			})
+			in.WriteString("#7\n")
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("renovate[bot]", 0, 0), 200, trusted)).To(BeTrue())
+			Expect(out.String()).To(ContainSubstring("changes 400 lines"))
+		})
+
+		It("should not approve when the size can't be checked", func() {
+			Expect(cmd.ConfirmLargeChangesTest(mockClient, "owner", "repo", pr("renovate[bot]", 0, 0), 200, trusted)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("Could not check how many lines"))
+		})
+
+		It("should keep large bot PRs out of batches", func() {
+			Expect(cmd.BatchApprovalBlockerTest(mockClient, "owner", "repo", pr("renovate[bot]", 900, 100), trusted, 200)).
+				To(Equal("changes 1000 lines, more than the 200 allowed by max_changes, approve it on its own"))
+			Expect(cmd.BatchApprovalBlockerTest(mockClient, "owner", "repo", pr("renovate[bot]", 0, 0), trusted, 200)).
+				To(HavePrefix("could not check the size of the changes"))
+			Expect(out.String()).To(BeEmpty())
+		})
+	})
+})
@@ -0,0 +1,282 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// snapshotTimeFormat names the snapshot files, so they sort by when they were taken
+const snapshotTimeFormat = "20060102-150405"
+
+// Snapshot is the fully enriched PRs of the configured repositories at one time, saved by 'ghprs snapshot' and
+// shown by list and konflux --offline
+type Snapshot struct {
+	SchemaVersion string    `json:"schemaVersion"`
+	TakenAt       time.Time `json:"takenAt"`
+	// List holds the PRs 'ghprs list' shows of every configured repository, Konflux those 'ghprs konflux' shows
+	// of the Konflux repositories
+	List    PRListOutput `json:"list"`
+	Konflux PRListOutput `json:"konflux"`
+}
+
+// snapshotsDir is where the snapshots are kept, next to the config file
+func snapshotsDir() string {
+	return filepath.Join(stateDir(), "snapshots")
+}
+
+// saveSnapshot writes a snapshot to dir in a file named after when it was taken and returns its path
+func saveSnapshot(dir string, snapshot Snapshot) (string, error) {
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return "", fmt.Errorf("failed to create snapshot directory: %w", err)
+	}
+	data, err := json.MarshalIndent(snapshot, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to encode snapshot: %w", err)
+	}
+	path := filepath.Join(dir, snapshot.TakenAt.UTC().Format(snapshotTimeFormat)+".json")
+	if err := os.WriteFile(path, append(data, '\n'), 0644); err != nil {
+		return "", fmt.Errorf("failed to write snapshot: %w", err)
+	}
+	return path, nil
+}
+
+// listSnapshots returns the paths of the snapshots of dir, oldest first
+func listSnapshots(dir string) ([]string, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(paths)
+	return paths, nil
+}
+
+// loadSnapshot reads the snapshot saved at path
+func loadSnapshot(path string) (Snapshot, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return Snapshot{}, fmt.Errorf("failed to read snapshot: %w", err)
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, fmt.Errorf("failed to parse snapshot %s: %w", path, err)
+	}
+	return snapshot, nil
+}
+
+// latestSnapshot loads the most recent snapshot of dir and returns it with its path
+func latestSnapshot(dir string) (Snapshot, string, error) {
+	paths, err := listSnapshots(dir)
+	if err != nil {
+		return Snapshot{}, "", err
+	}
+	if len(paths) == 0 {
+		return Snapshot{}, "", fmt.Errorf("no snapshot in %s, take one with 'ghprs snapshot'", dir)
+	}
+	path := paths[len(paths)-1]
+	snapshot, err := loadSnapshot(path)
+	return snapshot, path, err
+}
+
+// snapshotRepositories fetches the PRs of repositories and builds their rows the way list or konflux does with opts
+func snapshotRepositories(ctx context.Context, opts *listOptions, config *Config, repositories []string, isKonflux bool, limiter *rateLimiter) PRListOutput {
+	output := PRListOutput{
+		SchemaVersion: OutputSchemaVersion,
+		Konflux:       isKonflux,
+		Repositories:  []RepositoryPRs{},
+	}
+	if isKonflux {
+		repositories, _ = groupByApplication(repositories)
+	}
+	for _, repoSpec := range repositories {
+		owner, repo, ok := parseRepoSpec(repoSpec)
+		if !ok {
+			logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+			continue
+		}
+		// Snapshots are taken fresh, without the on-disk PR cache
+		client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+		if err != nil {
+			logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+			continue
+		}
+
+		func() {
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			defer cancel()
+
+			var authors []string
+			if isKonflux {
+				authors = []string{konfluxBotAuthor}
+			}
+			authors = queueAuthors(config, repoSpec, authors, isKonflux)
+			pullRequests, client, err := fetchRepositoryPRs(repoCtx, opts, withContext(client, repoCtx), owner, repo, authors, isKonflux)
+			if reason := describeCancellation(repoCtx.Err()); reason != "" {
+				logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+				return
+			}
+			if err != nil {
+				logger.Error("Failed to fetch pull requests", "repo", repoSpec, "error", err)
+				return
+			}
+
+			var priorityScores map[int]PriorityScore
+			if repoSort := sortFor(opts, owner, repo); repoSort == "priority" {
+				priorityScores = sortPullRequestsWithContext(repoCtx, opts, pullRequests, client, owner, repo, isKonflux)
+			} else if repoSort != "" {
+				sortPullRequests(pullRequests, repoSort)
+			}
+			rows := buildPRRows(repoCtx, opts, pullRequests, owner, repo, client, isKonflux, nil)
+			withPriorityScores(rows, priorityScores)
+			repoOutput := RepositoryPRs{Repository: repoSpec, PullRequests: rows}
+			if isKonflux {
+				repoOutput.Application = applicationFor(repoSpec)
+			}
+			output.Repositories = append(output.Repositories, repoOutput)
+		}()
+		if ctx.Err() != nil {
+			break
+		}
+	}
+	return output
+}
+
+// snapshotSelection keeps the repository given as an argument or with --repo of a snapshot, all of them without one
+func snapshotSelection(output PRListOutput, args []string) PRListOutput {
+	selected := repoFlag
+	if len(args) > 0 {
+		selected = args[0]
+	}
+	if selected == "" {
+		return output
+	}
+	repositories := []RepositoryPRs{}
+	for _, repoPRs := range output.Repositories {
+		if repoPRs.Repository == selected {
+			repositories = append(repositories, repoPRs)
+		}
+	}
+	output.Repositories = repositories
+	return output
+}
+
+// displaySnapshot shows the PRs of the latest snapshot instead of fetching them, for list and konflux --offline,
+// in the view and output of opts
+func displaySnapshot(opts *listOptions, args []string, isKonflux bool) error {
+	snapshot, path, err := latestSnapshot(snapshotsDir())
+	if err != nil {
+		return err
+	}
+	output := snapshot.List
+	if isKonflux {
+		output = snapshot.Konflux
+	}
+	output = snapshotSelection(output, args)
+	output.AsOf = snapshot.TakenAt.UTC().Format(time.RFC3339)
+
+	if isStructuredOutput(outputFormat) || isRowOutput(outputFormat) {
+		writePRListOutput(opts, output)
+		return nil
+	}
+
+	streams.Printf("📦 Offline: PRs as of %s (%s ago), from %s\n",
+		snapshot.TakenAt.Local().Format("2006-01-02 15:04"), formatAge(time.Since(snapshot.TakenAt)), path)
+	if len(output.Repositories) == 0 {
+		if len(args) > 0 || repoFlag != "" {
+			streams.Printf("\nThe snapshot doesn't have the repository, take a new one after configuring it\n")
+		} else {
+			streams.Printf("\nThe snapshot has no repositories\n")
+		}
+		return nil
+	}
+
+	var headers applicationHeaders
+	if isKonflux {
+		repositories := make([]string, len(output.Repositories))
+		for i, repoPRs := range output.Repositories {
+			repositories[i] = repoPRs.Repository
+		}
+		_, headers.enabled = groupByApplication(repositories)
+	}
+	for _, repoPRs := range output.Repositories {
+		owner, repo, _ := parseRepoSpec(repoPRs.Repository)
+		headers.print(repoPRs.Repository)
+		if len(repoPRs.PullRequests) == 0 {
+			if isKonflux {
+				streams.Printf("\nNo Konflux pull requests found for %s\n", repoPRs.Repository)
+			} else {
+				streams.Printf("\nNo %s pull requests found for %s\n", stateFor(opts, owner, repo), repoPRs.Repository)
+			}
+			continue
+		}
+		renderPRTable(opts, repoPRs.PullRequests, owner, repo, isKonflux, legend.Take())
+	}
+	return nil
+}
+
+// snapshotCmd saves the PRs of the configured repositories for --offline
+var snapshotCmd = &cobra.Command{
+	Use:   "snapshot",
+	Short: "Save the PRs of the configured repositories for viewing offline",
+	Long: `Fetch the PRs of every configured repository, as 'ghprs list' shows them, and of the Konflux
+repositories, as 'ghprs konflux' shows them, with their review, rebase, blocked, check and readiness
+state, and save them to a snapshot file next to the config (snapshots/<time>.json).
+
+'ghprs list --offline' and 'ghprs konflux --offline' then show the latest snapshot without asking
+GitHub, with the time it was taken. The snapshots are kept, so the queue can be compared between days.
+
+Examples:
+  ghprs snapshot
+  ghprs list --offline
+  ghprs konflux --offline --view readiness
+  diff <(jq .konflux snapshots/20261015-080000.json) <(jq .konflux snapshots/20261016-080000.json)`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		opts := newListOptions()
+		applyConfigDefaults(opts, config)
+		setRepositoryHosts(config)
+		setKonfluxComponents(config)
+		setPriorityWeights(config)
+		staleCheckAfter = config.StaleCheckAfter()
+		stalePRAfter = config.StalePRAfter()
+		// Snapshots hold every detail the tables can show, the checks and readiness too
+		opts.View = ViewReadiness
+
+		repositories := config.GetRepositories(false)
+		if len(repositories) == 0 {
+			log.Fatal("No repositories configured. Add some with 'ghprs config add-repo owner/repo'.")
+		}
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		snapshot := Snapshot{SchemaVersion: OutputSchemaVersion, TakenAt: time.Now()}
+		snapshot.List = snapshotRepositories(ctx, opts, config, repositories, false, limiter)
+		snapshot.Konflux = snapshotRepositories(ctx, opts, config, config.GetRepositories(true), true, limiter)
+		if ctx.Err() != nil {
+			log.Fatal("Interrupted, no snapshot saved")
+		}
+
+		path, err := saveSnapshot(snapshotsDir(), snapshot)
+		if err != nil {
+			log.Fatal(err)
+		}
+		streams.Printf("📦 Saved the PRs of %d repositories to %s\n", len(repositories), path)
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(snapshotCmd)
+	listCmd.Flags().BoolVar(&listOpts.Offline, "offline", false, "Show the PRs of the latest 'ghprs snapshot' instead of fetching them")
+	konfluxCmd.Flags().BoolVar(&konfluxOpts.Offline, "offline", false, "Show the Konflux PRs of the latest 'ghprs snapshot' instead of fetching them")
+}
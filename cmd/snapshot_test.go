@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Snapshots", func() {
+	var (
+		out *bytes.Buffer
+		dir string
+	)
+
+	snapshotAt := func(takenAt time.Time, title string) cmd.Snapshot {
+		return cmd.Snapshot{
+			SchemaVersion: cmd.OutputSchemaVersion,
+			TakenAt:       takenAt,
+			List: cmd.PRListOutput{
+				SchemaVersion: cmd.OutputSchemaVersion,
+				Repositories: []cmd.RepositoryPRs{
+					{Repository: "owner/app", PullRequests: []cmd.PRRow{{Number: 7, Title: title, Author: "alice", URL: "https://github.com/owner/app/pull/7"}}},
+					{Repository: "owner/lib", PullRequests: []cmd.PRRow{}},
+				},
+			},
+			Konflux: cmd.PRListOutput{
+				SchemaVersion: cmd.OutputSchemaVersion,
+				Konflux:       true,
+				Repositories: []cmd.RepositoryPRs{
+					{Repository: "owner/app", PullRequests: []cmd.PRRow{{Number: 9, Title: "chore(deps): update konflux references", Author: "red-hat-konflux[bot]"}}},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(&bytes.Buffer{}, out, &bytes.Buffer{}), nil)
+		dir = GinkgoT().TempDir()
+		cmd.SetConfigPath(filepath.Join(dir, "config.yaml"))
+		cmd.ResetLegendTest(render.LegendNever)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+		cmd.ResetConfigPath()
+	})
+
+	It("loads the latest snapshot", func() {
+		snapshotsDir := filepath.Join(dir, "snapshots")
+		older := time.Date(2026, 10, 15, 8, 0, 0, 0, time.UTC)
+		_, err := cmd.SaveSnapshotTest(snapshotsDir, snapshotAt(older, "Older"))
+		Expect(err).NotTo(HaveOccurred())
+		newerPath, err := cmd.SaveSnapshotTest(snapshotsDir, snapshotAt(older.Add(24*time.Hour), "Newer"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filepath.Base(newerPath)).To(Equal("20261016-080000.json"))
+
+		snapshot, path, err := cmd.LatestSnapshotTest(snapshotsDir)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(path).To(Equal(newerPath))
+		Expect(snapshot.TakenAt.Equal(older.Add(24 * time.Hour))).To(BeTrue())
+		Expect(snapshot.List.Repositories[0].PullRequests[0].Title).To(Equal("Newer"))
+	})
+
+	It("explains how to take a snapshot when there is none", func() {
+		_, _, err := cmd.LatestSnapshotTest(filepath.Join(dir, "snapshots"))
+		Expect(err).To(MatchError(ContainSubstring("take one with 'ghprs snapshot'")))
+		Expect(cmd.DisplaySnapshotTest(nil, false, cmd.OutputTable)).To(MatchError(ContainSubstring("no snapshot")))
+	})
+
+	Describe("showing a snapshot offline", func() {
+		BeforeEach(func() {
+			_, err := cmd.SaveSnapshotTest(filepath.Join(dir, "snapshots"), snapshotAt(time.Now().Add(-3*time.Hour), "Fix the widget"))
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		It("shows the tables of the list with when the snapshot was taken", func() {
+			Expect(cmd.DisplaySnapshotTest(nil, false, cmd.OutputTable)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("📦 Offline: PRs as of"))
+			Expect(out.String()).To(ContainSubstring("(3h0m ago)"))
+			Expect(out.String()).To(ContainSubstring("Fix the widget"))
+			Expect(out.String()).To(ContainSubstring("No open pull requests found for owner/lib"))
+			Expect(out.String()).NotTo(ContainSubstring("konflux references"))
+		})
+
+		It("shows the Konflux PRs for konflux", func() {
+			Expect(cmd.DisplaySnapshotTest(nil, true, cmd.OutputTable)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("konflux references"))
+			Expect(out.String()).NotTo(ContainSubstring("Fix the widget"))
+		})
+
+		It("shows only the repository asked for", func() {
+			Expect(cmd.DisplaySnapshotTest([]string{"owner/lib"}, false, cmd.OutputTable)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("owner/lib"))
+			Expect(out.String()).NotTo(ContainSubstring("Fix the widget"))
+
+			out.Reset()
+			Expect(cmd.DisplaySnapshotTest([]string{"owner/other"}, false, cmd.OutputTable)).To(Succeed())
+			Expect(out.String()).To(ContainSubstring("The snapshot doesn't have the repository"))
+		})
+
+		It("writes json output with the time of the snapshot", func() {
+			Expect(cmd.DisplaySnapshotTest(nil, false, cmd.OutputJSON)).To(Succeed())
+			var output cmd.PRListOutput
+			Expect(json.Unmarshal(out.Bytes(), &output)).To(Succeed())
+			Expect(output.AsOf).NotTo(BeEmpty())
+			Expect(output.Repositories).To(HaveLen(2))
+			Expect(output.Repositories[0].PullRequests[0].Number).To(Equal(7))
+		})
+	})
+})
@@ -0,0 +1,235 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var (
+	snoozeUntil string
+	snoozeClear bool
+	// snoozedPRs are the snoozed PRs list and konflux hide, nil to hide none
+	snoozedPRs *snoozeState
+)
+
+// Snooze is a PR hidden from list and konflux until it expires or the PR is updated
+type Snooze struct {
+	Repository string    `yaml:"repository"`
+	Number     int       `yaml:"number"`
+	Until      time.Time `yaml:"until"`
+	SnoozedAt  time.Time `yaml:"snoozed_at"`
+}
+
+// snoozeState is the local state file of snoozed PRs
+type snoozeState struct {
+	path    string
+	mutex   sync.Mutex
+	snoozes []Snooze
+	changed bool
+	hidden  int
+}
+
+// snoozeStatePath is where snoozed PRs are kept, next to the config file
+func snoozeStatePath() string {
+	return filepath.Join(stateDir(), "snoozed.yaml")
+}
+
+// loadSnoozes reads the snoozed PRs from path, none if it doesn't exist
+func loadSnoozes(path string) (*snoozeState, error) {
+	state := &snoozeState{path: path}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return state, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := yaml.Unmarshal(data, &state.snoozes); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return state, nil
+}
+
+// save writes the snoozed PRs back if they changed
+func (s *snoozeState) save() error {
+	if s == nil {
+		return nil
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if !s.changed {
+		return nil
+	}
+	data, err := yaml.Marshal(s.snoozes)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(s.path, data, 0644); err != nil {
+		return err
+	}
+	s.changed = false
+	return nil
+}
+
+// add snoozes a PR until the given time, replacing an earlier snooze of it and dropping the expired ones
+func (s *snoozeState) add(repoSpec string, number int, until, now time.Time) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.snoozes = slices.DeleteFunc(s.snoozes, func(snooze Snooze) bool {
+		return (snooze.Repository == repoSpec && snooze.Number == number) || !now.Before(snooze.Until)
+	})
+	s.snoozes = append(s.snoozes, Snooze{Repository: repoSpec, Number: number, Until: until, SnoozedAt: now})
+	s.changed = true
+}
+
+// remove unsnoozes a PR, returning false if it wasn't snoozed
+func (s *snoozeState) remove(repoSpec string, number int) bool {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	before := len(s.snoozes)
+	s.snoozes = slices.DeleteFunc(s.snoozes, func(snooze Snooze) bool {
+		return snooze.Repository == repoSpec && snooze.Number == number
+	})
+	s.changed = s.changed || len(s.snoozes) != before
+	return len(s.snoozes) != before
+}
+
+// hide returns the PRs of a page that aren't snoozed. Snoozes that expired, or whose PR was updated after it
+// was snoozed, are dropped so the PR shows up again. A nil state hides nothing.
+func (s *snoozeState) hide(repoSpec string, pullRequests []PullRequest, now time.Time) []PullRequest {
+	if s == nil {
+		return pullRequests
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	var shown []PullRequest
+	for _, pr := range pullRequests {
+		index := slices.IndexFunc(s.snoozes, func(snooze Snooze) bool {
+			return snooze.Repository == repoSpec && snooze.Number == pr.Number
+		})
+		if index < 0 {
+			shown = append(shown, pr)
+			continue
+		}
+		snooze := s.snoozes[index]
+		updated, err := time.Parse(time.RFC3339, pr.UpdatedAt)
+		if !now.Before(snooze.Until) || (err == nil && updated.After(snooze.SnoozedAt)) {
+			logger.Info("Unsnoozed pull request", "repo", repoSpec, "number", pr.Number, "expired", !now.Before(snooze.Until))
+			s.snoozes = slices.Delete(s.snoozes, index, index+1)
+			s.changed = true
+			shown = append(shown, pr)
+			continue
+		}
+		s.hidden++
+	}
+	return shown
+}
+
+// hiddenCount returns how many PRs hide left out
+func (s *snoozeState) hiddenCount() int {
+	if s == nil {
+		return 0
+	}
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.hidden
+}
+
+// parseSnoozeUntil returns when a snooze given --until ends: a duration such as 2d, 1w or 36h, or a date
+func parseSnoozeUntil(value string, now time.Time) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, fmt.Errorf("--until is required, e.g. --until 2d")
+	}
+	if _, err := parseHoldDuration(value); err == nil {
+		return parseHoldExpiry("", value, now)
+	}
+	until, err := parseHoldExpiry(value, "", now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid --until %q (use a duration such as 2d, 1w or 36h, or a date as YYYY-MM-DD)", value)
+	}
+	return until, nil
+}
+
+// loadSnoozesForListing loads the snoozed PRs list and konflux hide, warning and hiding none when they can't be read
+// or are shown (--show-snoozed)
+func loadSnoozesForListing(showSnoozed bool) *snoozeState {
+	if showSnoozed {
+		return nil
+	}
+	state, err := loadSnoozes(snoozeStatePath())
+	if err != nil {
+		logger.Warn("Could not read snoozed pull requests, showing them", "error", err)
+		return nil
+	}
+	return state
+}
+
+// snoozeCmd hides PRs from list and konflux for a while
+var snoozeCmd = &cobra.Command{
+	Use:   "snooze [owner/repo] <number>...",
+	Short: "Hide pull requests from list and konflux for a while",
+	Long: `Hide pull requests from 'ghprs list' and 'ghprs konflux' until --until passes or the PR is updated,
+whichever comes first. Snoozing only changes a local state file next to the config, nothing on GitHub.
+
+Use --show-snoozed with list or konflux to show snoozed PRs, and --clear to unsnooze them.
+
+Examples:
+  ghprs snooze owner/repo 12 --until 2d
+  ghprs snooze owner/repo 12 15 --until 1w
+  ghprs snooze owner/repo 12 --until 2025-01-15  # Until the start of that day
+  ghprs snooze owner/repo 12 --clear`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePRArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		owner, repo, numbers := parseBatchArgs(args)
+		repoSpec := owner + "/" + repo
+
+		state, err := loadSnoozes(snoozeStatePath())
+		if err != nil {
+			log.Fatalf("Failed to read snoozed pull requests: %v", err)
+		}
+
+		now := time.Now()
+		var until time.Time
+		if !snoozeClear {
+			if until, err = parseSnoozeUntil(snoozeUntil, now); err != nil {
+				log.Fatal(err)
+			}
+		}
+		for _, number := range numbers {
+			link := formatPRLink(owner, repo, number)
+			switch {
+			case snoozeClear && state.remove(repoSpec, number):
+				streams.Printf("🔔 Unsnoozed %s\n", link)
+			case snoozeClear:
+				streams.Printf("%s is not snoozed\n", link)
+			default:
+				state.add(repoSpec, number, until, now)
+				streams.Printf("💤 Snoozed %s until %s or it is updated\n", link, until.Format("Mon Jan 2 15:04"))
+			}
+		}
+
+		if err := state.save(); err != nil {
+			log.Fatalf("Failed to save snoozed pull requests: %v", err)
+		}
+	},
+}
+
+func init() {
+	RootCmd.AddCommand(snoozeCmd)
+
+	snoozeCmd.Flags().StringVar(&snoozeUntil, "until", "", "How long to snooze, e.g. 2d, 1w or 36h, or a date as YYYY-MM-DD")
+	snoozeCmd.Flags().BoolVar(&snoozeClear, "clear", false, "Unsnooze the pull requests")
+}
@@ -0,0 +1,79 @@
+package cmd_test
+
+import (
+	"path/filepath"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Snooze", func() {
+	var path string
+	now := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+	prs := []cmd.PullRequest{
+		{Number: 1, UpdatedAt: "2025-01-09T08:00:00Z"},
+		{Number: 2, UpdatedAt: "2025-01-09T08:00:00Z"},
+	}
+
+	BeforeEach(func() {
+		path = filepath.Join(GinkgoT().TempDir(), "snoozed.yaml")
+	})
+
+	It("should hide snoozed PRs of the same repository only", func() {
+		Expect(cmd.SnoozeTest(path, "owner/repo", 2, now.Add(48*time.Hour), now)).To(Succeed())
+
+		shown, hidden, err := cmd.HideSnoozedTest(path, "owner/repo", prs, now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(shown)).To(Equal([]int{1}))
+		Expect(hidden).To(Equal(1))
+
+		shown, _, err = cmd.HideSnoozedTest(path, "owner/other", prs, now.Add(time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(shown)).To(Equal([]int{1, 2}))
+	})
+
+	It("should unsnooze PRs once the snooze expired", func() {
+		Expect(cmd.SnoozeTest(path, "owner/repo", 2, now.Add(48*time.Hour), now)).To(Succeed())
+
+		shown, hidden, err := cmd.HideSnoozedTest(path, "owner/repo", prs, now.Add(49*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(shown)).To(Equal([]int{1, 2}))
+		Expect(hidden).To(BeZero())
+	})
+
+	It("should unsnooze PRs updated after they were snoozed, for good", func() {
+		Expect(cmd.SnoozeTest(path, "owner/repo", 2, now.Add(48*time.Hour), now)).To(Succeed())
+		updated := []cmd.PullRequest{prs[0], {Number: 2, UpdatedAt: "2025-01-10T13:00:00Z"}}
+
+		shown, _, err := cmd.HideSnoozedTest(path, "owner/repo", updated, now.Add(2*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(shown)).To(Equal([]int{1, 2}))
+
+		// The snooze was dropped, so the PR stays shown even as it was before the update
+		shown, _, err = cmd.HideSnoozedTest(path, "owner/repo", prs, now.Add(2*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(prNumbers(shown)).To(Equal([]int{1, 2}))
+	})
+
+	DescribeTable("--until",
+		func(value string, expected time.Time) {
+			until, err := cmd.ParseSnoozeUntilTest(value, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(until).To(Equal(expected))
+		},
+		Entry("days", "2d", now.Add(48*time.Hour)),
+		Entry("weeks", "1w", now.Add(7*24*time.Hour)),
+		Entry("hours", "36h", now.Add(36*time.Hour)),
+		Entry("a date", "2025-01-15", time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC)),
+	)
+
+	It("should reject a missing or invalid --until", func() {
+		_, err := cmd.ParseSnoozeUntilTest("", now)
+		Expect(err).To(MatchError(ContainSubstring("--until is required")))
+		_, err = cmd.ParseSnoozeUntilTest("tomorrow", now)
+		Expect(err).To(MatchError(ContainSubstring("invalid --until")))
+	})
+})
@@ -0,0 +1,317 @@
+package cmd
+
+import (
+	"fmt"
+	"log"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cli/go-gh/v2/pkg/repository"
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+// defaultStatsWindow is the time window stats are computed over unless --since is given
+const defaultStatsWindow = "30d"
+
+var statsSince string
+
+// StatsOutput is the document emitted by 'ghprs stats' in json/yaml output mode
+type StatsOutput struct {
+	SchemaVersion string            `json:"schemaVersion" yaml:"schemaVersion"`
+	Since         string            `json:"since" yaml:"since"`
+	Until         string            `json:"until" yaml:"until"`
+	Repositories  []RepositoryStats `json:"repositories" yaml:"repositories"`
+}
+
+// RepositoryStats are the metrics of a repository's Konflux PRs over the stats window.
+// Durations are in seconds and nil when there is nothing to measure.
+type RepositoryStats struct {
+	Repository string `json:"repository" yaml:"repository"`
+	// Opened and Merged count the PRs opened and merged within the window
+	Opened int `json:"opened" yaml:"opened"`
+	Merged int `json:"merged" yaml:"merged"`
+	// Approved counts the PRs opened within the window that were approved, which the average is taken over
+	Approved                    int    `json:"approved" yaml:"approved"`
+	AverageTimeToApproveSeconds *int64 `json:"averageTimeToApproveSeconds" yaml:"averageTimeToApproveSeconds"`
+	// Migration counts the PRs opened within the window with a migration warning
+	Migration int `json:"migration" yaml:"migration"`
+	// OpenPRs counts the PRs open now, whenever they were opened
+	OpenPRs              int    `json:"openPRs" yaml:"openPRs"`
+	OldestOpenNumber     *int   `json:"oldestOpenNumber" yaml:"oldestOpenNumber"`
+	OldestOpenAgeSeconds *int64 `json:"oldestOpenAgeSeconds" yaml:"oldestOpenAgeSeconds"`
+}
+
+// statsCmd reports metrics of the Konflux PRs of repositories over a time window
+var statsCmd = &cobra.Command{
+	Use:   "stats [owner/repo...]",
+	Short: "Show metrics of Konflux pull requests",
+	Long: `Show metrics of the Konflux pull requests of each repository over a time window: how many
+PRs were opened and merged, the average time from opening a PR to its first approving review,
+how many PRs had a migration warning, and how long the oldest open PR has been waiting.
+
+PRs are counted when they are authored by red-hat-konflux[bot] or one of the bot authors configured
+for the repository. Without repositories the configured Konflux repositories are used, or else the
+current repository. Use --output json or yaml to feed dashboards (see 'ghprs schema stats').
+
+Examples:
+  ghprs stats
+  ghprs stats owner/repo --since 7d
+  ghprs stats --since 2w --output json`,
+	ValidArgsFunction: completeRepositoryArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		if err := validateOutputFormat(outputFormat); err != nil {
+			log.Fatal(err)
+		}
+		if isRowOutput(outputFormat) {
+			log.Fatalf("--output %s is only supported by list and konflux", outputFormat)
+		}
+		window, err := parseHoldDuration(statsSince)
+		if err != nil {
+			log.Fatalf("Invalid --since %q (use e.g. 7d, 2w or 36h)", statsSince)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			logger.Warn("Could not load config, using defaults", "error", err)
+			config = DefaultConfig()
+		}
+		setRepositoryHosts(config)
+
+		repositories := statsRepositories(args, config)
+		now := time.Now()
+		output := StatsOutput{
+			SchemaVersion: OutputSchemaVersion,
+			Since:         now.Add(-window).UTC().Format(time.RFC3339),
+			Until:         now.UTC().Format(time.RFC3339),
+			Repositories:  []RepositoryStats{},
+		}
+
+		limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+		for _, repoSpec := range repositories {
+			owner, repo, ok := parseRepoSpec(repoSpec)
+			if !ok {
+				logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+				continue
+			}
+			client, err := newAPIClient(hostFor(owner, repo), limiter, nil)
+			if err != nil {
+				logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+				continue
+			}
+
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			authors := queueAuthors(config, repoSpec, []string{konfluxBotAuthor}, true)
+			stats, err := computeRepositoryStats(withContext(client, repoCtx), owner, repo, authors, now.Add(-window), now)
+			reason := describeCancellation(repoCtx.Err())
+			cancel()
+			if ctx.Err() != nil {
+				break
+			}
+			switch {
+			case reason != "":
+				logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+			case err != nil:
+				logger.Error("Failed to compute stats", "repo", repoSpec, "error", err)
+			default:
+				output.Repositories = append(output.Repositories, stats)
+			}
+		}
+
+		if isStructuredOutput(outputFormat) {
+			if err := writeStructuredOutput(streams.Out, output, outputFormat); err != nil {
+				log.Fatalf("Failed to write %s output: %v", outputFormat, err)
+			}
+			return
+		}
+		displayStatsTable(output.Repositories, statsSince, now.Add(-window))
+	},
+}
+
+// statsRepositories returns the repositories to compute stats for: the given ones, the configured Konflux
+// repositories or else the current repository
+func statsRepositories(args []string, config *Config) []string {
+	switch {
+	case len(args) > 0:
+		return args
+	case repoFlag != "":
+		return []string{repoFlag}
+	}
+	if repositories := config.GetRepositories(true); len(repositories) > 0 {
+		return repositories
+	}
+	currentRepo, err := repository.Current()
+	if err != nil {
+		log.Fatal("No repositories specified and no Konflux repositories configured. Specify owner/repo or run from a git repository.")
+	}
+	return []string{currentRepo.Owner + "/" + currentRepo.Name}
+}
+
+// computeRepositoryStats computes the metrics of the PRs of owner/repo by authors between since and now
+func computeRepositoryStats(client RESTClientInterface, owner, repo string, authors []string, since, now time.Time) (RepositoryStats, error) {
+	stats := RepositoryStats{Repository: owner + "/" + repo}
+	byAuthors := func(pr PullRequest) bool {
+		return slices.ContainsFunc(authors, func(author string) bool { return strings.EqualFold(pr.User.Login, author) })
+	}
+
+	// PRs opened or merged within the window were last updated within it too, so recently updated PRs are
+	// fetched until the window is left behind
+	var opened []PullRequest
+	for page := 1; ; page++ {
+		var pagePRs []PullRequest
+		path := fmt.Sprintf("repos/%s/%s/pulls?state=all&sort=updated&direction=desc&per_page=%d&page=%d", owner, repo, maxPerPage, page)
+		if err := client.Get(path, &pagePRs); err != nil {
+			return stats, err
+		}
+		done := len(pagePRs) < maxPerPage
+		for _, pr := range pagePRs {
+			if updatedAt, err := time.Parse(time.RFC3339, pr.UpdatedAt); err == nil && updatedAt.Before(since) {
+				done = true
+				break
+			}
+			if !byAuthors(pr) {
+				continue
+			}
+			if within(pr.CreatedAt, since, now) {
+				opened = append(opened, pr)
+			}
+			if within(pr.MergedAt, since, now) {
+				stats.Merged++
+			}
+		}
+		if done {
+			break
+		}
+	}
+
+	stats.Opened = len(opened)
+	for _, pr := range opened {
+		if hasMigrationWarning(pr) {
+			stats.Migration++
+		}
+	}
+
+	if total, approved := timeToApprove(client, owner, repo, opened); approved > 0 {
+		stats.Approved = approved
+		average := int64((total / time.Duration(approved)).Seconds())
+		stats.AverageTimeToApproveSeconds = &average
+	}
+
+	open, err := fetchPullRequestsREST(client, owner, repo, "open", "", 0, func(_ RESTClientInterface, page []PullRequest) []PullRequest {
+		return slices.DeleteFunc(page, func(pr PullRequest) bool { return !byAuthors(pr) })
+	})
+	if err != nil {
+		return stats, err
+	}
+	stats.OpenPRs = len(open)
+	for _, pr := range open {
+		createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+		if err != nil {
+			continue
+		}
+		age := int64(now.Sub(createdAt).Seconds())
+		if stats.OldestOpenAgeSeconds == nil || age > *stats.OldestOpenAgeSeconds {
+			number := pr.Number
+			stats.OldestOpenNumber, stats.OldestOpenAgeSeconds = &number, &age
+		}
+	}
+	return stats, nil
+}
+
+// within reports whether an RFC 3339 timestamp is between since and now
+func within(timestamp string, since, now time.Time) bool {
+	t, err := time.Parse(time.RFC3339, timestamp)
+	return err == nil && !t.Before(since) && !t.After(now)
+}
+
+// timeToApprove returns the total time from opening to the first approving review of the PRs that were approved,
+// and how many were. PRs whose reviews can't be fetched are left out.
+func timeToApprove(client RESTClientInterface, owner, repo string, pullRequests []PullRequest) (time.Duration, int) {
+	var mutex sync.Mutex
+	var total time.Duration
+	approved := 0
+	runConcurrently(len(pullRequests), defaultConcurrency, func(i int) {
+		pr := pullRequests[i]
+		createdAt, err := time.Parse(time.RFC3339, pr.CreatedAt)
+		if err != nil {
+			return
+		}
+		var reviews []Review
+		if err := client.Get(fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?per_page=%d", owner, repo, pr.Number, maxPerPage), &reviews); err != nil {
+			logger.Info("Could not fetch reviews", "repo", owner+"/"+repo, "pr", pr.Number, "error", err)
+			return
+		}
+		// Reviews are listed in the order they were submitted
+		for _, review := range reviews {
+			if review.State != "APPROVED" {
+				continue
+			}
+			if submittedAt, err := time.Parse(time.RFC3339, review.SubmittedAt); err == nil {
+				mutex.Lock()
+				total += submittedAt.Sub(createdAt)
+				approved++
+				mutex.Unlock()
+			}
+			return
+		}
+	})
+	return total, approved
+}
+
+// displayStatsTable prints the stats of each repository as a table
+func displayStatsTable(repositories []RepositoryStats, window string, since time.Time) {
+	streams.Printf("\n=== Konflux PR stats for the last %s (since %s) ===\n", window, since.Local().Format("Jan 2 15:04"))
+	if len(repositories) == 0 {
+		streams.Println("\nNo repositories to show stats for")
+		return
+	}
+
+	repoWidth := render.DisplayWidth("REPO")
+	for _, stats := range repositories {
+		repoWidth = max(repoWidth, render.DisplayWidth(stats.Repository))
+	}
+	const (
+		countWidth   = 9  // "MIGRATION"
+		approveWidth = 11 // "AVG APPROVE"
+		oldestWidth  = 18 // "#12345 (123d23h)"
+	)
+	table := render.NewTable(
+		render.Column{Header: "REPO", Width: repoWidth},
+		render.Column{Header: "OPENED", Width: countWidth},
+		render.Column{Header: "MERGED", Width: countWidth},
+		render.Column{Header: "AVG APPROVE", Width: approveWidth},
+		render.Column{Header: "MIGRATION", Width: countWidth},
+		render.Column{Header: "OPEN NOW", Width: countWidth},
+		render.Column{Header: "OLDEST OPEN", Width: oldestWidth},
+	)
+
+	for _, stats := range repositories {
+		average := "-"
+		if stats.AverageTimeToApproveSeconds != nil {
+			average = formatAge(time.Duration(*stats.AverageTimeToApproveSeconds) * time.Second)
+		}
+		oldest := "-"
+		if stats.OldestOpenNumber != nil && stats.OldestOpenAgeSeconds != nil {
+			oldest = fmt.Sprintf("#%d (%s)", *stats.OldestOpenNumber, formatAge(time.Duration(*stats.OldestOpenAgeSeconds)*time.Second))
+		}
+		table.AddRow(
+			stats.Repository,
+			strconv.Itoa(stats.Opened),
+			strconv.Itoa(stats.Merged),
+			average,
+			strconv.Itoa(stats.Migration),
+			strconv.Itoa(stats.OpenPRs),
+			oldest)
+	}
+	table.Write(streams.Out)
+}
+
+func init() {
+	statsCmd.Flags().StringVar(&statsSince, "since", defaultStatsWindow, "Time window to compute the stats over, e.g. 7d, 2w or 36h")
+	RootCmd.AddCommand(statsCmd)
+}
@@ -0,0 +1,118 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Stats", func() {
+	const (
+		recentPath = "repos/owner/repo/pulls?state=all&sort=updated&direction=desc&per_page=100&page=1"
+		openPath   = "repos/owner/repo/pulls?state=open&per_page=100&page=1"
+		konflux    = "red-hat-konflux[bot]"
+	)
+
+	var (
+		mockClient *cmd.MockRESTClient
+		now        time.Time
+		since      time.Time
+	)
+
+	at := func(ago time.Duration) string {
+		return now.Add(-ago).Format(time.RFC3339)
+	}
+
+	BeforeEach(func() {
+		mockClient = cmd.NewMockRESTClient()
+		now = time.Date(2025, 6, 30, 12, 0, 0, 0, time.UTC)
+		since = now.Add(-7 * 24 * time.Hour)
+	})
+
+	It("should compute the metrics of the PRs within the window", func() {
+		mockClient.AddResponse(recentPath, 200, []cmd.PullRequest{
+			// Opened and approved within the window
+			{Number: 5, User: cmd.User{Login: konflux}, State: "open", CreatedAt: at(48 * time.Hour), UpdatedAt: at(time.Hour)},
+			// Opened within the window with a migration warning, then merged
+			{Number: 4, User: cmd.User{Login: konflux}, State: "closed", CreatedAt: at(72 * time.Hour), UpdatedAt: at(2 * time.Hour),
+				MergedAt: at(2 * time.Hour), Body: "⚠️[migration] Update the pipeline"},
+			// Not a Konflux PR
+			{Number: 3, User: cmd.User{Login: "someone"}, State: "open", CreatedAt: at(24 * time.Hour), UpdatedAt: at(3 * time.Hour)},
+			// Opened before the window, merged within it
+			{Number: 2, User: cmd.User{Login: konflux}, State: "closed", CreatedAt: at(30 * 24 * time.Hour), UpdatedAt: at(4 * 24 * time.Hour),
+				MergedAt: at(4 * 24 * time.Hour)},
+			// Last updated before the window, so paging stops here
+			{Number: 1, User: cmd.User{Login: konflux}, State: "closed", CreatedAt: at(60 * 24 * time.Hour), UpdatedAt: at(20 * 24 * time.Hour),
+				MergedAt: at(20 * 24 * time.Hour)},
+		})
+		mockClient.AddResponse("repos/owner/repo/pulls/5/reviews?per_page=100", 200, []cmd.Review{
+			{State: "COMMENTED", SubmittedAt: at(47 * time.Hour)},
+			{State: "APPROVED", SubmittedAt: at(46 * time.Hour)},
+		})
+		mockClient.AddResponse("repos/owner/repo/pulls/4/reviews?per_page=100", 200, []cmd.Review{
+			{State: "APPROVED", SubmittedAt: at(68 * time.Hour)},
+			{State: "APPROVED", SubmittedAt: at(3 * time.Hour)},
+		})
+		mockClient.AddResponse(openPath, 200, []cmd.PullRequest{
+			{Number: 5, User: cmd.User{Login: konflux}, State: "open", CreatedAt: at(48 * time.Hour)},
+			{Number: 3, User: cmd.User{Login: "someone"}, State: "open", CreatedAt: at(24 * time.Hour)},
+			{Number: 7, User: cmd.User{Login: konflux}, State: "open", CreatedAt: at(40 * 24 * time.Hour)},
+		})
+
+		stats, err := cmd.ComputeRepositoryStatsTest(mockClient, "owner", "repo", []string{konflux}, since, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Repository).To(Equal("owner/repo"))
+		Expect(stats.Opened).To(Equal(2))
+		Expect(stats.Merged).To(Equal(2))
+		Expect(stats.Migration).To(Equal(1))
+		Expect(stats.Approved).To(Equal(2))
+		// 2h for #5 and 4h for #4
+		Expect(stats.AverageTimeToApproveSeconds).To(HaveValue(Equal(int64(3 * 60 * 60))))
+		Expect(stats.OpenPRs).To(Equal(2))
+		Expect(stats.OldestOpenNumber).To(HaveValue(Equal(7)))
+		Expect(stats.OldestOpenAgeSeconds).To(HaveValue(Equal(int64(40 * 24 * 60 * 60))))
+	})
+
+	It("should leave out what can't be measured", func() {
+		mockClient.AddResponse(recentPath, 200, []cmd.PullRequest{})
+		mockClient.AddResponse(openPath, 200, []cmd.PullRequest{})
+
+		stats, err := cmd.ComputeRepositoryStatsTest(mockClient, "owner", "repo", []string{konflux}, since, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(stats.Opened).To(BeZero())
+		Expect(stats.AverageTimeToApproveSeconds).To(BeNil())
+		Expect(stats.OldestOpenNumber).To(BeNil())
+		Expect(stats.OldestOpenAgeSeconds).To(BeNil())
+	})
+
+	It("should fail when the PRs can't be fetched", func() {
+		mockClient.AddResponse(recentPath, 500, map[string]string{"message": "boom"})
+
+		_, err := cmd.ComputeRepositoryStatsTest(mockClient, "owner", "repo", []string{konflux}, since, now)
+		Expect(err).To(HaveOccurred())
+	})
+
+	It("should show the metrics as a table", func() {
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		defer cmd.ResetIOStreams()
+
+		average, number, age := int64(3*60*60), 7, int64(40*24*60*60)
+		cmd.DisplayStatsTableTest([]cmd.RepositoryStats{
+			{Repository: "owner/repo", Opened: 2, Merged: 2, Approved: 2, AverageTimeToApproveSeconds: &average,
+				Migration: 1, OpenPRs: 2, OldestOpenNumber: &number, OldestOpenAgeSeconds: &age},
+			{Repository: "owner/quiet"},
+		}, "7d", since)
+
+		Expect(out.String()).To(ContainSubstring("Konflux PR stats for the last 7d"))
+		Expect(out.String()).To(ContainSubstring("AVG APPROVE"))
+		Expect(out.String()).To(ContainSubstring("3h0m"))
+		Expect(out.String()).To(ContainSubstring("#7 (40d0h)"))
+		Expect(out.String()).To(MatchRegexp(`owner/quiet\s+0\s+0\s+-\s+0\s+0\s+-`))
+	})
+})
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+	"github.com/spf13/cobra"
+)
+
+// tektonDir is where Konflux keeps a repository's pipelines
+const tektonDir = ".tekton/"
+
+// tektonChange is a structural change a PR makes to a repository's pipelines, as opposed to the routine task
+// bundle bumps of existing pipelines
+type tektonChange struct {
+	File string
+	// Change says what happened to the file, e.g. "added" or "renamed from .tekton/old-push.yaml"
+	Change string
+}
+
+// tektonStructuralChanges returns the pipelines files adds, removes or renames, and the existing ones missing
+// from baseline, the .tekton files the repository is expected to have. Without a baseline only the files the
+// PR itself adds, removes or renames are reported.
+func tektonStructuralChanges(files []PRFile, baseline []string) []tektonChange {
+	var changes []tektonChange
+	for _, file := range files {
+		if !strings.HasPrefix(file.Filename, tektonDir) && !strings.HasPrefix(file.PreviousFilename, tektonDir) {
+			continue
+		}
+		switch file.Status {
+		case "added":
+			if !slices.Contains(baseline, file.Filename) {
+				changes = append(changes, tektonChange{File: file.Filename, Change: "added"})
+			}
+		case "removed":
+			changes = append(changes, tektonChange{File: file.Filename, Change: "removed"})
+		case "renamed":
+			changes = append(changes, tektonChange{File: file.Filename, Change: "renamed from " + file.PreviousFilename})
+		default:
+			if len(baseline) > 0 && !slices.Contains(baseline, file.Filename) {
+				changes = append(changes, tektonChange{File: file.Filename, Change: "not in the expected .tekton files"})
+			}
+		}
+	}
+	return changes
+}
+
+// displayTektonStructuralChanges warns that a PR changes which pipelines a repository has, which a routine
+// Konflux update never does
+func displayTektonStructuralChanges(changes []tektonChange) {
+	if len(changes) == 0 {
+		return
+	}
+	streams.Printf("   🧱 STRUCTURAL PIPELINE CHANGE: this PR changes which .tekton pipelines the repository has:\n")
+	for _, change := range changes {
+		streams.Printf("      %s (%s)\n", change.File, change.Change)
+	}
+}
+
+// tektonContent is an entry of the .tekton directory listed by the contents API
+type tektonContent struct {
+	Path string `json:"path"`
+	Type string `json:"type"`
+}
+
+// fetchTektonFiles lists the .tekton files on a repository's default branch, nothing if it has no .tekton
+// directory
+func fetchTektonFiles(ctx context.Context, client RESTClientInterface, owner, repo string) ([]string, error) {
+	var contents []tektonContent
+	path := fmt.Sprintf("repos/%s/%s/contents/%s", owner, repo, strings.TrimSuffix(tektonDir, "/"))
+	if err := client.DoWithContext(ctx, http.MethodGet, path, nil, &contents); err != nil {
+		var httpErr *api.HTTPError
+		if errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusNotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var files []string
+	for _, content := range contents {
+		if content.Type == "file" {
+			files = append(files, content.Path)
+		}
+	}
+	sort.Strings(files)
+	return files, nil
+}
+
+// configLearnTektonFilesCmd records the .tekton files a repository is expected to have
+var configLearnTektonFilesCmd = &cobra.Command{
+	Use:   "learn-tekton-files <owner/repo> [file...]",
+	Short: "Record the .tekton files a Konflux repository is expected to have",
+	Long: `Record the .tekton files a configured repository is expected to have, learned from its default
+branch or given as arguments. When reviewing a Konflux PR, pipelines it adds or removes, and pipelines
+missing from these files, are highlighted as structural changes rather than routine bundle bumps.
+
+Without a baseline, pipelines a PR adds, removes or renames are still highlighted. Run it again after
+a structural change is merged to learn the new files.
+
+Examples:
+  ghprs config learn-tekton-files my-org/operator
+  ghprs config learn-tekton-files my-org/operator .tekton/operator-pull-request.yaml .tekton/operator-push.yaml`,
+	Args: cobra.MinimumNArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		repoSpec := args[0]
+		owner, repo, ok := parseRepoSpec(repoSpec)
+		if !ok {
+			fmt.Println("Repository must be in the format 'owner/repo'")
+			os.Exit(1)
+		}
+
+		config, err := LoadConfig()
+		if err != nil {
+			fmt.Printf("Error loading config: %v\n", err)
+			os.Exit(1)
+		}
+
+		files := args[1:]
+		if len(files) == 0 {
+			client, err := newAPIClient(config.HostFor(repoSpec), nil, nil)
+			if err != nil {
+				fmt.Printf("Error creating GitHub client: %v\n", err)
+				os.Exit(1)
+			}
+			files, err = fetchTektonFiles(context.Background(), client, owner, repo)
+			if err != nil {
+				fmt.Printf("Error listing the .tekton files of %s: %v\n", repoSpec, err)
+				os.Exit(1)
+			}
+			if len(files) == 0 {
+				fmt.Printf("Repository %s has no .tekton files\n", repoSpec)
+				os.Exit(1)
+			}
+		}
+
+		if !config.SetTektonBaseline(repoSpec, files) {
+			fmt.Printf("Repository %s is not configured, add it with 'ghprs config add-konflux-repo %s'\n", repoSpec, repoSpec)
+			os.Exit(1)
+		}
+		if err := SaveConfig(config); err != nil {
+			fmt.Printf("Error saving config: %v\n", err)
+			os.Exit(1)
+		}
+
+		fmt.Printf("Recorded %d expected .tekton files for %s:\n", len(files), repoSpec)
+		for _, file := range files {
+			fmt.Printf("  %s\n", file)
+		}
+	},
+}
@@ -0,0 +1,87 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Tekton baseline", func() {
+	baseline := []string{".tekton/app-pull-request.yaml", ".tekton/app-push.yaml"}
+
+	It("should not flag routine bundle bumps of expected pipelines", func() {
+		files := []cmd.PRFile{
+			{Filename: ".tekton/app-pull-request.yaml", Status: "modified"},
+			{Filename: ".tekton/app-push.yaml", Status: "modified"},
+		}
+		Expect(cmd.TektonStructuralChangesTest(files, baseline)).To(BeEmpty())
+		Expect(cmd.TektonStructuralChangesTest(files, nil)).To(BeEmpty())
+	})
+
+	It("should flag pipelines added, removed or renamed", func() {
+		files := []cmd.PRFile{
+			{Filename: ".tekton/app-pull-request.yaml", Status: "removed"},
+			{Filename: ".tekton/bundle-push.yaml", Status: "added"},
+			{Filename: ".tekton/app-on-push.yaml", Status: "renamed", PreviousFilename: ".tekton/app-push.yaml"},
+			{Filename: "Dockerfile", Status: "added"},
+		}
+		Expect(cmd.TektonStructuralChangesTest(files, baseline)).To(Equal([]string{
+			".tekton/app-pull-request.yaml (removed)",
+			".tekton/bundle-push.yaml (added)",
+			".tekton/app-on-push.yaml (renamed from .tekton/app-push.yaml)",
+		}))
+	})
+
+	It("should flag pipelines missing from the baseline and not the ones it expects", func() {
+		files := []cmd.PRFile{
+			{Filename: ".tekton/app-push.yaml", Status: "added"},
+			{Filename: ".tekton/bundle-push.yaml", Status: "modified"},
+		}
+		Expect(cmd.TektonStructuralChangesTest(files, baseline)).To(Equal([]string{
+			".tekton/bundle-push.yaml (not in the expected .tekton files)",
+		}))
+	})
+
+	It("should learn the files of the .tekton directory", func() {
+		mockClient := cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/contents/.tekton", 200, []map[string]string{
+			{"path": ".tekton/app-push.yaml", "type": "file"},
+			{"path": ".tekton/tasks", "type": "dir"},
+			{"path": ".tekton/app-pull-request.yaml", "type": "file"},
+		})
+
+		files, err := cmd.FetchTektonFilesTest(mockClient, "owner", "repo")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(files).To(Equal(baseline))
+	})
+
+	It("should record the baseline of configured repositories only", func() {
+		config := &cmd.Config{Repositories: []cmd.RepositoryConfig{{Name: "owner/repo", Konflux: true}}}
+		Expect(config.SetTektonBaseline("owner/repo", baseline)).To(BeTrue())
+		Expect(config.SetTektonBaseline("owner/other", baseline)).To(BeFalse())
+		Expect(config.TektonBaselines()).To(Equal(map[string][]string{"owner/repo": baseline}))
+	})
+
+	It("should highlight structural changes in the approval prompt", func() {
+		out := &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader("#1\nn\n"), out, out), nil)
+		defer cmd.ResetIOStreams()
+
+		mockClient := cmd.NewMockRESTClient()
+		mockClient.AddResponse("repos/owner/repo/pulls/1/files", 200, []cmd.PRFile{
+			{Filename: ".tekton/app-push.yaml", Status: "modified"},
+			{Filename: ".tekton/bundle-push.yaml", Status: "added"},
+		})
+		pr := cmd.PullRequest{Number: 1, Title: "Update", State: "open"}
+		pr.User.Login = "red-hat-konflux[bot]"
+
+		cmd.ApprovePRsTest(mockClient, "owner", "repo", []cmd.PullRequest{pr}, true)
+		Expect(out.String()).To(ContainSubstring("🧱 STRUCTURAL PIPELINE CHANGE"))
+		Expect(out.String()).To(ContainSubstring(".tekton/bundle-push.yaml (added)"))
+		Expect(out.String()).NotTo(ContainSubstring(".tekton/app-push.yaml ("))
+	})
+})
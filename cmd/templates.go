@@ -0,0 +1,81 @@
+package cmd
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// templateVariableRE matches a variable of a comment template, such as {{author}} or {{ number }}
+var templateVariableRE = regexp.MustCompile(`\{\{\s*(\w+)\s*\}\}`)
+
+// commentTemplateNames returns the names of the comment templates in the order they are offered
+func commentTemplateNames(templates map[string]string) []string {
+	names := make([]string, 0, len(templates))
+	for name := range templates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// expandCommentTemplate fills in the variables of a comment template for a PR: {{number}}, {{author}},
+// {{branch}}, {{target}}, {{title}} and {{repo}}. Unknown variables are left as they are.
+func expandCommentTemplate(template string, pr PullRequest, owner, repo string) string {
+	values := map[string]string{
+		"number": strconv.Itoa(pr.Number),
+		"author": pr.User.Login,
+		"branch": pr.Head.Ref,
+		"target": pr.Base.Ref,
+		"title":  pr.Title,
+		"repo":   owner + "/" + repo,
+	}
+	return templateVariableRE.ReplaceAllStringFunc(template, func(variable string) string {
+		name := templateVariableRE.FindStringSubmatch(variable)[1]
+		if value, ok := values[strings.ToLower(name)]; ok {
+			return value
+		}
+		return variable
+	})
+}
+
+// parseCommentTemplate parses the name=text of 'config set template'. Names can't be numbers, which pick a
+// template at the prompt.
+func parseCommentTemplate(value string) (string, string, error) {
+	name, text, ok := strings.Cut(value, "=")
+	name = strings.TrimSpace(name)
+	if !ok || name == "" {
+		return "", "", fmt.Errorf("missing template name")
+	}
+	if _, err := strconv.Atoi(name); err == nil {
+		return "", "", fmt.Errorf("template name %q is a number, which picks a template by position", name)
+	}
+	return name, text, nil
+}
+
+// promptForComment asks for a comment at the approval prompt, offering the configured comment templates by
+// number. A template picked is filled in for the PR.
+func promptForComment(templates map[string]string, pr PullRequest, owner, repo string) (string, error) {
+	if len(templates) == 0 {
+		return promptMessage("Enter your comment ('e' to use your editor): ")
+	}
+
+	names := commentTemplateNames(templates)
+	streams.Printf("Templates:\n")
+	for i, name := range names {
+		streams.Printf("  %d. %s: %s\n", i+1, name, strings.Join(strings.Fields(templates[name]), " "))
+	}
+	answer, err := promptMessage(fmt.Sprintf("Enter your comment or a template (1-%d) ('e' to use your editor): ", len(names)))
+	if err != nil {
+		return "", err
+	}
+	choice, convErr := strconv.Atoi(strings.TrimSpace(answer))
+	if convErr != nil || choice < 1 || choice > len(names) {
+		return answer, nil
+	}
+	comment := expandCommentTemplate(templates[names[choice-1]], pr, owner, repo)
+	streams.Printf("   Using template %s: %s\n", names[choice-1], comment)
+	return comment, nil
+}
@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Comment templates", func() {
+	pr := cmd.PullRequest{
+		Number: 42,
+		Title:  "Update deps",
+		User:   cmd.User{Login: "alice"},
+		Head:   cmd.Branch{Ref: "feature"},
+		Base:   cmd.Branch{Ref: "main"},
+	}
+	templates := map[string]string{
+		"retest": "/retest",
+		"ack":    "Thanks @{{author}} for #{{ number }} on {{branch}}!",
+	}
+
+	It("should fill in the variables of the PR, leaving unknown ones", func() {
+		Expect(cmd.ExpandCommentTemplateTest("Thanks @{{author}} for #{{ number }} ({{branch}} -> {{target}}, {{repo}}) {{unknown}}", pr, "owner", "repo")).
+			To(Equal("Thanks @alice for #42 (feature -> main, owner/repo) {{unknown}}"))
+	})
+
+	DescribeTable("should parse name=text",
+		func(value, name, text string, valid bool) {
+			parsedName, parsedText, err := cmd.ParseCommentTemplateTest(value)
+			if !valid {
+				Expect(err).To(HaveOccurred())
+				return
+			}
+			Expect(err).NotTo(HaveOccurred())
+			Expect(parsedName).To(Equal(name))
+			Expect(parsedText).To(Equal(text))
+		},
+		Entry("a template", "ok=/ok-to-test", "ok", "/ok-to-test", true),
+		Entry("text with =", "ack=a=b", "ack", "a=b", true),
+		Entry("no text, to remove it", "ok=", "ok", "", true),
+		Entry("no name", "=/retest", "", "", false),
+		Entry("no =", "retest", "", "", false),
+		Entry("a number", "1=/retest", "", "", false),
+	)
+
+	Describe("at the comment prompt", func() {
+		var out *bytes.Buffer
+
+		prompt := func(input string, templates map[string]string) string {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(input), out, &bytes.Buffer{}), nil)
+			comment, err := cmd.PromptForCommentTest(templates, pr, "owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			return comment
+		}
+
+		BeforeEach(func() {
+			out = &bytes.Buffer{}
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should offer the templates by number and fill in the one picked", func() {
+			Expect(prompt("1\n", templates)).To(Equal("Thanks @alice for #42 on feature!"))
+			Expect(out.String()).To(ContainSubstring("1. ack: Thanks @{{author}}"))
+			Expect(out.String()).To(ContainSubstring("2. retest: /retest"))
+		})
+
+		It("should take anything else as the comment", func() {
+			Expect(prompt("3\n", templates)).To(Equal("3"))
+			Expect(prompt("/hold\n", templates)).To(Equal("/hold"))
+		})
+
+		It("should only ask for the comment without templates", func() {
+			Expect(prompt("1\n", nil)).To(Equal("1"))
+			Expect(out.String()).NotTo(ContainSubstring("Templates"))
+		})
+	})
+})
@@ -1,22 +1,51 @@
 package cmd
 
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+
+	"ghprs/internal/render"
+	"ghprs/pkg/ghprs"
+)
+
 // Test helper functions that expose internal functionality for testing
 
+// listOptionsTest are the options the helpers below list and approve PRs with, as UseListFlagsTest and the
+// setters leave them
+var listOptionsTest = newListOptions()
+
+// approvalConfigTest is config with what the flags of listOptionsTest decide about the approval flow
+func approvalConfigTest(config ApprovalConfig) ApprovalConfig {
+	config.ShowFiles, config.ShowDiff, config.ShowCommits = listOptionsTest.ShowFiles, listOptionsTest.ShowDiff, listOptionsTest.ShowCommits
+	config.Diff = diffView{Mode: listOptionsTest.DiffMode, Files: listOptionsTest.DiffFiles}
+	config.ExpandChecks = listOptionsTest.ExpandChecks
+	config.SkipRedBase = listOptionsTest.SkipRedBase
+	config.Table = listOptionsTest
+	return config
+}
+
 // Exported utility functions for testing
 func TruncateStringTest(s string, maxWidth int) string {
-	return TruncateString(s, maxWidth)
+	return render.TruncateString(s, maxWidth)
 }
 
 func DisplayWidthTest(s string) int {
-	return DisplayWidth(s)
+	return render.DisplayWidth(s)
 }
 
 func StripANSISequencesTest(s string) string {
-	return StripANSISequences(s)
+	return render.StripANSISequences(s)
 }
 
 func PadStringTest(s string, width int) string {
-	return PadString(s, width)
+	return render.PadString(s, width)
 }
 
 func FormatPRLinkTest(owner, repo string, prNumber int) string {
@@ -56,7 +85,7 @@ func IsReviewedTest(client RESTClientInterface, owner, repo string, prNumber int
 }
 
 func FetchPRDetailsTest(client RESTClientInterface, owner, repo string, prNumber int) (*PullRequest, error) {
-	return fetchPRDetails(client, owner, repo, prNumber)
+	return fetchPRDetails(context.Background(), client, owner, repo, prNumber)
 }
 
 func NewPRDetailsCacheTest() *PRDetailsCache {
@@ -68,7 +97,7 @@ func (c *PRDetailsCache) GetOrFetchTest(client RESTClientInterface, owner, repo
 }
 
 func ColorizeGitDiffTest(diff string) string {
-	return colorizeGitDiff(diff)
+	return render.ColorizeGitDiff(diff)
 }
 
 func SortPullRequestsTest(prs []PullRequest, sortBy string) {
@@ -80,7 +109,7 @@ func HasSecurityTest(pr PullRequest) bool {
 }
 
 func CheckTektonFilesDetailedTest(client RESTClientInterface, owner, repo string, prNumber int) (bool, []string, error) {
-	return checkTektonFilesDetailed(client, owner, repo, prNumber)
+	return checkTektonFilesDetailed(context.Background(), client, owner, repo, prNumber)
 }
 
 func NeedsRebaseWithCacheTest(cache *PRDetailsCache, client RESTClientInterface, owner, repo string, pr PullRequest) (bool, bool) {
@@ -92,7 +121,7 @@ func IsBlockedWithCacheTest(cache *PRDetailsCache, client RESTClientInterface, o
 }
 
 func FilterPRsTest(pullRequests []PullRequest, client RESTClientInterface, owner, repo string, isKonflux bool) []PullRequest {
-	return filterPRs(pullRequests, client, owner, repo, isKonflux)
+	return filterPRs(context.Background(), listOptionsTest, pullRequests, client, owner, repo, isKonflux)
 }
 
 func SaveConfigTest(config Config, path string) error {
@@ -102,3 +131,1386 @@ func SaveConfigTest(config Config, path string) error {
 func LoadConfigTest(path string) (*Config, error) {
 	return loadConfig(path)
 }
+
+func BuildPRRowsTest(pullRequests []PullRequest, owner, repo string, client RESTClientInterface, isKonflux bool) []PRRow {
+	return buildPRRows(context.Background(), listOptionsTest, pullRequests, owner, repo, client, isKonflux, nil)
+}
+
+func ValidateOutputFormatTest(format string) error {
+	return validateOutputFormat(format)
+}
+
+func WriteStructuredOutputTest(w io.Writer, doc PRListOutput, format string) error {
+	return writeStructuredOutput(w, doc, format)
+}
+
+func WritePlainOutputTest(w io.Writer, doc PRListOutput, delimiter string) error {
+	return writePlainOutput(w, doc, delimiter)
+}
+
+// WriteCSVOutputTest writes the PR list as CSV
+func WriteCSVOutputTest(w io.Writer, doc PRListOutput) error {
+	return writeCSVOutput(w, doc)
+}
+
+// WriteMarkdownOutputTest writes the PR list as Markdown tables
+func WriteMarkdownOutputTest(w io.Writer, doc PRListOutput) error {
+	return writeMarkdownOutput(w, doc, false)
+}
+
+func ValidateDelimiterTest(delimiter string) error {
+	return validateDelimiter(delimiter)
+}
+
+func FetchPullRequestsGraphQLTest(gqlClient GraphQLClientInterface, restClient RESTClientInterface, owner, repo, state, baseRef string, maxPRs int) ([]PullRequest, RESTClientInterface, error) {
+	return FetchPullRequestsGraphQLWithContextTest(context.Background(), gqlClient, restClient, owner, repo, state, baseRef, maxPRs)
+}
+
+// FetchPullRequestsGraphQLWithContextTest is FetchPullRequestsGraphQLTest with the queries stopping when ctx is done
+func FetchPullRequestsGraphQLWithContextTest(ctx context.Context, gqlClient GraphQLClientInterface, restClient RESTClientInterface, owner, repo, state, baseRef string, maxPRs int) ([]PullRequest, RESTClientInterface, error) {
+	return fetchPullRequestsGraphQL(ctx, gqlClient, restClient, owner, repo, state, baseRef, maxPRs, nil)
+}
+
+func FetchPullRequestsRESTTest(client RESTClientInterface, owner, repo, state, baseRef string, maxPRs int, author string) ([]PullRequest, error) {
+	var filter prFilter
+	if author != "" {
+		filter = func(_ RESTClientInterface, page []PullRequest) []PullRequest {
+			var matches []PullRequest
+			for _, pr := range page {
+				if pr.User.Login == author {
+					matches = append(matches, pr)
+				}
+			}
+			return matches
+		}
+	}
+	return fetchPullRequestsREST(client, owner, repo, state, baseRef, maxPRs, filter)
+}
+
+func RunConcurrentlyTest(n, workers int, fn func(i int)) {
+	runConcurrently(n, workers, fn)
+}
+
+func WithRequestTimeoutTest(client RESTClientInterface, timeout time.Duration) RESTClientInterface {
+	return withRequestTimeout(client, timeout)
+}
+
+func FetchFilteredPullRequestsTest(client RESTClientInterface, owner, repo, state string, maxPRs int, author string, isKonflux bool) ([]PullRequest, error) {
+	var authors []string
+	if author != "" {
+		authors = []string{author}
+	}
+	return FetchPullRequestsByAuthorsTest(client, owner, repo, state, maxPRs, authors, isKonflux)
+}
+
+func FetchPullRequestsByAuthorsTest(client RESTClientInterface, owner, repo, state string, maxPRs int, authors []string, isKonflux bool) ([]PullRequest, error) {
+	return fetchPullRequestsREST(client, owner, repo, state, "", maxPRs, newPRFilter(context.Background(), listOptionsTest, owner, repo, authors, isKonflux))
+}
+
+func QueueAuthorsTest(config *Config, repoSpec string, authors []string, isKonflux bool) []string {
+	return queueAuthors(config, repoSpec, authors, isKonflux)
+}
+
+func WithTolerantDecodingTest(client RESTClientInterface) RESTClientInterface {
+	return withTolerantDecoding(client)
+}
+
+func NormalizeMergeableStateTest(state string) string {
+	return normalizeMergeableState(state)
+}
+
+func SetDebugModeTest(enabled bool) {
+	debugMode = enabled
+}
+
+// SetLogFlagsTest sets --verbose and --debug and applies them to the log level
+func SetLogFlagsTest(isVerbose, isDebug bool) {
+	verbose, debugMode = isVerbose, isDebug
+	setupLogging()
+}
+
+func WithAPILoggingTest(client RESTClientInterface) RESTClientInterface {
+	return withAPILogging(client)
+}
+
+func WithContextTest(client RESTClientInterface, ctx context.Context) RESTClientInterface {
+	return withContext(client, ctx)
+}
+
+func SetRunTimeoutTest(timeout time.Duration) {
+	runTimeout = timeout
+}
+
+func WithRepositoryTimeoutTest(ctx context.Context) (context.Context, context.CancelFunc) {
+	return withRepositoryTimeout(ctx)
+}
+
+func DescribeCancellationTest(err error) string {
+	return describeCancellation(err)
+}
+
+func LogWarningTest(message string) {
+	logger.Warn(message)
+}
+
+func RawPayloadTest(path string) ([]byte, bool) {
+	return rawPayload(path)
+}
+
+func WithDiskCacheTest(client RESTClientInterface, dir string, ttl time.Duration) RESTClientInterface {
+	return withDiskCache(client, newDiskCache(dir, ttl), "github.com")
+}
+
+func DiskCacheStatsTest(dir string, ttl time.Duration) (CacheStats, error) {
+	return newDiskCache(dir, ttl).stats()
+}
+
+func ClearDiskCacheTest(dir string) (int, error) {
+	return newDiskCache(dir, defaultCacheTTL).clear()
+}
+
+func WithRateLimitTest(client RESTClientInterface, threshold int, out io.Writer, sleep func(d time.Duration)) RESTClientInterface {
+	limiter := newRateLimiter(threshold, out)
+	limiter.sleep = func(_ context.Context, d time.Duration) error {
+		sleep(d)
+		return nil
+	}
+	return withRateLimit(withTolerantDecoding(withRateLimitObserver(client, limiter)), limiter)
+}
+
+func RateLimitSummaryTest(headers http.Header) string {
+	limiter := newRateLimiter(defaultRateLimitThreshold, io.Discard)
+	limiter.observe(headers)
+	return limiter.summary()
+}
+
+func ApprovePRsTest(client RESTClientInterface, owner, repo string, pullRequests []PullRequest, isKonflux bool) {
+	approvePRsWithConfig(context.Background(), client, owner, repo, pullRequests, approvalConfigTest(ApprovalConfig{IsKonflux: isKonflux, TrustedAuthors: defaultTrustedAuthors}), nil)
+}
+
+func PromptForRepositorySelectionTest(repositories []string) string {
+	return promptForRepositorySelection(repositories)
+}
+
+func ResetLegendTest(mode string) {
+	legend.Reset(mode)
+}
+
+func TakeLegendTest() bool {
+	return legend.Take()
+}
+
+func DiffWatchSnapshotsTest(previous, current map[int]WatchSnapshot) []WatchChange {
+	return diffWatchSnapshots(previous, current)
+}
+
+func WatchRefreshTest(client RESTClientInterface, owner, repo string) (map[int]WatchSnapshot, error) {
+	return watchRefresh(context.Background(), listOptionsTest, client, owner, repo, nil, false)
+}
+
+func ChecksSummaryTest(status *CheckStatus) string {
+	return checksSummary(status)
+}
+
+func ReportWatchChangesTest(repoSpec string, changes []WatchChange, notify func(title, message string) error) {
+	saved := notifier
+	notifier = notify
+	defer func() { notifier = saved }()
+	reportWatchChanges(repoSpec, changes, notify != nil)
+}
+
+func PlanHoldTest(client RESTClientInterface, owner, repo string, numbers []int, comment string) []PlannedAction {
+	return planHold(context.Background(), client, owner, repo, numbers, comment, time.Time{}).actions
+}
+
+func HoldPRsTest(client RESTClientInterface, owner, repo string, numbers []int, comment string, assumeYes bool) int {
+	return holdPRs(context.Background(), client, owner, repo, numbers, comment, time.Time{}, assumeYes)
+}
+
+func HoldPRsUntilTest(client RESTClientInterface, owner, repo string, numbers []int, comment string, expiry time.Time) int {
+	return holdPRs(context.Background(), client, owner, repo, numbers, comment, expiry, true)
+}
+
+func ParseHoldExpiryTest(until, duration string, now time.Time) (time.Time, error) {
+	return parseHoldExpiry(until, duration, now)
+}
+
+func FetchHoldExpiryTest(client RESTClientInterface, owner, repo string, prNumber int) (time.Time, error) {
+	return fetchHoldExpiry(client, owner, repo, prNumber)
+}
+
+func FindExpiredHoldsTest(client RESTClientInterface, owner, repo string, snapshots map[int]WatchSnapshot, now time.Time, reported map[int]time.Time) []ExpiredHold {
+	return findExpiredHolds(client, owner, repo, snapshots, now, reported)
+}
+
+func HandleExpiredHoldsTest(client RESTClientInterface, owner, repo string, expired []ExpiredHold, autoUnhold bool) {
+	handleExpiredHolds(client, owner, repo, expired, autoUnhold, false)
+}
+
+func PlanUnholdTest(client RESTClientInterface, owner, repo string, numbers []int, comment string) []PlannedAction {
+	return planUnhold(context.Background(), client, owner, repo, numbers, comment).actions
+}
+
+func UnholdPRsTest(client RESTClientInterface, owner, repo string, numbers []int, comment string, removeLabel, assumeYes bool) int {
+	return unholdPRs(context.Background(), client, owner, repo, numbers, comment, removeLabel, assumeYes)
+}
+
+func SetRepositoryHostsTest(config *Config) {
+	setRepositoryHosts(config)
+}
+
+func PRURLTest(owner, repo string, prNumber int) string {
+	return prURL(owner, repo, prNumber)
+}
+
+func WithDiskCacheForHostTest(client RESTClientInterface, dir string, ttl time.Duration, host string) RESTClientInterface {
+	return withDiskCache(client, newDiskCache(dir, ttl), host)
+}
+
+func WithFreshDataTest(ctx context.Context) context.Context {
+	return withFreshData(ctx)
+}
+
+func NewRevalidatingTransportTest(base http.RoundTripper) http.RoundTripper {
+	return &revalidatingTransport{base: base}
+}
+
+func RebasePRTest(client RESTClientInterface, owner, repo string, pr PullRequest) (string, error) {
+	return rebasePR(client, owner, repo, pr)
+}
+
+func PlanStateChangeTest(client RESTClientInterface, owner, repo string, numbers []int, state, comment string) []PlannedAction {
+	return planStateChange(context.Background(), client, owner, repo, numbers, state, comment).actions
+}
+
+func ChangePRStatesTest(client RESTClientInterface, owner, repo string, numbers []int, state, comment string, assumeYes bool) int {
+	return changePRStates(context.Background(), client, owner, repo, numbers, state, comment, assumeYes)
+}
+
+func PRChangesTest(displayed, current PullRequest, action string) ([]string, bool) {
+	return prChanges(displayed, current, action)
+}
+
+func ApprovePRsWithSettingsTest(client RESTClientInterface, owner, repo string, pullRequests []PullRequest, settings ApprovalSettings) {
+	approvePRsWithConfig(context.Background(), client, owner, repo, pullRequests, approvalConfigTest(ApprovalConfig{Review: settings, TrustedAuthors: defaultTrustedAuthors}), nil)
+}
+
+func NewApprovalConfigTest(config *Config, body string, withoutLGTM bool) ApprovalConfig {
+	opts := newListOptions()
+	opts.ApproveBody, opts.NoLGTM = body, withoutLGTM
+	return newApprovalConfig(opts, config, "", false)
+}
+
+func ValidateViewTest(view string) error {
+	return validateView(view)
+}
+
+func ParseReadinessFilterTest(values []string) ([]string, error) {
+	return parseReadinessFilter(values)
+}
+
+func PRReadinessTest(row PRRow, pr PullRequest) string {
+	return prReadiness(row, pr)
+}
+
+func BuildPRRowsWithReadinessTest(pullRequests []PullRequest, owner, repo string, client RESTClientInterface) []PRRow {
+	opts := newListOptions()
+	opts.View = ViewReadiness
+	return buildPRRows(context.Background(), opts, pullRequests, owner, repo, client, false, nil)
+}
+
+func FilterPRsByReadinessTest(pullRequests []PullRequest, client RESTClientInterface, owner, repo string, states []string) []PullRequest {
+	opts := newListOptions()
+	opts.Readiness = states
+	return filterPRsByReadiness(context.Background(), opts, pullRequests, client, owner, repo, false, states)
+}
+
+func SetColumnWidthsTest(config *Config) {
+	setColumnWidths(config)
+}
+
+func ParseColumnWidthTest(setting string) (string, string, error) {
+	return render.ParseColumnWidth(setting)
+}
+
+func TextColumnWidthsTest(rows []PRRow) (int, int, int, int) {
+	return textColumnWidths(rows)
+}
+
+func RenderPRTableTest(rows []PRRow, owner, repo string) {
+	renderPRTable(listOptionsTest, rows, owner, repo, false, false)
+}
+
+// CombinedPRsTest is the PRs fetched for one repository of the combined table
+type CombinedPRsTest struct {
+	RepoSpec string
+	PRs      []PullRequest
+}
+
+// DisplayCombinedTableTest shows the PRs of several repositories in the combined table, sorted by sort
+func DisplayCombinedTableTest(repos []CombinedPRsTest, sort string) {
+	opts := newListOptions()
+	opts.SortBy = sort
+
+	var combined []combinedRow
+	for _, repo := range repos {
+		rows := make([]PRRow, len(repo.PRs))
+		for i, pr := range repo.PRs {
+			rows[i] = PRRow{Number: pr.Number, Title: pr.Title, Author: pr.User.Login, State: pr.State, Migration: hasMigrationWarning(pr)}
+		}
+		combined = append(combined, newCombinedRows(repo.RepoSpec, repo.PRs, rows)...)
+	}
+	displayCombinedTable(opts, combined, false)
+}
+
+func SetStaleCheckAfterTest(after time.Duration) {
+	staleCheckAfter = after
+}
+
+func FormatAgeTest(age time.Duration) string {
+	return formatAge(age)
+}
+
+func StaleCheckSuitesTest(checkRuns []CheckRun, now time.Time) []int64 {
+	return staleCheckSuites(checkRuns, now)
+}
+
+func RetriggerStaleChecksTest(client RESTClientInterface, owner, repo, headSHA string) (int, int, error) {
+	return retriggerStaleChecks(client, owner, repo, headSHA)
+}
+
+func GetCheckStatusTest(client RESTClientInterface, owner, repo string, prNumber int, headSHA string) (*CheckStatus, error) {
+	return getCheckStatus(context.Background(), client, owner, repo, prNumber, headSHA)
+}
+
+// UseListFlagsTest parses args with the flags of list (or konflux) and makes them the options the helpers list
+// PRs with
+func UseListFlagsTest(args []string, isKonflux bool) error {
+	command := &cobra.Command{Use: "test"}
+	opts := newListOptions()
+	addListFlags(command, opts, isKonflux)
+	if err := command.ParseFlags(args); err != nil {
+		return err
+	}
+	opts.prepare(command)
+	listOptionsTest = opts
+	return nil
+}
+
+// ApplyConfigDefaultsTest applies the config defaults to the options of UseListFlagsTest and returns the
+// resulting state and limit
+func ApplyConfigDefaultsTest(config *Config) (string, int) {
+	applyConfigDefaults(listOptionsTest, config)
+	return listOptionsTest.State, listOptionsTest.Limit
+}
+
+func ParseBatchArgsTest(args []string, repo string) (string, string, []int) {
+	savedRepo := repoFlag
+	repoFlag = repo
+	defer func() { repoFlag = savedRepo }()
+	return parseBatchArgs(args)
+}
+
+func ShowChecksTest(client RESTClientInterface, owner, repo string, pr PullRequest, withLogs bool, lines int) (int, error) {
+	return showChecks(client, owner, repo, pr, withLogs, lines)
+}
+
+func FetchJobLogTailTest(client RESTClientInterface, owner, repo string, jobID int64, lines int) ([]string, error) {
+	return fetchJobLogTail(client, owner, repo, jobID, lines)
+}
+
+func RecordPRsTest(repoSpec string, prs []PullRequest, complete bool) {
+	recordPRs(repoSpec, prs, complete)
+}
+
+// CompletePRArgsTest returns the completions of the PR commands for args, with repo as the --repo flag
+func CompletePRArgsTest(args []string, repo string, batch bool) []string {
+	savedRepo := repoFlag
+	repoFlag = repo
+	defer func() { repoFlag = savedRepo }()
+	completions, _ := completePRArgs(batch)(nil, args, "")
+	return completions
+}
+
+func WritePRIndexTest(repositories []string) {
+	writePRIndex(loadPRIndex(), repositories)
+}
+
+func FetchedEveryOpenPRTest(authors []string, isKonflux bool, fetched int) bool {
+	return fetchedEveryOpenPR(listOptionsTest, authors, isKonflux, fetched)
+}
+
+func ImagePinningChangesTest(files []PRFile, policy string) []PinningChange {
+	return imagePinningChanges(files, policy)
+}
+
+func ConfirmPinningChangesTest(client RESTClientInterface, owner, repo string, pr PullRequest, policy string) bool {
+	return confirmPinningChanges(client, owner, repo, pr, policy)
+}
+
+func ReportRerunChecksTest(client RESTClientInterface, owner, repo string, pr PullRequest, comments []string) bool {
+	return reportRerunChecks(client, owner, repo, pr, comments)
+}
+
+// CanaryPRTest describes a Konflux PR of a repository for the canary tests
+type CanaryPRTest struct {
+	RepoSpec string
+	Client   RESTClientInterface
+	PR       PullRequest
+}
+
+// canaryRepoPRs groups the test PRs by repository, in the order the repositories first appear
+func canaryRepoPRs(prs []CanaryPRTest) [][]repoPR {
+	var prsByRepo [][]repoPR
+	index := make(map[string]int)
+	for _, pr := range prs {
+		owner, repo, _ := parseRepoSpec(pr.RepoSpec)
+		i, ok := index[pr.RepoSpec]
+		if !ok {
+			i = len(prsByRepo)
+			index[pr.RepoSpec] = i
+			prsByRepo = append(prsByRepo, nil)
+		}
+		prsByRepo[i] = append(prsByRepo[i], repoPR{Owner: owner, Repo: repo, Client: pr.Client, PR: pr.PR})
+	}
+	return prsByRepo
+}
+
+// GroupIdenticalChangesTest returns the title of each change with the PRs of the other repositories as
+// "owner/repo#number", after the canary's
+func GroupIdenticalChangesTest(canarySpec string, prs []CanaryPRTest) map[string][]string {
+	groups := make(map[string][]string)
+	for _, group := range groupIdenticalChanges(context.Background(), canarySpec, canaryRepoPRs(prs)) {
+		members := []string{fmt.Sprintf("%s/%s#%d", group.Canary.Owner, group.Canary.Repo, group.Canary.PR.Number)}
+		for _, other := range group.Others {
+			members = append(members, fmt.Sprintf("%s/%s#%d", other.Owner, other.Repo, other.PR.Number))
+		}
+		groups[group.Title] = members
+	}
+	return groups
+}
+
+// RunCanaryTest rolls out the only change shared by the canary and the other repositories
+func RunCanaryTest(canarySpec string, prs []CanaryPRTest, mergeMethod string, timeout time.Duration, assumeYes bool) bool {
+	return RunCanaryWithConfigTest(DefaultConfig(), canarySpec, prs, mergeMethod, timeout, assumeYes)
+}
+
+// RunCanaryWithConfigTest is RunCanaryTest with the approval settings of config
+func RunCanaryWithConfigTest(config *Config, canarySpec string, prs []CanaryPRTest, mergeMethod string, timeout time.Duration, assumeYes bool) bool {
+	groups := groupIdenticalChanges(context.Background(), canarySpec, canaryRepoPRs(prs))
+	if len(groups) != 1 {
+		return false
+	}
+	return runCanary(newListOptions(), groups[0], config, mergeMethod, timeout, 0, assumeYes)
+}
+
+func WaitForPostMergeChecksTest(client RESTClientInterface, owner, repo, sha string, timeout time.Duration) error {
+	return waitForPostMergeChecks(client, owner, repo, sha, timeout, 0)
+}
+
+func ValidateMergeMethodTest(method string) error {
+	return validateMergeMethod(method)
+}
+
+func ChooseMergeMethodTest(settings RepoMergeSettings, requested string) (string, error) {
+	return chooseMergeMethod(settings, requested)
+}
+
+func PostApprovalTest(client RESTClientInterface, owner, repo string, pr PullRequest, settings ApprovalSettings) error {
+	return postApproval(client, owner, repo, pr, settings)
+}
+
+func ExpectedApprovalLabelsTest(settings ApprovalSettings) []string {
+	return expectedApprovalLabels(settings)
+}
+
+// SetApprovalVerifyIntervalTest sets how often labels are polled while verifying an approval, returning the previous interval
+func SetApprovalVerifyIntervalTest(interval time.Duration) time.Duration {
+	previous := approvalVerifyInterval
+	approvalVerifyInterval = interval
+	return previous
+}
+
+// FetchPeoplePRsTest fetches the open PRs of a repository with the people filters of list, forgetting the
+// authenticated user looked up by earlier tests
+func FetchPeoplePRsTest(client RESTClientInterface, owner, repo string, authors []string, requested bool, assigned string) ([]PullRequest, error) {
+	viewerLoginsMutex.Lock()
+	viewerLogins = map[string]string{}
+	viewerLoginsMutex.Unlock()
+
+	opts := newListOptions()
+	opts.ReviewRequested, opts.Assignee = requested, assigned
+	prs, _, err := fetchRepositoryPRs(context.Background(), opts, client, owner, repo, authors, false)
+	return prs, err
+}
+
+func SetTokenFlagTest(token string) {
+	tokenFlag = token
+}
+
+func TokenOverrideTest() (string, string) {
+	return tokenOverride()
+}
+
+func ClientOptionsTokenTest(host string) string {
+	opts, _ := clientOptions(host)
+	return opts.AuthToken
+}
+
+func WithIdentityNoticeTest(client RESTClientInterface, host string) RESTClientInterface {
+	return withIdentityNotice(client, host)
+}
+
+func ResetActingLoginsTest() {
+	actingLoginsMutex.Lock()
+	defer actingLoginsMutex.Unlock()
+	actingLogins = map[string]string{}
+}
+
+func SetKonfluxComponentsTest(config *Config) {
+	setKonfluxComponents(config)
+}
+
+func KonfluxComponentForTest(owner, repo, branch string) (KonfluxComponent, bool) {
+	return konfluxComponentFor(owner, repo, branch)
+}
+
+func GroupByApplicationTest(repositories []string) ([]string, bool) {
+	return groupByApplication(repositories)
+}
+
+// PrintApplicationHeadersTest prints the application headers the Konflux dashboard shows for repositories
+func PrintApplicationHeadersTest(repositories []string) {
+	var headers applicationHeaders
+	repositories, headers.enabled = groupByApplication(repositories)
+	for _, repoSpec := range repositories {
+		headers.print(repoSpec)
+		streams.Printf("%s\n", repoSpec)
+	}
+}
+
+func ValidateComponentRepositoryTest(repository string) bool {
+	return validateComponentRepository(repository)
+}
+
+func ComputeRepositoryStatsTest(client RESTClientInterface, owner, repo string, authors []string, since, now time.Time) (RepositoryStats, error) {
+	return computeRepositoryStats(client, owner, repo, authors, since, now)
+}
+
+func DisplayStatsTableTest(repositories []RepositoryStats, window string, since time.Time) {
+	displayStatsTable(repositories, window, since)
+}
+
+func IsSecurityPRTest(pr PullRequest) bool {
+	return isSecurityPR(pr)
+}
+
+func SecuritySeverityTest(pr PullRequest) string {
+	return securitySeverity(pr)
+}
+
+func SecurityAdvisoriesTest(pr PullRequest) []string {
+	return securityAdvisories(pr)
+}
+
+// SecurityQueueTest fetches and sorts the security queue of repositories served by client and returns its report
+func SecurityQueueTest(client RESTClientInterface, repositories []string, now time.Time) (*SecurityQueueReport, error) {
+	var queue []repoPR
+	for _, repoSpec := range repositories {
+		owner, repo, _ := parseRepoSpec(repoSpec)
+		prs, err := fetchSecurityPRs(client, owner, repo)
+		if err != nil {
+			return nil, err
+		}
+		for _, pr := range prs {
+			queue = append(queue, repoPR{Owner: owner, Repo: repo, Client: client, PR: pr})
+		}
+	}
+	sortSecurityQueue(queue)
+	return newSecurityQueueReport(queue, now), nil
+}
+
+// ApproveSecurityQueueTest approves the security queue of repositories served by client, returning its report
+// and the number of failed approvals
+func ApproveSecurityQueueTest(client RESTClientInterface, repositories []string, assumeYes bool) (*SecurityQueueReport, int) {
+	return ApproveSecurityQueueWithConfigTest(DefaultConfig(), client, repositories, assumeYes)
+}
+
+// ApproveSecurityQueueWithConfigTest is ApproveSecurityQueueTest with the approval settings of config
+func ApproveSecurityQueueWithConfigTest(config *Config, client RESTClientInterface, repositories []string, assumeYes bool) (*SecurityQueueReport, int) {
+	var queue []repoPR
+	for _, repoSpec := range repositories {
+		owner, repo, _ := parseRepoSpec(repoSpec)
+		prs, _ := fetchSecurityPRs(client, owner, repo)
+		for _, pr := range prs {
+			queue = append(queue, repoPR{Owner: owner, Repo: repo, Client: client, PR: pr})
+		}
+	}
+	sortSecurityQueue(queue)
+	report := newSecurityQueueReport(queue, time.Now())
+	failed := approveSecurityQueue(queue, report, config, assumeYes)
+	return report, failed
+}
+
+func DisplaySecurityQueueTest(report *SecurityQueueReport) {
+	displaySecurityQueue(report)
+}
+
+func WriteSecurityQueueReportTest(path string, report *SecurityQueueReport) error {
+	return writeSecurityQueueReport(path, report)
+}
+
+func ValidateRulesTest(rules []Rule) error {
+	return ghprs.ValidateRules(rules)
+}
+
+// ApplyRulesTest applies rules to the PRs of owner/repo served by client, returning the number of failed actions
+func ApplyRulesTest(client RESTClientInterface, owner, repo string, prs []PullRequest, rules []Rule) int {
+	return applyRules(context.Background(), client, owner, repo, prs, rules, approvalConfigTest(ApprovalConfig{TrustedAuthors: defaultTrustedAuthors}))
+}
+
+// RuleDecisionTest returns the action the rules decide for a PR and the name of the matching rule
+func RuleDecisionTest(client RESTClientInterface, owner, repo string, pr PullRequest, rules []Rule) (string, string) {
+	decision := ghprs.Decide(context.Background(), client, owner, repo, pr, rules, ghprs.DecideOptions{TektonPatterns: settingsOf(owner, repo).TektonPatterns})
+	if decision.Rule < 0 {
+		return decision.Action, ""
+	}
+	return decision.Action, rules[decision.Rule].Name
+}
+
+// RenderPRTableViewTest prints rows as the PR table of view, with the legend when withLegend is set
+func RenderPRTableViewTest(rows []PRRow, owner, repo string, isKonflux bool, view string, withLegend bool) {
+	opts := *listOptionsTest
+	opts.View = view
+	renderPRTable(&opts, rows, owner, repo, isKonflux, withLegend)
+}
+
+// ConversationEntryTest is an entry of the conversation on a PR
+type ConversationEntryTest struct {
+	Kind   string
+	Author string
+	Body   string
+	Detail string
+}
+
+// FetchConversationTest fetches the conversation on a PR, oldest first
+func FetchConversationTest(client RESTClientInterface, owner, repo string, prNumber int) ([]ConversationEntryTest, error) {
+	entries, err := fetchConversation(client, owner, repo, prNumber)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]ConversationEntryTest, len(entries))
+	for i, entry := range entries {
+		result[i] = ConversationEntryTest{Kind: entry.Kind, Author: entry.Author, Body: entry.Body, Detail: entry.Detail}
+	}
+	return result, nil
+}
+
+func ShowConversationTest(client RESTClientInterface, owner, repo string, prNumber int) error {
+	return showConversation(client, owner, repo, prNumber)
+}
+
+func ParseCursorColumnTest(report string) (int, error) {
+	return parseCursorColumn(report)
+}
+
+func SetEmojiModeTest(mode string) {
+	setEmojiMode(mode)
+}
+
+func FetchUpdatedPullRequestsRESTTest(client RESTClientInterface, owner, repo string, since time.Time, maxPRs int) ([]PullRequest, error) {
+	return fetchUpdatedPullRequestsREST(client, owner, repo, "open", "", since, maxPRs, nil)
+}
+
+func ParseSinceWindowTest(window string, now time.Time) (time.Time, error) {
+	return parseSinceWindow(window, now)
+}
+
+func DescribeActivityTest(events []TimelineEvent, runs []CheckRun, statuses []StatusCheck, since time.Time) []string {
+	return describeActivity(events, runs, statuses, since)
+}
+
+func ReportActivityTest(client RESTClientInterface, owner, repo string, prs []PullRequest, since time.Time) {
+	reportActivity(context.Background(), client, owner, repo, prs, since, defaultConcurrency)
+}
+
+func DisplayCommitListTest(commits []PRCommit) {
+	displayCommitList(commits)
+}
+
+// SetShowCommitsTest sets --show-commits, returning the previous value
+func SetShowCommitsTest(show bool) bool {
+	previous := listOptionsTest.ShowCommits
+	listOptionsTest.ShowCommits = show
+	return previous
+}
+
+// SplitDiffFilesTest splits a diff by file, returning the paths and the parts of the files
+func SplitDiffFilesTest(diff string) (paths, texts []string) {
+	for _, file := range splitDiffFiles(diff) {
+		paths = append(paths, file.Path)
+		texts = append(texts, file.Text)
+	}
+	return paths, texts
+}
+
+func MatchesDiffFileTest(file string, globs []string) bool {
+	return matchesDiffFile(file, globs)
+}
+
+func ValidateDiffFileGlobsTest(globs []string) error {
+	return validateDiffFileGlobs(globs)
+}
+
+// BrowseDiffFilesTest shows the files of a diff one at a time, as on a terminal
+func BrowseDiffFilesTest(diff string) {
+	browseDiffFiles(splitDiffFiles(diff), listOptionsTest.DiffMode)
+}
+
+func IsSensitiveFileTest(filename string) bool {
+	return isSensitiveFile(filename)
+}
+
+func UntrustedSensitiveFilesTest(pr PullRequest, files []PRFile, trusted []string) []string {
+	return untrustedSensitiveFiles(pr, files, trusted)
+}
+
+func ConfirmSensitiveChangesTest(client RESTClientInterface, owner, repo string, pr PullRequest, trusted []string) bool {
+	return confirmSensitiveChanges(client, owner, repo, pr, trusted)
+}
+
+func BatchApprovalBlockerTest(client RESTClientInterface, owner, repo string, pr PullRequest, trusted []string, maxChanges int) string {
+	return batchApprovalBlocker(repoPR{Owner: owner, Repo: repo, Client: client, PR: pr}, trusted, maxChanges)
+}
+
+func SetUISettingsTest(settings UIConfig) {
+	uiSettings = settings
+}
+
+func PromptMessageTest(prompt string) (string, error) {
+	return promptMessage(prompt)
+}
+
+func PagedTest(display func()) {
+	paged(display)
+}
+
+func DisplayDetailedCheckStatusTest(client RESTClientInterface, owner, repo string, prNumber int, headSHA string) int {
+	pr := PullRequest{Number: prNumber}
+	pr.Head.SHA = headSHA
+	return displayDetailedCheckStatus(client, owner, repo, pr, listOptionsTest.ExpandChecks)
+}
+
+// SetExpandChecksTest sets --expand-checks, returning the previous value
+func SetExpandChecksTest(expand bool) bool {
+	previous := listOptionsTest.ExpandChecks
+	listOptionsTest.ExpandChecks = expand
+	return previous
+}
+
+// FilterRowsTest returns the rows shown after toggling the filters of keys
+func FilterRowsTest(rows []PRRow, keys ...string) []PRRow {
+	var filters rowFilters
+	for _, key := range keys {
+		filters.toggle(key)
+	}
+	return filterRows(rows, filters)
+}
+
+func BrowseTableTest(rows []PRRow, owner, repo string, isKonflux bool) {
+	browseTable(listOptionsTest, rows, owner, repo, isKonflux)
+}
+
+// ServeHandlerTest returns the handler of 'ghprs serve' for config, using client for every repository
+func ServeHandlerTest(config *Config, token string, client RESTClientInterface) http.Handler {
+	opts := newListOptions()
+	applyConfigDefaults(opts, config)
+	server := &prServer{
+		config: config,
+		token:  token,
+		opts:   opts,
+		newClient: func(ctx context.Context, owner, repo string) (RESTClientInterface, error) {
+			return client, nil
+		},
+	}
+	return server.handler()
+}
+
+// TektonStructuralChangesTest returns the structural pipeline changes of files as "file (change)"
+func TektonStructuralChangesTest(files []PRFile, baseline []string) []string {
+	var changes []string
+	for _, change := range tektonStructuralChanges(files, baseline) {
+		changes = append(changes, fmt.Sprintf("%s (%s)", change.File, change.Change))
+	}
+	return changes
+}
+
+// FetchTektonFilesTest lists the .tekton files on a repository's default branch
+func FetchTektonFilesTest(client RESTClientInterface, owner, repo string) ([]string, error) {
+	return fetchTektonFiles(context.Background(), client, owner, repo)
+}
+
+// NewAlertsTest returns the PRs of current that newly need attention compared to previous
+func NewAlertsTest(owner, repo string, previous, current map[int]WatchSnapshot) []Alert {
+	return newAlerts(owner, repo, previous, current)
+}
+
+// SendAlertsTest posts alerts to the webhooks of config
+func SendAlertsTest(config NotificationsConfig, alerts []Alert) error {
+	return sendAlerts(context.Background(), newWebhookNotifier(config), alerts)
+}
+
+// NotifyStateTest saves state to path and loads it back
+func NotifyStateTest(path string, state map[string]map[int]WatchSnapshot) (map[string]map[int]WatchSnapshot, error) {
+	if err := saveNotifyState(path, state); err != nil {
+		return nil, err
+	}
+	return loadNotifyState(path)
+}
+
+// AdoptionGapsTest returns the entries of template no file matches
+func AdoptionGapsTest(template []string, component string, files []string) []string {
+	return adoptionGaps(template, component, files)
+}
+
+// AdoptRepositoryTest reports the gaps of a repository against the template of config
+func AdoptRepositoryTest(client RESTClientInterface, config *Config, owner, repo string, openIssue, assumeYes bool) (bool, error) {
+	return adoptRepository(context.Background(), client, config, owner, repo, openIssue, assumeYes)
+}
+
+// SnoozeTest snoozes a PR in the snooze state file at path
+func SnoozeTest(path, repoSpec string, number int, until, now time.Time) error {
+	state, err := loadSnoozes(path)
+	if err != nil {
+		return err
+	}
+	state.add(repoSpec, number, until, now)
+	return state.save()
+}
+
+// HideSnoozedTest hides the snoozed PRs of the state file at path, saving any snoozes that ended, and returns
+// the PRs shown and how many were hidden
+func HideSnoozedTest(path, repoSpec string, pullRequests []PullRequest, now time.Time) ([]PullRequest, int, error) {
+	state, err := loadSnoozes(path)
+	if err != nil {
+		return nil, 0, err
+	}
+	shown := state.hide(repoSpec, pullRequests, now)
+	return shown, state.hiddenCount(), state.save()
+}
+
+func ParseSnoozeUntilTest(value string, now time.Time) (time.Time, error) {
+	return parseSnoozeUntil(value, now)
+}
+
+// MarkSeenTest marks PRs as listed in the seen state file at path
+func MarkSeenTest(path, repoSpec string, pullRequests []PullRequest) error {
+	state, err := loadSeen(path)
+	if err != nil {
+		return err
+	}
+	state.markSeen(repoSpec, pullRequests)
+	return state.save()
+}
+
+// OnlyNewTest returns the PRs the seen state file at path doesn't have, or has with another update time
+func OnlyNewTest(path, repoSpec string, pullRequests []PullRequest) ([]PullRequest, error) {
+	state, err := loadSeen(path)
+	if err != nil {
+		return nil, err
+	}
+	return state.onlyNew(repoSpec, pullRequests), nil
+}
+
+// RecordArtifactTest runs fn while recording an artifact of what it shows into dir, as --artifact does
+func RecordArtifactTest(dir string, args []string, fn func()) {
+	save := startArtifact(dir, args)
+	fn()
+	save()
+}
+
+func OpenFailedCheckPageTest(client RESTClientInterface, owner, repo string, pr PullRequest) (bool, error) {
+	return openFailedCheckPage(client, owner, repo, pr)
+}
+
+func SetPriorityWeightsTest(config *Config) {
+	setPriorityWeights(config)
+}
+
+func ParsePriorityWeightTest(setting string) (string, float64, error) {
+	return parsePriorityWeight(setting)
+}
+
+// SortByPriorityTest sorts prs by priority as list and konflux do, returning the score of each PR by number
+func SortByPriorityTest(client RESTClientInterface, owner, repo string, prs []PullRequest, isKonflux bool) map[int]PriorityScore {
+	return sortPullRequestsWithContext(context.Background(), listOptionsTest, prs, client, owner, repo, isKonflux)
+}
+
+func ExplainPrioritySortTest(prs []PullRequest, scores map[int]PriorityScore, owner, repo string) {
+	explainPrioritySort(prs, scores, owner, repo)
+}
+
+func AssignPRTest(client RESTClientInterface, owner, repo string, prNumber int, reviewers, assignees []string) bool {
+	return assignPR(client, owner, repo, prNumber, reviewers, assignees)
+}
+
+// ResolveLoginsTest resolves @me in logins, forgetting the authenticated user looked up by earlier tests
+func ResolveLoginsTest(client RESTClientInterface, owner, repo string, logins []string) ([]string, error) {
+	viewerLoginsMutex.Lock()
+	viewerLogins = map[string]string{}
+	viewerLoginsMutex.Unlock()
+	return resolveLogins(client, owner, repo, logins)
+}
+
+// ResetBaseStatusesTest forgets the base branches checked by earlier tests
+func ResetBaseStatusesTest() {
+	resetBaseStatuses()
+}
+
+// BaseBranchFailuresTest returns the failed checks of the tip of a branch that make it red
+func BaseBranchFailuresTest(client RESTClientInterface, owner, repo, branch string) ([]string, error) {
+	status, err := fetchBaseStatus(client, owner, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	return status.Failed, nil
+}
+
+// ApplyRulesSkippingRedBaseTest applies rules as --auto --skip-red-base does
+func ApplyRulesSkippingRedBaseTest(client RESTClientInterface, owner, repo string, prs []PullRequest, rules []Rule) int {
+	config := approvalConfigTest(ApprovalConfig{TrustedAuthors: defaultTrustedAuthors})
+	config.SkipRedBase = true
+	return applyRules(context.Background(), client, owner, repo, prs, rules, config)
+}
+
+// BuildPRRowsWithBudgetTest builds the rows of a repository listed with others, with budget requests for their details
+func BuildPRRowsWithBudgetTest(pullRequests []PullRequest, owner, repo string, client RESTClientInterface, budget int) []PRRow {
+	previous := repoRequestBudget
+	defer func() { repoRequestBudget = previous }()
+	repoRequestBudget = budget
+	return buildPRRows(context.Background(), listOptionsTest, pullRequests, owner, repo, client, false, nil)
+}
+
+// RenderPRTableWithBudgetTest renders rows as a run with budget requests per repository does
+func RenderPRTableWithBudgetTest(rows []PRRow, owner, repo string, budget int) {
+	previous := repoRequestBudget
+	defer func() { repoRequestBudget = previous }()
+	repoRequestBudget = budget
+	renderPRTable(listOptionsTest, rows, owner, repo, false, false)
+}
+
+func RepoRequestBudgetForTest(config *Config, repositories int) int {
+	return repoRequestBudgetFor(config, repositories)
+}
+
+// ChangeLabelsTest adds and removes labels of a PR for testing
+func ChangeLabelsTest(client RESTClientInterface, owner, repo string, pr PullRequest, add, remove []string) bool {
+	return changeLabels(client, owner, repo, pr, add, remove)
+}
+
+// ApprovalSettingsForTest returns the settings of an approval variant chosen at the prompt
+func ApprovalSettingsForTest(settings ApprovalSettings, result ApprovalResult) ApprovalSettings {
+	return approvalSettingsFor(settings, result)
+}
+
+// RunSelftestTest runs the self-test against a sandbox repository at a fixed time, forgetting the authenticated
+// user looked up by earlier tests, and reports it, returning the steps and how many failed
+func RunSelftestTest(client RESTClientInterface, owner, repo string, prNumber int, settings ApprovalSettings, now time.Time) ([]SelftestStep, int) {
+	viewerLoginsMutex.Lock()
+	viewerLogins = map[string]string{}
+	viewerLoginsMutex.Unlock()
+	steps := runSelftest(client, owner, repo, prNumber, settings, now)
+	return steps, reportSelftest(steps)
+}
+
+// ParsePRSelectionTest parses what was entered at the PR selection prompt
+func ParsePRSelectionTest(input string, approvable, held []PullRequest) ([]PullRequest, error) {
+	return parsePRSelection(input, approvable, held)
+}
+
+// SearchQualifiersTest completes a search query with the qualifiers it leaves out
+func SearchQualifiersTest(query, state string) string {
+	return searchQualifiers(query, state)
+}
+
+// SearchPullRequestsTest runs a search and groups the PRs found by repository
+func SearchPullRequestsTest(client RESTClientInterface, query string, maxPRs int) ([]string, map[string][]int, error) {
+	return searchPullRequests(client, query, maxPRs)
+}
+
+// FetchSearchedPRsTest fetches the PRs a search found in a repository
+func FetchSearchedPRsTest(client RESTClientInterface, owner, repo string, numbers []int, authors []string) ([]PullRequest, error) {
+	return fetchSearchedPRs(context.Background(), listOptionsTest, client, owner, repo, numbers, authors, false)
+}
+
+// DiscoverOrgRepositoriesTest lists the repositories of an organization for the konflux --org dashboard
+func DiscoverOrgRepositoriesTest(client RESTClientInterface, org string, topics []string) ([]string, error) {
+	return discoverOrgRepositories(client, org, topics)
+}
+
+// DisplayOrgSummaryTest shows the per-repository counts and oldest pending PR of the konflux --org dashboard for
+// rows paired with their PRs
+func DisplayOrgSummaryTest(org string, repositories []string, prs []PullRequest, rows []PRRow, now time.Time) {
+	combined := make([]combinedRow, len(rows))
+	for i := range rows {
+		combined[i] = combinedRow{PR: prs[i], Row: rows[i]}
+	}
+	displayOrgSummary(org, combined, repositories, now)
+}
+
+// EnableAuditLogTest records the actions taken on PRs in the audit log at path, forgetting the authenticated user
+// looked up by earlier tests
+func EnableAuditLogTest(path string) {
+	viewerLoginsMutex.Lock()
+	viewerLogins = map[string]string{}
+	viewerLoginsMutex.Unlock()
+	auditLog = &auditLogger{path: path}
+}
+
+// DisableAuditLogTest stops recording actions in the audit log
+func DisableAuditLogTest() {
+	auditLog = nil
+}
+
+// ReadAuditLogTest reads the entries of the audit log of a repository, or of every repository, taken since a time
+func ReadAuditLogTest(path, repoSpec string, since time.Time) ([]AuditEntry, error) {
+	return readAuditLog(path, repoSpec, since)
+}
+
+// DisplayHistoryTest shows the entries of the audit log as a table
+func DisplayHistoryTest(entries []AuditEntry) {
+	displayHistory(entries)
+}
+
+// WriteHistoryCSVTest writes the entries of the audit log as CSV
+func WriteHistoryCSVTest(w io.Writer, entries []AuditEntry) error {
+	return writeHistoryCSV(w, entries)
+}
+
+// ExpandCommentTemplateTest fills in the variables of a comment template for a PR
+func ExpandCommentTemplateTest(template string, pr PullRequest, owner, repo string) string {
+	return expandCommentTemplate(template, pr, owner, repo)
+}
+
+// ParseCommentTemplateTest parses the name=text of 'config set template'
+func ParseCommentTemplateTest(value string) (string, string, error) {
+	return parseCommentTemplate(value)
+}
+
+// PromptForCommentTest asks for a comment, offering the comment templates
+func PromptForCommentTest(templates map[string]string, pr PullRequest, owner, repo string) (string, error) {
+	return promptForComment(templates, pr, owner, repo)
+}
+
+// SetProfileTest selects a config profile as --profile does, "" for none
+func SetProfileTest(name string) {
+	profileFlag = name
+}
+
+// ListProfilesTest returns the names of the config profiles
+func ListProfilesTest() ([]string, error) {
+	return listProfiles()
+}
+
+// StateDirTest returns the directory the local state files are kept in
+func StateDirTest() string {
+	return stateDir()
+}
+
+// ApplyEnvOverridesTest sets the flags of a command that weren't given from the environment of lookupEnv
+func ApplyEnvOverridesTest(cmd *cobra.Command, lookupEnv func(string) (string, bool)) error {
+	return applyEnvOverrides(cmd, lookupEnv)
+}
+
+// ExecuteTest runs ghprs with args as its command line, its help and usage written to out, then forgets the
+// flags and the config the run was given. The log level and UI settings it set up are left for the caller to
+// look at and reset.
+func ExecuteTest(out io.Writer, args ...string) error {
+	RootCmd.SetArgs(args)
+	RootCmd.SetOut(out)
+	defer func() {
+		RootCmd.SetArgs(nil)
+		RootCmd.SetOut(nil)
+		RootCmd.PersistentFlags().VisitAll(func(flag *pflag.Flag) { flag.Changed = false })
+		configPath = ""
+	}()
+	return RootCmd.Execute()
+}
+
+// DebugLoggingTest reports whether debug messages are logged
+func DebugLoggingTest() bool {
+	return logger.Enabled(context.Background(), slog.LevelDebug)
+}
+
+// UISettingsTest returns the pager, editor and browser settings in use
+func UISettingsTest() UIConfig {
+	return uiSettings
+}
+
+// ValidateConfigDataTest checks a config file, returning its issues as "line N: message"
+func ValidateConfigDataTest(data string) []string {
+	var issues []string
+	for _, issue := range validateConfigData([]byte(data)) {
+		issues = append(issues, issue.String())
+	}
+	return issues
+}
+
+// EditConfigFileTest edits the config file at path in the configured editor
+func EditConfigFileTest(path string) (bool, error) {
+	return editConfigFile(path)
+}
+
+// WriteRepositoryListTest writes the repositories as 'config list-repos' does
+func WriteRepositoryListTest(w io.Writer, repositories []RepositoryConfig, konfluxOnly bool, format string) error {
+	return writeRepositoryList(w, repositories, konfluxOnly, format)
+}
+
+// RepositoryListSettingsTest returns the state, limit and sort order a repository is listed with
+func RepositoryListSettingsTest(owner, repo string) (string, int, string) {
+	return stateFor(listOptionsTest, owner, repo), limitFor(listOptionsTest, owner, repo), sortFor(listOptionsTest, owner, repo)
+}
+
+// SetCompletionFetchTest replaces how completion fetches the open PRs of a repository, returning a function
+// restoring it
+func SetCompletionFetchTest(fetch func(ctx context.Context, owner, repo string) ([]PullRequest, error)) func() {
+	saved := completionFetch
+	completionFetch = fetch
+	return func() { completionFetch = saved }
+}
+
+// CompleteRepositoryArgsTest returns the completions of the commands that take repositories for args
+func CompleteRepositoryArgsTest(args []string, multiple bool) []string {
+	completions, _ := completeRepositoryArgs(multiple)(nil, args, "")
+	return completions
+}
+
+// AgePRIndexTest makes the PRs indexed for repoSpec look as old as age
+func AgePRIndexTest(repoSpec string, age time.Duration) {
+	index := loadPRIndex()
+	if repoIndex, ok := index[repoSpec]; ok {
+		repoIndex.UpdatedAt = time.Now().Add(-age)
+		_ = index.save()
+	}
+}
+
+// SetAccountTest sets --account and forgets the tokens found for accounts
+func SetAccountTest(account string) {
+	accountFlag = account
+	accountTokensMutex.Lock()
+	accountTokens = map[string]accountToken{}
+	accountTokensMutex.Unlock()
+}
+
+// SetGhAccountTokenTest replaces how the tokens of gh's accounts are found, returning a function restoring it
+func SetGhAccountTokenTest(lookup func(host, account string) (string, error)) func() {
+	saved := ghAccountToken
+	ghAccountToken = lookup
+	return func() { ghAccountToken = saved }
+}
+
+// SetAuthWhoamiTest replaces how 'auth status' looks up the user of a token, returning a function restoring it
+func SetAuthWhoamiTest(whoami func(host, token string) (string, error)) func() {
+	saved := authWhoami
+	authWhoami = whoami
+	return func() { authWhoami = saved }
+}
+
+// TokenForHostTest returns the token used on host and where it came from
+func TokenForHostTest(host string) (string, string, error) {
+	return tokenForHost(host)
+}
+
+// DisplayAuthStatusTest shows the identity used on each host
+func DisplayAuthStatusTest(hosts []string) bool {
+	return displayAuthStatus(hosts)
+}
+
+// NewAPIClientTest creates the API client of host, without a rate limiter or cache
+func NewAPIClientTest(host string) (RESTClientInterface, error) {
+	return newAPIClient(host, nil, nil)
+}
+
+// SaveSnapshotTest writes a snapshot to dir and returns its path
+func SaveSnapshotTest(dir string, snapshot Snapshot) (string, error) {
+	return saveSnapshot(dir, snapshot)
+}
+
+// LatestSnapshotTest loads the most recent snapshot of dir
+func LatestSnapshotTest(dir string) (Snapshot, string, error) {
+	return latestSnapshot(dir)
+}
+
+// DisplaySnapshotTest shows the latest snapshot of the state directory in the given --output format
+func DisplaySnapshotTest(args []string, isKonflux bool, format string) error {
+	savedFormat := outputFormat
+	outputFormat = format
+	defer func() { outputFormat = savedFormat }()
+	return displaySnapshot(listOptionsTest, args, isKonflux)
+}
+
+// DiffSnapshotsTest lists what changed between two snapshots, looking up the PRs no longer listed with lookup
+func DiffSnapshotsTest(older, newer Snapshot, lookup func(ctx context.Context, owner, repo string, number int) (*PullRequest, error)) SnapshotDiff {
+	return diffSnapshots(context.Background(), older, newer, lookup)
+}
+
+// WriteSnapshotDiffTest writes the changes between two snapshots in format
+func WriteSnapshotDiffTest(w io.Writer, diff SnapshotDiff, format string) error {
+	return writeSnapshotDiff(w, diff, format)
+}
+
+// SnapshotPathsTest picks the snapshots of dir diff-snapshots compares for args
+func SnapshotPathsTest(dir string, args []string) (string, string, error) {
+	return snapshotPaths(dir, args)
+}
+
+// SetAgeFiltersTest sets --older-than and --updated-within, with their windows starting from now
+func SetAgeFiltersTest(older, updated string, now time.Time) error {
+	listOptionsTest.OlderThan, listOptionsTest.UpdatedWithin = older, updated
+	return listOptionsTest.parseAgeFilters(now)
+}
+
+// SetStalePRAfterTest sets how long a PR may go without an update before it is marked stale
+func SetStalePRAfterTest(after time.Duration) {
+	stalePRAfter = after
+}
+
+// HumanAgeTest renders an age in its largest whole unit
+func HumanAgeTest(age time.Duration) string {
+	return humanAge(age)
+}
+
+// PRSizeTest returns the size bucket of a PR changing lines lines
+func PRSizeTest(lines int) string {
+	return prSize(lines)
+}
+
+// ConfirmLargeChangesTest asks for the PR number before approving a bot PR changing more than maxChanges lines
+func ConfirmLargeChangesTest(client RESTClientInterface, owner, repo string, pr PullRequest, maxChanges int, trusted []string) bool {
+	return confirmLargeChanges(client, owner, repo, pr, maxChanges, trusted)
+}
+
+// DisplayDetailedPRCheckStatusTest shows the detailed check status of a PR, with the checks its base requires
+func DisplayDetailedPRCheckStatusTest(client RESTClientInterface, owner, repo string, pr PullRequest) int {
+	return displayDetailedCheckStatus(client, owner, repo, pr, listOptionsTest.ExpandChecks)
+}
+
+// ExplainBlockedTest describes why a blocked PR can't be merged
+func ExplainBlockedTest(client RESTClientInterface, owner, repo string, pr PullRequest) string {
+	return explainBlocked(client, owner, repo, pr)
+}
+
+// CodeOwnersTest returns the owners the CODEOWNERS content gives files
+func CodeOwnersTest(content string, files []string) []string {
+	return codeOwners(parseCodeowners(content), files)
+}
+
+// ResetCodeownersTest forgets the CODEOWNERS, owners, user and teams looked up by earlier tests
+func ResetCodeownersTest() {
+	resetCodeowners()
+	viewerLoginsMutex.Lock()
+	viewerLogins = map[string]string{}
+	viewerLoginsMutex.Unlock()
+}
+
+// PromptForAssignTest asks whom to request a review from and whom to assign a PR to
+func PromptForAssignTest(client RESTClientInterface, owner, repo string, pr PullRequest) error {
+	return promptForAssign(context.Background(), client, owner, repo, pr)
+}
+
+// CollectMigrationPRsTest keeps the PRs of a repository with a migration warning, with their notes
+func CollectMigrationPRsTest(owner, repo string, prs []PullRequest) []MigrationPR {
+	repoPRs := make([]repoPR, len(prs))
+	for i, pr := range prs {
+		repoPRs[i] = repoPR{Owner: owner, Repo: repo, PR: pr}
+	}
+	return collectMigrationPRs([][]repoPR{repoPRs})
+}
+
+// DisplayMigrationPRsTest lists migration PRs with their notes boxed
+func DisplayMigrationPRsTest(migrations []MigrationPR) {
+	displayMigrationPRs(migrations)
+}
+
+// WriteMigrationNotesTest boxes the migration notes of a PR, cut to maxLines lines
+func WriteMigrationNotesTest(w io.Writer, pr PullRequest, maxLines int) {
+	writeMigrationNotes(w, pr, maxLines)
+}
+
+// ParseUpdateTypeFilterTest checks --update-type values
+func ParseUpdateTypeFilterTest(values []string) ([]string, error) {
+	return parseUpdateTypeFilter(values)
+}
+
+// GroupDependencyUpdatesTest returns each update open in several repositories with its PRs as "owner/repo#number"
+func GroupDependencyUpdatesTest(prs []CanaryPRTest) map[string][]string {
+	groups := make(map[string][]string)
+	for _, group := range groupDependencyUpdates(canaryRepoPRs(prs)) {
+		for _, member := range group.PRs {
+			groups[group.describe()] = append(groups[group.describe()], fmt.Sprintf("%s/%s#%d", member.Owner, member.Repo, member.PR.Number))
+		}
+	}
+	return groups
+}
+
+// ReviewUpdateGroupTest shows the only update open in several repositories and approves it where confirmed
+func ReviewUpdateGroupTest(prs []CanaryPRTest) bool {
+	groups := groupDependencyUpdates(canaryRepoPRs(prs))
+	if len(groups) != 1 {
+		return false
+	}
+	reviewUpdateGroup(context.Background(), listOptionsTest, groups[0], DefaultConfig())
+	return true
+}
+
+func WithRetriesTest(client RESTClientInterface, retries int, sleep func(d time.Duration)) RESTClientInterface {
+	wrapped := withRetries(client, retries)
+	if c, ok := wrapped.(*retryRESTClient); ok {
+		c.sleep = func(_ context.Context, d time.Duration) error {
+			sleep(d)
+			return nil
+		}
+	}
+	return wrapped
+}
+
+func IsTransientErrorTest(err error) bool {
+	return isTransientError(err)
+}
+
+func RetryBackoffTest(attempt int) time.Duration {
+	return retryBackoff(attempt)
+}
+
+func CollectPRDetailTest(client RESTClientInterface, owner, repo string, pr PullRequest, config *Config) PRDetail {
+	return collectPRDetail(context.Background(), client, owner, repo, pr, config)
+}
+
+func DisplayPRDetailTest(detail PRDetail, owner, repo string) {
+	displayPRDetail(detail, owner, repo)
+}
+
+func ParseFailOnTest(values []string) ([]string, error) {
+	conditions, err := parseFailOn(values)
+	var names []string
+	for _, condition := range conditions {
+		names = append(names, condition.Name)
+	}
+	return names, err
+}
+
+// CheckFailOnTest checks the --fail-on values against the PRs of a repository and returns the exit status
+// the run would end with
+func CheckFailOnTest(values []string, client RESTClientInterface, owner, repo string, pullRequests []PullRequest, unchecked ...string) (int, error) {
+	conditions, err := parseFailOn(values)
+	if err != nil {
+		return 0, err
+	}
+	failOn.reset(conditions)
+	defer failOn.reset(nil)
+	for _, repoSpec := range unchecked {
+		failOn.skip(repoSpec)
+	}
+	failOn.check(context.Background(), client, owner, repo, pullRequests, NewPRDetailsCache(), defaultConcurrency)
+	return failOn.report(), nil
+}
@@ -0,0 +1,102 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+)
+
+// rowFilters are the filters toggled after the table is shown; a row is shown when it passes every filter that is on
+type rowFilters struct {
+	TektonOnly    bool
+	MigrationOnly bool
+	NeedsRebase   bool
+	GreenChecks   bool
+}
+
+// toggle switches the filter of a key (t, m, r or g) and reports whether the key has a filter
+func (f *rowFilters) toggle(key string) bool {
+	switch key {
+	case "t":
+		f.TektonOnly = !f.TektonOnly
+	case "m":
+		f.MigrationOnly = !f.MigrationOnly
+	case "r":
+		f.NeedsRebase = !f.NeedsRebase
+	case "g":
+		f.GreenChecks = !f.GreenChecks
+	default:
+		return false
+	}
+	return true
+}
+
+// match reports whether a row passes the filters that are on. Signals that weren't fetched, such as the
+// rebase state in fast mode, don't match.
+func (f rowFilters) match(row PRRow) bool {
+	switch {
+	case f.TektonOnly && (row.TektonOnly == nil || !*row.TektonOnly):
+		return false
+	case f.MigrationOnly && !row.Migration:
+		return false
+	case f.NeedsRebase && (row.NeedsRebase == nil || !*row.NeedsRebase):
+		return false
+	case f.GreenChecks && row.Checks != checksPassing:
+		return false
+	}
+	return true
+}
+
+// String lists the filters that are on, "none" when every row is shown
+func (f rowFilters) String() string {
+	var active []string
+	for _, filter := range []struct {
+		on   bool
+		name string
+	}{{f.TektonOnly, "tekton-only"}, {f.MigrationOnly, "migration-only"}, {f.NeedsRebase, "needs-rebase"}, {f.GreenChecks, "green-checks"}} {
+		if filter.on {
+			active = append(active, filter.name)
+		}
+	}
+	if len(active) == 0 {
+		return "none"
+	}
+	return strings.Join(active, ", ")
+}
+
+// filterRows returns the rows that pass the filters
+func filterRows(rows []PRRow, filters rowFilters) []PRRow {
+	var filtered []PRRow
+	for _, row := range rows {
+		if filters.match(row) {
+			filtered = append(filtered, row)
+		}
+	}
+	return filtered
+}
+
+// browseTable asks for filters to toggle after the table of a repository is shown and shows the table again
+// with the PRs that pass them, from the rows already built so no API call is made. Enter moves on.
+func browseTable(opts *listOptions, rows []PRRow, owner, repo string, isKonflux bool) {
+	var filters rowFilters
+	for {
+		answer, err := prompter.Input(fmt.Sprintf("\nToggle a filter: [t]ekton-only, [m]igration-only, [r] needs rebase, [g]reen checks, Enter to continue (active: %s): ", filters))
+		if err != nil || answer == "" || answer == "q" {
+			return
+		}
+		if !filters.toggle(strings.ToLower(answer)) {
+			streams.Printf("Unknown filter %q, use t, m, r or g.\n", answer)
+			continue
+		}
+
+		filtered := filterRows(rows, filters)
+		if len(filtered) == 0 {
+			streams.Printf("\nNo PRs of %s/%s match the active filters (%s)\n", owner, repo, filters)
+			if opts.Fast {
+				streams.Printf("--fast skips the Tekton, rebase and check status, so PRs can't match those filters\n")
+			}
+			continue
+		}
+		renderPRTable(opts, filtered, owner, repo, isKonflux, false)
+		streams.Printf("Showing %d of %d PR(s), filters: %s\n", len(filtered), len(rows), filters)
+	}
+}
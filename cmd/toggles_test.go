@@ -0,0 +1,75 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Interactive filters", func() {
+	yes, no := true, false
+	rows := []cmd.PRRow{
+		{Number: 1, Title: "Update tasks", TektonOnly: &yes, NeedsRebase: &no, Checks: "passing"},
+		{Number: 2, Title: "Migrate pipeline", TektonOnly: &yes, Migration: true, NeedsRebase: &yes, Checks: "failing"},
+		{Number: 3, Title: "Bump go", TektonOnly: &no, NeedsRebase: &yes, Checks: "passing"},
+		{Number: 4, Title: "Unknown state"},
+	}
+	numbers := func(rows []cmd.PRRow) []int {
+		var numbers []int
+		for _, row := range rows {
+			numbers = append(numbers, row.Number)
+		}
+		return numbers
+	}
+
+	It("should show every row without filters", func() {
+		Expect(numbers(cmd.FilterRowsTest(rows))).To(Equal([]int{1, 2, 3, 4}))
+	})
+
+	It("should show the rows passing every filter that is on", func() {
+		Expect(numbers(cmd.FilterRowsTest(rows, "t"))).To(Equal([]int{1, 2}))
+		Expect(numbers(cmd.FilterRowsTest(rows, "m"))).To(Equal([]int{2}))
+		Expect(numbers(cmd.FilterRowsTest(rows, "r"))).To(Equal([]int{2, 3}))
+		Expect(numbers(cmd.FilterRowsTest(rows, "g"))).To(Equal([]int{1, 3}))
+		Expect(numbers(cmd.FilterRowsTest(rows, "r", "g"))).To(Equal([]int{3}))
+	})
+
+	It("should turn a filter off when it is toggled again", func() {
+		Expect(numbers(cmd.FilterRowsTest(rows, "t", "g", "t"))).To(Equal([]int{1, 3}))
+	})
+
+	Describe("browsing the table", func() {
+		var out *bytes.Buffer
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		browse := func(input string) string {
+			out = &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(input), out, &bytes.Buffer{}), nil)
+			cmd.BrowseTableTest(rows, "owner", "repo", true)
+			return out.String()
+		}
+
+		It("should show the table again with the active filters", func() {
+			output := browse("g\nr\n\n")
+			Expect(output).To(ContainSubstring("Showing 2 of 4 PR(s), filters: green-checks"))
+			Expect(output).To(ContainSubstring("Showing 1 of 4 PR(s), filters: needs-rebase, green-checks"))
+			Expect(output).To(ContainSubstring("(active: needs-rebase, green-checks)"))
+		})
+
+		It("should say when no PR matches", func() {
+			output := browse("m\ng\nq\n")
+			Expect(output).To(ContainSubstring("No PRs of owner/repo match the active filters (migration-only, green-checks)"))
+		})
+
+		It("should reject unknown keys", func() {
+			Expect(browse("x\n")).To(ContainSubstring(`Unknown filter "x"`))
+		})
+	})
+})
@@ -0,0 +1,150 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sync"
+
+	"github.com/cli/go-gh/v2/pkg/api"
+)
+
+// tokenEnvVar names the environment variable holding a token to use instead of gh's stored auth
+const tokenEnvVar = "GHPRS_TOKEN"
+
+// tokenFlag is a token to use for this run instead of gh's stored auth, e.g. a bot account's
+var tokenFlag string
+
+// tokenOverride returns the token given with --token or GHPRS_TOKEN, and where it came from.
+// An empty token means gh's stored auth is used.
+func tokenOverride() (string, string) {
+	if tokenFlag != "" {
+		return tokenFlag, "--token"
+	}
+	if token := os.Getenv(tokenEnvVar); token != "" {
+		return token, tokenEnvVar
+	}
+	return "", ""
+}
+
+// clientOptions returns the options of the API clients for host, authenticating with the token override or the
+// token of the account chosen for host, if any, and revalidating the fresh reads of PRs
+func clientOptions(host string) (api.ClientOptions, error) {
+	token, _, err := hostToken(host)
+	if err != nil {
+		return api.ClientOptions{}, err
+	}
+	return api.ClientOptions{Host: host, AuthToken: token, Transport: &revalidatingTransport{base: http.DefaultTransport}}, nil
+}
+
+// identityRESTClient notes which identity performs every change made with an overriding token or a chosen account
+type identityRESTClient struct {
+	RESTClientInterface
+	host   string
+	source string
+	// identity says where the acting identity came from: the token override or the account chosen for host
+	identity string
+}
+
+var (
+	actingLoginsMutex sync.Mutex
+	// actingLogins maps a GitHub host to the announced user acting there with the token override
+	actingLogins = map[string]string{}
+)
+
+// withIdentityNotice wraps the client of host so the user acting with the token override, or the account chosen
+// for host, is announced before the first change and logged with every change. Without either the client is
+// returned as is.
+func withIdentityNotice(client RESTClientInterface, host string) RESTClientInterface {
+	_, source, _ := hostToken(host)
+	if source == "" {
+		return client
+	}
+	identity := "the token override user"
+	if _, override := tokenOverride(); override == "" {
+		_, chosenBy := accountFor(host)
+		identity = "the account from " + chosenBy
+	}
+	return &identityRESTClient{RESTClientInterface: client, host: host, source: source, identity: identity}
+}
+
+// Request performs a request, noting the acting identity when it changes anything
+func (c *identityRESTClient) Request(method string, path string, body io.Reader) (*http.Response, error) {
+	return c.RequestWithContext(context.Background(), method, path, body)
+}
+
+// RequestWithContext performs a request, noting the acting identity when it changes anything
+func (c *identityRESTClient) RequestWithContext(ctx context.Context, method string, path string, body io.Reader) (*http.Response, error) {
+	c.noteChange(ctx, method, path)
+	return c.RESTClientInterface.RequestWithContext(ctx, method, path, body)
+}
+
+// Do performs a request, noting the acting identity when it changes anything
+func (c *identityRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext performs a request, noting the acting identity when it changes anything
+func (c *identityRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	c.noteChange(ctx, method, path)
+	return c.RESTClientInterface.DoWithContext(ctx, method, path, body, response)
+}
+
+// Post performs a POST request, noting the acting identity
+func (c *identityRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request, noting the acting identity
+func (c *identityRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request, noting the acting identity
+func (c *identityRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request, noting the acting identity
+func (c *identityRESTClient) Delete(path string, response interface{}) error {
+	return c.DoWithContext(context.Background(), http.MethodDelete, path, nil, response)
+}
+
+// noteChange announces the acting identity before the first change and logs every change with it
+func (c *identityRESTClient) noteChange(ctx context.Context, method, path string) {
+	if method == http.MethodGet || method == http.MethodHead {
+		return
+	}
+	login := c.actingLogin(ctx)
+	logger.Info("Changing as "+c.identity, "user", login, "token_source", c.source, "method", method, "path", path)
+}
+
+func init() {
+	RootCmd.PersistentFlags().StringVar(&tokenFlag, "token", "", "GitHub token to use for this run instead of gh's stored auth, e.g. a bot account's (or set "+tokenEnvVar+")")
+}
+
+// announceTokenOverride logs that a token override is used instead of gh's stored auth
+func announceTokenOverride() {
+	if _, source := tokenOverride(); source != "" {
+		logger.Info("Using a token override instead of gh's stored auth", "token_source", source)
+	}
+}
+
+// actingLogin returns the user acting on the client's host, looking it up and announcing it the first time
+func (c *identityRESTClient) actingLogin(ctx context.Context) string {
+	actingLoginsMutex.Lock()
+	defer actingLoginsMutex.Unlock()
+	if login, ok := actingLogins[c.host]; ok {
+		return login
+	}
+	login := "an unknown user"
+	var user User
+	if err := c.RESTClientInterface.DoWithContext(ctx, http.MethodGet, "user", nil, &user); err == nil && user.Login != "" {
+		login = "@" + user.Login
+	}
+	actingLogins[c.host] = login
+	_, _ = fmt.Fprintf(streams.ErrOut, "🔑 Acting as %s on %s with the token from %s\n", login, c.host, c.source)
+	return login
+}
@@ -0,0 +1,136 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Token override", func() {
+	var errOut *bytes.Buffer
+
+	BeforeEach(func() {
+		errOut = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), &bytes.Buffer{}, errOut), nil)
+		cmd.ResetActingLoginsTest()
+		previous, set := os.LookupEnv("GHPRS_TOKEN")
+		Expect(os.Unsetenv("GHPRS_TOKEN")).To(Succeed())
+		DeferCleanup(func() {
+			if set {
+				_ = os.Setenv("GHPRS_TOKEN", previous)
+			} else {
+				_ = os.Unsetenv("GHPRS_TOKEN")
+			}
+		})
+	})
+
+	AfterEach(func() {
+		cmd.SetTokenFlagTest("")
+		cmd.ResetIOStreams()
+	})
+
+	Describe("choosing the token", func() {
+		It("should use gh's stored auth without an override", func() {
+			token, source := cmd.TokenOverrideTest()
+			Expect(token).To(BeEmpty())
+			Expect(source).To(BeEmpty())
+			Expect(cmd.ClientOptionsTokenTest("github.com")).To(BeEmpty())
+		})
+
+		It("should use GHPRS_TOKEN", func() {
+			Expect(os.Setenv("GHPRS_TOKEN", "env-token")).To(Succeed())
+
+			token, source := cmd.TokenOverrideTest()
+			Expect(token).To(Equal("env-token"))
+			Expect(source).To(Equal("GHPRS_TOKEN"))
+			Expect(cmd.ClientOptionsTokenTest("github.com")).To(Equal("env-token"))
+		})
+
+		It("should prefer --token over GHPRS_TOKEN", func() {
+			Expect(os.Setenv("GHPRS_TOKEN", "env-token")).To(Succeed())
+			cmd.SetTokenFlagTest("flag-token")
+
+			token, source := cmd.TokenOverrideTest()
+			Expect(token).To(Equal("flag-token"))
+			Expect(source).To(Equal("--token"))
+		})
+	})
+
+	Describe("noting the acting identity", func() {
+		var mockClient *cmd.MockRESTClient
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			mockClient.AddResponse("user", 200, cmd.User{Login: "ci-bot"})
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1})
+			mockClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]any{})
+		})
+
+		It("should leave the client alone without an override", func() {
+			client := cmd.WithIdentityNoticeTest(mockClient, "github.com")
+			Expect(client).To(BeIdenticalTo(mockClient))
+		})
+
+		It("should announce the acting user once, before the first change", func() {
+			cmd.SetTokenFlagTest("flag-token")
+			client := cmd.WithIdentityNoticeTest(mockClient, "github.com")
+
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+			Expect(errOut.String()).To(BeEmpty())
+
+			for range 2 {
+				Expect(client.Post("repos/owner/repo/issues/1/comments", strings.NewReader(`{"body":"/lgtm"}`), nil)).To(Succeed())
+			}
+			Expect(errOut.String()).To(ContainSubstring("🔑 Acting as @ci-bot on github.com with the token from --token\n"))
+			Expect(strings.Count(errOut.String(), "Acting as")).To(Equal(1))
+
+			lookups := 0
+			for _, request := range mockClient.Requests {
+				if request.URL == "user" {
+					lookups++
+				}
+			}
+			Expect(lookups).To(Equal(1))
+		})
+
+		It("should still make the change when the acting user can't be looked up", func() {
+			Expect(os.Setenv("GHPRS_TOKEN", "env-token")).To(Succeed())
+			failingClient := cmd.NewMockRESTClient()
+			failingClient.AddResponse("repos/owner/repo/issues/1/comments", 201, map[string]any{})
+			client := cmd.WithIdentityNoticeTest(failingClient, "github.com")
+
+			Expect(client.Post("repos/owner/repo/issues/1/comments", strings.NewReader(`{}`), nil)).To(Succeed())
+			Expect(errOut.String()).To(ContainSubstring("Acting as an unknown user on github.com with the token from GHPRS_TOKEN"))
+		})
+
+		It("should word the change log by where the acting identity came from", func() {
+			cmd.SetLogFlagsTest(true, false)
+			defer cmd.SetLogFlagsTest(false, false)
+			cmd.SetTokenFlagTest("flag-token")
+			client := cmd.WithIdentityNoticeTest(mockClient, "github.com")
+			Expect(client.Post("repos/owner/repo/issues/1/comments", strings.NewReader(`{}`), nil)).To(Succeed())
+			Expect(errOut.String()).To(ContainSubstring("Changing as the token override user"))
+
+			cmd.SetTokenFlagTest("")
+			cmd.ResetActingLoginsTest()
+			restoreLookup := cmd.SetGhAccountTokenTest(func(host, account string) (string, error) {
+				return "work-token", nil
+			})
+			defer restoreLookup()
+			cmd.SetAccountTest("work-user")
+			defer cmd.SetAccountTest("")
+			errOut.Reset()
+
+			client = cmd.WithIdentityNoticeTest(mockClient, "github.com")
+			Expect(client.Post("repos/owner/repo/issues/1/comments", strings.NewReader(`{}`), nil)).To(Succeed())
+			Expect(errOut.String()).To(ContainSubstring("Changing as the account from --account"))
+			Expect(errOut.String()).NotTo(ContainSubstring("token override"))
+		})
+	})
+})
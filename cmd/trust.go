@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+
+	"ghprs/pkg/ghprs"
+)
+
+// defaultTrustedAuthors are the dependency bots whose PRs routinely bump workflow actions and Tekton tasks
+var defaultTrustedAuthors = []string{konfluxBotAuthor, dependabotAuthor, "renovate[bot]"}
+
+// TrustConfig says whose changes to CI and ownership files are routine
+type TrustConfig struct {
+	// Authors are the logins trusted to change CI workflows, Tekton pipelines and OWNERS files; unset trusts
+	// the Konflux, Dependabot and Renovate bots
+	Authors []string `yaml:"authors,omitempty"`
+}
+
+// TrustedAuthors returns the logins trusted to change CI and ownership files
+func (c *Config) TrustedAuthors() []string {
+	if len(c.Trust.Authors) == 0 {
+		return defaultTrustedAuthors
+	}
+	return c.Trust.Authors
+}
+
+// isSensitiveFile reports whether a file runs in or controls CI, or decides who may approve changes:
+// GitHub workflows, Tekton pipelines and OWNERS files. A PR changing them can take over CI.
+func isSensitiveFile(filename string) bool {
+	if strings.HasPrefix(filename, ".github/workflows/") || strings.HasPrefix(filename, ".tekton/") {
+		return true
+	}
+	name := path.Base(filename)
+	return name == "OWNERS" || name == "OWNERS_ALIASES"
+}
+
+// isTrustedAuthor reports whether login is one of trusted, ignoring case
+func isTrustedAuthor(login string, trusted []string) bool {
+	return slices.ContainsFunc(trusted, func(author string) bool { return strings.EqualFold(author, login) })
+}
+
+// untrustedSensitiveFiles returns the sensitive files of files when the PR's author isn't trusted, nothing
+// for trusted authors
+func untrustedSensitiveFiles(pr PullRequest, files []PRFile, trusted []string) []string {
+	if isTrustedAuthor(pr.User.Login, trusted) {
+		return nil
+	}
+	var sensitive []string
+	for _, file := range files {
+		if isSensitiveFile(file.Filename) {
+			sensitive = append(sensitive, file.Filename)
+		}
+	}
+	return sensitive
+}
+
+// fetchUntrustedSensitiveFiles fetches every file a PR changes and returns the sensitive ones when its author
+// isn't trusted. Trusted authors are let through without fetching anything.
+func fetchUntrustedSensitiveFiles(client RESTClientInterface, owner, repo string, pr PullRequest, trusted []string) ([]string, error) {
+	if isTrustedAuthor(pr.User.Login, trusted) {
+		return nil, nil
+	}
+	files, err := ghprs.FetchFiles(withFreshData(context.Background()), client, owner, repo, pr.Number)
+	if err != nil {
+		return nil, err
+	}
+	return untrustedSensitiveFiles(pr, files, trusted), nil
+}
+
+// displaySensitiveFiles warns that a PR by an untrusted author changes sensitive files
+func displaySensitiveFiles(pr PullRequest, files []string) {
+	streams.Printf("   🛡️  %s isn't a trusted author and this PR changes CI or ownership files:\n", pr.User.Login)
+	for _, file := range files {
+		streams.Printf("      %s\n", file)
+	}
+}
+
+// confirmSensitiveChanges asks for the PR number to be typed before a PR by an untrusted author that changes
+// sensitive files is approved, so routine triage doesn't wave a CI takeover through with a y. It returns
+// true right away for other PRs, and false when the files can't be checked.
+func confirmSensitiveChanges(client RESTClientInterface, owner, repo string, pr PullRequest, trusted []string) bool {
+	link := formatPRLink(owner, repo, pr.Number)
+	files, err := fetchUntrustedSensitiveFiles(client, owner, repo, pr, trusted)
+	if err != nil {
+		streams.Printf("❌ Could not check the files %s changes, not approving it: %v\n", link, err)
+		return false
+	}
+	if len(files) == 0 {
+		return true
+	}
+
+	streams.Printf("\n🛡️  ⚠️  CI OR OWNERSHIP FILES CHANGED BY AN UNTRUSTED AUTHOR ⚠️  🛡️\n")
+	displaySensitiveFiles(pr, files)
+	streams.Printf("Changes to these files run with the repository's CI credentials or change who can approve PRs.\n")
+	answer, err := prompter.Input(fmt.Sprintf("Type the PR number (%d) to approve it anyway: ", pr.Number))
+	if err != nil || strings.TrimPrefix(answer, "#") != strconv.Itoa(pr.Number) {
+		streams.Printf("❌ Approval cancelled due to CI or ownership changes. Skipping PR %s\n", link)
+		return false
+	}
+	streams.Printf("✅ Confirmed - proceeding with approval despite CI or ownership changes.\n")
+	return true
+}
+
+// batchApprovalBlocker returns why a PR can't be approved in a batch, where nobody types its number: it's by a
+// bot or trusted author and changes more than maxChanges lines, its author isn't trusted and it changes
+// sensitive files, or its size or files couldn't be checked. It returns "" for PRs that can be approved.
+func batchApprovalBlocker(item repoPR, trusted []string, maxChanges int) string {
+	if reason := largeChangesBlocker(item, maxChanges, trusted); reason != "" {
+		return reason
+	}
+	files, err := fetchUntrustedSensitiveFiles(item.Client, item.Owner, item.Repo, item.PR, trusted)
+	if err != nil {
+		return fmt.Sprintf("could not check changed files: %v", err)
+	}
+	if len(files) > 0 {
+		return fmt.Sprintf("untrusted author changes %s", strings.Join(files, ", "))
+	}
+	return ""
+}
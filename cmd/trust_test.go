@@ -0,0 +1,84 @@
+package cmd_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Trusted authors", func() {
+	pr := func(author string) cmd.PullRequest {
+		pullRequest := cmd.PullRequest{Number: 7}
+		pullRequest.User.Login = author
+		return pullRequest
+	}
+	trusted := []string{"red-hat-konflux[bot]"}
+
+	It("should default to trusting the dependency bots", func() {
+		config := &cmd.Config{}
+		Expect(config.TrustedAuthors()).To(Equal([]string{"red-hat-konflux[bot]", "dependabot[bot]", "renovate[bot]"}))
+		config.Trust.Authors = []string{"alice"}
+		Expect(config.TrustedAuthors()).To(Equal([]string{"alice"}))
+	})
+
+	DescribeTable("sensitive files",
+		func(filename string, sensitive bool) {
+			Expect(cmd.IsSensitiveFileTest(filename)).To(Equal(sensitive))
+		},
+		Entry("a GitHub workflow", ".github/workflows/ci.yaml", true),
+		Entry("a Tekton pipeline", ".tekton/app-push.yaml", true),
+		Entry("the root OWNERS", "OWNERS", true),
+		Entry("a nested OWNERS", "docs/OWNERS", true),
+		Entry("OWNERS_ALIASES", "OWNERS_ALIASES", true),
+		Entry("other GitHub files", ".github/dependabot.yml", false),
+		Entry("source files", "main.go", false),
+		Entry("files named like OWNERS", "OWNERS.md", false),
+	)
+
+	It("should only report sensitive files of untrusted authors", func() {
+		files := []cmd.PRFile{{Filename: "go.mod"}, {Filename: ".github/workflows/ci.yaml"}, {Filename: "OWNERS"}}
+		Expect(cmd.UntrustedSensitiveFilesTest(pr("mallory"), files, trusted)).To(Equal([]string{".github/workflows/ci.yaml", "OWNERS"}))
+		Expect(cmd.UntrustedSensitiveFilesTest(pr("Red-Hat-Konflux[bot]"), files, trusted)).To(BeEmpty())
+	})
+
+	Describe("Confirming an approval", func() {
+		var mockClient *cmd.MockRESTClient
+		var in, out *bytes.Buffer
+
+		BeforeEach(func() {
+			mockClient = cmd.NewMockRESTClient()
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+			mockClient.AddResponse("repos/owner/repo/pulls/7/files", 200, []cmd.PRFile{{Filename: ".tekton/app-push.yaml"}})
+		})
+
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should approve once the PR number is typed", func() {
+			in.WriteString("7\n")
+			Expect(cmd.ConfirmSensitiveChangesTest(mockClient, "owner", "repo", pr("mallory"), trusted)).To(BeTrue())
+			Expect(out.String()).To(ContainSubstring(".tekton/app-push.yaml"))
+		})
+
+		It("should not take a y for the PR number", func() {
+			in.WriteString("y\n")
+			Expect(cmd.ConfirmSensitiveChangesTest(mockClient, "owner", "repo", pr("mallory"), trusted)).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("Approval cancelled"))
+		})
+
+		It("should not ask about trusted authors", func() {
+			Expect(cmd.ConfirmSensitiveChangesTest(mockClient, "owner", "repo", pr("red-hat-konflux[bot]"), trusted)).To(BeTrue())
+			Expect(out.String()).To(BeEmpty())
+		})
+
+		It("should keep untrusted CI changes out of batches", func() {
+			Expect(cmd.BatchApprovalBlockerTest(mockClient, "owner", "repo", pr("mallory"), trusted, 0)).To(Equal("untrusted author changes .tekton/app-push.yaml"))
+			Expect(cmd.BatchApprovalBlockerTest(mockClient, "owner", "repo", pr("red-hat-konflux[bot]"), trusted, 0)).To(BeEmpty())
+		})
+	})
+})
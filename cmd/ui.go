@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// defaultPager pages output taller than the terminal when neither ui.pager nor $PAGER is set; -R keeps the colors
+const defaultPager = "less -R"
+
+// defaultEditor composes messages when neither ui.editor, $VISUAL nor $EDITOR is set
+const defaultEditor = "vi"
+
+// editorAnswer is entered at a comment prompt to write the comment in the editor instead
+const editorAnswer = "e"
+
+// UIConfig controls the external programs ghprs hands long output and messages to
+type UIConfig struct {
+	// Pager is the command long output is paged through, e.g. "less -R"; unset uses $PAGER, "cat" disables paging
+	Pager string `yaml:"pager,omitempty"`
+	// Editor is the command comments are composed in, e.g. "code --wait"; unset uses $VISUAL or $EDITOR
+	Editor string `yaml:"editor,omitempty"`
+	// Browser is the command PRs and checks are opened with, e.g. "firefox"; unset uses $GH_BROWSER, the gh
+	// config, $BROWSER or the system's default browser
+	Browser string `yaml:"browser,omitempty"`
+}
+
+// PagerCommand returns the command long output is paged through: ui.pager, else $PAGER, else less -R
+func (u UIConfig) PagerCommand() string {
+	if u.Pager != "" {
+		return u.Pager
+	}
+	// An empty $PAGER is kept, it disables paging
+	if pager, ok := os.LookupEnv("PAGER"); ok {
+		return pager
+	}
+	return defaultPager
+}
+
+// EditorCommand returns the command messages are composed in: ui.editor, else $VISUAL, else $EDITOR, else vi
+func (u UIConfig) EditorCommand() string {
+	for _, editor := range []string{u.Editor, os.Getenv("VISUAL"), os.Getenv("EDITOR")} {
+		if editor != "" {
+			return editor
+		}
+	}
+	return defaultEditor
+}
+
+// uiSettings are the configured pager, editor and browser
+var uiSettings UIConfig
+
+// loadUISettings reads the pager, editor and browser from the config
+func loadUISettings() {
+	if config, err := LoadConfig(); err == nil {
+		uiSettings = config.UI
+	}
+}
+
+// pageOutput shows text, through the pager when it is taller than the terminal. Without a terminal, or with
+// the pager set to nothing or cat, it is printed as it is.
+func pageOutput(text string) {
+	height := streams.Height()
+	if !streams.IsTerminal() || height == 0 || strings.Count(text, "\n") < height {
+		streams.Print(text)
+		return
+	}
+	pager := uiSettings.PagerCommand()
+	args := strings.Fields(pager)
+	if len(args) == 0 || args[0] == "cat" {
+		streams.Print(text)
+		return
+	}
+
+	pagerCmd := exec.Command(args[0], args[1:]...)
+	pagerCmd.Stdin = strings.NewReader(text)
+	pagerCmd.Stdout, pagerCmd.Stderr = streams.Out, streams.ErrOut
+	if err := pagerCmd.Start(); err != nil {
+		logger.Debug("Could not start the pager, printing instead", "pager", pager, "error", err)
+		streams.Print(text)
+		return
+	}
+	// Some pagers exit with an error when quit early, the text was shown regardless
+	_ = pagerCmd.Wait()
+}
+
+// paged shows what display prints through the pager when it is taller than the terminal
+func paged(display func()) {
+	if !streams.IsTerminal() {
+		display()
+		return
+	}
+	// display still sees a terminal, so colors are kept
+	saved := streams
+	var captured bytes.Buffer
+	capture := *saved
+	capture.Out = &captured
+	capture.SetTerminal(true)
+	streams = &capture
+	defer func() {
+		streams = saved
+		pageOutput(captured.String())
+	}()
+	display()
+}
+
+// editFile opens the editor on a file and waits for it to be closed
+func editFile(path string) error {
+	args := strings.Fields(uiSettings.EditorCommand())
+	if len(args) == 0 {
+		return fmt.Errorf("no editor configured")
+	}
+	editorCmd := exec.Command(args[0], append(args[1:], path)...)
+	editorCmd.Stdin, editorCmd.Stdout, editorCmd.Stderr = streams.In, streams.Out, streams.ErrOut
+	if err := editorCmd.Run(); err != nil {
+		return fmt.Errorf("editor %s failed: %w", args[0], err)
+	}
+	return nil
+}
+
+// editMessage opens the editor on an empty file and returns what was written to it, trimmed
+func editMessage() (string, error) {
+	file, err := os.CreateTemp("", "ghprs-message-*.md")
+	if err != nil {
+		return "", fmt.Errorf("failed to create the message file: %w", err)
+	}
+	defer func() { _ = os.Remove(file.Name()) }()
+	if err := file.Close(); err != nil {
+		return "", fmt.Errorf("failed to create the message file: %w", err)
+	}
+
+	if err := editFile(file.Name()); err != nil {
+		return "", err
+	}
+	message, err := os.ReadFile(file.Name())
+	if err != nil {
+		return "", fmt.Errorf("failed to read the message: %w", err)
+	}
+	return strings.TrimSpace(string(message)), nil
+}
+
+// promptMessage asks for a comment on one line, or in the editor when e is entered, so it can span
+// several lines
+func promptMessage(prompt string) (string, error) {
+	answer, err := prompter.Input(prompt)
+	if err != nil || answer != editorAnswer {
+		return answer, err
+	}
+	message, err := editMessage()
+	if err != nil {
+		return "", err
+	}
+	if message != "" {
+		streams.Printf("📝 %d line(s) written in the editor\n", strings.Count(message, "\n")+1)
+	}
+	return message, nil
+}
@@ -0,0 +1,120 @@
+package cmd_test
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Pager and editor", func() {
+	setenv := func(key, value string, set bool) {
+		previous, wasSet := os.LookupEnv(key)
+		if set {
+			Expect(os.Setenv(key, value)).To(Succeed())
+		} else {
+			Expect(os.Unsetenv(key)).To(Succeed())
+		}
+		DeferCleanup(func() {
+			if wasSet {
+				_ = os.Setenv(key, previous)
+			} else {
+				_ = os.Unsetenv(key)
+			}
+		})
+	}
+
+	Describe("choosing the pager", func() {
+		It("should prefer ui.pager, then $PAGER, then less", func() {
+			setenv("PAGER", "more", true)
+			Expect(cmd.UIConfig{Pager: "bat"}.PagerCommand()).To(Equal("bat"))
+			Expect(cmd.UIConfig{}.PagerCommand()).To(Equal("more"))
+			setenv("PAGER", "", false)
+			Expect(cmd.UIConfig{}.PagerCommand()).To(Equal("less -R"))
+		})
+
+		It("should keep an empty $PAGER, which disables paging", func() {
+			setenv("PAGER", "", true)
+			Expect(cmd.UIConfig{}.PagerCommand()).To(BeEmpty())
+		})
+	})
+
+	It("should prefer ui.editor, then $VISUAL, then $EDITOR, then vi", func() {
+		setenv("VISUAL", "", false)
+		setenv("EDITOR", "nano", true)
+		Expect(cmd.UIConfig{Editor: "code --wait"}.EditorCommand()).To(Equal("code --wait"))
+		Expect(cmd.UIConfig{}.EditorCommand()).To(Equal("nano"))
+		setenv("VISUAL", "emacs", true)
+		Expect(cmd.UIConfig{}.EditorCommand()).To(Equal("emacs"))
+		setenv("VISUAL", "", false)
+		setenv("EDITOR", "", false)
+		Expect(cmd.UIConfig{}.EditorCommand()).To(Equal("vi"))
+	})
+
+	Describe("prompting for a message", func() {
+		var in, out *bytes.Buffer
+
+		BeforeEach(func() {
+			in, out = &bytes.Buffer{}, &bytes.Buffer{}
+			cmd.SetIOStreams(cmd.NewIOStreams(in, out, &bytes.Buffer{}), nil)
+
+			editor := filepath.Join(GinkgoT().TempDir(), "editor")
+			Expect(os.WriteFile(editor, []byte("#!/bin/sh\nprintf 'waiting on\\nthe release\\n\\n' > \"$1\"\n"), 0o755)).To(Succeed())
+			cmd.SetUISettingsTest(cmd.UIConfig{Editor: editor})
+		})
+
+		AfterEach(func() {
+			cmd.SetUISettingsTest(cmd.UIConfig{})
+			cmd.ResetIOStreams()
+		})
+
+		It("should take the line entered", func() {
+			in.WriteString("waiting on CI\n")
+			Expect(cmd.PromptMessageTest("Comment: ")).To(Equal("waiting on CI"))
+		})
+
+		It("should compose the message in the editor when e is entered", func() {
+			in.WriteString("e\n")
+			Expect(cmd.PromptMessageTest("Comment: ")).To(Equal("waiting on\nthe release"))
+			Expect(out.String()).To(ContainSubstring("2 line(s) written in the editor"))
+		})
+
+		It("should report an editor that fails", func() {
+			cmd.SetUISettingsTest(cmd.UIConfig{Editor: "false"})
+			in.WriteString("e\n")
+			_, err := cmd.PromptMessageTest("Comment: ")
+			Expect(err).To(MatchError(ContainSubstring("editor false failed")))
+		})
+	})
+
+	Describe("paging output", func() {
+		AfterEach(func() {
+			cmd.ResetIOStreams()
+		})
+
+		It("should show the output directly without a terminal", func() {
+			cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), &bytes.Buffer{}, &bytes.Buffer{}), nil)
+			var colors, called bool
+			cmd.PagedTest(func() { colors, called = cmd.ShouldUseColorsTest(), true })
+			Expect(called).To(BeTrue())
+			Expect(colors).To(BeFalse())
+		})
+
+		It("should keep the colors of a terminal while capturing the output", func() {
+			setenv("NO_COLOR", "", false)
+			out := &bytes.Buffer{}
+			streams := cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{})
+			streams.SetTerminal(true)
+			cmd.SetIOStreams(streams, nil)
+
+			var colors bool
+			cmd.PagedTest(func() { colors = cmd.ShouldUseColorsTest() })
+			Expect(colors).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/pkg/ghprs"
+)
+
+// holdLabel is the label Prow adds to PRs on hold
+const holdLabel = ghprs.HoldLabel
+
+var (
+	unholdComment   string
+	removeHoldLabel bool
+)
+
+// unholdCmd takes several PRs off hold after showing what will be done
+var unholdCmd = &cobra.Command{
+	Use:   "unhold [owner/repo] <number>...",
+	Short: "Take several pull requests off hold",
+	Long: `Take pull requests off hold by commenting /unhold.
+
+Repositories where no Prow bot acts on /unhold can have the do-not-merge/hold label removed
+directly instead with --remove-label.
+
+A plan of what will happen to each PR is shown first and nothing is changed until it is
+confirmed. PRs that are closed or not on hold are skipped.
+
+Examples:
+  ghprs unhold owner/repo 12 15 20
+  ghprs unhold owner/repo 12 --comment "release branch is out"
+  ghprs unhold owner/repo 12 --remove-label   # Remove the label instead of commenting /unhold
+  ghprs unhold --repo owner/repo 12 15 --yes`,
+	Args:              cobra.MinimumNArgs(1),
+	ValidArgsFunction: completePRArgs(true),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		owner, repo, numbers := parseBatchArgs(args)
+		client := newCommandClient(ctx, owner, repo)
+		if failed := unholdPRs(ctx, client, owner, repo, numbers, unholdComment, removeHoldLabel, assumeYes); failed > 0 {
+			os.Exit(1)
+		}
+	},
+}
+
+// planUnhold decides which of the given PRs will be taken off hold
+func planUnhold(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, comment string) *batchPlan {
+	plan := &batchPlan{owner: owner, repo: repo}
+	for _, number := range numbers {
+		pr, err := fetchPRDetails(ctx, client, owner, repo, number)
+		switch {
+		case err != nil:
+			plan.add(PullRequest{Number: number}, PlanActionSkip, fmt.Sprintf("could not fetch PR: %v", err))
+		case pr.State != "open":
+			plan.add(*pr, PlanActionSkip, fmt.Sprintf("PR is %s", pr.State))
+		case !isOnHold(*pr):
+			plan.add(*pr, PlanActionSkip, "not on hold")
+		case comment != "":
+			plan.add(*pr, PlanActionUnhold, comment)
+		default:
+			plan.add(*pr, PlanActionUnhold, "requested")
+		}
+	}
+	return plan
+}
+
+// unholdPRs shows the unhold plan, asks for one confirmation and takes the PRs off hold, returning the number of failures
+func unholdPRs(ctx context.Context, client RESTClientInterface, owner, repo string, numbers []int, comment string, removeLabel, assumeYes bool) int {
+	plan := planUnhold(ctx, client, owner, repo, numbers, comment)
+	if !confirmPlan(plan, assumeYes) {
+		return 0
+	}
+
+	return executePlan(plan, func(action PlannedAction) error {
+		return unholdPR(client, owner, repo, action.Number, comment, removeLabel)
+	})
+}
+
+// unholdPR takes a PR off hold by commenting /unhold, or with removeLabel by removing the hold label directly
+// and posting the comment, if any, on its own
+func unholdPR(client RESTClientInterface, owner, repo string, prNumber int, additionalComment string, removeLabel bool) error {
+	if !removeLabel {
+		commentBody := "/unhold"
+		if additionalComment != "" {
+			commentBody += "\n\n" + additionalComment
+		}
+		if err := addCommentToPR(client, owner, repo, prNumber, commentBody); err != nil {
+			return fmt.Errorf("failed to add /unhold comment: %v", err)
+		}
+		auditLog.record(client, owner, repo, prNumber, AuditUnhold, "")
+		return nil
+	}
+
+	labelPath := fmt.Sprintf("repos/%s/%s/issues/%d/labels/%s", owner, repo, prNumber, url.PathEscape(holdLabel))
+	if err := client.Delete(labelPath, nil); err != nil {
+		return fmt.Errorf("failed to remove %s label: %v", holdLabel, err)
+	}
+	auditLog.record(client, owner, repo, prNumber, AuditUnhold, "")
+	if additionalComment != "" {
+		if err := addCommentToPR(client, owner, repo, prNumber, additionalComment); err != nil {
+			return fmt.Errorf("removed %s label but failed to post comment: %v", holdLabel, err)
+		}
+	}
+	return nil
+}
+
+func init() {
+	RootCmd.AddCommand(unholdCmd)
+
+	unholdCmd.Flags().StringVar(&unholdComment, "comment", "", "Explanation to add below the /unhold comment")
+	unholdCmd.Flags().BoolVar(&removeHoldLabel, "remove-label", false, "Remove the do-not-merge/hold label directly instead of commenting /unhold")
+	unholdCmd.Flags().BoolVarP(&assumeYes, "yes", "y", false, "Carry out the plan without asking for confirmation")
+}
@@ -0,0 +1,138 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+)
+
+// defaultApprovalVerifyTimeout is how long Prow is given to label an approved PR
+const defaultApprovalVerifyTimeout = 30 * time.Second
+
+// approvalVerifyInterval is how often the labels of an approved PR are looked at while Prow is waited for
+var approvalVerifyInterval = 5 * time.Second
+
+// prowRejectionRE matches Prow's replies refusing a command, such as "you cannot LGTM your own PR"
+var prowRejectionRE = regexp.MustCompile(`(?i)\b(?:cannot|can't|can not)\s+(?:lgtm|approve)\b[^\n]*`)
+
+// prowCommandLabels maps the Prow commands an approval can post to the label Prow adds for them
+var prowCommandLabels = map[string]string{
+	"/lgtm":    "lgtm",
+	"/approve": "approved",
+}
+
+// expectedApprovalLabels returns the labels Prow adds for the commands posted by an approval with settings
+func expectedApprovalLabels(settings ApprovalSettings) []string {
+	var labels []string
+	for _, body := range append([]string{settings.ReviewBody()}, settings.ExtraComments...) {
+		for command, label := range prowCommandLabels {
+			if isProwCommand(body, command) && !slices.Contains(labels, label) {
+				labels = append(labels, label)
+			}
+		}
+	}
+	slices.Sort(labels)
+	return labels
+}
+
+// verifyApproval checks that an approval landed: the posted review is on the PR and, for PRs managed by Prow,
+// Prow added the labels for the posted commands. It returns an error when the approval verifiably didn't
+// land, including Prow's reply when it refused a command; when it can't tell, it only warns.
+func verifyApproval(client RESTClientInterface, owner, repo string, pr PullRequest, settings ApprovalSettings, reviewID int64, postedAt time.Time) error {
+	ctx := withFreshData(context.Background())
+	prLink := formatPRLink(owner, repo, pr.Number)
+
+	// Without the ID of the posted review there is nothing to look for
+	if reviewID != 0 {
+		var reviews []Review
+		reviewsPath := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?per_page=%d", owner, repo, pr.Number, maxPerPage)
+		if err := client.DoWithContext(ctx, http.MethodGet, reviewsPath, nil, &reviews); err != nil {
+			streams.Printf("   ⚠️  Could not verify the review on %s: %v\n", prLink, err)
+			return nil
+		}
+		found := false
+		for _, review := range reviews {
+			if review.ID == reviewID {
+				found = true
+				if review.State == "DISMISSED" {
+					return fmt.Errorf("the review was dismissed")
+				}
+			}
+		}
+		if !found {
+			return fmt.Errorf("the review isn't on the PR")
+		}
+	}
+
+	labels := expectedApprovalLabels(settings)
+	timeout := settings.VerificationTimeout()
+	if len(labels) == 0 || !isProwManaged(pr) || timeout == 0 {
+		return nil
+	}
+
+	missing, err := waitForApprovalLabels(ctx, client, owner, repo, pr.Number, labels, postedAt, timeout)
+	switch {
+	case err != nil:
+		if rejection, ok := err.(prowRejection); ok {
+			return rejection
+		}
+		streams.Printf("   ⚠️  Could not verify that Prow acted on the approval of %s: %v\n", prLink, err)
+	case len(missing) > 0:
+		streams.Printf("   ⚠️  Prow didn't add the %s label to %s within %s, check it later\n", strings.Join(missing, " and "), prLink, formatAge(timeout))
+	default:
+		streams.Printf("   ✓ Prow added the %s label\n", strings.Join(labels, " and "))
+	}
+	return nil
+}
+
+// prowRejection is Prow refusing a command posted by an approval
+type prowRejection struct {
+	reply string
+}
+
+func (r prowRejection) Error() string {
+	return "Prow refused it: " + r.reply
+}
+
+// waitForApprovalLabels waits until the PR has labels, returning those still missing after timeout. A Prow
+// reply refusing a command posted since postedAt ends the wait with a prowRejection.
+func waitForApprovalLabels(ctx context.Context, client RESTClientInterface, owner, repo string, prNumber int, labels []string, postedAt time.Time, timeout time.Duration) ([]string, error) {
+	deadline := time.Now().Add(timeout)
+	labelsPath := fmt.Sprintf("repos/%s/%s/issues/%d/labels", owner, repo, prNumber)
+	// A minute of leeway covers the clock difference with GitHub
+	commentsPath := fmt.Sprintf("repos/%s/%s/issues/%d/comments?since=%s&per_page=%d", owner, repo, prNumber,
+		url.QueryEscape(postedAt.Add(-time.Minute).UTC().Format(time.RFC3339)), maxPerPage)
+	for {
+		var comments []IssueComment
+		if err := client.DoWithContext(ctx, http.MethodGet, commentsPath, nil, &comments); err != nil {
+			return nil, err
+		}
+		for _, comment := range comments {
+			if reply := prowRejectionRE.FindString(comment.Body); reply != "" {
+				return nil, prowRejection{reply: strings.TrimSpace(reply)}
+			}
+		}
+
+		var current []Label
+		if err := client.DoWithContext(ctx, http.MethodGet, labelsPath, nil, &current); err != nil {
+			return nil, err
+		}
+		var missing []string
+		for _, label := range labels {
+			if !slices.ContainsFunc(current, func(l Label) bool { return l.Name == label }) {
+				missing = append(missing, label)
+			}
+		}
+		if len(missing) == 0 || time.Now().After(deadline) {
+			return missing, nil
+		}
+		if err := sleepContext(ctx, min(approvalVerifyInterval, time.Until(deadline))); err != nil {
+			return missing, err
+		}
+	}
+}
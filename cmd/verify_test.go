@@ -0,0 +1,90 @@
+package cmd_test
+
+import (
+	"bytes"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Approval verification", func() {
+	var (
+		mockClient       *cmd.MockRESTClient
+		out              *bytes.Buffer
+		pr               cmd.PullRequest
+		settings         cmd.ApprovalSettings
+		previousInterval time.Duration
+	)
+
+	const (
+		reviewsPath  = "repos/owner/repo/pulls/1/reviews"
+		commentsPath = "repos/owner/repo/issues/1/comments"
+		labelsPath   = "repos/owner/repo/issues/1/labels"
+	)
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, &bytes.Buffer{}), nil)
+		previousInterval = cmd.SetApprovalVerifyIntervalTest(time.Millisecond)
+
+		mockClient = cmd.NewMockRESTClient()
+		mockClient.AddResponse(reviewsPath, 200, cmd.Review{ID: 42, State: "APPROVED"})
+		mockClient.AddResponse(reviewsPath+"?per_page=100", 200, []cmd.Review{{ID: 42, State: "APPROVED"}})
+		mockClient.AddResponse(commentsPath, 200, []cmd.IssueComment{})
+		mockClient.AddResponse(labelsPath, 200, []cmd.Label{{Name: "ok-to-test"}, {Name: "lgtm"}})
+
+		pr = cmd.PullRequest{Number: 1, State: "open", Labels: []cmd.Label{{Name: "ok-to-test"}}}
+		settings = cmd.ApprovalSettings{VerifyTimeout: "50ms"}
+	})
+
+	AfterEach(func() {
+		cmd.SetApprovalVerifyIntervalTest(previousInterval)
+		cmd.ResetIOStreams()
+	})
+
+	It("should expect the labels of the Prow commands an approval posts", func() {
+		Expect(cmd.ExpectedApprovalLabelsTest(cmd.ApprovalSettings{})).To(Equal([]string{"lgtm"}))
+		empty := ""
+		Expect(cmd.ExpectedApprovalLabelsTest(cmd.ApprovalSettings{Body: &empty, ExtraComments: []string{"/approve"}})).To(Equal([]string{"approved"}))
+		Expect(cmd.ExpectedApprovalLabelsTest(cmd.ApprovalSettings{Body: &empty})).To(BeEmpty())
+	})
+
+	It("should confirm an approval once Prow added the label", func() {
+		Expect(cmd.PostApprovalTest(mockClient, "owner", "repo", pr, settings)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Prow added the lgtm label"))
+	})
+
+	It("should report an approval Prow refused", func() {
+		mockClient.AddResponse(commentsPath, 200, []cmd.IssueComment{{Body: "@me: you cannot LGTM your own PR.\n\n<details>Instructions</details>"}})
+		mockClient.AddResponse(labelsPath, 200, []cmd.Label{{Name: "ok-to-test"}})
+
+		err := cmd.PostApprovalTest(mockClient, "owner", "repo", pr, settings)
+		Expect(err).To(MatchError("Prow refused it: cannot LGTM your own PR."))
+	})
+
+	It("should warn when the label doesn't appear in time", func() {
+		mockClient.AddResponse(labelsPath, 200, []cmd.Label{{Name: "ok-to-test"}})
+
+		Expect(cmd.PostApprovalTest(mockClient, "owner", "repo", pr, settings)).To(Succeed())
+		Expect(out.String()).To(ContainSubstring("Prow didn't add the lgtm label to #1"))
+		Expect(mockClient.GetRequestCount(labelsPath)).To(BeNumerically(">", 1))
+	})
+
+	It("should report a review that isn't on the PR", func() {
+		mockClient.AddResponse(reviewsPath+"?per_page=100", 200, []cmd.Review{{ID: 7, State: "APPROVED"}})
+
+		Expect(cmd.PostApprovalTest(mockClient, "owner", "repo", pr, settings)).To(MatchError(ContainSubstring("isn't on the PR")))
+	})
+
+	It("should only check the review of PRs not managed by Prow", func() {
+		pr.Labels = nil
+
+		Expect(cmd.PostApprovalTest(mockClient, "owner", "repo", pr, settings)).To(Succeed())
+		Expect(mockClient.GetRequestCount(labelsPath)).To(Equal(0))
+		Expect(mockClient.GetRequestCount(reviewsPath + "?per_page=100")).To(Equal(1))
+	})
+})
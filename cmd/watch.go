@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os/exec"
+	"runtime"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+
+	"ghprs/internal/render"
+)
+
+// defaultWatchInterval is how often watch refreshes when --interval isn't given
+const defaultWatchInterval = 60 * time.Second
+
+// Check summaries shown in watch change reports
+const (
+	checksNone    = "none"
+	checksPending = "pending"
+	checksFailing = "failing"
+	checksPassing = "passing"
+)
+
+var (
+	watchInterval time.Duration
+	watchKonflux  bool
+	watchNotify   bool
+	unholdExpired bool
+)
+
+// notifier sends a desktop notification, replaced in tests
+var notifier = desktopNotify
+
+// watchCmd represents the watch command
+var watchCmd = &cobra.Command{
+	Use:   "watch [owner/repo]",
+	Short: "Continuously refresh the PR table and report what changed",
+	Long: `Refresh the PR table on an interval and highlight what changed since the previous refresh:
+new PRs, PRs that are no longer listed, check status transitions and new migration warnings.
+
+PRs put on hold with an expiry (ghprs hold --until/--for) are reported once their hold expired,
+or taken off hold with a comment with --unhold-expired.
+
+Repositories are chosen like 'ghprs list', except that every configured repository is watched.
+Press Ctrl+C to stop.
+
+Examples:
+  ghprs watch
+  ghprs watch owner/repo --interval 2m
+  ghprs watch --konflux                     # Watch Konflux PRs (e.g. while waiting on nudges)
+  ghprs watch --konflux --notify            # Also send desktop notifications for changes, and post
+                                            # migration warnings and failing checks to the webhooks
+                                            # configured for 'ghprs notify'
+  ghprs watch --unhold-expired              # Take PRs off hold once their hold expired`,
+	ValidArgsFunction: completeRepositoryArgs(false),
+	Run: func(cmd *cobra.Command, args []string) {
+		ctx := commandContext(cmd)
+		watchOpts.prepare(cmd)
+		var authors []string
+		if watchKonflux {
+			authors = []string{konfluxBotAuthor}
+		}
+		watchPullRequests(ctx, watchOpts, args, authors, watchKonflux)
+	},
+}
+
+// WatchSnapshot is what one refresh saw of a PR
+type WatchSnapshot struct {
+	Title     string
+	Checks    string
+	Migration bool
+	// OnHold is set for open PRs on hold, whose hold may expire
+	OnHold bool
+}
+
+// WatchChange describes something that changed for a PR between two refreshes
+type WatchChange struct {
+	Number  int
+	Message string
+}
+
+func watchPullRequests(ctx context.Context, opts *listOptions, args []string, authors []string, isKonflux bool) {
+	if watchInterval <= 0 {
+		log.Fatal("--interval must be greater than 0")
+	}
+
+	config, err := LoadConfig()
+	if err != nil {
+		logger.Warn("Could not load config, using defaults", "error", err)
+		config = DefaultConfig()
+	}
+	applyConfigDefaults(opts, config)
+	setRepositoryHosts(config)
+	setColumnWidths(config)
+
+	repositories := resolveRepositories(opts, args, config, isKonflux, false)
+	limiter := newRateLimiter(config.RateLimitThreshold(), streams.ErrOut)
+	webhooks := newWebhookNotifier(config.Notifications)
+
+	// Check results change without the PR being updated, so watch never reads from the disk cache
+	previous := make(map[string]map[int]WatchSnapshot)
+	// Expired holds are reported once per expiry
+	reportedHolds := make(map[string]map[int]time.Time)
+	for {
+		if streams.IsTerminal() {
+			streams.Print("\033[H\033[2J")
+		}
+		streams.Printf("Every %s: ghprs watch (last refresh %s, Ctrl+C to stop)\n", watchInterval, time.Now().Format("15:04:05"))
+
+		// The legend is shown once per refresh, since the screen is cleared in between
+		legendMode := config.LegendMode()
+		if opts.NoLegend {
+			legendMode = render.LegendNever
+		}
+		legend.Reset(legendMode)
+
+		for _, repoSpec := range repositories {
+			owner, repo, ok := parseRepoSpec(repoSpec)
+			if !ok {
+				logger.Warn("Invalid repository format, skipping. Must be 'owner/repo'", "repo", repoSpec)
+				continue
+			}
+
+			client, err := newAPIClientWithTimeout(hostFor(owner, repo), limiter, nil, opts.RequestTimeout)
+			if err != nil {
+				logger.Error("Failed to create GitHub client", "repo", repoSpec, "error", err)
+				continue
+			}
+
+			// A slow repository is skipped for this refresh rather than holding up the others
+			repoCtx, cancel := withRepositoryTimeout(ctx)
+			client = withContext(client, repoCtx)
+			snapshots, err := watchRefresh(repoCtx, opts, client, owner, repo, queueAuthors(config, repoSpec, authors, isKonflux), isKonflux)
+			if reason := describeCancellation(repoCtx.Err()); reason != "" {
+				cancel()
+				if ctx.Err() != nil {
+					break
+				}
+				logger.Warn("Skipping repository", "repo", repoSpec, "reason", reason)
+				continue
+			}
+			if err != nil {
+				cancel()
+				logger.Error("Failed to refresh pull requests", "repo", repoSpec, "error", err)
+				continue
+			}
+
+			if last, seen := previous[repoSpec]; seen {
+				reportWatchChanges(repoSpec, diffWatchSnapshots(last, snapshots), watchNotify)
+				if watchNotify {
+					_ = sendAlerts(repoCtx, webhooks, newAlerts(owner, repo, last, snapshots))
+				}
+			}
+			previous[repoSpec] = snapshots
+
+			if reportedHolds[repoSpec] == nil {
+				reportedHolds[repoSpec] = make(map[int]time.Time)
+			}
+			expired := findExpiredHolds(client, owner, repo, snapshots, time.Now(), reportedHolds[repoSpec])
+			handleExpiredHolds(client, owner, repo, expired, unholdExpired, watchNotify)
+			cancel()
+		}
+
+		if err := sleepContext(ctx, watchInterval); err != nil {
+			streams.Println("\nStopped watching.")
+			return
+		}
+	}
+}
+
+// watchRefresh fetches and renders a repository's PRs, returning a snapshot of each PR keyed by number
+func watchRefresh(ctx context.Context, opts *listOptions, client RESTClientInterface, owner, repo string, authors []string, isKonflux bool) (map[int]WatchSnapshot, error) {
+	pullRequests, client, snapshots, err := fetchWatchSnapshots(ctx, opts, client, owner, repo, authors, isKonflux)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(pullRequests) == 0 {
+		streams.Printf("\nNo %s pull requests found for %s/%s\n", opts.State, owner, repo)
+	} else {
+		_ = displayPRTable(ctx, opts, pullRequests, owner, repo, client, isKonflux, legend.Take(), nil)
+	}
+	return snapshots, nil
+}
+
+// fetchWatchSnapshots fetches a repository's PRs, newest first, and the check status of each, returning the
+// client to use for follow-up calls and a snapshot of each PR keyed by number
+func fetchWatchSnapshots(ctx context.Context, opts *listOptions, client RESTClientInterface, owner, repo string, authors []string, isKonflux bool) ([]PullRequest, RESTClientInterface, map[int]WatchSnapshot, error) {
+	pullRequests, client, err := fetchRepositoryPRs(ctx, opts, client, owner, repo, authors, isKonflux)
+	if err != nil {
+		return nil, client, nil, err
+	}
+	sortPullRequests(pullRequests, "newest")
+
+	checks := make([]string, len(pullRequests))
+	runConcurrently(len(pullRequests), opts.Concurrency, func(i int) {
+		checks[i] = checksNone
+		if pullRequests[i].Head.SHA == "" {
+			return
+		}
+		if status, err := getCheckStatus(ctx, client, owner, repo, pullRequests[i].Number, pullRequests[i].Head.SHA); err == nil {
+			checks[i] = checksSummary(status)
+		}
+	})
+
+	snapshots := make(map[int]WatchSnapshot, len(pullRequests))
+	for i, pr := range pullRequests {
+		snapshots[pr.Number] = WatchSnapshot{
+			Title:     pr.Title,
+			Checks:    checks[i],
+			Migration: hasMigrationWarning(pr),
+			OnHold:    pr.State == "open" && isOnHold(pr),
+		}
+	}
+	return pullRequests, client, snapshots, nil
+}
+
+// checksSummary reduces a check status to the state reported in watch changes
+func checksSummary(status *CheckStatus) string {
+	switch {
+	case status.Total == 0:
+		return checksNone
+	case status.Failed > 0:
+		return checksFailing
+	case status.Pending > 0:
+		return checksPending
+	default:
+		return checksPassing
+	}
+}
+
+// diffWatchSnapshots lists what changed between two refreshes, ordered by PR number
+func diffWatchSnapshots(previous, current map[int]WatchSnapshot) []WatchChange {
+	var changes []WatchChange
+	for number, now := range current {
+		before, existed := previous[number]
+		if !existed {
+			changes = append(changes, WatchChange{Number: number, Message: fmt.Sprintf("✨ new PR: %s", now.Title)})
+			continue
+		}
+		if before.Checks != now.Checks {
+			icon := "🔁"
+			switch now.Checks {
+			case checksFailing:
+				icon = "❌"
+			case checksPassing:
+				icon = "✅"
+			}
+			changes = append(changes, WatchChange{Number: number, Message: fmt.Sprintf("%s checks %s → %s", icon, before.Checks, now.Checks)})
+		}
+		if now.Migration && !before.Migration {
+			changes = append(changes, WatchChange{Number: number, Message: "🚨 new migration warning"})
+		}
+	}
+	for number, before := range previous {
+		if _, exists := current[number]; !exists {
+			changes = append(changes, WatchChange{Number: number, Message: fmt.Sprintf("👋 no longer listed: %s", before.Title)})
+		}
+	}
+
+	sort.SliceStable(changes, func(i, j int) bool {
+		return changes[i].Number < changes[j].Number
+	})
+	return changes
+}
+
+// reportWatchChanges prints the changes for a repository and optionally sends a desktop notification
+func reportWatchChanges(repoSpec string, changes []WatchChange, notify bool) {
+	if len(changes) == 0 {
+		streams.Printf("\nNo changes for %s since the last refresh\n", repoSpec)
+		return
+	}
+
+	streams.Printf("\nChanges for %s since the last refresh:\n", repoSpec)
+	for _, change := range changes {
+		streams.Printf("  #%d %s\n", change.Number, change.Message)
+	}
+
+	if notify {
+		message := fmt.Sprintf("#%d %s", changes[0].Number, changes[0].Message)
+		if len(changes) > 1 {
+			message += fmt.Sprintf(" (+%d more)", len(changes)-1)
+		}
+		if err := notifier("ghprs: "+repoSpec, message); err != nil {
+			warnOnce("notify", "could not send desktop notification: %v", err)
+		}
+	}
+}
+
+// desktopNotify shows a desktop notification using the platform's notification tool
+func desktopNotify(title, message string) error {
+	switch runtime.GOOS {
+	case "darwin":
+		script := fmt.Sprintf("display notification %q with title %q", message, title)
+		return exec.Command("osascript", "-e", script).Run()
+	case "linux":
+		return exec.Command("notify-send", title, message).Run()
+	default:
+		return fmt.Errorf("desktop notifications are not supported on %s", runtime.GOOS)
+	}
+}
+
+func init() {
+	RootCmd.AddCommand(watchCmd)
+
+	watchCmd.Flags().DurationVar(&watchInterval, "interval", defaultWatchInterval, "How often to refresh")
+	watchCmd.Flags().BoolVar(&watchKonflux, "konflux", false, "Watch Konflux pull requests (authored by red-hat-konflux[bot])")
+	watchCmd.Flags().BoolVar(&watchNotify, "notify", false, "Send a desktop notification when something changes, and post new migration warnings and failing checks to the configured webhooks")
+	watchCmd.Flags().BoolVar(&unholdExpired, "unhold-expired", false, "Take PRs off hold, with a comment, once the expiry given to 'ghprs hold' passed")
+}
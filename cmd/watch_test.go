@@ -0,0 +1,134 @@
+package cmd_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+var _ = Describe("Watch", func() {
+	var out, errOut *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+		errOut = &bytes.Buffer{}
+		cmd.SetIOStreams(cmd.NewIOStreams(strings.NewReader(""), out, errOut), nil)
+	})
+
+	AfterEach(func() {
+		cmd.ResetIOStreams()
+	})
+
+	Describe("Diffing refreshes", func() {
+		It("should report nothing when nothing changed", func() {
+			snapshots := map[int]cmd.WatchSnapshot{1: {Title: "First", Checks: "passing"}}
+			Expect(cmd.DiffWatchSnapshotsTest(snapshots, snapshots)).To(BeEmpty())
+		})
+
+		It("should report new and removed PRs", func() {
+			previous := map[int]cmd.WatchSnapshot{1: {Title: "Merged one", Checks: "passing"}}
+			current := map[int]cmd.WatchSnapshot{2: {Title: "New one", Checks: "pending"}}
+
+			Expect(cmd.DiffWatchSnapshotsTest(previous, current)).To(Equal([]cmd.WatchChange{
+				{Number: 1, Message: "👋 no longer listed: Merged one"},
+				{Number: 2, Message: "✨ new PR: New one"},
+			}))
+		})
+
+		It("should report check transitions and new migration warnings in PR order", func() {
+			previous := map[int]cmd.WatchSnapshot{
+				3: {Title: "Three", Checks: "pending"},
+				5: {Title: "Five", Checks: "pending"},
+				7: {Title: "Seven", Checks: "passing", Migration: true},
+			}
+			current := map[int]cmd.WatchSnapshot{
+				3: {Title: "Three", Checks: "failing", Migration: true},
+				5: {Title: "Five", Checks: "passing"},
+				7: {Title: "Seven", Checks: "pending", Migration: true},
+			}
+
+			Expect(cmd.DiffWatchSnapshotsTest(previous, current)).To(Equal([]cmd.WatchChange{
+				{Number: 3, Message: "❌ checks pending → failing"},
+				{Number: 3, Message: "🚨 new migration warning"},
+				{Number: 5, Message: "✅ checks pending → passing"},
+				{Number: 7, Message: "🔁 checks passing → pending"},
+			}))
+		})
+	})
+
+	Describe("Check summaries", func() {
+		It("should prefer failures over pending checks", func() {
+			Expect(cmd.ChecksSummaryTest(&cmd.CheckStatus{})).To(Equal("none"))
+			Expect(cmd.ChecksSummaryTest(&cmd.CheckStatus{Total: 3, Passed: 1, Failed: 1, Pending: 1})).To(Equal("failing"))
+			Expect(cmd.ChecksSummaryTest(&cmd.CheckStatus{Total: 2, Passed: 1, Pending: 1})).To(Equal("pending"))
+			Expect(cmd.ChecksSummaryTest(&cmd.CheckStatus{Total: 2, Passed: 2})).To(Equal("passing"))
+		})
+	})
+
+	Describe("Refreshing a repository", func() {
+		It("should snapshot each PR's checks and migration warning", func() {
+			mockClient := cmd.NewMockRESTClient()
+			mockClient.AddResponse("repos/owner/repo/pulls?", 200, []cmd.PullRequest{
+				{Number: 1, Title: "First", State: "open", Head: cmd.Branch{SHA: "abc123"}},
+				{Number: 2, Title: "Second", State: "open", Body: "⚠️[migration] breaking change"},
+			})
+			mockClient.AddResponse("repos/owner/repo/commits/abc123/check-runs", 200, cmd.CreateMockCheckRuns(1, 1, 0))
+			mockClient.AddResponse("repos/owner/repo/pulls/1/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/pulls/2/reviews", 200, []cmd.Review{})
+			mockClient.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1, MergeableState: "clean"})
+			mockClient.AddResponse("repos/owner/repo/pulls/2", 200, cmd.PullRequest{Number: 2, MergeableState: "clean"})
+
+			snapshots, err := cmd.WatchRefreshTest(mockClient, "owner", "repo")
+			Expect(err).NotTo(HaveOccurred())
+			Expect(snapshots).To(Equal(map[int]cmd.WatchSnapshot{
+				1: {Title: "First", Checks: "failing"},
+				2: {Title: "Second", Checks: "none", Migration: true},
+			}))
+			Expect(out.String()).To(ContainSubstring("First"))
+		})
+	})
+
+	Describe("Reporting changes", func() {
+		changes := []cmd.WatchChange{
+			{Number: 1, Message: "✨ new PR: First"},
+			{Number: 2, Message: "✅ checks pending → passing"},
+		}
+
+		It("should print each change and summarize them in one notification", func() {
+			var titles, messages []string
+			cmd.ReportWatchChangesTest("owner/repo", changes, func(title, message string) error {
+				titles = append(titles, title)
+				messages = append(messages, message)
+				return nil
+			})
+
+			Expect(out.String()).To(ContainSubstring("#1 ✨ new PR: First"))
+			Expect(out.String()).To(ContainSubstring("#2 ✅ checks pending → passing"))
+			Expect(titles).To(Equal([]string{"ghprs: owner/repo"}))
+			Expect(messages).To(Equal([]string{"#1 ✨ new PR: First (+1 more)"}))
+		})
+
+		It("should not notify when nothing changed", func() {
+			notified := false
+			cmd.ReportWatchChangesTest("owner/repo", nil, func(title, message string) error {
+				notified = true
+				return nil
+			})
+
+			Expect(notified).To(BeFalse())
+			Expect(out.String()).To(ContainSubstring("No changes for owner/repo"))
+		})
+
+		It("should keep going when the notification fails", func() {
+			cmd.ReportWatchChangesTest("owner/repo", changes, func(title, message string) error {
+				return errors.New("no notification daemon")
+			})
+			Expect(out.String()).To(ContainSubstring("#2 ✅"))
+		})
+	})
+})
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Defaults for the per-PR enrichment worker pool
+const (
+	defaultConcurrency    = 5
+	defaultRequestTimeout = 30 * time.Second
+)
+
+// runConcurrently calls fn for every index in [0, n) using at most workers goroutines.
+// Callers write results into pre-sized slices by index, so ordering is preserved.
+func runConcurrently(n, workers int, fn func(i int)) {
+	if n == 0 {
+		return
+	}
+	if workers < 1 {
+		workers = 1
+	}
+	if workers > n {
+		workers = n
+	}
+
+	indexes := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range indexes {
+				fn(i)
+			}
+		}()
+	}
+
+	for i := 0; i < n; i++ {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+}
+
+// timeoutRESTClient bounds every JSON request made through it with a timeout
+type timeoutRESTClient struct {
+	RESTClientInterface
+	timeout time.Duration
+}
+
+// withRequestTimeout wraps a client so each request is cancelled after timeout (0 disables the timeout)
+func withRequestTimeout(client RESTClientInterface, timeout time.Duration) RESTClientInterface {
+	if timeout <= 0 {
+		return client
+	}
+	return &timeoutRESTClient{RESTClientInterface: client, timeout: timeout}
+}
+
+// Get performs a GET request bounded by the timeout
+func (c *timeoutRESTClient) Get(path string, response interface{}) error {
+	return c.Do(http.MethodGet, path, nil, response)
+}
+
+// Post performs a POST request bounded by the timeout
+func (c *timeoutRESTClient) Post(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPost, path, body, response)
+}
+
+// Put performs a PUT request bounded by the timeout
+func (c *timeoutRESTClient) Put(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPut, path, body, response)
+}
+
+// Patch performs a PATCH request bounded by the timeout
+func (c *timeoutRESTClient) Patch(path string, body io.Reader, response interface{}) error {
+	return c.Do(http.MethodPatch, path, body, response)
+}
+
+// Delete performs a DELETE request bounded by the timeout
+func (c *timeoutRESTClient) Delete(path string, response interface{}) error {
+	return c.Do(http.MethodDelete, path, nil, response)
+}
+
+// Do performs a request bounded by the timeout
+func (c *timeoutRESTClient) Do(method string, path string, body io.Reader, response interface{}) error {
+	return c.DoWithContext(context.Background(), method, path, body, response)
+}
+
+// DoWithContext performs a request bounded by both the timeout and the caller's context
+func (c *timeoutRESTClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	return c.RESTClientInterface.DoWithContext(ctx, method, path, body, response)
+}
@@ -0,0 +1,114 @@
+package cmd_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/cmd"
+)
+
+// deadlineRecordingClient records whether requests carried a context deadline
+type deadlineRecordingClient struct {
+	*cmd.MockRESTClient
+	mutex     sync.Mutex
+	deadlines []bool
+}
+
+func (c *deadlineRecordingClient) DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error {
+	_, hasDeadline := ctx.Deadline()
+	c.mutex.Lock()
+	c.deadlines = append(c.deadlines, hasDeadline)
+	c.mutex.Unlock()
+	return c.MockRESTClient.DoWithContext(ctx, method, path, body, response)
+}
+
+var _ = Describe("Concurrent Enrichment", func() {
+	Describe("Worker pool", func() {
+		It("should call the function once for every index", func() {
+			var mutex sync.Mutex
+			seen := map[int]int{}
+			cmd.RunConcurrentlyTest(50, 5, func(i int) {
+				mutex.Lock()
+				seen[i]++
+				mutex.Unlock()
+			})
+			Expect(seen).To(HaveLen(50))
+			for i := 0; i < 50; i++ {
+				Expect(seen[i]).To(Equal(1))
+			}
+		})
+
+		It("should never run more than the configured number of workers", func() {
+			var active, maxActive int32
+			cmd.RunConcurrentlyTest(20, 3, func(i int) {
+				current := atomic.AddInt32(&active, 1)
+				for {
+					observed := atomic.LoadInt32(&maxActive)
+					if current <= observed || atomic.CompareAndSwapInt32(&maxActive, observed, current) {
+						break
+					}
+				}
+				time.Sleep(2 * time.Millisecond)
+				atomic.AddInt32(&active, -1)
+			})
+			Expect(maxActive).To(BeNumerically("<=", 3))
+			Expect(maxActive).To(BeNumerically(">", 1))
+		})
+
+		It("should run serially when given fewer than one worker", func() {
+			var order []int
+			cmd.RunConcurrentlyTest(5, 0, func(i int) {
+				order = append(order, i)
+			})
+			Expect(order).To(Equal([]int{0, 1, 2, 3, 4}))
+		})
+
+		It("should handle an empty workload", func() {
+			called := false
+			cmd.RunConcurrentlyTest(0, 5, func(i int) { called = true })
+			Expect(called).To(BeFalse())
+		})
+	})
+
+	Describe("Building rows in parallel", func() {
+		It("should preserve the input order", func() {
+			mockClient := cmd.NewMockRESTClient()
+			prs := cmd.CreateMockPullRequests(12)
+			for _, pr := range prs {
+				mockClient.AddResponse(
+					fmt.Sprintf("repos/owner/repo/pulls/%d/reviews", pr.Number), 200, cmd.CreateMockReviews(false))
+			}
+
+			rows := cmd.BuildPRRowsTest(prs, "owner", "repo", mockClient, false)
+			Expect(rows).To(HaveLen(12))
+			for i, row := range rows {
+				Expect(row.Number).To(Equal(prs[i].Number))
+			}
+		})
+	})
+
+	Describe("Request timeouts", func() {
+		It("should attach a deadline to every request", func() {
+			inner := &deadlineRecordingClient{MockRESTClient: cmd.NewMockRESTClient()}
+			inner.AddResponse("repos/owner/repo/pulls/1", 200, cmd.PullRequest{Number: 1})
+
+			client := cmd.WithRequestTimeoutTest(inner, time.Second)
+			var pr cmd.PullRequest
+			Expect(client.Get("repos/owner/repo/pulls/1", &pr)).To(Succeed())
+			Expect(pr.Number).To(Equal(1))
+			Expect(inner.deadlines).To(Equal([]bool{true}))
+		})
+
+		It("should leave the client untouched when the timeout is disabled", func() {
+			inner := cmd.NewMockRESTClient()
+			Expect(cmd.WithRequestTimeoutTest(inner, 0)).To(BeIdenticalTo(inner))
+		})
+	})
+})
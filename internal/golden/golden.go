@@ -0,0 +1,53 @@
+// Package golden compares rendered output with golden files, so layout changes show up as reviewable diffs
+// of the files under testdata. Run the tests with -update to rewrite the golden files with the current output.
+package golden
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+var update = flag.Bool("update", false, "Rewrite the golden files with the current output")
+
+// Check compares actual with the golden file at path, or writes it there with -update
+func Check(path string, actual []byte) error {
+	if *update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			return err
+		}
+		return os.WriteFile(path, actual, 0644)
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("failed to read golden file (run the tests with -update to create it): %w", err)
+	}
+	if bytes.Equal(expected, actual) {
+		return nil
+	}
+	return fmt.Errorf("output differs from %s (run the tests with -update to accept it):\n%s", path, diffLines(string(expected), string(actual)))
+}
+
+// diffLines lists the lines that differ between expected and actual, as "-" and "+" pairs
+func diffLines(expected, actual string) string {
+	expectedLines := strings.Split(expected, "\n")
+	actualLines := strings.Split(actual, "\n")
+	var diff strings.Builder
+	for i := 0; i < max(len(expectedLines), len(actualLines)); i++ {
+		var want, got string
+		if i < len(expectedLines) {
+			want = expectedLines[i]
+		}
+		if i < len(actualLines) {
+			got = actualLines[i]
+		}
+		if want != got {
+			fmt.Fprintf(&diff, "line %d:\n- %q\n+ %q\n", i+1, want, got)
+		}
+	}
+	return diff.String()
+}
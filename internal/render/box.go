@@ -0,0 +1,94 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// colorYellow frames the boxes that call for attention
+const colorYellow = "\033[33m"
+
+// boxFrame holds the characters a box is drawn with
+type boxFrame struct {
+	topLeft, topRight, bottomLeft, bottomRight, horizontal, vertical string
+}
+
+var (
+	unicodeFrame = boxFrame{"┌", "┐", "└", "┘", "─", "│"}
+	asciiFrame   = boxFrame{"+", "+", "+", "+", "-", "|"}
+)
+
+// WriteBox draws lines in a box headed by title, no wider than width, wrapping the lines that don't fit at
+// spaces. With color the frame is yellow and the title bold. In ASCII mode the frame is drawn with +, - and |.
+func WriteBox(w io.Writer, title string, lines []string, width int, color bool) {
+	frame := unicodeFrame
+	if ASCII() {
+		frame = asciiFrame
+	}
+	title = Symbols(title)
+	inner := max(width-4, 10) // the frame and a space on either side
+
+	var wrapped []string
+	for _, line := range lines {
+		wrapped = append(wrapped, wrapLine(Symbols(line), inner)...)
+	}
+	// Boxes of short notes shrink to fit them
+	contentWidth := DisplayWidth(title) + 2
+	for _, line := range wrapped {
+		contentWidth = max(contentWidth, DisplayWidth(line))
+	}
+	inner = min(inner, contentWidth)
+
+	paint := func(text, style string) string {
+		if !color {
+			return text
+		}
+		return style + text + colorReset
+	}
+	titleRule := strings.Repeat(frame.horizontal, max(inner-DisplayWidth(title)-1, 0))
+	_, _ = fmt.Fprintf(w, "%s %s %s\n",
+		paint(frame.topLeft+frame.horizontal, colorYellow), paint(title, colorBold+colorYellow), paint(titleRule+frame.topRight, colorYellow))
+	for _, line := range wrapped {
+		_, _ = fmt.Fprintf(w, "%s %s %s\n", paint(frame.vertical, colorYellow), PadString(line, inner), paint(frame.vertical, colorYellow))
+	}
+	_, _ = fmt.Fprintf(w, "%s\n", paint(frame.bottomLeft+strings.Repeat(frame.horizontal, inner+2)+frame.bottomRight, colorYellow))
+}
+
+// wrapLine breaks a line at spaces into lines no wider than width, breaking words wider than width where they
+// reach it. An empty line stays an empty line.
+func wrapLine(line string, width int) []string {
+	if DisplayWidth(line) <= width {
+		return []string{line}
+	}
+	var lines []string
+	current := ""
+	for _, word := range strings.Fields(line) {
+		for DisplayWidth(word) > width {
+			if current != "" {
+				lines = append(lines, current)
+				current = ""
+			}
+			runes := []rune(word)
+			cut := 0
+			for cut < len(runes) && DisplayWidth(string(runes[:cut+1])) <= width {
+				cut++
+			}
+			lines = append(lines, string(runes[:cut]))
+			word = string(runes[cut:])
+		}
+		switch {
+		case current == "":
+			current = word
+		case DisplayWidth(current)+1+DisplayWidth(word) <= width:
+			current += " " + word
+		default:
+			lines = append(lines, current)
+			current = word
+		}
+	}
+	if current != "" {
+		lines = append(lines, current)
+	}
+	return lines
+}
@@ -0,0 +1,52 @@
+package render_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/render"
+)
+
+var _ = Describe("WriteBox", func() {
+	AfterEach(func() {
+		render.SetASCII(false)
+	})
+
+	It("should shrink to short notes", func() {
+		out := &bytes.Buffer{}
+		render.WriteBox(out, "Notes", []string{"Add the param.", "", "Done"}, 80, false)
+		Expect(out.String()).To(Equal("" +
+			"┌─ Notes ────────┐\n" +
+			"│ Add the param. │\n" +
+			"│                │\n" +
+			"│ Done           │\n" +
+			"└────────────────┘\n"))
+	})
+
+	It("should wrap long lines at spaces and split long words", func() {
+		out := &bytes.Buffer{}
+		render.WriteBox(out, "N", []string{"update the pipelines first", "https://example.com/a/very/long/guide"}, 20, false)
+		Expect(out.String()).To(Equal("" +
+			"┌─ N ──────────────┐\n" +
+			"│ update the       │\n" +
+			"│ pipelines first  │\n" +
+			"│ https://example. │\n" +
+			"│ com/a/very/long/ │\n" +
+			"│ guide            │\n" +
+			"└──────────────────┘\n"))
+	})
+
+	It("should draw with ASCII and color the frame", func() {
+		render.SetASCII(true)
+		out := &bytes.Buffer{}
+		render.WriteBox(out, "🚨 Notes", []string{"Step"}, 80, false)
+		Expect(out.String()).To(Equal("+- !! Notes -+\n| Step       |\n+------------+\n"))
+
+		render.SetASCII(false)
+		out.Reset()
+		render.WriteBox(out, "Notes", []string{"Step"}, 80, true)
+		Expect(out.String()).To(ContainSubstring("\033[33m│\033[0m Step    \033[33m│\033[0m"))
+	})
+})
@@ -0,0 +1,34 @@
+package render
+
+import (
+	"hash/fnv"
+)
+
+// ANSI color codes shared by the colorized output
+const (
+	colorReset = "\033[0m"
+	colorBold  = "\033[1m"
+	colorDim   = "\033[90m"
+)
+
+// authorColors are the colors authors are told apart by, readable on dark and light terminals
+var authorColors = []string{
+	"\033[31m", // red
+	"\033[32m", // green
+	"\033[33m", // yellow
+	"\033[34m", // blue
+	"\033[35m", // magenta
+	"\033[36m", // cyan
+}
+
+// ColorizeAuthor shows a login in bold in a color of its own, the same on every run
+func ColorizeAuthor(login string) string {
+	hash := fnv.New32a()
+	_, _ = hash.Write([]byte(login))
+	return colorBold + authorColors[hash.Sum32()%uint32(len(authorColors))] + login + colorReset
+}
+
+// Dim shows text in gray, for details such as timestamps
+func Dim(text string) string {
+	return colorDim + text + colorReset
+}
@@ -0,0 +1,85 @@
+package render
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Text columns of the PR table whose width can be configured
+const (
+	ColumnTitle  = "title"
+	ColumnAuthor = "author"
+	ColumnBranch = "branch"
+	ColumnTarget = "target"
+	// ColumnRepo is only shown by the combined table of several repositories
+	ColumnRepo = "repo"
+	// ColumnComponent is only shown for Konflux PRs of repositories mapped to a Konflux component
+	ColumnComponent = "component"
+	// ColumnOwners is only shown when the PRs have code owners
+	ColumnOwners = "owners"
+	// ColumnDependency is only shown when the PRs update dependencies
+	ColumnDependency = "dep"
+)
+
+// ColumnWidthAuto sizes a column to its widest value, so nothing in it is truncated
+const ColumnWidthAuto = "auto"
+
+// defaultColumnWidths are the compact but readable widths used unless configured otherwise
+var defaultColumnWidths = map[string]int{
+	ColumnTitle:      41,
+	ColumnAuthor:     16,
+	ColumnBranch:     14,
+	ColumnTarget:     12,
+	ColumnRepo:       24,
+	ColumnComponent:  20,
+	ColumnOwners:     20,
+	ColumnDependency: 24,
+}
+
+// ValidateColumnWidth checks that column can be resized and that width is "auto" or a positive number
+func ValidateColumnWidth(column, width string) error {
+	if _, ok := defaultColumnWidths[column]; !ok {
+		return fmt.Errorf("unknown column %q (must be one of: %s, %s, %s, %s, %s, %s, %s, %s)", column, ColumnTitle, ColumnAuthor, ColumnBranch, ColumnTarget, ColumnRepo, ColumnComponent, ColumnOwners, ColumnDependency)
+	}
+	if width == ColumnWidthAuto {
+		return nil
+	}
+	if n, err := strconv.Atoi(width); err != nil || n <= 0 {
+		return fmt.Errorf("invalid width %q for column %s (must be a positive number or %s)", width, column, ColumnWidthAuto)
+	}
+	return nil
+}
+
+// ParseColumnWidth parses a "column=width" setting such as "title=auto" or "author=20"
+func ParseColumnWidth(setting string) (string, string, error) {
+	column, width, ok := strings.Cut(setting, "=")
+	if !ok {
+		return "", "", fmt.Errorf("invalid column width %q (must be column=width, e.g. title=auto)", setting)
+	}
+	column, width = strings.ToLower(strings.TrimSpace(column)), strings.ToLower(strings.TrimSpace(width))
+	if err := ValidateColumnWidth(column, width); err != nil {
+		return "", "", err
+	}
+	return column, width, nil
+}
+
+// ColumnWidths maps a text column to its configured width, a number or "auto"
+type ColumnWidths map[string]string
+
+// Width returns the width of a text column: its configured width, the width of its widest value
+// (and header) for "auto", or else its default width
+func (c ColumnWidths) Width(column, header string, values []string) int {
+	configured := c[column]
+	if configured == ColumnWidthAuto {
+		width := DisplayWidth(header)
+		for _, value := range values {
+			width = max(width, DisplayWidth(value))
+		}
+		return width
+	}
+	if width, err := strconv.Atoi(configured); err == nil && width > 0 {
+		return width
+	}
+	return defaultColumnWidths[column]
+}
@@ -0,0 +1,27 @@
+package render_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Column widths", func() {
+	It("should use the default width of a column unless configured", func() {
+		Expect(render.ColumnWidths{}.Width(render.ColumnTitle, "TITLE", nil)).To(Equal(41))
+		Expect(render.ColumnWidths{render.ColumnTitle: "30"}.Width(render.ColumnTitle, "TITLE", nil)).To(Equal(30))
+	})
+
+	It("should fit auto columns to their widest value or header", func() {
+		widths := render.ColumnWidths{render.ColumnAuthor: render.ColumnWidthAuto}
+		Expect(widths.Width(render.ColumnAuthor, "AUTHOR", []string{"me", "red-hat-konflux[bot]"})).To(Equal(20))
+		Expect(widths.Width(render.ColumnAuthor, "AUTHOR", []string{"me"})).To(Equal(6))
+	})
+
+	It("should only accept known columns with a positive width or auto", func() {
+		Expect(render.ValidateColumnWidth(render.ColumnRepo, "auto")).To(Succeed())
+		Expect(render.ValidateColumnWidth("labels", "10")).To(MatchError(ContainSubstring(`unknown column "labels"`)))
+		Expect(render.ValidateColumnWidth(render.ColumnRepo, "-1")).To(HaveOccurred())
+	})
+})
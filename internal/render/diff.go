@@ -0,0 +1,418 @@
+package render
+
+import (
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// Diff modes choose how a diff is laid out
+const (
+	DiffUnified = "unified" // the diff as git shows it, the changed words of changed lines highlighted
+	DiffSplit   = "split"   // the old and the new lines side by side
+	DiffWord    = "word"    // each changed line once, with the removed and added words inline
+)
+
+// ValidateDiffMode checks that a diff mode is supported
+func ValidateDiffMode(mode string) error {
+	switch mode {
+	case DiffUnified, DiffSplit, DiffWord:
+		return nil
+	default:
+		return fmt.Errorf("invalid diff mode %q (must be %s, %s or %s)", mode, DiffUnified, DiffSplit, DiffWord)
+	}
+}
+
+// ANSI colors of diffs
+const (
+	diffRed     = "\033[31m"
+	diffGreen   = "\033[32m"
+	diffYellow  = "\033[33m"
+	diffBlue    = "\033[34m"
+	diffCyan    = "\033[36m"
+	diffWhite   = "\033[37m"
+	diffReverse = "\033[7m"
+)
+
+// ColorizeGitDiff adds ANSI color codes to diff output similar to git diff
+func ColorizeGitDiff(diff string) string {
+	lines := strings.Split(diff, "\n")
+	for i, line := range lines {
+		lines[i] = colorizeDiffLine(line)
+	}
+	return strings.Join(lines, "\n")
+}
+
+// colorizeDiffLine colors a line of a diff by its kind, as git does
+func colorizeDiffLine(line string) string {
+	switch {
+	case strings.HasPrefix(line, "diff --git"):
+		// File header - bold white
+		return colorBold + diffWhite + line + colorReset
+	case strings.HasPrefix(line, "index "):
+		// Index line - dim gray
+		return colorDim + line + colorReset
+	case strings.HasPrefix(line, "--- "):
+		// Old file - red
+		return diffRed + line + colorReset
+	case strings.HasPrefix(line, "+++ "):
+		// New file - green
+		return diffGreen + line + colorReset
+	case strings.HasPrefix(line, "@@"):
+		// Hunk header - cyan
+		return diffCyan + line + colorReset
+	case strings.HasPrefix(line, "+"):
+		// Added lines - green
+		return diffGreen + line + colorReset
+	case strings.HasPrefix(line, "-"):
+		// Removed lines - red
+		return diffRed + line + colorReset
+	case strings.HasPrefix(line, "new file mode"):
+		// New file mode - green
+		return diffGreen + line + colorReset
+	case strings.HasPrefix(line, "deleted file mode"):
+		// Deleted file mode - red
+		return diffRed + line + colorReset
+	case strings.HasPrefix(line, "rename from") || strings.HasPrefix(line, "rename to"):
+		// Rename operations - yellow
+		return diffYellow + line + colorReset
+	case strings.HasPrefix(line, "similarity index") || strings.HasPrefix(line, "dissimilarity index"):
+		// Similarity index - dim gray
+		return colorDim + line + colorReset
+	default:
+		// Context lines - no color
+		return line
+	}
+}
+
+// DiffOptions control how RenderDiff lays out a diff
+type DiffOptions struct {
+	// Mode is one of the diff modes, unified when empty
+	Mode string
+	// Width is the width of the terminal, shared by the two sides in split mode
+	Width int
+	// Color colors the diff and highlights the changed words and, in YAML files, keys and comments.
+	// Without colors, word mode marks changes as [-removed-]{+added+} and split mode with - and +.
+	Color bool
+}
+
+// minSplitWidth is the narrowest a split diff is laid out, even on narrower terminals
+const minSplitWidth = 80
+
+// splitSeparator separates the old and the new side of a split diff
+const splitSeparator = " │ "
+
+// hunkHeaderRE matches a hunk header, capturing the start and length of the old and the new lines
+var hunkHeaderRE = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@`)
+
+// RenderDiff lays out a unified diff, as GitHub returns it for a PR, in a diff mode
+func RenderDiff(diff string, opts DiffOptions) string {
+	r := &diffRenderer{opts: opts}
+	if opts.Mode == DiffSplit {
+		r.sideWidth = (max(opts.Width, minSplitWidth) - DisplayWidth(splitSeparator)) / 2
+	}
+
+	// The lengths in the hunk header tell where a hunk ends, so removed lines such as "-- x" are never
+	// mistaken for the header of the next file
+	var oldLeft, newLeft int
+	for _, line := range strings.Split(diff, "\n") {
+		inHunk := oldLeft > 0 || newLeft > 0
+		switch {
+		case inHunk && strings.HasPrefix(line, "-"):
+			r.removed = append(r.removed, line[1:])
+			oldLeft--
+		case inHunk && strings.HasPrefix(line, "+"):
+			r.added = append(r.added, line[1:])
+			newLeft--
+		case inHunk && (strings.HasPrefix(line, " ") || line == ""):
+			r.flush()
+			r.context(strings.TrimPrefix(line, " "))
+			oldLeft--
+			newLeft--
+		case hunkHeaderRE.MatchString(line):
+			r.flush()
+			match := hunkHeaderRE.FindStringSubmatch(line)
+			r.oldNumber, oldLeft = hunkRange(match[1], match[2])
+			r.newNumber, newLeft = hunkRange(match[3], match[4])
+			r.line(line)
+		default:
+			r.flush()
+			if strings.HasPrefix(line, "diff --git") {
+				// A new file starts, even if the previous hunk was shorter than its header said
+				oldLeft, newLeft = 0, 0
+			}
+			if file, ok := strings.CutPrefix(line, "+++ "); ok {
+				r.yaml = isYAMLFile(file)
+			}
+			r.line(line)
+		}
+	}
+	r.flush()
+	return strings.Join(r.out, "\n")
+}
+
+// hunkRange parses the start and length of a side of a hunk, the length defaulting to 1
+func hunkRange(start, length string) (int, int) {
+	first, _ := strconv.Atoi(start)
+	count := 1
+	if length != "" {
+		count, _ = strconv.Atoi(length)
+	}
+	return first, count
+}
+
+// isYAMLFile reports whether a file of a diff header, such as b/.tekton/push.yaml, is YAML
+func isYAMLFile(file string) bool {
+	ext := path.Ext(strings.TrimSpace(file))
+	return ext == ".yaml" || ext == ".yml"
+}
+
+// styledText is text shown in one style, an ANSI color sequence or none
+type styledText struct {
+	text  string
+	style string
+}
+
+// diffRenderer lays out a diff line by line, holding back removed and added lines until the block of
+// changes ends so they can be paired up
+type diffRenderer struct {
+	opts      DiffOptions
+	sideWidth int
+	// yaml is set while the lines of a YAML file are rendered
+	yaml      bool
+	oldNumber int
+	newNumber int
+	removed   []string
+	added     []string
+	out       []string
+}
+
+// paint joins segments, coloring them when colors are on
+func (r *diffRenderer) paint(segments []styledText) string {
+	var b strings.Builder
+	for _, segment := range segments {
+		if r.opts.Color && segment.style != "" && segment.text != "" {
+			b.WriteString(segment.style + segment.text + colorReset)
+		} else {
+			b.WriteString(segment.text)
+		}
+	}
+	return b.String()
+}
+
+// line shows a line outside of the hunk bodies, such as a file or hunk header, across the whole width
+func (r *diffRenderer) line(line string) {
+	if r.opts.Color {
+		line = colorizeDiffLine(line)
+	}
+	r.out = append(r.out, line)
+}
+
+// context shows an unchanged line
+func (r *diffRenderer) context(text string) {
+	if r.opts.Mode == DiffSplit {
+		segments := r.syntax(expandTabs(text))
+		r.splitRow(r.splitCell(r.oldNumber, " ", "", segments), r.splitCell(r.newNumber, " ", "", segments))
+	} else {
+		r.out = append(r.out, " "+r.paint(r.syntax(text)))
+	}
+	r.oldNumber++
+	r.newNumber++
+}
+
+// yamlKeyRE matches a YAML line with a key, capturing the indentation with any list dash, the key and the rest
+var yamlKeyRE = regexp.MustCompile(`^(\s*(?:-\s+)?)([^\s#:'"][^:#]*|"[^"]*"|'[^']*')(:(?:\s.*)?)$`)
+
+// syntax splits an unchanged line of a YAML file into its key, comment and the rest, so they can be told
+// apart when colored. Other lines are one plain segment.
+func (r *diffRenderer) syntax(text string) []styledText {
+	if !r.yaml || !r.opts.Color {
+		return []styledText{{text: text}}
+	}
+	if trimmed := strings.TrimLeft(text, " \t"); strings.HasPrefix(trimmed, "#") {
+		return []styledText{{text: text[:len(text)-len(trimmed)]}, {text: trimmed, style: colorDim}}
+	}
+	if match := yamlKeyRE.FindStringSubmatch(text); match != nil {
+		return []styledText{{text: match[1]}, {text: match[2], style: diffBlue}, {text: match[3]}}
+	}
+	return []styledText{{text: text}}
+}
+
+// flush shows the block of removed and added lines held back, pairing the nth removed line with the nth
+// added line to highlight the words that changed between them
+func (r *diffRenderer) flush() {
+	removed, added := r.removed, r.added
+	r.removed, r.added = nil, nil
+	if len(removed) == 0 && len(added) == 0 {
+		return
+	}
+
+	pairs := min(len(removed), len(added))
+	edits := make([][]wordEdit, pairs)
+	for i := range pairs {
+		if pairEdits := diffWords(splitWords(removed[i]), splitWords(added[i])); similarEnough(pairEdits) {
+			edits[i] = pairEdits
+		}
+	}
+
+	switch r.opts.Mode {
+	case DiffSplit:
+		r.flushSplit(removed, added, edits)
+	case DiffWord:
+		r.flushWord(removed, added, edits)
+	default:
+		r.flushUnified(removed, added, edits)
+	}
+}
+
+// flushUnified shows the removed lines and then the added lines, as git does
+func (r *diffRenderer) flushUnified(removed, added []string, edits [][]wordEdit) {
+	for i, text := range removed {
+		r.out = append(r.out, r.paint(append([]styledText{{text: "-", style: diffRed}}, r.changedLine(text, edits, i, '-', diffRed)...)))
+		r.oldNumber++
+	}
+	for i, text := range added {
+		r.out = append(r.out, r.paint(append([]styledText{{text: "+", style: diffGreen}}, r.changedLine(text, edits, i, '+', diffGreen)...)))
+		r.newNumber++
+	}
+}
+
+// flushWord shows each pair of similar lines once, with the removed and the added words inline
+func (r *diffRenderer) flushWord(removed, added []string, edits [][]wordEdit) {
+	for i := range max(len(removed), len(added)) {
+		if i < len(edits) && edits[i] != nil {
+			segments := []styledText{{text: "~", style: diffYellow}}
+			for _, edit := range edits[i] {
+				switch {
+				case edit.op == '=':
+					segments = append(segments, styledText{text: edit.text})
+				case r.opts.Color && edit.op == '-':
+					segments = append(segments, styledText{text: edit.text, style: diffRed + diffReverse})
+				case r.opts.Color:
+					segments = append(segments, styledText{text: edit.text, style: diffGreen + diffReverse})
+				case edit.op == '-':
+					segments = append(segments, styledText{text: "[-" + edit.text + "-]"})
+				default:
+					segments = append(segments, styledText{text: "{+" + edit.text + "+}"})
+				}
+			}
+			r.out = append(r.out, r.paint(segments))
+			continue
+		}
+		if i < len(removed) {
+			r.out = append(r.out, r.paint([]styledText{{text: "-" + removed[i], style: diffRed}}))
+		}
+		if i < len(added) {
+			r.out = append(r.out, r.paint([]styledText{{text: "+" + added[i], style: diffGreen}}))
+		}
+	}
+	r.oldNumber += len(removed)
+	r.newNumber += len(added)
+}
+
+// flushSplit shows the removed lines on the left and the added lines on the right, row by row
+func (r *diffRenderer) flushSplit(removed, added []string, edits [][]wordEdit) {
+	for i := range max(len(removed), len(added)) {
+		var left, right []string
+		if i < len(removed) {
+			left = r.splitCell(r.oldNumber, "-", diffRed, r.changedLine(expandTabs(removed[i]), edits, i, '-', diffRed))
+			r.oldNumber++
+		}
+		if i < len(added) {
+			right = r.splitCell(r.newNumber, "+", diffGreen, r.changedLine(expandTabs(added[i]), edits, i, '+', diffGreen))
+			r.newNumber++
+		}
+		r.splitRow(left, right)
+	}
+}
+
+// splitRow shows the old and the new side of a row of a split diff next to each other, leaving a side
+// that has fewer lines blank
+func (r *diffRenderer) splitRow(left, right []string) {
+	blank := strings.Repeat(" ", r.sideWidth)
+	for i := range max(len(left), len(right)) {
+		oldSide, newSide := blank, blank
+		if i < len(left) {
+			oldSide = left[i]
+		}
+		if i < len(right) {
+			newSide = right[i]
+		}
+		r.out = append(r.out, strings.TrimRight(oldSide+splitSeparator+newSide, " "))
+	}
+}
+
+// changedLine returns the segments of the ith removed (op -) or added (op +) line, the changed words
+// highlighted when the line is paired with a similar one
+func (r *diffRenderer) changedLine(text string, edits [][]wordEdit, i int, op byte, style string) []styledText {
+	if i >= len(edits) || edits[i] == nil {
+		return []styledText{{text: text, style: style}}
+	}
+	var segments []styledText
+	for _, edit := range edits[i] {
+		switch edit.op {
+		case '=':
+			segments = append(segments, styledText{text: edit.text, style: style})
+		case op:
+			segments = append(segments, styledText{text: edit.text, style: style + diffReverse})
+		}
+	}
+	if r.opts.Mode == DiffSplit {
+		// The words were split before the tabs were expanded
+		for j := range segments {
+			segments[j].text = expandTabs(segments[j].text)
+		}
+	}
+	return segments
+}
+
+// splitLinePrefixWidth is the width of the line number and the marker in front of a split diff line
+const splitLinePrefixWidth = 7
+
+// splitCell lays out one side of a row of a split diff: the line number, the marker and the text,
+// wrapped onto as many lines as it needs so that nothing that changed is cut off
+func (r *diffRenderer) splitCell(number int, marker, style string, segments []styledText) []string {
+	var lines []string
+	for i, wrapped := range wrapText(segments, r.sideWidth-splitLinePrefixWidth) {
+		prefix := []styledText{{text: fmt.Sprintf("%4d ", number), style: colorDim}, {text: marker + " ", style: style}}
+		if i > 0 {
+			prefix = []styledText{{text: strings.Repeat(" ", splitLinePrefixWidth)}}
+		}
+		lines = append(lines, r.paint(append(prefix, wrapped...)))
+	}
+	return lines
+}
+
+// wrapText breaks segments into lines of width columns, padding each line to exactly width
+func wrapText(segments []styledText, width int) [][]styledText {
+	var lines [][]styledText
+	var line []styledText
+	used := 0
+	endLine := func() {
+		lines = append(lines, append(line, styledText{text: strings.Repeat(" ", width-used)}))
+		line, used = nil, 0
+	}
+	for _, segment := range segments {
+		var b strings.Builder
+		for _, r := range segment.text {
+			runeWidth := DisplayWidth(string(r))
+			if used+runeWidth > width && used > 0 {
+				line = append(line, styledText{text: b.String(), style: segment.style})
+				b.Reset()
+				endLine()
+			}
+			b.WriteRune(r)
+			used += runeWidth
+		}
+		line = append(line, styledText{text: b.String(), style: segment.style})
+	}
+	endLine()
+	return lines
+}
+
+// expandTabs replaces tabs with four spaces, so the width of split diff lines is known
+func expandTabs(text string) string {
+	return strings.ReplaceAll(text, "\t", "    ")
+}
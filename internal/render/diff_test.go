@@ -0,0 +1,68 @@
+package render_test
+
+import (
+	"os"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/golden"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("ColorizeGitDiff", func() {
+	It("should color each kind of diff line like git", func() {
+		diff, err := os.ReadFile("testdata/sample.diff")
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(golden.Check("testdata/sample.diff.golden", []byte(render.ColorizeGitDiff(string(diff))))).To(Succeed())
+	})
+})
+
+var _ = Describe("RenderDiff", func() {
+	var diff string
+
+	BeforeEach(func() {
+		content, err := os.ReadFile("testdata/sample.diff")
+		Expect(err).NotTo(HaveOccurred())
+		diff = string(content)
+	})
+
+	DescribeTable("should lay out the diff in each mode",
+		func(mode string, color bool, goldenFile string) {
+			rendered := render.RenderDiff(diff, render.DiffOptions{Mode: mode, Width: 100, Color: color})
+			Expect(golden.Check(goldenFile, []byte(rendered))).To(Succeed())
+		},
+		Entry("unified", render.DiffUnified, true, "testdata/sample.diff.unified.golden"),
+		Entry("word", render.DiffWord, true, "testdata/sample.diff.word.golden"),
+		Entry("word without colors", render.DiffWord, false, "testdata/sample.diff.word-plain.golden"),
+		Entry("split", render.DiffSplit, true, "testdata/sample.diff.split.golden"),
+		Entry("split without colors", render.DiffSplit, false, "testdata/sample.diff.split-plain.golden"),
+	)
+
+	It("should leave a unified diff without colors as it is", func() {
+		Expect(render.RenderDiff(diff, render.DiffOptions{Mode: render.DiffUnified})).To(Equal(diff))
+	})
+
+	It("should only merge lines that have enough in common", func() {
+		changed := "@@ -1,2 +1,2 @@\n-image: quay.io/app:v1\n-replicas: 1\n+image: quay.io/app:v2\n+something else entirely\n"
+		Expect(render.RenderDiff(changed, render.DiffOptions{Mode: render.DiffWord})).To(Equal(
+			"@@ -1,2 +1,2 @@\n~image: quay.io/app:[-v1-]{+v2+}\n-replicas: 1\n+something else entirely\n"))
+	})
+
+	It("should not mistake removed and added lines for file headers", func() {
+		changed := "@@ -1 +1 @@\n--- a\n+++ b\n"
+		rendered := render.RenderDiff(changed, render.DiffOptions{Mode: render.DiffSplit})
+		Expect(rendered).To(HavePrefix("@@ -1 +1 @@\n   1 - -- a "))
+		Expect(rendered).To(HaveSuffix("│    1 + ++ b\n"))
+	})
+})
+
+var _ = Describe("ValidateDiffMode", func() {
+	It("should accept the diff modes and reject others", func() {
+		for _, mode := range []string{render.DiffUnified, render.DiffSplit, render.DiffWord} {
+			Expect(render.ValidateDiffMode(mode)).To(Succeed())
+		}
+		Expect(render.ValidateDiffMode("side-by-side")).To(MatchError(ContainSubstring(`invalid diff mode "side-by-side"`)))
+	})
+})
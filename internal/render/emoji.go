@@ -0,0 +1,94 @@
+package render
+
+import (
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Emoji modes control whether the tables show emoji or ASCII tokens in their place
+const (
+	EmojiAuto   = "auto"   // emoji unless the terminal probably can't draw them
+	EmojiAlways = "always" // always emoji
+	EmojiNever  = "never"  // always ASCII tokens
+)
+
+// ValidateEmojiMode checks that an emoji mode is supported
+func ValidateEmojiMode(mode string) error {
+	switch mode {
+	case EmojiAuto, EmojiAlways, EmojiNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid emoji mode %q (must be %s, %s or %s)", mode, EmojiAuto, EmojiAlways, EmojiNever)
+	}
+}
+
+// asciiSymbols is set when emoji are replaced with ASCII tokens
+var asciiSymbols atomic.Bool
+
+// SetASCII replaces the emoji of tables and legends with ASCII tokens from now on, or stops doing so
+func SetASCII(ascii bool) {
+	asciiSymbols.Store(ascii)
+}
+
+// ASCII reports whether emoji are replaced with ASCII tokens
+func ASCII() bool {
+	return asciiSymbols.Load()
+}
+
+// emojiFallbacks replaces every emoji shown in a table or legend with a token of at most two characters,
+// so the fixed-width columns keep their width. Variation selectors go first so the emoji they follow match.
+var emojiFallbacks = strings.NewReplacer(
+	"\uFE0F", "",
+	"🟢", "o",
+	"🟡", "d",
+	"🔶", "h",
+	"🔴", "c",
+	"🟣", "m",
+	"🟠", "!",
+	"⚪", "-",
+	"✅", "+",
+	"❌", "x",
+	"🔄", "R",
+	"🚫", "B",
+	"👉", "N",
+	"🔒", "S",
+	"🚨", "!!",
+	"🆕", "*",
+	"🧊", "F",
+	"👀", "?",
+	"⚠", "!",
+	"🐌", "~",
+	"📖", ">",
+)
+
+// Symbols returns s with its emoji replaced by ASCII tokens when that is enabled
+func Symbols(s string) string {
+	if !ASCII() {
+		return s
+	}
+	return emojiFallbacks.Replace(s)
+}
+
+// EmojiLikelySupported guesses from the environment whether the terminal draws emoji two columns wide.
+// The Linux console and old terminals have no emoji glyphs, and a host without a UTF-8 locale (common over
+// SSH to older hosts) shows them as replacement boxes of the wrong width.
+func EmojiLikelySupported(getenv func(string) string) bool {
+	switch term := getenv("TERM"); {
+	case term == "linux", term == "dumb", term == "cons25", strings.HasPrefix(term, "vt1"), strings.HasPrefix(term, "vt2"):
+		return false
+	}
+
+	locale := ""
+	for _, name := range []string{"LC_ALL", "LC_CTYPE", "LANG"} {
+		if locale = getenv(name); locale != "" {
+			break
+		}
+	}
+	if locale == "" {
+		// Without any locale only a remote login is suspicious, local terminals usually default to UTF-8
+		return getenv("SSH_CONNECTION") == "" && getenv("SSH_TTY") == ""
+	}
+	normalized := strings.ToLower(strings.ReplaceAll(locale, "-", ""))
+	return strings.Contains(normalized, "utf8")
+}
@@ -0,0 +1,59 @@
+package render_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/golden"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Emoji fallback", func() {
+	AfterEach(func() {
+		render.SetASCII(false)
+	})
+
+	It("should leave emoji alone unless ASCII tokens are enabled", func() {
+		Expect(render.Symbols("🟢 ✅ 🚨")).To(Equal("🟢 ✅ 🚨"))
+		render.SetASCII(true)
+		Expect(render.Symbols("🟢 ✅ 🚨 ⚠️ READY")).To(Equal("o + !! ! READY"))
+	})
+
+	It("should keep the columns of a table aligned with ASCII tokens", func() {
+		render.SetASCII(true)
+		out := &bytes.Buffer{}
+		table := render.NewTable(
+			render.Column{Header: "ST", Width: 2},
+			render.Column{Header: "STATUS", Width: 17},
+			render.Column{Header: "SECURITY", Width: 8},
+		)
+		table.AddRow("🟢", "✅ READY", "🔒")
+		table.AddRow("🔶", "🔶 ON_HOLD 🚨", "")
+		table.AddRow("🟡", "❌ CHECKS_FAILING", "🔒")
+		table.Write(out)
+		render.WriteReadinessLegend(out, true)
+
+		Expect(golden.Check("testdata/table-ascii.golden", out.Bytes())).To(Succeed())
+	})
+
+	DescribeTable("guessing whether the terminal draws emoji",
+		func(env map[string]string, supported bool) {
+			Expect(render.EmojiLikelySupported(func(name string) string { return env[name] })).To(Equal(supported))
+		},
+		Entry("a UTF-8 terminal", map[string]string{"TERM": "xterm-256color", "LANG": "en_US.UTF-8"}, true),
+		Entry("a UTF-8 locale spelled utf8", map[string]string{"TERM": "screen", "LC_ALL": "de_DE.utf8"}, true),
+		Entry("a local terminal without a locale", map[string]string{"TERM": "xterm"}, true),
+		Entry("the Linux console", map[string]string{"TERM": "linux", "LANG": "en_US.UTF-8"}, false),
+		Entry("a VT100", map[string]string{"TERM": "vt100"}, false),
+		Entry("a non UTF-8 locale", map[string]string{"TERM": "xterm", "LANG": "en_US.ISO-8859-1"}, false),
+		Entry("LC_ALL overriding LANG", map[string]string{"TERM": "xterm", "LC_ALL": "C", "LANG": "en_US.UTF-8"}, false),
+		Entry("SSH without a locale", map[string]string{"TERM": "xterm", "SSH_CONNECTION": "10.0.0.1 22 10.0.0.2 22"}, false),
+	)
+
+	It("should validate modes", func() {
+		Expect(render.ValidateEmojiMode(render.EmojiNever)).To(Succeed())
+		Expect(render.ValidateEmojiMode("sometimes")).To(HaveOccurred())
+	})
+})
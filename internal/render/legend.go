@@ -0,0 +1,110 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"sync"
+)
+
+// Legend modes control when the table legend is shown
+const (
+	LegendOnce   = "once"   // before the first table of a run
+	LegendAlways = "always" // before every table
+	LegendNever  = "never"
+)
+
+// ValidateLegendMode checks that a legend mode is supported
+func ValidateLegendMode(mode string) error {
+	switch mode {
+	case LegendOnce, LegendAlways, LegendNever:
+		return nil
+	default:
+		return fmt.Errorf("invalid legend mode %q (must be %s, %s or %s)", mode, LegendOnce, LegendAlways, LegendNever)
+	}
+}
+
+// Legend tracks whether the legend is due before the next table
+type Legend struct {
+	mutex sync.Mutex
+	mode  string
+	shown bool
+}
+
+// NewLegend returns a legend shown according to mode
+func NewLegend(mode string) *Legend {
+	return &Legend{mode: mode}
+}
+
+// Reset starts a new run in which the legend is shown according to mode
+func (l *Legend) Reset(mode string) {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	l.mode = mode
+	l.shown = false
+}
+
+// Take reports whether the legend should be shown before the next table and records that it was
+func (l *Legend) Take() bool {
+	l.mutex.Lock()
+	defer l.mutex.Unlock()
+	switch l.mode {
+	case LegendNever:
+		return false
+	case LegendAlways:
+		return true
+	default:
+		if l.shown {
+			return false
+		}
+		l.shown = true
+		return true
+	}
+}
+
+// WriteLegend explains what the various emojis and symbols of the detailed PR table mean
+func WriteLegend(w io.Writer, konflux bool) {
+	lines := []string{
+		"\nLegend:",
+		"  Status: 🟢 open  🟡 draft  🔶 on hold  🔴 closed  🟣 merged",
+		"  Reviewed: ✅ approved  ❌ not approved  - labels only (fast mode)",
+		"  Rebase: 🔄 needs rebase  ? unknown  - skipped (fast mode)  (empty = up to date)",
+		"  Blocked: 🚫 blocked from merging  ? unknown  - skipped (fast mode)  (empty = not blocked)",
+		"  Nudge: 👉 konflux nudge PR  (empty = not a nudge)",
+		"  Security: 🔒 security/CVE update  (empty = not security)",
+		"  Age: time since opened  🐌 stale (no update within display.stale_after, default 14d)",
+		"  Size: lines changed XS <10  S <30  M <100  L <500  XL  ? unknown  - skipped (fast mode)",
+	}
+	if konflux {
+		lines = append(lines,
+			"  Tekton: ✅ exclusively Tekton files  ❌ mixed/other files  - skipped (fast mode)",
+			"  🚨 = migration warning")
+	}
+	lines = append(lines, "  🆕 = new or updated since last listed", "  err = could not be determined, the failed lookups are listed under the table")
+	writeLines(w, lines)
+}
+
+// WriteReadinessLegend explains the readiness view
+func WriteReadinessLegend(w io.Writer, konflux bool) {
+	lines := []string{
+		"\nLegend:",
+		"  Status: 🟢 open  🟡 draft  🔶 on hold  🔴 closed  🟣 merged",
+		"  Readiness (first that applies): 🔶 ON_HOLD  🧊 FROZEN (draft/do-not-merge)  🔄 NEEDS_REBASE",
+		"             ❌ CHECKS_FAILING  👀 NEEDS_REVIEW  🚫 BLOCKED  ✅ READY",
+		"  Security: 🔒 security/CVE update  (empty = not security)",
+		"  Age: time since opened  🐌 stale (no update within display.stale_after, default 14d)",
+		"  Size: lines changed XS <10  S <30  M <100  L <500  XL  ? unknown  - skipped (fast mode)",
+	}
+	if konflux {
+		lines = append(lines, "  Tekton: ✅ exclusively Tekton files  ❌ mixed/other files  - skipped (fast mode)")
+	}
+	lines = append(lines, "  🆕 = new or updated since last listed", "  err = could not be determined, the failed lookups are listed under the table")
+	writeLines(w, lines)
+}
+
+// writeLines writes each line followed by an empty line, with ASCII tokens for emoji when enabled
+func writeLines(w io.Writer, lines []string) {
+	for _, line := range lines {
+		_, _ = fmt.Fprintln(w, Symbols(line))
+	}
+	_, _ = fmt.Fprintln(w)
+}
@@ -0,0 +1,43 @@
+package render_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/golden"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Legend", func() {
+	DescribeTable("rendering",
+		func(write func(*bytes.Buffer), path string) {
+			out := &bytes.Buffer{}
+			write(out)
+			Expect(golden.Check(path, out.Bytes())).To(Succeed())
+		},
+		Entry("detailed", func(out *bytes.Buffer) { render.WriteLegend(out, false) }, "testdata/legend.golden"),
+		Entry("detailed Konflux", func(out *bytes.Buffer) { render.WriteLegend(out, true) }, "testdata/legend-konflux.golden"),
+		Entry("readiness", func(out *bytes.Buffer) { render.WriteReadinessLegend(out, false) }, "testdata/legend-readiness.golden"),
+		Entry("readiness Konflux", func(out *bytes.Buffer) { render.WriteReadinessLegend(out, true) }, "testdata/legend-readiness-konflux.golden"),
+	)
+
+	It("should be due once per run, always or never according to its mode", func() {
+		legend := render.NewLegend(render.LegendOnce)
+		Expect(legend.Take()).To(BeTrue())
+		Expect(legend.Take()).To(BeFalse())
+
+		legend.Reset(render.LegendAlways)
+		Expect(legend.Take()).To(BeTrue())
+		Expect(legend.Take()).To(BeTrue())
+
+		legend.Reset(render.LegendNever)
+		Expect(legend.Take()).To(BeFalse())
+	})
+
+	It("should validate modes", func() {
+		Expect(render.ValidateLegendMode(render.LegendAlways)).To(Succeed())
+		Expect(render.ValidateLegendMode("sometimes")).To(MatchError(ContainSubstring(`invalid legend mode "sometimes"`)))
+	})
+})
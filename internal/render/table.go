@@ -0,0 +1,73 @@
+package render
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Column is a column of a table
+type Column struct {
+	Header string
+	// Width is the display width of the column. A column of width 0 is left out, for columns only some
+	// tables have, such as the REPO column of the combined table.
+	Width int
+	// Truncate shortens longer values with an ellipsis; otherwise they overflow into the next column
+	Truncate bool
+}
+
+// Table lays out rows in aligned columns below a header and a separator line
+type Table struct {
+	columns []Column
+	rows    [][]string
+}
+
+// NewTable returns an empty table with columns
+func NewTable(columns ...Column) *Table {
+	return &Table{columns: columns}
+}
+
+// AddRow adds a row with a cell for every column of the table, including those left out
+func (t *Table) AddRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// Write writes the table to w. Cells are padded to the width of their column and separated by a space,
+// without trailing spaces, and their emoji are replaced with ASCII tokens when that is enabled.
+func (t *Table) Write(w io.Writer) {
+	headers := make([]string, len(t.columns))
+	separators := make([]string, len(t.columns))
+	for i, column := range t.columns {
+		headers[i] = column.Header
+		separators[i] = strings.Repeat("-", column.Width)
+	}
+	t.writeLine(w, headers, false)
+	t.writeLine(w, separators, false)
+	for _, row := range t.rows {
+		t.writeLine(w, row, true)
+	}
+}
+
+// writeLine writes the cells of one line, truncating them where their column says so when truncate is set
+func (t *Table) writeLine(w io.Writer, cells []string, truncate bool) {
+	var line strings.Builder
+	first := true
+	for i, column := range t.columns {
+		if column.Width == 0 {
+			continue
+		}
+		cell := ""
+		if i < len(cells) {
+			cell = Symbols(cells[i])
+		}
+		if truncate && column.Truncate {
+			cell = TruncateString(cell, column.Width)
+		}
+		if !first {
+			line.WriteString(" ")
+		}
+		first = false
+		line.WriteString(PadString(cell, column.Width))
+	}
+	_, _ = fmt.Fprintln(w, strings.TrimRight(line.String(), " "))
+}
@@ -0,0 +1,55 @@
+package render_test
+
+import (
+	"bytes"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/internal/golden"
+	"ghprs/internal/render"
+)
+
+var _ = Describe("Table", func() {
+	var out *bytes.Buffer
+
+	BeforeEach(func() {
+		out = &bytes.Buffer{}
+	})
+
+	It("should align cells with emojis and links and truncate where asked", func() {
+		table := render.NewTable(
+			render.Column{Header: "ST", Width: 2},
+			render.Column{Header: "PR", Width: 6},
+			render.Column{Header: "TITLE", Width: 20, Truncate: true},
+			render.Column{Header: "STATUS", Width: 10},
+			render.Column{Header: "NOTE", Width: 4},
+		)
+		table.AddRow("🟢", "#1", "Short title", "open", "")
+		table.AddRow("🔶", "\033]8;;https://github.com/o/r/pull/22\033\\#22\033]8;;\033\\", "A title much too long for the column", "on hold 🚨", "overflowing note")
+		table.AddRow("🟡", "#333", "Café ☕ update", "draft", "✅")
+		table.Write(out)
+
+		Expect(golden.Check("testdata/table.golden", out.Bytes())).To(Succeed())
+	})
+
+	It("should leave out columns of width 0 with their cells", func() {
+		table := render.NewTable(
+			render.Column{Header: "REPO", Width: 0},
+			render.Column{Header: "PR", Width: 4},
+			render.Column{Header: "TITLE", Width: 8},
+		)
+		table.AddRow("owner/repo", "#1", "Title")
+		table.Write(out)
+
+		Expect(out.String()).To(Equal("PR   TITLE\n---- --------\n#1   Title\n"))
+	})
+
+	It("should not leave trailing spaces", func() {
+		table := render.NewTable(render.Column{Header: "A", Width: 5}, render.Column{Header: "B", Width: 5})
+		table.AddRow("x", "")
+		table.Write(out)
+
+		Expect(out.String()).To(Equal("A     B\n----- -----\nx\n"))
+	})
+})
@@ -0,0 +1,134 @@
+// Package render lays out what ghprs shows in the terminal: tables, the legend, colorized diffs and the
+// display width of text with emojis and escape sequences.
+package render
+
+import (
+	"strings"
+)
+
+// TruncateString truncates a string to a maximum display width with ellipsis
+func TruncateString(s string, maxWidth int) string {
+	if DisplayWidth(s) <= maxWidth {
+		return s
+	}
+	if maxWidth <= 3 {
+		// If maxWidth is very small, just truncate by runes
+		runes := []rune(s)
+		if len(runes) <= maxWidth {
+			return s
+		}
+		return string(runes[:maxWidth])
+	}
+
+	// Truncate to fit within maxWidth - 3 (for "...")
+	targetWidth := maxWidth - 3
+	runes := []rune(s)
+	currentWidth := 0
+
+	for i, r := range runes {
+		charWidth := 1
+		if r >= 0x1F600 && r <= 0x1F64F || // Emoticons
+			r >= 0x1F300 && r <= 0x1F5FF || // Misc Symbols and Pictographs
+			r >= 0x1F680 && r <= 0x1F6FF || // Transport and Map
+			r >= 0x1F1E0 && r <= 0x1F1FF || // Regional indicators
+			r >= 0x2600 && r <= 0x26FF || // Misc symbols
+			r >= 0x2700 && r <= 0x27BF { // Dingbats
+			charWidth = 2
+		}
+
+		if currentWidth+charWidth > targetWidth {
+			return string(runes[:i]) + "..."
+		}
+		currentWidth += charWidth
+	}
+
+	return s
+}
+
+// DisplayWidth calculates the visual width of a string in the terminal
+func DisplayWidth(s string) int {
+	// Remove ANSI escape sequences (including OSC 8 sequences for links)
+	cleanString := StripANSISequences(s)
+
+	width := 0
+	for _, r := range cleanString {
+		// Most emojis and some Unicode characters take 2 character widths
+		if r >= 0x1F600 && r <= 0x1F64F || // Emoticons
+			r >= 0x1F300 && r <= 0x1F5FF || // Misc Symbols and Pictographs
+			r >= 0x1F680 && r <= 0x1F6FF || // Transport and Map
+			r >= 0x1F7E0 && r <= 0x1F7EB || // Geometric Shapes Extended (colored circles)
+			r >= 0x1F1E0 && r <= 0x1F1FF || // Regional indicators
+			r >= 0x2600 && r <= 0x26FF || // Misc symbols
+			r >= 0x2700 && r <= 0x27BF || // Dingbats
+			r == 0x200D || // Zero width joiner
+			r >= 0xFE0F && r <= 0xFE0F { // Variation selectors
+			width += 2
+		} else if r >= 0x20 { // Printable ASCII and most Unicode
+			width += 1
+		}
+		// Control characters (< 0x20) don't add width
+	}
+	return width
+}
+
+// StripANSISequences removes ANSI escape sequences from a string
+func StripANSISequences(s string) string {
+	result := strings.Builder{}
+	i := 0
+	runes := []rune(s)
+
+	for i < len(runes) {
+		if runes[i] == '\033' && i+1 < len(runes) { // ESC character
+			i++ // Skip the ESC
+
+			if i < len(runes) && runes[i] == ']' { // OSC sequence (like ]8;;URL\033\\)
+				i++ // Skip the ]
+				// Skip everything until we find the terminator
+				for i < len(runes) {
+					if runes[i] == '\007' { // BEL terminator
+						i++
+						break
+					} else if runes[i] == '\033' && i+1 < len(runes) && runes[i+1] == '\\' { // ST terminator
+						i += 2 // Skip \033\
+						break
+					}
+					i++
+				}
+			} else if i < len(runes) && runes[i] == '[' { // CSI sequence (like [31m)
+				i++ // Skip the [
+				// Skip until we find the final byte (@ to ~)
+				for i < len(runes) {
+					if runes[i] >= 0x40 && runes[i] <= 0x7E {
+						i++
+						break
+					}
+					i++
+				}
+			} else {
+				// Other escape sequences, skip until final byte
+				for i < len(runes) {
+					if runes[i] >= 0x40 && runes[i] <= 0x7E {
+						i++
+						break
+					}
+					i++
+				}
+			}
+		} else {
+			result.WriteRune(runes[i])
+			i++
+		}
+	}
+
+	return result.String()
+}
+
+// PadString pads a string to a specific width, accounting for actual display width
+func PadString(s string, width int) string {
+	currentWidth := DisplayWidth(s)
+	if currentWidth >= width {
+		return s
+	}
+	padding := width - currentWidth
+	return s + strings.Repeat(" ", padding)
+}
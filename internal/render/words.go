@@ -0,0 +1,121 @@
+package render
+
+import (
+	"strings"
+	"unicode"
+)
+
+// maxWordDiffCells bounds the work of comparing two lines word by word; longer lines are shown as changed
+// as a whole
+const maxWordDiffCells = 1 << 16
+
+// wordEdit is a run of words of a pair of lines that is unchanged (op =), removed (op -) or added (op +)
+type wordEdit struct {
+	op   byte
+	text string
+}
+
+// splitWords splits a line into words, runs of whitespace and single punctuation characters, so that
+// changes such as a bumped version or digest are found inside of longer tokens
+func splitWords(line string) []string {
+	var words []string
+	start := -1
+	class := 0
+	classOf := func(r rune) int {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_':
+			return 1
+		case unicode.IsSpace(r):
+			return 2
+		default:
+			return 3
+		}
+	}
+	for i, r := range line {
+		runeClass := classOf(r)
+		if start >= 0 && (runeClass != class || runeClass == 3) {
+			words = append(words, line[start:i])
+			start = -1
+		}
+		if start < 0 {
+			start, class = i, runeClass
+		}
+	}
+	if start >= 0 {
+		words = append(words, line[start:])
+	}
+	return words
+}
+
+// diffWords compares two lines word by word, keeping their longest common subsequence of words
+func diffWords(old, new []string) []wordEdit {
+	if len(old)*len(new) > maxWordDiffCells {
+		return []wordEdit{{op: '-', text: strings.Join(old, "")}, {op: '+', text: strings.Join(new, "")}}
+	}
+
+	// lengths[i][j] is the length of the longest common subsequence of old[i:] and new[j:]
+	lengths := make([][]int, len(old)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(new)+1)
+	}
+	for i := len(old) - 1; i >= 0; i-- {
+		for j := len(new) - 1; j >= 0; j-- {
+			if old[i] == new[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else {
+				lengths[i][j] = max(lengths[i+1][j], lengths[i][j+1])
+			}
+		}
+	}
+
+	var edits []wordEdit
+	add := func(op byte, text string) {
+		if n := len(edits); n > 0 && edits[n-1].op == op {
+			edits[n-1].text += text
+			return
+		}
+		edits = append(edits, wordEdit{op: op, text: text})
+	}
+	i, j := 0, 0
+	for i < len(old) && j < len(new) {
+		switch {
+		case old[i] == new[j]:
+			add('=', old[i])
+			i++
+			j++
+		case lengths[i+1][j] >= lengths[i][j+1]:
+			add('-', old[i])
+			i++
+		default:
+			add('+', new[j])
+			j++
+		}
+	}
+	for ; i < len(old); i++ {
+		add('-', old[i])
+	}
+	for ; j < len(new); j++ {
+		add('+', new[j])
+	}
+	return edits
+}
+
+// similarEnough reports whether a pair of lines has at least half of its text in common, ignoring
+// whitespace. Highlighting the changed words of lines that have little in common only adds noise.
+func similarEnough(edits []wordEdit) bool {
+	visible := func(text string) int {
+		return len(strings.Join(strings.Fields(text), ""))
+	}
+	var common, removed, added int
+	for _, edit := range edits {
+		switch edit.op {
+		case '=':
+			common += visible(edit.text)
+		case '-':
+			removed += visible(edit.text)
+		case '+':
+			added += visible(edit.text)
+		}
+	}
+	return common > 0 && 2*common >= common+max(removed, added)
+}
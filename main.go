@@ -18,7 +18,7 @@ var versionCmd = &cobra.Command{
 }
 
 func main() {
-	if err := cmd.RootCmd.Execute(); err != nil {
+	if err := cmd.Execute(); err != nil {
 		fmt.Println(err)
 		os.Exit(1)
 	}
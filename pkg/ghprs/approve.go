@@ -0,0 +1,103 @@
+package ghprs
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Review events an approval can post
+const (
+	ReviewEventApprove = "APPROVE"
+	ReviewEventComment = "COMMENT"
+)
+
+// ReviewEventRequestChanges is the review event asking for changes before a PR can be merged
+const ReviewEventRequestChanges = "REQUEST_CHANGES"
+
+// ErrHeadChanged is returned by Approve when commits were pushed to the PR after it was looked at
+var ErrHeadChanged = errors.New("new commits were pushed since the PR was looked at")
+
+// ApprovalOptions say what an approval posts
+type ApprovalOptions struct {
+	// Body is the review body, such as "/lgtm" for Prow; empty posts a review without a body
+	Body string
+	// Event is the review event, ReviewEventApprove when empty
+	Event string
+	// ExtraComments are posted as separate comments after the review, such as "/approve" for Prow
+	ExtraComments []string
+}
+
+// Approval is what Approve posted
+type Approval struct {
+	// Review is the posted review, with only the fields GitHub returned
+	Review Review
+	// CommentErrors maps the extra comments that couldn't be posted to why; the review stands regardless
+	CommentErrors map[string]error
+}
+
+// Approve approves a PR as it was looked at: it refuses with ErrHeadChanged if the PR's head moved on from
+// pr.Head.SHA, posts the review pinned to that commit and then the extra comments.
+func Approve(ctx context.Context, client RESTClient, owner, repo string, pr PullRequest, opts ApprovalOptions) (Approval, error) {
+	var current PullRequest
+	if err := client.DoWithContext(ctx, http.MethodGet, fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pr.Number), nil, &current); err != nil {
+		return Approval{}, fmt.Errorf("failed to fetch PR details: %w", err)
+	}
+	if current.Head.SHA != pr.Head.SHA {
+		return Approval{}, ErrHeadChanged
+	}
+
+	event := opts.Event
+	if event == "" {
+		event = ReviewEventApprove
+	}
+	review, err := PostReview(ctx, client, owner, repo, pr, opts.Body, event)
+	if err != nil {
+		return Approval{}, err
+	}
+
+	approval := Approval{Review: review}
+	for _, comment := range opts.ExtraComments {
+		if err := PostComment(ctx, client, owner, repo, pr.Number, comment); err != nil {
+			if approval.CommentErrors == nil {
+				approval.CommentErrors = make(map[string]error)
+			}
+			approval.CommentErrors[comment] = err
+		}
+	}
+	return approval, nil
+}
+
+// PostReview posts a review of a PR pinned to its head commit. The review was posted even when the returned
+// review is empty because GitHub's response couldn't be read.
+func PostReview(ctx context.Context, client RESTClient, owner, repo string, pr PullRequest, body, event string) (Review, error) {
+	reviewJSON, err := json.Marshal(ReviewRequest{Body: body, Event: event, CommitID: pr.Head.SHA})
+	if err != nil {
+		return Review{}, fmt.Errorf("failed to marshal review: %v", err)
+	}
+
+	var response json.RawMessage
+	reviewPath := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews", owner, repo, pr.Number)
+	if err := client.DoWithContext(ctx, http.MethodPost, reviewPath, bytes.NewReader(reviewJSON), &response); err != nil {
+		return Review{}, err
+	}
+	var posted Review
+	_ = json.Unmarshal(response, &posted)
+	return posted, nil
+}
+
+// PostComment posts a comment on a PR
+func PostComment(ctx context.Context, client RESTClient, owner, repo string, prNumber int, body string) error {
+	commentJSON, err := json.Marshal(CommentRequest{Body: body})
+	if err != nil {
+		return fmt.Errorf("failed to marshal comment: %v", err)
+	}
+	commentPath := fmt.Sprintf("repos/%s/%s/issues/%d/comments", owner, repo, prNumber)
+	if err := client.DoWithContext(ctx, http.MethodPost, commentPath, bytes.NewReader(commentJSON), nil); err != nil {
+		return fmt.Errorf("failed to post comment: %v", err)
+	}
+	return nil
+}
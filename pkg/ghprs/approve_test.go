@@ -0,0 +1,55 @@
+package ghprs_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+var _ = Describe("Approve", func() {
+	var (
+		client *fakeClient
+		pr     ghprs.PullRequest
+	)
+
+	BeforeEach(func() {
+		client = newFakeClient(map[string]string{
+			"GET repos/o/r/pulls/7":            `{"number": 7, "head": {"sha": "abc"}}`,
+			"POST repos/o/r/pulls/7/reviews":   `{"id": 42, "state": "APPROVED"}`,
+			"POST repos/o/r/issues/7/comments": "",
+		})
+		pr = ghprs.PullRequest{Number: 7, Head: ghprs.Branch{SHA: "abc"}}
+	})
+
+	It("should post a review pinned to the head commit and then the extra comments", func() {
+		approval, err := ghprs.Approve(context.Background(), client, "o", "r", pr, ghprs.ApprovalOptions{Body: "/lgtm", ExtraComments: []string{"/approve"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval.Review.ID).To(Equal(int64(42)))
+		Expect(approval.CommentErrors).To(BeEmpty())
+
+		posted := client.posted(http.MethodPost)
+		Expect(posted).To(HaveLen(2))
+		Expect(posted[0].Body).To(MatchJSON(`{"body": "/lgtm", "event": "APPROVE", "commit_id": "abc"}`))
+		Expect(posted[1].Path).To(Equal("repos/o/r/issues/7/comments"))
+		Expect(posted[1].Body).To(MatchJSON(`{"body": "/approve"}`))
+	})
+
+	It("should refuse when commits were pushed since the PR was looked at", func() {
+		pr.Head.SHA = "old"
+		_, err := ghprs.Approve(context.Background(), client, "o", "r", pr, ghprs.ApprovalOptions{})
+		Expect(err).To(MatchError(ghprs.ErrHeadChanged))
+		Expect(client.posted(http.MethodPost)).To(BeEmpty())
+	})
+
+	It("should keep the review when an extra comment fails", func() {
+		delete(client.responses, "POST repos/o/r/issues/7/comments")
+		approval, err := ghprs.Approve(context.Background(), client, "o", "r", pr, ghprs.ApprovalOptions{ExtraComments: []string{"/approve"}})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(approval.Review.ID).To(Equal(int64(42)))
+		Expect(approval.CommentErrors).To(HaveKey("/approve"))
+	})
+})
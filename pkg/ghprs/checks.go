@@ -0,0 +1,108 @@
+package ghprs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// CheckSummary counts the check runs and status checks of a commit by outcome
+type CheckSummary struct {
+	// Passed counts the checks that completed without failing, including skipped and neutral ones
+	Passed  int
+	Failed  int
+	Pending int
+	// Cancelled counts the check runs that were cancelled or went stale before they could pass
+	Cancelled int
+}
+
+// Total returns the number of checks
+func (s CheckSummary) Total() int {
+	return s.Passed + s.Failed + s.Pending + s.Cancelled
+}
+
+// Green reports whether there are checks and all of them passed
+func (s CheckSummary) Green() bool {
+	return s.Total() > 0 && s.Failed == 0 && s.Pending == 0 && s.Cancelled == 0
+}
+
+// String describes the checks by their most important outcome, such as "2 failed" or "5 passed"
+func (s CheckSummary) String() string {
+	switch {
+	case s.Failed > 0:
+		return fmt.Sprintf("%d failed", s.Failed)
+	case s.Cancelled > 0:
+		return fmt.Sprintf("%d cancelled", s.Cancelled)
+	case s.Pending > 0:
+		return fmt.Sprintf("%d pending", s.Pending)
+	case s.Passed == 0:
+		return "no checks"
+	default:
+		return fmt.Sprintf("%d passed", s.Passed)
+	}
+}
+
+// CheckRunFailed reports whether a check run completed unsuccessfully
+func CheckRunFailed(checkRun CheckRun) bool {
+	switch checkRun.Conclusion {
+	case "failure", "timed_out", "action_required":
+		return checkRun.Status == "completed"
+	}
+	return false
+}
+
+// StatusCheckFailed reports whether a status check failed or errored
+func StatusCheckFailed(statusCheck StatusCheck) bool {
+	return statusCheck.State == "failure" || statusCheck.State == "error"
+}
+
+// checkRunCancelled reports whether a check run was cancelled or went stale, so it never passed
+func checkRunCancelled(checkRun CheckRun) bool {
+	return checkRun.Status == "completed" && (checkRun.Conclusion == "cancelled" || checkRun.Conclusion == "stale")
+}
+
+// SummarizeChecks counts check runs and status checks by outcome
+func SummarizeChecks(checkRuns []CheckRun, statusChecks []StatusCheck) CheckSummary {
+	var summary CheckSummary
+	for _, checkRun := range checkRuns {
+		switch {
+		case CheckRunFailed(checkRun):
+			summary.Failed++
+		case checkRunCancelled(checkRun):
+			summary.Cancelled++
+		case checkRun.Status != "completed":
+			summary.Pending++
+		default:
+			summary.Passed++
+		}
+	}
+	for _, statusCheck := range statusChecks {
+		switch {
+		case StatusCheckFailed(statusCheck):
+			summary.Failed++
+		case statusCheck.State == "pending":
+			summary.Pending++
+		default:
+			summary.Passed++
+		}
+	}
+	return summary
+}
+
+// FetchChecks fetches the check runs and status checks of a commit
+func FetchChecks(ctx context.Context, client RESTClient, owner, repo, sha string) ([]CheckRun, []StatusCheck, error) {
+	var checkRunsResp CheckRunsResponse
+	checkRunsPath := fmt.Sprintf("repos/%s/%s/commits/%s/check-runs?per_page=%d", owner, repo, sha, maxPerPage)
+	if err := client.DoWithContext(ctx, http.MethodGet, checkRunsPath, nil, &checkRunsResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch check runs: %v", err)
+	}
+
+	var statusResp struct {
+		Statuses []StatusCheck `json:"statuses"`
+	}
+	statusPath := fmt.Sprintf("repos/%s/%s/commits/%s/status?per_page=%d", owner, repo, sha, maxPerPage)
+	if err := client.DoWithContext(ctx, http.MethodGet, statusPath, nil, &statusResp); err != nil {
+		return nil, nil, fmt.Errorf("failed to fetch status checks: %v", err)
+	}
+	return checkRunsResp.CheckRuns, statusResp.Statuses, nil
+}
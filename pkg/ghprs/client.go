@@ -0,0 +1,16 @@
+package ghprs
+
+import (
+	"context"
+	"io"
+)
+
+// RESTClient sends requests to the GitHub REST API. The REST client of github.com/cli/go-gh/v2/pkg/api
+// satisfies it. Paths are relative to the API root, such as "repos/owner/repo/pulls/1", and response
+// is decoded from JSON unless it is nil.
+type RESTClient interface {
+	DoWithContext(ctx context.Context, method string, path string, body io.Reader, response interface{}) error
+}
+
+// maxPerPage is the largest page size the GitHub REST API accepts
+const maxPerPage = 100
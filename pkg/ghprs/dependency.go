@@ -0,0 +1,171 @@
+package ghprs
+
+import (
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// The kinds of update a dependency bump is, from the most to the least disruptive
+const (
+	UpdateMajor  = "major"
+	UpdateMinor  = "minor"
+	UpdatePatch  = "patch"
+	UpdateDigest = "digest"
+)
+
+// UpdateTypes are the kinds of update, from the most to the least disruptive
+var UpdateTypes = []string{UpdateMajor, UpdateMinor, UpdatePatch, UpdateDigest}
+
+// DependencyUpdate is a dependency a Renovate or Dependabot PR bumps
+type DependencyUpdate struct {
+	Package string `json:"package" yaml:"package"`
+	From    string `json:"from,omitempty" yaml:"from,omitempty"`
+	To      string `json:"to" yaml:"to"`
+	// Type is major, minor, patch or digest, empty when the versions can't be compared
+	Type string `json:"type,omitempty" yaml:"type,omitempty"`
+}
+
+var (
+	// bumpSentence matches Dependabot's "Bumps [pkg](url) from 1.2.3 to 1.3.0" and "Updates `pkg` from 1.2.3 to
+	// 1.3.0", the package in the first group and the versions in the next two
+	bumpSentence = regexp.MustCompile("(?i)\\b(?:bumps?|updates?)\\s+(?:the\\s+)?\\[?`?([^\\s`\\[\\]]+)`?\\]?(?:\\([^)]*\\))?\\s+from\\s+`?v?([^\\s`]+?)`?\\s+to\\s+`?v?([^\\s`]+?)`?(?:[.,;:]?(?:\\s|$))")
+	// versionChange matches the Change cell of Renovate's table, "`1.2.3` -> `1.3.0`" or with an arrow
+	versionChange = regexp.MustCompile("`?v?([^\\s`]+)`?\\s*(?:->|→)\\s*`?v?([^\\s`]+)`?")
+	// hexDigest matches a commit or image digest
+	hexDigest = regexp.MustCompile(`^(?:sha256:)?[0-9a-f]{7,64}$`)
+	// versionNumbers matches the leading numbers of a version, such as 1.2.3 in 1.2.3-rc1
+	versionNumbers = regexp.MustCompile(`^(\d+)(?:\.(\d+))?(?:\.(\d+))?`)
+)
+
+// ParseDependencyUpdates finds the dependencies a Renovate or Dependabot PR updates: the rows of Renovate's
+// update table, else Dependabot's "Bumps ... from ... to ..." sentences in the body or the title. Each is
+// typed by its Update column when Renovate gives one, else by comparing its versions.
+func ParseDependencyUpdates(title, body string) []DependencyUpdate {
+	updates := renovateTableUpdates(body)
+	if len(updates) == 0 {
+		updates = bumpSentenceUpdates(body)
+	}
+	if len(updates) == 0 {
+		updates = bumpSentenceUpdates(title)
+	}
+	return updates
+}
+
+// renovateTableUpdates reads the table Renovate opens its PRs with, whose header names a Package and a Change
+// column and optionally an Update column
+func renovateTableUpdates(body string) []DependencyUpdate {
+	var updates []DependencyUpdate
+	packageCol, changeCol, updateCol := -1, -1, -1
+	for _, line := range strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n") {
+		if !isTableRow(line) {
+			packageCol, changeCol, updateCol = -1, -1, -1
+			continue
+		}
+		cells := tableCells(line)
+		if packageCol < 0 {
+			for i, cell := range cells {
+				switch strings.ToLower(cell) {
+				case "package", "dependency":
+					packageCol = i
+				case "change":
+					changeCol = i
+				case "update":
+					updateCol = i
+				}
+			}
+			if changeCol < 0 {
+				packageCol = -1
+			}
+			continue
+		}
+		if packageCol >= len(cells) || changeCol >= len(cells) {
+			continue
+		}
+		change := versionChange.FindStringSubmatch(cells[changeCol])
+		if change == nil {
+			continue
+		}
+		update := DependencyUpdate{Package: packageName(cells[packageCol]), From: change[1], To: change[2]}
+		if updateCol >= 0 && updateCol < len(cells) && slices.Contains(UpdateTypes, strings.ToLower(cells[updateCol])) {
+			update.Type = strings.ToLower(cells[updateCol])
+		} else {
+			update.Type = CompareVersions(update.From, update.To)
+		}
+		updates = append(updates, update)
+	}
+	return updates
+}
+
+// bumpSentenceUpdates reads Dependabot's sentences naming what it bumps, each package once
+func bumpSentenceUpdates(text string) []DependencyUpdate {
+	var updates []DependencyUpdate
+	seen := map[string]bool{}
+	for _, match := range bumpSentence.FindAllStringSubmatch(text, -1) {
+		if seen[match[1]] {
+			continue
+		}
+		seen[match[1]] = true
+		updates = append(updates, DependencyUpdate{Package: match[1], From: match[2], To: match[3], Type: CompareVersions(match[2], match[3])})
+	}
+	return updates
+}
+
+// tableCells splits a markdown table row into its trimmed cells
+func tableCells(row string) []string {
+	cells := strings.Split(strings.Trim(strings.TrimSpace(row), "|"), "|")
+	for i, cell := range cells {
+		cells[i] = strings.TrimSpace(cell)
+	}
+	return cells
+}
+
+// packageName reduces a Package cell, often a link with its source in parentheses, to the package's name
+func packageName(cell string) string {
+	cell = markdownLink.ReplaceAllString(cell, "$1")
+	if i := strings.Index(cell, " ("); i > 0 {
+		cell = cell[:i]
+	}
+	return strings.Trim(cell, "` ")
+}
+
+// CompareVersions returns the kind of update going from one version to another: major, minor or patch by the
+// first of their numbers that changes, digest between commit or image digests, and empty when they can't be
+// compared
+func CompareVersions(from, to string) string {
+	from, to = strings.TrimPrefix(from, "v"), strings.TrimPrefix(to, "v")
+	if isDigest(from) && isDigest(to) {
+		return UpdateDigest
+	}
+	fromParts, toParts := versionNumbers.FindStringSubmatch(from), versionNumbers.FindStringSubmatch(to)
+	if fromParts == nil || toParts == nil {
+		return ""
+	}
+	for i, updateType := range []string{UpdateMajor, UpdateMinor, UpdatePatch} {
+		fromN, _ := strconv.Atoi(fromParts[i+1])
+		toN, _ := strconv.Atoi(toParts[i+1])
+		if fromN != toN {
+			return updateType
+		}
+	}
+	// Only pre-release or build parts differ, e.g. 1.2.3-rc1 to 1.2.3
+	return UpdatePatch
+}
+
+// isDigest reports whether a version is a commit or image digest rather than a number, such as a date
+func isDigest(version string) bool {
+	return hexDigest.MatchString(version) && strings.ContainsAny(strings.TrimPrefix(version, "sha256:"), "abcdef")
+}
+
+// HighestUpdateType returns the most disruptive kind of update among updates, empty when none is known
+func HighestUpdateType(updates []DependencyUpdate) string {
+	for _, updateType := range UpdateTypes {
+		for _, update := range updates {
+			if update.Type == updateType {
+				return updateType
+			}
+		}
+	}
+	return ""
+}
@@ -0,0 +1,59 @@
+package ghprs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+var _ = Describe("Dependency updates", func() {
+	It("should read Renovate's update table", func() {
+		body := "This PR contains the following updates:\n\n" +
+			"| Package | Type | Update | Change |\n|---|---|---|---|\n" +
+			"| [golang.org/x/net](https://github.com/golang/net) | require | minor | `v0.20.0` -> `v0.21.0` |\n" +
+			"| [github.com/onsi/gomega](https://github.com/onsi/gomega) ([source](https://github.com/onsi/gomega)) | require | patch | `v1.31.0` -> `v1.31.1` |\n\n---\n"
+		Expect(ghprs.ParseDependencyUpdates("chore(deps): update go modules", body)).To(Equal([]ghprs.DependencyUpdate{
+			{Package: "golang.org/x/net", From: "0.20.0", To: "0.21.0", Type: ghprs.UpdateMinor},
+			{Package: "github.com/onsi/gomega", From: "1.31.0", To: "1.31.1", Type: ghprs.UpdatePatch},
+		}))
+	})
+
+	It("should compare the versions of a table without an Update column", func() {
+		body := "| Package | Change | Notes |\n|---|---|---|\n" +
+			"| quay.io/konflux-ci/tekton-catalog/task-buildah | `0.2` -> `1.0` | |\n" +
+			"| quay.io/konflux-ci/tekton-catalog/task-git-clone | `9d6f2a1` -> `c3e88b7` | |\n"
+		updates := ghprs.ParseDependencyUpdates("Update Konflux references", body)
+		Expect(updates).To(HaveLen(2))
+		Expect(updates[0].Type).To(Equal(ghprs.UpdateMajor))
+		Expect(updates[1].Type).To(Equal(ghprs.UpdateDigest))
+		Expect(ghprs.HighestUpdateType(updates)).To(Equal(ghprs.UpdateMajor))
+	})
+
+	It("should read Dependabot's sentences, or its title", func() {
+		body := "Bumps the npm group with 2 updates: [lodash](https://github.com/lodash/lodash) and [axios](https://github.com/axios/axios).\n\n" +
+			"Updates `lodash` from 4.17.20 to 4.17.21\n- [Release notes](https://github.com/lodash/lodash/releases)\n\n" +
+			"Updates `axios` from 0.27.2 to 1.6.0\n"
+		Expect(ghprs.ParseDependencyUpdates("Bump the npm group with 2 updates", body)).To(Equal([]ghprs.DependencyUpdate{
+			{Package: "lodash", From: "4.17.20", To: "4.17.21", Type: ghprs.UpdatePatch},
+			{Package: "axios", From: "0.27.2", To: "1.6.0", Type: ghprs.UpdateMajor},
+		}))
+		Expect(ghprs.ParseDependencyUpdates("Bump golang.org/x/crypto from 0.17.0 to 0.18.0 in /tools", "")).To(Equal([]ghprs.DependencyUpdate{
+			{Package: "golang.org/x/crypto", From: "0.17.0", To: "0.18.0", Type: ghprs.UpdateMinor},
+		}))
+		Expect(ghprs.ParseDependencyUpdates("Fix a typo", "Fixes the docs.")).To(BeEmpty())
+	})
+
+	DescribeTable("CompareVersions",
+		func(from, to, expected string) {
+			Expect(ghprs.CompareVersions(from, to)).To(Equal(expected))
+		},
+		Entry("major", "1.9.0", "2.0.0", ghprs.UpdateMajor),
+		Entry("minor", "v1.2.3", "v1.3.0", ghprs.UpdateMinor),
+		Entry("patch", "1.2.3", "1.2.4", ghprs.UpdatePatch),
+		Entry("pre-release", "1.2.3-rc1", "1.2.3", ghprs.UpdatePatch),
+		Entry("dates are versions", "20240101", "20240201", ghprs.UpdateMajor),
+		Entry("digests", "sha256:0a1b2c3d", "sha256:4e5f6a7b", ghprs.UpdateDigest),
+		Entry("unknown", "latest", "stable", ""),
+	)
+})
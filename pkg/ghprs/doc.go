@@ -0,0 +1,16 @@
+// Package ghprs is the Go API of the ghprs command line tool, for bots and services that want to look at
+// and act on pull requests the way ghprs does without running the CLI.
+//
+// It covers three flows:
+//
+//   - Enrichment: [Enrich] adds to a pull request what the PR table shows, such as whether it needs a
+//     rebase, is blocked or on hold, its checks and its readiness to merge.
+//   - Policy: [Decide] evaluates the rules of the ghprs config against a pull request and explains its
+//     decision, as 'ghprs konflux --auto' does.
+//   - Approval: [Approve] approves a pull request with a review pinned to the head commit that was looked at,
+//     followed by comments such as /approve for Prow.
+//
+// Everything is passed in explicitly: the package keeps no state between calls and reads no config,
+// environment or flags. Requests go through a [RESTClient], which the REST client of
+// github.com/cli/go-gh/v2/pkg/api satisfies.
+package ghprs
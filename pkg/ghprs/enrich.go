@@ -0,0 +1,165 @@
+package ghprs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+)
+
+// Readiness states, the single answer to "what does this PR need before it can merge?"
+const (
+	ReadinessReady         = "READY"
+	ReadinessNeedsReview   = "NEEDS_REVIEW"
+	ReadinessNeedsRebase   = "NEEDS_REBASE"
+	ReadinessChecksFailing = "CHECKS_FAILING"
+	ReadinessBlocked       = "BLOCKED"
+	ReadinessOnHold        = "ON_HOLD"
+	ReadinessFrozen        = "FROZEN"
+)
+
+// ReadinessSignals are what the readiness of a PR is rolled up from. Unknown signals are nil.
+type ReadinessSignals struct {
+	Open          bool
+	OnHold        bool
+	Frozen        bool
+	NeedsRebase   *bool
+	ChecksFailing bool
+	Reviewed      *bool
+	Blocked       *bool
+}
+
+// Readiness rolls signals up into one readiness state. The first state that applies wins, so a PR on hold
+// is ON_HOLD even when its checks fail. Missing reviews come before BLOCKED because they are the usual reason
+// branch protection blocks a PR. PRs that are not open have no readiness.
+func Readiness(signals ReadinessSignals) string {
+	if !signals.Open {
+		return ""
+	}
+
+	switch {
+	case signals.OnHold:
+		return ReadinessOnHold
+	case signals.Frozen:
+		return ReadinessFrozen
+	case signals.NeedsRebase != nil && *signals.NeedsRebase:
+		return ReadinessNeedsRebase
+	case signals.ChecksFailing:
+		return ReadinessChecksFailing
+	case signals.Reviewed == nil || !*signals.Reviewed:
+		return ReadinessNeedsReview
+	case signals.Blocked != nil && *signals.Blocked:
+		return ReadinessBlocked
+	default:
+		return ReadinessReady
+	}
+}
+
+// EnrichOptions choose what Enrich looks up beyond the PR itself
+type EnrichOptions struct {
+	// Konflux also finds out whether the PR only changes Tekton pipelines, which costs a request for its files
+	Konflux bool
+	// SkipChecks doesn't fetch the checks of the head commit, leaving Checks empty
+	SkipChecks bool
+	// TektonPatterns are the Tekton pipelines of the repository, as MatchesTektonPatterns; unset uses the ones
+	// Konflux updates
+	TektonPatterns []string
+}
+
+// Enrichment is a PR with the signals the ghprs PR table shows for it
+type Enrichment struct {
+	// PullRequest is the PR with its mergeable state, which the PR list doesn't return
+	PullRequest PullRequest
+
+	OnHold           bool
+	Frozen           bool
+	MigrationWarning bool
+	SecurityUpdate   bool
+	// Reviewed is set when the PR has an approving review or Prow's approved or lgtm label
+	Reviewed bool
+	// NeedsRebase and Blocked are nil while GitHub is still computing the mergeable state
+	NeedsRebase *bool
+	Blocked     *bool
+	// TektonOnly is nil unless EnrichOptions.Konflux is set
+	TektonOnly *bool
+	// Checks summarizes the checks of the head commit, unless EnrichOptions.SkipChecks is set
+	Checks CheckSummary
+	// Readiness is one of the Readiness states, empty for PRs that are not open
+	Readiness string
+}
+
+// Enrich looks up the signals of a PR as returned by the PR list or a single PR request
+func Enrich(ctx context.Context, client RESTClient, owner, repo string, pr PullRequest, opts EnrichOptions) (Enrichment, error) {
+	// The PR list doesn't return the mergeable state
+	if pr.MergeableState == "" {
+		var details PullRequest
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d", owner, repo, pr.Number)
+		if err := client.DoWithContext(ctx, http.MethodGet, path, nil, &details); err != nil {
+			return Enrichment{}, fmt.Errorf("failed to fetch PR details: %w", err)
+		}
+		pr = details
+	}
+
+	enrichment := Enrichment{
+		PullRequest:      pr,
+		OnHold:           IsOnHold(pr),
+		Frozen:           IsFrozen(pr),
+		MigrationWarning: HasMigrationWarning(pr),
+		SecurityUpdate:   IsSecurityUpdate(pr),
+	}
+	if pr.MergeableState != "" && pr.MergeableState != "unknown" {
+		needsRebase, blocked := NeedsRebase(pr), IsBlocked(pr)
+		enrichment.NeedsRebase, enrichment.Blocked = &needsRebase, &blocked
+	}
+
+	reviewed, err := isReviewed(ctx, client, owner, repo, pr)
+	if err != nil {
+		return Enrichment{}, err
+	}
+	enrichment.Reviewed = reviewed
+
+	if opts.Konflux {
+		files, err := FetchFiles(ctx, client, owner, repo, pr.Number)
+		if err != nil {
+			return Enrichment{}, fmt.Errorf("failed to fetch files: %w", err)
+		}
+		tektonOnly := OnlyTektonFiles(files, opts.TektonPatterns)
+		enrichment.TektonOnly = &tektonOnly
+	}
+
+	if !opts.SkipChecks && pr.Head.SHA != "" {
+		checkRuns, statusChecks, err := FetchChecks(ctx, client, owner, repo, pr.Head.SHA)
+		if err != nil {
+			return Enrichment{}, err
+		}
+		enrichment.Checks = SummarizeChecks(checkRuns, statusChecks)
+	}
+
+	enrichment.Readiness = Readiness(ReadinessSignals{
+		Open:          pr.State == "open",
+		OnHold:        enrichment.OnHold,
+		Frozen:        enrichment.Frozen,
+		NeedsRebase:   enrichment.NeedsRebase,
+		ChecksFailing: enrichment.Checks.Failed > 0,
+		Reviewed:      &enrichment.Reviewed,
+		Blocked:       enrichment.Blocked,
+	})
+	return enrichment, nil
+}
+
+// isReviewed reports whether a PR has Prow's approved or lgtm label or an approving review
+func isReviewed(ctx context.Context, client RESTClient, owner, repo string, pr PullRequest) (bool, error) {
+	if HasApprovedLabel(pr.Labels) {
+		return true, nil
+	}
+	var reviews []Review
+	path := fmt.Sprintf("repos/%s/%s/pulls/%d/reviews?per_page=%d", owner, repo, pr.Number, maxPerPage)
+	if err := client.DoWithContext(ctx, http.MethodGet, path, nil, &reviews); err != nil {
+		return false, fmt.Errorf("failed to fetch reviews: %w", err)
+	}
+	for _, review := range reviews {
+		if review.State == "APPROVED" {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,61 @@
+package ghprs_test
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+var _ = Describe("Enrich", func() {
+	var client *fakeClient
+
+	BeforeEach(func() {
+		client = newFakeClient(map[string]string{
+			"GET repos/o/r/pulls/7":                `{"number": 7, "state": "open", "mergeable_state": "blocked", "head": {"sha": "abc"}}`,
+			"GET repos/o/r/pulls/7/reviews":        `[{"id": 1, "state": "APPROVED"}]`,
+			"GET repos/o/r/pulls/7/files":          `[{"filename": ".tekton/app-push.yaml"}]`,
+			"GET repos/o/r/commits/abc/check-runs": `{"check_runs": [{"name": "build", "status": "completed", "conclusion": "failure"}]}`,
+			"GET repos/o/r/commits/abc/status":     `{"statuses": [{"context": "ci", "state": "success"}]}`,
+		})
+	})
+
+	It("should fetch the mergeable state, reviews and checks and roll them up", func() {
+		enrichment, err := ghprs.Enrich(context.Background(), client, "o", "r", ghprs.PullRequest{Number: 7}, ghprs.EnrichOptions{})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enrichment.PullRequest.MergeableState).To(Equal("blocked"))
+		Expect(enrichment.Reviewed).To(BeTrue())
+		Expect(*enrichment.Blocked).To(BeTrue())
+		Expect(*enrichment.NeedsRebase).To(BeFalse())
+		Expect(enrichment.TektonOnly).To(BeNil())
+		Expect(enrichment.Checks).To(Equal(ghprs.CheckSummary{Passed: 1, Failed: 1}))
+		Expect(enrichment.Readiness).To(Equal(ghprs.ReadinessChecksFailing))
+	})
+
+	It("should leave the mergeable signals unknown while GitHub computes them", func() {
+		pr := ghprs.PullRequest{Number: 7, State: "open", MergeableState: "unknown", Head: ghprs.Branch{SHA: "abc"}}
+		enrichment, err := ghprs.Enrich(context.Background(), client, "o", "r", pr, ghprs.EnrichOptions{SkipChecks: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enrichment.NeedsRebase).To(BeNil())
+		Expect(enrichment.Blocked).To(BeNil())
+		Expect(enrichment.Checks.Total()).To(BeZero())
+		Expect(enrichment.Readiness).To(Equal(ghprs.ReadinessReady))
+	})
+
+	It("should not fetch reviews of a PR Prow already approved", func() {
+		delete(client.responses, "GET repos/o/r/pulls/7/reviews")
+		pr := ghprs.PullRequest{Number: 7, State: "open", MergeableState: "clean", Labels: []ghprs.Label{{Name: "approved"}}}
+		enrichment, err := ghprs.Enrich(context.Background(), client, "o", "r", pr, ghprs.EnrichOptions{Konflux: true})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(enrichment.Reviewed).To(BeTrue())
+		Expect(*enrichment.TektonOnly).To(BeTrue())
+	})
+
+	It("should return the errors of the requests it needs", func() {
+		delete(client.responses, "GET repos/o/r/pulls/7/reviews")
+		_, err := ghprs.Enrich(context.Background(), client, "o", "r", ghprs.PullRequest{Number: 7}, ghprs.EnrichOptions{})
+		Expect(err).To(MatchError(ContainSubstring("failed to fetch reviews")))
+	})
+})
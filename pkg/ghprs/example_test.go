@@ -0,0 +1,80 @@
+package ghprs_test
+
+import (
+	"context"
+	"fmt"
+
+	"ghprs/pkg/ghprs"
+)
+
+// The examples answer from canned responses; real code passes the REST client of go-gh:
+//
+//	client, err := api.DefaultRESTClient()
+func exampleClient() *fakeClient {
+	return newFakeClient(map[string]string{
+		"GET repos/octo/app/pulls/12":                `{"number": 12, "state": "open", "mergeable_state": "clean", "user": {"login": "red-hat-konflux[bot]"}, "head": {"sha": "f00d"}}`,
+		"GET repos/octo/app/pulls/12/reviews":        `[]`,
+		"GET repos/octo/app/pulls/12/files":          `[{"filename": ".tekton/app-push.yaml"}]`,
+		"GET repos/octo/app/commits/f00d/check-runs": `{"check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}]}`,
+		"GET repos/octo/app/commits/f00d/status":     `{"statuses": []}`,
+		"POST repos/octo/app/pulls/12/reviews":       `{"id": 1001, "state": "APPROVED"}`,
+		"POST repos/octo/app/issues/12/comments":     "",
+	})
+}
+
+func ExampleEnrich() {
+	ctx := context.Background()
+	enrichment, err := ghprs.Enrich(ctx, exampleClient(), "octo", "app", ghprs.PullRequest{Number: 12}, ghprs.EnrichOptions{Konflux: true})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("readiness:", enrichment.Readiness)
+	fmt.Println("checks:", enrichment.Checks)
+	fmt.Println("tekton only:", *enrichment.TektonOnly)
+	// Output:
+	// readiness: NEEDS_REVIEW
+	// checks: 1 passed
+	// tekton only: true
+}
+
+func ExampleDecide() {
+	rules := []ghprs.Rule{
+		{Name: "held", When: ghprs.RuleConditions{AllowLabels: []string{ghprs.HoldLabel}}, Action: ghprs.RuleActionSkip},
+		{Name: "konflux", When: ghprs.RuleConditions{Authors: []string{"red-hat-konflux[bot]"}, ChecksGreen: true}, Action: ghprs.RuleActionApprove},
+	}
+	if err := ghprs.ValidateRules(rules); err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	pr := ghprs.PullRequest{Number: 12, User: ghprs.User{Login: "red-hat-konflux[bot]"}, Head: ghprs.Branch{SHA: "f00d"}}
+	decision := ghprs.Decide(context.Background(), exampleClient(), "octo", "app", pr, rules, ghprs.DecideOptions{})
+	fmt.Printf("%s (%s)\n", decision.Action, decision.Reason(rules))
+	for _, line := range decision.Trail {
+		fmt.Println(line)
+	}
+	// Output:
+	// approve (rule "konflux")
+	// rule "held": ✗ none of the labels do-not-merge/hold
+	// rule "konflux": ✓ author red-hat-konflux[bot], ✓ checks 1 passed
+}
+
+func ExampleApprove() {
+	pr := ghprs.PullRequest{Number: 12, Head: ghprs.Branch{SHA: "f00d"}}
+	approval, err := ghprs.Approve(context.Background(), exampleClient(), "octo", "app", pr, ghprs.ApprovalOptions{
+		Body:          "/lgtm",
+		ExtraComments: []string{"/approve"},
+	})
+	if err == ghprs.ErrHeadChanged {
+		fmt.Println("look at the PR again")
+		return
+	}
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	fmt.Println("posted review", approval.Review.ID, "with", len(approval.CommentErrors), "failed comments")
+	// Output:
+	// posted review 1001 with 0 failed comments
+}
@@ -0,0 +1,68 @@
+package ghprs_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+)
+
+func TestGhprs(t *testing.T) {
+	RegisterFailHandler(Fail)
+	RunSpecs(t, "Ghprs Suite")
+}
+
+// fakeRequest is a request made to a fakeClient
+type fakeRequest struct {
+	Method string
+	Path   string
+	Body   string
+}
+
+// fakeClient answers requests with canned JSON responses keyed by "METHOD path", the path without its query
+// also matching. Requests without a response fail.
+type fakeClient struct {
+	responses map[string]string
+	requests  []fakeRequest
+}
+
+func newFakeClient(responses map[string]string) *fakeClient {
+	return &fakeClient{responses: responses}
+}
+
+func (c *fakeClient) DoWithContext(_ context.Context, method, path string, body io.Reader, response interface{}) error {
+	request := fakeRequest{Method: method, Path: path}
+	if body != nil {
+		data, _ := io.ReadAll(body)
+		request.Body = string(data)
+	}
+	c.requests = append(c.requests, request)
+
+	data, ok := c.responses[method+" "+path]
+	if !ok {
+		data, ok = c.responses[method+" "+strings.SplitN(path, "?", 2)[0]]
+	}
+	if !ok {
+		return fmt.Errorf("HTTP 404: Not Found (%s)", path)
+	}
+	if response == nil || data == "" {
+		return nil
+	}
+	return json.Unmarshal([]byte(data), response)
+}
+
+// posted returns the requests made with method
+func (c *fakeClient) posted(method string) []fakeRequest {
+	var requests []fakeRequest
+	for _, request := range c.requests {
+		if request.Method == method {
+			requests = append(requests, request)
+		}
+	}
+	return requests
+}
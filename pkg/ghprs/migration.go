@@ -0,0 +1,167 @@
+package ghprs
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+)
+
+var (
+	// migrationHeading matches a markdown heading about migration, its level in the first group
+	migrationHeading = regexp.MustCompile(`(?i)^(#{1,6})\s+.*migrat`)
+	// markdownHeading matches any markdown heading, its level in the first group
+	markdownHeading = regexp.MustCompile(`^(#{1,6})\s`)
+	// markdownLink matches a markdown link, its text in the first group and its target in the second
+	markdownLink = regexp.MustCompile(`\[([^\]]*)\]\(([^)\s]+)\)`)
+	// htmlTag matches the HTML tags bots use to lay out bodies, leaving placeholders such as <namespace> alone
+	htmlTag = regexp.MustCompile(`(?i)</?(?:details|summary|br|p|b|i|em|strong|code|sub|sup|div|span)\b[^>]*>`)
+	// admonition matches the marker of a GitHub alert, such as [!WARNING]
+	admonition = regexp.MustCompile(`^\[![A-Z]+\]$`)
+)
+
+// MigrationNotes holds the migration instructions of a PR body: the lines explaining them and the migration
+// guides they link to
+type MigrationNotes struct {
+	Lines  []string `json:"lines,omitempty" yaml:"lines,omitempty"`
+	Guides []string `json:"guides,omitempty" yaml:"guides,omitempty"`
+}
+
+// Empty reports whether no instructions were found
+func (n MigrationNotes) Empty() bool {
+	return len(n.Lines) == 0 && len(n.Guides) == 0
+}
+
+// ExtractMigrationNotes finds the migration instructions in the body of a PR with a migration warning. A section
+// under a heading about migration is taken whole; otherwise the paragraphs and table rows carrying a migration
+// marker are. Links are reduced to their text, with the migration guides they point to collected in Guides.
+func ExtractMigrationNotes(body string) MigrationNotes {
+	lines := strings.Split(strings.ReplaceAll(body, "\r\n", "\n"), "\n")
+	section := migrationSection(lines)
+	if section == nil {
+		section = markedParagraphs(lines)
+	}
+
+	var notes MigrationNotes
+	for _, line := range section {
+		for _, link := range markdownLink.FindAllStringSubmatch(line, -1) {
+			if isMigrationGuide(link[1], link[2]) && !slices.Contains(notes.Guides, link[2]) {
+				notes.Guides = append(notes.Guides, link[2])
+			}
+		}
+		if line = cleanNoteLine(line); line != "" || (len(notes.Lines) > 0 && notes.Lines[len(notes.Lines)-1] != "") {
+			notes.Lines = append(notes.Lines, line)
+		}
+	}
+	for len(notes.Lines) > 0 && notes.Lines[len(notes.Lines)-1] == "" {
+		notes.Lines = notes.Lines[:len(notes.Lines)-1]
+	}
+	return notes
+}
+
+// migrationSection returns the lines under the first heading about migration, up to the next heading of the
+// same or a higher level, nil without such a heading
+func migrationSection(lines []string) []string {
+	for i, line := range lines {
+		heading := migrationHeading.FindStringSubmatch(strings.TrimSpace(line))
+		if heading == nil {
+			continue
+		}
+		section := []string{}
+		for _, next := range lines[i+1:] {
+			if level := markdownHeading.FindStringSubmatch(strings.TrimSpace(next)); level != nil && len(level[1]) <= len(heading[1]) {
+				break
+			}
+			section = append(section, next)
+		}
+		return section
+	}
+	return nil
+}
+
+// markedParagraphs returns the paragraphs holding a migration marker, a paragraph being lines up to a blank
+// line. Table rows are taken alone, as the rest of the table is about other updates.
+func markedParagraphs(lines []string) []string {
+	var notes []string
+	for i := 0; i < len(lines); i++ {
+		if !hasMigrationMarker(lines[i]) {
+			continue
+		}
+		if isTableRow(lines[i]) {
+			notes = append(notes, tableRowNote(lines[i]))
+			continue
+		}
+		start := i
+		for start > 0 && strings.TrimSpace(lines[start-1]) != "" && !isTableRow(lines[start-1]) {
+			start--
+		}
+		end := i + 1
+		for end < len(lines) && strings.TrimSpace(lines[end]) != "" && !isTableRow(lines[end]) {
+			end++
+		}
+		if len(notes) > 0 {
+			notes = append(notes, "")
+		}
+		notes = append(notes, lines[start:end]...)
+		i = end
+	}
+	return notes
+}
+
+// hasMigrationMarker reports whether text carries one of the migration patterns
+func hasMigrationMarker(text string) bool {
+	textLower := strings.ToLower(text)
+	for _, pattern := range migrationPatterns {
+		if strings.Contains(textLower, strings.ToLower(pattern)) {
+			return true
+		}
+	}
+	return false
+}
+
+// isTableRow reports whether a line is a row of a markdown table
+func isTableRow(line string) bool {
+	line = strings.TrimSpace(line)
+	return strings.HasPrefix(line, "|") && strings.HasSuffix(line, "|")
+}
+
+// tableRowNote turns the row of an update table into a note naming the update: the package and the change,
+// the first two cells, followed by the cell with the migration marker
+func tableRowNote(row string) string {
+	var cells []string
+	for _, cell := range strings.Split(strings.Trim(strings.TrimSpace(row), "|"), "|") {
+		if cell = strings.TrimSpace(cell); cell != "" {
+			cells = append(cells, cell)
+		}
+	}
+	if len(cells) < 2 {
+		return row
+	}
+	note := cells[0] + " " + cells[1]
+	for _, cell := range cells[2:] {
+		if hasMigrationMarker(cell) {
+			note += " " + cell
+		}
+	}
+	return note
+}
+
+// isMigrationGuide reports whether a link points to migration instructions, by its text or target
+func isMigrationGuide(text, target string) bool {
+	return strings.Contains(strings.ToLower(text), "migration") || strings.Contains(strings.ToLower(target), "migration")
+}
+
+// cleanNoteLine strips the markdown of a line that doesn't read well in a terminal: HTML tags, quote markers,
+// alert markers, link targets and backticks
+func cleanNoteLine(line string) string {
+	line = htmlTag.ReplaceAllString(line, "")
+	line = strings.TrimSpace(line)
+	for strings.HasPrefix(line, ">") {
+		line = strings.TrimSpace(strings.TrimPrefix(line, ">"))
+	}
+	if admonition.MatchString(line) || strings.Trim(line, "-*_ ") == "" && line != "" {
+		return ""
+	}
+	line = markdownLink.ReplaceAllString(line, "$1")
+	line = strings.ReplaceAll(line, ":warning:", "⚠️")
+	return strings.ReplaceAll(line, "`", "")
+}
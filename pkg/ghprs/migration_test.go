@@ -0,0 +1,47 @@
+package ghprs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+var _ = Describe("ExtractMigrationNotes", func() {
+	It("should take the section under a migration heading", func() {
+		body := "## Changes\nBumps the task.\n\n## Migration notes\n\n<details>\n<summary>Steps</summary>\n\n" +
+			"Add the `oci-storage` param to your pipelines.\nSee [the guide](https://example.com/MIGRATION.md).\n\n" +
+			"### Rollback\nRemove the param.\n</details>\n\n## Configuration\nEnabled."
+		notes := ghprs.ExtractMigrationNotes(body)
+		Expect(notes.Lines).To(Equal([]string{
+			"Steps",
+			"",
+			"Add the oci-storage param to your pipelines.",
+			"See the guide.",
+			"",
+			"### Rollback",
+			"Remove the param.",
+		}))
+		Expect(notes.Guides).To(Equal([]string{"https://example.com/MIGRATION.md"}))
+	})
+
+	It("should take the table rows carrying a migration marker", func() {
+		body := "| Package | Change | Notes |\n|---|---|---|\n" +
+			"| task-buildah | `0.2` -> `0.4` | :warning:[migration](https://example.com/buildah/0.4/MIGRATION.md):warning: |\n" +
+			"| task-git-clone | `0.1` -> `0.2` | |\n"
+		notes := ghprs.ExtractMigrationNotes(body)
+		Expect(notes.Lines).To(Equal([]string{"task-buildah 0.2 -> 0.4 ⚠️migration⚠️"}))
+		Expect(notes.Guides).To(Equal([]string{"https://example.com/buildah/0.4/MIGRATION.md"}))
+	})
+
+	It("should take the paragraph around a migration marker", func() {
+		body := "Intro.\n\n> [!WARNING]\n> ⚠️[migration] The bundle moved,\n> update your <namespace> pipelines.\n\nFooter."
+		notes := ghprs.ExtractMigrationNotes(body)
+		Expect(notes.Lines).To(Equal([]string{"⚠️[migration] The bundle moved,", "update your <namespace> pipelines."}))
+		Expect(notes.Guides).To(BeEmpty())
+	})
+
+	It("should find nothing without a migration warning", func() {
+		Expect(ghprs.ExtractMigrationNotes("Bumps a dependency.").Empty()).To(BeTrue())
+	})
+})
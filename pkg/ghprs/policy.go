@@ -0,0 +1,269 @@
+package ghprs
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+// Actions a rule can take
+const (
+	RuleActionApprove = "approve"
+	RuleActionHold    = "hold"
+	RuleActionSkip    = "skip"
+	RuleActionLabel   = "label"
+)
+
+// Rule is an action taken on the PRs that meet all of its conditions
+type Rule struct {
+	// Name identifies the rule in the decision audit trail; unset uses its position
+	Name string         `yaml:"name,omitempty" json:"name,omitempty"`
+	When RuleConditions `yaml:"when,omitempty" json:"when,omitempty"`
+	// Action is approve, hold, skip or label
+	Action string `yaml:"action" json:"action"`
+	// Labels are added to the PR by the label action
+	Labels []string `yaml:"labels,omitempty" json:"labels,omitempty"`
+}
+
+// RuleConditions are the conditions a PR must all meet for a rule to apply; unset conditions always pass
+type RuleConditions struct {
+	// Authors are the authors one of which the PR must be by
+	Authors []string `yaml:"authors,omitempty" json:"authors,omitempty"`
+	// TektonOnly requires the PR to only change Tekton pipeline files (true) or to change other files (false)
+	TektonOnly *bool `yaml:"tekton_only,omitempty" json:"tekton_only,omitempty"`
+	// NoMigrationWarning requires the PR to have no migration warning
+	NoMigrationWarning bool `yaml:"no_migration_warning,omitempty" json:"no_migration_warning,omitempty"`
+	// ChecksGreen requires the PR to have checks, all of which passed
+	ChecksGreen bool `yaml:"checks_green,omitempty" json:"checks_green,omitempty"`
+	// MaxFilesChanged is the most files the PR may change; 0 doesn't limit
+	MaxFilesChanged int `yaml:"max_files_changed,omitempty" json:"max_files_changed,omitempty"`
+	// AllowLabels are the labels one of which the PR must have
+	AllowLabels []string `yaml:"allow_labels,omitempty" json:"allow_labels,omitempty"`
+	// DenyLabels are the labels the PR must not have
+	DenyLabels []string `yaml:"deny_labels,omitempty" json:"deny_labels,omitempty"`
+}
+
+// ValidateRules checks that every rule has a valid action, with labels for the label action
+func ValidateRules(rules []Rule) error {
+	for i, rule := range rules {
+		switch rule.Action {
+		case RuleActionApprove, RuleActionHold, RuleActionSkip:
+		case RuleActionLabel:
+			if len(rule.Labels) == 0 {
+				return fmt.Errorf("%s: the label action needs labels", RuleName(rule, i))
+			}
+		default:
+			return fmt.Errorf("%s: invalid action %q (must be one of: %s, %s, %s, %s)", RuleName(rule, i), rule.Action,
+				RuleActionApprove, RuleActionHold, RuleActionSkip, RuleActionLabel)
+		}
+		if rule.When.MaxFilesChanged < 0 {
+			return fmt.Errorf("%s: max_files_changed can't be negative", RuleName(rule, i))
+		}
+	}
+	return nil
+}
+
+// RuleName names the rule at index in messages, by its name or else its position
+func RuleName(rule Rule, index int) string {
+	if rule.Name != "" {
+		return fmt.Sprintf("rule %q", rule.Name)
+	}
+	return fmt.Sprintf("rule #%d", index+1)
+}
+
+// Decision is what the rules decided for a PR and why
+type Decision struct {
+	// Rule is the index of the matching rule, -1 when none matched
+	Rule   int
+	Action string
+	// Trail describes, for each rule evaluated, the conditions checked, e.g.
+	// `rule "tekton": ✓ author red-hat-konflux[bot], ✗ checks 1 failed`
+	Trail []string
+}
+
+// Reason names the rule that decided, or says that none matched
+func (d Decision) Reason(rules []Rule) string {
+	if d.Rule < 0 || d.Rule >= len(rules) {
+		return "no rule matched"
+	}
+	return RuleName(rules[d.Rule], d.Rule)
+}
+
+// ruleCheck is the outcome of one condition of a rule for a PR, as shown in the audit trail
+type ruleCheck struct {
+	Passed bool
+	Detail string
+}
+
+// prFacts lazily fetches and remembers what the conditions of the rules need to know about a PR
+type prFacts struct {
+	ctx    context.Context
+	client RESTClient
+	owner  string
+	repo   string
+	pr     PullRequest
+	opts   DecideOptions
+
+	files      []PRFile
+	filesErr   error
+	filesDone  bool
+	checks     CheckSummary
+	checksErr  error
+	checksDone bool
+}
+
+// changedFiles returns the files the PR changes
+func (f *prFacts) changedFiles() ([]PRFile, error) {
+	if !f.filesDone {
+		f.filesDone = true
+		f.files, f.filesErr = FetchFiles(f.ctx, f.client, f.owner, f.repo, f.pr.Number)
+	}
+	return f.files, f.filesErr
+}
+
+// headChecks returns the checks of the PR's head commit
+func (f *prFacts) headChecks() (CheckSummary, error) {
+	if !f.checksDone {
+		f.checksDone = true
+		checkRuns, statusChecks, err := FetchChecks(f.ctx, f.client, f.owner, f.repo, f.pr.Head.SHA)
+		f.checks, f.checksErr = SummarizeChecks(checkRuns, statusChecks), err
+	}
+	return f.checks, f.checksErr
+}
+
+// FetchFiles fetches every file a PR changes
+func FetchFiles(ctx context.Context, client RESTClient, owner, repo string, prNumber int) ([]PRFile, error) {
+	var files []PRFile
+	for page := 1; ; page++ {
+		var pageFiles []PRFile
+		path := fmt.Sprintf("repos/%s/%s/pulls/%d/files?per_page=%d&page=%d", owner, repo, prNumber, maxPerPage, page)
+		if err := client.DoWithContext(ctx, http.MethodGet, path, nil, &pageFiles); err != nil {
+			return nil, err
+		}
+		files = append(files, pageFiles...)
+		if len(pageFiles) < maxPerPage {
+			return files, nil
+		}
+	}
+}
+
+// evaluateRule checks the conditions of a rule in order, stopping at the first that fails
+func evaluateRule(facts *prFacts, conditions RuleConditions) (bool, []ruleCheck) {
+	var checks []ruleCheck
+	check := func(passed bool, detail string) bool {
+		checks = append(checks, ruleCheck{Passed: passed, Detail: detail})
+		return passed
+	}
+	pr := facts.pr
+	labelNames := make([]string, len(pr.Labels))
+	for i, label := range pr.Labels {
+		labelNames[i] = label.Name
+	}
+
+	if len(conditions.Authors) > 0 {
+		byAuthor := slices.ContainsFunc(conditions.Authors, func(author string) bool { return strings.EqualFold(author, pr.User.Login) })
+		if !check(byAuthor, "author "+pr.User.Login) {
+			return false, checks
+		}
+	}
+	if len(conditions.DenyLabels) > 0 {
+		denied := slices.DeleteFunc(slices.Clone(labelNames), func(name string) bool { return !slices.Contains(conditions.DenyLabels, name) })
+		detail := "no denied label"
+		if len(denied) > 0 {
+			detail = "denied label " + strings.Join(denied, ", ")
+		}
+		if !check(len(denied) == 0, detail) {
+			return false, checks
+		}
+	}
+	if len(conditions.AllowLabels) > 0 {
+		allowed := slices.ContainsFunc(labelNames, func(name string) bool { return slices.Contains(conditions.AllowLabels, name) })
+		detail := "allowed label"
+		if !allowed {
+			detail = "none of the labels " + strings.Join(conditions.AllowLabels, ", ")
+		}
+		if !check(allowed, detail) {
+			return false, checks
+		}
+	}
+	if conditions.NoMigrationWarning {
+		migration := HasMigrationWarning(pr)
+		detail := "no migration warning"
+		if migration {
+			detail = "migration warning"
+		}
+		if !check(!migration, detail) {
+			return false, checks
+		}
+	}
+	if conditions.TektonOnly != nil || conditions.MaxFilesChanged > 0 {
+		files, err := facts.changedFiles()
+		if err != nil {
+			check(false, fmt.Sprintf("files unknown: %v", err))
+			return false, checks
+		}
+		if conditions.TektonOnly != nil {
+			onlyTekton := OnlyTektonFiles(files, facts.opts.TektonPatterns)
+			detail := "only Tekton files"
+			if !onlyTekton {
+				detail = "not only Tekton files"
+			}
+			if !check(onlyTekton == *conditions.TektonOnly, detail) {
+				return false, checks
+			}
+		}
+		if conditions.MaxFilesChanged > 0 {
+			if !check(len(files) <= conditions.MaxFilesChanged, fmt.Sprintf("%d file(s) changed (max %d)", len(files), conditions.MaxFilesChanged)) {
+				return false, checks
+			}
+		}
+	}
+	if conditions.ChecksGreen {
+		summary, err := facts.headChecks()
+		if err != nil {
+			check(false, fmt.Sprintf("checks unknown: %v", err))
+			return false, checks
+		}
+		if !check(summary.Green(), "checks "+summary.String()) {
+			return false, checks
+		}
+	}
+	return true, checks
+}
+
+// DecideOptions are what Decide needs to know about the repository beyond the rules
+type DecideOptions struct {
+	// TektonPatterns are the Tekton pipelines tekton_only looks for, as MatchesTektonPatterns; unset uses the
+	// ones Konflux updates
+	TektonPatterns []string
+}
+
+// Decide evaluates the rules in order for a PR, the first rule whose conditions all pass deciding the action.
+// Without a matching rule the PR is skipped. The PR's files and checks are fetched only when a condition
+// needs them, at most once.
+func Decide(ctx context.Context, client RESTClient, owner, repo string, pr PullRequest, rules []Rule, opts DecideOptions) Decision {
+	facts := &prFacts{ctx: ctx, client: client, owner: owner, repo: repo, pr: pr, opts: opts}
+	decision := Decision{Rule: -1, Action: RuleActionSkip}
+	for i, rule := range rules {
+		matched, checks := evaluateRule(facts, rule.When)
+		var details []string
+		for _, check := range checks {
+			mark := "✓"
+			if !check.Passed {
+				mark = "✗"
+			}
+			details = append(details, mark+" "+check.Detail)
+		}
+		if len(details) == 0 {
+			details = append(details, "✓ no conditions")
+		}
+		decision.Trail = append(decision.Trail, fmt.Sprintf("%s: %s", RuleName(rule, i), strings.Join(details, ", ")))
+		if matched {
+			decision.Rule, decision.Action = i, rule.Action
+			return decision
+		}
+	}
+	return decision
+}
@@ -0,0 +1,81 @@
+package ghprs_test
+
+import (
+	"context"
+	"net/http"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+var _ = Describe("Decide", func() {
+	var (
+		client *fakeClient
+		pr     ghprs.PullRequest
+		rules  []ghprs.Rule
+	)
+
+	BeforeEach(func() {
+		client = newFakeClient(map[string]string{
+			"GET repos/o/r/pulls/7/files":          `[{"filename": ".tekton/app-push.yaml"}]`,
+			"GET repos/o/r/commits/abc/check-runs": `{"total_count": 1, "check_runs": [{"name": "build", "status": "completed", "conclusion": "success"}]}`,
+			"GET repos/o/r/commits/abc/status":     `{"statuses": []}`,
+		})
+		pr = ghprs.PullRequest{Number: 7, State: "open", User: ghprs.User{Login: "red-hat-konflux[bot]"}, Head: ghprs.Branch{SHA: "abc"}}
+		rules = []ghprs.Rule{
+			{Name: "held", When: ghprs.RuleConditions{AllowLabels: []string{ghprs.HoldLabel}}, Action: ghprs.RuleActionSkip},
+			{Name: "tekton", When: ghprs.RuleConditions{Authors: []string{"red-hat-konflux[bot]"}, TektonOnly: ptr(true), ChecksGreen: true}, Action: ghprs.RuleActionApprove},
+		}
+	})
+
+	It("should take the action of the first matching rule and explain every rule evaluated", func() {
+		decision := ghprs.Decide(context.Background(), client, "o", "r", pr, rules, ghprs.DecideOptions{})
+		Expect(decision.Rule).To(Equal(1))
+		Expect(decision.Action).To(Equal(ghprs.RuleActionApprove))
+		Expect(decision.Reason(rules)).To(Equal(`rule "tekton"`))
+		Expect(decision.Trail).To(Equal([]string{
+			`rule "held": ✗ none of the labels do-not-merge/hold`,
+			`rule "tekton": ✓ author red-hat-konflux[bot], ✓ only Tekton files, ✓ checks 1 passed`,
+		}))
+	})
+
+	It("should skip a PR no rule matches without fetching what the failed conditions don't need", func() {
+		pr.User.Login = "someone"
+		decision := ghprs.Decide(context.Background(), client, "o", "r", pr, rules, ghprs.DecideOptions{})
+		Expect(decision.Rule).To(Equal(-1))
+		Expect(decision.Action).To(Equal(ghprs.RuleActionSkip))
+		Expect(decision.Reason(rules)).To(Equal("no rule matched"))
+		Expect(client.posted(http.MethodGet)).To(BeEmpty())
+	})
+
+	It("should look for the Tekton pipelines of the repository", func() {
+		decision := ghprs.Decide(context.Background(), client, "o", "r", pr, rules, ghprs.DecideOptions{TektonPatterns: []string{".tekton/*-build.yaml"}})
+		Expect(decision.Rule).To(Equal(-1))
+		Expect(decision.Trail[1]).To(ContainSubstring("✗ not only Tekton files"))
+	})
+
+	It("should not match when the files can't be fetched", func() {
+		delete(client.responses, "GET repos/o/r/pulls/7/files")
+		decision := ghprs.Decide(context.Background(), client, "o", "r", pr, rules, ghprs.DecideOptions{})
+		Expect(decision.Rule).To(Equal(-1))
+		Expect(decision.Trail[1]).To(ContainSubstring("✗ files unknown"))
+	})
+})
+
+var _ = Describe("ValidateRules", func() {
+	It("should accept the known actions", func() {
+		Expect(ghprs.ValidateRules([]ghprs.Rule{{Action: ghprs.RuleActionApprove}, {Action: ghprs.RuleActionLabel, Labels: []string{"ok"}}})).To(Succeed())
+	})
+
+	It("should name the rule with an invalid action", func() {
+		err := ghprs.ValidateRules([]ghprs.Rule{{Action: ghprs.RuleActionHold}, {Action: "merge"}})
+		Expect(err).To(MatchError(ContainSubstring(`rule #2: invalid action "merge"`)))
+	})
+
+	It("should require labels for the label action", func() {
+		err := ghprs.ValidateRules([]ghprs.Rule{{Name: "tag", Action: ghprs.RuleActionLabel}})
+		Expect(err).To(MatchError(`rule "tag": the label action needs labels`))
+	})
+})
@@ -0,0 +1,122 @@
+package ghprs
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// HoldLabel is the label Prow adds to PRs on hold
+const HoldLabel = "do-not-merge/hold"
+
+// migrationPatterns mark a migration warning in the body of a Konflux PR
+var migrationPatterns = []string{
+	"⚠️[migration]",
+	":warning:[migration]",
+	"⚠️migration⚠️",
+	"[migration]",
+}
+
+// IsOnHold reports whether a PR has the hold label
+func IsOnHold(pr PullRequest) bool {
+	for _, label := range pr.Labels {
+		if label.Name == HoldLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// NeedsRebase reports whether a PR is behind its target branch or has conflicts. It needs the mergeable
+// state, which only the API of a single PR returns.
+func NeedsRebase(pr PullRequest) bool {
+	switch pr.MergeableState {
+	case "dirty", "behind":
+		return true
+	default:
+		return false
+	}
+}
+
+// IsBlocked reports whether branch protection blocks a PR from merging, e.g. for failed checks or missing
+// reviews. Like NeedsRebase it needs the mergeable state.
+func IsBlocked(pr PullRequest) bool {
+	return pr.MergeableState == "blocked"
+}
+
+// IsFrozen reports whether a PR is a draft or carries a do-not-merge label other than the hold label
+func IsFrozen(pr PullRequest) bool {
+	if pr.Draft {
+		return true
+	}
+	for _, label := range pr.Labels {
+		if strings.HasPrefix(label.Name, "do-not-merge/") && label.Name != HoldLabel {
+			return true
+		}
+	}
+	return false
+}
+
+// HasMigrationWarning reports whether the body of a PR has a migration warning, as Konflux adds to updates
+// that need manual steps
+func HasMigrationWarning(pr PullRequest) bool {
+	return hasMigrationMarker(pr.Body)
+}
+
+// IsSecurityUpdate reports whether a PR is a security update, by SECURITY or CVE in its title
+func IsSecurityUpdate(pr PullRequest) bool {
+	titleUpper := strings.ToUpper(pr.Title)
+	return strings.Contains(titleUpper, "SECURITY") || strings.Contains(titleUpper, "CVE")
+}
+
+// HasApprovedLabel reports whether labels include Prow's approved or lgtm label
+func HasApprovedLabel(labels []Label) bool {
+	for _, label := range labels {
+		if label.Name == "approved" || label.Name == "lgtm" {
+			return true
+		}
+	}
+	return false
+}
+
+// IsTektonFile reports whether a file is one of the Tekton pipelines Konflux updates:
+// .tekton/*-pull-request.yaml or .tekton/*-push.yaml
+func IsTektonFile(filename string) bool {
+	return strings.HasPrefix(filename, ".tekton/") &&
+		(strings.HasSuffix(filename, "-pull-request.yaml") || strings.HasSuffix(filename, "-push.yaml"))
+}
+
+// MatchesTektonPatterns reports whether a file is one of the Tekton pipelines of a repository, given as
+// path.Match patterns such as ".tekton/*-build.yaml"; without patterns it is IsTektonFile
+func MatchesTektonPatterns(filename string, patterns []string) bool {
+	if len(patterns) == 0 {
+		return IsTektonFile(filename)
+	}
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, filename); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateTektonPatterns checks that each Tekton file pattern is a valid path.Match pattern
+func ValidateTektonPatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid Tekton file pattern %q: %w", pattern, err)
+		}
+	}
+	return nil
+}
+
+// OnlyTektonFiles reports whether files are all Tekton pipelines matching patterns, as MatchesTektonPatterns,
+// and there is at least one
+func OnlyTektonFiles(files []PRFile, patterns []string) bool {
+	for _, file := range files {
+		if !MatchesTektonPatterns(file.Filename, patterns) {
+			return false
+		}
+	}
+	return len(files) > 0
+}
@@ -0,0 +1,81 @@
+package ghprs_test
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	"ghprs/pkg/ghprs"
+)
+
+func labeled(names ...string) ghprs.PullRequest {
+	pr := ghprs.PullRequest{State: "open"}
+	for _, name := range names {
+		pr.Labels = append(pr.Labels, ghprs.Label{Name: name})
+	}
+	return pr
+}
+
+var _ = Describe("Signals", func() {
+	It("should tell a hold from other do-not-merge labels", func() {
+		Expect(ghprs.IsOnHold(labeled(ghprs.HoldLabel))).To(BeTrue())
+		Expect(ghprs.IsFrozen(labeled(ghprs.HoldLabel))).To(BeFalse())
+		Expect(ghprs.IsFrozen(labeled("do-not-merge/work-in-progress"))).To(BeTrue())
+		Expect(ghprs.IsFrozen(ghprs.PullRequest{Draft: true})).To(BeTrue())
+	})
+
+	It("should read the mergeable state", func() {
+		Expect(ghprs.NeedsRebase(ghprs.PullRequest{MergeableState: "dirty"})).To(BeTrue())
+		Expect(ghprs.IsBlocked(ghprs.PullRequest{MergeableState: "blocked"})).To(BeTrue())
+		Expect(ghprs.NeedsRebase(ghprs.PullRequest{MergeableState: "clean"})).To(BeFalse())
+	})
+
+	It("should recognize Prow's approval labels", func() {
+		Expect(ghprs.HasApprovedLabel(labeled("lgtm").Labels)).To(BeTrue())
+		Expect(ghprs.HasApprovedLabel(labeled("approved").Labels)).To(BeTrue())
+		Expect(ghprs.HasApprovedLabel(labeled("bug").Labels)).To(BeFalse())
+	})
+
+	It("should only call a PR Tekton only when every file is a pipeline", func() {
+		Expect(ghprs.OnlyTektonFiles([]ghprs.PRFile{{Filename: ".tekton/app-push.yaml"}}, nil)).To(BeTrue())
+		Expect(ghprs.OnlyTektonFiles([]ghprs.PRFile{{Filename: ".tekton/app-push.yaml"}, {Filename: "main.go"}}, nil)).To(BeFalse())
+		Expect(ghprs.OnlyTektonFiles(nil, nil)).To(BeFalse())
+	})
+
+	It("should look for the Tekton pipelines of a repository by its patterns", func() {
+		patterns := []string{".tekton/*-build.yaml", "pipelines/*.yaml"}
+		Expect(ghprs.OnlyTektonFiles([]ghprs.PRFile{{Filename: ".tekton/app-build.yaml"}, {Filename: "pipelines/release.yaml"}}, patterns)).To(BeTrue())
+		Expect(ghprs.OnlyTektonFiles([]ghprs.PRFile{{Filename: ".tekton/app-push.yaml"}}, patterns)).To(BeFalse())
+		Expect(ghprs.ValidateTektonPatterns(patterns)).To(Succeed())
+		Expect(ghprs.ValidateTektonPatterns([]string{".tekton/[push"})).To(MatchError(ContainSubstring(`invalid Tekton file pattern ".tekton/[push"`)))
+	})
+
+	DescribeTable("Readiness",
+		func(signals ghprs.ReadinessSignals, expected string) {
+			Expect(ghprs.Readiness(signals)).To(Equal(expected))
+		},
+		Entry("not open", ghprs.ReadinessSignals{}, ""),
+		Entry("on hold wins over failing checks", ghprs.ReadinessSignals{Open: true, OnHold: true, ChecksFailing: true}, ghprs.ReadinessOnHold),
+		Entry("frozen", ghprs.ReadinessSignals{Open: true, Frozen: true}, ghprs.ReadinessFrozen),
+		Entry("needs rebase", ghprs.ReadinessSignals{Open: true, NeedsRebase: ptr(true)}, ghprs.ReadinessNeedsRebase),
+		Entry("checks failing", ghprs.ReadinessSignals{Open: true, ChecksFailing: true, Reviewed: ptr(true)}, ghprs.ReadinessChecksFailing),
+		Entry("unknown reviews need review", ghprs.ReadinessSignals{Open: true}, ghprs.ReadinessNeedsReview),
+		Entry("blocked", ghprs.ReadinessSignals{Open: true, Reviewed: ptr(true), Blocked: ptr(true)}, ghprs.ReadinessBlocked),
+		Entry("ready", ghprs.ReadinessSignals{Open: true, Reviewed: ptr(true), Blocked: ptr(false)}, ghprs.ReadinessReady),
+	)
+
+	DescribeTable("CheckSummary",
+		func(summary ghprs.CheckSummary, description string, green bool) {
+			Expect(summary.String()).To(Equal(description))
+			Expect(summary.Green()).To(Equal(green))
+		},
+		Entry("no checks", ghprs.CheckSummary{}, "no checks", false),
+		Entry("all passed", ghprs.CheckSummary{Passed: 3}, "3 passed", true),
+		Entry("pending", ghprs.CheckSummary{Passed: 3, Pending: 1}, "1 pending", false),
+		Entry("failed", ghprs.CheckSummary{Passed: 3, Pending: 1, Failed: 2}, "2 failed", false),
+		Entry("cancelled", ghprs.CheckSummary{Passed: 3, Cancelled: 1}, "1 cancelled", false),
+	)
+})
+
+func ptr(b bool) *bool {
+	return &b
+}
@@ -0,0 +1,121 @@
+package ghprs
+
+import "time"
+
+// PullRequest represents a GitHub pull request
+type PullRequest struct {
+	Number         int     `json:"number"`
+	Title          string  `json:"title"`
+	State          string  `json:"state"`
+	User           User    `json:"user"`
+	Head           Branch  `json:"head"`
+	Base           Branch  `json:"base"`
+	Draft          bool    `json:"draft"`
+	CreatedAt      string  `json:"created_at"`
+	UpdatedAt      string  `json:"updated_at"`
+	HTMLURL        string  `json:"html_url"`
+	Body           string  `json:"body"`
+	MergeableState string  `json:"mergeable_state"`
+	Labels         []Label `json:"labels"`
+	Merged         bool    `json:"merged"`
+	// MergeCommitSHA is the commit a merged PR was merged as
+	MergeCommitSHA string `json:"merge_commit_sha,omitempty"`
+	// MergedAt is when the PR was merged, which the PR list fills in unlike Merged
+	MergedAt string `json:"merged_at,omitempty"`
+	// Additions, Deletions and ChangedFiles count the changes of the PR, only filled in by its details
+	Additions    int `json:"additions,omitempty"`
+	Deletions    int `json:"deletions,omitempty"`
+	ChangedFiles int `json:"changed_files,omitempty"`
+	// RequestedReviewers are the users whose review is requested and who haven't reviewed since
+	RequestedReviewers []User `json:"requested_reviewers,omitempty"`
+	Assignees          []User `json:"assignees,omitempty"`
+}
+
+// User is a GitHub user or bot
+type User struct {
+	Login string `json:"login"`
+}
+
+// Branch is the head or base of a pull request
+type Branch struct {
+	Ref string `json:"ref"`
+	SHA string `json:"sha"`
+}
+
+// Label is a label of a pull request
+type Label struct {
+	Name string `json:"name"`
+}
+
+// ReviewRequest represents a pull request review request
+type ReviewRequest struct {
+	Body  string `json:"body"`
+	Event string `json:"event"`
+	// CommitID binds the review to the commit that was reviewed
+	CommitID string `json:"commit_id,omitempty"`
+}
+
+// CommentRequest represents a pull request comment request
+type CommentRequest struct {
+	Body string `json:"body"`
+}
+
+// Review represents a pull request review
+type Review struct {
+	ID          int64  `json:"id,omitempty"`
+	State       string `json:"state"`
+	User        User   `json:"user"`
+	SubmittedAt string `json:"submitted_at,omitempty"`
+	Body        string `json:"body,omitempty"`
+}
+
+// PRFile represents a file changed in a pull request
+type PRFile struct {
+	Filename string `json:"filename"`
+	Status   string `json:"status"` // "added", "modified", "removed", etc.
+	// Patch is the diff of the file, used to check image pinning (not included in GraphQL results)
+	Patch string `json:"patch,omitempty"`
+	// PreviousFilename is the name a renamed file had before the PR
+	PreviousFilename string `json:"previous_filename,omitempty"`
+}
+
+// CheckRun represents a GitHub check run
+type CheckRun struct {
+	ID         int64  `json:"id"`
+	Name       string `json:"name"`
+	Status     string `json:"status"`     // "queued", "in_progress", "completed"
+	Conclusion string `json:"conclusion"` // "success", "failure", "neutral", "cancelled", "timed_out", "action_required", "skipped"
+	HTMLURL    string `json:"html_url"`
+	// StartedAt and CheckSuite are used to find and re-trigger check runs that stay pending
+	StartedAt  *time.Time     `json:"started_at,omitempty"`
+	CheckSuite *CheckSuiteRef `json:"check_suite,omitempty"`
+	// CompletedAt and App tell when a check run finished and which GitHub app reported it
+	CompletedAt *time.Time `json:"completed_at,omitempty"`
+	App         *CheckApp  `json:"app,omitempty"`
+}
+
+// CheckRunsResponse represents the response from the check runs API
+type CheckRunsResponse struct {
+	TotalCount int        `json:"total_count"`
+	CheckRuns  []CheckRun `json:"check_runs"`
+}
+
+// StatusCheck represents a GitHub status check (legacy)
+type StatusCheck struct {
+	State       string `json:"state"` // "pending", "success", "error", "failure"
+	Description string `json:"description"`
+	Context     string `json:"context"`
+	TargetURL   string `json:"target_url"`
+	// CreatedAt is when the check was set to its current state
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+}
+
+// CheckSuiteRef identifies the check suite a check run belongs to
+type CheckSuiteRef struct {
+	ID int64 `json:"id"`
+}
+
+// CheckApp identifies the GitHub app that reported a check run
+type CheckApp struct {
+	Slug string `json:"slug"`
+}